@@ -6,7 +6,9 @@ package api
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -20,6 +22,378 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for APIKeyScope.
+const (
+	GamesWrite   APIKeyScope = "games:write"
+	RunsModerate APIKeyScope = "runs:moderate"
+	RunsWrite    APIKeyScope = "runs:write"
+)
+
+// Defines values for AuditEntityType.
+const (
+	AuditEntityTypeApiKey     AuditEntityType = "api_key"
+	AuditEntityTypeCategory   AuditEntityType = "category"
+	AuditEntityTypeGame       AuditEntityType = "game"
+	AuditEntityTypeLevel      AuditEntityType = "level"
+	AuditEntityTypePlatform   AuditEntityType = "platform"
+	AuditEntityTypeRace       AuditEntityType = "race"
+	AuditEntityTypeRegion     AuditEntityType = "region"
+	AuditEntityTypeRun        AuditEntityType = "run"
+	AuditEntityTypeRunComment AuditEntityType = "run_comment"
+	AuditEntityTypeUser       AuditEntityType = "user"
+	AuditEntityTypeVariable   AuditEntityType = "variable"
+	AuditEntityTypeWebhook    AuditEntityType = "webhook"
+)
+
+// Defines values for ErrorDetailIn.
+const (
+	Body   ErrorDetailIn = "body"
+	Cookie ErrorDetailIn = "cookie"
+	Header ErrorDetailIn = "header"
+	Path   ErrorDetailIn = "path"
+	Query  ErrorDetailIn = "query"
+)
+
+// Defines values for FeedItemEvent.
+const (
+	FeedItemEventCategoryCreated FeedItemEvent = "category.created"
+	FeedItemEventRecordBroken    FeedItemEvent = "record.broken"
+	FeedItemEventRunVerified     FeedItemEvent = "run.verified"
+)
+
+// Defines values for GameField.
+const (
+	GameFieldCreatedAt GameField = "created_at"
+	GameFieldId        GameField = "id"
+	GameFieldName      GameField = "name"
+	GameFieldSlug      GameField = "slug"
+	GameFieldUpdatedAt GameField = "updated_at"
+)
+
+// Defines values for HealthState.
+const (
+	Draining HealthState = "draining"
+	Failing  HealthState = "failing"
+	Ok       HealthState = "ok"
+)
+
+// Defines values for LogLevel.
+const (
+	LogLevelDebug LogLevel = "debug"
+	LogLevelError LogLevel = "error"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+)
+
+// Defines values for ModeratorLevel.
+const (
+	SuperModerator ModeratorLevel = "super_moderator"
+	Verifier       ModeratorLevel = "verifier"
+)
+
+// Defines values for NotificationEvent.
+const (
+	NotificationEventCommentCreated NotificationEvent = "comment.created"
+	NotificationEventRecordBroken   NotificationEvent = "record.broken"
+	NotificationEventRunRejected    NotificationEvent = "run.rejected"
+	NotificationEventRunVerified    NotificationEvent = "run.verified"
+)
+
+// Defines values for NotificationPreferenceEvent.
+const (
+	NotificationPreferenceEventCommentCreated NotificationPreferenceEvent = "comment.created"
+	NotificationPreferenceEventRecordBroken   NotificationPreferenceEvent = "record.broken"
+	NotificationPreferenceEventRunRejected    NotificationPreferenceEvent = "run.rejected"
+	NotificationPreferenceEventRunVerified    NotificationPreferenceEvent = "run.verified"
+)
+
+// Defines values for OAuthProvider.
+const (
+	Discord OAuthProvider = "discord"
+	Twitch  OAuthProvider = "twitch"
+)
+
+// Defines values for OperationKind.
+const (
+	UsersExport OperationKind = "users.export"
+	UsersImport OperationKind = "users.import"
+)
+
+// Defines values for OperationStatus.
+const (
+	OperationStatusFailed    OperationStatus = "failed"
+	OperationStatusPending   OperationStatus = "pending"
+	OperationStatusRunning   OperationStatus = "running"
+	OperationStatusSucceeded OperationStatus = "succeeded"
+)
+
+// Defines values for RaceStatus.
+const (
+	RaceStatusCancelled RaceStatus = "cancelled"
+	RaceStatusFinished  RaceStatus = "finished"
+	RaceStatusOpen      RaceStatus = "open"
+	RaceStatusRunning   RaceStatus = "running"
+)
+
+// Defines values for RunStatus.
+const (
+	RunStatusPending  RunStatus = "pending"
+	RunStatusRejected RunStatus = "rejected"
+	RunStatusVerified RunStatus = "verified"
+)
+
+// Defines values for RunInclude.
+const (
+	RunIncludeCategory RunInclude = "category"
+	RunIncludeGame     RunInclude = "game"
+	RunIncludeRunner   RunInclude = "runner"
+)
+
+// Defines values for SearchResultType.
+const (
+	SearchResultTypeGame SearchResultType = "game"
+	SearchResultTypeRun  SearchResultType = "run"
+	SearchResultTypeUser SearchResultType = "user"
+)
+
+// Defines values for TimingMethod.
+const (
+	Igt TimingMethod = "igt"
+	Lrt TimingMethod = "lrt"
+	Rta TimingMethod = "rta"
+)
+
+// Defines values for UserField.
+const (
+	UserFieldAvatarUrl       UserField = "avatar_url"
+	UserFieldBio             UserField = "bio"
+	UserFieldCountryCode     UserField = "country_code"
+	UserFieldCreatedAt       UserField = "created_at"
+	UserFieldDeletedAt       UserField = "deleted_at"
+	UserFieldEmail           UserField = "email"
+	UserFieldEmailVerifiedAt UserField = "email_verified_at"
+	UserFieldId              UserField = "id"
+	UserFieldName            UserField = "name"
+	UserFieldPronouns        UserField = "pronouns"
+	UserFieldPublicId        UserField = "public_id"
+	UserFieldTwitchHandle    UserField = "twitch_handle"
+	UserFieldTwitterHandle   UserField = "twitter_handle"
+	UserFieldUpdatedAt       UserField = "updated_at"
+	UserFieldYoutubeHandle   UserField = "youtube_handle"
+)
+
+// Defines values for UserImportRowStatus.
+const (
+	Failed   UserImportRowStatus = "failed"
+	Imported UserImportRowStatus = "imported"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for WebhookEvent.
+const (
+	WebhookEventCategoryCreated WebhookEvent = "category.created"
+	WebhookEventCommentCreated  WebhookEvent = "comment.created"
+	WebhookEventRecordBroken    WebhookEvent = "record.broken"
+	WebhookEventRunRejected     WebhookEvent = "run.rejected"
+	WebhookEventRunSubmitted    WebhookEvent = "run.submitted"
+	WebhookEventRunVerified     WebhookEvent = "run.verified"
+	WebhookEventUserCreated     WebhookEvent = "user.created"
+)
+
+// Defines values for StreamEventsParamsEvent.
+const (
+	RecordBroken StreamEventsParamsEvent = "record.broken"
+	RunRejected  StreamEventsParamsEvent = "run.rejected"
+	RunVerified  StreamEventsParamsEvent = "run.verified"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt When the key stops working; absent for keys that never expire
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Id        int        `json:"id"`
+
+	// Key The key itself; only present in the response that created it
+	Key *string `json:"key,omitempty"`
+
+	// LastUsedAt Roughly when the key was last used, to the minute
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+
+	// Prefix Leading characters of the key, to tell keys apart
+	Prefix string        `json:"prefix"`
+	Scopes []APIKeyScope `json:"scopes"`
+}
+
+// APIKeyScope An operation group an API key may call
+type APIKeyScope string
+
+// AddGameModeratorRequest defines model for AddGameModeratorRequest.
+type AddGameModeratorRequest struct {
+	// Level Super moderators may edit the game and manage its verifiers; verifiers may only review its runs
+	Level ModeratorLevel `json:"level"`
+
+	// UserId ID of the user to add
+	UserId int `json:"user_id"`
+}
+
+// AuditChange How one field changed. before is absent for a created entity and after for a deleted one.
+type AuditChange struct {
+	// After The field's value after the change
+	After *interface{} `json:"after,omitempty"`
+
+	// Before The field's value before the change
+	Before *interface{} `json:"before,omitempty"`
+}
+
+// AuditEntityType A kind of entity whose changes are audited
+type AuditEntityType string
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	// Action What was done, as the entity type and a verb
+	Action string `json:"action"`
+
+	// ActorId The user who made the change; absent for changes made anonymously, such as registration
+	ActorId *int `json:"actor_id,omitempty"`
+
+	// Changes The fields that changed, by name. Secrets such as API key hashes and webhook secrets are never included.
+	Changes   json.RawMessage `json:"changes"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	// EntityId ID of the entity that changed
+	EntityId int `json:"entity_id"`
+
+	// EntityType A kind of entity whose changes are audited
+	EntityType AuditEntityType `json:"entity_type"`
+	Id         int             `json:"id"`
+
+	// RequestId ID of the request that made the change, matching request_id in the server's logs
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// BatchGetUsersResponse defines model for BatchGetUsersResponse.
+type BatchGetUsersResponse struct {
+	// MissingIds Requested IDs that did not match any user
+	MissingIds []int `json:"missing_ids"`
+
+	// Users Users that were found, in the order their IDs were requested
+	Users []User `json:"users"`
+}
+
+// Category defines model for Category.
+type Category struct {
+	// CreatedAt Timestamp when the category was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// GameId ID of the game the category belongs to
+	GameId int `json:"game_id"`
+
+	// Id Unique category identifier
+	Id int `json:"id"`
+
+	// IsDefault Whether this is the game's default category
+	IsDefault bool `json:"is_default"`
+
+	// Name Display name of the category
+	Name string `json:"name"`
+
+	// Position Display order within the game, lowest first
+	Position int `json:"position"`
+
+	// Rules Rules a run must follow to count in this category
+	Rules string `json:"rules"`
+
+	// Slug URL-safe identifier, unique within the game
+	Slug string `json:"slug"`
+
+	// TimingMethod How a category's runs are timed and ranked; real time (rta), in-game time (igt), or real time with loading screens removed (lrt). Defaults to rta when creating a category.
+	TimingMethod TimingMethod `json:"timing_method"`
+}
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	// ExpiresAt When the key stops working; omit for a key that never expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Name A label for the key, e.g. the bot it is for
+	Name   string        `json:"name"`
+	Scopes []APIKeyScope `json:"scopes"`
+}
+
+// CreateCategoryRequest defines model for CreateCategoryRequest.
+type CreateCategoryRequest struct {
+	// IsDefault Make this the game's default category
+	IsDefault *bool `json:"is_default,omitempty"`
+
+	// Name Display name of the category
+	Name string `json:"name"`
+
+	// Position Display order within the game; defaults to after the existing categories
+	Position *int `json:"position,omitempty"`
+
+	// Rules Rules a run must follow to count in this category
+	Rules *string `json:"rules,omitempty"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens
+	Slug string `json:"slug"`
+
+	// TimingMethod How a category's runs are timed and ranked; real time (rta), in-game time (igt), or real time with loading screens removed (lrt). Defaults to rta when creating a category.
+	TimingMethod *TimingMethod `json:"timing_method,omitempty"`
+}
+
+// CreateGameRequest defines model for CreateGameRequest.
+type CreateGameRequest struct {
+	// Name Display name of the game
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens; suggest is reserved
+	Slug string `json:"slug"`
+}
+
+// CreateLevelRequest defines model for CreateLevelRequest.
+type CreateLevelRequest struct {
+	// Name Display name of the level
+	Name string `json:"name"`
+
+	// Position Display order within the game; defaults to after the existing levels
+	Position *int `json:"position,omitempty"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens
+	Slug string `json:"slug"`
+}
+
+// CreatePlatformRequest defines model for CreatePlatformRequest.
+type CreatePlatformRequest struct {
+	// Name Display name of the platform
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens
+	Slug string `json:"slug"`
+}
+
+// CreateRegionRequest defines model for CreateRegionRequest.
+type CreateRegionRequest struct {
+	// Name Display name of the region
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens
+	Slug string `json:"slug"`
+}
+
 // CreateUserRequest defines model for CreateUserRequest.
 type CreateUserRequest struct {
 	// Email User's email address
@@ -29,31 +403,105 @@ type CreateUserRequest struct {
 	Name string `json:"name"`
 }
 
-// Error defines model for Error.
-type Error struct {
-	// Code Error code
-	Code *string `json:"code,omitempty"`
+// CreateVariableRequest defines model for CreateVariableRequest.
+type CreateVariableRequest struct {
+	// Category Slug of the only category the variable applies to; omit to apply it to every category of the game
+	Category *string `json:"category,omitempty"`
 
-	// Message Error message
-	Message string `json:"message"`
+	// Name Display name of the variable
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens
+	Slug string `json:"slug"`
+
+	// Values The values a run may be played with, in display order
+	Values []struct {
+		// Label Display label of the value
+		Label string `json:"label"`
+
+		// Slug URL-safe identifier, unique within the variable
+		Slug string `json:"slug"`
+	} `json:"values"`
 }
 
-// UpdateUserRequest defines model for UpdateUserRequest.
-type UpdateUserRequest struct {
-	// Email User's email address
-	Email *openapi_types.Email `json:"email,omitempty"`
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	Events []WebhookEvent `json:"events"`
 
-	// Name User's full name
+	// Url Absolute http or https URL deliveries are posted to
+	Url string `json:"url"`
+}
+
+// ErrorDetail defines model for ErrorDetail.
+type ErrorDetail struct {
+	// In Where the invalid value was sent
+	In ErrorDetailIn `json:"in"`
+
+	// Message What is wrong with the value
+	Message string `json:"message"`
+
+	// Name The parameter's name, or the dotted path of the body field; omitted for problems with the body as a whole
 	Name *string `json:"name,omitempty"`
 }
 
-// User defines model for User.
-type User struct {
-	// CreatedAt Timestamp when the user was created
-	CreatedAt time.Time `json:"created_at"`
+// ErrorDetailIn Where the invalid value was sent
+type ErrorDetailIn string
 
-	// Email User's email address
-	Email openapi_types.Email `json:"email"`
+// FeedItem An event in a user's activity feed
+type FeedItem struct {
+	// ActorId The runner whose run the event is about; absent for new categories
+	ActorId *int `json:"actor_id,omitempty"`
+
+	// ActorName Name of the runner; absent for new categories and deleted runners
+	ActorName *string `json:"actor_name,omitempty"`
+
+	// Data The event's data as webhooks receive it: the run for run.verified, the run and the previous record for record.broken, and the category for category.created
+	Data json.RawMessage `json:"data"`
+
+	// Event What happened: record.broken when a verified run beat its category's previous record, run.verified when a moderator verified a run, and category.created when a category was added to a game. A run that breaks a record appears as both run.verified and record.broken.
+	Event FeedItemEvent `json:"event"`
+
+	// GameId The game the event is about
+	GameId int `json:"game_id"`
+
+	// GameName Display name of the game
+	GameName string `json:"game_name"`
+
+	// GameSlug URL-safe identifier of the game
+	GameSlug string `json:"game_slug"`
+
+	// Id Unique feed item identifier
+	Id int `json:"id"`
+
+	// OccurredAt When the event happened
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// FeedItemEvent What happened: record.broken when a verified run beat its category's previous record, run.verified when a moderator verified a run, and category.created when a category was added to a game. A run that breaks a record appears as both run.verified and record.broken.
+type FeedItemEvent string
+
+// FollowedGame A game a user follows
+type FollowedGame struct {
+	// FollowedAt When the user started following the game
+	FollowedAt time.Time `json:"followed_at"`
+
+	// Id Unique game identifier
+	Id int `json:"id"`
+
+	// Name Display name of the game
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier of the game
+	Slug string `json:"slug"`
+}
+
+// FollowedUser A user in a follow list, such as a user's followers
+type FollowedUser struct {
+	// AvatarUrl URL of the user's avatar; absent when the user has not uploaded one
+	AvatarUrl *string `json:"avatar_url,omitempty"`
+
+	// FollowedAt When the follow started
+	FollowedAt time.Time `json:"followed_at"`
 
 	// Id Unique user identifier
 	Id int `json:"id"`
@@ -61,114 +509,5550 @@ type User struct {
 	// Name User's full name
 	Name string `json:"name"`
 
-	// UpdatedAt Timestamp when the user was last updated
+	// PublicId Opaque user identifier that is safe to share externally
+	PublicId openapi_types.UUID `json:"public_id"`
+}
+
+// Game defines model for Game.
+type Game struct {
+	// Links Links to the resource itself, as self, when it has a route of its own, and to the resources related to it, keyed by relation. Pages of a list also link to the next page, as next, and to the previous one, as prev, when there is one.
+	Links *Links `json:"_links,omitempty"`
+
+	// CreatedAt Timestamp when the game was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id Unique game identifier
+	Id int `json:"id"`
+
+	// Name Display name of the game
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens
+	Slug string `json:"slug"`
+
+	// UpdatedAt Timestamp when the game was last updated
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// ListUsersParams defines parameters for ListUsers.
-type ListUsersParams struct {
-	// Limit Maximum number of users to return
-	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+// GameField A field of a game, as named in the Game schema
+type GameField string
 
-	// Offset Number of users to skip
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+// GameModerator defines model for GameModerator.
+type GameModerator struct {
+	// CreatedAt When the user joined the game's moderation team
+	CreatedAt time.Time `json:"created_at"`
+
+	// Level Super moderators may edit the game and manage its verifiers; verifiers may only review its runs
+	Level ModeratorLevel `json:"level"`
+
+	// UserId ID of the moderator
+	UserId int `json:"user_id"`
+
+	// UserName Display name of the moderator
+	UserName string `json:"user_name"`
 }
 
-// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
-type CreateUserJSONRequestBody = CreateUserRequest
+// GameSuggestions defines model for GameSuggestions.
+type GameSuggestions struct {
+	// Games The suggested games, best match first
+	Games []Game `json:"games"`
+}
 
-// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
-type UpdateUserJSONRequestBody = UpdateUserRequest
+// GraphQLError defines model for GraphQLError.
+type GraphQLError struct {
+	Extensions *struct {
+		// Code The error code the REST API uses for the same error
+		Code *string `json:"code,omitempty"`
+	} `json:"extensions,omitempty"`
+	Message string `json:"message"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// List all users
-	// (GET /users)
-	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
-	// Create a new user
-	// (POST /users)
-	CreateUser(w http.ResponseWriter, r *http.Request)
-	// Delete user
-	// (DELETE /users/{id})
-	DeleteUser(w http.ResponseWriter, r *http.Request, id int)
-	// Get user by ID
-	// (GET /users/{id})
-	GetUser(w http.ResponseWriter, r *http.Request, id int)
-	// Update user
-	// (PUT /users/{id})
-	UpdateUser(w http.ResponseWriter, r *http.Request, id int)
+	// Path The path of the field that failed
+	Path *[]interface{} `json:"path,omitempty"`
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// GraphQLRequest defines model for GraphQLRequest.
+type GraphQLRequest struct {
+	// OperationName The operation to run when the query holds several
+	OperationName *string `json:"operationName,omitempty"`
 
-type Unimplemented struct{}
+	// Query The GraphQL query
+	Query string `json:"query"`
 
-// List all users
-// (GET /users)
-func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	// Variables The query's variables
+	Variables *map[string]interface{} `json:"variables,omitempty"`
 }
 
-// Create a new user
-// (POST /users)
-func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// GraphQLResponse defines model for GraphQLResponse.
+type GraphQLResponse struct {
+	// Data The query's result; fields that failed to resolve are null
+	Data   *map[string]interface{} `json:"data,omitempty"`
+	Errors *[]GraphQLError         `json:"errors,omitempty"`
 }
 
-// Delete user
-// (DELETE /users/{id})
-func (_ Unimplemented) DeleteUser(w http.ResponseWriter, r *http.Request, id int) {
-	w.WriteHeader(http.StatusNotImplemented)
+// HealthState ok when healthy, failing when a check returned an error or timed
+// out, draining once shutdown has begun
+type HealthState string
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus struct {
+	// Checks Outcome of each readiness check, keyed by check name
+	Checks *map[string]HealthState `json:"checks,omitempty"`
+
+	// Status ok when healthy, failing when a check returned an error or timed
+	// out, draining once shutdown has begun
+	Status HealthState `json:"status"`
 }
 
-// Get user by ID
-// (GET /users/{id})
-func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, id int) {
-	w.WriteHeader(http.StatusNotImplemented)
+// LeaderboardEntry defines model for LeaderboardEntry.
+type LeaderboardEntry struct {
+	// Platform Platform the run was played on
+	Platform string `json:"platform"`
+
+	// PlayedOn Day the run was played
+	PlayedOn openapi_types.Date `json:"played_on"`
+
+	// Rank Position on the leaderboard; tied times share a rank
+	Rank int `json:"rank"`
+
+	// RunId ID of the runner's best run
+	RunId int `json:"run_id"`
+
+	// TimeMs Run duration in milliseconds by the category's timing method
+	TimeMs int64 `json:"time_ms"`
+
+	// Times A run's duration by each timing method it was timed with. A submission needs at least the time by its category's timing method.
+	Times RunTimes `json:"times"`
+
+	// UserId ID of the runner
+	UserId int `json:"user_id"`
+
+	// UserName Name of the runner
+	UserName string `json:"user_name"`
+
+	// VideoUrl Link to a recording of the run
+	VideoUrl string `json:"video_url"`
 }
 
-// Update user
-// (PUT /users/{id})
-func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, id int) {
-	w.WriteHeader(http.StatusNotImplemented)
+// Level defines model for Level.
+type Level struct {
+	// CreatedAt Timestamp when the level was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// GameId ID of the game the level belongs to
+	GameId int `json:"game_id"`
+
+	// Id Unique level identifier
+	Id int `json:"id"`
+
+	// Name Display name of the level
+	Name string `json:"name"`
+
+	// Position Display order within the game, lowest first
+	Position int `json:"position"`
+
+	// Slug URL-safe identifier, unique within the game
+	Slug string `json:"slug"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// Link defines model for Link.
+type Link struct {
+	// Href Path of the linked resource, under the version prefix the request was sent with
+	Href string `json:"href"`
+}
+
+// Links Links to the resource itself, as self, when it has a route of its own, and to the resources related to it, keyed by relation. Pages of a list also link to the next page, as next, and to the previous one, as prev, when there is one.
+type Links map[string]Link
+
+// LogLevel Minimum severity of log lines that are written
+type LogLevel string
+
+// LogLevelSetting defines model for LogLevelSetting.
+type LogLevelSetting struct {
+	// Level Minimum severity of log lines that are written
+	Level LogLevel `json:"level"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+}
+
+// ModerationQueueEntry defines model for ModerationQueueEntry.
+type ModerationQueueEntry struct {
+	// Overdue Whether the run has waited longer than the review SLA
+	Overdue bool `json:"overdue"`
+	Run     Run  `json:"run"`
+
+	// WaitingSeconds How long ago the run was submitted
+	WaitingSeconds int64 `json:"waiting_seconds"`
+}
+
+// ModeratorLevel Super moderators may edit the game and manage its verifiers; verifiers may only review its runs
+type ModeratorLevel string
+
+// NewUserCounts defines model for NewUserCounts.
+type NewUserCounts struct {
+	// Last24h Users created in the last 24 hours
+	Last24h int64 `json:"last_24h"`
+
+	// Last30d Users created in the last 30 days
+	Last30d int64 `json:"last_30d"`
+
+	// Last7d Users created in the last 7 days
+	Last7d int64 `json:"last_7d"`
+}
+
+// Notification A notification of something that happened to one of the user's runs
+type Notification struct {
+	// CreatedAt When the event happened
+	CreatedAt time.Time `json:"created_at"`
+
+	// Data The event's data as webhooks receive it: the run for run.verified and run.rejected, the comment for comment.created, and the run and the previous record for record.broken
+	Data json.RawMessage `json:"data"`
+
+	// Event What happened: run.verified or run.rejected when a moderator reviewed one of the user's runs, comment.created when someone commented on one, and record.broken when a run beat a record the user held.
+	Event NotificationEvent `json:"event"`
+
+	// Id Unique notification identifier
+	Id int `json:"id"`
+
+	// Read Whether the user has read the notification
+	Read bool `json:"read"`
+
+	// ReadAt When the user first read the notification; absent while unread
+	ReadAt *time.Time `json:"read_at,omitempty"`
+}
+
+// NotificationEvent What happened: run.verified or run.rejected when a moderator reviewed one of the user's runs, comment.created when someone commented on one, and record.broken when a run beat a record the user held.
+type NotificationEvent string
+
+// NotificationPreference Which channels a user is told about one event through
+type NotificationPreference struct {
+	// Email Whether the user is emailed about the event
+	Email bool `json:"email"`
+
+	// Event The event, as in Notification
+	Event NotificationPreferenceEvent `json:"event"`
+
+	// InApp Whether the event is added to the user's notifications
+	InApp bool `json:"in_app"`
+
+	// Webhooks Whether the event is delivered to webhooks subscribed to it
+	Webhooks bool `json:"webhooks"`
+}
+
+// NotificationPreferenceEvent The event, as in Notification
+type NotificationPreferenceEvent string
+
+// NotificationSettings Which channels a user is told about each event through
+type NotificationSettings struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+// OAuthProvider External account provider
+type OAuthProvider string
+
+// Operation A long-running task running in the background
+type Operation struct {
+	// Links Links to the resource itself, as self, when it has a route of its own, and to the resources related to it, keyed by relation. Pages of a list also link to the next page, as next, and to the previous one, as prev, when there is one.
+	Links     *Links    `json:"_links,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Error Why a failed operation failed
+	Error *string `json:"error,omitempty"`
+
+	// FinishedAt When the operation succeeded or failed
+	FinishedAt *time.Time         `json:"finished_at,omitempty"`
+	Id         openapi_types.UUID `json:"id"`
+
+	// Kind What an operation does
+	Kind     OperationKind     `json:"kind"`
+	Progress OperationProgress `json:"progress"`
+
+	// Result The outcome of a succeeded operation: a UserImportReport for users.import, and the number of users exported, as rows, for users.export, whose file is downloaded from the output link
+	Result *interface{} `json:"result,omitempty"`
+
+	// StartedAt When a worker first picked the operation up
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// Status pending until a worker picks the operation up, then running until it has succeeded or failed. An operation whose worker stops is pending again until another picks it up.
+	Status OperationStatus `json:"status"`
+}
+
+// OperationKind What an operation does
+type OperationKind string
+
+// OperationProgress defines model for OperationProgress.
+type OperationProgress struct {
+	// Completed Items done so far, such as rows imported or users exported
+	Completed int `json:"completed"`
+
+	// Total The number of items in all; omitted while it isn't known
+	Total *int `json:"total,omitempty"`
+}
+
+// OperationStatus pending until a worker picks the operation up, then running until it has succeeded or failed. An operation whose worker stops is pending again until another picks it up.
+type OperationStatus string
+
+// PersonalBest defines model for PersonalBest.
+type PersonalBest struct {
+	CategoryId   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	CategorySlug string `json:"category_slug"`
+
+	// DeltaMs Milliseconds behind the world record; 0 when the run is the record
+	DeltaMs  int64  `json:"delta_ms"`
+	GameId   int    `json:"game_id"`
+	GameName string `json:"game_name"`
+	GameSlug string `json:"game_slug"`
+
+	// Platform Platform the run was played on
+	Platform string `json:"platform"`
+
+	// PlayedOn Day the run was played
+	PlayedOn openapi_types.Date `json:"played_on"`
+
+	// Rank Position on the category's leaderboard; tied times share a rank
+	Rank int `json:"rank"`
+
+	// RecordTimeMs Duration of the category's world record in milliseconds
+	RecordTimeMs int64 `json:"record_time_ms"`
+
+	// RunId ID of the user's best run in the category
+	RunId int `json:"run_id"`
+
+	// TimeMs Run duration in milliseconds
+	TimeMs int64 `json:"time_ms"`
+
+	// VideoUrl Link to a recording of the run
+	VideoUrl string `json:"video_url"`
+}
+
+// Platform defines model for Platform.
+type Platform struct {
+	// CreatedAt Timestamp when the platform was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id Unique platform identifier
+	Id int `json:"id"`
+
+	// Name Display name of the platform
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier runs refer to the platform by
+	Slug string `json:"slug"`
+}
+
+// Problem An RFC 9457 problem details object, sent as application/problem+json for every error response
+type Problem struct {
+	// Code Error code
+	Code string `json:"code"`
+
+	// Detail What went wrong with this request
+	Detail string `json:"detail"`
+
+	// Errors What was wrong with each part of a request that did not match this specification, with INVALID_REQUEST, or with each body field that failed validation, with INVALID_INPUT
+	Errors *[]ErrorDetail `json:"errors,omitempty"`
+
+	// Instance The path of the request that failed
+	Instance string `json:"instance"`
+
+	// Status The response's HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the kind of problem, the status's reason phrase
+	Title string `json:"title"`
+
+	// Type Identifies the kind of problem; one per error code
+	Type string `json:"type"`
+}
+
+// Race defines model for Race.
+type Race struct {
+	// CategoryId ID of the category that is raced
+	CategoryId int `json:"category_id"`
+
+	// CreatedAt When the race was opened
+	CreatedAt time.Time `json:"created_at"`
+
+	// CreatedBy ID of the user who opened the race; omitted once their account is deleted
+	CreatedBy *int `json:"created_by,omitempty"`
+
+	// FinishedAt When the race finished or was cancelled
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// Id Unique race identifier
+	Id int `json:"id"`
+
+	// Participants The runners in the race, finishers fastest first, then everyone else in the order they joined
+	Participants []RaceParticipant `json:"participants"`
+
+	// StartsAt End of the countdown, which finish times are measured from; set once every participant is ready
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+
+	// Status open while runners join and ready up, running from the start of the countdown until everyone has finished or forfeited, then finished; cancelled if its creator called it off before it started
+	Status RaceStatus `json:"status"`
+}
+
+// RaceStatus open while runners join and ready up, running from the start of the countdown until everyone has finished or forfeited, then finished; cancelled if its creator called it off before it started
+type RaceStatus string
+
+// RaceParticipant defines model for RaceParticipant.
+type RaceParticipant struct {
+	// FinishedAt When the runner finished; omitted until they do
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// ForfeitedAt When the runner left the running race without finishing it
+	ForfeitedAt *time.Time `json:"forfeited_at,omitempty"`
+	JoinedAt    time.Time  `json:"joined_at"`
+
+	// Name Display name of the runner
+	Name string `json:"name"`
+
+	// Ready Whether the runner is ready to start
+	Ready bool `json:"ready"`
+
+	// RunId ID of the run the finish was submitted as
+	RunId *int `json:"run_id,omitempty"`
+
+	// TimeMs Finish time in milliseconds from starts_at
+	TimeMs *int64 `json:"time_ms,omitempty"`
+
+	// UserId ID of the runner
+	UserId int `json:"user_id"`
+}
+
+// RecordHistoryEntry defines model for RecordHistoryEntry.
+type RecordHistoryEntry struct {
+	// Platform Platform the run was played on
+	Platform string `json:"platform"`
+
+	// PlayedOn Day the run was played
+	PlayedOn openapi_types.Date `json:"played_on"`
+
+	// RunId ID of the record-setting run
+	RunId int `json:"run_id"`
+
+	// SetAt When the run took the record, i.e. when it was verified
+	SetAt time.Time `json:"set_at"`
+
+	// TimeMs Run duration in milliseconds
+	TimeMs int64 `json:"time_ms"`
+
+	// UserId ID of the runner
+	UserId int `json:"user_id"`
+
+	// UserName Name of the runner
+	UserName string `json:"user_name"`
+
+	// VideoUrl Link to a recording of the run
+	VideoUrl string `json:"video_url"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	// RefreshToken Refresh token from the last login or refresh
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Region defines model for Region.
+type Region struct {
+	// CreatedAt Timestamp when the region was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id Unique region identifier
+	Id int `json:"id"`
+
+	// Name Display name of the region
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier runs refer to the region by
+	Slug string `json:"slug"`
+}
+
+// RegisterRequest defines model for RegisterRequest.
+type RegisterRequest struct {
+	// Email User's email address
+	Email openapi_types.Email `json:"email"`
+
+	// Name User's full name
+	Name string `json:"name"`
+
+	// Password Between 8 characters and 72 bytes
+	Password string `json:"password"`
+}
+
+// RejectRunRequest defines model for RejectRunRequest.
+type RejectRunRequest struct {
+	// Reason Why the run is being rejected
+	Reason string `json:"reason"`
+}
+
+// Run defines model for Run.
+type Run struct {
+	// Links Links to the resource itself, as self, when it has a route of its own, and to the resources related to it, keyed by relation. Pages of a list also link to the next page, as next, and to the previous one, as prev, when there is one.
+	Links    *Links    `json:"_links,omitempty"`
+	Category *Category `json:"category,omitempty"`
+
+	// CategoryId ID of the category the run was played in
+	CategoryId int `json:"category_id"`
+
+	// CreatedAt Timestamp when the run was submitted
+	CreatedAt time.Time `json:"created_at"`
+	Game      *Game     `json:"game,omitempty"`
+
+	// Id Unique run identifier
+	Id int `json:"id"`
+
+	// LevelId ID of the individual level the run is of; omitted for a full-game run
+	LevelId *int `json:"level_id,omitempty"`
+
+	// Obsolete Whether the runner has since had a faster run verified in the same category and level with the same variable values. Obsolete runs are left out of run listings unless include_obsolete is set.
+	Obsolete bool `json:"obsolete"`
+
+	// Platform Slug of the platform the run was played on
+	Platform string `json:"platform"`
+
+	// PlayedOn Day the run was played
+	PlayedOn openapi_types.Date `json:"played_on"`
+
+	// RaceId ID of the race the run was finished in; omitted for runs that were not race results
+	RaceId *int `json:"race_id,omitempty"`
+
+	// Region Slug of the region the run was played in; omitted when not recorded
+	Region *string `json:"region,omitempty"`
+
+	// RejectionReason Why a moderator rejected the run
+	RejectionReason *string `json:"rejection_reason,omitempty"`
+
+	// ReviewedAt Timestamp when a moderator verified or rejected the run
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+
+	// Runner The player who submitted a run, embedded in it when a run listing is asked to include the runner; absent for deleted users
+	Runner *Runner `json:"runner,omitempty"`
+
+	// Status Moderation state; only verified runs appear on leaderboards
+	Status RunStatus `json:"status"`
+
+	// TimeMs Run duration in milliseconds by the category's timing method, which the run is ranked by
+	TimeMs int64 `json:"time_ms"`
+
+	// Times A run's duration by each timing method it was timed with. A submission needs at least the time by its category's timing method.
+	Times RunTimes `json:"times"`
+
+	// UserId ID of the player who submitted the run
+	UserId int `json:"user_id"`
+
+	// Variables The values the run was played with, as value slugs keyed by variable slug. Included in run listings and in the response to a submission.
+	Variables *map[string]string `json:"variables,omitempty"`
+
+	// VideoUrl Canonical link to a recording of the run
+	VideoUrl string `json:"video_url"`
+}
+
+// RunStatus Moderation state; only verified runs appear on leaderboards
+type RunStatus string
+
+// RunComment defines model for RunComment.
+type RunComment struct {
+	// Body Text of the comment
+	Body string `json:"body"`
+
+	// CreatedAt When the comment was posted
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id Unique identifier for the comment
+	Id int `json:"id"`
+
+	// RunId ID of the run the comment is on
+	RunId int `json:"run_id"`
+
+	// UpdatedAt When the comment was last edited
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// UserId ID of the comment's author
+	UserId int `json:"user_id"`
+}
+
+// RunCommentRequest defines model for RunCommentRequest.
+type RunCommentRequest struct {
+	// Body Text of the comment
+	Body string `json:"body"`
+}
+
+// RunInclude A resource related to a run that run listings can embed in it
+type RunInclude string
+
+// RunTimes A run's duration by each timing method it was timed with. A submission needs at least the time by its category's timing method.
+type RunTimes struct {
+	// IgtMs In-game time in milliseconds, as shown by the game
+	IgtMs *int64 `json:"igt_ms,omitempty"`
+
+	// LrtMs Real time in milliseconds with loading screens removed
+	LrtMs *int64 `json:"lrt_ms,omitempty"`
+
+	// RtaMs Real time in milliseconds, from the first input to the last
+	RtaMs *int64 `json:"rta_ms,omitempty"`
+}
+
+// Runner The player who submitted a run, embedded in it when a run listing is asked to include the runner; absent for deleted users
+type Runner struct {
+	// AvatarUrl URL of the user's avatar; absent when the user has not uploaded one
+	AvatarUrl *string `json:"avatar_url,omitempty"`
+
+	// Id Unique user identifier
+	Id int `json:"id"`
+
+	// Name User's full name
+	Name string `json:"name"`
+
+	// PublicId Opaque user identifier that is safe to share externally
+	PublicId openapi_types.UUID `json:"public_id"`
+}
+
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	// CategorySlug Slug of a run's category; present for runs
+	CategorySlug *string `json:"category_slug,omitempty"`
+
+	// GameSlug Slug of the game, or of a run's game; absent for users
+	GameSlug *string `json:"game_slug,omitempty"`
+
+	// Id ID of the user, game, or run
+	Id int `json:"id"`
+
+	// Rank How well the result matched the query; higher is better. Ranks are only comparable within one search.
+	Rank float32 `json:"rank"`
+
+	// Snippet Excerpt of a run's best matching comment, with the matched words between ** marks; present for runs
+	Snippet *string `json:"snippet,omitempty"`
+
+	// Title The user's or game's name, or a run's game and category
+	Title string `json:"title"`
+
+	// Type What a search result is; run results are runs with a matching comment
+	Type SearchResultType `json:"type"`
+}
+
+// SearchResultType What a search result is; run results are runs with a matching comment
+type SearchResultType string
+
+// SearchResults defines model for SearchResults.
+type SearchResults struct {
+	// Query The query that was searched for, trimmed
+	Query string `json:"query"`
+
+	// Results The best matches of every requested type, highest rank first
+	Results []SearchResult `json:"results"`
+}
+
+// SubmitRunRequest defines model for SubmitRunRequest.
+type SubmitRunRequest struct {
+	// Level Slug of the game's level the run is of; omit for a full-game run
+	Level *string `json:"level,omitempty"`
+
+	// Platform Slug of one of the platforms, the one the run was played on
+	Platform string `json:"platform"`
+
+	// PlayedOn Day the run was played; may not be in the future
+	PlayedOn openapi_types.Date `json:"played_on"`
+
+	// Region Slug of one of the regions, the one the run was played in; omit if not recorded
+	Region *string `json:"region,omitempty"`
+
+	// TimeMs Real-time (RTA) duration in milliseconds; shorthand for times.rta_ms, which must then be omitted
+	TimeMs *int64 `json:"time_ms,omitempty"`
+
+	// Times A run's duration by each timing method it was timed with. A submission needs at least the time by its category's timing method.
+	Times *RunTimes `json:"times,omitempty"`
+
+	// UserId ID of the player submitting the run
+	UserId int `json:"user_id"`
+
+	// Variables The values the run was played with, as value slugs keyed by variable slug. Every variable must apply to the category; variables that are left out have no value.
+	Variables *map[string]string `json:"variables,omitempty"`
+
+	// VideoUrl Link to a YouTube video or Twitch VOD of the run, stored as its canonical link; when video checks are enabled the video must exist and be public
+	VideoUrl string `json:"video_url"`
+}
+
+// TimingMethod How a category's runs are timed and ranked; real time (rta), in-game time (igt), or real time with loading screens removed (lrt). Defaults to rta when creating a category.
+type TimingMethod string
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	// AccessToken Signed JWT to send as a bearer token
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Seconds until the access token expires
+	ExpiresIn int `json:"expires_in"`
+
+	// RefreshToken Single-use token for POST /auth/refresh
+	RefreshToken string `json:"refresh_token"`
+
+	// TokenType Always "Bearer"
+	TokenType string `json:"token_type"`
+}
+
+// UpdateGameRequest defines model for UpdateGameRequest.
+type UpdateGameRequest struct {
+	// Name Display name of the game
+	Name *string `json:"name,omitempty"`
+
+	// Slug URL-safe identifier of lowercase letters, digits, and hyphens; suggest is reserved
+	Slug *string `json:"slug,omitempty"`
+}
+
+// UpdatePlatformRequest defines model for UpdatePlatformRequest.
+type UpdatePlatformRequest struct {
+	// Name New display name of the platform
+	Name string `json:"name"`
+}
+
+// UpdateRegionRequest defines model for UpdateRegionRequest.
+type UpdateRegionRequest struct {
+	// Name New display name of the region
+	Name string `json:"name"`
+}
+
+// UpdateUserRequest defines model for UpdateUserRequest.
+type UpdateUserRequest struct {
+	// Bio Short description of the user, at most 1000 characters. An empty string clears it.
+	Bio *string `json:"bio,omitempty"`
+
+	// CountryCode ISO 3166-1 alpha-2 country code, in either case. An empty string clears it.
+	CountryCode *string `json:"country_code,omitempty"`
+
+	// Email User's email address
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// Name User's full name
+	Name *string `json:"name,omitempty"`
+
+	// Pronouns Pronouns the user goes by, at most 40 characters. An empty string clears it.
+	Pronouns *string `json:"pronouns,omitempty"`
+
+	// TwitchHandle Twitch username, 4 to 25 letters, digits, and underscores; a leading @ is dropped. An empty string clears it.
+	TwitchHandle *string `json:"twitch_handle,omitempty"`
+
+	// TwitterHandle Twitter username, at most 15 letters, digits, and underscores; a leading @ is dropped. An empty string clears it.
+	TwitterHandle *string `json:"twitter_handle,omitempty"`
+
+	// YoutubeHandle YouTube handle, 3 to 30 letters, digits, underscores, hyphens, and periods; a leading @ is dropped. An empty string clears it.
+	YoutubeHandle *string `json:"youtube_handle,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	// Links Links to the resource itself, as self, when it has a route of its own, and to the resources related to it, keyed by relation. Pages of a list also link to the next page, as next, and to the previous one, as prev, when there is one.
+	Links *Links `json:"_links,omitempty"`
+
+	// AvatarUrl URL of the user's avatar, a square PNG; absent when the user has not uploaded one
+	AvatarUrl *string `json:"avatar_url,omitempty" xml:"avatar_url,omitempty"`
+
+	// Bio Short description the user wrote about themselves; absent when not set
+	Bio *string `json:"bio,omitempty" xml:"bio,omitempty"`
+
+	// CountryCode ISO 3166-1 alpha-2 code of the country the user runs from; absent when not set
+	CountryCode *string `json:"country_code,omitempty" xml:"country_code,omitempty"`
+
+	// CreatedAt Timestamp when the user was created
+	CreatedAt time.Time `json:"created_at" xml:"created_at"`
+
+	// DeletedAt Timestamp when the user was soft-deleted; only present on deleted users, which are listed with include_deleted
+	DeletedAt *time.Time `json:"deleted_at,omitempty" xml:"deleted_at,omitempty"`
+
+	// Email User's email address, shown only to the user themself and admins
+	Email *openapi_types.Email `json:"email,omitempty" xml:"email"`
+
+	// EmailVerifiedAt Timestamp when the user verified their email address; absent until they do. Unverified users cannot submit runs.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" xml:"email_verified_at,omitempty"`
+
+	// Id Unique user identifier
+	Id int `json:"id" xml:"id"`
+
+	// Name User's full name
+	Name string `json:"name" xml:"name"`
+
+	// Pronouns Pronouns the user goes by; absent when not set
+	Pronouns *string `json:"pronouns,omitempty" xml:"pronouns,omitempty"`
+
+	// PublicId Opaque user identifier that is safe to share externally
+	PublicId openapi_types.UUID `json:"public_id" xml:"public_id"`
+
+	// TwitchHandle Twitch username, without a leading @; absent when not set
+	TwitchHandle *string `json:"twitch_handle,omitempty" xml:"twitch_handle,omitempty"`
+
+	// TwitterHandle Twitter username, without a leading @; absent when not set
+	TwitterHandle *string `json:"twitter_handle,omitempty" xml:"twitter_handle,omitempty"`
+
+	// UpdatedAt Timestamp when the user was last updated
+	UpdatedAt time.Time `json:"updated_at" xml:"updated_at"`
+
+	// YoutubeHandle YouTube handle, without a leading @; absent when not set
+	YoutubeHandle *string `json:"youtube_handle,omitempty" xml:"youtube_handle,omitempty"`
+}
+
+// UserField A field of a user, as named in the User schema
+type UserField string
+
+// UserImportReport defines model for UserImportReport.
+type UserImportReport struct {
+	// Failed Number of rows that failed
+	Failed int `json:"failed"`
+
+	// Imported Number of users created
+	Imported int `json:"imported"`
+
+	// Rows What became of each row, in the order of the file
+	Rows []UserImportRow `json:"rows"`
+}
+
+// UserImportRow defines model for UserImportRow.
+type UserImportRow struct {
+	// Email The row's email, as given
+	Email string `json:"email"`
+
+	// Errors What was wrong with each field of a failed row
+	Errors *[]ErrorDetail `json:"errors,omitempty"`
+
+	// Row The row's 1-based position in the file, not counting a CSV file's header row
+	Row    int                 `json:"row"`
+	Status UserImportRowStatus `json:"status"`
+
+	// UserId ID of the created user; present when the row was imported
+	UserId *int `json:"user_id,omitempty"`
+}
+
+// UserImportRowStatus defines model for UserImportRow.Status.
+type UserImportRowStatus string
+
+// UserPatch A JSON merge patch (RFC 7396) of a user. Fields left out are unchanged; null clears an optional profile field. The name and email can be changed but not cleared.
+type UserPatch struct {
+	// Bio Short description of the user, at most 1000 characters. Null clears it.
+	Bio *string `json:"bio"`
+
+	// CountryCode ISO 3166-1 alpha-2 country code, in either case. Null clears it.
+	CountryCode *string `json:"country_code"`
+
+	// Email User's email address
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// Name User's full name
+	Name *string `json:"name,omitempty"`
+
+	// Pronouns Pronouns the user goes by, at most 40 characters. Null clears it.
+	Pronouns *string `json:"pronouns"`
+
+	// TwitchHandle Twitch username, 4 to 25 letters, digits, and underscores; a leading @ is dropped. Null clears it.
+	TwitchHandle *string `json:"twitch_handle"`
+
+	// TwitterHandle Twitter username, at most 15 letters, digits, and underscores; a leading @ is dropped. Null clears it.
+	TwitterHandle *string `json:"twitter_handle"`
+
+	// YoutubeHandle YouTube handle, 3 to 30 letters, digits, underscores, hyphens, and periods; a leading @ is dropped. Null clears it.
+	YoutubeHandle *string `json:"youtube_handle"`
+}
+
+// UserProfile The public view of a user, without their email address
+type UserProfile struct {
+	// AvatarUrl URL of the user's avatar, a square PNG; absent when the user has not uploaded one
+	AvatarUrl *string `json:"avatar_url,omitempty"`
+
+	// Bio Short description the user wrote about themselves; absent when not set
+	Bio *string `json:"bio,omitempty"`
+
+	// CountryCode ISO 3166-1 alpha-2 code of the country the user runs from; absent when not set
+	CountryCode *string `json:"country_code,omitempty"`
+
+	// CreatedAt Timestamp when the user was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// FollowedGameCount Number of games the user follows
+	FollowedGameCount int `json:"followed_game_count"`
+
+	// FollowerCount Number of users following the user
+	FollowerCount int `json:"follower_count"`
+
+	// FollowingCount Number of users the user follows
+	FollowingCount int `json:"following_count"`
+
+	// Id Unique user identifier
+	Id int `json:"id"`
+
+	// Name User's full name
+	Name string `json:"name"`
+
+	// Pronouns Pronouns the user goes by; absent when not set
+	Pronouns *string `json:"pronouns,omitempty"`
+
+	// PublicId Opaque user identifier that is safe to share externally
+	PublicId openapi_types.UUID `json:"public_id"`
+
+	// TwitchHandle Twitch username, without a leading @; absent when not set
+	TwitchHandle *string `json:"twitch_handle,omitempty"`
+
+	// TwitterHandle Twitter username, without a leading @; absent when not set
+	TwitterHandle *string `json:"twitter_handle,omitempty"`
+
+	// YoutubeHandle YouTube handle, without a leading @; absent when not set
+	YoutubeHandle *string `json:"youtube_handle,omitempty"`
+}
+
+// UserStats defines model for UserStats.
+type UserStats struct {
+	// Corporate Users with a corporate email domain
+	Corporate int64         `json:"corporate"`
+	NewUsers  NewUserCounts `json:"new_users"`
+
+	// NonCorporate Users without a corporate email domain
+	NonCorporate int64 `json:"non_corporate"`
+
+	// Total Total number of users
+	Total int64 `json:"total"`
+}
+
+// Variable defines model for Variable.
+type Variable struct {
+	// CategoryId ID of the only category the variable applies to; absent when it applies to every category of the game
+	CategoryId *int `json:"category_id,omitempty"`
+
+	// CreatedAt Timestamp when the variable was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// GameId ID of the game the variable belongs to
+	GameId int `json:"game_id"`
+
+	// Id Unique variable identifier
+	Id int `json:"id"`
+
+	// Name Display name of the variable
+	Name string `json:"name"`
+
+	// Slug URL-safe identifier, unique within the game
+	Slug string `json:"slug"`
+
+	// Values The values a run may be played with, in display order
+	Values []VariableValue `json:"values"`
+}
+
+// VariableValue defines model for VariableValue.
+type VariableValue struct {
+	// Id Unique value identifier
+	Id int `json:"id"`
+
+	// Label Display label of the value
+	Label string `json:"label"`
+
+	// Slug URL-safe identifier, unique within the variable
+	Slug string `json:"slug"`
+}
+
+// VerifyEmailRequest defines model for VerifyEmailRequest.
+type VerifyEmailRequest struct {
+	// Token The verification token from the email
+	Token string `json:"token"`
+}
+
+// VersionInfo defines model for VersionInfo.
+type VersionInfo struct {
+	// BuildTime When the binary was built
+	BuildTime string `json:"build_time"`
+
+	// Commit Git commit the build was made from
+	Commit string `json:"commit"`
+
+	// Version Release version of the build
+	Version string `json:"version"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// CreatedBy The admin who registered the webhook; absent once their account is purged
+	CreatedBy *int           `json:"created_by,omitempty"`
+	Events    []WebhookEvent `json:"events"`
+	Id        int            `json:"id"`
+
+	// Secret Key of the HMAC-SHA256 signature sent with every delivery; only present in the response that created the webhook
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempts How many times the delivery has been sent
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Event An event webhooks can subscribe to. user.created is sent for every new account, run.submitted when a runner submits a run, run.verified and run.rejected when a moderator reviews a run, record.broken when a verified run beats its category's previous record, category.created when a category is added to a game, and comment.created when a user comments on a run.
+	Event WebhookEvent `json:"event"`
+
+	// Id Sent in the X-Webhook-Delivery header, so receivers can drop repeated deliveries
+	Id int `json:"id"`
+
+	// LastError Why the latest attempt failed
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttemptAt When a pending delivery is next sent
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+
+	// Payload The body that is posted: the event's id, which is the same for every delivery of the event, its name as event, occurred_at, and data
+	Payload json.RawMessage `json:"payload"`
+
+	// ResponseStatus HTTP status of the latest attempt's response; absent if no response was received
+	ResponseStatus *int `json:"response_status,omitempty"`
+
+	// Status Whether a delivery is still being attempted, was accepted by the endpoint, or ran out of attempts
+	Status    WebhookDeliveryStatus `json:"status"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// WebhookDeliveryStatus Whether a delivery is still being attempted, was accepted by the endpoint, or ran out of attempts
+type WebhookDeliveryStatus string
+
+// WebhookEvent An event webhooks can subscribe to. user.created is sent for every new account, run.submitted when a runner submits a run, run.verified and run.rejected when a moderator reviews a run, record.broken when a verified run beats its category's previous record, category.created when a category is added to a game, and comment.created when a user comments on a run.
+type WebhookEvent string
+
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	// Limit Maximum number of events to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of events to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while events are recorded. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// ActorId Only return changes made by this user
+	ActorId *int `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+
+	// EntityType Only return changes to this kind of entity
+	EntityType *AuditEntityType `form:"entity_type,omitempty" json:"entity_type,omitempty"`
+
+	// EntityId Only return changes to the entity with this ID; usually combined with entity_type
+	EntityId *int `form:"entity_id,omitempty" json:"entity_id,omitempty"`
+
+	// From Only return events recorded at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return events recorded before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ImportUsersParams defines parameters for ImportUsers.
+type ImportUsersParams struct {
+	// Prefer respond-async to import the file in the background instead, answered with 202 and an operation to poll; ignored when the file is rejected or sent with an API key
+	Prefer *string `json:"Prefer,omitempty"`
+}
+
+// OAuthCallbackParams defines parameters for OAuthCallback.
+type OAuthCallbackParams struct {
+	// Code Authorization code issued by the provider
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// State State passed to the provider by the start endpoint
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error Set by the provider when the user denied access
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// Game Only stream events about the game with this slug. Repeat to follow several games.
+	Game *[]string `form:"game,omitempty" json:"game,omitempty"`
+
+	// Event Only stream these events. Repeat to stream several.
+	Event *[]StreamEventsParamsEvent `form:"event,omitempty" json:"event,omitempty"`
+}
+
+// StreamEventsParamsEvent defines parameters for StreamEvents.
+type StreamEventsParamsEvent string
+
+// ListGamesParams defines parameters for ListGames.
+type ListGamesParams struct {
+	// Limit Maximum number of games to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of games to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while games are added or removed. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Fields Comma-separated fields of each game to return, leaving out the rest to keep responses small; all fields by default. Applies to JSON responses.
+	Fields *[]GameField `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// SuggestGamesParams defines parameters for SuggestGames.
+type SuggestGamesParams struct {
+	// Q What has been typed so far
+	Q string `form:"q" json:"q"`
+
+	// Limit Maximum number of games to suggest
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetGameParams defines parameters for GetGame.
+type GetGameParams struct {
+	// Fields Comma-separated fields of the game to return, leaving out the rest to keep responses small; all fields by default. Applies to JSON responses.
+	Fields *[]GameField `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// GetLeaderboardParams defines parameters for GetLeaderboard.
+type GetLeaderboardParams struct {
+	// Limit Maximum number of entries to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of entries to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while runs are verified or removed. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Variable Only rank runs played with a variable value, given as the variable's slug and the value's slug separated by a colon, e.g. difficulty:hard. Repeat to filter by several variables.
+	Variable *[]string `form:"variable,omitempty" json:"variable,omitempty"`
+
+	// Platform Only rank runs played on the platform with this slug
+	Platform *string `form:"platform,omitempty" json:"platform,omitempty"`
+
+	// Region Only rank runs played in the region with this slug
+	Region *string `form:"region,omitempty" json:"region,omitempty"`
+}
+
+// ListCategoryRunsParams defines parameters for ListCategoryRuns.
+type ListCategoryRunsParams struct {
+	// Limit Maximum number of runs to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of runs to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while runs are added or removed. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// IncludeObsolete Also return obsolete runs, i.e. verified runs the runner has since beaten in the same category
+	IncludeObsolete *bool `form:"include_obsolete,omitempty" json:"include_obsolete,omitempty"`
+
+	// Include Comma-separated related resources to embed in each run as runner, category, and game. Each kind is looked up once for the whole page, so clients don't need a request per run.
+	Include *[]RunInclude `form:"include,omitempty" json:"include,omitempty"`
+}
+
+// ListGameFollowersParams defines parameters for ListGameFollowers.
+type ListGameFollowersParams struct {
+	// Limit Maximum number of followers to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of followers to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while users follow. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetLevelLeaderboardParams defines parameters for GetLevelLeaderboard.
+type GetLevelLeaderboardParams struct {
+	// Category Slug of the category to rank the level's runs in; defaults to the game's default category
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Limit Maximum number of entries to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of entries to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while runs are verified or removed. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Variable Only rank runs played with a variable value, given as the variable's slug and the value's slug separated by a colon, e.g. difficulty:hard. Repeat to filter by several variables.
+	Variable *[]string `form:"variable,omitempty" json:"variable,omitempty"`
+
+	// Platform Only rank runs played on the platform with this slug
+	Platform *string `form:"platform,omitempty" json:"platform,omitempty"`
+
+	// Region Only rank runs played in the region with this slug
+	Region *string `form:"region,omitempty" json:"region,omitempty"`
+}
+
+// GetModerationQueueParams defines parameters for GetModerationQueue.
+type GetModerationQueueParams struct {
+	// Limit Maximum number of runs to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of runs to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while runs are reviewed. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// QueryGraphQLParams defines parameters for QueryGraphQL.
+type QueryGraphQLParams struct {
+	// Query The GraphQL query
+	Query string `form:"query" json:"query"`
+
+	// OperationName The operation to run when the query holds several
+	OperationName *string `form:"operationName,omitempty" json:"operationName,omitempty"`
+
+	// Variables The query's variables as a JSON object
+	Variables *string `form:"variables,omitempty" json:"variables,omitempty"`
+}
+
+// EnterRaceRoomParams defines parameters for EnterRaceRoom.
+type EnterRaceRoomParams struct {
+	// AccessToken Access token to take part with, for clients that cannot send an Authorization header
+	AccessToken *string `form:"access_token,omitempty" json:"access_token,omitempty"`
+}
+
+// ListRunCommentsParams defines parameters for ListRunComments.
+type ListRunCommentsParams struct {
+	// Limit Maximum number of comments to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of comments to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while comments are posted. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// SearchParams defines parameters for Search.
+type SearchParams struct {
+	// Q The search query
+	Q string `form:"q" json:"q"`
+
+	// Type Comma-separated types of results to look for; all types by default
+	Type *[]SearchResultType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Limit Maximum number of results to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	// Limit Maximum number of users to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of users to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while users are added or removed. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Corporate Only return corporate (true) or non-corporate (false) accounts. Omit to return all users.
+	Corporate *bool `form:"corporate,omitempty" json:"corporate,omitempty"`
+
+	// Name Only return users whose name contains this text, ignoring case
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// EmailDomain Only return users whose email address is at this domain, e.g. company.com. Requires the admin role.
+	EmailDomain *string `form:"email_domain,omitempty" json:"email_domain,omitempty"`
+
+	// Sort Column to sort by, optionally followed by :asc or :desc. One of id, name, email, created_at, or updated_at; ties are broken by id in the same direction. Sorting by email requires the admin role.
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// IncludeDeleted Also return soft-deleted users. Requires the admin role.
+	IncludeDeleted *bool `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+
+	// Fields Comma-separated fields of each user to return, leaving out the rest to keep responses small; all fields by default. Applies to JSON responses.
+	Fields *[]UserField `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Prefer respond-async to have an export made in the background instead, answered with 202 and an operation to poll; ignored by anything but an export by a logged-in admin
+	Prefer *string `json:"Prefer,omitempty"`
+}
+
+// BatchGetUsersParams defines parameters for BatchGetUsers.
+type BatchGetUsersParams struct {
+	// Ids Comma-separated list of user IDs
+	Ids []int `form:"ids" json:"ids"`
+}
+
+// UploadAvatarMultipartBody defines parameters for UploadAvatar.
+type UploadAvatarMultipartBody struct {
+	// Avatar The image file
+	Avatar openapi_types.File `json:"avatar"`
+}
+
+// GetFeedParams defines parameters for GetFeed.
+type GetFeedParams struct {
+	// Limit Maximum number of items to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while activity is added. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListNotificationsParams defines parameters for ListNotifications.
+type ListNotificationsParams struct {
+	// Unread Only list notifications that have not been read
+	Unread *bool `form:"unread,omitempty" json:"unread,omitempty"`
+
+	// Limit Maximum number of notifications to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of notifications to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while notifications arrive. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUserParams defines parameters for GetUser.
+type GetUserParams struct {
+	// Fields Comma-separated fields of the user to return, leaving out the rest to keep responses small; all fields by default. Applies to JSON responses.
+	Fields *[]UserField `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// PatchUserParams defines parameters for PatchUser.
+type PatchUserParams struct {
+	// IfMatch ETag of the version the patch is based on, or * to patch whichever version is current. Requests without it get 428.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// UpdateUserParams defines parameters for UpdateUser.
+type UpdateUserParams struct {
+	// IfMatch ETag of the version the update is based on, or * to update whichever version is current. Requests without it get 428.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ListFollowedGamesParams defines parameters for ListFollowedGames.
+type ListFollowedGamesParams struct {
+	// Limit Maximum number of games to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of games to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while the user follows games. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListUserFollowersParams defines parameters for ListUserFollowers.
+type ListUserFollowersParams struct {
+	// Limit Maximum number of followers to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of followers to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while users follow. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListUserFollowingParams defines parameters for ListUserFollowing.
+type ListUserFollowingParams struct {
+	// Limit Maximum number of users to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of users to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while the user follows others. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListUserRunsParams defines parameters for ListUserRuns.
+type ListUserRunsParams struct {
+	// Limit Maximum number of runs to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of runs to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while runs are added or removed. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// IncludeObsolete Also return obsolete runs, i.e. verified runs the runner has since beaten in the same category
+	IncludeObsolete *bool `form:"include_obsolete,omitempty" json:"include_obsolete,omitempty"`
+
+	// Include Comma-separated related resources to embed in each run as runner, category, and game. Each kind is looked up once for the whole page, so clients don't need a request per run.
+	Include *[]RunInclude `form:"include,omitempty" json:"include,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Limit Maximum number of deliveries to return. Values above the server's maximum page size (100 by default) are clamped to it.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of deliveries to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's next_cursor. Continues right after that page, so results stay consistent while events are queued. Cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
+type SetLogLevelJSONRequestBody = LogLevelSetting
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginRequest
+
+// LogoutJSONRequestBody defines body for Logout for application/json ContentType.
+type LogoutJSONRequestBody = RefreshTokenRequest
+
+// RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody = RefreshTokenRequest
+
+// RegisterJSONRequestBody defines body for Register for application/json ContentType.
+type RegisterJSONRequestBody = RegisterRequest
+
+// VerifyEmailJSONRequestBody defines body for VerifyEmail for application/json ContentType.
+type VerifyEmailJSONRequestBody = VerifyEmailRequest
+
+// CreateGameJSONRequestBody defines body for CreateGame for application/json ContentType.
+type CreateGameJSONRequestBody = CreateGameRequest
+
+// UpdateGameJSONRequestBody defines body for UpdateGame for application/json ContentType.
+type UpdateGameJSONRequestBody = UpdateGameRequest
+
+// CreateCategoryJSONRequestBody defines body for CreateCategory for application/json ContentType.
+type CreateCategoryJSONRequestBody = CreateCategoryRequest
+
+// SubmitRunJSONRequestBody defines body for SubmitRun for application/json ContentType.
+type SubmitRunJSONRequestBody = SubmitRunRequest
+
+// CreateLevelJSONRequestBody defines body for CreateLevel for application/json ContentType.
+type CreateLevelJSONRequestBody = CreateLevelRequest
+
+// AddGameModeratorJSONRequestBody defines body for AddGameModerator for application/json ContentType.
+type AddGameModeratorJSONRequestBody = AddGameModeratorRequest
+
+// CreateVariableJSONRequestBody defines body for CreateVariable for application/json ContentType.
+type CreateVariableJSONRequestBody = CreateVariableRequest
+
+// PostGraphQLJSONRequestBody defines body for PostGraphQL for application/json ContentType.
+type PostGraphQLJSONRequestBody = GraphQLRequest
+
+// CreatePlatformJSONRequestBody defines body for CreatePlatform for application/json ContentType.
+type CreatePlatformJSONRequestBody = CreatePlatformRequest
+
+// UpdatePlatformJSONRequestBody defines body for UpdatePlatform for application/json ContentType.
+type UpdatePlatformJSONRequestBody = UpdatePlatformRequest
+
+// CreateRegionJSONRequestBody defines body for CreateRegion for application/json ContentType.
+type CreateRegionJSONRequestBody = CreateRegionRequest
+
+// UpdateRegionJSONRequestBody defines body for UpdateRegion for application/json ContentType.
+type UpdateRegionJSONRequestBody = UpdateRegionRequest
+
+// CreateRunCommentJSONRequestBody defines body for CreateRunComment for application/json ContentType.
+type CreateRunCommentJSONRequestBody = RunCommentRequest
+
+// UpdateRunCommentJSONRequestBody defines body for UpdateRunComment for application/json ContentType.
+type UpdateRunCommentJSONRequestBody = RunCommentRequest
+
+// RejectRunJSONRequestBody defines body for RejectRun for application/json ContentType.
+type RejectRunJSONRequestBody = RejectRunRequest
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = CreateUserRequest
+
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody = CreateAPIKeyRequest
+
+// UploadAvatarMultipartRequestBody defines body for UploadAvatar for multipart/form-data ContentType.
+type UploadAvatarMultipartRequestBody UploadAvatarMultipartBody
+
+// UpdateNotificationSettingsJSONRequestBody defines body for UpdateNotificationSettings for application/json ContentType.
+type UpdateNotificationSettingsJSONRequestBody = NotificationSettings
+
+// PatchUserJSONRequestBody defines body for PatchUser for application/json ContentType.
+type PatchUserJSONRequestBody = UserPatch
+
+// PatchUserApplicationMergePatchPlusJSONRequestBody defines body for PatchUser for application/merge-patch+json ContentType.
+type PatchUserApplicationMergePatchPlusJSONRequestBody = UserPatch
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UpdateUserRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List audit events
+	// (GET /admin/audit-events)
+	ListAuditEvents(w http.ResponseWriter, r *http.Request, params ListAuditEventsParams)
+	// Get the log level
+	// (GET /admin/log-level)
+	GetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Change the log level
+	// (PUT /admin/log-level)
+	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Import users
+	// (POST /admin/users/import)
+	ImportUsers(w http.ResponseWriter, r *http.Request, params ImportUsersParams)
+	// Log in
+	// (POST /auth/login)
+	Login(w http.ResponseWriter, r *http.Request)
+	// Log out
+	// (POST /auth/logout)
+	Logout(w http.ResponseWriter, r *http.Request)
+	// Finish an OAuth login
+	// (GET /auth/oauth/{provider}/callback)
+	OAuthCallback(w http.ResponseWriter, r *http.Request, provider OAuthProvider, params OAuthCallbackParams)
+	// Start an OAuth login
+	// (GET /auth/oauth/{provider}/start)
+	StartOAuth(w http.ResponseWriter, r *http.Request, provider OAuthProvider)
+	// Refresh an access token
+	// (POST /auth/refresh)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
+	// Register an account
+	// (POST /auth/register)
+	Register(w http.ResponseWriter, r *http.Request)
+	// Verify an email address
+	// (POST /auth/verify-email)
+	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	// Browse the API documentation
+	// (GET /docs)
+	GetDocs(w http.ResponseWriter, r *http.Request)
+	// Stream live events
+	// (GET /events/stream)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
+	// List all games
+	// (GET /games)
+	ListGames(w http.ResponseWriter, r *http.Request, params ListGamesParams)
+	// Create a new game
+	// (POST /games)
+	CreateGame(w http.ResponseWriter, r *http.Request)
+	// Suggest games for a search box
+	// (GET /games/suggest)
+	SuggestGames(w http.ResponseWriter, r *http.Request, params SuggestGamesParams)
+	// Delete game
+	// (DELETE /games/{slug})
+	DeleteGame(w http.ResponseWriter, r *http.Request, slug string)
+	// Get game by slug
+	// (GET /games/{slug})
+	GetGame(w http.ResponseWriter, r *http.Request, slug string, params GetGameParams)
+	// Update game
+	// (PUT /games/{slug})
+	UpdateGame(w http.ResponseWriter, r *http.Request, slug string)
+	// List a game's categories
+	// (GET /games/{slug}/categories)
+	ListCategories(w http.ResponseWriter, r *http.Request, slug string)
+	// Create a category
+	// (POST /games/{slug}/categories)
+	CreateCategory(w http.ResponseWriter, r *http.Request, slug string)
+	// Get a category leaderboard
+	// (GET /games/{slug}/categories/{category}/leaderboard)
+	GetLeaderboard(w http.ResponseWriter, r *http.Request, slug string, category string, params GetLeaderboardParams)
+	// List a category's races
+	// (GET /games/{slug}/categories/{category}/races)
+	ListRaces(w http.ResponseWriter, r *http.Request, slug string, category string)
+	// Open a race
+	// (POST /games/{slug}/categories/{category}/races)
+	CreateRace(w http.ResponseWriter, r *http.Request, slug string, category string)
+	// Get a category's world record history
+	// (GET /games/{slug}/categories/{category}/records/history)
+	GetRecordHistory(w http.ResponseWriter, r *http.Request, slug string, category string)
+	// List runs in a category
+	// (GET /games/{slug}/categories/{category}/runs)
+	ListCategoryRuns(w http.ResponseWriter, r *http.Request, slug string, category string, params ListCategoryRunsParams)
+	// Submit a run
+	// (POST /games/{slug}/categories/{category}/runs)
+	SubmitRun(w http.ResponseWriter, r *http.Request, slug string, category string)
+	// Unfollow a game
+	// (DELETE /games/{slug}/follow)
+	UnfollowGame(w http.ResponseWriter, r *http.Request, slug string)
+	// Follow a game
+	// (POST /games/{slug}/follow)
+	FollowGame(w http.ResponseWriter, r *http.Request, slug string)
+	// List a game's followers
+	// (GET /games/{slug}/followers)
+	ListGameFollowers(w http.ResponseWriter, r *http.Request, slug string, params ListGameFollowersParams)
+	// List a game's levels
+	// (GET /games/{slug}/levels)
+	ListLevels(w http.ResponseWriter, r *http.Request, slug string)
+	// Create a level
+	// (POST /games/{slug}/levels)
+	CreateLevel(w http.ResponseWriter, r *http.Request, slug string)
+	// Get an individual level leaderboard
+	// (GET /games/{slug}/levels/{level}/leaderboard)
+	GetLevelLeaderboard(w http.ResponseWriter, r *http.Request, slug string, level string, params GetLevelLeaderboardParams)
+	// List runs waiting for review
+	// (GET /games/{slug}/moderation/queue)
+	GetModerationQueue(w http.ResponseWriter, r *http.Request, slug string, params GetModerationQueueParams)
+	// List a game's moderators
+	// (GET /games/{slug}/moderators)
+	ListGameModerators(w http.ResponseWriter, r *http.Request, slug string)
+	// Add a game moderator
+	// (POST /games/{slug}/moderators)
+	AddGameModerator(w http.ResponseWriter, r *http.Request, slug string)
+	// Remove a game moderator
+	// (DELETE /games/{slug}/moderators/{userId})
+	RemoveGameModerator(w http.ResponseWriter, r *http.Request, slug string, userId int)
+	// List a game's variables
+	// (GET /games/{slug}/variables)
+	ListVariables(w http.ResponseWriter, r *http.Request, slug string)
+	// Create a variable
+	// (POST /games/{slug}/variables)
+	CreateVariable(w http.ResponseWriter, r *http.Request, slug string)
+	// Run a GraphQL query
+	// (GET /graphql)
+	QueryGraphQL(w http.ResponseWriter, r *http.Request, params QueryGraphQLParams)
+	// Run a GraphQL query
+	// (POST /graphql)
+	PostGraphQL(w http.ResponseWriter, r *http.Request)
+	// Check that the process is up
+	// (GET /healthz)
+	GetHealthz(w http.ResponseWriter, r *http.Request)
+	// Check that the process should keep running
+	// (GET /livez)
+	GetLivez(w http.ResponseWriter, r *http.Request)
+	// Get Prometheus metrics
+	// (GET /metrics)
+	GetMetrics(w http.ResponseWriter, r *http.Request)
+	// Get the OpenAPI specification
+	// (GET /openapi.json)
+	GetOpenAPISpec(w http.ResponseWriter, r *http.Request)
+	// Get an operation
+	// (GET /operations/{id})
+	GetOperation(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Download an operation's output
+	// (GET /operations/{id}/output)
+	GetOperationOutput(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// List platforms
+	// (GET /platforms)
+	ListPlatforms(w http.ResponseWriter, r *http.Request)
+	// Create a platform
+	// (POST /platforms)
+	CreatePlatform(w http.ResponseWriter, r *http.Request)
+	// Delete a platform
+	// (DELETE /platforms/{slug})
+	DeletePlatform(w http.ResponseWriter, r *http.Request, slug string)
+	// Rename a platform
+	// (PATCH /platforms/{slug})
+	UpdatePlatform(w http.ResponseWriter, r *http.Request, slug string)
+	// Get a race
+	// (GET /races/{id})
+	GetRace(w http.ResponseWriter, r *http.Request, id int)
+	// Enter a race room
+	// (GET /races/{id}/live)
+	EnterRaceRoom(w http.ResponseWriter, r *http.Request, id int, params EnterRaceRoomParams)
+	// Check that the server can take traffic
+	// (GET /readyz)
+	GetReadyz(w http.ResponseWriter, r *http.Request)
+	// List regions
+	// (GET /regions)
+	ListRegions(w http.ResponseWriter, r *http.Request)
+	// Create a region
+	// (POST /regions)
+	CreateRegion(w http.ResponseWriter, r *http.Request)
+	// Delete a region
+	// (DELETE /regions/{slug})
+	DeleteRegion(w http.ResponseWriter, r *http.Request, slug string)
+	// Rename a region
+	// (PATCH /regions/{slug})
+	UpdateRegion(w http.ResponseWriter, r *http.Request, slug string)
+	// List a run's comments
+	// (GET /runs/{id}/comments)
+	ListRunComments(w http.ResponseWriter, r *http.Request, id int, params ListRunCommentsParams)
+	// Comment on a run
+	// (POST /runs/{id}/comments)
+	CreateRunComment(w http.ResponseWriter, r *http.Request, id int)
+	// Delete a comment
+	// (DELETE /runs/{id}/comments/{commentId})
+	DeleteRunComment(w http.ResponseWriter, r *http.Request, id int, commentId int)
+	// Edit a comment
+	// (PUT /runs/{id}/comments/{commentId})
+	UpdateRunComment(w http.ResponseWriter, r *http.Request, id int, commentId int)
+	// Reject a run
+	// (POST /runs/{id}/reject)
+	RejectRun(w http.ResponseWriter, r *http.Request, id int)
+	// Verify a run
+	// (POST /runs/{id}/verify)
+	VerifyRun(w http.ResponseWriter, r *http.Request, id int)
+	// Search users, games, and runs
+	// (GET /search)
+	Search(w http.ResponseWriter, r *http.Request, params SearchParams)
+	// List all users
+	// (GET /users)
+	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
+	// Create a new user
+	// (POST /users)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Get multiple users by ID
+	// (GET /users/batch)
+	BatchGetUsers(w http.ResponseWriter, r *http.Request, params BatchGetUsersParams)
+	// List API keys
+	// (GET /users/me/api-keys)
+	ListAPIKeys(w http.ResponseWriter, r *http.Request)
+	// Create an API key
+	// (POST /users/me/api-keys)
+	CreateAPIKey(w http.ResponseWriter, r *http.Request)
+	// Revoke an API key
+	// (DELETE /users/me/api-keys/{id})
+	RevokeAPIKey(w http.ResponseWriter, r *http.Request, id int)
+	// Upload an avatar
+	// (PUT /users/me/avatar)
+	UploadAvatar(w http.ResponseWriter, r *http.Request)
+	// Get the caller's activity feed
+	// (GET /users/me/feed)
+	GetFeed(w http.ResponseWriter, r *http.Request, params GetFeedParams)
+	// List the caller's notifications
+	// (GET /users/me/notifications)
+	ListNotifications(w http.ResponseWriter, r *http.Request, params ListNotificationsParams)
+	// Mark all notifications read
+	// (POST /users/me/notifications/read)
+	MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request)
+	// Count the caller's unread notifications
+	// (GET /users/me/notifications/unread-count)
+	GetUnreadNotificationCount(w http.ResponseWriter, r *http.Request)
+	// Mark a notification read
+	// (POST /users/me/notifications/{id}/read)
+	MarkNotificationRead(w http.ResponseWriter, r *http.Request, id int)
+	// Get notification settings
+	// (GET /users/me/settings/notifications)
+	GetNotificationSettings(w http.ResponseWriter, r *http.Request)
+	// Update notification settings
+	// (PUT /users/me/settings/notifications)
+	UpdateNotificationSettings(w http.ResponseWriter, r *http.Request)
+	// Get user statistics
+	// (GET /users/stats)
+	GetUserStats(w http.ResponseWriter, r *http.Request)
+	// Delete user
+	// (DELETE /users/{id})
+	DeleteUser(w http.ResponseWriter, r *http.Request, id int)
+	// Get user by ID
+	// (GET /users/{id})
+	GetUser(w http.ResponseWriter, r *http.Request, id string, params GetUserParams)
+	// Patch user
+	// (PATCH /users/{id})
+	PatchUser(w http.ResponseWriter, r *http.Request, id int, params PatchUserParams)
+	// Update user
+	// (PUT /users/{id})
+	UpdateUser(w http.ResponseWriter, r *http.Request, id int, params UpdateUserParams)
+	// Unfollow a user
+	// (DELETE /users/{id}/follow)
+	UnfollowUser(w http.ResponseWriter, r *http.Request, id int)
+	// Follow a user
+	// (POST /users/{id}/follow)
+	FollowUser(w http.ResponseWriter, r *http.Request, id int)
+	// List the games a user follows
+	// (GET /users/{id}/followed-games)
+	ListFollowedGames(w http.ResponseWriter, r *http.Request, id int, params ListFollowedGamesParams)
+	// List a user's followers
+	// (GET /users/{id}/followers)
+	ListUserFollowers(w http.ResponseWriter, r *http.Request, id int, params ListUserFollowersParams)
+	// List the users a user follows
+	// (GET /users/{id}/following)
+	ListUserFollowing(w http.ResponseWriter, r *http.Request, id int, params ListUserFollowingParams)
+	// List a user's personal bests
+	// (GET /users/{id}/personal-bests)
+	ListUserPersonalBests(w http.ResponseWriter, r *http.Request, id int)
+	// Get a user's public profile
+	// (GET /users/{id}/profile)
+	GetUserProfile(w http.ResponseWriter, r *http.Request, id string)
+	// Permanently delete a user
+	// (DELETE /users/{id}/purge)
+	PurgeUser(w http.ResponseWriter, r *http.Request, id int)
+	// Restore a deleted user
+	// (POST /users/{id}/restore)
+	RestoreUser(w http.ResponseWriter, r *http.Request, id int)
+	// List a user's runs
+	// (GET /users/{id}/runs)
+	ListUserRuns(w http.ResponseWriter, r *http.Request, id int, params ListUserRunsParams)
+	// Get build information
+	// (GET /version)
+	GetVersion(w http.ResponseWriter, r *http.Request)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	// Register a webhook
+	// (POST /webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// Delete a webhook
+	// (DELETE /webhooks/{id})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, id int)
+	// Get a webhook
+	// (GET /webhooks/{id})
+	GetWebhook(w http.ResponseWriter, r *http.Request, id int)
+	// List a webhook's deliveries
+	// (GET /webhooks/{id}/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, id int, params ListWebhookDeliveriesParams)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List audit events
+// (GET /admin/audit-events)
+func (_ Unimplemented) ListAuditEvents(w http.ResponseWriter, r *http.Request, params ListAuditEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the log level
+// (GET /admin/log-level)
+func (_ Unimplemented) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the log level
+// (PUT /admin/log-level)
+func (_ Unimplemented) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import users
+// (POST /admin/users/import)
+func (_ Unimplemented) ImportUsers(w http.ResponseWriter, r *http.Request, params ImportUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Log in
+// (POST /auth/login)
+func (_ Unimplemented) Login(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Log out
+// (POST /auth/logout)
+func (_ Unimplemented) Logout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Finish an OAuth login
+// (GET /auth/oauth/{provider}/callback)
+func (_ Unimplemented) OAuthCallback(w http.ResponseWriter, r *http.Request, provider OAuthProvider, params OAuthCallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start an OAuth login
+// (GET /auth/oauth/{provider}/start)
+func (_ Unimplemented) StartOAuth(w http.ResponseWriter, r *http.Request, provider OAuthProvider) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refresh an access token
+// (POST /auth/refresh)
+func (_ Unimplemented) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register an account
+// (POST /auth/register)
+func (_ Unimplemented) Register(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Verify an email address
+// (POST /auth/verify-email)
+func (_ Unimplemented) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Browse the API documentation
+// (GET /docs)
+func (_ Unimplemented) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream live events
+// (GET /events/stream)
+func (_ Unimplemented) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all games
+// (GET /games)
+func (_ Unimplemented) ListGames(w http.ResponseWriter, r *http.Request, params ListGamesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new game
+// (POST /games)
+func (_ Unimplemented) CreateGame(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Suggest games for a search box
+// (GET /games/suggest)
+func (_ Unimplemented) SuggestGames(w http.ResponseWriter, r *http.Request, params SuggestGamesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete game
+// (DELETE /games/{slug})
+func (_ Unimplemented) DeleteGame(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get game by slug
+// (GET /games/{slug})
+func (_ Unimplemented) GetGame(w http.ResponseWriter, r *http.Request, slug string, params GetGameParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update game
+// (PUT /games/{slug})
+func (_ Unimplemented) UpdateGame(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a game's categories
+// (GET /games/{slug}/categories)
+func (_ Unimplemented) ListCategories(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a category
+// (POST /games/{slug}/categories)
+func (_ Unimplemented) CreateCategory(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a category leaderboard
+// (GET /games/{slug}/categories/{category}/leaderboard)
+func (_ Unimplemented) GetLeaderboard(w http.ResponseWriter, r *http.Request, slug string, category string, params GetLeaderboardParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a category's races
+// (GET /games/{slug}/categories/{category}/races)
+func (_ Unimplemented) ListRaces(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Open a race
+// (POST /games/{slug}/categories/{category}/races)
+func (_ Unimplemented) CreateRace(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a category's world record history
+// (GET /games/{slug}/categories/{category}/records/history)
+func (_ Unimplemented) GetRecordHistory(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List runs in a category
+// (GET /games/{slug}/categories/{category}/runs)
+func (_ Unimplemented) ListCategoryRuns(w http.ResponseWriter, r *http.Request, slug string, category string, params ListCategoryRunsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Submit a run
+// (POST /games/{slug}/categories/{category}/runs)
+func (_ Unimplemented) SubmitRun(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unfollow a game
+// (DELETE /games/{slug}/follow)
+func (_ Unimplemented) UnfollowGame(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Follow a game
+// (POST /games/{slug}/follow)
+func (_ Unimplemented) FollowGame(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a game's followers
+// (GET /games/{slug}/followers)
+func (_ Unimplemented) ListGameFollowers(w http.ResponseWriter, r *http.Request, slug string, params ListGameFollowersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a game's levels
+// (GET /games/{slug}/levels)
+func (_ Unimplemented) ListLevels(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a level
+// (POST /games/{slug}/levels)
+func (_ Unimplemented) CreateLevel(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an individual level leaderboard
+// (GET /games/{slug}/levels/{level}/leaderboard)
+func (_ Unimplemented) GetLevelLeaderboard(w http.ResponseWriter, r *http.Request, slug string, level string, params GetLevelLeaderboardParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List runs waiting for review
+// (GET /games/{slug}/moderation/queue)
+func (_ Unimplemented) GetModerationQueue(w http.ResponseWriter, r *http.Request, slug string, params GetModerationQueueParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a game's moderators
+// (GET /games/{slug}/moderators)
+func (_ Unimplemented) ListGameModerators(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a game moderator
+// (POST /games/{slug}/moderators)
+func (_ Unimplemented) AddGameModerator(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a game moderator
+// (DELETE /games/{slug}/moderators/{userId})
+func (_ Unimplemented) RemoveGameModerator(w http.ResponseWriter, r *http.Request, slug string, userId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a game's variables
+// (GET /games/{slug}/variables)
+func (_ Unimplemented) ListVariables(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a variable
+// (POST /games/{slug}/variables)
+func (_ Unimplemented) CreateVariable(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Run a GraphQL query
+// (GET /graphql)
+func (_ Unimplemented) QueryGraphQL(w http.ResponseWriter, r *http.Request, params QueryGraphQLParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Run a GraphQL query
+// (POST /graphql)
+func (_ Unimplemented) PostGraphQL(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check that the process is up
+// (GET /healthz)
+func (_ Unimplemented) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check that the process should keep running
+// (GET /livez)
+func (_ Unimplemented) GetLivez(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get Prometheus metrics
+// (GET /metrics)
+func (_ Unimplemented) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the OpenAPI specification
+// (GET /openapi.json)
+func (_ Unimplemented) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an operation
+// (GET /operations/{id})
+func (_ Unimplemented) GetOperation(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download an operation's output
+// (GET /operations/{id}/output)
+func (_ Unimplemented) GetOperationOutput(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List platforms
+// (GET /platforms)
+func (_ Unimplemented) ListPlatforms(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a platform
+// (POST /platforms)
+func (_ Unimplemented) CreatePlatform(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a platform
+// (DELETE /platforms/{slug})
+func (_ Unimplemented) DeletePlatform(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename a platform
+// (PATCH /platforms/{slug})
+func (_ Unimplemented) UpdatePlatform(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a race
+// (GET /races/{id})
+func (_ Unimplemented) GetRace(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enter a race room
+// (GET /races/{id}/live)
+func (_ Unimplemented) EnterRaceRoom(w http.ResponseWriter, r *http.Request, id int, params EnterRaceRoomParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check that the server can take traffic
+// (GET /readyz)
+func (_ Unimplemented) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List regions
+// (GET /regions)
+func (_ Unimplemented) ListRegions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a region
+// (POST /regions)
+func (_ Unimplemented) CreateRegion(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a region
+// (DELETE /regions/{slug})
+func (_ Unimplemented) DeleteRegion(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename a region
+// (PATCH /regions/{slug})
+func (_ Unimplemented) UpdateRegion(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a run's comments
+// (GET /runs/{id}/comments)
+func (_ Unimplemented) ListRunComments(w http.ResponseWriter, r *http.Request, id int, params ListRunCommentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Comment on a run
+// (POST /runs/{id}/comments)
+func (_ Unimplemented) CreateRunComment(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a comment
+// (DELETE /runs/{id}/comments/{commentId})
+func (_ Unimplemented) DeleteRunComment(w http.ResponseWriter, r *http.Request, id int, commentId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Edit a comment
+// (PUT /runs/{id}/comments/{commentId})
+func (_ Unimplemented) UpdateRunComment(w http.ResponseWriter, r *http.Request, id int, commentId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a run
+// (POST /runs/{id}/reject)
+func (_ Unimplemented) RejectRun(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Verify a run
+// (POST /runs/{id}/verify)
+func (_ Unimplemented) VerifyRun(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search users, games, and runs
+// (GET /search)
+func (_ Unimplemented) Search(w http.ResponseWriter, r *http.Request, params SearchParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all users
+// (GET /users)
+func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new user
+// (POST /users)
+func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get multiple users by ID
+// (GET /users/batch)
+func (_ Unimplemented) BatchGetUsers(w http.ResponseWriter, r *http.Request, params BatchGetUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List API keys
+// (GET /users/me/api-keys)
+func (_ Unimplemented) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API key
+// (POST /users/me/api-keys)
+func (_ Unimplemented) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key
+// (DELETE /users/me/api-keys/{id})
+func (_ Unimplemented) RevokeAPIKey(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload an avatar
+// (PUT /users/me/avatar)
+func (_ Unimplemented) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the caller's activity feed
+// (GET /users/me/feed)
+func (_ Unimplemented) GetFeed(w http.ResponseWriter, r *http.Request, params GetFeedParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the caller's notifications
+// (GET /users/me/notifications)
+func (_ Unimplemented) ListNotifications(w http.ResponseWriter, r *http.Request, params ListNotificationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Mark all notifications read
+// (POST /users/me/notifications/read)
+func (_ Unimplemented) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Count the caller's unread notifications
+// (GET /users/me/notifications/unread-count)
+func (_ Unimplemented) GetUnreadNotificationCount(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Mark a notification read
+// (POST /users/me/notifications/{id}/read)
+func (_ Unimplemented) MarkNotificationRead(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get notification settings
+// (GET /users/me/settings/notifications)
+func (_ Unimplemented) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update notification settings
+// (PUT /users/me/settings/notifications)
+func (_ Unimplemented) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user statistics
+// (GET /users/stats)
+func (_ Unimplemented) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete user
+// (DELETE /users/{id})
+func (_ Unimplemented) DeleteUser(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user by ID
+// (GET /users/{id})
+func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, id string, params GetUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Patch user
+// (PATCH /users/{id})
+func (_ Unimplemented) PatchUser(w http.ResponseWriter, r *http.Request, id int, params PatchUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update user
+// (PUT /users/{id})
+func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, id int, params UpdateUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unfollow a user
+// (DELETE /users/{id}/follow)
+func (_ Unimplemented) UnfollowUser(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Follow a user
+// (POST /users/{id}/follow)
+func (_ Unimplemented) FollowUser(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the games a user follows
+// (GET /users/{id}/followed-games)
+func (_ Unimplemented) ListFollowedGames(w http.ResponseWriter, r *http.Request, id int, params ListFollowedGamesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a user's followers
+// (GET /users/{id}/followers)
+func (_ Unimplemented) ListUserFollowers(w http.ResponseWriter, r *http.Request, id int, params ListUserFollowersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the users a user follows
+// (GET /users/{id}/following)
+func (_ Unimplemented) ListUserFollowing(w http.ResponseWriter, r *http.Request, id int, params ListUserFollowingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a user's personal bests
+// (GET /users/{id}/personal-bests)
+func (_ Unimplemented) ListUserPersonalBests(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a user's public profile
+// (GET /users/{id}/profile)
+func (_ Unimplemented) GetUserProfile(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Permanently delete a user
+// (DELETE /users/{id}/purge)
+func (_ Unimplemented) PurgeUser(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a deleted user
+// (POST /users/{id}/restore)
+func (_ Unimplemented) RestoreUser(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a user's runs
+// (GET /users/{id}/runs)
+func (_ Unimplemented) ListUserRuns(w http.ResponseWriter, r *http.Request, id int, params ListUserRunsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get build information
+// (GET /version)
+func (_ Unimplemented) GetVersion(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhooks
+// (GET /webhooks)
+func (_ Unimplemented) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a webhook
+// (POST /webhooks)
+func (_ Unimplemented) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a webhook
+// (DELETE /webhooks/{id})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a webhook
+// (GET /webhooks/{id})
+func (_ Unimplemented) GetWebhook(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a webhook's deliveries
+// (GET /webhooks/{id}/deliveries)
+func (_ Unimplemented) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, id int, params ListWebhookDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAuditEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEventsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "actor_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor_id", r.URL.Query(), &params.ActorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "entity_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_type", r.URL.Query(), &params.EntityType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entity_type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "entity_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_id", r.URL.Query(), &params.EntityId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entity_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuditEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogLevel(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetLogLevel(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ImportUsers operation middleware
+func (siw *ServerInterfaceWrapper) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportUsersParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Prefer" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Prefer")]; found {
+		var Prefer string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Prefer", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Prefer", runtime.ParamLocationHeader, valueList[0], &Prefer)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Prefer", Err: err})
+			return
+		}
+
+		params.Prefer = &Prefer
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Logout operation middleware
+func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Logout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// OAuthCallback operation middleware
+func (siw *ServerInterfaceWrapper) OAuthCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider OAuthProvider
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "provider", runtime.ParamLocationPath, chi.URLParam(r, "provider"), &provider)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params OAuthCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.OAuthCallback(w, r, provider, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// StartOAuth operation middleware
+func (siw *ServerInterfaceWrapper) StartOAuth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider OAuthProvider
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "provider", runtime.ParamLocationPath, chi.URLParam(r, "provider"), &provider)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartOAuth(w, r, provider)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Register operation middleware
+func (siw *ServerInterfaceWrapper) Register(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Register(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// VerifyEmail operation middleware
+func (siw *ServerInterfaceWrapper) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetDocs operation middleware
+func (siw *ServerInterfaceWrapper) GetDocs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDocs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Optional query parameter "game" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "game", r.URL.Query(), &params.Game)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "game", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "event" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "event", r.URL.Query(), &params.Event)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "event", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListGames operation middleware
+func (siw *ServerInterfaceWrapper) ListGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListGamesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGames(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateGame operation middleware
+func (siw *ServerInterfaceWrapper) CreateGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateGame(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SuggestGames operation middleware
+func (siw *ServerInterfaceWrapper) SuggestGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SuggestGamesParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SuggestGames(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteGame operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteGame(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetGame operation middleware
+func (siw *ServerInterfaceWrapper) GetGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGameParams
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGame(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateGame operation middleware
+func (siw *ServerInterfaceWrapper) UpdateGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateGame(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCategories operation middleware
+func (siw *ServerInterfaceWrapper) ListCategories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCategories(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateCategory operation middleware
+func (siw *ServerInterfaceWrapper) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCategory(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "category", runtime.ParamLocationPath, chi.URLParam(r, "category"), &category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLeaderboardParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "variable" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "variable", r.URL.Query(), &params.Variable)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variable", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "platform" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "platform", r.URL.Query(), &params.Platform)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "platform", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", r.URL.Query(), &params.Region)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "region", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLeaderboard(w, r, slug, category, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListRaces operation middleware
+func (siw *ServerInterfaceWrapper) ListRaces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "category", runtime.ParamLocationPath, chi.URLParam(r, "category"), &category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRaces(w, r, slug, category)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateRace operation middleware
+func (siw *ServerInterfaceWrapper) CreateRace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "category", runtime.ParamLocationPath, chi.URLParam(r, "category"), &category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRace(w, r, slug, category)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRecordHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetRecordHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "category", runtime.ParamLocationPath, chi.URLParam(r, "category"), &category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRecordHistory(w, r, slug, category)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCategoryRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListCategoryRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "category", runtime.ParamLocationPath, chi.URLParam(r, "category"), &category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCategoryRunsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_obsolete" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_obsolete", r.URL.Query(), &params.IncludeObsolete)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_obsolete", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "include", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCategoryRuns(w, r, slug, category, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SubmitRun operation middleware
+func (siw *ServerInterfaceWrapper) SubmitRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "category", runtime.ParamLocationPath, chi.URLParam(r, "category"), &category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"runs:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubmitRun(w, r, slug, category)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UnfollowGame operation middleware
+func (siw *ServerInterfaceWrapper) UnfollowGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnfollowGame(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// FollowGame operation middleware
+func (siw *ServerInterfaceWrapper) FollowGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FollowGame(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListGameFollowers operation middleware
+func (siw *ServerInterfaceWrapper) ListGameFollowers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListGameFollowersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGameFollowers(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListLevels operation middleware
+func (siw *ServerInterfaceWrapper) ListLevels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLevels(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateLevel operation middleware
+func (siw *ServerInterfaceWrapper) CreateLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateLevel(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetLevelLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetLevelLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "level" -------------
+	var level string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "level", runtime.ParamLocationPath, chi.URLParam(r, "level"), &level)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "level", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLevelLeaderboardParams
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "variable" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "variable", r.URL.Query(), &params.Variable)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variable", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "platform" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "platform", r.URL.Query(), &params.Platform)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "platform", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", r.URL.Query(), &params.Region)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "region", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLevelLeaderboard(w, r, slug, level, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetModerationQueue operation middleware
+func (siw *ServerInterfaceWrapper) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"runs:moderate"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModerationQueueParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetModerationQueue(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListGameModerators operation middleware
+func (siw *ServerInterfaceWrapper) ListGameModerators(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGameModerators(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AddGameModerator operation middleware
+func (siw *ServerInterfaceWrapper) AddGameModerator(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddGameModerator(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RemoveGameModerator operation middleware
+func (siw *ServerInterfaceWrapper) RemoveGameModerator(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userId", runtime.ParamLocationPath, chi.URLParam(r, "userId"), &userId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveGameModerator(w, r, slug, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListVariables operation middleware
+func (siw *ServerInterfaceWrapper) ListVariables(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListVariables(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateVariable operation middleware
+func (siw *ServerInterfaceWrapper) CreateVariable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateVariable(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// QueryGraphQL operation middleware
+func (siw *ServerInterfaceWrapper) QueryGraphQL(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params QueryGraphQLParams
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "operationName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "operationName", r.URL.Query(), &params.OperationName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "operationName", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "variables" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "variables", r.URL.Query(), &params.Variables)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variables", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.QueryGraphQL(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostGraphQL operation middleware
+func (siw *ServerInterfaceWrapper) PostGraphQL(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostGraphQL(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetHealthz operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealthz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetLivez operation middleware
+func (siw *ServerInterfaceWrapper) GetLivez(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLivez(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetMetrics operation middleware
+func (siw *ServerInterfaceWrapper) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMetrics(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetOpenAPISpec operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOpenAPISpec(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetOperation operation middleware
+func (siw *ServerInterfaceWrapper) GetOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOperation(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetOperationOutput operation middleware
+func (siw *ServerInterfaceWrapper) GetOperationOutput(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOperationOutput(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListPlatforms operation middleware
+func (siw *ServerInterfaceWrapper) ListPlatforms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPlatforms(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreatePlatform operation middleware
+func (siw *ServerInterfaceWrapper) CreatePlatform(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePlatform(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeletePlatform operation middleware
+func (siw *ServerInterfaceWrapper) DeletePlatform(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePlatform(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdatePlatform operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePlatform(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdatePlatform(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRace operation middleware
+func (siw *ServerInterfaceWrapper) GetRace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRace(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// EnterRaceRoom operation middleware
+func (siw *ServerInterfaceWrapper) EnterRaceRoom(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params EnterRaceRoomParams
+
+	// ------------- Optional query parameter "access_token" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "access_token", r.URL.Query(), &params.AccessToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "access_token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnterRaceRoom(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetReadyz operation middleware
+func (siw *ServerInterfaceWrapper) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadyz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListRegions operation middleware
+func (siw *ServerInterfaceWrapper) ListRegions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRegions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateRegion operation middleware
+func (siw *ServerInterfaceWrapper) CreateRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRegion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRegion operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRegion(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateRegion operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "slug", runtime.ParamLocationPath, chi.URLParam(r, "slug"), &slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"games:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateRegion(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListRunComments operation middleware
+func (siw *ServerInterfaceWrapper) ListRunComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListRunCommentsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRunComments(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateRunComment operation middleware
+func (siw *ServerInterfaceWrapper) CreateRunComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRunComment(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRunComment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRunComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "commentId", runtime.ParamLocationPath, chi.URLParam(r, "commentId"), &commentId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "commentId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRunComment(w, r, id, commentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateRunComment operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRunComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "commentId", runtime.ParamLocationPath, chi.URLParam(r, "commentId"), &commentId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "commentId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateRunComment(w, r, id, commentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RejectRun operation middleware
+func (siw *ServerInterfaceWrapper) RejectRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"runs:moderate"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectRun(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// VerifyRun operation middleware
+func (siw *ServerInterfaceWrapper) VerifyRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"runs:moderate"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyRun(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Search operation middleware
+func (siw *ServerInterfaceWrapper) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Search(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "corporate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "corporate", r.URL.Query(), &params.Corporate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "corporate", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "email_domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "email_domain", r.URL.Query(), &params.EmailDomain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email_domain", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", r.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_deleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Prefer" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Prefer")]; found {
+		var Prefer string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Prefer", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Prefer", runtime.ParamLocationHeader, valueList[0], &Prefer)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Prefer", Err: err})
+			return
+		}
+
+		params.Prefer = &Prefer
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// BatchGetUsers operation middleware
+func (siw *ServerInterfaceWrapper) BatchGetUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BatchGetUsersParams
+
+	// ------------- Required query parameter "ids" -------------
+
+	if paramValue := r.URL.Query().Get("ids"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "ids"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "ids", r.URL.Query(), &params.Ids)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ids", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchGetUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListAPIKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAPIKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAPIKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RevokeAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeAPIKey(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UploadAvatar operation middleware
+func (siw *ServerInterfaceWrapper) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadAvatar(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFeedParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFeed(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListNotifications operation middleware
+func (siw *ServerInterfaceWrapper) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListNotificationsParams
+
+	// ------------- Optional query parameter "unread" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unread", r.URL.Query(), &params.Unread)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "unread", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListNotifications(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// MarkAllNotificationsRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkAllNotificationsRead(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetUnreadNotificationCount operation middleware
+func (siw *ServerInterfaceWrapper) GetUnreadNotificationCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUnreadNotificationCount(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// MarkNotificationRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkNotificationRead(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNotificationSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNotificationSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateNotificationSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateNotificationSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetUserStats operation middleware
+func (siw *ServerInterfaceWrapper) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserStats(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserParams
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchUser operation middleware
+func (siw *ServerInterfaceWrapper) PatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchUser(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateUser(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UnfollowUser operation middleware
+func (siw *ServerInterfaceWrapper) UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnfollowUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// FollowUser operation middleware
+func (siw *ServerInterfaceWrapper) FollowUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FollowUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListFollowedGames operation middleware
+func (siw *ServerInterfaceWrapper) ListFollowedGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFollowedGamesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFollowedGames(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListUserFollowers operation middleware
+func (siw *ServerInterfaceWrapper) ListUserFollowers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUserFollowersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserFollowers(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListUserFollowing operation middleware
+func (siw *ServerInterfaceWrapper) ListUserFollowing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUserFollowingParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserFollowing(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListUserPersonalBests operation middleware
+func (siw *ServerInterfaceWrapper) ListUserPersonalBests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserPersonalBests(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetUserProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserProfile(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PurgeUser operation middleware
+func (siw *ServerInterfaceWrapper) PurgeUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RestoreUser operation middleware
+func (siw *ServerInterfaceWrapper) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListUserRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListUserRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUserRunsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_obsolete" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_obsolete", r.URL.Query(), &params.IncludeObsolete)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_obsolete", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "include", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserRuns(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
-
-// ListUsers operation middleware
-func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+// GetVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetVersion(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListUsersParams
-
-	// ------------- Optional query parameter "limit" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVersion(w, r)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
-		return
-	}
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListUsers(w, r, params)
+		siw.Handler.ListWebhooks(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -178,12 +6062,14 @@ func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateUser operation middleware
-func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateUser(w, r)
+		siw.Handler.CreateWebhook(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -193,8 +6079,8 @@ func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteUser operation middleware
-func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -208,8 +6094,10 @@ func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteUser(w, r, id)
+		siw.Handler.DeleteWebhook(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -219,8 +6107,8 @@ func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetUser operation middleware
-func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -234,8 +6122,10 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUser(w, r, id)
+		siw.Handler.GetWebhook(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -245,8 +6135,8 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// UpdateUser operation middleware
-func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -260,8 +6150,37 @@ func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateUser(w, r, id)
+		siw.Handler.ListWebhookDeliveries(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -384,21 +6303,306 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/audit-events", wrapper.ListAuditEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/log-level", wrapper.GetLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/log-level", wrapper.SetLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/import", wrapper.ImportUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/logout", wrapper.Logout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oauth/{provider}/callback", wrapper.OAuthCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oauth/{provider}/start", wrapper.StartOAuth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.RefreshToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/register", wrapper.Register)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/verify-email", wrapper.VerifyEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/docs", wrapper.GetDocs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events/stream", wrapper.StreamEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games", wrapper.ListGames)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games", wrapper.CreateGame)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/suggest", wrapper.SuggestGames)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/games/{slug}", wrapper.DeleteGame)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}", wrapper.GetGame)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/games/{slug}", wrapper.UpdateGame)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/categories", wrapper.ListCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games/{slug}/categories", wrapper.CreateCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/categories/{category}/leaderboard", wrapper.GetLeaderboard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/categories/{category}/races", wrapper.ListRaces)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games/{slug}/categories/{category}/races", wrapper.CreateRace)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/categories/{category}/records/history", wrapper.GetRecordHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/categories/{category}/runs", wrapper.ListCategoryRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games/{slug}/categories/{category}/runs", wrapper.SubmitRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/games/{slug}/follow", wrapper.UnfollowGame)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games/{slug}/follow", wrapper.FollowGame)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/followers", wrapper.ListGameFollowers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/levels", wrapper.ListLevels)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games/{slug}/levels", wrapper.CreateLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/levels/{level}/leaderboard", wrapper.GetLevelLeaderboard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/moderation/queue", wrapper.GetModerationQueue)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/moderators", wrapper.ListGameModerators)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games/{slug}/moderators", wrapper.AddGameModerator)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/games/{slug}/moderators/{userId}", wrapper.RemoveGameModerator)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/games/{slug}/variables", wrapper.ListVariables)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/games/{slug}/variables", wrapper.CreateVariable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/graphql", wrapper.QueryGraphQL)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/graphql", wrapper.PostGraphQL)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/healthz", wrapper.GetHealthz)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/livez", wrapper.GetLivez)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/metrics", wrapper.GetMetrics)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/openapi.json", wrapper.GetOpenAPISpec)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/operations/{id}", wrapper.GetOperation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/operations/{id}/output", wrapper.GetOperationOutput)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/platforms", wrapper.ListPlatforms)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/platforms", wrapper.CreatePlatform)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/platforms/{slug}", wrapper.DeletePlatform)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/platforms/{slug}", wrapper.UpdatePlatform)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/races/{id}", wrapper.GetRace)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/races/{id}/live", wrapper.EnterRaceRoom)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/readyz", wrapper.GetReadyz)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/regions", wrapper.ListRegions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/regions", wrapper.CreateRegion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/regions/{slug}", wrapper.DeleteRegion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/regions/{slug}", wrapper.UpdateRegion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/runs/{id}/comments", wrapper.ListRunComments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/runs/{id}/comments", wrapper.CreateRunComment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/runs/{id}/comments/{commentId}", wrapper.DeleteRunComment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/runs/{id}/comments/{commentId}", wrapper.UpdateRunComment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/runs/{id}/reject", wrapper.RejectRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/runs/{id}/verify", wrapper.VerifyRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/search", wrapper.Search)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users", wrapper.ListUsers)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/users", wrapper.CreateUser)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/batch", wrapper.BatchGetUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/api-keys", wrapper.ListAPIKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/api-keys", wrapper.CreateAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/me/api-keys/{id}", wrapper.RevokeAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/me/avatar", wrapper.UploadAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/feed", wrapper.GetFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/notifications", wrapper.ListNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/notifications/read", wrapper.MarkAllNotificationsRead)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/notifications/unread-count", wrapper.GetUnreadNotificationCount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/notifications/{id}/read", wrapper.MarkNotificationRead)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/settings/notifications", wrapper.GetNotificationSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/me/settings/notifications", wrapper.UpdateNotificationSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/stats", wrapper.GetUserStats)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/users/{id}", wrapper.DeleteUser)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{id}", wrapper.GetUser)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/users/{id}", wrapper.PatchUser)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/users/{id}", wrapper.UpdateUser)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}/follow", wrapper.UnfollowUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/follow", wrapper.FollowUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/followed-games", wrapper.ListFollowedGames)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/followers", wrapper.ListUserFollowers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/following", wrapper.ListUserFollowing)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/personal-bests", wrapper.ListUserPersonalBests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/profile", wrapper.GetUserProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}/purge", wrapper.PurgeUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/restore", wrapper.RestoreUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/runs", wrapper.ListUserRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/version", wrapper.GetVersion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks", wrapper.ListWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/webhooks/{id}", wrapper.DeleteWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/{id}", wrapper.GetWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/{id}/deliveries", wrapper.ListWebhookDeliveries)
+	})
 
 	return r
 }
@@ -406,26 +6610,459 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RYUU/jOBD+K9bcSfcSaMqCtJen2zu4FSeWPQF9uV2ETDJpzSa2sceFCvW/n2ynbdoE",
-	"2JXYLtK+pcl4ZjzzfZ/HfYBc1VpJlGQhewCbT7Dm4fEvg5xwZNGc4a1DS/6lNkqjIYHBBGsuKv9QoM2N",
-	"0CSUhAz8mt8sC18ZLwqD1kICeM9rXSFkcKMmcrdQ+EfzajdXNSRQKlNzgqzxmwDNtDe3ZIQcwzwByWt8",
-	"NF7pqooFi3asf9REskPlX9b8/gTlmCaQ7R0cJFALufg97ASbJ2Dw1gmDBWSfYOE3ZHa5tFbXN5iTT+3I",
-	"GGW6JcpV0ZNxMGbhWzvX0fnR2dXpx4urvz+OTg/7ClCjtXz8qMfF5zWnFg2TilipnCzguY0uXPTtcaSL",
-	"nxwS3ZJY7Ot64E5xxamb2oWo0RKvNbuboGQ0QeZ8i+64Zc26tWz30r39nXS4Mzy4GKbZmzRL0//alfEt",
-	"2SER9tipztbbIYqeaFLcumaXokBJohRo2vFWtRaScIxmy51NwAVof3vHKm6JNYtfqm0bjBTe8Zr+JG2A",
-	"reXeZa33JmSpohhJ4jm1eArWaa0MbfQ5lh7e/XvMzqOBr9F6Vd6xAmvFzo7OL5g3LJUJpfkM5xqxYGdO",
-	"SiHHSwP7GRjx6ovfr6CVNH3gko+xRkneChKYorExxHA33U19ZKVRci0ggzfhVQKa0yRQbeBbEZ7G2NO7",
-	"MyQjcIqMM83HQvpCsUpYYqpkvKpYXB5CGO4XHReQwYmwNGq+aG54jRSifNr0/4Hfi9rVTLr6Go13Ghwy",
-	"UswgOSPBlx8yuHVoZqvSVqIWvnfxwI15l9xVBNkwDej1bv2PNGC3+dXlyTzZTOm0m4r9IvQjiaiytPhI",
-	"Ju3QaU/oSw9Uq5W0Ufb20nQBM5ShGVzrSuShroMb69N7aAVaF81YkuyhRwqaJHu/kSLeI3EX/vVmW6BP",
-	"Z5YAEoR1ePjVYAkZ/DJYDUeDZjIaBMFfnQTcGD7rOxrmHcKcuzxHa0tXsUXVvKODbyzaU8nFIaQn9rEk",
-	"NJJXzKKZomHYGCZgXV1zM2sw36LEPAGtbA+l4mDIOJN41wihoEngvjZqKgosmFccL3R+xSa3VoMlRKFD",
-	"S3+qYvZiVehOrvN1TSXjcN7B7vDFEogY6XYh6F0j3cwu4VDNfLH3t4ODKa9EwcyiMD7u798/7qgFE7Ec",
-	"PCqDvJgxvBeW7KviQgfj4Xs8awYPophHWlRIPSPKYXjPeCTH9cxTQxh2fNihQrRsqPDkORMKGDwEEffH",
-	"30rDw4SwDu+2nj95fHQ1fL9/6GJxu33A3d8SgFa3mNeElabdrjkYnhtDrMZclCJ/Hh3vkV4ZNNLvLpGP",
-	"HJI/O8beIy3xcnwYzmbXA7N4QWdcRk3147eL96WnDuTVtf7HQu3lJ4HuHxZfNQmk25kEmnvbK5oEfgjJ",
-	"tjKAHK1NHEJ6Xngycalo4nvRqPerYXxDZbcEULTtY+WJynnFCpxipXS4SEdbSMAZf8mfEOlsMKi83URZ",
-	"yt6mb1OYX87/DwAA//+MTz+u/xUAAA==",
+	"H4sIAAAAAAAC/+z9i3MbOZInjv8rOP4uwj1zRYqSZXdbjos7te3u8axfI8nTezvqnxZkgSRGRYADoCRz",
+	"Ovy/fyMzARSKrCIpWW9rN2LaYlXhmZlI5OOTf3SGejrTSihnO3t/dCaC58LgP1/x4US80soZXcDfubBD",
+	"I2dOatXZ6/xFn7NCqzEbGH1uhbGMq5y9ev3BsimfMyNKK5ibCGaEnWllRY+9dUxaNisHhRyykTaMK63m",
+	"U11aZsS/SmEdNTIz8ow7ga/EB+fSTdjQiFwoJ3lhM3zVTrRxwuCrBQ59oLnJLXMTrvDXMZ8KNhWO59zx",
+	"Xifr2OFETDlMSHzh01khOnsdGlPGpvxLl4/F/37a73eyjpvP4KF1Rqpx5+vXrPMrn4o3R3y8vBy/TYTC",
+	"6WJ/59yyglvHylnOncgzxi3j7FzwUwbfv2RWqJxJx6Rib0fdD1qJ7nvuhhPmNBsIxpU9F0bk7Gl/l33Q",
+	"jr3XuRxJkbPziSxE1ZO0rFTDCVdjkbdM7ret4872j/1nT7d3n/f9/x13Wuf3jlsXervoPBfnFdrpHko1",
+	"FNcwt/daZWz7GfsrV2ynv7PLtvt7T/t7/T779f1R4xTfVVTSvJP7bMLthOkRDiQhKjbjY3EtOwkNb7qT",
+	"L0Y/Pc/7P23/9NPu8Mf8+bMXfGckOO8Pnz3jeX/7WcvOfrbCNM/3aCJYaYV5YtmwNEYox86EsVIrP1fr",
+	"DPB5fbYDPjwNm4yzPQfCQDKQasycbzRj2nzLykAbm6zMcedp48S/htdRou1/evsfYg7/mhk9E8ZJgb8P",
+	"jQDqPeEO/hppM4V/dYCku05OxXLDWUd8mUkjrP+mhUlOxZxZp2eWnWtzKtX4JeMDC0sMoulUzFFQOabE",
+	"mTCMmuxkG45A5rU12I6vSOXEWBh451TMl4d35EcmnRXF6CXTqpizmRE4MKlqUpvG5xeISdc0EJAAJ6WN",
+	"C1jv7UCX40kxJwIJi1IJDgvS0Wl8MpWqdJsvgOJTUSeDg1IxWw6m0gL9soFuHO/MiJH8sjxSEA1AvMMJ",
+	"N3zohLFBCpyKOQ1SFAVtG59xA41XfVtzevJ09LfTF/y/tpt6tUM9I3KTTkzxH//TiFFnr/P/26rO4C1P",
+	"rltEq4fwUedrbI4bw+fI0HAwSiPyzt4/OjLv+NWIk4v9ZSl1/x4b0oN/iqGDltOOloWhYsAoHP5kY6PL",
+	"GeOK7X96i7sIB/2QF0Un6whVTmEoplR279xI3Eb8Y6pzaAD+Bqkenv7esET7eQ4n0Hv6QpsDOvyXGbYQ",
+	"Z6JYt4KxmXf49tesA9LkRDacam9fh52GV2CneZ6nu/t0mbkW9iC0nfnBNS51mUv3CgVZs0KllWAjKYqc",
+	"eXHXYwMx0gZPh0Ry8MiRQjnp5qgL8VHQhDjLRSHgsVai18kWVg9fbBYL2PkTy854UQrfIiwLDQfmQOPZ",
+	"5Gs/8vTzr22L8gancTRvpEF2KlUOG+Qnez7RNrRpGTeCcWhD5AkdwnZ4igMW4E6MtZkTTXayDp/JE5CN",
+	"WedcDCZan3ayzhk3kg8KEbcw68wK7kAWwXdiDMPBBk6GejoVysFffNhCyzitM6EayJcPaWrL5wZ3KBpz",
+	"rUjTgMXzs4YeaJ/hfB7UZA/Mtkc6WJPo4UOnmwk/HP6wpmzK83S7amdVWG18J+rtxTxjthxOYKiwQNaR",
+	"qEgHt93vN51MvkFcjjyX8BUvPtWWaaV4TFjpa9ZGi/549cyUscGcgZjssUMxNMLZOPgg0UD1E3QL8YTB",
+	"rH8V6IzOaamGRZmLvJdO8494HHn26vxVTxQ7nEo36VRsQ7++1k3MkHW+dMe663/8p9Wqd8DP3wtr+Vik",
+	"T7tyOtOGCIu7SWevI9RQw9m1BV9h03WdpiIV0JK7/e3u9rOjoCv/18YnLpHiGhEa6DVZ+Ro9NFGDb9h5",
+	"AbB25xN5saEm5C+Sa8bu36LBL/ADXA/dcAIaQtVY0JesMGeoQxd6bBs18KUD20uB+uTTNa6YZO0h/jOM",
+	"7FfhQM23B153WxY8qBip8YnMbYOiRpMSOXv72jNOLnOmtKOJM67mzMvVuNb/2M1+/D2rVJrlha9rLnQI",
+	"N/SOI6de4VbARrpUeRaWV5ucTiJpcHT4igkD7mSb6VTQx1plisaX1daqaclfhTNlzXViQTTJqbCOT2eV",
+	"PhwOJ5T8/ttOdlUsCyfgGqKHV+ojGQgw7Vjm9FrObWr6s5L/KpPmJBptRlKY9c3Zk1yMeFk0X6vcBMlA",
+	"WiZtHPsTy/w3LDnoYz/OlCJ2NdC6EFyl14d6J6+lnRWczomwQE2tdrZ3+uzQcdN4w9BWNh/xoXkiaDBq",
+	"ycqekrFCnwvr2EgaW7tdNB6hpixEEx/Dz4wzUyo2LaE1XRT6nDnNhroMVzxpm6f1SheFGDrGi4LBFK3j",
+	"xvbYkZyC4BMqt0zTiEdS8YL9jNY/NpGu13jrKcoGm8Png3ddy0cioYyMlUQ1C2uyuOZd27LmDkd4MhVu",
+	"ovN1koCm857ebZTOgW/8FOL9ihY92eIazS4OY63gfoWP6Q7Wet25rK1BT2W4L8DTJVODvfBVe1EzL/hA",
+	"FNhFvCaL3riHfw20Q7uYheed7MLX9G+7ME+lekufba8R+H5jfXftmxQEfus2Lcou/88RL6xYVFHf81NB",
+	"XLhail2n2JryL++EGoMCufPsGS5Z+Hv76oTayzAti/fqeKUUX6RFW6EfphQ2HWgfxyOn5fT+SL9kQbfB",
+	"yv4t8hA2EU4DM+RWsEI4J4zNWC7H0nnnx2Q+mwhl2yRkfTRZZ8ahDeju//8P3v13v/vi9//1Qzf+809/",
+	"/p/XK1ZTOdrOZWAAauWwzWl/6eg4LGfCsPfcSM2e716c+q9h414yW47HwqKMNAKvEHXNz8Kgu1MYdPf5",
+	"7hXt6aW2BW1oV7AvwbJSzfFnPejq6YD9zJ0rBF7b745swuFeTC5dN4cPaL26g7b1ulnC+ORNZFdAG4m1",
+	"rZruB1hald8Zpq0NTt0uUx6gTfIKVj4aN6up/fXTB7bFPhwdvrp7y/7PmbrNZQczQuuiiymXRbN544ll",
+	"+BScC0bYhTnpierlWvxf/1NvqKepek7tbqya+/5GZVEwtXgWRhvkBXe2WXGmkbUv19+9Xb11yYaJJaU+",
+	"i8OiHAcaRS9leBV/CQZ7xmezQgqQ4f7OA8J8NivmjP4NV57k2zYVgat5dybMkCz7Gy50EzslnoSq9ddy",
+	"NJLDsnDzu8dQecvYvkFvRB+QbfY40LOgpHOwN4Hwn4PrX7oJWv3y9NxODXwLnkC4gLbvCj6utqUo63vy",
+	"F27ya9yNJptGI21MlsZxxRKNlqmJR6f8S7gn9/sXuTbXzSJ+u9ulwG/kTmmXm2chBG2j275vjhxsq6/7",
+	"Wac0DSSyP7C6KJ1gE+dmTBv8r2WfD96xXBTyTBjpvYszjVbxujG0g6/vbW0l8noLhmS37EyInPyMUXyX",
+	"Rq7dKxhmFhaiaSXfGKPNa+H8CVNfQNnoUBTeAyvVGS9k7l2zYGm2JOaCwxQdSVnnX6XAKzHFAXayzlDr",
+	"Uyk6WWeg8zmMqlqB8O4Sg0y9y6rZwSktO8eIIuCKFsZU5XQgDF3gB4JxxwrBrWPbmwvmIwysMnwqHB6F",
+	"Cg2s3lKVawdbCpMO0gHmR15DOkTgORi2ZkYPCjG11XDxTQoDm+gFTi7kVLq1Oy1Vp1qlpp3+RYgc6Lkx",
+	"IgMpBGQkD4Fb4Eo6k27ORgJvkEvu5hXuX1MqRQ5gi3/gFH0XEHSgS1fzBCtx3mKy2Wm6DlHnzVv0IVVB",
+	"cRgresLDK8Q10Nu2rtVwJbxWs0QiOXe8efo4U7C9ccdhU73b1zIjhkKeCSbdXhggjsqUqgfCYSQxcsk/",
+	"gcHBv2dGnEmKaB1qQxRE/+wNjD4VKouvRn0E3gl/9CoH0DU6h0WIS2hgzwmfzYQS+V593OSv4ixMHWc9",
+	"EMDPrjKmPbGLK5DVFiy0Mg2xOVV7qA3Q6iwuRviq5injeY5CmXHU5Hps31Mvd2xgBD9FBYN2AabEjYX9",
+	"HWg3qQ8JeqxNtVePZ4pvdrJO7b0ksCRuW01ALr69uXPuKHXL1ZkxpfndJo7DRq/SUtY87AvppI0dLVm3",
+	"WkIcG52LIOmYdGLa4l182uhe1EOMcc1Xu1JowQMjNPhin3b720fbO3v9i/him7xM2FOnPq7U91StdLqv",
+	"Xp41Hhxo7RYYR9fksEGionPDW8bt0nkx8m2sXiRswjpu6KCET0Lk79JOf8OqrSQCnE3z/jeyxrVyxfUz",
+	"RBMF1dXwdOtWkQdGQjSQB24qahbUEiukdVWMV1Q4fD9mmXj4GXfcnDSq3KBbJ3GWoLjg2/HUP68R14Rb",
+	"jD4pZ4XmOUUzrtWpsw3J18/PE/CNUCstbiO17mxOrastOyt0IEpyaTxuPs54wwDpLJWWIf06zeyEG8HE",
+	"FyeM4kVRd531Bz+Nng+fiu4O393u7uY/Drovhs+edZ+OtsVPfCd/PnjRr+1eKfPNSLwa+MZ0HsRfnTZP",
+	"CqlO114t3+FLSyF0a+N4YkbMlcfwXE4Cbl+3BLxjRqxrcZZlHZ/adGEqSPOirooU1h8ACc3Wht7GJL+g",
+	"/6jhJKAwdD3y6jVGI0MfMeIRvmbEM4m+fKkhVYtdC/+/WGRdXTH5p5ZK5Glwhb9ySK2YE3x6ddx5fckI",
+	"8Za0JhXBN7Y5bzc2vOLwaM11qPqtgubXRDzBFh+S31tq1WDShf1qMRx7f7nIcVNtxgbChujUEDO3kfkQ",
+	"BrE2EpQG0jgHw2eTv71De1xTuJYTyjZPbqjzFluVgMYYPMctOnhzeIRh6aUVNkZYWT71b9Z27u2Hv++/",
+	"e/v65NXng8OPB437t2z4rYx0SUPeTDgsjdXNoY1oU2g2tlXGNJIeqD+MuCzqEvAfC2kZaEbqAwHFXMtO",
+	"ElO8bptW2dH8RrVanWNy04dWG2J8hTmNFoYo6tH+ySYa8gysOBOGNzro8LXmtv3wWLCkLthWf/ifIEn3",
+	"2CG29T/+xP5Auv+BfsWH8BtydrWW4ZdkOX9A5thjT/F1neNLhqtTBkLtvSWZ6b/7Wv1/s2OHHBgrUjco",
+	"4nZ5ujgpzA8KTdQSKEhB6Njp893OMsUu7Dot2co9b4uCD8bAyw3eCFsW7mUtwYRoHAlEWF2cCcoWKYui",
+	"0zBA5N/NXR01YdPEDEsd/EXwwk0OHXcNNK1PiYIn+NI8w8HDzT0Y2SZieMqMcKVRaB3zkgkkkJyK/Fjp",
+	"0mUsN1wq+EyroWB2Urpcnyu8sw3EuFTHKtEKML/K99PJOuHburUMX1oit2ouZZM4hcFeOokoXaelJKKP",
+	"pRtqOjIFH06YwexQYS2tUAbRriKHlCL8e+kuRnQ24DbObSrHJEks/dK0dTZOdOOBL/rlqIUmzkhz35Vr",
+	"Sl2IIThLZBPifKLFG3Rc77TVqiEqZvnkwJdPGoO0+Lyh3WYVbXdRMWvqC2Rbwxx8lFiI4Uwk5EvmJHAw",
+	"KPT+nstRQq69UUE+4JqkInRWPLGkrZBjsGqz8eoP4ziZNoa3KpaX/kCSik1lUUgrhlrlFmgxdSw8sYyi",
+	"N1mMRo/dPvtp9ylGqMallMql+7YwmLUkeVAqvA1tqNrSmqxd3BV67bLzqC3GZfkIk7nQzSYquPyTU4GM",
+	"9yjhYieNDuDz8/PeXJeuHJAT+BzU0f9z9r//Ovrxl9Ofd7585n+7qCfYE54nraxF4Q5EEnYonVgtd7Xi",
+	"vGap4O8v35TFhNr/7acw0TCuJn+J2roW48qmYbC3lFl0tVk7zRGrG5g22nJwktybNZdNYOdl0p6gHFs6",
+	"HZL7CxgKRY7aXGmGAqbpExADOAojoIVa1mgIq8DVqC3B1tn2FiYWbm2vnTqOrm0yl9Z14ONlJQebDPAb",
+	"YbYeGARNPvQP5HLpULvjzOjSISVLZ5k+Dy7teiOWGVFwipth0iXqEv4uteqxT3wsLNmYwM/AeGE1Ln1o",
+	"TIkvroLdgb9qfUVXc0iXhx+yKJMINsFDICR6mSlVQgbp3mzhI2AAUYyaX2nWuN/pcRSjC4k+FCxOF0Tp",
+	"5mTcHMM0hb89cCPYuZHOiVRnzsUAaV6qke5knXNu8CneApZMZ+D/hw+7Z9wosp/8Iw7qtW8p/P2WWgx/",
+	"/kYthz/pnvF7MqlD4Rx0cjkYjrg0i3TejpPxTo+lWh9hewXBszNu7bk29fzxzlAbI4aOTbSxgg3QbDxn",
+	"1vFZUXNAxa/XsXToP37QNOv30UD5t1KUokVD12fC5KVYlR1LejTw6jmXwIFwGOITHsB9zqQ4Z4fv9lPG",
+	"8HlqyxlnoPis1/7gTegPsoW8QroCNY6PdU3jx3RAt6A2vHj6fCMVdVF7QkVtcSxZXLoVix/ss8uRyOj8",
+	"iHZTArkTuXSV+gGCacoVYnk5GwJbjH1Z/RO/wkBmvwXwIsqciu/RjXGSWmjD5/Xrcvy1gaw/iHNwE76C",
+	"hLfGoFnrTnZ2J21Z+BHuyat23Dq2s8smujR28e6ywf0Bu3vazy/S3dM+y/nc1iM6+pt39+OFevtxqbOf",
+	"nl2c7uKyVmNIJt9EdR80qFFD3qzR7TOVPIeDw2q4yVGYRRKqBeehVmLBt+7p6hIelOuJf7m2KDzkPPjB",
+	"CFjYEJbnQXrwdf/vEKZVBeFdKHbvTgTlpRPXpjbv5eg6EjMibyGPbHFhqAUgM/jAP8TPvY61GCsXuozR",
+	"gDHmrgrjEEW+KqQumQDGG9cGtBRzVw+wqzd1Ecd5jbVa7ng/NgPJ8Hz16RuDV+BV/CXtbLMjV/BNvJx4",
+	"m2vuJompkYVgpcJxf3ukGvKwX4W1169Uvn0yYiSMUMNG3UUOJwizo0QRwowQ4kMXOYU9IgWTYHITA5iC",
+	"S7KtJfVqaWukz8MSoeko8zaBDWlh0yjF8CIiFfuwsOdXT/3LLy8zgDrhs9nqNanCS0NEbSIlUpKymyxP",
+	"kNwb9ulTLajf8C1og/DlINweN+CZRY07bKfXvP1CJONbR6/+1mMvR63oMlhNrrPIEJv7gloYap2LNO2q",
+	"ad4f90s3+WQ02A8b4gLf+IgvxoeEoTALr1Z07c6lG8Ikc2mH9StORY4fgy+1EUtEq3EXLLmo33B7ysIf",
+	"XlUDzFnAoFTLKQ7fFNl1VeBoIRpgkVrmjAcPYeVMXnaLd/bzqVSwxsJaFrevoaeRVNJO1mlxVV+2HA6F",
+	"yElhaOh4adq7O/91WRjazvZgV+z8NODdnRHf7m5v5zvdn356Our2+9vP86fDId/d+XF9HGDWAdDHdRsa",
+	"6ek/4GWEdtVjTK3d9MNP4QPkGFsWLaJdV95Anq5naGePcQb3i7eo5x0I+F9UINFs1CP1r1I7fX6THtFz",
+	"Jr7Acw8UDoDqWfIxPcx8is4IjnOQnPpc+bjYkdHkl9Olm5UOTWjemWhWKvscsYKiIjGTw1MfNFURTzlb",
+	"Y8nf3pxWNvNuxs3xXt9GfQTJI7aY7PxapaRONM36Nk9xb3Mt7AK0aNhR75EJe1Q/nxdebJeGnxKqXQwX",
+	"grZcExI7pjsiUiizmo24SaA49bll1C/xfJ3IaoHHzxodAE47XjRzQkW6eGJhtHhRVDlzpG8i8JN64tip",
+	"0ueLeKD9tVfpat4rd7AKC6iPcyYUeu5K5WRRUTnQt10ib7wtqnjY0Dfe2N0gOXusBopMTOk7IPwtaVkY",
+	"AB9zqcIwlHaTOArpWDlLr0X+E9IJFf0rdu/DJ5YSjOKbS8T1SRgLHoKfV6bcnyxI7+eNUK3h5WXA7bdq",
+	"AOexbRpB/Cx4k6rP5IrPclE43uj7fl/zdYuJ9ML0XJsiXEtfsn7lk4RLqUfYoqcpHT7f1P2duB9XB2HW",
+	"Eq+qyX7SxjVHhtXyqKoPZq0fXEl4xqdX1xWdsdPd+XHxLLh8dEYSxnDRQI2d5os7kMBJa2jF6xBWsQCf",
+	"9sTWKGwx6qKWdPTsx02pan3YSGlrQSNBC27CLdt+emVBJLXpPN84RuTehVSsz7FLZeSiNFsUimkwxgKd",
+	"JRLtkgEanxKu/6YYjdDjjWepxI6vJZhiA9yob0hGATspw/tzdDuH2Qzm6+PeLpSy0bj7hD/QiARw8Msr",
+	"9mL32Y8BpIDlCA5hGX2eUTQCt4SQQ2aDLf/u/wLDM94yCB6HojxD3Y9OtlHg+psYtF5bic+Hbw5OPnw8",
+	"Ovnl4+cPr5tPeddirEMAaOXqGBEyloiq92OFwRTBkbcKNF/K7Qqo/aQbtNnMuHF0y6thgdeRsHFAdiaG",
+	"0RiTURMhEP/gzd8+vzk8QryJqu0KYqIWNowh902tvP3w6fPRpikNKThIA+y2VNbxRuPrYux+bd4N1gIf",
+	"CbG7syCCu5U9auXVb7n3QHRPLPvL0dEnRu8ukdVuf7f5mHNFw7QOJ9o4ZsvplFeYT6GShOcBchRRdxja",
+	"zYEnZhPDrahLE+3YL2005hrLVrwNIsQ29fwS7dkzYZK8j/oq+xctLndXadcNRL7poi+IHnwaliu5OXtW",
+	"TEgkI25vkkYHfCjWXifaNJoEvYsSSw0fblAcYCOfJTSFDK3bnJZ0rL3Ye/bThY610PtgvrZuDBTR0N4j",
+	"64dU3YsxTp4Q7IMZlezfwm2wBpuZ/HARwqsoe+Cghz0tVtj8dvp7288vYfNrPOpxCC2QDE0TA3krh3LG",
+	"faRCG0CNjZWp+FBkYZLGshG3LkY2+rs8nmjAYKKwYqmGwNxnB24qWYHmP1XDbJKuaGlrBuh+Q3xPHulS",
+	"YZoE2PLkcOJn4S8z3Ag2FdyWxpv0oNqaI7qhIzpZK+QewfP5ik3t9/e2+5cxztUnABTtrTphK2D9vC+Y",
+	"53O0oQTzSbRF4pIszdzbQuIGgZElJdiRNiMhgw9fxWcvKzJmkiIPkTEROod+hc5GsV6SS1P7QxrKTKia",
+	"fSW0jvq8b35DC0uTXle/MUQBW0uArdF7m4RNqW1J2G4oCnCrkvULcog2ANkg11cnEuLGbTSsQoxc/BvI",
+	"hsS3dBNwntGg4WfpVoyw/+xCgpyYfqXP58KHwwUgVpdTFIYTIWxjs8TZ60L8FHkd8WWEZ3ALNenafLQb",
+	"pawElHMQULUIPcbtNySw/FKJvKX8FZQdlShNIwFf7Gxqibiq5JPWnOdQCMJL34qsGrkZjQF/kdZpM3/o",
+	"SVfryQpXo2vJtb5ZKpQVbq1IYQ4qdVU9ZEz2RC+GrcPskpiLRt7vv7ioveObzWsXycF6TKn6VvvfprlU",
+	"a010kSSb+X1khJ0cQbROawS7oZdOHLzVQD/0mOHjSqHCSNUCQuMx7o9eWj/vWl/NQx77iIxvMi4ShviN",
+	"mxZ9t9diWFwHi36lVkU/kcG8Aer8qm2KB1ir8TtFMa9nfdQ7/Fm4cyEU+yktAgwXnR932GDu6ogFl8kT",
+	"SUb204Xw1dckjxxgDOFBuUrogHGrOT4pcZYOBJ7MVUhiNV1geg/5Z+HEZUWjIrAkgLDfxkGX6ttBuhLw",
+	"+FUfxHJ9X7NLmKuWtC+pvsls1SQ/V2XCfHsC7aaIOKtEbXkhOYv5XWuWWKpcnsm85IXPtk0oUY/qQMwc",
+	"5UAXJrOoODQqjhoAvoUTG92jMNhDqqFgE54zTlYlFNMVQm0o9ZnArVDlZZ/7HPCh8YVYnICQ0Xvsox8O",
+	"iX5uBF2CMbR5hB0VVHTGslIVwtpQZ/YkTAQWxQrX2yiCvP1SkRZVmN0zVIfhukRweKXWaTQuSVUnJ9yG",
+	"qt6o0o4+pihAu9ZwaaLa1L689E6z/EgjpoSi/lE/Fvl6DQC6BykqtTpZJdjruSA+S6RJ895QtmedkFGy",
+	"iVhrxHleN5JIEs/hTnbBDCN/a1mfraj85bLF5lnlYsKaOPGSsvZS8GvrUaWZVml8im0M6apBSNPkG7P5",
+	"8iu7aK4DAwkm6ETgQogEpmjXw/V2n2KQ4C0BhSC/kGulskA10E3jEbQBVtUfTb7ploIlDXxM9Up4qHkP",
+	"WritUt3jIQC/99hbXzgc9qom8LmKp0vwhdKNuaqSuZDDnlRs8eVDmtLSV9zKX3GllRzCwXt19/P8b+e7",
+	"5y9+G//n8ML384W7ed2UfgmkkwWzezTGR62gRSF95Uv7L+mlWP9iWeKJL4mTgz6tFZSE85iQ7YdajQ13",
+	"sYLkp5//xyp/40qbl++KKFHbm7psJxfYgEjYMOlmO97mRucwOQRvWG/PmuUXWy+0o4hcrlm0nQst2gaS",
+	"zI8BUK9LN1kL6tnEHw1GLCTLi+G+VlTeelm8JmJP7+l9X25o43JjoQBN03y8ZG1KK4qoJgkeCa9KRdTk",
+	"8JArJqYDktDS1dP2yByahF+O62XiKmKIR1zTcEr1xFan9mBOoUG1sznYrIHY6IiB6hbVWcCUELmtquLA",
+	"AsO70NpCNY5au72lqC45do2axVtF96wmNw3hwkzAmet1jEXEn+3+zvZPjYbtWDqz+cZomkdzIHjROBS6",
+	"c0EWDEzSDo0QaFab6oVKqtv9p/3dS4zIOH6xEWWVvZZyaqSalS7Y+ED0bKZcrRrW12YeUE2pe0dtKpQv",
+	"t4LUngeCT7O5PVNgYqg9Jb7xN9K2gj2hOg8GiN3BygCXw+bfvips/hXuk4eDzd8koA8FN8PJQUyvawke",
+	"a7aeh/s096IzvP6SzYyIpBdAVJoKYV+kfk16ex+HimFJ71Q1OKH5QOtVz4hfuyH11QPIsqrLBc27GYmg",
+	"MY0CIHbORVGE20RZ+KhVf29CHNuXbCLHE4ofGAjnhOmxAw5YYEBFVGpTT2fc4O3Fg7thqhluZe0y0u/1",
+	"n/d/fLHzY0JAo0LzJOeNcsbwwq3kbCaawqS+DIWZuXStK3BvLBNPCkdW2dnCrMAojtNA0/2f/8ym3Jza",
+	"NfQBDfz5zz+/++uf/8yk8vJ5wK2ATpjlZ8IyJxSr/LYN9/LGwNOjSohpE9DnY/W5lJBqxa5W1FpgXZaU",
+	"7W+NP111605Z8Ajeb4kO9dctChFFAlvH0EeN0a+URenJJZChtC/xaKE/idZgY2hL+dJeLyRdBpUL9eBG",
+	"xSsdV0M25QokbnzkzYGg3mBDZCnMmDNyOl24LQyKfzabx2Lfy51UBE2wdxTV5+OtgT3nM5ERZ1pH+NwX",
+	"QrVPp78WEyBgjocRN+4zqgurvEpFC1zWghh9Yr2RvMG+v864vxl+5CYW7wQEJ7xsKQQcnmxqAl8sK7LO",
+	"2XhRA/lLRAkD9WYQY1dHpSuN+BbT+RprdbI09OrqhQnmayZHF7Zct5szBS/wgs1+ODja/1OrafMl3D6M",
+	"m4D0HHlYctsjVT2YNbFyKEaQDkQwtH+75n2tJk6vm4ciavfQvvkG5Vn8EfeA6nD787XS3eLIKwjM6A6b",
+	"8DPBlKYur9ruWUUj/T9dHpUDwfBlOJqPEMKE/f1japHKmHXaYNSjv1undtOXdDGhJgiMHuciFEyOFC56",
+	"iIshviDMqcqBKkl1vin7amUxulQO4hHaEd4TjnijzslTs0P0cZIJA2ZM3oWXzMSr8w/G8T9lTKa2hh/k",
+	"2P2JNOD43qpLPvuhMO5PPfZajDjqFE4z4zhtCxrF4KNqbDUgMsc7WUeOXQdNDwuB3/hwiXd8YFdbaQkC",
+	"b2kL7DqUYyVy9tffjvDGJlRORfUGghsMBGopEyq+zKQR9qSpxPOht4TEsO4AIIOtMf9pzdb4vN9vdmiu",
+	"jEo7lGpciG5phW8aRO+nj4dHbAvsmVutAWlZB98/aU6T2i/O+dyy487PuAjHnToqNv64lrhry17rr7Z4",
+	"2QbRcJ/RdgrREK06z8OqofYyVFWiWHIrzILt7HoKq31tXfsQ9HzB9f8gzll+uezgC21CU5BWOyFRcOUV",
+	"TWVdPOI1zgMsW+3eAqnb8i+T3+qWDu7YVENF936/nwTbIbqKmM7cnNFA2bDA0s2yHvfSOfRF9jF7JNlO",
+	"5LkQy7PT337Wa3SyQT6SmZ80ZzW/PfzInm4/f97dZryYTXh3h/kPME8TjiwmJIYQAXttPuY3TWN54LGW",
+	"RivtAdcXkhv8k8qsO9YC4hYq4ti9FGnYidiaNAMjE0rdCVwdGk03+BgHQwabXTiod541C1CsCWCH2gj7",
+	"knEMAoFR/V/M6zR6NhP5xmNG6XpiPVHbtrE7YVYO3gmTjD7y2I1NoG3sXpltHXvQxel5xp7Cuj/tLw87",
+	"GXIWTjCazEwYqfNvnwgezoftG9F4cPlyyt8UyXoZ/0jGOLP/KrkR7NOHXy/mLVm+dgxz1UuEyRb1Ybc2",
+	"cR9svRj99Dzv/7T900+7wx/z5896MzVOxVHTJQUQkjWfyS6I1bFQXfHFGd51nGA3v0yLzl6yLhlc5HEP",
+	"cRs2PHaq9GyjnaggX6dWFGfC1hcN1skKd1VHzUYTHEi9MLNLHE+5qOXamnk1b7yMUULx2qm+udwU0gEv",
+	"zuViYdC0U1eeRLLZLKqhfiUkMnGZkVs9cl3/sQ8cDK4IrepO0mCuQvuHRCMw3nZD6G8DPEDE2upvH/V/",
+	"2utf9SJUs17YyAvoKZmPD9Cqsv3g8ni2G6HE5vlUqqvQaTaaF30aJ3ISIi4vtL3hIw/kUJt05K5ahnWP",
+	"fVbxq5IqEHCFvIf2PuTO3goq3312xRu8NP2Ffb4qL/lGg5E59nlzKulGo8LOvl5Oe10vZNv0042GFka0",
+	"sGn3IYBgs/nFiXy9hL4eEAQSHXD9fqzTvTcad22kC5tzcc39G+bxbVOohrkwhwuWwY+H4TWUwd9oMsl4",
+	"v17iAnKpDVhzddho3PWB1jZho/ibC4SCZtRlsELRLearv878gm7WhgBGwi/DMA1vyaHohhjBDl8zut0k",
+	"xva20YYTvW3UNTWs6exeFBJLe73Efwv6dSLl6U5Ru4o1hRksAn03YLQQbNqyZS+CJyNGcwvEWnOhyqlH",
+	"b17RaJlWOFrbIgyhJXJjIIY8rX2sz7M6jFKs847ruVF0QrJq+nxteEKcbkQ+9gP+veX+XTW9aTYzgkvp",
+	"86C8IiWP5ZmoW1jHhg8X9dFvhxlMuIimByO5Iqg/o8+XR1JNdrsLYU45C1U0Y4yBBMEHcg3Zg/xmrw7/",
+	"jg+eWDbBJCc/ztWpl1VaVWT/pe2sedviFl8qsJ7oHam/ivqqEmshII5XgOgbFJCjKXrJtKKgNtDdJ/DN",
+	"NgnKvx5+/MCmwowRXnE4YT8AXOePT188/1MlPnvsF6ojH73f3AhWKqguMobrIxSRD6YqRKUnFz+bGQ37",
+	"QoTUY7C9KoSU4cgxmH0gmG+JDUpHmwttiYZY8Cu14n9Ihv1NpnuYPx8soRldmyl/5cDfbDKgR3v+pvb8",
+	"VWtdXZLWLvjNG/VXDXz5NrHRBG7Bsr9+FptO4C6Y91fNZkkxXzOjNhv/JxK6LWkWqNwyrOuZqMfhJtFg",
+	"MbqSFIl77gK4H3b8O2aTvzNmdQR+LCDQJD/BrAqc6KorCq11HBJ9bddqlL4Xs74DugPR+yGW0kePr8lQ",
+	"jd9s2smqWTRiRtzhBKSbNHTel4SnO2J/vBMWxDtiQ7uUEWxBeCxzerMUa7vxQdWpxlpdZqYNd6Kt8rTP",
+	"conveWUg11Neh5Pa3RAUUonzE5REa8tG1uqBw5danWw0Xtq/9UP+aVOIkJbCYvDzYlW8usR+dolK8NRb",
+	"lmzN4tTTRWza77/7OPVLY95TLl2KJBbj47Eoh7DM6TpbSJc88klCsYWWyNKrQCCLA7sWPSEpo9W2VmMM",
+	"A09HMhBQFRTWYe1kV5ytsblrQYkMrdeW6nWVp/AtYbxwI8Ip+BTMpq3v5Cv7otyO5isLPfMJ35B1NBD1",
+	"xA+pYiAq2n03tVAGtvk7dLDe1lsvyFSHsvTjX4tpWe9yiV1XkkdRXog2Cj4QRTtx4OOKOmA46Xb9hZv8",
+	"yomikQgnjT2tQA+liTWurjByNH8D0r81DLglcQAJTZhYKmcR17bFrrUkytsi9v8ujJVavVUjvTymQSkL",
+	"qsW1Ah1mIBU3cxR78L7bROg13A+nU9kgaH+VjtEz6gsGhF1NeS5wFWrdPR3tDLf5i0ZOpok2Jc4VglvB",
+	"/AuB9LCrWuNn272dXn/tWoeO4qSydB2b9uA3qqq9DkT42yuuADFh3BDCWRiPY+szrXxt73iaNpdcmZVm",
+	"vJgM2JgQg+W7N6/N7dfgDXzVWIGpXkKyUbJYMTRN2fH/IeLJ/5f3+6+6h3/Z33n2nFk5VtyVRlChL3Iv",
+	"ob7g66rPF+LPlkDG4FLl1ztdwkZPjClqE4g2o9ReBB/brXCXuRwIGGam+cVfK/b9qr/2812mQO7QgW6b",
+	"s9amXM19/RWYflg2NJgNhFC4sBdUtDajcpzgRWmq6RQ7TDb2P7v+i+7rOBN022XMArsMhTzzIWhoOWVG",
+	"zGjz/cylsGtnCyEdJyuqnRPSjRPWMb/4rN2/p8QXd+JfW1WoOlTUjTskLYNvwwZttugzPgcDaEt6vs6r",
+	"0lSEqraHk8GtemKZjKiJ0lZIr1UJvTg2Paq+yzBtlHxzNvykh8PSGIq+Q/N2ztN8wypAozvWXf8j1Ovr",
+	"HfDz98JaPhbp064vMg3Ez92ks9cRaqhhvbbgK89nxPMnbZiXaek1PWrYRayQRo1EEYv53/FnPNc8ldXL",
+	"TDdL2M3qgS+weKgKvhiTtAkFNMkbYsSKNrIKLDDKjosBrDUPuBWGmNeI2jpZFB6G2/cvgO64hUNMzByl",
+	"XiN9qXymJdKTYYarACacDHsZirS5pvQSp9QkT1PtSVy2cF6QRLHlAF4aCOZ0j9zb4WyBiQUsFmIWJc7D",
+	"qYzVo3oVNlWFQqViXry/pdCrMaAWWAd+iIiyS7izhFdbfY1ABb2BQR3Uv53CurKB4M4uwqjNoBld2lhf",
+	"JDyLE/RNhd9hwhyBtTDXnHB9YLQe2mTxO1is8AxB83C8vQUElF51K6+tmP87RZlNFiXWpPXTTlDskgYX",
+	"BtZAFKSflEa6+SGwpj9fZ/I/xHy/dE1BEJ/eAlgA6fqUK0zFI6diCwLhTsXcVkVK/3sfm2LHZb//dHgq",
+	"5vgP8d899hF0mFhz3cdNFZhLH3v33MG08iuNVRfFnDLvJ7rIU5ALH2xvh3oG4Nwg/vW5QuO50YWwHrcA",
+	"lSd0Sobmnlj/EZ6oHHtQQNHMCrHg3heIADTj+C/UWrvYIAEFwOZKWCU6ocONd6+zj/CQ8t842UqG0VTR",
+	"YSa4ESYsOf31S5B+f/3tqLOI8bCfpoRLa0uSIUn2NtYV6bGPjWtMM2YVwERBqDXSCA/rUBRUyAKXGb+E",
+	"VcyY6I17NHOYLQp0mMpgIa0bNEkKcJT+FjfUyvGhS6K3OracwSG3EB4R1uzTW3ZILyxDXOyzXEw1O3hz",
+	"eMTgxYBdekwuQXbgfYLhBXvcYY4Xpz0GayyUg5uryGm9PFyPRXsJ5fAr9jYX05l2Qg3nXSBh2lJwVhvh",
+	"zJw086gxAM0YAbYCUiO0kWMJQT3hHM0QwkrkVbuueyDIMpMxqawTHKPHSH0Lbq7IIT12IEorMYIL+Q8R",
+	"dsBOJAwwm58DDK40yrLdnR3iGRwtfEf1/ioYw/CFxDqMM6PHQOBVA/0X0KdfGSSAXKsnq+rl2hLyXmAZ",
+	"Czi5gSJ1LgUGAZwqyB4ByedNVATDVrquHnUNVxhSZfhUUPQAN6LCFMel3u33F2vxhsqAdLKSjyL3aGLn",
+	"9TA9qagqq+0xDLUjKIvWYsvriizDaWFoWfHUjgVfoSP4L8gfWFgbb/DbQU7tf3qbMcrJ93Jo62z7ZUVK",
+	"iVDkRoQ3ucOKvqkJP7Q8M2IkvyBFDAuJp825kc4JFRbIv2kJrYQixTzUGRVcfs8VHxNO2v6nt53EMNHZ",
+	"7vV7fWBAPROKzyQYNPAnBAmY4IGxhfJgi5e5dN3qjj1uuvceCGekOBNJbS9YGYpp8yYUp0MuFfqW49mM",
+	"tAROpIz5c8iHkgSdJQMdJJYO7WSduJhvc0SKsW4fBvkmXEUrmuvs/WMJMp5/AdygxIlBc4PxEZv02N+9",
+	"vXWg/ZRwv+DQmfqvZ3wsmJX/FuyH7X4fhHROyCZ/wv0dFnw681icLh4gAcrLy8JCks3Gx2DjomIbYOlY",
+	"By3a7vOupmNP5aylbz0aWdHSedp3f5O+vSd4WBqrDSkRvFLFYKme0CXwhF7psVdaOalgjY0cTxzjIxdc",
+	"x/C6vwUT+px1HIIAlQUqV86LPD9LbgK9QWzRK8rYGiDs8UCqIGZotm37QIOqrcWSRrU0ZVXMPbnUqRxV",
+	"fmlDKENTf3zotMf4qXq82G43dY/5e9LGCtZCOenmLWOghwF7pRrGqvsdMRl+GKAJNx6X8MNJCsW/ff2S",
+	"lbZELaW+XfXBrRj+la2hp6ZASSCYtYlUKQniuWUs3jRcDWOzC+5FhuMl/rqROH3xcfxeWRxQvO/0+0Gv",
+	"8zfK9Lj8p69gUnWyYMQDEjm5oEG2Et5N5liSknt/LO2ot0l57l0unOClkdch4V2ULWml76pe34wsNUvX",
+	"ay8nG7vf0EfuNzOCZvoYfkee8/Wg0V+X9OTDEu8Jo7LSRGE8uyu3LtV06lu4am8+0VdNo3irznghcz8X",
+	"UHfpb9oQD6JKfzSKYxry9k0OObkmgI4VTUw4kqc3OhL0jcDlqzaKZze9hRSV5fUbUnlrBgRUoNJ77D86",
+	"qBZ2fgfJYcvplJu5V8IYcr+nd2zFq5CFHncjAGmb/ghyD9GCSYh7HFI0vypXzKGiJrmD6trfr8K90+N3",
+	"2Po3irKV6B++j0MqiXshrnwk8WoUlyCtXwV5ZAs9JqKguMQGKnqFGkcDFRHtZMzxU5DAYjQSQ8cQLFhy",
+	"J4o5WZZIY8EDIQXqw6PDCCy6FRwLFFvJh4hR+e7jryfv3vz9zbveEnkeLpAn3rx/9lU7ro8yK+O5M6X4",
+	"eruM8S5snF/g/BYPq0hAj2z5jWyZMFvCmZXQJwNy4vDStoll0Yrt47SlYoOyOA03yJDTCDynrUgSG9FN",
+	"ZxOHXZVMp4tyqqzXPjC1751UwmJDaPbEsllKMFKvSCFZaGQmDCukEgEpF3qUCK0r0Z9EaKBpOiM712UB",
+	"ero3z0HSMA2aWpSW8QIr0YMMEgrHR0ZBTBcU3BSS5vbEZuiG7bFfYsJpuOdSYiR2AXf7GSQe+jgBSkgM",
+	"Y3J6TD4rXw7AGa4sH3qLng6pdLwI8U/T2ufaMAWfcQOgebR2FILAwVg4QLOeJXdR4tLRheixtz590+8p",
+	"9zUb0fhWCy/ClbE99o6bMWW12pALGVJAg7scBO3Y6FJ5BfKTESNh9vw5m3e5navhy5gdW7nn/DDRcIQT",
+	"12pxJukUOMWiLItxmtTnUC9llX2pNiY0AOG3yeCW5uQNxUA79lyYoCbv9HdwqzGd1I8GGpzponjJ5Fhp",
+	"ExxT6czjbLRJwk24Cta1NncGLWrtBpnA7KazartGbnq4LcSn/HGMQzju7LHjzn7O2Tt9Jgo+FMedjB2T",
+	"X4Ee8pynboXjztdjVfv6VywN+hc9mwmz9PVShjh+v9ruUxv7l67Kl+Xw8jdOfHFbQ3tWnyaMMqPhqHSW",
+	"2eKsVDqLbHnUq4d8o6f/EsJCw4HTAlQAR99Of+fKhhJdYk1jAO3O8yEifzneLF4QW4DKKIHhYJWwAS6s",
+	"e3PwEziTotsFuFeXbqjR9EIMh3vwTtMcmwNcYotPLPt88G4NhdL4hBqK7j6sXhP0xCL7riCfm9fNjoLw",
+	"WjhdMp8OiScgnpjw95QsYVxhMnsfH7Ifjj5+PHm//+H/nbx9/+njwdHJwcffDv/0qOctWxh2+y9udBRK",
+	"o6YR/JJO69PUv09KAMtLE0xjxKYvQWlAc1kNEgImsP30VohTWlaApmIC8bH38ud7bbMhwe1zeEhzjyEG",
+	"7Rr7my/+msxViKKAtG9uLdR38i5sSwj6KcT9st8O+7m2S7GMaN43fCbWSw8034elYlV81+3dhrHo4S1I",
+	"ydA/0Y82kXzuFj9V1k0NwQZ1HtHlimvtgTjTpwLDSrCQgQ/pwSsmXinpb6Md3rdijgXGChSeMZbYBbq8",
+	"Hn45oGF60r0A2+w2+T9OqfAHLMFtUrcJE7mzNAUbWhGVxv/9Y2Y0lH4xX7cgXgt001azeZTFQDs8DUej",
+	"uBX4OTTHjMiloZshNErKLYlrosYZl4YMoRRLhHSI5XNsvaWQpJEixtYjp2Kkpkfs8p/6mLMIdRFfk9CY",
+	"Rh2PbBCWcYw8pbb9FdfgKay0arihf4Sj7lVYsDV3dHw5zidciDEkO16Hk6d1JtjUXY6dfAqtNHh895c3",
+	"rIr5Sxe8LW6BUPEuELUAEc6CAh3zsHtxV32neDeKIcstXVvH3UX7Fm5xXguwF7lQMioNLR0TJ63q+Pc7",
+	"crSjJ+gWpJ+PIQW1mRYUucrvrBMs14IwZkLInz9w6pdiqDcKNythbuHqAiIIxI7IK1GBST4eYjLOqC55",
+	"FuVTRPGacAsXy0TaoC1QJlZA6o7munuTcw0CgiSbY0OtRnJchtvazs5NL/ySlPeX8LpIvzuHKozj9hYp",
+	"GlsJPSgXeIShM8BHohkweIl84fT/RSpp0SpLZxHpfCt0AeTOFf5zOtvJnEWMuyjgoSazVmgRhmgXLFU/",
+	"0cZ1IacFEh70qRTMSZ9iF5SP0ExsFU7wICui9GjwfMIrOLk7eRovHhNPiYjallUvHsgbG/OS5a9raOss",
+	"e1/vljCqkS9ubjv1+lvPJkaEhRsS6aM+56jKhUA3CP5ce73H3qA1udaE9yGVFkO8hgJKGVKQvVbCX0ts",
+	"7RrWcP1apuf0inTXbmE3qOGE2x3dXO/G7e6GrRcHNXLDTAscUuarOJJ+Evy9QId39AoaJsJVjdtqjEyJ",
+	"+2sd+Dzc1DTyX4EGk0o1Q7jmaOWhS2b4i7y16MvErCsqtTk08xnqHhNkfswiPsMD15VGibyJQf1Yr4s5",
+	"qfkLMeb2lXraWtR91NJCOuCdMCbeqIvhc3IJkDGzz3Mf5sbYO8t/RFKeAWEfE95DhXvejTDFzfz3npvT",
+	"5PtF5GKWWlnoFAR+JNGFb4Ysleoy7ptK4aWY92xS2/iAQrl6jBBnoGGu4rLHLsMwvLW7ynTC7+Er6ZZZ",
+	"OUGxuSZubsDJuQXXeRPhvKltX1jIW+Dro5XHG7rRBRkBuYrjTelHaQaoYAR3eFd5kAghcWbRRIgPcz1s",
+	"z3k7hEbhQwnd8KGTZ4BzOSu0qQovfJwJBXE3uR6WmInH3bHa8ll3Pco6pIhSTMwXKq8SV0NCD43+WDXF",
+	"Pr+GEa6lUgxImTiqIrIydGT5/hJm9MSyvxy9f0eZEvU1/BmvhiEPMs41RGNknS2KCd+yzgg+bV3RT6Wd",
+	"IAalMAPNTR5TiDx8QAUSYKls14TPZkJljNtjhdthuoiyQpmAaOQJyZOU5ool630Ilb88TAmmA2FDjpUH",
+	"DAlIIm9fEywI/u2LQaTPVcQLgLdy7jg8Plb0PrcxhzGgbTDp9tZAI2ASKjWDUAj4Qg0OIdT/D9nzC6gH",
+	"kF6HGAEYxGiPFQ/AQwTOLYeYc+U0OxVi5i0XSokh1Q2YCdU7VscKE5JCMiFc+mm1ff6d/wIm4Ft/Gdca",
+	"3j5WRvh3wMwABhEjCs1zSuLF7YPic2CGoO9ClZWiANLXbMTNsRqIiST1L5c29tlrYIZDpK3NckBxakSM",
+	"YYYRpprQDSt9AiDXIGd6JjgKO0IgZRa0UV7g27Yts9BDAFYcF/OhEvDdaYqQmUDJLiDxrZqDmwjridKm",
+	"Y/WP/VjbRhmAVZqGGUrwXwC14vf1k/l9M4mFA+tWQqMpMFHme2x7x3NcnbWOFTDkHvvjuCPzY0STPqbJ",
+	"Hnf2jmtzOu5kx50E7AdfSBHldnwBR3wRmj3u7IV2f/xKYYSbiVOUDDQnD6lRZRp4RqhI3d7+BbtCAOAB",
+	"TCZgLykdZnIrpvN9z6oeM38EAXx3VMsg4cQKVBGS/CiUHuuz6jmculKhJo5QHwGgvTEZ/lf/5IJp8B7x",
+	"/YFkwcfZPOQkeJokLDVhGGFSwVSf3WwuPGgbvGsF0BtGs1ClpBDri0waySoDBe8MrbGlq5IXgjISjwVm",
+	"pxzC3CFFwTdYkVeP7VfAy5jkEb+jgiKzAqs/jHhhRUvWNrbZfOqtEgTAXFRz7ytSyFv6aHs5cdm6OdXM",
+	"0mbaueI86wuWLI9iZuMpPshM7CAyryrnuuYFegVuvi7wtNFF2xL797fw5fDu16+3d8hfJFn7LgZRYdpx",
+	"4dVwGOQaKzW4kFIFX1ShSVIRYAJ8sXis0ve/kjp/HfaoqoNbsi8T0y/vAvwerYBVYEsx/y5dP4/h/c0q",
+	"eO22fKeN7+tj5LM6VuI/6PTcA/QtsZQEuyhXErV+y5bjsQdgbzYg0nMb1DjLOLOCm+GEDfQXxK6dz1Aw",
+	"AUwnavU+qZTKGhhabYzF8FVL/gXCW4R8R6BV3zg+5Qz9bYU8FexfjKv5OcVUKg++TdYsq9m/wc41kiq3",
+	"6Kl7J8aCMIX+SxQ5B93SYiimz0HsMT+ViG82xJgXNjBSjIp55q+4hK8JH3qBkoWK03gA+eiuhlASan2j",
+	"e81vZKnyAWO0gBaNSZ1s2erSpBn+a2WMSVKGEeHIV5Zh/Jpd4NoVyOXC16RnWBzS35LWXZmuMzwSNiih",
+	"hO9IjaLNuqO2B9oRT2g+PyeKmVRg/QHy5CvRFrBiQ+kM/D0w8mCOZm9flKLOs/Sm15hWciy8E9poiPzy",
+	"T9o5cn0AcEOSAnbqBU6DWvOoVNRHcaN2PdybO2rRu1r1wbPT2F+41xkBAzLreu77VbjbYL0LmIaqOlKP",
+	"lqHrPZFv+hjOOm+O+HjdNzAyfO9r1nnHreu+1zm5lzb4ED6I7+P0njZmogUa8wkAocQ6VZAFCoMRgP77",
+	"dtT9oJXovsecCG+ocnIq/MPQWfcQPq0v1cVm+/VRnjZqKYDyFcQayp02kK/PeGNAZxRcL0FewHdP7EoD",
+	"Dn11a+rI1RuMqgndUvzSSoORv9Y9Gozumm6nDbPlTJik8kZ6GN8pre+GbVmHqelKKlZaFEbcI1gEJe0e",
+	"6KIXU0K9QF20XtFlcKsCaV/vpl4ugRosPsvlKZfd1q+qnm79xvgtvrn6im2kOvqpz9dW4Eza/v1OOK0e",
+	"FZlml1TQSZINa3VO7ed5WpEoFiLqsfeEV+pLUvorDpUkGfo0Pd9PDDQIL8XKQS2+rEhyD0Mdqk/qlnxo",
+	"FRsvk0549uhLe1SN7p1qFC/SQT2aEAJqoOm6/+9BaknR0zesmLxdV9r6I7z2dSsJaG9Xobg69ciIWEzv",
+	"CfivrGMgHbpEfqUCNarqH1BirYtlcXpYHUsY7+oT/yp54Qum2glGiDHD1Sm+hgY4qXJ5JnN4DbF7PcYs",
+	"V6dJQA6hZ1cTsAGrtNcIxF69ePuWx0Cb7Z0MqyPwGzpqqCeknJEPKJQymc9DDqY0pXdex9SM2wmnpEIw",
+	"IBBwREmdf8Zj/Xiqt5b5xFrKh4kPn4Q4DJ8fgu+GHytjPFxu2VAXWvkCgFB4Tg7Lws33JtzkaSYBFfmA",
+	"T0LiQ+isNfkhKXW/MgFioddL50IsLZkXYrOCOzAMLp5SzaMOb7fgIKumZI1NBxSreo9B4dloOPRuy2D+",
+	"OVPXXtHHM//GV9nkDHijnJk/yEBSf0rSWX0XI0o388GkexWdE62eFDyhLuZJubijpHFItxwbuzIwlkJn",
+	"VSyGGaReFiR0ECgZHSfIzrd0hYApBLXoPviDkhtGqkRvqngbPlxhtUQ7DQEdxfLR8AUzWk99xiM3CMaN",
+	"d0NDxWczpos8at2kVnjFe8gVBs1RkQHN/qmbcGSg3wMc2UNVka/2AIq7uNHxAyu71opKTf7+LZXWHpl3",
+	"AxvoAmetMIFCmjzjFf/FM37RxBCLWHMq/4JceKxm3Dg5lDOukosw8F/GPArPjBKpR4S7ps98pXDo7Ik9",
+	"Vr+JwaEenoLQcezXN0eMpMfWHzL/ugVpe5gSjXyLUgFu1hGnk1bC1xcp5onRt3rv4GjfI1MdK2g6p/HQ",
+	"AUqOEz82UAhjiX0eK+Jjy1RT5XyijxWmsserCgXw1qE/oPA1dNWULU3mDOSW70cMXZ2ll8RMAzQUH9KJ",
+	"cTuIHSnD+PzclAC/ayMv8S6wIHLSIOxTLI+TQmBliByXyJslXB25iM3yeCJczPhas64m8n9z9Q5RB+zW",
+	"RFoHsmGlokcSFoyoBGURgDySI+pcmyIgbTRoefFeKeBayzxQiK9PGZE39qkPfurdc/Q7YStJl1JNsOiA",
+	"vBpCgUs05xqKOsMSE9WY4ze+ELB0L33LllnCVQ6xzFXVrUKMHNOlazTVHuDXf/FL96iJbqSJ0opvroum",
+	"a9xiDFnUTH0X36tz//7eUBcECAtSaWNpVqpLAkHUxgDNLHmGXiW4PHjMzRwLNcoy1lThjKwai2XbGAdq",
+	"gT4xstq7gthYJPI1i4ggpLuGIo5B3CVFHDVq8UmNRj0C3d1XZqyg4ki3jfbmlwzth1k0wIAe7S00IPdC",
+	"UtqqIKM5eMG+Zx8VmqUfioMqTOa78E7dJtLHfmEDyTA9sBqzV0jkyJ7oVXoK7QjBlCmP4E9X3YHgTqjg",
+	"CkHA5YTGmwYq1bAoc3ESOmzeRJ/Q4acw0LoQXG2SkmJE4f9rdWmG5OcU0wH5a4JPHiQVzSVLPPAgeShS",
+	"C6HkTj1UWaE11i6Y0S0i2DKwQGq133VkOiVAYsY6aTOBFsdNc1b8Gl08aeWgVG/9t/cIz+TuOpDCMb7p",
+	"4je5xzZ1QtERtoHrKWuuwHozZU9lnsGd5ETmWWAc+DeGlsA/4L5zMrWZcRz+I8cO/lMY/A8gc+iT0hRZ",
+	"dKOQCyUjl+qJVpkPqDvhLrOOu9JmBE0ntToxglutMsKNpHeCGMkMH4oTwHz8MdvOnmbZs592n/b7/fjf",
+	"LJs4N7N7W1vn5+e9uS5dOcACrFvn4Nv6P2f/O//b+e75i9/G/zn8W/bh+W6WRcS43SwFj+vvPUXwuCzI",
+	"x+TN50f9Fx5bLiMGX1/fdUMb9Z0HlHm8YWxgRsejtBb51W5GP0S7MVmMfab3OD1hs1D42wc2QngZvUHB",
+	"jr4wp3TWx4Q1QDFADyC4HrTN4OoDlOPC3VJsMp41DVbRUiXehseY5Ltjri5V3Vpd7VJQX3g1WG/4q8zW",
+	"S86g27dXp4ozShc8JGDY99B6vRQ6DGK6OXI4FcoN5iDCE14FuvGen6auCGadnnkYYoTd91eQz2rxt/Sj",
+	"WPaNXloByM/VHCsvLxtQQg93FtXjqJpvBT5PY7Z+Tb7vRIdPb9mpmKNgqWjhEeLjUj6rwA0JYbWV6Kjz",
+	"b+2zHvtlBdeGfItAw5fh2l/uDc8+cuojp14Hp/5S59OWI1iYSzpgQiVSu3QoZ2yqLTqHsXwEDcM7ZV5f",
+	"wGEbUL1/iQO97dvXskchLuKDcSvUZvSQfQsp8V6jO+FqTcb3PolglDDzciO4Jxtbkr1gyD/7gs0rgwyo",
+	"6TDOLLKPn9M3xcU+2hzvPWRBRZfLJ6W3Cm6IBLKYYnpRRJB3wQZ5j9FAqhXbMH3qTBRrOdg3+ggBch/4",
+	"yW/WavgPtcwsEQckJJlggMw/S+sL8NBbvtpxEjGucp+f1hL0TST2kGA/cEa3ZFf3DLtMKO9oex7RPh7R",
+	"Ph4G2gfJm+8J6qPwvN2sBW39gf/9NoAPUyrSimh1VyF8ZFXE1Dmfh5z/CiEkGcamYCDNIB5norhLSB4k",
+	"SNt7KPx59g1dIPSfZ/8UhwsWqQJC8eG1TKqXwUjhi7RGGK5F9K22y3L1+BFp5BFp5BFp5BFp5BFp5BFp",
+	"5BFp5BFp5EEgjaQBPsvRlviz0tUTqODrjxeVR3UKrx1KL+tUdzgdrMGMsxq5xN8+YdT/KkUpLpsCFmBg",
+	"hcrB/Ug5GRgAYx075xJx8v01Amlqos/xOV1KYKnhLW8/Op8IDBOljNUZt6EeB4RRs8N3+z32Plyb69U8",
+	"IFqt8VrxPk70bzjPu+fAfEyJuq9qdAjvvze+y4uqOgvMcw/VHVvwEyuGWuV2ufu/BFlEgetTPkdhhOPx",
+	"MicmvoNA0mfC5ChDooa68+zFDpTjowog1HeqQF9C8wLqipIzjqRZBUudM2FzWx2s9dV46O7W79TKHM/H",
+	"ClfkjlqX73G8tdeeliy4VeJMAwO3KmF6swAwr2pVmhtzgk+zRXeCx6YKKm0E8TC2Nbyr0qnut8+7vp4b",
+	"F0+L01/r/046uGcobvfLdZ2sc6v7+lMJH2Aah1at7AElghfYA455kVNti2NFDieVsylXoFZLZyuOeVn9",
+	"Ez/DnBivGcCLwOq9Y4WePXqD53nIdfNX0cRKv8Sp2F7sogkvbT/P6yT6MPzni9O6xeLzCfevPE3z/NGd",
+	"fpcUnQ/aMU7RhxiykudsumAbkDa4Um8t53c5zewWnOo4iOBUD7qLpQW6V+XFasoWlS9C+T1NeLhdydr6",
+	"Axbibb6y0vQRJM2Ec2U0WnGwJFKffGiMq7lW4kIif0ngH2BTtyrzl4wsEN3L3r4OBrdpMrCG/miRN+lx",
+	"VXX4BhN9JYy9z/KxgHaLMPT0iAw+TbXbW1U9s1DgtrRpYmgQSEy6+ymIDjz3r5dF0aO8aSRz+KAytsN9",
+	"bzhh3Ca+bHR3TLjhQydMxjjatCJw8Zm3J0cb10CkLvfGW+Hf40Dv9YWwtt4b3QfDxNdeBaumH6Oh78OV",
+	"stqvNfUQw4v12BIfggXsM5xobQX5GpJYaX/rA4PPYj1S+lUr0RIZ/fcqjuThBEeHSd3S1a5i5GXqCc8e",
+	"o6Qfo6SvFszpbkRMRxH2PQVNn1UMD2qX4bPJv4p2PatUjDP0xzI+5lLFUAOed/GO9iu08Ld3bP/T2wwc",
+	"v8MJE19m2gpLeasZ2Q5tlpRdyHwABBwdteqFVjMlLIianDseCzKMhBtOKGxOKxH4v8dgX2l1mUQQLpyO",
+	"X/Cen5uFbo4VtMULq0Gtk8oZbWdi6ESOhSPewHoHZ/VMG5fG6JHkBYR5equQ1mUUlxGUx2OFz9hQ5xSL",
+	"cPDm8AiWhJ3rssAkcmhPfHFCWamV7cGbPfa3UsB6MG6hkPCxQg+v1mzKFdSbEEUO66ZLhU4S6Nj/SkBC",
+	"s4DUSzwPjt9j5SZiDg3CYZr5Kf0zTHXpZIURzP0erjtXYbnDdgcXfZO/PvzZfsJWkYt/IGP+AHy3x447",
+	"dvp897jzJ/YHUwkwGiyR/+Ur/P8mgZcw2DhVvOyVinDeYamIoicaltLHsbZMJrbxgU/FxeJ3j0JHqV5F",
+	"sMuIquz14NVBs7Yl5POPY1Rkjjt7YdW+XkMI6EqrMJHCQXTZNAvesAIULJIxwkL1mCiBp4ywujiTWEwb",
+	"D4idnVsZJzBbkTMfpDLjxlL0t6/WQfpHKgj9EOoqNUnNOqu0qtMXFLHcopaMNXC8hMuOVbzFUjtRdqGg",
+	"ZAOdzxt4/5O2rmL961Bx49JfQLe9JwR68+NMdzMQZEVlqVL88JgHdJWJ4IWb/HuFTQhObgpRq+IB2czo",
+	"oUfY8zXiUO9Axc9pxpU9F+ZY+fWzWYLdJIa+vr9luZgJlQs1lMI2sNKvwv3FD+8aCZq6OEQU3badSKZb",
+	"zhaW9hXMqFqg5VexlNa/V5RqORMKvgAFWPTYfwgxs34FYaF2+n0f+5esf25gw0FoHSs7KV0OwdEUxRre",
+	"BGVvwK2AkcBjDC7kimkznAjr/MVGFXPYJuu4cZbxOHycDyKYOz2bwTVVGORUoZw0opg379c7nOrd2S0O",
+	"a7/ZhtkJMtqpELNA07R9U+GMHK40m5ZGRUmCmqUlNZw7Im6vVJaOLDsE2YyKbXas4kbNtC7wmbRODr0q",
+	"/ysqWVggxw8kHESfjJ4KNxGlPVYAQ80oDrB5Y977SazdGmhpa1ZwuQb6erOAk6Vo8WrQYTq0yHomFJ/J",
+	"XqCGVSuNl8pcD8upUC6U08iY+MKxphBWycP4+qiZ+v08Vp594OGglEUIDId34O/8CQZgWKmJm4Z6OpXO",
+	"L/ix+tLFl7rpK+E3/2p1G6F68yPdvB9Q/Gn/09vDmRh+K7usNQADT/j+4rJ1VuWixLWdcrgj2hUpKMs7",
+	"7JLe4DooR37ocaNpJajOYOtef9JFwTiGyHbDERO/ZSivqmKEhKVXuSe4YnKKB5c2eHM2QCenwUEFi513",
+	"uZ2rYY/9hhLTR/37VIKq9Gntusosn1vMIhhxTBQAATnWFO9mhcotAx/KvLs/QmcICWwMeYWOMb7XB/XO",
+	"dFFA83hyv6Sbp2+QSiLCZdn5yxS0o0s31ACdmLh+rQh+3ye2WhzbayE4+mHdhTS+yN6+brb1ytUO1hiO",
+	"XJYyv+HrUzXLyzhCks1rwBnfaCMtsA3I64J2LS5murOdlf7or3fMBLt78+UcgZtDKlS1hJVF8e3r++mt",
+	"9flSOqHSZZG4pUs3K12rZHytz1WhOTmIsMwUDxipcDWIyzXleeKxhZehLpU2afWpkfT+XeXFZBa0C+Li",
+	"cJKS8Bxps1q2fKSR3z8Jc+21QS6gSB35bV1w0tLgu6+lnWkr6d2llR2NhLEVYTi0zFoO8SqlyjG7zaLN",
+	"z+ekozW5B2NcWX7jUSKtl0gYYSJdfAlZAU1L8f14rToTkOZbMS3oD6Sw+NJI91O8RcmUyrgn1q8FCbuQ",
+	"sLtxGEr4gJzgHvorYhBkBKVHqqAia/JySMmn2OmVhnjU5rJRiEcYyNoQj6rpuxDicVcjLKpVWhNYsZqI",
+	"WkIjPlVgFdcXqhA6uaVQhYogl7chPHsMVbiroQpGF4sBCTcaA7DfBv8SgwLEF2mdvSfHWYfDqnYuGQQw",
+	"q1gpPed8/OWquG+CRU+aID1Bef8qtytEFX2biKqVqnd478YrHsSOQ83ux/DpdZx8o1pu3J/bDCnC+k/n",
+	"wogWjKmHLEaWZACqNGhjXYYuQHyg5N0nNiJLK6yv8tZ5OexLaRCiUOYRhVAJMmIkTMjQDg2BFi0bijF8",
+	"nuX8DkiZq1fC6hO7JZ/6RkpYiSN9VMIeRfdGovuhyskDgSFri+qW4UOxxqOUwBnA22R0cZbNuHFyKGdc",
+	"OZsFG72xdSDaJrPnAR+uDZuHdy5r5twgU+3qZBBO5s7iCOAy3vWkD7TuI20t0iSGgLQS5ufZ2PCcAmfY",
+	"b2JwqIenwiWFldAHCSsAzfgIXRoGOR35sYIFOtB6+l5Yy8do0wfyoc+ijxv+gsRQx52ovONUlP1YDbVS",
+	"YuhjF+ApGOCYDNqDzZhW3igHzl2prONqKChOgcroHivsFVeHOuDoROdUJN6IUWkBs2ofvZboxIyV4WBw",
+	"GDi8X6vxGUrGO0iOBV4lmFRy1MK0X/n2pzR1u3es/qmlCoHQHsgMWs8YXUrhSanw34HhPWyfGorCD8UH",
+	"/eqZUDDijwpEhoMWrWPuXAd8SjTnMujRG3J5UfgIYWgfm0kW3jh7wtHZbIUL6pdQOWXZYfAIROscqx8O",
+	"9l+9OXn18fOHo9cff/uQse1+dCYnqGsv4wJZcEzjflaNpIFhfn2eWE88JxhUYrXfUpoOs0LYCnRbK9iS",
+	"fb9IMGr4KPrdPRViOlI1N1iEpDYD0mcC0rcEOr1Inr5IOQYsAfVxYzBAzEdyQIxGKJGOfYWToMfeCY5B",
+	"Sjy675H+R9qMBIh66bJjFXKr6BGJex/77S3O1YFQd8hDtJVvC0jitbSeZaAnyimlTKxQvQLpY8gVM8K/",
+	"iQT+s9Hn1j9S2iEleOMr4YlEIYANwWAjoNKpCImUx6oGT0xvnNAbFDoYTybkVcEbg9XfKCdMEB83epwt",
+	"xXbvp5N0OmF5IJEM9rESBxPuqvVT6HMAwaGN/Lf3uOOKtkSCp6t1IRC8bVJ2Fw7Jc0nJFAsiHEh33ian",
+	"iG0qAYy0vCjHl18mnrsFzT8Nl42RstVcSzrFbuFCcLTIH5i3QtcUiv+5Jc38XmgtyP9BJzZaB4UaTq9V",
+	"ccE8l2nY6iepxrYeeIoRdBBs6bmVpOtUjkn6HCsQrgMhFMNFEHmSAATnN541UBuS7WM0rGXP+k9JUMeY",
+	"1wm3x2ogxiXFt6L3ccALOMgNBa9i3CXwoBLngX4tmwgjfAhPVHzQQ8uNoOhakTdH7h3QwtxijCuOgDlN",
+	"u8qc4ZCMT+T19MZGsU97y0ZcFhSSnmgE0uIW+YPxXK0OwfUfwUGJIj/OiAhxDBuzqbuYXq/8fHDyGjzw",
+	"5zXTotzQdXzgu79Sx3Eyp43cxgcR2Hql0zg0++gybncZhzVa4zDenIxanMcHobTA9bmOqYtbchwHkmwS",
+	"T/Dk0Wn86DRuGUVziY7v0WUcChYk59xF3MV+HVucxbLNWRxF0+qrHjV+045i3+2jm/hO+hr87twpJ3Gt",
+	"7s934SKuprrOQUxvfrN72Aualc7hW5Uq1+UYvoSK1b85FevRJfwopjcW0w/eIVxTpsqQTAPeJxjz5YoM",
+	"kTuFWiDTvAFLky7y6BnusddeV4kvciNYIUaO6bJBXKJVoVSvwsDWScxSXZ/FfbkiUJzEQ6kKlE7oIVcG",
+	"qlHfTFt3j2oDpUy6mUEs8s+DrICYihzv9Vxfg2dYCZSWIjwPvezO7g3fQ+4LDCk5zSN9tJo+32G0BA9v",
+	"xgMPxBP+gh51IjnE+gulfMWUywJg8I2w1rvY0ZWDaMuxegX0yjgbifNEWrGpVKUT7Idn6bHR5Kn2Vs+K",
+	"9W/w5LymW0Y1mdsy4yaCdJnG/CN/nNyVe4ZUmE34Hd8y3qT8RjhQnhXvgiDc3blZINKA7RhliidXmRzW",
+	"JGRepvgchOZBUXCauYAUdz9Tb18tiOy2a9DWH/5fa0pRRFx5/3pQYvE0WK6DSifMimqo3vJ8K7J7ST0P",
+	"i9XWQVyiaygkEfp+NHCvKx54i/aTsEn3r2Zgs8F4WN2VPLLIIrfPCu5LjSOUmB75gF4216VhEGnj27BR",
+	"GSTHOCp2A4FVzQgihHF/Iw13WDFP7qTsh+1nXhrX4lhbzcoPX2TcGbWyf8NqpaeZR7XyDlXySSyeTyzj",
+	"GFHroU0sbhg7lyrX5xgZPePWPgroywvoN7CeiXiu62wEMQ4jbL6uv+fmFOyKSWg9txGYnMwjnBnBrVaY",
+	"HaCiPw9D0xNFrkVrO8C2Dkr1EK7aYS63JxLbbk+hlvuj8HvUP1uu1DceYxFC/KN08YWkH2TtbJINzTdn",
+	"tKzMLyqFo2nU5zARKLDT55yiSNMSHevl8N9xDLchh29bGD4Ko0dh9J0JI2L2VBhZwc1w0hrB8EtZFF28",
+	"ttOLjA+Ntr5UUEhuyNA6F//EKldFOabkXRhPxFIOt3uKYEAb6jRjA2E9HnQIe6hKFEDShmXn2kBlnePO",
+	"v0oN+udsYrgV9riTsY8HbCDcOab6FLiCTp4Jj3DePcfIes3EF6gRAfYK+KWKq6B5BDxuHBulJuNwlqXl",
+	"IS3XBoV3/HqtrLuzUmhO+Zd3Qo1hV3f6FCYQ/t7eoJ4OpgR2rYCBwkzhA7SpBle/06zQGssOvcTEYnqj",
+	"spt0so74Mit0Ljp7I15Y0TwLHEk68I287LSQBziWI2jhK87wLX27vex4t26OdXQ8fs36aJNknvcn2OQ6",
+	"D8Z0ye3d98+nDIQpYJ5K7qAvnFbW109jvnxaEH4kZ/HZ5QLFgDfx8wjQjrE7cHrPHAtIwBlrwhGm8IZF",
+	"RGLGIbkK2ka8Zp/BzcYiJlKC9IPTEPrNmHVG8KlPuEeoZ4QA9lYAStSiwmYm1EzwINB6xLDKNgWF2JcM",
+	"mSPzoRW0SD72AvhLjpU2EFz05gsh1gJIvR8TDAWKutXiAajwCAI+iMKKBAgC8QXCghV6PCavHXRIcNQw",
+	"vak2wp8BbsIVGZSHMJIAxT4i7AbKA/+Ekct7dZh/eHUSoCBwXaY8F8FDOODD07EB9Qa7zgOMrXRV2nUF",
+	"+pti9UcI3+Ywv89IUGsOomWp6Cf7QALw4mwecvSd11GMAKqndFhfKP4ag/CWZw7OGCIaNtRmprG2+Q+g",
+	"tfwJhqS06ia/o8LwJxBSeD3tsY9T6Sq6S4Ra2xhDW03DHGhdCK7WjZNW7nyirfD1CbVynCSotCg6M5I5",
+	"IAyG3IqWwagL1xJsG0ZNfGEZIReKzky5VB47HY4orua9oZ722EGIigLu5DEIvG3hsIcTau5iY36li3KK",
+	"hlyrEQsnYxqf8aIIYDqU0rzH7RA2fQ8aAAAb9OcBrooHgIcxZCFP84Q7PId8TsEJdy+ZwzKakChvEFcB",
+	"8j9iWAXiw+TSUOJ8jx16cT2Y+/UzF1sSmE6zAOjIHObSyZJKjdWocXqbFK/cL2ykbKtHrpun94sLbyFV",
+	"tBMnvpXmoXuVfD1HLN4EQkXSURJHFxkzQ1wjWGxdhrqGFhmXqlcF5ZTZKS8Kujj4BquDocf2EXUBRTNq",
+	"A/G73oa3Cmrz4vcKOBZ/gW+/9ULRfMbHYhZth7zXpTJfZS3I453+DgE4qXqRUyjx8jKoPbCCXM0dal6D",
+	"0iW9wROvxXSlIhIKxBPBaPzSkZbSUoa0Nq1Ods3B1yeFVKdr9+wdvvQQgqtLr5QtNxD1/42puBGSYTHG",
+	"OruZciO1b6bFqi2/B3t4LbuTdfzCeC6kl5b3rOk90Of9u0ldl4ppZZ7NykEhhwBdhicsHbDJ+VqdrZk/",
+	"NeCfpAsEhwH84hBO6mTCVV6IbK5LVw5E+BMeOmHCn6i6mfkJFhOcGa10qWw2kDrjZ9xxAyhpx2o7+3H4",
+	"Qjx//uOL7o+7O8+6u/1cdF/s7g66ov/jaLg9etHn4sfsr3qi2Gstsn/qifq/fm6g4GQ7/Z3dbn+7u/3s",
+	"aLu/97S/1+//V/OP4f+OVys2mxo2dvo7N1MdDOyBXpCfcxsrzC2dHhlL6yHRydF453yJB8fC5XHkIylm",
+	"Ro9Ru4QDx9d2q+OvvNM0x+YSfWl5lc8H71brkP60EWoounTo58utLh4668oB3Y69ydf0u1heyF1wIpGB",
+	"G8mLBNmdyE2FUXlFltXU4Swu9UgWTpio1mdJScVkIs9vNk6J7jJJOW6RV1UayUYO9xZbzmCg4m7n54Rb",
+	"dntiTgQMAQwzeLfCzJwZDWiYOdYaNdNQ4rEpcwZPu+sEJIIObimPpTrJF7B1YbEeoYgeoYimrdRRwRB5",
+	"u8+9xiFqBhoKciPxc2wNAnbIam+HBcs+9yIKrfPMSjUuoujtsbevvbsj1+imJzcxx088FDKJYfh8Ki18",
+	"fyLzhkK1P8OXv4rNDOeLNpPgi8Fu3762G1oxJJow2n288bqxygB+ITvGdToQayt4UBXivuOOxEQm3lFk",
+	"92lZODmLpv7BHKvQVuw0FVt8JrunYm5X1Pr3WNxUUBICrIcQCYF46vAlRVXAv+C1qRXFmVdlKOKB7H8i",
+	"9x4KPNjA+trofdr/9PY/YDRXai3iM3kS5rjRRZxGsRa7Mrb7TUnq3+tp6skn4DlNuQJ/YPj5fgbHI7Ok",
+	"U2iJv5TKMQ4v4eUaL9Z8Corw0AfPJXXh2QBTHLjzAPUWvf89diiwGAi88981FPE9to+RW+y47PefDk/F",
+	"HP8h/jtyKpOWkqAib6LJQNpImi+Zddqgz9jqqThH7GHLR6LXoqh7lrlOVZ26uCVlPYiEVkIOGvtjQPzd",
+	"Fio3rK4npZiDkj7x1Q+mS0EU91v4Bc1dhXm0qBqx5FF7IveZPhUssZhE3SOs0EuUTE7PMIbzFBxbcjoV",
+	"ueROFPOGpCBoMcqolSp64OdLxqRfMAyvId06DMDgoPNHhl7H0Lu3MKL7nsbneaydWdETg7r0uuzr6mKA",
+	"3/jwPcXkFLbKYpkQH6FAL6APnooH0Q2FG8eoGs5fP735NWOfPvxKIXy/vv2FmvFxShiaKPKX2Bq1Ly0b",
+	"Gj2bhUomQwGLA5azf5XcYP0kiDHLqUHUany84acPv/rgmc8H70LBKBq9jwycQUhdVdMHK3APOVZNkSoX",
+	"I6kkiJumPHD4cp/WcJVOFOe/BfPv5tzxlTeZuCvLpwwtBwQKdrIO2VU7e52BVBwtB8seltpVhhpuvsjc",
+	"XMJhm0mUVtJviMjrXqc3R3y8DuwfGsb3bsMT9J7MRzXyzyi2Da4B0eJPW/go7xN5TzvuV+42xP1R3fKB",
+	"rmumNCu0GguTGFx3t5/e9Lj84kjLCm7GFP3py+NpNZLj0qCFcSrvkI1qXcGXq6eoVHR4u5R26Qppf/cN",
+	"Vaoucox+9vSpPIkunKIjIfJWy9rK4Hwjhnhwgq1Nunnw6VNCFJxkMco71GyrjmIfUmkzsKFH0Nc9X01o",
+	"SDlPMdvTQPAFNgnPfYU7KSArIHZufYiw0wymhIX7jCvm3qaHkeWzmQgNoW3B5+3T+zGm+nyiCQm9KhVJ",
+	"SVzqZV2ZgNR/GFodmZahvRp/OxUzF8N6YnA5dMeMANICUTYTRuqc/fC0z3I+T2MJG9BbfhXuFyHydReE",
+	"5eB3NCo+mOD3OJuHHPzOF2n73gDPRgv2RqZsIGjgmQeJOUuU6kXjiFh3DeIsfvL9ws1+72olBlsrOsXu",
+	"58Ud/HrL7jiQZDSnmv6htJMjP5tvgZMPfdXaW1Qv3ERI4/OyBRzsUcXAzCKC+MkWgaL9Jz6vkbQTypeb",
+	"gJWAWhoI7oTqsQ9p/4wbA47Iui5y7iv0zRlNckBFHds1hnRODZrDi000B/D71Ma2TofANB5c4tqS0hHm",
+	"idRRoUkwGrecPVQQ+hvTOJbVmYUhPRC1ZmlWD1m9UQ2Mcm80nNvVS5Zk5kZ6Vsr9TbrWFWg79T0F4dGo",
+	"8GReLJygraKz184R7aLdZ4IbwaKIWaNXqQXpF/Sr2lge1a3vVN2qU8f9De1o55hVihcWpl6DxkUehyaX",
+	"Z50xoall/QOa2C+KmgpyQHy73t9Y+4pNuTlFEwp/9Dw+NBpGSoPY/WWaWkm/JMS78UBZfYuY6HMCt19N",
+	"ydXp4pPTCYd5wPOxaLTNfcaXU2p95U+VK9Q9ruXsjBl3T9eeo7X+H+MKH/mWKiWA16lGckQnFziDPDzv",
+	"2oNowyOIwcvo1Fy8QPOcilJgYr8vViEpQRENBSuOsJS3/fG18hadvn+NGJJrzszHI3Nj1rs15y0G3S2M",
+	"KMmiefv6fgoGD+S6xIELksAK56Qa24vaA88ncjjByBglipqHUVrmAEKLD3TpCO9DnAkUUkaX48leyLyU",
+	"qstns0XDIVjkzsVgovWp7bE3qPv6big2mZXKySLt0ZVGWRAkejRqVA9Sljz0E+5cY6xKY3+PB/S3SQlm",
+	"40reV+N863QaA+l82euNOe2M6oISlyHrVLinpVvIMCZsHTKyh7Z77Kg0Ck7uwIDAUaR8K8/EdHKjHks/",
+	"oNGSTPG5KOSZ8HnYoOX7ZuJBL60fazWJtkIprSx79SkE7dx6c9FtG0sM/ywAW7301gHciyc2bqXPU6QU",
+	"jttPhEtyVgIhVRBzSgfOEBj6ibjhgo3lmVDMncvho1B8qEKReL1tRpWiYh1fUfN6fzw2YgwNlZgfT+j0",
+	"ILZybicISg+yTU6Fr/ZCdDcV3GKUF6ChVEFTw9IYVFfg/RKjMyvQm2brgxXmEEd4zfGv1MnmisQdzT3F",
+	"XYItldbJYW2j1+V/xBpg2AaFh0lDF7ymEn0eJGLlVfEzJVjfUkoH9p4C+L2ELQwZdoTH8/HwiCULtOVf",
+	"+K7FIrrJMXPAR97qcyUMI12F8A6hUi8tKl3lSg+idcNXTdzhh1F7L6zgulgROxNDkOgLbKrKqTByyN6+",
+	"ZuSIlYYRylgTB3vJutbS4xv1OAlMxzY/f/42w8/mSPcVviVQ5CO85XXDQnwO4B8rUQIvk07SdJJ+Y0rJ",
+	"0yaxfxToZMKteuJ8jlHOrFQ+dwoaYFKxt6MuIER13yPAyf1KbwnXAM+bd0L4PqJ7faPm5rFCQCx7yJ1G",
+	"u4XVU0GZfB5GPxeOy8IGDH+UYlNhxoJhQ+yHg19esR+fvnj+p70gAN0kPAQZKiyKUPKfeYbJAsYtiEA5",
+	"lI6psijYsBAcq6xEOGk2Mxqh8rHpHvusCnkq2KfPRxl+Pp25qpYMQSfJpPyg4W4S02g8ABfZjWkgPeBT",
+	"ZFHMOrbwUDqWa0E3kU+fj5bvDp/g/VtVUZdONpQ6nlzPhLFSq2QXpGUDTpUOMM7lzwgoTLMGMxWiuoTP",
+	"pA13KUKjFtbR5sMmSoe1H3Z3fuq1gQqHBV0fXnb1NiFYcNydpVMGKbaLc/5fl2/zTqROwu+0e4tocvfz",
+	"lJnR4j5eitZdiohj79ad6IbBN97UwPEkVkwgMHSNgj6uy/bOjeduerWwQSeMolXF4wYHufPTjfJbOOmI",
+	"++mcrGj+/t1uUSrHLW90xnhLJSoa0rpQqOiJTZFKPfJS1N7Rpvjrm5rxJt27l0wmN8alLc/oGfWclBlC",
+	"BWp3e4dZzYZaBYOlyKWzLNdwndBnwpwb6QQ5YJGm21wt90MBqZZhWQPxzx6YChI355ZKPK9UG7z/6SGo",
+	"DY8AthsrDp7RHjWHR83hUXNIHJiLYMTopSEYg1XerPf8NMVJQtSyBP2ALCdgqlj8Lf0ITQzEEPBSKOJX",
+	"nXNIDPitL/TToAj4Hq5bFbiciyyJ1avgVWjA1i/IY4RAgozjCe9+MpSnxGRf2yKS67xT+6zHflnBMUF2",
+	"BxK6DMf8cj/4pYlL+jd9XC8QZoIF/ci2jWz76KC+sNz4pS41Go9ikXcRJOnyaAceY4kkSoRSmmrrAioT",
+	"/eiLyjeCAfzix/IrDuUmhccG+f00wYeS1x9n85Dz+aP9KMh6nPW9yeiPHLkZZlHCPA8St4hINsir9cn1",
+	"Yy9FvlPQosdzsrEIV9tZ1XYwmm84E7G1pVvrilORvU5Ls9VB/Frrzv8SB3rHTsy4gg/m1KzN6OFXuafp",
+	"PiLg3NQJN0o4+VvL4gZ1oLV4cS1/PlRKfjwqH4/Kql5l8ONWdNl8SAIHfOMhufHF8RuOSBjmHTsiS/uQ",
+	"jsfSfgdH49KlEn1b9vGUvNGy/qvugY9H5eNReQu3yqaDbOnAnAljteJFdyCs2+BqGRp+Yhl8UQOgB481",
+	"5Th7/Pm5h4CFbFitBJMqox3E+ndcnQYWDe8/saxAdzNmgmK8OOBQjbhhAzGRPmDrXJsioMxSrnqPfTQ5",
+	"prMP5nibxvBwDMpSPqcJf35iY1dMwxftR/QnvzA/47rcnbzEbxG1YbNP4mZvJI/SpVgrjxb6+Cb588jc",
+	"q/XgsNaM1nqJtymNYjOm9ol4/psqG2TDlMCYpbEQQ5kBgyYZIdCVr3ad54ZqZGpiYqxMidoa8DzhUmgl",
+	"WvO4P/np3X7S4XXnz4WZ3vmz+y4lj93RvKyKeWsMt8y8pRmLVRFJn4SZchgeVoCd6jNRxU8g/LiN0ROI",
+	"QJ4mrvcYDFdBMRiEjMGoQThtYc6F5GqIp3xDGhSM6p5k6s+SBfLz/r6DGHCXjS7Ewii+r6BQHACcNk4W",
+	"RSiDDrQ/LS3ellNGISPPPYmz6CAfdxYzJpbYoC30ImBVtEJLfla5ZhwXyDeVsSlHAMlohTiTVg4KWlEO",
+	"/3CaFRrToxFQsqGqK/Z6x4TKDcXm+yV/FEyPgokGQAiXvmhIcmrdW/Hj2ZvxMJsW2VNetrQNfOmL6jsy",
+	"AAS3dlrYpvWef1Aqe3dSqpYt8ji9h2KQD5N5yPb4WO6Qii1q47Xz66xNtzTx/cIGkmF6YDUe+1ShSfZE",
+	"r143MilAifKHUlGoWlOoy2bBqBZsZy0DlWpYlLk4CR1+Y0mjReQeIwr/X6tLM6RwQTEdYB1nSp4EIyS3",
+	"fi5ZHC8hT4ABsMfewHunUlHFVQ212Vk5YxqmHHwJ5xM4i+J+DwspVMzXVELkjEdwzpnA0lebQvv4Nbo4",
+	"ts9Bqd76b28W3KduQjwppDpdO9h3+NKdDvILB86mi98Uwrihg8jQEbMsrh5dM9+z9RbJAl7Y8hl/q/Sf",
+	"0hC9+1czNpYO1n4qHUm3QSmLnJAwA4RRqRAhmAbUZEX9u+/3Gm8/vou3aqQ3pu8lmxnNLcnep2UL0Met",
+	"6xZdYUaM4YwGB1H4KGO6yKN62GNHaM+2YmiEo/NbYX56QOb1mgDiiwJ+QKNC+VsY0ZXK3HSeG4krP4y1",
+	"vprY8GOJkiu7td7bixoyS6SI1mS+A89KiK+h8pmWChESwXAmQn2TibbCo0fHygAecpxqNRNQqh4FcLEZ",
+	"n2MJdivH8SxBTYzG88R6zgzq6H92PY13D+VYcVca4ROVMSALOkIExomoBhmzb7my58JQJ5ztfPkSILiN",
+	"DH2LL7QHEpxrfHgK5QpARMRhWCqPHqWD9OXnA2u8ZBEY1uqpOJ8II9DBtSw4XoFIEYFnrwehotbHhUAq",
+	"tq9sDFEqLVOxf5TI6VvUcqSale5RtD0g0VbJrCBQ6grEWizrQ6dnIN5y0KdC9QZdNVcTOuRY+FcpSu9e",
+	"kwSEmBs9A8MLh7T4Kg4mysVCNyQvU3RpJRxW2qkCG92a3y0M4NHddles2mFH7lvOcDMjR0z580rD9Zr/",
+	"0uXmTvJM/yZO00dN/ZETr5sTKZSl/TTdyuOBuFn8GfiI9SgcrlYoh3YmOkyr+0VWO3c38PH4Za/O59sU",
+	"CBv4e/Lk9vJAvD71KT1k34+nXlhw0v/uTRZGnV0vYmXynDV/kDn+ddJNLBLfoT3/UXt41B6u0tbIE+te",
+	"In6+UoPmrPl4fqeHvGC5OBOFnk1B9Eb/RmmKzl5n4txsb2urgPcm2rq9n/o/9bfOtjtfs03byiK8WIrH",
+	"ODNiJL+s7qfz9fev/98A8YSVFT7sAgA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
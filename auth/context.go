@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Role is an elevated permission granted to a user; users without any grant
+// are regular users
+type Role string
+
+const (
+	// RoleModerator may verify and reject runs
+	RoleModerator Role = "moderator"
+
+	// RoleAdmin may do anything, including managing other users' accounts
+	RoleAdmin Role = "admin"
+
+	// RoleSuperModerator is held by a game's own moderators who may edit the
+	// game, review its runs, and add or remove its verifiers
+	RoleSuperModerator Role = "super_moderator"
+
+	// RoleVerifier is held by a game's own moderators who may only review
+	// its runs
+	RoleVerifier Role = "verifier"
+)
+
+// Grant gives a user a role, either everywhere or for a single game
+type Grant struct {
+	Role Role
+
+	// GameID scopes the grant to one game; zero applies it to every game
+	GameID int32
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	UserID int32
+	Grants []Grant
+
+	// APIKeyID is set when the caller authenticated with an API key rather
+	// than an access token
+	APIKeyID int32
+
+	// Scopes limits an API key to operations that accept one of them
+	Scopes []string
+}
+
+// ViaAPIKey reports whether the caller authenticated with an API key
+func (p Principal) ViaAPIKey() bool {
+	return p.APIKeyID != 0
+}
+
+// HasScope reports whether the caller's API key holds scope
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// HasRole reports whether the caller holds role for every game
+// Admins hold every role.
+func (p Principal) HasRole(role Role) bool {
+	for _, g := range p.Grants {
+		if g.GameID == 0 && (g.Role == role || g.Role == RoleAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanModerate reports whether the caller may moderate runs for gameID, either
+// through a grant for that game, including a place on its moderation team, or
+// a global moderator grant
+func (p Principal) CanModerate(gameID int32) bool {
+	if p.HasRole(RoleModerator) {
+		return true
+	}
+	for _, g := range p.Grants {
+		if g.GameID != gameID {
+			continue
+		}
+		switch g.Role {
+		case RoleModerator, RoleAdmin, RoleSuperModerator, RoleVerifier:
+			return true
+		}
+	}
+	return false
+}
+
+// CanManageGame reports whether the caller may edit gameID and its moderation
+// team, as admins and the game's super moderators may
+func (p Principal) CanManageGame(gameID int32) bool {
+	if p.HasRole(RoleAdmin) {
+		return true
+	}
+	for _, g := range p.Grants {
+		if g.GameID == gameID && (g.Role == RoleSuperModerator || g.Role == RoleAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the authenticated caller, if any
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
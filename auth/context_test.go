@@ -0,0 +1,51 @@
+package auth
+
+import "testing"
+
+func TestPrincipal_Roles(t *testing.T) {
+	user := Principal{UserID: 1}
+	gameMod := Principal{UserID: 2, Grants: []Grant{{Role: RoleModerator, GameID: 7}}}
+	globalMod := Principal{UserID: 3, Grants: []Grant{{Role: RoleModerator}}}
+	admin := Principal{UserID: 4, Grants: []Grant{{Role: RoleAdmin}}}
+	superMod := Principal{UserID: 5, Grants: []Grant{{Role: RoleSuperModerator, GameID: 7}}}
+	verifier := Principal{UserID: 6, Grants: []Grant{{Role: RoleVerifier, GameID: 7}}}
+
+	tests := []struct {
+		name          string
+		principal     Principal
+		isModerator   bool
+		isAdmin       bool
+		moderatesGame bool
+		moderatesElse bool
+		managesGame   bool
+	}{
+		{"regular user", user, false, false, false, false, false},
+		{"game moderator", gameMod, false, false, true, false, false},
+		{"global moderator", globalMod, true, false, true, true, false},
+		{"admin", admin, true, true, true, true, true},
+		{"super moderator", superMod, false, false, true, false, true},
+		{"verifier", verifier, false, false, true, false, false},
+	}
+
+	for _, tt := range tests {
+		p := tt.principal
+		if got := p.HasRole(RoleModerator); got != tt.isModerator {
+			t.Errorf("%s: HasRole(moderator) = %v, want %v", tt.name, got, tt.isModerator)
+		}
+		if got := p.HasRole(RoleAdmin); got != tt.isAdmin {
+			t.Errorf("%s: HasRole(admin) = %v, want %v", tt.name, got, tt.isAdmin)
+		}
+		if got := p.CanModerate(7); got != tt.moderatesGame {
+			t.Errorf("%s: CanModerate(7) = %v, want %v", tt.name, got, tt.moderatesGame)
+		}
+		if got := p.CanModerate(8); got != tt.moderatesElse {
+			t.Errorf("%s: CanModerate(8) = %v, want %v", tt.name, got, tt.moderatesElse)
+		}
+		if got := p.CanManageGame(7); got != tt.managesGame {
+			t.Errorf("%s: CanManageGame(7) = %v, want %v", tt.name, got, tt.managesGame)
+		}
+		if p.CanManageGame(8) && !tt.isAdmin {
+			t.Errorf("%s: CanManageGame(8) = true for a game it has no grant for", tt.name)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSigner(now time.Time) *Signer {
+	s := NewSigner([]byte("test-secret"), time.Hour)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSigner_RoundTrip(t *testing.T) {
+	s := newTestSigner(time.Now())
+
+	token, err := s.Issue(42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	p, err := s.Verify(token)
+	if err != nil {
+		t.Fatalf("expected token to verify, got %v", err)
+	}
+	if p.UserID != 42 {
+		t.Errorf("expected user 42, got %d", p.UserID)
+	}
+}
+
+func TestSigner_Expired(t *testing.T) {
+	issued := time.Now()
+	token, _ := newTestSigner(issued).Issue(42)
+
+	_, err := newTestSigner(issued.Add(time.Hour)).Verify(token)
+	if !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("expected ErrExpiredToken, got %v", err)
+	}
+}
+
+func TestSigner_Rejects(t *testing.T) {
+	s := newTestSigner(time.Now())
+	token, _ := s.Issue(42)
+	parts := strings.Split(token, ".")
+
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1","iat":0,"exp":99999999999}`))
+	otherKey, _ := NewSigner([]byte("other-secret"), time.Hour).Issue(42)
+
+	tests := map[string]string{
+		"empty":           "",
+		"two parts":       parts[0] + "." + parts[1],
+		"alg none":        noneHeader + "." + parts[1] + ".",
+		"swapped payload": parts[0] + "." + forged + "." + parts[2],
+		"other key":       otherKey,
+	}
+
+	for name, token := range tests {
+		if _, err := s.Verify(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
+
+func TestSigner_EmailVerificationRoundTrip(t *testing.T) {
+	s := newTestSigner(time.Now())
+
+	token, err := s.IssueEmailVerification(42, "jane@example.com", time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	userID, email, err := s.VerifyEmailVerification(token)
+	if err != nil {
+		t.Fatalf("expected token to verify, got %v", err)
+	}
+	if userID != 42 || email != "jane@example.com" {
+		t.Errorf("expected user 42 at jane@example.com, got %d at %s", userID, email)
+	}
+}
+
+func TestSigner_KeepsPurposesApart(t *testing.T) {
+	s := newTestSigner(time.Now())
+	access, _ := s.Issue(42)
+	verification, _ := s.IssueEmailVerification(42, "jane@example.com", time.Hour)
+
+	if _, err := s.Verify(verification); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected a verification token to be rejected as an access token, got %v", err)
+	}
+	if _, _, err := s.VerifyEmailVerification(access); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected an access token to be rejected as a verification token, got %v", err)
+	}
+}
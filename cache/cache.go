@@ -0,0 +1,160 @@
+// Package cache keeps copies of hot, rarely changing reads in front of the
+// database, either in process or in a Redis shared by every replica.
+//
+// Entries expire after a TTL, and writers invalidate the entries they change,
+// so the TTL only bounds how stale a read can be when an invalidation is
+// lost, e.g. because Redis was briefly unreachable, or when a read that
+// started before a write stores its result after the write invalidated it.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/example/speedrun-rest-api/metrics"
+)
+
+// Store holds cache entries
+type Store interface {
+	// Get returns the value stored under key and whether there was one
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+
+	// Incr adds one to the counter stored under key, which never expires,
+	// and returns its new value; a missing counter counts from zero
+	Incr(ctx context.Context, key string) (int64, error)
+
+	// Close releases the store's resources
+	Close() error
+}
+
+// Cache stores JSON-encoded values in a Store and counts hits and misses
+//
+// A nil *Cache is valid and caches nothing, so services can use one whether
+// or not caching is enabled. Store errors are logged and treated as misses,
+// so an outage of a shared store slows requests down instead of failing them.
+type Cache struct {
+	store    Store
+	ttl      time.Duration
+	requests *metrics.CounterVec
+}
+
+// New creates a Cache keeping entries in store for ttl
+func New(store Store, ttl time.Duration) *Cache {
+	return &Cache{
+		store: store,
+		ttl:   ttl,
+		requests: metrics.NewCounterVec("cache_requests_total",
+			"Number of cache lookups, by cache and result (hit, miss, or error).",
+			"cache", "result",
+		),
+	}
+}
+
+// Get decodes the value stored under key into v and reports whether there
+// was one; name labels the lookup in the hit and miss counts
+func (c *Cache) Get(ctx context.Context, name, key string, v any) bool {
+	if c == nil {
+		return false
+	}
+
+	data, ok, err := c.store.Get(ctx, key)
+	if err == nil && ok {
+		err = json.Unmarshal(data, v)
+	}
+	switch {
+	case err != nil:
+		slog.WarnContext(ctx, "Failed to read from cache", "key", key, "error", err)
+		c.requests.Inc(name, "error")
+		return false
+	case !ok:
+		c.requests.Inc(name, "miss")
+		return false
+	default:
+		c.requests.Inc(name, "hit")
+		return true
+	}
+}
+
+// Set stores v under key
+func (c *Cache) Set(ctx context.Context, key string, v any) {
+	if c == nil {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = c.store.Set(ctx, key, data, c.ttl)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to write to cache", "key", key, "error", err)
+	}
+}
+
+// Delete removes the entries stored under keys
+func (c *Cache) Delete(ctx context.Context, keys ...string) {
+	if c == nil {
+		return
+	}
+
+	if err := c.store.Delete(ctx, keys...); err != nil {
+		slog.ErrorContext(ctx, "Failed to invalidate cache", "keys", keys, "error", err)
+	}
+}
+
+// Version returns the current value of the version counter stored under key
+//
+// Entries derived from many rows, such as a page of a listing, are stored
+// under keys that include a version; Bump then invalidates all of them at
+// once. ok is false if the version could not be read, in which case nothing
+// should be cached.
+func (c *Cache) Version(ctx context.Context, key string) (version string, ok bool) {
+	if c == nil {
+		return "", false
+	}
+
+	data, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to read cache version", "key", key, "error", err)
+		return "", false
+	}
+	if !found {
+		return "0", true
+	}
+	return string(data), true
+}
+
+// Bump advances the version counter stored under key, invalidating every
+// entry stored under the previous version
+func (c *Cache) Bump(ctx context.Context, key string) {
+	if c == nil {
+		return
+	}
+
+	if _, err := c.store.Incr(ctx, key); err != nil {
+		slog.ErrorContext(ctx, "Failed to invalidate cache", "keys", []string{key}, "error", err)
+	}
+}
+
+// Collect writes the hit and miss counts
+func (c *Cache) Collect(w *metrics.Writer) {
+	if c == nil {
+		return
+	}
+	c.requests.Collect(w)
+}
+
+// Close closes the store
+func (c *Cache) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.store.Close()
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/metrics"
+)
+
+type game struct {
+	Slug string `json:"slug"`
+}
+
+func TestCache_GetSet(t *testing.T) {
+	ctx := context.Background()
+	c := New(NewMemory(), time.Minute)
+
+	var got game
+	if c.Get(ctx, "game", "game:celeste", &got) {
+		t.Fatal("expected a miss before anything is stored")
+	}
+	c.Set(ctx, "game:celeste", game{Slug: "celeste"})
+	if !c.Get(ctx, "game", "game:celeste", &got) || got.Slug != "celeste" {
+		t.Fatalf("expected a hit, got %+v", got)
+	}
+	c.Delete(ctx, "game:celeste")
+	if c.Get(ctx, "game", "game:celeste", &got) {
+		t.Error("expected a miss after the entry was deleted")
+	}
+
+	registry := metrics.NewRegistry()
+	registry.Register(c)
+	var out bytes.Buffer
+	registry.WriteTo(&out)
+	for _, want := range []string{
+		`cache_requests_total{cache="game",result="hit"} 1`,
+		`cache_requests_total{cache="game",result="miss"} 2`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCache_Expires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+	c := New(store, time.Minute)
+
+	c.Set(ctx, "user:1", game{})
+	now = now.Add(time.Minute)
+	var got game
+	if c.Get(ctx, "user", "user:1", &got) {
+		t.Error("expected the entry to expire after its TTL")
+	}
+}
+
+func TestCache_Version(t *testing.T) {
+	ctx := context.Background()
+	c := New(NewMemory(), time.Minute)
+
+	v, ok := c.Version(ctx, "leaderboard:1")
+	if !ok || v != "0" {
+		t.Fatalf("expected version 0 before any bump, got %q %v", v, ok)
+	}
+	c.Bump(ctx, "leaderboard:1")
+	c.Bump(ctx, "leaderboard:1")
+	if v, _ := c.Version(ctx, "leaderboard:1"); v != "2" {
+		t.Errorf("expected version 2 after two bumps, got %q", v)
+	}
+}
+
+func TestCache_Nil(t *testing.T) {
+	ctx := context.Background()
+	var c *Cache
+
+	c.Set(ctx, "game:celeste", game{Slug: "celeste"})
+	var got game
+	if c.Get(ctx, "game", "game:celeste", &got) {
+		t.Error("expected a nil cache to miss")
+	}
+	if _, ok := c.Version(ctx, "leaderboard:1"); ok {
+		t.Error("expected a nil cache to have no versions")
+	}
+	c.Bump(ctx, "leaderboard:1")
+	c.Delete(ctx, "game:celeste")
+	if err := c.Close(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
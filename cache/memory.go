@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// sweepInterval is how often the memory store drops expired entries
+	sweepInterval = time.Minute
+
+	// maxMemoryEntries bounds the memory store; once it is full new entries
+	// are not stored until expired ones have been swept, so clients asking
+	// for many distinct pages can't exhaust memory
+	maxMemoryEntries = 10000
+)
+
+// entry is one value in the memory store
+type entry struct {
+	value []byte
+
+	// expires is when the entry stops being returned; zero for counters,
+	// which never expire
+	expires time.Time
+}
+
+// Memory is a Store that keeps entries in process
+// Invalidations only reach the replica that made the change, so other
+// replicas may serve stale entries until they expire.
+type Memory struct {
+	mu        sync.Mutex
+	entries   map[string]entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemory creates an empty in-process Store
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]entry), now: time.Now}
+}
+
+// Get returns the value stored under key and whether there was one
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok || m.expired(e, m.now()) {
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+// Set stores value under key for ttl
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+	if _, ok := m.entries[key]; !ok && len(m.entries) >= maxMemoryEntries {
+		return nil
+	}
+	m.entries[key] = entry{value: value, expires: now.Add(ttl)}
+	return nil
+}
+
+// Delete removes keys
+func (m *Memory) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
+
+// Incr adds one to the counter stored under key
+func (m *Memory) Incr(ctx context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	if e, ok := m.entries[key]; ok && !m.expired(e, m.now()) {
+		n, _ = strconv.ParseInt(string(e.value), 10, 64)
+	}
+	n++
+	m.entries[key] = entry{value: []byte(strconv.FormatInt(n, 10))}
+	return n, nil
+}
+
+// expired reports whether e has expired at now
+func (m *Memory) expired(e entry, now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// sweep drops expired entries at most once per sweepInterval
+// The caller must hold m.mu.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+	for key, e := range m.entries {
+		if m.expired(e, now) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// Close is a no-op; it exists to satisfy Store
+func (m *Memory) Close() error {
+	return nil
+}
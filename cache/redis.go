@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/example/speedrun-rest-api/redis"
+)
+
+// redisKeyPrefix namespaces cache keys in a shared Redis
+const redisKeyPrefix = "cache:"
+
+// Redis is a Store that keeps entries in Redis, so every replica shares them
+// and sees the others' invalidations
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis creates a Store for a URL of the form
+// redis://[[user]:password@]host[:port][/db]; use rediss:// for TLS
+// Connections are opened on first use.
+func NewRedis(rawURL string) (*Redis, error) {
+	client, err := redis.NewClient(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Redis{client: client}, nil
+}
+
+// Get returns the value stored under key and whether there was one
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis GET: %w", err)
+	}
+	return value, true, nil
+}
+
+// Set stores value under key for ttl
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := r.client.Set(ctx, redisKeyPrefix+key, value, max(ttl, time.Millisecond)).Err(); err != nil {
+		return fmt.Errorf("redis SET: %w", err)
+	}
+	return nil
+}
+
+// Delete removes keys
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = redisKeyPrefix + key
+	}
+	if err := r.client.Del(ctx, prefixed...).Err(); err != nil {
+		return fmt.Errorf("redis DEL: %w", err)
+	}
+	return nil
+}
+
+// Incr adds one to the counter stored under key
+func (r *Redis) Incr(ctx context.Context, key string) (int64, error) {
+	n, err := r.client.Incr(ctx, redisKeyPrefix+key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis INCR: %w", err)
+	}
+	return n, nil
+}
+
+// Close closes the connections to Redis
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestRedis_Commands(t *testing.T) {
+	server := miniredis.RunT(t)
+	r, err := NewRedis("redis://" + server.Addr())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer r.Close()
+	ctx := context.Background()
+
+	if err := r.Set(ctx, "game:celeste", []byte(`{"a"`), 90*time.Second); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ttl := server.TTL("cache:game:celeste"); ttl != 90*time.Second {
+		t.Errorf("expected a TTL of 90s, got %v", ttl)
+	}
+	if value, ok, err := r.Get(ctx, "game:celeste"); err != nil || !ok || string(value) != `{"a"` {
+		t.Errorf("expected the stored value, got %q %v %v", value, ok, err)
+	}
+	if _, ok, err := r.Get(ctx, "game:missing"); err != nil || ok {
+		t.Errorf("expected a miss, got %v %v", ok, err)
+	}
+
+	server.Set("cache:user:1", "a")
+	server.Set("cache:user:2", "b")
+	if err := r.Delete(ctx, "user:1", "user:2"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if server.Exists("cache:user:1") || server.Exists("cache:user:2") {
+		t.Error("expected both keys to be deleted")
+	}
+
+	server.Set("cache:leaderboard:1", "2")
+	if n, err := r.Incr(ctx, "leaderboard:1"); err != nil || n != 3 {
+		t.Errorf("expected 3, got %d %v", n, err)
+	}
+}
+
+func TestRedis_ErrorReply(t *testing.T) {
+	server := miniredis.RunT(t)
+	r, _ := NewRedis("redis://" + server.Addr())
+	defer r.Close()
+
+	server.SetError("ERR wrong type")
+	if _, _, err := r.Get(context.Background(), "game:celeste"); err == nil {
+		t.Error("expected the error reply to be returned")
+	}
+}
@@ -0,0 +1,123 @@
+// Package client is a Go SDK for the speedrun API.
+//
+// It wraps a client generated from openapi.yaml with typed methods grouped
+// by resource, authentication, retries of requests that are safe to send
+// again, and iterators that follow pagination cursors:
+//
+//	c, err := client.New("https://speedrun.example.com", client.WithAPIKey("srk_..."))
+//	if err != nil {
+//		return err
+//	}
+//	user, err := c.Users.Get(ctx, 4)
+//	for run, err := range c.Runs.ListByUser(ctx, 4, nil) {
+//		...
+//	}
+//
+// Errors returned by the API are *APIError values carrying the response's
+// status and error code.
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/client/internal/openapi"
+)
+
+// apiVersionPrefix is the path of the API version the client was generated
+// from
+const apiVersionPrefix = "/v1"
+
+// Client calls the speedrun API
+type Client struct {
+	// Users looks up and lists users
+	Users *UsersService
+
+	// Runs submits, reviews, and lists runs
+	Runs *RunsService
+}
+
+// settings are the options a Client is created with
+type settings struct {
+	httpClient    *http.Client
+	authorization string
+	maxRetries    int
+	retryWait     time.Duration
+}
+
+// Option configures a Client
+type Option func(*settings)
+
+// WithToken authenticates requests with an access token from POST
+// /auth/login
+func WithToken(token string) Option {
+	return func(s *settings) {
+		s.authorization = "Bearer " + token
+	}
+}
+
+// WithAPIKey authenticates requests with an API key; it is only accepted
+// by operations that allow one of its scopes
+func WithAPIKey(key string) Option {
+	return func(s *settings) {
+		s.authorization = "ApiKey " + key
+	}
+}
+
+// WithHTTPClient sends requests through httpClient instead of
+// http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(s *settings) {
+		s.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times a failed request is retried, and the
+// wait before the first retry, which doubles for each one after; zero
+// disables retries
+func WithRetries(maxRetries int, wait time.Duration) Option {
+	return func(s *settings) {
+		s.maxRetries = maxRetries
+		s.retryWait = wait
+	}
+}
+
+// New creates a Client for the API served at baseURL, such as
+// "https://speedrun.example.com"
+// Requests go to version 1 of the API, under /v1, which the client was
+// generated from. By default they are anonymous and retried up to three
+// times.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	s := settings{
+		httpClient: http.DefaultClient,
+		maxRetries: 3,
+		retryWait:  500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	api, err := openapi.NewClientWithResponses(strings.TrimSuffix(baseURL, "/")+apiVersionPrefix,
+		openapi.WithHTTPClient(&retrier{
+			doer:       s.httpClient,
+			maxRetries: s.maxRetries,
+			wait:       s.retryWait,
+		}),
+		openapi.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Accept", "application/json")
+			if s.authorization != "" {
+				req.Header.Set("Authorization", s.authorization)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Users: &UsersService{api: api},
+		Runs:  &RunsService{api: api},
+	}, nil
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// newTestClient creates a Client for a fake API served by handler, retrying
+// without waiting
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL, append([]Option{WithRetries(3, 0)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestUsersGet_SendsAuthorization(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+		want string
+	}{
+		{name: "token", opt: WithToken("abc"), want: "Bearer abc"},
+		{name: "api key", opt: WithAPIKey("srk_abc"), want: "ApiKey srk_abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tt.want {
+					t.Errorf("Authorization = %q, want %q", got, tt.want)
+				}
+				if r.URL.Path != "/v1/users/4" {
+					t.Errorf("path = %q, want /v1/users/4", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"id":4,"name":"Ada","email":"ada@example.com"}`)
+			}, tt.opt)
+
+			user, err := c.Users.Get(context.Background(), 4)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if user.Id != 4 || user.Name != "Ada" {
+				t.Errorf("user = %+v, want user 4 named Ada", user)
+			}
+		})
+	}
+}
+
+func TestUsersGet_APIError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type":"/problems/user-not-found","title":"Not Found","status":404,"detail":"User not found","instance":"/users/4","code":"USER_NOT_FOUND"}`)
+	})
+
+	_, err := c.Users.Get(context.Background(), 4)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "USER_NOT_FOUND" || apiErr.Message != "User not found" {
+		t.Errorf("err = %+v, want 404 USER_NOT_FOUND", apiErr)
+	}
+	if apiErr.Type != "/problems/user-not-found" || apiErr.Instance != "/users/4" {
+		t.Errorf("err = %+v, want the problem's type and instance", apiErr)
+	}
+}
+
+func TestRunsSubmit_ValidationErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"Request does not match the API specification","instance":"/games/celeste/categories/any/runs","code":"INVALID_REQUEST","errors":[{"in":"body","name":"time_ms","message":"must be at least 1"}]}`)
+	})
+
+	_, err := c.Runs.Submit(context.Background(), "celeste", "any", SubmitRunRequest{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if len(apiErr.Errors) != 1 || apiErr.Errors[0].Name == nil || *apiErr.Errors[0].Name != "time_ms" {
+		t.Errorf("Errors = %+v, want one for time_ms", apiErr.Errors)
+	}
+}
+
+func TestRunsListByUser_FollowsCursors(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"runs":[{"id":1},{"id":2}],"next_cursor":"c2"}`,
+		"c2": `{"runs":[{"id":3}],"next_cursor":"c3"}`,
+		"c3": `{"runs":[{"id":4}]}`,
+	}
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want 2", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, pages[r.URL.Query().Get("cursor")])
+	})
+
+	var ids []int
+	for run, err := range c.Runs.ListByUser(context.Background(), 7, &ListRunsOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("ListByUser: %v", err)
+		}
+		ids = append(ids, run.Id)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4]" {
+		t.Errorf("ids = %v, want [1 2 3 4]", ids)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestRunsListByUser_StopsFetchingOnBreak(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"runs":[{"id":1},{"id":2}],"next_cursor":"more"}`)
+	})
+
+	for range c.Runs.ListByUser(context.Background(), 7, nil) {
+		break
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestUsersGet_RetriesAfterRetryAfter(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":4,"name":"Ada"}`)
+	})
+
+	if _, err := c.Users.Get(context.Background(), 4); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestUsersGet_GivesUpAfterMaxRetries(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := c.Users.Get(context.Background(), 4)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("err = %v, want a 502 *APIError", err)
+	}
+	if requests != 4 {
+		t.Errorf("requests = %d, want 4", requests)
+	}
+}
+
+func TestRunsSubmit_RetriesWithSameIdempotencyKey(t *testing.T) {
+	var keys []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":9,"status":"pending"}`)
+	})
+
+	run, err := c.Runs.Submit(context.Background(), "celeste", "any", SubmitRunRequest{
+		UserId:   7,
+		Platform: "pc",
+		VideoUrl: "https://example.com/v",
+		PlayedOn: openapi_types.Date{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if run.Id != 9 {
+		t.Errorf("run.Id = %d, want 9", run.Id)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Idempotency-Keys = %q, want the same key sent twice", keys)
+	}
+}
+
+func TestShouldRetry_DoesNotRepeatUnsafeRequests(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/runs/1/verify", nil)
+	resp := &http.Response{StatusCode: http.StatusBadGateway}
+	if shouldRetry(req, resp, nil) {
+		t.Error("shouldRetry = true for a POST without an Idempotency-Key after a 502")
+	}
+
+	resp.StatusCode = http.StatusTooManyRequests
+	if !shouldRetry(req, resp, nil) {
+		t.Error("shouldRetry = false for a POST after a 429")
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/client/internal/openapi"
+)
+
+// APIError is an error response from the API, read from the RFC 9457
+// problem details it carries
+type APIError struct {
+	// StatusCode is the response's HTTP status
+	StatusCode int
+
+	// Code is the API's error code, such as USER_NOT_FOUND; empty when the
+	// response didn't carry one
+	Code string
+
+	// Type is the URI reference identifying the kind of problem, such as
+	// /problems/user-not-found
+	Type string
+
+	// Message describes the error
+	Message string
+
+	// Instance is the path of the request the problem occurred on
+	Instance string
+
+	// Errors lists the fields a request failed validation on
+	Errors []ErrorDetail
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("speedrun api: %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("speedrun api: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// newAPIError reads the error out of a response that was not a success
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	var problem openapi.Problem
+	if json.Unmarshal(body, &problem) != nil {
+		return apiErr
+	}
+	apiErr.Code = problem.Code
+	apiErr.Type = problem.Type
+	apiErr.Instance = problem.Instance
+	if problem.Detail != "" {
+		apiErr.Message = problem.Detail
+	} else if problem.Title != "" {
+		apiErr.Message = problem.Title
+	}
+	if problem.Errors != nil {
+		apiErr.Errors = *problem.Errors
+	}
+	return apiErr
+}
@@ -0,0 +1,28 @@
+package client
+
+import "github.com/example/speedrun-rest-api/client/internal/openapi"
+
+// The models are the ones generated from the OpenAPI spec; see openapi.yaml
+// for what each field holds.
+type (
+	// User is a user account
+	User = openapi.User
+
+	// Run is a submitted run
+	Run = openapi.Run
+
+	// RunTimes holds a run's time by each timing method it was timed with
+	RunTimes = openapi.RunTimes
+
+	// SubmitRunRequest describes a run being submitted
+	SubmitRunRequest = openapi.SubmitRunRequest
+
+	// LeaderboardEntry is a runner's standing on a leaderboard
+	LeaderboardEntry = openapi.LeaderboardEntry
+
+	// PersonalBest is a user's best verified run in a category
+	PersonalBest = openapi.PersonalBest
+
+	// ErrorDetail describes one field a request failed validation on
+	ErrorDetail = openapi.ErrorDetail
+)
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// page is one page of a list and the cursor of the page after it, nil on
+// the last page
+type page[T any] struct {
+	items []T
+	next  *string
+}
+
+// paginate iterates over the items of a list, fetching its pages one at a
+// time as the loop reaches them; fetch is given the cursor of the page to
+// fetch, nil for the first
+// An error ends the iteration after being yielded.
+func paginate[T any](ctx context.Context, fetch func(ctx context.Context, cursor *string) (page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var cursor *string
+		for {
+			p, err := fetch(ctx, cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range p.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if p.next == nil || *p.next == "" {
+				return
+			}
+			cursor = p.next
+		}
+	}
+}
+
+// items dereferences the optional list of a page
+func items[T any](list *[]T) []T {
+	if list == nil {
+		return nil
+	}
+	return *list
+}
+
+// checkResponse returns an *APIError for a response that was not a success
+func checkResponse(resp *http.Response, body []byte) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return newAPIError(resp, body)
+}
+
+// optional returns a pointer to v, or nil for its zero value
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/example/speedrun-rest-api/client/internal/openapi"
+)
+
+// retrier sends requests through doer, sending them again after responses
+// and errors that may succeed on a later attempt
+type retrier struct {
+	doer       openapi.HttpRequestDoer
+	maxRetries int
+	wait       time.Duration
+}
+
+// Do implements openapi.HttpRequestDoer
+func (r *retrier) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := r.doer.Do(req)
+		if attempt >= r.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := r.wait << attempt
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				wait = after
+			}
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether a request that got resp or err is worth
+// sending again
+// Rate limiting and unavailability mean the request wasn't handled, so any
+// request is retried after them; gateway and network errors may happen after
+// the request took effect, so only requests that are safe to repeat are
+// retried after those.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body was consumed and can't be sent again
+		return false
+	}
+	if err == nil {
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+		default:
+			return false
+		}
+	}
+	return req.Method == http.MethodGet || req.Method == http.MethodHead || req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryAfter returns how long the Retry-After header of resp asks to wait
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d, or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
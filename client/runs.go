@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"iter"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/client/internal/openapi"
+)
+
+// RunsService submits, reviews, and lists runs
+type RunsService struct {
+	api *openapi.ClientWithResponses
+}
+
+// ListRunsOptions filters and pages the runs ListByUser and ListByCategory
+// iterate over
+type ListRunsOptions struct {
+	// IncludeObsolete also lists verified runs the runner has since beaten
+	// in the same category
+	IncludeObsolete bool
+
+	// PageSize is how many runs are fetched per request; zero uses the
+	// server's default
+	PageSize int
+}
+
+// LeaderboardOptions filters and pages the standings Leaderboard iterates
+// over
+type LeaderboardOptions struct {
+	// Variables only ranks runs played with these variable values, each
+	// given as variable:value, e.g. difficulty:hard
+	Variables []string
+
+	// Platform only ranks runs played on the platform with this slug
+	Platform string
+
+	// Region only ranks runs played in the region with this slug
+	Region string
+
+	// PageSize is how many standings are fetched per request; zero uses the
+	// server's default
+	PageSize int
+}
+
+// Submit submits a run in a game's category for review
+// The request carries an Idempotency-Key, so retrying it after a network
+// error can't submit the run twice.
+func (s *RunsService) Submit(ctx context.Context, game, category string, run SubmitRunRequest) (*Run, error) {
+	resp, err := s.api.SubmitRunWithResponse(ctx, game, category, run, withIdempotencyKey())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	return resp.JSON201, nil
+}
+
+// Verify marks a pending run as verified; it requires the moderator role
+func (s *RunsService) Verify(ctx context.Context, id int) (*Run, error) {
+	resp, err := s.api.VerifyRunWithResponse(ctx, id, withIdempotencyKey())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	return resp.JSON200, nil
+}
+
+// Reject marks a pending run as rejected for a reason; it requires the
+// moderator role
+func (s *RunsService) Reject(ctx context.Context, id int, reason string) (*Run, error) {
+	resp, err := s.api.RejectRunWithResponse(ctx, id, openapi.RejectRunRequest{Reason: reason}, withIdempotencyKey())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	return resp.JSON200, nil
+}
+
+// ListByUser iterates over a user's runs, newest first
+func (s *RunsService) ListByUser(ctx context.Context, userID int, opts *ListRunsOptions) iter.Seq2[Run, error] {
+	if opts == nil {
+		opts = &ListRunsOptions{}
+	}
+	return paginate(ctx, func(ctx context.Context, cursor *string) (page[Run], error) {
+		resp, err := s.api.ListUserRunsWithResponse(ctx, userID, &openapi.ListUserRunsParams{
+			Limit:           optional(opts.PageSize),
+			Cursor:          cursor,
+			IncludeObsolete: optional(opts.IncludeObsolete),
+		})
+		if err != nil {
+			return page[Run]{}, err
+		}
+		if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return page[Run]{}, err
+		}
+		return page[Run]{items: items(resp.JSON200.Runs), next: resp.JSON200.NextCursor}, nil
+	})
+}
+
+// ListByCategory iterates over the runs of a game's category, newest first
+func (s *RunsService) ListByCategory(ctx context.Context, game, category string, opts *ListRunsOptions) iter.Seq2[Run, error] {
+	if opts == nil {
+		opts = &ListRunsOptions{}
+	}
+	return paginate(ctx, func(ctx context.Context, cursor *string) (page[Run], error) {
+		resp, err := s.api.ListCategoryRunsWithResponse(ctx, game, category, &openapi.ListCategoryRunsParams{
+			Limit:           optional(opts.PageSize),
+			Cursor:          cursor,
+			IncludeObsolete: optional(opts.IncludeObsolete),
+		})
+		if err != nil {
+			return page[Run]{}, err
+		}
+		if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return page[Run]{}, err
+		}
+		return page[Run]{items: items(resp.JSON200.Runs), next: resp.JSON200.NextCursor}, nil
+	})
+}
+
+// Leaderboard iterates over the standings of a game's category, fastest
+// first
+func (s *RunsService) Leaderboard(ctx context.Context, game, category string, opts *LeaderboardOptions) iter.Seq2[LeaderboardEntry, error] {
+	if opts == nil {
+		opts = &LeaderboardOptions{}
+	}
+	params := openapi.GetLeaderboardParams{
+		Limit:    optional(opts.PageSize),
+		Platform: optional(opts.Platform),
+		Region:   optional(opts.Region),
+	}
+	if len(opts.Variables) > 0 {
+		params.Variable = &opts.Variables
+	}
+	return paginate(ctx, func(ctx context.Context, cursor *string) (page[LeaderboardEntry], error) {
+		params := params
+		params.Cursor = cursor
+		resp, err := s.api.GetLeaderboardWithResponse(ctx, game, category, &params)
+		if err != nil {
+			return page[LeaderboardEntry]{}, err
+		}
+		if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return page[LeaderboardEntry]{}, err
+		}
+		return page[LeaderboardEntry]{items: items(resp.JSON200.Entries), next: resp.JSON200.NextCursor}, nil
+	})
+}
+
+// PersonalBests returns a user's best verified run in each category they
+// have run
+func (s *RunsService) PersonalBests(ctx context.Context, userID int) ([]PersonalBest, error) {
+	resp, err := s.api.ListUserPersonalBestsWithResponse(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	return resp.JSON200.PersonalBests, nil
+}
+
+// withIdempotencyKey gives a request a random Idempotency-Key, so the server
+// handles it once however many times it is retried
+func withIdempotencyKey() openapi.RequestEditorFn {
+	key := rand.Text()
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Idempotency-Key", key)
+		return nil
+	}
+}
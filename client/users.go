@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"strconv"
+
+	"github.com/example/speedrun-rest-api/client/internal/openapi"
+)
+
+// UsersService looks up and lists users
+type UsersService struct {
+	api *openapi.ClientWithResponses
+}
+
+// ListUsersOptions filters and pages the users List iterates over
+type ListUsersOptions struct {
+	// Name only lists users whose name contains it, ignoring case
+	Name string
+
+	// Corporate only lists corporate (true) or non-corporate (false)
+	// accounts when set
+	Corporate *bool
+
+	// Sort is the column to sort by, optionally followed by :asc or :desc
+	Sort string
+
+	// PageSize is how many users are fetched per request; zero uses the
+	// server's default
+	PageSize int
+}
+
+// Get returns the user with an ID
+func (s *UsersService) Get(ctx context.Context, id int) (*User, error) {
+	return s.get(ctx, strconv.Itoa(id))
+}
+
+// GetByPublicID returns the user with a public ID, the UUID that identifies
+// a user without revealing how many accounts there are
+func (s *UsersService) GetByPublicID(ctx context.Context, publicID string) (*User, error) {
+	return s.get(ctx, publicID)
+}
+
+// get returns the user identified by id, either form of ID
+func (s *UsersService) get(ctx context.Context, id string) (*User, error) {
+	resp, err := s.api.GetUserWithResponse(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	return resp.JSON200, nil
+}
+
+// BatchGet returns the users with the given IDs in the order they were
+// asked for, and the IDs that matched no user
+func (s *UsersService) BatchGet(ctx context.Context, ids []int) (users []User, missing []int, err error) {
+	resp, err := s.api.BatchGetUsersWithResponse(ctx, &openapi.BatchGetUsersParams{Ids: ids})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, nil, err
+	}
+	return resp.JSON200.Users, resp.JSON200.MissingIds, nil
+}
+
+// List iterates over users, fetching further pages as the loop reaches them
+func (s *UsersService) List(ctx context.Context, opts *ListUsersOptions) iter.Seq2[User, error] {
+	if opts == nil {
+		opts = &ListUsersOptions{}
+	}
+	return paginate(ctx, func(ctx context.Context, cursor *string) (page[User], error) {
+		resp, err := s.api.ListUsersWithResponse(ctx, &openapi.ListUsersParams{
+			Limit:     optional(opts.PageSize),
+			Cursor:    cursor,
+			Name:      optional(opts.Name),
+			Corporate: opts.Corporate,
+			Sort:      optional(opts.Sort),
+		})
+		if err != nil {
+			return page[User]{}, err
+		}
+		if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return page[User]{}, err
+		}
+		return page[User]{items: items(resp.JSON200.Users), next: resp.JSON200.NextCursor}, nil
+	})
+}
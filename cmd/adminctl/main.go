@@ -0,0 +1,299 @@
+// Command adminctl manages users, roles, runs, and sessions through the same
+// services as the API, so operators never have to write SQL by hand.
+//
+// Usage:
+//
+//	adminctl user create -name NAME -email EMAIL [-password-stdin]
+//	adminctl user promote -role admin|moderator [-game SLUG] USER_ID
+//	adminctl run verify RUN_ID
+//	adminctl token revoke USER_ID
+//	adminctl db seed
+//
+// The database is taken from the same configuration as the API server, e.g.
+// DATABASE_URL or a file named by CONFIG_FILE. Commands run as an admin that
+// is not any user, so the audit log records their changes without an actor.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/fixtures"
+	"github.com/example/speedrun-rest-api/logging"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// action carries out a command once its arguments have been parsed
+type action func(ctx context.Context, a *admin) error
+
+// commands parse their arguments into an action, keyed by "noun verb"
+var commands = map[string]func(args []string) (action, error){
+	"user create":  userCreate,
+	"user promote": userPromote,
+	"run verify":   runVerify,
+	"token revoke": tokenRevoke,
+	"db seed":      dbSeed,
+}
+
+// admin holds the services commands are carried out with
+type admin struct {
+	store db.Store
+	users *service.UserService
+	games *service.GameService
+	runs  *service.RunService
+	auth  *service.AuthService
+}
+
+func main() {
+	slog.SetDefault(logging.New(os.Stderr))
+
+	if len(os.Args) < 3 {
+		usage()
+	}
+	parse, ok := commands[os.Args[1]+" "+os.Args[2]]
+	if !ok {
+		usage()
+	}
+	run, err := parse(os.Args[3:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fatal("Invalid DATABASE_URL", err)
+	}
+	defer pool.Close()
+	if err := pool.Ping(ctx); err != nil {
+		fatal("Unable to connect to database", err)
+	}
+
+	ctx = auth.WithPrincipal(ctx, auth.Principal{Grants: []auth.Grant{{Role: auth.RoleAdmin}}})
+	if err := run(ctx, newAdmin(db.NewStore(pool), cfg)); err != nil {
+		fatal("Command failed", err)
+	}
+}
+
+// newAdmin creates the services configured like the API server's; no mail
+// is sent and no cache is invalidated, so cached reads catch up within
+// CACHE_TTL
+func newAdmin(queries db.Store, cfg *config.Config) *admin {
+	return &admin{
+		store: queries,
+		users: service.NewUserService(queries,
+			service.WithMaxNameLength(cfg.MaxNameLength),
+			service.WithCorporateDomains(cfg.CorporateDomains),
+			service.WithPasswordCost(cfg.PasswordHashCost),
+		),
+		games: service.NewGameService(queries),
+		runs:  service.NewRunService(queries),
+		auth:  service.NewAuthService(queries, auth.NewSigner([]byte(cfg.JWTSecret), cfg.AccessTokenTTL)),
+	}
+}
+
+// userCreate creates a user, optionally with a password read from stdin so
+// it never shows up in process listings
+func userCreate(args []string) (action, error) {
+	fs := newFlagSet("user create", "-name NAME -email EMAIL [-password-stdin]")
+	name := fs.String("name", "", "the user's name")
+	email := fs.String("email", "", "the user's email address")
+	passwordStdin := fs.Bool("password-stdin", false, "read a password to log in with from stdin")
+	if err := parseArgs(fs, args, 0); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, a *admin) error {
+		var user *db.User
+		var err error
+		if *passwordStdin {
+			password, readErr := readPassword()
+			if readErr != nil {
+				return readErr
+			}
+			user, err = a.users.Register(ctx, *name, *email, password)
+		} else {
+			user, err = a.users.CreateUser(ctx, *name, *email)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created user %d <%s>\n", user.ID, user.Email)
+		return nil
+	}, nil
+}
+
+// userPromote grants a user a role, for every game unless -game is given
+func userPromote(args []string) (action, error) {
+	fs := newFlagSet("user promote", "-role admin|moderator [-game SLUG] USER_ID")
+	role := fs.String("role", "", "the role to grant: admin or moderator")
+	game := fs.String("game", "", "slug of the only game the role applies to")
+	if err := parseArgs(fs, args, 1); err != nil {
+		return nil, err
+	}
+	id, err := parseID(fs, "USER_ID")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, a *admin) error {
+		var gameID int32
+		if *game != "" {
+			g, err := a.games.GetGameBySlug(ctx, *game)
+			if err != nil {
+				return err
+			}
+			gameID = g.ID
+		}
+		if _, err := a.users.GrantRole(ctx, id, auth.Role(*role), gameID); err != nil {
+			return err
+		}
+		scope := "every game"
+		if *game != "" {
+			scope = *game
+		}
+		fmt.Printf("Granted %s of %s to user %d\n", *role, scope, id)
+		return nil
+	}, nil
+}
+
+// runVerify verifies a pending run
+func runVerify(args []string) (action, error) {
+	fs := newFlagSet("run verify", "RUN_ID")
+	if err := parseArgs(fs, args, 1); err != nil {
+		return nil, err
+	}
+	id, err := parseID(fs, "RUN_ID")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, a *admin) error {
+		if _, err := a.runs.VerifyRun(ctx, id); err != nil {
+			return err
+		}
+		fmt.Printf("Verified run %d\n", id)
+		return nil
+	}, nil
+}
+
+// tokenRevoke signs a user out everywhere
+func tokenRevoke(args []string) (action, error) {
+	fs := newFlagSet("token revoke", "USER_ID")
+	if err := parseArgs(fs, args, 1); err != nil {
+		return nil, err
+	}
+	id, err := parseID(fs, "USER_ID")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, a *admin) error {
+		revoked, err := a.auth.RevokeUserTokens(ctx, id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Revoked %d refresh tokens of user %d\n", revoked, id)
+		return nil
+	}, nil
+}
+
+// dbSeed loads the development dataset from the fixtures package
+func dbSeed(args []string) (action, error) {
+	fs := newFlagSet("db seed", "")
+	if err := parseArgs(fs, args, 0); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, a *admin) error {
+		if err := fixtures.Seed(ctx, a.store); err != nil {
+			return err
+		}
+		fmt.Printf("Seeded %d users, %d games, %d categories, and %d runs; every user's password is %q\n",
+			len(fixtures.Users), len(fixtures.Games), len(fixtures.Categories), len(fixtures.Runs), fixtures.Password)
+		return nil
+	}, nil
+}
+
+// newFlagSet creates the flags of a command whose arguments are described
+// by synopsis
+func newFlagSet(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), strings.TrimSpace("usage: adminctl "+name+" "+synopsis))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses args into fs and checks that exactly want positional
+// arguments follow the flags
+func parseArgs(fs *flag.FlagSet, args []string, want int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != want {
+		fs.Usage()
+		return fmt.Errorf("expected %d argument(s), got %d", want, fs.NArg())
+	}
+	return nil
+}
+
+// parseID parses the first positional argument as the ID called name
+func parseID(fs *flag.FlagSet, name string) (int32, error) {
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 32)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", name, fs.Arg(0))
+	}
+	return int32(id), nil
+}
+
+// readPassword reads the first line of stdin
+func readPassword() (string, error) {
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read password from stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// usage lists the commands and exits
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "usage: adminctl <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, "  "+name)
+	}
+	os.Exit(2)
+}
+
+// fatal logs an error that prevents the command from completing and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/telemetry"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// connect opens a connection pool sized by cfg to the database at url and
+// checks that it answers
+func connect(ctx context.Context, url string, cfg *config.Config) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection string: %w", err)
+	}
+	poolConfig.MaxConns = int32(cfg.DBMaxConns)
+	poolConfig.MinConns = int32(cfg.DBMinConns)
+	poolConfig.ConnConfig.Tracer = telemetry.NewPgxTracer()
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ping failed: %w", err)
+	}
+	return pool, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/mailer"
+	"github.com/example/speedrun-rest-api/outbox"
+)
+
+// newEventSinks creates the feed, notification, and stream sinks, which the
+// API serves and so are always used, followed by the sinks named by
+// cfg.EventSinks, in that order; notifications are emailed through mail
+func newEventSinks(cfg *config.Config, queries db.Store, mail mailer.Mailer) ([]outbox.Sink, error) {
+	sinks := make([]outbox.Sink, 0, len(cfg.EventSinks)+3)
+	sinks = append(sinks,
+		outbox.NewFeedSink(queries),
+		outbox.NewNotificationSink(queries, mail),
+		outbox.NewStreamSink(queries),
+	)
+	for _, name := range cfg.EventSinks {
+		switch name {
+		case "webhooks":
+			sinks = append(sinks, outbox.NewWebhookSink(queries))
+		case "nats":
+			sink, err := outbox.NewNATSSink(cfg.NATSURL, cfg.NATSSubjectPrefix)
+			if err != nil {
+				closeEventSinks(sinks)
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "kafka":
+			sinks = append(sinks, outbox.NewKafkaSink(cfg.KafkaBrokers, cfg.KafkaTopic))
+		}
+	}
+	return sinks, nil
+}
+
+// closeEventSinks closes the sinks that hold connections
+func closeEventSinks(sinks []outbox.Sink) {
+	for _, sink := range sinks {
+		if c, ok := sink.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				slog.Error("Error closing event sink", "sink", sink.Name(), "error", err)
+			}
+		}
+	}
+}
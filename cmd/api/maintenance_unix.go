@@ -0,0 +1,29 @@
+//go:build !windows
+
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/example/speedrun-rest-api/server"
+)
+
+// watchMaintenanceSignals lets operators toggle maintenance mode without a
+// restart: SIGUSR1 toggles read-only mode, SIGUSR2 toggles full unavailability
+func watchMaintenanceSignals(m *server.Maintenance) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	go func() {
+		for sig := range sigs {
+			mode := server.MaintenanceReadOnly
+			if sig == syscall.SIGUSR2 {
+				mode = server.MaintenanceUnavailable
+			}
+			slog.Warn("Maintenance mode changed", "mode", m.Toggle(mode).String())
+		}
+	}()
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+import "github.com/example/speedrun-rest-api/server"
+
+// watchMaintenanceSignals is a no-op on Windows, which has no SIGUSR1/SIGUSR2
+func watchMaintenanceSignals(m *server.Maintenance) {}
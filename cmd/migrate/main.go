@@ -0,0 +1,108 @@
+// Command migrate applies or reverts the database migrations embedded in the
+// API, for deployments that migrate explicitly rather than on startup.
+//
+// Usage:
+//
+//	migrate up [flags]      apply every pending migration
+//	migrate down [flags]    revert the most recent migration
+//	migrate status [flags]  list migrations and whether each has been applied
+//
+// The database is taken from the same configuration as the API server, e.g.
+// DATABASE_URL or a file named by -config.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/db/migrations"
+	"github.com/example/speedrun-rest-api/logging"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+func main() {
+	slog.SetDefault(logging.New(os.Stderr))
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+	command := os.Args[1]
+
+	cfg, err := config.Load(os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	connConfig, err := pgx.ParseConfig(cfg.DatabaseURL)
+	if err != nil {
+		fatal("Invalid DATABASE_URL", err)
+	}
+	conn := stdlib.OpenDB(*connConfig)
+	defer conn.Close()
+
+	provider, err := migrations.NewProvider(conn)
+	if err != nil {
+		fatal("Unable to load migrations", err)
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		if err := migrations.Up(ctx, provider); err != nil {
+			fatal("Migration failed", err)
+		}
+	case "down":
+		result, err := provider.Down(ctx)
+		if result != nil {
+			migrations.LogResults(ctx, []*goose.MigrationResult{result})
+		}
+		if err != nil {
+			fatal("Migration failed", err)
+		}
+	case "status":
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			fatal("Unable to read migration status", err)
+		}
+		printStatus(statuses)
+	default:
+		usage()
+	}
+}
+
+// printStatus writes a table of migrations and when each was applied
+func printStatus(statuses []*goose.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tFILE\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Source.Version, status.Source.Path, appliedAt)
+	}
+	w.Flush()
+}
+
+// usage describes the commands and exits
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up|down|status [flags]")
+	os.Exit(2)
+}
+
+// fatal logs an error that prevents the command from completing and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"log/slog"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes contents to a YAML file in a temporary directory
+func writeConfigFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestDefault_IsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("expected defaults to be valid, got %v", err)
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+listen_addr: ":9000"
+read_timeout: 5s
+max_page_size: 50
+log_level: warn
+cors_allowed_origins:
+  - https://a.example.com
+  - https://b.example.com
+trusted_proxies: 10.0.0.0/8, 192.168.1.1
+`)
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("READ_TIMEOUT", "7s")
+	t.Setenv("MAX_PAGE_SIZE", "70")
+
+	cfg, err := Load([]string{"-max-page-size", "90"})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.ListenAddr != ":9000" {
+		t.Errorf("expected listen_addr from the file, got %q", cfg.ListenAddr)
+	}
+	if cfg.ReadTimeout != 7*time.Second {
+		t.Errorf("expected READ_TIMEOUT to override the file, got %s", cfg.ReadTimeout)
+	}
+	if cfg.MaxPageSize != 90 {
+		t.Errorf("expected the flag to override the environment, got %d", cfg.MaxPageSize)
+	}
+	if cfg.WriteTimeout != Default().WriteTimeout {
+		t.Errorf("expected unset write_timeout to keep its default, got %s", cfg.WriteTimeout)
+	}
+	if cfg.LogLevel != slog.LevelWarn {
+		t.Errorf("expected log level warn, got %s", cfg.LogLevel)
+	}
+	if want := []string{"https://a.example.com", "https://b.example.com"}; !reflect.DeepEqual(cfg.CORSAllowedOrigins, want) {
+		t.Errorf("expected origins %v, got %v", want, cfg.CORSAllowedOrigins)
+	}
+	wantProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.168.1.1/32")}
+	if !reflect.DeepEqual(cfg.TrustedProxies, wantProxies) {
+		t.Errorf("expected proxies %v, got %v", wantProxies, cfg.TrustedProxies)
+	}
+}
+
+func TestLoad_ConfigFlagOverridesEnv(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+	path := writeConfigFile(t, "max_batch_size: 5\n")
+
+	cfg, err := Load([]string{"-config", path})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.MaxBatchSize != 5 {
+		t.Errorf("expected max_batch_size 5, got %d", cfg.MaxBatchSize)
+	}
+}
+
+func TestLoad_RejectsUnknownFileSettings(t *testing.T) {
+	path := writeConfigFile(t, "max_pagesize: 50\n")
+
+	_, err := Load([]string{"-config", path})
+	if err == nil || !strings.Contains(err.Error(), "unknown setting max_pagesize") {
+		t.Errorf("expected unknown setting error, got %v", err)
+	}
+}
+
+func TestLoad_ReportsEveryInvalidSetting(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "lots")
+	t.Setenv("ACCESS_TOKEN_TTL", "1 hour")
+
+	_, err := Load(nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"MAX_BATCH_SIZE", "ACCESS_TOKEN_TTL"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got %v", want, err)
+		}
+	}
+}
+
+func TestLoad_SecretsHaveNoFlags(t *testing.T) {
+	if _, err := Load([]string{"-jwt-secret", "abc"}); err == nil {
+		t.Error("expected -jwt-secret to be rejected")
+	}
+}
+
+func TestLoad_NormalizesValues(t *testing.T) {
+	t.Setenv("PUBLIC_URL", "https://api.example.com/")
+	t.Setenv("OTEL_TRACES_EXPORTER", "OTLP")
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.PublicURL != "https://api.example.com" {
+		t.Errorf("expected trailing slash trimmed, got %q", cfg.PublicURL)
+	}
+	if cfg.TracesExporter != "otlp" {
+		t.Errorf("expected exporter lowercased, got %q", cfg.TracesExporter)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{"listen address", func(c *Config) { c.ListenAddr = "8080" }, "listen_addr"},
+		{"gRPC listen address", func(c *Config) { c.GRPCListenAddr = "9090" }, "grpc_listen_addr"},
+		{"page sizes", func(c *Config) { c.DefaultPageSize = c.MaxPageSize + 1 }, "default_page_size"},
+		{"zero timeout", func(c *Config) { c.WriteTimeout = 0 }, "write_timeout"},
+		{"pool sizes", func(c *Config) { c.DBMinConns = c.DBMaxConns + 1 }, "db_min_conns"},
+		{"short JWT secret", func(c *Config) { c.JWTSecret = "short" }, "jwt_secret"},
+		{"half an OAuth app", func(c *Config) { c.TwitchClientID = "id" }, "twitch_client_id"},
+		{"video check without credentials", func(c *Config) { c.VideoCheckEnabled = true }, "video_check_enabled"},
+		{"bcrypt cost", func(c *Config) { c.PasswordHashCost = 2 }, "password_hash_cost"},
+		{"public URL", func(c *Config) { c.PublicURL = "localhost:8080" }, "public_url"},
+		{"redis URL", func(c *Config) { c.RedisURL = "http://cache" }, "redis_url"},
+		{"cache TTL", func(c *Config) { c.CacheTTL = 0 }, "cache_ttl"},
+		{"negative HTTP cache max age", func(c *Config) { c.HTTPCacheLeaderboardMaxAge = -time.Second }, "http_cache_leaderboard_max_age"},
+		{"S3 without credentials", func(c *Config) { c.S3Bucket = "avatars" }, "s3_access_key_id"},
+		{"avatar public URL", func(c *Config) { c.AvatarPublicURL = "cdn.example.com" }, "avatar_public_url"},
+		{"avatar size", func(c *Config) { c.AvatarSize = 0 }, "avatar_size"},
+		{"CORS origin", func(c *Config) { c.CORSAllowedOrigins = []string{"https://example.com/app"} }, "cors_allowed_origins"},
+		{"CORS method", func(c *Config) { c.CORSAllowedMethods = []string{"TRACE"} }, "cors_allowed_methods"},
+		{"CORS header", func(c *Config) { c.CORSAllowedHeaders = []string{"X-Foo: bar"} }, "cors_allowed_headers"},
+		{"multiline CSP", func(c *Config) { c.DocsContentSecurityPolicy = "default-src 'none'\nX-Injected: 1" }, "docs_content_security_policy"},
+		{"CORS credentials for any origin", func(c *Config) { c.CORSAllowCredentials = true; c.CORSAllowedOrigins = []string{"*"} }, "cors_allow_credentials"},
+		{"mail driver", func(c *Config) { c.MailDriver = "ses" }, "mail_driver"},
+		{"SMTP without a relay", func(c *Config) { c.MailDriver = "smtp"; c.MailFrom = "noreply@example.com" }, "smtp_addr"},
+		{"SendGrid without a key", func(c *Config) { c.MailDriver = "sendgrid"; c.MailFrom = "noreply@example.com" }, "sendgrid_api_key"},
+		{"missing sender", func(c *Config) { c.MailDriver = "smtp"; c.SMTPAddr = "mail:587" }, "mail_from"},
+		{"webhook attempts", func(c *Config) { c.WebhookMaxAttempts = 0 }, "webhook_max_attempts"},
+		{"unknown event sink", func(c *Config) { c.EventSinks = []string{"sqs"} }, "event_sinks"},
+		{"NATS without a server", func(c *Config) { c.EventSinks = []string{"nats"} }, "nats_url"},
+		{"Kafka without brokers", func(c *Config) { c.EventSinks = []string{"kafka"} }, "kafka_brokers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error about %s, got %v", tt.want, err)
+			}
+		})
+	}
+}
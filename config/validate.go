@@ -0,0 +1,244 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minJWTSecretLength is the shortest JWT secret accepted, the size of an
+// HS256 key
+const minJWTSecretLength = 32
+
+// Validate reports every setting that is out of range or inconsistent with
+// another, so the server refuses to start rather than misbehave later
+func (cfg *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.ListenAddr); err != nil {
+		fail("listen_addr %q must be host:port, e.g. :8080", cfg.ListenAddr)
+	}
+	if cfg.GRPCListenAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.GRPCListenAddr); err != nil {
+			fail("grpc_listen_addr %q must be host:port, e.g. :9090", cfg.GRPCListenAddr)
+		}
+	}
+	if cfg.DatabaseURL == "" {
+		fail("database_url must be set")
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"read_timeout", cfg.ReadTimeout},
+		{"write_timeout", cfg.WriteTimeout},
+		{"idle_timeout", cfg.IdleTimeout},
+		{"shutdown_timeout", cfg.ShutdownTimeout},
+		{"health_check_timeout", cfg.HealthCheckTimeout},
+		{"maintenance_retry_after", cfg.MaintenanceRetryAfter},
+		{"janitor_interval", cfg.JanitorInterval},
+		{"janitor_retention", cfg.JanitorRetention},
+		{"access_token_ttl", cfg.AccessTokenTTL},
+		{"refresh_token_ttl", cfg.RefreshTokenTTL},
+		{"email_verification_ttl", cfg.EmailVerificationTTL},
+		{"moderation_sla", cfg.ModerationSLA},
+		{"comment_edit_window", cfg.CommentEditWindow},
+		{"race_countdown", cfg.RaceCountdown},
+		{"webhook_poll_interval", cfg.WebhookPollInterval},
+		{"webhook_retry_backoff", cfg.WebhookRetryBackoff},
+		{"outbox_poll_interval", cfg.OutboxPollInterval},
+		{"operation_poll_interval", cfg.OperationPollInterval},
+		{"operation_retention", cfg.OperationRetention},
+		{"feed_retention", cfg.FeedRetention},
+		{"notification_retention", cfg.NotificationRetention},
+		{"cache_ttl", cfg.CacheTTL},
+	} {
+		if d.value <= 0 {
+			fail("%s must be positive, got %s", d.name, d.value)
+		}
+	}
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"shutdown_drain_delay", cfg.ShutdownDrainDelay},
+		{"http_cache_max_age", cfg.HTTPCacheMaxAge},
+		{"http_cache_leaderboard_max_age", cfg.HTTPCacheLeaderboardMaxAge},
+		{"cors_max_age", cfg.CORSMaxAge},
+		{"hsts_max_age", cfg.HSTSMaxAge},
+	} {
+		if d.value < 0 {
+			fail("%s may not be negative, got %s", d.name, d.value)
+		}
+	}
+
+	for _, n := range []struct {
+		name  string
+		value int
+	}{
+		{"db_max_conns", cfg.DBMaxConns},
+		{"max_batch_size", cfg.MaxBatchSize},
+		{"default_page_size", cfg.DefaultPageSize},
+		{"max_page_size", cfg.MaxPageSize},
+		{"max_name_length", cfg.MaxNameLength},
+		{"comment_rate_limit", cfg.CommentRateLimit},
+		{"graphql_max_complexity", cfg.GraphQLMaxComplexity},
+		{"avatar_max_bytes", cfg.AvatarMaxBytes},
+		{"avatar_size", cfg.AvatarSize},
+		{"rate_limit_per_ip", cfg.RateLimitPerIP},
+		{"rate_limit_per_key", cfg.RateLimitPerKey},
+		{"webhook_max_attempts", cfg.WebhookMaxAttempts},
+	} {
+		if n.value <= 0 {
+			fail("%s must be positive, got %d", n.name, n.value)
+		}
+	}
+	if cfg.DBMinConns < 0 || cfg.DBMinConns > cfg.DBMaxConns {
+		fail("db_min_conns must be between 0 and db_max_conns (%d), got %d", cfg.DBMaxConns, cfg.DBMinConns)
+	}
+	if cfg.DefaultPageSize > cfg.MaxPageSize {
+		fail("default_page_size (%d) may not exceed max_page_size (%d)", cfg.DefaultPageSize, cfg.MaxPageSize)
+	}
+	if cfg.PasswordHashCost < bcrypt.MinCost || cfg.PasswordHashCost > bcrypt.MaxCost {
+		fail("password_hash_cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cfg.PasswordHashCost)
+	}
+
+	// An empty secret is allowed and replaced by a random key at startup
+	if cfg.JWTSecret != "" && len(cfg.JWTSecret) < minJWTSecretLength {
+		fail("jwt_secret must be at least %d bytes", minJWTSecretLength)
+	}
+	if (cfg.TwitchClientID == "") != (cfg.TwitchClientSecret == "") {
+		fail("twitch_client_id and twitch_client_secret must be set together")
+	}
+	if (cfg.DiscordClientID == "") != (cfg.DiscordClientSecret == "") {
+		fail("discord_client_id and discord_client_secret must be set together")
+	}
+	if cfg.VideoCheckEnabled && cfg.YouTubeAPIKey == "" && cfg.TwitchClientID == "" {
+		fail("video_check_enabled needs youtube_api_key or the Twitch client credentials")
+	}
+
+	if !isHTTPURL(cfg.PublicURL) {
+		fail("public_url %q must be an http or https URL", cfg.PublicURL)
+	}
+	switch cfg.MailDriver {
+	case "log":
+	case "smtp":
+		if _, _, err := net.SplitHostPort(cfg.SMTPAddr); err != nil {
+			fail("smtp_addr %q must be host:port when mail_driver is smtp", cfg.SMTPAddr)
+		}
+		if (cfg.SMTPUsername == "") != (cfg.SMTPPassword == "") {
+			fail("smtp_username and smtp_password must be set together")
+		}
+	case "sendgrid":
+		if cfg.SendGridAPIKey == "" {
+			fail("sendgrid_api_key must be set when mail_driver is sendgrid")
+		}
+	default:
+		fail("mail_driver must be log, smtp, or sendgrid, got %q", cfg.MailDriver)
+	}
+	if cfg.MailDriver != "log" {
+		if _, err := mail.ParseAddress(cfg.MailFrom); err != nil {
+			fail("mail_from %q must be an email address when mail_driver is %s", cfg.MailFrom, cfg.MailDriver)
+		}
+	}
+	if cfg.S3Bucket != "" {
+		if !isHTTPURL(cfg.S3Endpoint) {
+			fail("s3_endpoint %q must be an http or https URL when s3_bucket is set", cfg.S3Endpoint)
+		}
+		if cfg.S3Region == "" {
+			fail("s3_region must be set when s3_bucket is set")
+		}
+		if cfg.S3AccessKeyID == "" || cfg.S3SecretAccessKey == "" {
+			fail("s3_access_key_id and s3_secret_access_key must be set when s3_bucket is set")
+		}
+	}
+	if cfg.AvatarPublicURL != "" && !isHTTPURL(cfg.AvatarPublicURL) {
+		fail("avatar_public_url %q must be an http or https URL", cfg.AvatarPublicURL)
+	}
+	if cfg.RedisURL != "" {
+		if u, err := url.Parse(cfg.RedisURL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+			fail("redis_url must be a redis:// or rediss:// URL")
+		}
+	}
+	for i, sink := range cfg.EventSinks {
+		switch sink {
+		case "webhooks":
+		case "nats":
+			if u, err := url.Parse(cfg.NATSURL); err != nil || !slices.Contains([]string{"nats", "tls", "ws", "wss"}, u.Scheme) {
+				fail("nats_url must be a nats://, tls://, ws://, or wss:// URL when event_sinks includes nats")
+			}
+			if cfg.NATSSubjectPrefix == "" || strings.ContainsAny(cfg.NATSSubjectPrefix, " \t*>") {
+				fail("nats_subject_prefix %q must be a subject without spaces or wildcards", cfg.NATSSubjectPrefix)
+			}
+		case "kafka":
+			if len(cfg.KafkaBrokers) == 0 {
+				fail("kafka_brokers must be set when event_sinks includes kafka")
+			}
+			for _, broker := range cfg.KafkaBrokers {
+				if _, _, err := net.SplitHostPort(broker); err != nil {
+					fail("kafka_brokers entry %q must be host:port", broker)
+				}
+			}
+			if cfg.KafkaTopic == "" {
+				fail("kafka_topic must be set when event_sinks includes kafka")
+			}
+		default:
+			fail("event_sinks entry %q must be webhooks, nats, or kafka", sink)
+		}
+		if slices.Contains(cfg.EventSinks[:i], sink) {
+			fail("event_sinks lists %q more than once", sink)
+		}
+	}
+	for _, origin := range cfg.CORSAllowedOrigins {
+		if origin != "*" && !isOrigin(origin) {
+			fail("cors_allowed_origins entry %q must be * or an origin such as https://example.com", origin)
+		}
+	}
+	for _, method := range cfg.CORSAllowedMethods {
+		if !slices.Contains(corsMethods, method) {
+			fail("cors_allowed_methods entry %q must be one of %s", method, strings.Join(corsMethods, ", "))
+		}
+	}
+	for _, header := range cfg.CORSAllowedHeaders {
+		if header == "" || strings.ContainsAny(header, " \t,:") {
+			fail("cors_allowed_headers entry %q must be a header name", header)
+		}
+	}
+	// Browsers refuse credentials with a wildcard origin, and echoing every
+	// origin instead would let any site act as a signed-in user
+	if cfg.CORSAllowCredentials && slices.Contains(cfg.CORSAllowedOrigins, "*") {
+		fail("cors_allow_credentials can't be combined with * in cors_allowed_origins")
+	}
+	if strings.ContainsAny(cfg.DocsContentSecurityPolicy, "\r\n") {
+		fail("docs_content_security_policy must be a single line")
+	}
+
+	return errors.Join(errs...)
+}
+
+// corsMethods are the methods cors_allowed_methods may list
+var corsMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+
+// isHTTPURL reports whether s is an absolute http or https URL
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// isOrigin reports whether s is a scheme and host with no path, as browsers
+// send in the Origin header
+func isOrigin(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && isHTTPURL(s) && u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
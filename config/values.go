@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The types below adapt Config fields to flag.Value, so a setting is parsed
+// the same way whether it comes from the YAML file, the environment, or a
+// flag. Lists are comma-separated in every source; a YAML sequence is joined
+// with commas before it is parsed.
+
+// stringValue is a string setting
+type stringValue struct{ p *string }
+
+func (v stringValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return *v.p
+}
+
+func (v stringValue) Set(s string) error {
+	*v.p = strings.TrimSpace(s)
+	return nil
+}
+
+// intValue is an integer setting
+type intValue struct{ p *int }
+
+func (v intValue) String() string {
+	if v.p == nil {
+		return "0"
+	}
+	return strconv.Itoa(*v.p)
+}
+
+func (v intValue) Set(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("%q is not an integer", s)
+	}
+	*v.p = n
+	return nil
+}
+
+// boolValue is a boolean setting such as "true" or "0"
+type boolValue struct{ p *bool }
+
+func (v boolValue) String() string {
+	if v.p == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*v.p)
+}
+
+func (v boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("%q is not a boolean", s)
+	}
+	*v.p = b
+	return nil
+}
+
+// IsBoolFlag lets a bool flag be given without a value, e.g. -pretty-json
+func (v boolValue) IsBoolFlag() bool { return true }
+
+// durationValue is a duration setting such as "30s"
+type durationValue struct{ p *time.Duration }
+
+func (v durationValue) String() string {
+	if v.p == nil {
+		return "0s"
+	}
+	return v.p.String()
+}
+
+func (v durationValue) Set(s string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("%q is not a duration such as 30s", s)
+	}
+	*v.p = d
+	return nil
+}
+
+// listValue is a comma-separated list setting; empty entries are dropped
+type listValue struct{ p *[]string }
+
+func (v listValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return strings.Join(*v.p, ",")
+}
+
+func (v listValue) Set(s string) error {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	*v.p = values
+	return nil
+}
+
+// prefixesValue is a comma-separated list of CIDRs or bare IPs
+// A single invalid entry rejects the whole setting, so a typo never widens
+// the set of trusted networks.
+type prefixesValue struct{ p *[]netip.Prefix }
+
+func (v prefixesValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	values := make([]string, len(*v.p))
+	for i, prefix := range *v.p {
+		values[i] = prefix.String()
+	}
+	return strings.Join(values, ",")
+}
+
+func (v prefixesValue) Set(s string) error {
+	var entries []string
+	if err := (listValue{&entries}).Set(s); err != nil {
+		return err
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return fmt.Errorf("%q is not a CIDR or IP address", entry)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	*v.p = prefixes
+	return nil
+}
+
+// levelValue is a log level such as "debug" or "warn"
+type levelValue struct{ p *slog.Level }
+
+func (v levelValue) String() string {
+	if v.p == nil {
+		return slog.LevelInfo.String()
+	}
+	return strings.ToLower(v.p.String())
+}
+
+func (v levelValue) Set(s string) error {
+	if err := v.p.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return fmt.Errorf("%q is not one of debug, info, warn, error", s)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at;
+
+-- name: GetAPIKeyByHash :one
+SELECT id, user_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at
+FROM api_keys
+WHERE key_hash = $1;
+
+-- name: ListAPIKeysByUser :many
+SELECT id, user_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at
+FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL
+ORDER BY id;
+
+-- name: RevokeAPIKey :one
+-- Scoped to the owner so one user cannot revoke another's key by ID
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+RETURNING id, user_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at;
+
+-- name: TouchAPIKey :exec
+-- Records use at most once a minute so busy bots don't write on every request
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
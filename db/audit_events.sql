@@ -0,0 +1,38 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (actor_id, action, entity_type, entity_id, changes, request_id)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListAuditEvents :many
+-- Newest first. A NULL filter matches every event; the time range includes
+-- created_from and excludes created_to.
+SELECT id, actor_id, action, entity_type, entity_id, changes, request_id, created_at
+FROM audit_events
+WHERE (sqlc.narg(actor_id)::int IS NULL OR actor_id = sqlc.narg(actor_id)::int)
+  AND (sqlc.narg(entity_type)::text IS NULL OR entity_type = sqlc.narg(entity_type)::text)
+  AND (sqlc.narg(entity_id)::int IS NULL OR entity_id = sqlc.narg(entity_id)::int)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to)::timestamptz)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListAuditEventsAfter :many
+-- Keyset page of ListAuditEvents continuing after the event with after_id,
+-- i.e. with events recorded before it
+SELECT id, actor_id, action, entity_type, entity_id, changes, request_id, created_at
+FROM audit_events
+WHERE (sqlc.narg(actor_id)::int IS NULL OR actor_id = sqlc.narg(actor_id)::int)
+  AND (sqlc.narg(entity_type)::text IS NULL OR entity_type = sqlc.narg(entity_type)::text)
+  AND (sqlc.narg(entity_id)::int IS NULL OR entity_id = sqlc.narg(entity_id)::int)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to)::timestamptz)
+  AND id < @after_id
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*) FROM audit_events
+WHERE (sqlc.narg(actor_id)::int IS NULL OR actor_id = sqlc.narg(actor_id)::int)
+  AND (sqlc.narg(entity_type)::text IS NULL OR entity_type = sqlc.narg(entity_type)::text)
+  AND (sqlc.narg(entity_id)::int IS NULL OR entity_id = sqlc.narg(entity_id)::int)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to)::timestamptz);
@@ -0,0 +1,54 @@
+-- name: GetCategoryByID :one
+SELECT id, game_id, slug, name, rules, position, is_default, created_at, timing_method
+FROM categories
+WHERE id = $1;
+
+-- name: GetCategoriesByIDs :many
+SELECT id, game_id, slug, name, rules, position, is_default, created_at, timing_method
+FROM categories
+WHERE id = ANY(@ids::int[])
+ORDER BY id;
+
+-- name: GetCategoryBySlug :one
+SELECT c.id, c.game_id, c.slug, c.name, c.rules, c.position, c.is_default, c.created_at, c.timing_method
+FROM categories c
+JOIN games g ON g.id = c.game_id
+WHERE g.slug = @game_slug AND c.slug = @category_slug;
+
+-- name: ListCategoriesByGame :many
+SELECT id, game_id, slug, name, rules, position, is_default, created_at, timing_method
+FROM categories
+WHERE game_id = $1
+ORDER BY position, id;
+
+-- name: ListCategoriesByGames :many
+-- The categories of several games, grouped by game in display order
+SELECT id, game_id, slug, name, rules, position, is_default, created_at, timing_method
+FROM categories
+WHERE game_id = ANY(@game_ids::int[])
+ORDER BY game_id, position, id;
+
+-- name: CreateCategory :one
+-- Creating a default category clears the previous default in the same statement
+WITH cleared AS (
+    UPDATE categories
+    SET is_default = FALSE
+    WHERE game_id = @game_id AND is_default AND @is_default::boolean
+)
+INSERT INTO categories (game_id, slug, name, rules, position, is_default, timing_method)
+VALUES (
+    @game_id,
+    @slug,
+    @name,
+    @rules,
+    COALESCE(sqlc.narg(position)::int, (SELECT COALESCE(MAX(position) + 1, 0) FROM categories WHERE game_id = @game_id)),
+    @is_default,
+    @timing_method
+)
+RETURNING id, game_id, slug, name, rules, position, is_default, created_at, timing_method;
+
+-- name: GetDefaultCategory :one
+-- A game has at most one default category
+SELECT id, game_id, slug, name, rules, position, is_default, created_at, timing_method
+FROM categories
+WHERE game_id = $1 AND is_default;
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Exporter runs queries whose every row is handed to a callback as it
+// arrives from the database, so an export of a whole table never holds more
+// than one row in memory. sqlc only generates queries that collect their
+// rows, so these are written by hand.
+type Exporter interface {
+	// ExportUsers calls fn with each user ListUsers would return for arg,
+	// in the same order, across every page
+	ExportUsers(ctx context.Context, arg ExportUsersParams, fn func(User) error) error
+
+	// ExportRunsByCategory calls fn with each run ListRunsByCategory would
+	// return for arg, in the same order, across every page
+	ExportRunsByCategory(ctx context.Context, arg ExportRunsByCategoryParams, fn func(Run) error) error
+}
+
+const exportUsers = `-- ExportUsers: ListUsers without its total or pagination
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE ($1::boolean IS NULL
+   OR (split_part(email, '@', 2) = ANY($2::text[])) = $1::boolean)
+  AND ($3::text IS NULL OR name ILIKE '%' || $3::text || '%')
+  AND ($4::text IS NULL OR lower(split_part(email, '@', 2)) = $4::text)
+  AND ($5::boolean OR deleted_at IS NULL)
+ORDER BY
+    CASE WHEN $6::text = 'name' AND NOT $7::boolean THEN name END,
+    CASE WHEN $6::text = 'name' AND $7::boolean THEN name END DESC,
+    CASE WHEN $6::text = 'email' AND NOT $7::boolean THEN email END,
+    CASE WHEN $6::text = 'email' AND $7::boolean THEN email END DESC,
+    CASE WHEN $6::text = 'created_at' AND NOT $7::boolean THEN created_at END,
+    CASE WHEN $6::text = 'created_at' AND $7::boolean THEN created_at END DESC,
+    CASE WHEN $6::text = 'updated_at' AND NOT $7::boolean THEN updated_at END,
+    CASE WHEN $6::text = 'updated_at' AND $7::boolean THEN updated_at END DESC,
+    CASE WHEN $7::boolean THEN id END DESC,
+    id
+`
+
+// ExportUsersParams are ListUsersParams without the page
+type ExportUsersParams struct {
+	Corporate        pgtype.Bool
+	CorporateDomains []string
+	Name             pgtype.Text
+	EmailDomain      pgtype.Text
+	IncludeDeleted   bool
+	Sort             string
+	Descending       bool
+}
+
+func (q *Queries) ExportUsers(ctx context.Context, arg ExportUsersParams, fn func(User) error) error {
+	rows, err := q.db.Query(ctx, exportUsers,
+		arg.Corporate,
+		arg.CorporateDomains,
+		arg.Name,
+		arg.EmailDomain,
+		arg.IncludeDeleted,
+		arg.Sort,
+		arg.Descending,
+	)
+	if err != nil {
+		return err
+	}
+	return forEachRow(rows, fn)
+}
+
+const exportRunsByCategory = `-- ExportRunsByCategory: ListRunsByCategory without pagination
+SELECT id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id
+FROM runs
+WHERE category_id = $1 AND ($2::bool OR NOT obsolete)
+ORDER BY time_ms, id
+`
+
+// ExportRunsByCategoryParams are ListRunsByCategoryParams without the page
+type ExportRunsByCategoryParams struct {
+	CategoryID      int32
+	IncludeObsolete bool
+}
+
+func (q *Queries) ExportRunsByCategory(ctx context.Context, arg ExportRunsByCategoryParams, fn func(Run) error) error {
+	rows, err := q.db.Query(ctx, exportRunsByCategory, arg.CategoryID, arg.IncludeObsolete)
+	if err != nil {
+		return err
+	}
+	return forEachRow(rows, fn)
+}
+
+// forEachRow scans each of rows into a T, whose fields must be in the order
+// of the selected columns, and calls fn with it, stopping at the first error
+func forEachRow[T any](rows pgx.Rows, fn func(T) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		row, err := pgx.RowToStructByPos[T](rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,43 @@
+-- name: CreateFeedItems :exec
+-- Adds the event to the feed of every user who follows its game or its actor,
+-- other than the actor, skipping feeds it is already in
+INSERT INTO feed_items (user_id, event_id, event, game_id, actor_id, payload, created_at)
+SELECT r.user_id, @event_id::integer, @event::text, @game_id::integer, sqlc.narg(actor_id)::integer, @payload::jsonb, @occurred_at::timestamptz
+FROM (
+    SELECT gf.user_id FROM game_follows gf WHERE gf.game_id = @game_id::integer
+    UNION
+    SELECT uf.follower_id FROM user_follows uf WHERE uf.followed_id = sqlc.narg(actor_id)::integer
+) r
+WHERE r.user_id IS DISTINCT FROM sqlc.narg(actor_id)::integer
+ON CONFLICT (user_id, event_id) DO NOTHING;
+
+-- name: ListFeedItems :many
+-- A user's feed, newest first; items about games that have since been deleted
+-- are left out
+SELECT f.id, f.event, f.payload, f.created_at, g.id AS game_id, g.slug AS game_slug, g.name AS game_name, f.actor_id, u.name AS actor_name
+FROM feed_items f
+JOIN games g ON g.id = f.game_id
+LEFT JOIN users u ON u.id = f.actor_id
+WHERE f.user_id = @user_id
+ORDER BY f.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListFeedItemsAfter :many
+-- Keyset page of ListFeedItems continuing after the item with after_id
+SELECT f.id, f.event, f.payload, f.created_at, g.id AS game_id, g.slug AS game_slug, g.name AS game_name, f.actor_id, u.name AS actor_name
+FROM feed_items f
+JOIN games g ON g.id = f.game_id
+LEFT JOIN users u ON u.id = f.actor_id
+WHERE f.user_id = @user_id AND f.id < @after_id
+ORDER BY f.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountFeedItems :one
+SELECT COUNT(*)
+FROM feed_items f
+JOIN games g ON g.id = f.game_id
+WHERE f.user_id = $1;
+
+-- name: DeleteExpiredFeedItems :execrows
+DELETE FROM feed_items
+WHERE created_at < $1;
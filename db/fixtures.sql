@@ -0,0 +1,63 @@
+-- Queries used only by the fixtures package to load the development dataset.
+-- Rows are inserted with fixed IDs; rows that already exist are left alone,
+-- so seeding twice changes nothing.
+
+-- name: SeedUser :exec
+INSERT INTO users (id, public_id, name, email, email_verified_at, created_at, updated_at)
+VALUES (@id, @public_id, @name, @email, @created_at, @created_at, @created_at)
+ON CONFLICT DO NOTHING;
+
+-- name: SeedUserPassword :exec
+INSERT INTO user_credentials (user_id, password_hash)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: SeedCategoryRecords :exec
+-- Replays the verified runs in the order they were reviewed, recording each
+-- one that took its category's record, as migration 00008 does
+INSERT INTO category_records (category_id, run_id, set_at)
+SELECT category_id, id, set_at
+FROM (
+    SELECT id, category_id, time_ms, COALESCE(reviewed_at, created_at) AS set_at,
+           MIN(time_ms) OVER (PARTITION BY category_id ORDER BY COALESCE(reviewed_at, created_at), id
+                              ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS previous_record_ms
+    FROM runs
+    WHERE status = 'verified'
+) verified
+WHERE previous_record_ms IS NULL OR time_ms < previous_record_ms
+ON CONFLICT (run_id) DO NOTHING;
+
+-- name: SeedGame :exec
+INSERT INTO games (id, slug, name, created_at, updated_at)
+VALUES (@id, @slug, @name, @created_at, @created_at)
+ON CONFLICT DO NOTHING;
+
+-- name: SeedCategory :exec
+INSERT INTO categories (id, game_id, slug, name, rules, position, is_default, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT DO NOTHING;
+
+-- name: SeedPlatform :exec
+INSERT INTO platforms (id, slug, name, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
+
+-- name: SeedRegion :exec
+INSERT INTO regions (id, slug, name, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
+
+-- name: SeedRun :exec
+INSERT INTO runs (id, user_id, category_id, time_ms, rta_ms, video_url, platform, region, played_on, status, rejection_reason, created_at, reviewed_at)
+VALUES ($1, $2, $3, $4, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT DO NOTHING;
+
+-- name: SyncSeededSequences :exec
+-- Moves each sequence past the highest ID in its table, so rows created after
+-- seeding don't collide with the fixed IDs
+SELECT setval(pg_get_serial_sequence('users', 'id'), (SELECT COALESCE(MAX(id), 1) FROM users)),
+       setval(pg_get_serial_sequence('games', 'id'), (SELECT COALESCE(MAX(id), 1) FROM games)),
+       setval(pg_get_serial_sequence('categories', 'id'), (SELECT COALESCE(MAX(id), 1) FROM categories)),
+       setval(pg_get_serial_sequence('platforms', 'id'), (SELECT COALESCE(MAX(id), 1) FROM platforms)),
+       setval(pg_get_serial_sequence('regions', 'id'), (SELECT COALESCE(MAX(id), 1) FROM regions)),
+       setval(pg_get_serial_sequence('runs', 'id'), (SELECT COALESCE(MAX(id), 1) FROM runs));
@@ -0,0 +1,113 @@
+-- name: FollowUser :execrows
+-- Affects no row when the follower already follows the user
+INSERT INTO user_follows (follower_id, followed_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnfollowUser :execrows
+DELETE FROM user_follows
+WHERE follower_id = $1 AND followed_id = $2;
+
+-- name: FollowGame :execrows
+-- Affects no row when the user already follows the game
+INSERT INTO game_follows (user_id, game_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnfollowGame :execrows
+DELETE FROM game_follows
+WHERE user_id = $1 AND game_id = $2;
+
+-- name: ListUserFollowers :many
+-- Users following followed_id, most recent follow first; deleted users are
+-- left out
+SELECT u.id AS user_id, u.public_id, u.name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.follower_id
+WHERE f.followed_id = @followed_id AND u.deleted_at IS NULL
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListUserFollowersAfter :many
+-- Keyset page of ListUserFollowers continuing after the given follow
+SELECT u.id AS user_id, u.public_id, u.name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.follower_id
+WHERE f.followed_id = @followed_id AND u.deleted_at IS NULL
+  AND (f.created_at, u.id) < (@after_followed_at::timestamptz, @after_id::int)
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListUserFollowing :many
+-- Users follower_id follows, most recent follow first; deleted users are
+-- left out
+SELECT u.id AS user_id, u.public_id, u.name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.followed_id
+WHERE f.follower_id = @follower_id AND u.deleted_at IS NULL
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListUserFollowingAfter :many
+-- Keyset page of ListUserFollowing continuing after the given follow
+SELECT u.id AS user_id, u.public_id, u.name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.followed_id
+WHERE f.follower_id = @follower_id AND u.deleted_at IS NULL
+  AND (f.created_at, u.id) < (@after_followed_at::timestamptz, @after_id::int)
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListGameFollowers :many
+-- Users following a game, most recent follow first; deleted users are left
+-- out
+SELECT u.id AS user_id, u.public_id, u.name, u.avatar_url, f.created_at AS followed_at
+FROM game_follows f
+JOIN users u ON u.id = f.user_id
+WHERE f.game_id = @game_id AND u.deleted_at IS NULL
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListGameFollowersAfter :many
+-- Keyset page of ListGameFollowers continuing after the given follow
+SELECT u.id AS user_id, u.public_id, u.name, u.avatar_url, f.created_at AS followed_at
+FROM game_follows f
+JOIN users u ON u.id = f.user_id
+WHERE f.game_id = @game_id AND u.deleted_at IS NULL
+  AND (f.created_at, u.id) < (@after_followed_at::timestamptz, @after_id::int)
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListFollowedGames :many
+-- Games a user follows, most recent follow first
+SELECT g.id AS game_id, g.slug, g.name, f.created_at AS followed_at
+FROM game_follows f
+JOIN games g ON g.id = f.game_id
+WHERE f.user_id = @user_id
+ORDER BY f.created_at DESC, g.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListFollowedGamesAfter :many
+-- Keyset page of ListFollowedGames continuing after the given follow
+SELECT g.id AS game_id, g.slug, g.name, f.created_at AS followed_at
+FROM game_follows f
+JOIN games g ON g.id = f.game_id
+WHERE f.user_id = @user_id
+  AND (f.created_at, g.id) < (@after_followed_at::timestamptz, @after_id::int)
+ORDER BY f.created_at DESC, g.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetUserFollowCounts :one
+-- Deleted users are not counted on either side
+SELECT
+    (SELECT COUNT(*) FROM user_follows f JOIN users u ON u.id = f.follower_id
+     WHERE f.followed_id = @user_id AND u.deleted_at IS NULL) AS follower_count,
+    (SELECT COUNT(*) FROM user_follows f JOIN users u ON u.id = f.followed_id
+     WHERE f.follower_id = @user_id AND u.deleted_at IS NULL) AS following_count,
+    (SELECT COUNT(*) FROM game_follows WHERE user_id = @user_id) AS followed_game_count;
+
+-- name: CountGameFollowers :one
+SELECT COUNT(*)
+FROM game_follows f
+JOIN users u ON u.id = f.user_id
+WHERE f.game_id = $1 AND u.deleted_at IS NULL;
@@ -0,0 +1,29 @@
+-- name: ListGameModerators :many
+-- Super moderators first, then verifiers, each in the order they were added
+SELECT gm.game_id, gm.user_id, u.name AS user_name, gm.level, gm.created_at
+FROM game_moderators gm
+JOIN users u ON u.id = gm.user_id
+WHERE gm.game_id = $1 AND u.deleted_at IS NULL
+ORDER BY gm.level, gm.created_at, gm.user_id;
+
+-- name: ListGameModeratorsByUser :many
+SELECT game_id, user_id, level, created_at
+FROM game_moderators
+WHERE user_id = $1
+ORDER BY game_id;
+
+-- name: GetGameModerator :one
+SELECT game_id, user_id, level, created_at
+FROM game_moderators
+WHERE game_id = $1 AND user_id = $2;
+
+-- name: AddGameModerator :one
+-- Returns no row when the user already moderates the game
+INSERT INTO game_moderators (game_id, user_id, level)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+RETURNING game_id, user_id, level, created_at;
+
+-- name: RemoveGameModerator :execrows
+DELETE FROM game_moderators
+WHERE game_id = $1 AND user_id = $2;
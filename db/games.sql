@@ -0,0 +1,53 @@
+-- name: GetGameBySlug :one
+SELECT id, slug, name, created_at, updated_at
+FROM games
+WHERE slug = $1;
+
+-- name: GetGamesByIDs :many
+SELECT id, slug, name, created_at, updated_at
+FROM games
+WHERE id = ANY(@ids::int[])
+ORDER BY id;
+
+-- name: ListGames :many
+SELECT id, slug, name, created_at, updated_at
+FROM games
+ORDER BY id
+LIMIT $1 OFFSET $2;
+
+-- name: ListGamesAfter :many
+-- Keyset page of ListGames continuing after the game with after_id
+SELECT id, slug, name, created_at, updated_at
+FROM games
+WHERE id > @after_id
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: SuggestGames :many
+-- Games whose name or slug starts with prefix, then those with a word like
+-- query, best match first. The expressions match idx_games_suggest.
+SELECT id, slug, name, created_at, updated_at
+FROM games
+WHERE lower(name) LIKE @prefix::text || '%' OR slug LIKE @prefix::text || '%'
+   OR @query::text <% lower(name) OR @query::text <% slug
+ORDER BY (lower(name) LIKE @prefix::text || '%' OR slug LIKE @prefix::text || '%') DESC,
+    greatest(word_similarity(@query::text, lower(name)), word_similarity(@query::text, slug)) DESC,
+    name, id
+LIMIT sqlc.arg('limit');
+
+-- name: CountGames :one
+SELECT COUNT(*) FROM games;
+
+-- name: CreateGame :one
+INSERT INTO games (slug, name)
+VALUES ($1, $2)
+RETURNING id, slug, name, created_at, updated_at;
+
+-- name: UpdateGame :one
+UPDATE games
+SET slug = $1, name = $2, updated_at = NOW()
+WHERE id = $3
+RETURNING id, slug, name, created_at, updated_at;
+
+-- name: DeleteGame :execrows
+DELETE FROM games WHERE slug = $1;
@@ -0,0 +1,22 @@
+-- name: ClaimIdempotencyKey :execrows
+-- Affects no rows when the caller has already used the key
+INSERT INTO idempotency_keys (user_id, key, request_hash)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, key) DO NOTHING;
+
+-- name: GetIdempotencyKey :one
+SELECT user_id, key, request_hash, status_code, response_headers, response_body, created_at
+FROM idempotency_keys
+WHERE user_id = $1 AND key = $2;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $3, response_headers = $4, response_body = $5
+WHERE user_id = $1 AND key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+-- Releases a key whose request failed, so a retry is served again
+DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys WHERE created_at < $1;
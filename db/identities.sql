@@ -0,0 +1,24 @@
+-- name: GetUserIdentity :one
+SELECT id, user_id, provider, subject, created_at
+FROM user_identities
+WHERE provider = $1 AND subject = $2;
+
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (user_id, provider, subject)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, provider, subject, created_at;
+
+-- name: CreateUserWithIdentity :one
+-- Inserts the user and the provider account they signed up with in one
+-- statement so a user is never left without a way to log in. The provider has
+-- already verified the email, so the user starts out verified.
+WITH new_user AS (
+    INSERT INTO users (name, email, email_verified_at)
+    VALUES (@name, @email, NOW())
+    RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+), identity AS (
+    INSERT INTO user_identities (user_id, provider, subject)
+    SELECT id, @provider, @subject FROM new_user
+)
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM new_user;
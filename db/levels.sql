@@ -0,0 +1,21 @@
+-- name: GetLevelBySlug :one
+SELECT l.id, l.game_id, l.slug, l.name, l.position, l.created_at
+FROM levels l
+JOIN games g ON g.id = l.game_id
+WHERE g.slug = @game_slug AND l.slug = @level_slug;
+
+-- name: ListLevelsByGame :many
+SELECT id, game_id, slug, name, position, created_at
+FROM levels
+WHERE game_id = $1
+ORDER BY position, id;
+
+-- name: CreateLevel :one
+INSERT INTO levels (game_id, slug, name, position)
+VALUES (
+    @game_id,
+    @slug,
+    @name,
+    COALESCE(sqlc.narg(position)::int, (SELECT COALESCE(MAX(position) + 1, 0) FROM levels WHERE game_id = @game_id))
+)
+RETURNING id, game_id, slug, name, position, created_at;
@@ -0,0 +1,152 @@
+-- Schema as it stood when migrations were introduced. Tables and indexes are
+-- created only if missing, so a database set up by hand from the old
+-- db/schema.sql adopts this version without changes.
+
+-- +goose Up
+-- User management table
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Set when a user is soft-deleted; only soft-deleted users can be purged
+    deleted_at TIMESTAMPTZ,
+    -- Opaque identifier exposed by the API so sequential IDs aren't leaked
+    public_id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid()
+);
+
+-- Index for email lookups
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+
+-- Index for pagination
+CREATE INDEX IF NOT EXISTS idx_users_id ON users(id);
+
+-- Password hashes live apart from users so no user query can return one
+CREATE TABLE IF NOT EXISTS user_credentials (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    password_hash TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Accounts at external OAuth providers (e.g. Twitch, Discord) that log in as a
+-- local user. subject is the provider's stable user ID; usernames and emails
+-- can change on the provider's side.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, subject)
+);
+
+-- Index for listing a user's linked accounts
+CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities(user_id);
+
+-- Keys for programmatic access such as bots that submit runs, stored as
+-- SHA-256 hashes. scopes limits a key to the operations that accept one of
+-- them; the owner's roles still apply on top.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    -- Leading characters of the key, shown so owners can tell keys apart
+    prefix VARCHAR(20) NOT NULL,
+    key_hash TEXT UNIQUE NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+-- Index for listing a user's keys
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON api_keys(user_id);
+
+-- Long-lived refresh tokens, stored as SHA-256 hashes. Each refresh rotates to
+-- a new token in the same family; reusing a rotated token revokes the family.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT UNIQUE NOT NULL,
+    family_id UUID NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+-- Index for revoking every token in a family
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);
+
+-- Games that speedruns are submitted against
+CREATE TABLE IF NOT EXISTS games (
+    id SERIAL PRIMARY KEY,
+    -- URL-safe identifier used in /games/{slug}
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Categories a game is run in, such as Any% or 100%
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    game_id INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    slug VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    rules TEXT NOT NULL DEFAULT '',
+    -- Display order within the game, lowest first
+    position INTEGER NOT NULL DEFAULT 0,
+    -- The category shown first for a game; at most one per game
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (game_id, slug)
+);
+
+-- Runs submitted by players for a game category
+CREATE TABLE IF NOT EXISTS runs (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    -- Run duration in milliseconds
+    time_ms BIGINT NOT NULL CHECK (time_ms > 0),
+    video_url TEXT NOT NULL,
+    platform VARCHAR(100) NOT NULL,
+    -- Day the run was played, which may be before it was submitted
+    played_on DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Moderation state: runs start pending and are verified or rejected
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'verified', 'rejected')),
+    rejection_reason TEXT,
+    reviewed_at TIMESTAMPTZ
+);
+
+-- Index for listing a category's runs fastest first
+CREATE INDEX IF NOT EXISTS idx_runs_category_time ON runs(category_id, time_ms);
+
+-- Index for listing a player's runs
+CREATE INDEX IF NOT EXISTS idx_runs_user ON runs(user_id);
+
+-- Elevated permissions granted to users; everyone without a row is a regular
+-- user. A NULL game_id applies the role to every game, otherwise the role is
+-- scoped to that game (e.g. a moderator for one game only).
+CREATE TABLE IF NOT EXISTS user_roles (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('moderator', 'admin')),
+    game_id INTEGER REFERENCES games(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE NULLS NOT DISTINCT (user_id, role, game_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS runs;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS games;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS user_credentials;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,9 @@
+-- Optimistic concurrency for user edits: every write bumps version, and
+-- UpdateUser only applies if the caller's version is still current. The API
+-- exposes it as the user's ETag.
+
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS version;
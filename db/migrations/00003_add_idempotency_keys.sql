@@ -0,0 +1,24 @@
+-- Responses to POST requests sent with an Idempotency-Key header, so a client
+-- that retries after a timeout gets the original response instead of creating
+-- a duplicate. The janitor deletes keys once they are past retention.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    -- Keys are only unique per user, and no user can replay another's response
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    -- SHA-256 of the method, path, and body the key was first used with
+    request_hash BYTEA NOT NULL,
+    -- NULL until the first request has been served
+    status_code INTEGER,
+    response_headers JSONB,
+    response_body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, key)
+);
+
+-- Index for the janitor's cleanup
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,27 @@
+-- A record of every change made through the service layer: who made it, to
+-- what, and how the entity's fields changed. Rows are never updated or
+-- deleted by the application.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS audit_events (
+    id SERIAL PRIMARY KEY,
+    -- NULL for changes made anonymously, such as registration. Not a foreign
+    -- key, so the history of a purged user is kept.
+    actor_id INTEGER,
+    -- What was done, e.g. "user.update"
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    -- Changed fields, as {"field": {"before": ..., "after": ...}}
+    changes JSONB NOT NULL,
+    request_id VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes for the admin listing's filters
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_events;
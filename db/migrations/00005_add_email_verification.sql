@@ -0,0 +1,10 @@
+-- Tracks when a user proved they own their email address. Users created
+-- before verification existed are treated as verified, so existing accounts
+-- can keep submitting runs.
+
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
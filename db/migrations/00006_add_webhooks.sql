@@ -0,0 +1,42 @@
+-- Endpoints registered by admins to be notified of events, and the queue of
+-- notifications sent to them. Each delivery is retried with backoff until the
+-- endpoint accepts it or it runs out of attempts; the janitor deletes
+-- finished deliveries once they are past retention.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    -- Signs every delivery; kept in the clear since it is needed to sign them
+    secret VARCHAR(100) NOT NULL,
+    -- Events the endpoint is subscribed to, e.g. {run.verified}
+    events TEXT[] NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    -- When a pending delivery is next sent
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Outcome of the latest attempt
+    response_status INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes for the dispatcher's polling, the delivery log, and the janitor's
+-- cleanup
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_updated_at ON webhook_deliveries(updated_at) WHERE status <> 'pending';
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,35 @@
+-- Domain events written in the same transaction as the change they describe,
+-- so no event is lost or published for a change that was rolled back. A
+-- dispatcher publishes each event to every configured sink and retries the
+-- sinks that failed; the janitor deletes published events once they are past
+-- retention. Webhook deliveries remember the event they were queued for, so
+-- an event published twice is only delivered once.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id SERIAL PRIMARY KEY,
+    event VARCHAR(50) NOT NULL,
+    -- The event's data; the envelope is added when it is published
+    payload JSONB NOT NULL,
+    -- Sinks the event has been published to, e.g. {webhooks}
+    published_to TEXT[] NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    -- When an unpublished event is next tried
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    -- Set once every sink has the event
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes for the dispatcher's polling and the janitor's cleanup
+CREATE INDEX IF NOT EXISTS idx_outbox_events_due ON outbox_events(next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event_id INTEGER;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(webhook_id, event_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_id;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS event_id;
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,31 @@
+-- Every run that took its category's record, written when the run is
+-- verified, so the progression is kept once a record is beaten. A run takes
+-- the record when it is verified with a strictly faster time than every run
+-- verified before it.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS category_records (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    run_id INTEGER NOT NULL UNIQUE REFERENCES runs(id) ON DELETE CASCADE,
+    -- When the run took the record, i.e. when it was verified
+    set_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_records_category_id ON category_records(category_id, set_at);
+
+-- Replay the runs verified so far in the order they were reviewed
+INSERT INTO category_records (category_id, run_id, set_at)
+SELECT category_id, id, set_at
+FROM (
+    SELECT id, category_id, time_ms, COALESCE(reviewed_at, created_at) AS set_at,
+           MIN(time_ms) OVER (PARTITION BY category_id ORDER BY COALESCE(reviewed_at, created_at), id
+                              ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS previous_record_ms
+    FROM runs
+    WHERE status = 'verified'
+) verified
+WHERE previous_record_ms IS NULL OR time_ms < previous_record_ms
+ON CONFLICT (run_id) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS category_records;
@@ -0,0 +1,20 @@
+-- Marks verified runs that their runner has since beaten in the same
+-- category. Only a runner's best verified run in a category is current;
+-- run listings leave the others out unless asked for them.
+
+-- +goose Up
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS obsolete BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Every verified run but each runner's best in its category, picked the same
+-- way as on the leaderboard
+UPDATE runs SET obsolete = TRUE
+WHERE status = 'verified'
+  AND id NOT IN (
+    SELECT DISTINCT ON (category_id, user_id) id
+    FROM runs
+    WHERE status = 'verified'
+    ORDER BY category_id, user_id, time_ms, played_on, id
+  );
+
+-- +goose Down
+ALTER TABLE runs DROP COLUMN IF EXISTS obsolete;
@@ -0,0 +1,42 @@
+-- Game-defined variables, such as difficulty or character, each with a fixed
+-- set of allowed values. A run records the value it was played with for any
+-- of its category's variables, and leaderboards can be narrowed to runs
+-- with particular values.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS variables (
+    id SERIAL PRIMARY KEY,
+    game_id INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    -- The only category the variable applies to; NULL for every category of
+    -- the game
+    category_id INTEGER REFERENCES categories(id) ON DELETE CASCADE,
+    slug VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (game_id, slug)
+);
+
+CREATE TABLE IF NOT EXISTS variable_values (
+    id SERIAL PRIMARY KEY,
+    variable_id INTEGER NOT NULL REFERENCES variables(id) ON DELETE CASCADE,
+    slug VARCHAR(100) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    -- Display order within the variable, lowest first
+    position INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (variable_id, slug)
+);
+
+-- The value a run was played with, at most one per variable
+CREATE TABLE IF NOT EXISTS run_variable_values (
+    run_id INTEGER NOT NULL REFERENCES runs(id) ON DELETE CASCADE,
+    variable_id INTEGER NOT NULL REFERENCES variables(id) ON DELETE CASCADE,
+    value_id INTEGER NOT NULL REFERENCES variable_values(id) ON DELETE CASCADE,
+    PRIMARY KEY (run_id, variable_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_run_variable_values_value_id ON run_variable_values(value_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_variable_values;
+DROP TABLE IF EXISTS variable_values;
+DROP TABLE IF EXISTS variables;
@@ -0,0 +1,24 @@
+-- Levels of a game, for individual level (IL) leaderboards. A run tied to a
+-- level is ranked on that level's leaderboard in its category rather than on
+-- the category's full-game leaderboard.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS levels (
+    id SERIAL PRIMARY KEY,
+    game_id INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    slug VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    -- Display order within the game, lowest first
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (game_id, slug)
+);
+
+-- NULL for full-game runs
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS level_id INTEGER REFERENCES levels(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_runs_level_id ON runs(level_id);
+
+-- +goose Down
+ALTER TABLE runs DROP COLUMN IF EXISTS level_id;
+DROP TABLE IF EXISTS levels;
@@ -0,0 +1,49 @@
+-- Platforms and regions runs are played on. Runs reference their platform,
+-- and optionally their region, by slug, so run payloads keep naming them the
+-- way clients send them.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS platforms (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS regions (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Runs so far named their platform freely; each distinct name becomes a
+-- platform, and its runs are pointed at the platform's slug
+CREATE TEMPORARY TABLE run_platforms ON COMMIT DROP AS
+SELECT DISTINCT platform AS name,
+       COALESCE(NULLIF(TRIM(BOTH '-' FROM regexp_replace(LOWER(TRIM(platform)), '[^a-z0-9]+', '-', 'g')), ''), 'other') AS slug
+FROM runs;
+
+INSERT INTO platforms (slug, name)
+SELECT slug, MIN(TRIM(name)) FROM run_platforms
+GROUP BY slug;
+
+UPDATE runs
+SET platform = run_platforms.slug
+FROM run_platforms
+WHERE runs.platform = run_platforms.name;
+
+ALTER TABLE runs
+    ADD CONSTRAINT runs_platform_fkey FOREIGN KEY (platform) REFERENCES platforms(slug),
+    ADD COLUMN IF NOT EXISTS region VARCHAR(100) REFERENCES regions(slug);
+
+CREATE INDEX IF NOT EXISTS idx_runs_platform ON runs(platform);
+CREATE INDEX IF NOT EXISTS idx_runs_region ON runs(region);
+
+-- +goose Down
+ALTER TABLE runs
+    DROP CONSTRAINT IF EXISTS runs_platform_fkey,
+    DROP COLUMN IF EXISTS region;
+DROP INDEX IF EXISTS idx_runs_platform;
+DROP TABLE IF EXISTS regions;
+DROP TABLE IF EXISTS platforms;
@@ -0,0 +1,24 @@
+-- Runs can be timed by up to three methods: real time (RTA), in-game time
+-- (IGT), and real time with loading screens removed (LRT). Each category
+-- names its primary method, and time_ms keeps holding a run's time by that
+-- method, so leaderboards and records rank by it as before.
+
+-- +goose Up
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS timing_method VARCHAR(3) NOT NULL DEFAULT 'rta'
+        CHECK (timing_method IN ('rta', 'igt', 'lrt'));
+
+ALTER TABLE runs
+    ADD COLUMN IF NOT EXISTS rta_ms BIGINT CHECK (rta_ms > 0),
+    ADD COLUMN IF NOT EXISTS igt_ms BIGINT CHECK (igt_ms > 0),
+    ADD COLUMN IF NOT EXISTS lrt_ms BIGINT CHECK (lrt_ms > 0);
+
+-- Every category so far was timed in real time
+UPDATE runs SET rta_ms = time_ms;
+
+-- +goose Down
+ALTER TABLE runs
+    DROP COLUMN IF EXISTS rta_ms,
+    DROP COLUMN IF EXISTS igt_ms,
+    DROP COLUMN IF EXISTS lrt_ms;
+ALTER TABLE categories DROP COLUMN IF EXISTS timing_method;
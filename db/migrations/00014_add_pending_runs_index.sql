@@ -0,0 +1,8 @@
+-- Moderators work through a game's pending runs oldest first; only a small
+-- share of runs is ever pending, so a partial index keeps the queue cheap.
+
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_runs_pending ON runs(created_at, id) WHERE status = 'pending';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_runs_pending;
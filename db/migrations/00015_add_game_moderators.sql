@@ -0,0 +1,18 @@
+-- Each game's own moderation team. Super moderators may edit the game and
+-- manage its team; verifiers may only review its runs. These sit alongside
+-- the moderator and admin roles in user_roles, which site staff hold.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS game_moderators (
+    game_id INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    level VARCHAR(20) NOT NULL CHECK (level IN ('super_moderator', 'verifier')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (game_id, user_id)
+);
+
+-- Index for loading the games a user moderates on every request
+CREATE INDEX IF NOT EXISTS idx_game_moderators_user ON game_moderators(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS game_moderators;
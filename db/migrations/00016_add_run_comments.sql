@@ -0,0 +1,24 @@
+-- Comments users leave on runs. Moderators remove comments by soft deleting
+-- them, keeping who removed them for the audit trail; deleted comments are
+-- never shown again.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_comments (
+    id SERIAL PRIMARY KEY,
+    run_id INTEGER NOT NULL REFERENCES runs(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL CHECK (char_length(body) BETWEEN 1 AND 2000),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    deleted_by INTEGER REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Index for listing a run's comments oldest first
+CREATE INDEX IF NOT EXISTS idx_run_comments_run ON run_comments(run_id, id) WHERE deleted_at IS NULL;
+
+-- Index for counting a user's recent comments when throttling them
+CREATE INDEX IF NOT EXISTS idx_run_comments_user_created ON run_comments(user_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_comments;
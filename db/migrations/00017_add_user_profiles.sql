@@ -0,0 +1,21 @@
+-- Optional public profile details: handles on streaming and social sites, an
+-- ISO 3166-1 alpha-2 country code, pronouns, and a short bio. Handles are
+-- stored without a leading @.
+
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS twitch_handle VARCHAR(25),
+    ADD COLUMN IF NOT EXISTS youtube_handle VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS twitter_handle VARCHAR(15),
+    ADD COLUMN IF NOT EXISTS country_code CHAR(2),
+    ADD COLUMN IF NOT EXISTS pronouns VARCHAR(40),
+    ADD COLUMN IF NOT EXISTS bio TEXT CHECK (char_length(bio) <= 1000);
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN IF EXISTS bio,
+    DROP COLUMN IF EXISTS pronouns,
+    DROP COLUMN IF EXISTS country_code,
+    DROP COLUMN IF EXISTS twitter_handle,
+    DROP COLUMN IF EXISTS youtube_handle,
+    DROP COLUMN IF EXISTS twitch_handle;
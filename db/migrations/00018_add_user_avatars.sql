@@ -0,0 +1,8 @@
+-- Where each user's avatar is served from, when they have uploaded one. The
+-- image itself lives in object storage.
+
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_url TEXT;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_url;
@@ -0,0 +1,28 @@
+-- Users following other runners and games, the starting point for feeds and
+-- notifications. Follows go away with either side.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_follows (
+    follower_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followed_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followed_id),
+    CHECK (follower_id <> followed_id)
+);
+
+-- Index for listing a user's followers, newest first
+CREATE INDEX IF NOT EXISTS idx_user_follows_followed ON user_follows(followed_id, created_at);
+
+CREATE TABLE IF NOT EXISTS game_follows (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    game_id INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, game_id)
+);
+
+-- Index for listing a game's followers, newest first
+CREATE INDEX IF NOT EXISTS idx_game_follows_game ON game_follows(game_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS game_follows;
+DROP TABLE IF EXISTS user_follows;
@@ -0,0 +1,28 @@
+-- Activity feeds: the feed sink copies each event about a game or runner a
+-- user follows into that user's feed, so reading a feed is a single indexed
+-- scan rather than a join over every follow. game_id and actor_id carry no
+-- foreign keys, so an event about a game or runner deleted before the sink
+-- got to it is still stored; reads skip items whose game is gone, and the
+-- janitor removes items once feed_retention has passed.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS feed_items (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL,
+    event TEXT NOT NULL,
+    game_id INTEGER NOT NULL,
+    actor_id INTEGER,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, event_id)
+);
+
+-- Index for reading a user's feed, newest first
+CREATE INDEX IF NOT EXISTS idx_feed_items_user ON feed_items(user_id, id);
+
+-- Index for removing items past the retention period
+CREATE INDEX IF NOT EXISTS idx_feed_items_created_at ON feed_items(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS feed_items;
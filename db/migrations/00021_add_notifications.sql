@@ -0,0 +1,28 @@
+-- In-app notifications of what happened to a user's runs: reviews, comments,
+-- and records they held being beaten. The notification sink writes one per
+-- recipient of each event, so event_id keeps a republished event from
+-- notifying anyone twice.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, event_id)
+);
+
+-- Index for listing a user's notifications, newest first
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, id);
+
+-- Index for counting a user's unread notifications
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- Index for removing notifications past the retention period
+CREATE INDEX IF NOT EXISTS idx_notifications_created_at ON notifications(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS notifications;
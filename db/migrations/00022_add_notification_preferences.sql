@@ -0,0 +1,17 @@
+-- Which channels each user is told about an event through: email, in-app
+-- notifications, and webhooks. Only the events a user has changed are
+-- stored; every channel is on for the rest.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    email BOOLEAN NOT NULL DEFAULT TRUE,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    webhooks BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, event)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,39 @@
+-- Race rooms where runners play a category at the same time. Participants
+-- ready up, the race starts at starts_at once everyone is ready, and each
+-- finish is stored as a run flagged with the race it was played in.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS races (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'running', 'finished', 'cancelled')),
+    -- End of the countdown, from which finish times are measured
+    starts_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index for listing a category's races
+CREATE INDEX IF NOT EXISTS idx_races_category ON races(category_id, created_at);
+
+-- Runners in a race. time_ms and run_id are set when they finish; a runner
+-- who leaves a running race has forfeited it instead.
+CREATE TABLE IF NOT EXISTS race_participants (
+    race_id INTEGER NOT NULL REFERENCES races(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ready BOOLEAN NOT NULL DEFAULT FALSE,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ,
+    time_ms BIGINT CHECK (time_ms > 0),
+    run_id INTEGER REFERENCES runs(id) ON DELETE SET NULL,
+    forfeited_at TIMESTAMPTZ,
+    PRIMARY KEY (race_id, user_id)
+);
+
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS race_id INTEGER REFERENCES races(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE runs DROP COLUMN IF EXISTS race_id;
+DROP TABLE IF EXISTS race_participants;
+DROP TABLE IF EXISTS races;
@@ -0,0 +1,22 @@
+-- Full-text indexes behind GET /search. They index expressions rather than
+-- stored tsvector columns, so the search queries must repeat each expression
+-- exactly for the planner to use its index. Names are indexed with the
+-- simple configuration, since stemming mangles names, and comments with the
+-- english one.
+
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_users_name_search ON users
+    USING GIN (to_tsvector('simple', name)) WHERE deleted_at IS NULL;
+
+-- A game matches on its name and, weighted lower, the words of its slug,
+-- which is often the abbreviation runners know it by
+CREATE INDEX IF NOT EXISTS idx_games_search ON games
+    USING GIN ((setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', replace(slug, '-', ' ')), 'B')));
+
+CREATE INDEX IF NOT EXISTS idx_run_comments_body_search ON run_comments
+    USING GIN (to_tsvector('english', body)) WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_run_comments_body_search;
+DROP INDEX IF EXISTS idx_games_search;
+DROP INDEX IF EXISTS idx_users_name_search;
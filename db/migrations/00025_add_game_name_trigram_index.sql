@@ -0,0 +1,13 @@
+-- Trigram index behind GET /games/suggest, which matches what a user has
+-- typed so far against the start of game names and slugs, or against words
+-- anywhere in them.
+-- pg_trgm is a trusted extension, so the migrating role needs only CREATE on
+-- the database; where it lacks that, enable the extension beforehand.
+
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_games_suggest ON games USING GIN (lower(name) gin_trgm_ops, slug gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_games_suggest;
@@ -0,0 +1,46 @@
+-- Long-running tasks clients start through the API, such as bulk imports and
+-- exports, which background workers run while the client polls their
+-- progress. Workers lease an operation while running it, so one whose worker
+-- died is picked up again once its lease runs out; the janitor deletes
+-- finished operations once they are past retention.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS operations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- What the operation does, e.g. users.import
+    kind VARCHAR(50) NOT NULL,
+    -- What it was started with, read by the worker running it
+    input JSONB NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    -- Items done so far, out of total when the total is known
+    completed INTEGER NOT NULL DEFAULT 0,
+    total INTEGER,
+    -- Outcome of a succeeded operation, or why it failed
+    result JSONB,
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    -- When the worker running the operation is presumed dead
+    lease_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Files produced by operations, such as exports, kept apart from the
+-- operations so polling one doesn't read its file
+CREATE TABLE IF NOT EXISTS operation_outputs (
+    operation_id UUID PRIMARY KEY REFERENCES operations(id) ON DELETE CASCADE,
+    content_type VARCHAR(100) NOT NULL,
+    filename VARCHAR(100) NOT NULL,
+    data BYTEA NOT NULL
+);
+
+-- Indexes for the workers' polling and the janitor's cleanup
+CREATE INDEX IF NOT EXISTS idx_operations_due ON operations(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_operations_finished_at ON operations(finished_at) WHERE finished_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS operation_outputs;
+DROP TABLE IF EXISTS operations;
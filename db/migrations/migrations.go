@@ -0,0 +1,86 @@
+// Package migrations embeds the SQL migrations that build the database schema
+// and applies them with goose.
+//
+// Migrations are numbered files such as 00002_add_run_notes.sql, each with a
+// "-- +goose Up" section and a "-- +goose Down" section that reverts it. sqlc
+// reads this directory as the schema, so a new migration is all it takes to
+// change the tables queries are generated against.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// NewProvider creates a goose provider that applies the embedded migrations
+// to db
+// Migrations take a Postgres advisory lock, so replicas that start at the same
+// time apply each migration exactly once.
+func NewProvider(db *sql.DB) (*goose.Provider, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration lock: %w", err)
+	}
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, files,
+		goose.WithSessionLocker(locker),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return provider, nil
+}
+
+// Up applies every pending migration and logs each one applied
+func Up(ctx context.Context, provider *goose.Provider) error {
+	results, err := provider.Up(ctx)
+	LogResults(ctx, results)
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// LogResults logs each migration that was applied or reverted
+func LogResults(ctx context.Context, results []*goose.MigrationResult) {
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		msg := "Migration applied"
+		if result.Direction == "down" {
+			msg = "Migration reverted"
+		}
+		slog.InfoContext(ctx, msg,
+			"version", result.Source.Version,
+			"file", result.Source.Path,
+			"duration_ms", result.Duration.Milliseconds(),
+		)
+	}
+}
+
+// CheckApplied returns an error while any migration is pending
+// It takes no lock, so readiness can be checked while another replica is
+// migrating.
+func CheckApplied(ctx context.Context, provider *goose.Provider) error {
+	pending, err := provider.HasPending(ctx)
+	if err != nil {
+		return err
+	}
+	if pending {
+		current, target, err := provider.GetVersions(ctx)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("schema is at version %d, want %d", current, target)
+	}
+	return nil
+}
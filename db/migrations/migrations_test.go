@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/fs"
+	"strings"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func TestNewProvider_LoadsEmbeddedMigrations(t *testing.T) {
+	// sql.Open does not connect, so no database is needed to load sources
+	conn, err := sql.Open("pgx", "postgres://localhost:1/unused")
+	if err != nil {
+		t.Fatalf("failed to open database handle: %v", err)
+	}
+	defer conn.Close()
+
+	provider, err := NewProvider(conn)
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	sources := provider.ListSources()
+	if len(sources) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+	for i, source := range sources {
+		if source.Version != int64(i+1) {
+			t.Errorf("expected version %d, got %d (%s)", i+1, source.Version, source.Path)
+		}
+	}
+}
+
+func TestMigrations_CanBeReverted(t *testing.T) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	for _, name := range names {
+		contents, err := fs.ReadFile(files, name)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if !strings.Contains(string(contents), "-- +goose Down") {
+			t.Errorf("%s has no -- +goose Down section", name)
+		}
+	}
+}
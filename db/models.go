@@ -8,10 +8,323 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiKey struct {
+	ID         int32              `json:"id"`
+	UserID     int32              `json:"user_id"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	KeyHash    string             `json:"key_hash"`
+	Scopes     []string           `json:"scopes"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type AuditEvent struct {
+	ID         int32              `json:"id"`
+	ActorID    pgtype.Int4        `json:"actor_id"`
+	Action     string             `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   int32              `json:"entity_id"`
+	Changes    []byte             `json:"changes"`
+	RequestID  pgtype.Text        `json:"request_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Category struct {
+	ID           int32              `json:"id"`
+	GameID       int32              `json:"game_id"`
+	Slug         string             `json:"slug"`
+	Name         string             `json:"name"`
+	Rules        string             `json:"rules"`
+	Position     int32              `json:"position"`
+	IsDefault    bool               `json:"is_default"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	TimingMethod string             `json:"timing_method"`
+}
+
+type CategoryRecord struct {
+	ID         int32              `json:"id"`
+	CategoryID int32              `json:"category_id"`
+	RunID      int32              `json:"run_id"`
+	SetAt      pgtype.Timestamptz `json:"set_at"`
+}
+
+type FeedItem struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	EventID   int32              `json:"event_id"`
+	Event     string             `json:"event"`
+	GameID    int32              `json:"game_id"`
+	ActorID   pgtype.Int4        `json:"actor_id"`
+	Payload   []byte             `json:"payload"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Game struct {
+	ID        int32              `json:"id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type GameFollow struct {
+	UserID    int32              `json:"user_id"`
+	GameID    int32              `json:"game_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type GameModerator struct {
+	GameID    int32              `json:"game_id"`
+	UserID    int32              `json:"user_id"`
+	Level     string             `json:"level"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	UserID          int32              `json:"user_id"`
+	Key             string             `json:"key"`
+	RequestHash     []byte             `json:"request_hash"`
+	StatusCode      pgtype.Int4        `json:"status_code"`
+	ResponseHeaders []byte             `json:"response_headers"`
+	ResponseBody    []byte             `json:"response_body"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type Level struct {
+	ID        int32              `json:"id"`
+	GameID    int32              `json:"game_id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Notification struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	EventID   int32              `json:"event_id"`
+	Event     string             `json:"event"`
+	Payload   []byte             `json:"payload"`
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type NotificationPreference struct {
+	UserID    int32              `json:"user_id"`
+	Event     string             `json:"event"`
+	Email     bool               `json:"email"`
+	InApp     bool               `json:"in_app"`
+	Webhooks  bool               `json:"webhooks"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Operation struct {
+	ID         pgtype.UUID        `json:"id"`
+	Kind       string             `json:"kind"`
+	Input      []byte             `json:"input"`
+	CreatedBy  int32              `json:"created_by"`
+	Status     string             `json:"status"`
+	Completed  int32              `json:"completed"`
+	Total      pgtype.Int4        `json:"total"`
+	Result     []byte             `json:"result"`
+	Error      pgtype.Text        `json:"error"`
+	Attempts   int32              `json:"attempts"`
+	LeaseUntil pgtype.Timestamptz `json:"lease_until"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	StartedAt  pgtype.Timestamptz `json:"started_at"`
+	FinishedAt pgtype.Timestamptz `json:"finished_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OperationOutput struct {
+	OperationID pgtype.UUID `json:"operation_id"`
+	ContentType string      `json:"content_type"`
+	Filename    string      `json:"filename"`
+	Data        []byte      `json:"data"`
+}
+
+type OutboxEvent struct {
+	ID            int32              `json:"id"`
+	Event         string             `json:"event"`
+	Payload       []byte             `json:"payload"`
+	PublishedTo   []string           `json:"published_to"`
+	Attempts      int32              `json:"attempts"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LastError     pgtype.Text        `json:"last_error"`
+	PublishedAt   pgtype.Timestamptz `json:"published_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type Platform struct {
+	ID        int32              `json:"id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Race struct {
+	ID         int32              `json:"id"`
+	CategoryID int32              `json:"category_id"`
+	CreatedBy  pgtype.Int4        `json:"created_by"`
+	Status     string             `json:"status"`
+	StartsAt   pgtype.Timestamptz `json:"starts_at"`
+	FinishedAt pgtype.Timestamptz `json:"finished_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type RaceParticipant struct {
+	RaceID      int32              `json:"race_id"`
+	UserID      int32              `json:"user_id"`
+	Ready       bool               `json:"ready"`
+	JoinedAt    pgtype.Timestamptz `json:"joined_at"`
+	FinishedAt  pgtype.Timestamptz `json:"finished_at"`
+	TimeMs      pgtype.Int8        `json:"time_ms"`
+	RunID       pgtype.Int4        `json:"run_id"`
+	ForfeitedAt pgtype.Timestamptz `json:"forfeited_at"`
+}
+
+type RefreshToken struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	TokenHash string             `json:"token_hash"`
+	FamilyID  pgtype.UUID        `json:"family_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type Region struct {
+	ID        int32              `json:"id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Run struct {
+	ID              int32              `json:"id"`
+	UserID          int32              `json:"user_id"`
+	CategoryID      int32              `json:"category_id"`
+	TimeMs          int64              `json:"time_ms"`
+	VideoUrl        string             `json:"video_url"`
+	Platform        string             `json:"platform"`
+	PlayedOn        pgtype.Date        `json:"played_on"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Status          string             `json:"status"`
+	RejectionReason pgtype.Text        `json:"rejection_reason"`
+	ReviewedAt      pgtype.Timestamptz `json:"reviewed_at"`
+	Obsolete        bool               `json:"obsolete"`
+	LevelID         pgtype.Int4        `json:"level_id"`
+	Region          pgtype.Text        `json:"region"`
+	RtaMs           pgtype.Int8        `json:"rta_ms"`
+	IgtMs           pgtype.Int8        `json:"igt_ms"`
+	LrtMs           pgtype.Int8        `json:"lrt_ms"`
+	RaceID          pgtype.Int4        `json:"race_id"`
+}
+
+type RunComment struct {
+	ID        int32              `json:"id"`
+	RunID     int32              `json:"run_id"`
+	UserID    int32              `json:"user_id"`
+	Body      string             `json:"body"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy pgtype.Int4        `json:"deleted_by"`
+}
+
+type RunVariableValue struct {
+	RunID      int32 `json:"run_id"`
+	VariableID int32 `json:"variable_id"`
+	ValueID    int32 `json:"value_id"`
+}
+
 type User struct {
-	ID        int32            `json:"id"`
-	Name      string           `json:"name"`
-	Email     string           `json:"email"`
-	CreatedAt pgtype.Timestamp `json:"created_at"`
-	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+	ID              int32              `json:"id"`
+	Name            string             `json:"name"`
+	Email           string             `json:"email"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	PublicID        pgtype.UUID        `json:"public_id"`
+	Version         int32              `json:"version"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+	TwitchHandle    pgtype.Text        `json:"twitch_handle"`
+	YoutubeHandle   pgtype.Text        `json:"youtube_handle"`
+	TwitterHandle   pgtype.Text        `json:"twitter_handle"`
+	CountryCode     pgtype.Text        `json:"country_code"`
+	Pronouns        pgtype.Text        `json:"pronouns"`
+	Bio             pgtype.Text        `json:"bio"`
+	AvatarUrl       pgtype.Text        `json:"avatar_url"`
+}
+
+type UserCredential struct {
+	UserID       int32              `json:"user_id"`
+	PasswordHash string             `json:"password_hash"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserFollow struct {
+	FollowerID int32              `json:"follower_id"`
+	FollowedID int32              `json:"followed_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserIdentity struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	Provider  string             `json:"provider"`
+	Subject   string             `json:"subject"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserRole struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	Role      string             `json:"role"`
+	GameID    pgtype.Int4        `json:"game_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Variable struct {
+	ID         int32              `json:"id"`
+	GameID     int32              `json:"game_id"`
+	CategoryID pgtype.Int4        `json:"category_id"`
+	Slug       string             `json:"slug"`
+	Name       string             `json:"name"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type VariableValue struct {
+	ID         int32  `json:"id"`
+	VariableID int32  `json:"variable_id"`
+	Slug       string `json:"slug"`
+	Label      string `json:"label"`
+	Position   int32  `json:"position"`
+}
+
+type Webhook struct {
+	ID        int32              `json:"id"`
+	Url       string             `json:"url"`
+	Secret    string             `json:"secret"`
+	Events    []string           `json:"events"`
+	CreatedBy pgtype.Int4        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int32              `json:"id"`
+	WebhookID      int32              `json:"webhook_id"`
+	Event          string             `json:"event"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	LastError      pgtype.Text        `json:"last_error"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	EventID        pgtype.Int4        `json:"event_id"`
 }
@@ -0,0 +1,19 @@
+-- name: GetNotificationPreference :one
+-- The user's channels for the event; no row is returned while they have
+-- every channel on
+SELECT user_id, event, email, in_app, webhooks, updated_at
+FROM notification_preferences
+WHERE user_id = $1 AND event = $2;
+
+-- name: ListNotificationPreferences :many
+SELECT user_id, event, email, in_app, webhooks, updated_at
+FROM notification_preferences
+WHERE user_id = $1
+ORDER BY event;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (user_id, event, email, in_app, webhooks)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, event) DO UPDATE
+SET email = EXCLUDED.email, in_app = EXCLUDED.in_app, webhooks = EXCLUDED.webhooks, updated_at = NOW()
+RETURNING user_id, event, email, in_app, webhooks, updated_at;
@@ -0,0 +1,45 @@
+-- name: CreateNotification :exec
+-- Notifies the user of the event unless they already were or their account
+-- has been purged
+INSERT INTO notifications (user_id, event_id, event, payload, created_at)
+SELECT u.id, @event_id::integer, @event::text, @payload::jsonb, @occurred_at::timestamptz
+FROM users u
+WHERE u.id = @user_id::integer
+ON CONFLICT (user_id, event_id) DO NOTHING;
+
+-- name: ListNotifications :many
+-- A user's notifications, newest first, optionally only the unread ones
+SELECT id, user_id, event_id, event, payload, read_at, created_at
+FROM notifications
+WHERE user_id = @user_id AND (NOT @unread_only::boolean OR read_at IS NULL)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListNotificationsAfter :many
+-- Keyset page of ListNotifications continuing after the notification with
+-- after_id
+SELECT id, user_id, event_id, event, payload, read_at, created_at
+FROM notifications
+WHERE user_id = @user_id AND (NOT @unread_only::boolean OR read_at IS NULL) AND id < @after_id
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountNotifications :one
+SELECT COUNT(*)
+FROM notifications
+WHERE user_id = @user_id AND (NOT @unread_only::boolean OR read_at IS NULL);
+
+-- name: MarkNotificationRead :execrows
+-- Reading a notification again keeps when it was first read
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_at = NOW()
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: DeleteExpiredNotifications :execrows
+DELETE FROM notifications
+WHERE created_at < $1;
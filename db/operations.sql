@@ -0,0 +1,62 @@
+-- name: CreateOperation :one
+INSERT INTO operations (kind, input, created_by, total)
+VALUES ($1, $2, $3, $4)
+RETURNING id, kind, input, created_by, status, completed, total, result, error, attempts, lease_until, created_at, started_at, finished_at, updated_at;
+
+-- name: GetOperationByID :one
+SELECT id, kind, input, created_by, status, completed, total, result, error, attempts, lease_until, created_at, started_at, finished_at, updated_at
+FROM operations
+WHERE id = $1;
+
+-- name: ClaimOperations :many
+-- Leases up to batch_size operations to the caller, oldest first: pending
+-- ones, and running ones whose worker let their lease run out. Each claim
+-- counts an attempt, so one that keeps killing its worker can be given up on.
+UPDATE operations
+SET status = 'running', attempts = attempts + 1, lease_until = @lease_until,
+    started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM operations
+    WHERE status = 'pending' OR (status = 'running' AND lease_until <= NOW())
+    ORDER BY created_at
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, kind, input, created_by, status, completed, total, result, error, attempts, lease_until, created_at, started_at, finished_at, updated_at;
+
+-- name: UpdateOperationProgress :exec
+-- Records progress and extends the lease of a running operation
+UPDATE operations
+SET completed = $2, total = $3, lease_until = $4, updated_at = NOW()
+WHERE id = $1 AND status = 'running';
+
+-- name: RequeueOperation :exec
+-- Hands a running operation back for any worker to claim, as a worker that is
+-- stopping does with the ones it didn't finish
+UPDATE operations
+SET status = 'pending', lease_until = NULL, updated_at = NOW()
+WHERE id = $1 AND status = 'running';
+
+-- name: FinishOperation :exec
+-- Records the outcome of an operation, with its final progress: its result
+-- when it succeeded or its error when it failed
+UPDATE operations
+SET status = $2, result = $3, error = $4, completed = $5, total = $6,
+    lease_until = NULL, finished_at = NOW(), updated_at = NOW()
+WHERE id = $1;
+
+-- name: SaveOperationOutput :exec
+INSERT INTO operation_outputs (operation_id, content_type, filename, data)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (operation_id) DO UPDATE
+SET content_type = EXCLUDED.content_type, filename = EXCLUDED.filename, data = EXCLUDED.data;
+
+-- name: GetOperationOutput :one
+SELECT operation_id, content_type, filename, data
+FROM operation_outputs
+WHERE operation_id = $1;
+
+-- name: DeleteFinishedOperations :execrows
+-- Operations still pending or running are kept however old they are
+DELETE FROM operations
+WHERE finished_at < $1;
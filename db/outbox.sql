@@ -0,0 +1,31 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (event, payload)
+VALUES ($1, $2);
+
+-- name: ClaimOutboxEvents :many
+-- Leases up to batch_size due events to the caller by counting the attempt
+-- and pushing the next one to lease_until, so other dispatchers skip them
+-- while they are being published and retry them if the caller dies
+UPDATE outbox_events
+SET attempts = attempts + 1, next_attempt_at = @lease_until
+WHERE id IN (
+    SELECT id FROM outbox_events
+    WHERE published_at IS NULL AND next_attempt_at <= NOW()
+    ORDER BY id
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, event, payload, published_to, attempts, next_attempt_at, last_error, published_at, created_at;
+
+-- name: UpdateOutboxEvent :exec
+-- Records the sinks an event has been published to; an event whose
+-- published_at is left NULL is tried again at next_attempt_at
+UPDATE outbox_events
+SET published_to = $2, published_at = $3, next_attempt_at = $4, last_error = $5
+WHERE id = $1;
+
+-- name: DeletePublishedOutboxEvents :execrows
+-- Unpublished events are kept however old they are, so none is lost while a
+-- sink is down
+DELETE FROM outbox_events
+WHERE published_at < $1;
@@ -0,0 +1,24 @@
+-- name: ListPlatforms :many
+SELECT id, slug, name, created_at
+FROM platforms
+ORDER BY name, id;
+
+-- name: GetPlatformBySlug :one
+SELECT id, slug, name, created_at
+FROM platforms
+WHERE slug = $1;
+
+-- name: CreatePlatform :one
+INSERT INTO platforms (slug, name)
+VALUES ($1, $2)
+RETURNING id, slug, name, created_at;
+
+-- name: UpdatePlatform :one
+-- Only the name can change; runs refer to the platform by its slug
+UPDATE platforms
+SET name = @name
+WHERE slug = @slug
+RETURNING id, slug, name, created_at;
+
+-- name: DeletePlatform :execrows
+DELETE FROM platforms WHERE slug = $1;
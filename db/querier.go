@@ -6,16 +6,347 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	CountUsers(ctx context.Context) (int64, error)
+	// Returns no row when the user already moderates the game
+	AddGameModerator(ctx context.Context, arg AddGameModeratorParams) (GameModerator, error)
+	// Affects no row when the user is already in the race
+	AddRaceParticipant(ctx context.Context, arg AddRaceParticipantParams) (int64, error)
+	// Affects no rows when the caller has already used the key
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error)
+	// Leases up to batch_size operations to the caller, oldest first: pending
+	// ones, and running ones whose worker let their lease run out. Each claim
+	// counts an attempt, so one that keeps killing its worker can be given up on.
+	ClaimOperations(ctx context.Context, arg ClaimOperationsParams) ([]Operation, error)
+	// Leases up to batch_size due events to the caller by counting the attempt
+	// and pushing the next one to lease_until, so other dispatchers skip them
+	// while they are being published and retry them if the caller dies
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error)
+	// Leases up to batch_size due deliveries to the caller by counting the attempt
+	// and pushing the next one to lease_until, so other dispatchers skip them
+	// while they are being sent and retry them if the caller dies
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
+	CountAuditEvents(ctx context.Context, arg CountAuditEventsParams) (int64, error)
+	CountFeedItems(ctx context.Context, userID int32) (int64, error)
+	CountGameFollowers(ctx context.Context, gameID int32) (int64, error)
+	CountGames(ctx context.Context) (int64, error)
+	CountLeaderboard(ctx context.Context, arg CountLeaderboardParams) (int64, error)
+	CountModerationQueue(ctx context.Context, gameID int32) (int64, error)
+	CountNotifications(ctx context.Context, arg CountNotificationsParams) (int64, error)
+	// Deleted comments still count, so removing spam does not make room for more
+	CountRecentRunCommentsByUser(ctx context.Context, arg CountRecentRunCommentsByUserParams) (int64, error)
+	CountRunComments(ctx context.Context, runID int32) (int64, error)
+	CountRunsByCategory(ctx context.Context, arg CountRunsByCategoryParams) (int64, error)
+	CountRunsByUser(ctx context.Context, arg CountRunsByUserParams) (int64, error)
+	CountUsers(ctx context.Context, arg CountUsersParams) (int64, error)
+	CountWebhookDeliveries(ctx context.Context, webhookID int32) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	// Records that the run took its category's record
+	CreateCategoryRecord(ctx context.Context, arg CreateCategoryRecordParams) error
+	// Adds the event to the feed of every user who follows its game or its actor,
+	// other than the actor, skipping feeds it is already in
+	CreateFeedItems(ctx context.Context, arg CreateFeedItemsParams) error
+	CreateGame(ctx context.Context, arg CreateGameParams) (Game, error)
+	CreateLevel(ctx context.Context, arg CreateLevelParams) (Level, error)
+	// Notifies the user of the event unless they already were or their account
+	// has been purged
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) error
+	CreateOperation(ctx context.Context, arg CreateOperationParams) (Operation, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
+	CreatePlatform(ctx context.Context, arg CreatePlatformParams) (Platform, error)
+	CreateRace(ctx context.Context, arg CreateRaceParams) (Race, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
+	CreateRegion(ctx context.Context, arg CreateRegionParams) (Region, error)
+	CreateRun(ctx context.Context, arg CreateRunParams) (Run, error)
+	CreateRunComment(ctx context.Context, arg CreateRunCommentParams) (RunComment, error)
+	CreateRunVariableValue(ctx context.Context, arg CreateRunVariableValueParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteUser(ctx context.Context, id int32) error
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
+	CreateUserWithIdentity(ctx context.Context, arg CreateUserWithIdentityParams) (User, error)
+	CreateUserWithPassword(ctx context.Context, arg CreateUserWithPasswordParams) (User, error)
+	CreateVariable(ctx context.Context, arg CreateVariableParams) (Variable, error)
+	CreateVariableValue(ctx context.Context, arg CreateVariableValueParams) (VariableValue, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	DeleteExpiredFeedItems(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteExpiredNotifications(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteExpiredRefreshTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+	// Operations still pending or running are kept however old they are
+	DeleteFinishedOperations(ctx context.Context, finishedAt pgtype.Timestamptz) (int64, error)
+	// Pending deliveries are kept however old they are, so none is dropped
+	// before it runs out of attempts
+	DeleteFinishedWebhookDeliveries(ctx context.Context, updatedAt pgtype.Timestamptz) (int64, error)
+	DeleteGame(ctx context.Context, slug string) (int64, error)
+	// Releases a key whose request failed, so a retry is served again
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeletePlatform(ctx context.Context, slug string) (int64, error)
+	// Unpublished events are kept however old they are, so none is lost while a
+	// sink is down
+	DeletePublishedOutboxEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error)
+	DeleteRegion(ctx context.Context, slug string) (int64, error)
+	// Returns no row when the comment was already deleted
+	DeleteRunComment(ctx context.Context, arg DeleteRunCommentParams) (RunComment, error)
+	// Soft-deletes the user; RestoreUser undoes it and PurgeUser makes it permanent
+	DeleteUser(ctx context.Context, id int32) (int64, error)
+	DeleteWebhook(ctx context.Context, id int32) (int64, error)
+	// Sets status to finished or cancelled
+	EndRace(ctx context.Context, arg EndRaceParams) (Race, error)
+	// Queues a delivery of the event to every webhook subscribed to it, skipping
+	// webhooks it was already queued for
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) error
+	// Records the outcome of an operation, with its final progress: its result
+	// when it succeeded or its error when it failed
+	FinishOperation(ctx context.Context, arg FinishOperationParams) error
+	FinishRaceParticipant(ctx context.Context, arg FinishRaceParticipantParams) (int64, error)
+	// Affects no row when the user already follows the game
+	FollowGame(ctx context.Context, arg FollowGameParams) (int64, error)
+	// Affects no row when the follower already follows the user
+	FollowUser(ctx context.Context, arg FollowUserParams) (int64, error)
+	ForfeitRaceParticipant(ctx context.Context, arg ForfeitRaceParticipantParams) (int64, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetCategoriesByIDs(ctx context.Context, ids []int32) ([]Category, error)
+	GetCategoryByID(ctx context.Context, id int32) (Category, error)
+	GetCategoryBySlug(ctx context.Context, arg GetCategoryBySlugParams) (Category, error)
+	GetCredentialsByEmail(ctx context.Context, email string) (GetCredentialsByEmailRow, error)
+	// A game has at most one default category
+	GetDefaultCategory(ctx context.Context, gameID int32) (Category, error)
+	// The category's full-game record, ignoring the run with exclude_id; ties
+	// are broken the same way as on the leaderboard
+	GetFastestVerifiedRun(ctx context.Context, arg GetFastestVerifiedRunParams) (Run, error)
+	GetGameBySlug(ctx context.Context, slug string) (Game, error)
+	GetGameModerator(ctx context.Context, arg GetGameModeratorParams) (GameModerator, error)
+	GetGamesByIDs(ctx context.Context, ids []int32) ([]Game, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	// Ranks each runner's best run; equal times share a rank (1, 1, 3) and are
+	// listed by who played them first. Only runs of the given level (none for
+	// full-game runs) with all of the given variable values are ranked; an
+	// empty list of values ranks every run. Runs are limited to the given
+	// platform and region unless they are null.
+	GetLeaderboard(ctx context.Context, arg GetLeaderboardParams) ([]GetLeaderboardRow, error)
+	// Keyset page of GetLeaderboard continuing after the given entry; ranks are
+	// still computed over every runner, so they match the offset pages
+	GetLeaderboardAfter(ctx context.Context, arg GetLeaderboardAfterParams) ([]GetLeaderboardAfterRow, error)
+	GetLevelBySlug(ctx context.Context, arg GetLevelBySlugParams) (Level, error)
+	// The user's channels for the event; no row is returned while they have
+	// every channel on
+	GetNotificationPreference(ctx context.Context, arg GetNotificationPreferenceParams) (NotificationPreference, error)
+	GetOperationByID(ctx context.Context, id pgtype.UUID) (Operation, error)
+	GetOperationOutput(ctx context.Context, operationID pgtype.UUID) (OperationOutput, error)
+	// The user's best full-game verified run in each category they have one
+	// in, ranked the same way as on that category's leaderboard, with the
+	// category's record
+	GetPersonalBests(ctx context.Context, userID int32) ([]GetPersonalBestsRow, error)
+	GetPlatformBySlug(ctx context.Context, slug string) (Platform, error)
+	GetRaceByID(ctx context.Context, id int32) (Race, error)
+	// Locks the race until the transaction ends, so changes to it and its
+	// participants are made one at a time
+	GetRaceByIDForUpdate(ctx context.Context, id int32) (Race, error)
+	GetRaceParticipant(ctx context.Context, arg GetRaceParticipantParams) (RaceParticipant, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	GetRegionBySlug(ctx context.Context, slug string) (Region, error)
+	GetRunByID(ctx context.Context, id int32) (Run, error)
+	GetRunCommentByID(ctx context.Context, id int32) (RunComment, error)
+	// The names a notification about the run refers to; no row is returned once
+	// the runner's account is deleted
+	GetRunSummary(ctx context.Context, id int32) (GetRunSummaryRow, error)
+	// Includes soft-deleted users: their email stays taken until they are purged
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int32) (User, error)
-	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	// For callers that must tell a soft-deleted user apart from a missing one
+	GetUserByIDIncludingDeleted(ctx context.Context, id int32) (User, error)
+	GetUserByPublicID(ctx context.Context, publicID pgtype.UUID) (User, error)
+	// Deleted users are not counted on either side
+	GetUserFollowCounts(ctx context.Context, userID int32) (GetUserFollowCountsRow, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
+	GetUserStats(ctx context.Context, corporateDomains []string) (GetUserStatsRow, error)
+	// Includes soft-deleted users, like GetUserByEmail
+	GetUsersByEmails(ctx context.Context, emails []string) ([]User, error)
+	GetUsersByIDs(ctx context.Context, ids []int32) ([]User, error)
+	GetWebhookByID(ctx context.Context, id int32) (Webhook, error)
+	// Returns no row when the user already has the role
+	GrantUserRole(ctx context.Context, arg GrantUserRoleParams) (UserRole, error)
+	ImportUsers(ctx context.Context, arg []ImportUsersParams) (int64, error)
+	ListAPIKeysByUser(ctx context.Context, userID int32) ([]ApiKey, error)
+	// The category's open and running races, oldest first
+	ListActiveRacesByCategory(ctx context.Context, categoryID int32) ([]Race, error)
+	// Newest first. A NULL filter matches every event; the time range includes
+	// created_from and excludes created_to.
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	// Keyset page of ListAuditEvents continuing after the event with after_id,
+	// i.e. with events recorded before it
+	ListAuditEventsAfter(ctx context.Context, arg ListAuditEventsAfterParams) ([]AuditEvent, error)
+	ListCategoriesByGame(ctx context.Context, gameID int32) ([]Category, error)
+	// The categories of several games, grouped by game in display order
+	ListCategoriesByGames(ctx context.Context, gameIds []int32) ([]Category, error)
+	// The category's record progression, oldest first; records set by users who
+	// have since been deleted are left out
+	ListCategoryRecords(ctx context.Context, categoryID int32) ([]ListCategoryRecordsRow, error)
+	// A user's feed, newest first; items about games that have since been deleted
+	// are left out
+	ListFeedItems(ctx context.Context, arg ListFeedItemsParams) ([]ListFeedItemsRow, error)
+	// Keyset page of ListFeedItems continuing after the item with after_id
+	ListFeedItemsAfter(ctx context.Context, arg ListFeedItemsAfterParams) ([]ListFeedItemsAfterRow, error)
+	// Games a user follows, most recent follow first
+	ListFollowedGames(ctx context.Context, arg ListFollowedGamesParams) ([]ListFollowedGamesRow, error)
+	// Keyset page of ListFollowedGames continuing after the given follow
+	ListFollowedGamesAfter(ctx context.Context, arg ListFollowedGamesAfterParams) ([]ListFollowedGamesAfterRow, error)
+	// Users following a game, most recent follow first; deleted users are left
+	// out
+	ListGameFollowers(ctx context.Context, arg ListGameFollowersParams) ([]ListGameFollowersRow, error)
+	// Keyset page of ListGameFollowers continuing after the given follow
+	ListGameFollowersAfter(ctx context.Context, arg ListGameFollowersAfterParams) ([]ListGameFollowersAfterRow, error)
+	// Super moderators first, then verifiers, each in the order they were added
+	ListGameModerators(ctx context.Context, gameID int32) ([]ListGameModeratorsRow, error)
+	ListGameModeratorsByUser(ctx context.Context, userID int32) ([]GameModerator, error)
+	ListGames(ctx context.Context, arg ListGamesParams) ([]Game, error)
+	// Keyset page of ListGames continuing after the game with after_id
+	ListGamesAfter(ctx context.Context, arg ListGamesAfterParams) ([]Game, error)
+	ListLevelsByGame(ctx context.Context, gameID int32) ([]Level, error)
+	// Pending runs of every category of a game, longest waiting first
+	ListModerationQueue(ctx context.Context, arg ListModerationQueueParams) ([]Run, error)
+	// Keyset page of ListModerationQueue continuing after the given run
+	ListModerationQueueAfter(ctx context.Context, arg ListModerationQueueAfterParams) ([]Run, error)
+	ListNotificationPreferences(ctx context.Context, userID int32) ([]NotificationPreference, error)
+	// A user's notifications, newest first, optionally only the unread ones
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	// Keyset page of ListNotifications continuing after the notification with
+	// after_id
+	ListNotificationsAfter(ctx context.Context, arg ListNotificationsAfterParams) ([]Notification, error)
+	ListPlatforms(ctx context.Context) ([]Platform, error)
+	// Finishers fastest first, then everyone else in the order they joined
+	ListRaceParticipants(ctx context.Context, raceID int32) ([]ListRaceParticipantsRow, error)
+	ListRegions(ctx context.Context) ([]Region, error)
+	// Comments of a run that have not been deleted, oldest first
+	ListRunComments(ctx context.Context, arg ListRunCommentsParams) ([]RunComment, error)
+	// Keyset page of ListRunComments continuing after the comment with after_id
+	ListRunCommentsAfter(ctx context.Context, arg ListRunCommentsAfterParams) ([]RunComment, error)
+	// The variable values of each of the runs, by slug
+	ListRunVariableValues(ctx context.Context, runIds []int32) ([]ListRunVariableValuesRow, error)
+	// Obsolete runs are left out unless include_obsolete is set
+	ListRunsByCategory(ctx context.Context, arg ListRunsByCategoryParams) ([]Run, error)
+	// Keyset page of ListRunsByCategory continuing after the given run
+	ListRunsByCategoryAfter(ctx context.Context, arg ListRunsByCategoryAfterParams) ([]Run, error)
+	// Obsolete runs are left out unless include_obsolete is set
+	ListRunsByUser(ctx context.Context, arg ListRunsByUserParams) ([]Run, error)
+	// Keyset page of ListRunsByUser continuing after the given run, i.e. with
+	// runs submitted before it
+	ListRunsByUserAfter(ctx context.Context, arg ListRunsByUserAfterParams) ([]Run, error)
+	// Users following followed_id, most recent follow first; deleted users are
+	// left out
+	ListUserFollowers(ctx context.Context, arg ListUserFollowersParams) ([]ListUserFollowersRow, error)
+	// Keyset page of ListUserFollowers continuing after the given follow
+	ListUserFollowersAfter(ctx context.Context, arg ListUserFollowersAfterParams) ([]ListUserFollowersAfterRow, error)
+	// Users follower_id follows, most recent follow first; deleted users are
+	// left out
+	ListUserFollowing(ctx context.Context, arg ListUserFollowingParams) ([]ListUserFollowingRow, error)
+	// Keyset page of ListUserFollowing continuing after the given follow
+	ListUserFollowingAfter(ctx context.Context, arg ListUserFollowingAfterParams) ([]ListUserFollowingAfterRow, error)
+	ListUserRoles(ctx context.Context, userID int32) ([]UserRole, error)
+	// Sorts by the column named by sort, one of those listed in ORDER BY; any
+	// other value sorts by id. Ties are broken by id in the same direction, so
+	// ListUsersAfter can seek past the last row of a page. Every row carries the
+	// number of users matching the filters, so a page needs no CountUsers; a
+	// page past the end has no rows to carry it.
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error)
+	// Keyset page of ListUsers continuing after the user with after_id, whose
+	// sort column holds after_text (name, email) or after_time (created_at,
+	// updated_at)
+	ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error)
+	ListVariableValuesByGame(ctx context.Context, gameID int32) ([]VariableValue, error)
+	ListVariablesByGame(ctx context.Context, gameID int32) ([]Variable, error)
+	// Newest first
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Keyset page of ListWebhookDeliveries continuing after the delivery with
+	// after_id, i.e. with deliveries queued before it
+	ListWebhookDeliveriesAfter(ctx context.Context, arg ListWebhookDeliveriesAfterParams) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	MarkAllNotificationsRead(ctx context.Context, userID int32) (int64, error)
+	// Reading a notification again keeps when it was first read
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (int64, error)
+	// Sends payload to every connection listening on channel; it is dropped if
+	// none are
+	NotifyListeners(ctx context.Context, arg NotifyListenersParams) error
+	// Marks the runner's verified runs in the run's category and level with the
+	// same variable values obsolete, except their best, which is picked the same
+	// way as on the leaderboard
+	ObsoleteBeatenRuns(ctx context.Context, runID int32) ([]Run, error)
+	PurgeUser(ctx context.Context, id int32) (int64, error)
+	RemoveGameModerator(ctx context.Context, arg RemoveGameModeratorParams) (int64, error)
+	RemoveRaceParticipant(ctx context.Context, arg RemoveRaceParticipantParams) (int64, error)
+	// Hands a running operation back for any worker to claim, as a worker that is
+	// stopping does with the ones it didn't finish
+	RequeueOperation(ctx context.Context, id pgtype.UUID) error
+	RestoreUser(ctx context.Context, id int32) (User, error)
+	// Scoped to the owner so one user cannot revoke another's key by ID
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (ApiKey, error)
+	RevokeRefreshToken(ctx context.Context, id int32) (int64, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int32) (int64, error)
+	SaveOperationOutput(ctx context.Context, arg SaveOperationOutputParams) error
+	// Games whose name or slug matches a web search query, best match first. The
+	// expressions match idx_games_search.
+	SearchGames(ctx context.Context, arg SearchGamesParams) ([]SearchGamesRow, error)
+	// Runs with a comment matching a web search query, best match first, each
+	// with an excerpt of its best matching comment. The expressions match
+	// idx_run_comments_body_search.
+	SearchRuns(ctx context.Context, arg SearchRunsParams) ([]SearchRunsRow, error)
+	// Users whose name matches a web search query, best match first. The
+	// expressions match idx_users_name_search.
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SeedCategory(ctx context.Context, arg SeedCategoryParams) error
+	// Replays the verified runs in the order they were reviewed, recording each
+	// one that took its category's record, as migration 00008 does
+	SeedCategoryRecords(ctx context.Context) error
+	SeedGame(ctx context.Context, arg SeedGameParams) error
+	SeedPlatform(ctx context.Context, arg SeedPlatformParams) error
+	SeedRegion(ctx context.Context, arg SeedRegionParams) error
+	SeedRun(ctx context.Context, arg SeedRunParams) error
+	SeedUser(ctx context.Context, arg SeedUserParams) error
+	SeedUserPassword(ctx context.Context, arg SeedUserPasswordParams) error
+	SetRaceParticipantReady(ctx context.Context, arg SetRaceParticipantReadyParams) (int64, error)
+	// Points the user at a newly uploaded avatar, or at none when avatar_url is
+	// NULL. Changes the version, so copies read before the upload are stale.
+	SetUserAvatar(ctx context.Context, arg SetUserAvatarParams) (User, error)
+	StartRace(ctx context.Context, arg StartRaceParams) (Race, error)
+	// Games whose name or slug starts with prefix, then those with a word like
+	// query, best match first. The expressions match idx_games_suggest.
+	SuggestGames(ctx context.Context, arg SuggestGamesParams) ([]Game, error)
+	// Moves each sequence past the highest ID in its table, so rows created after
+	// seeding don't collide with the fixed IDs
+	SyncSeededSequences(ctx context.Context) error
+	TouchAPIKey(ctx context.Context, id int32) error
+	UnfollowGame(ctx context.Context, arg UnfollowGameParams) (int64, error)
+	UnfollowUser(ctx context.Context, arg UnfollowUserParams) (int64, error)
+	UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, error)
+	// Records progress and extends the lease of a running operation
+	UpdateOperationProgress(ctx context.Context, arg UpdateOperationProgressParams) error
+	// Records the sinks an event has been published to; an event whose
+	// published_at is left NULL is tried again at next_attempt_at
+	UpdateOutboxEvent(ctx context.Context, arg UpdateOutboxEventParams) error
+	// Only the name can change; runs refer to the platform by its slug
+	UpdatePlatform(ctx context.Context, arg UpdatePlatformParams) (Platform, error)
+	// Only the name can change; runs refer to the region by its slug
+	UpdateRegion(ctx context.Context, arg UpdateRegionParams) (Region, error)
+	UpdateRunComment(ctx context.Context, arg UpdateRunCommentParams) (RunComment, error)
+	UpdateRunStatus(ctx context.Context, arg UpdateRunStatusParams) (Run, error)
+	// Only applies if the user is still at the version the caller read, so
+	// concurrent edits can't overwrite each other. Changing the email clears its
+	// verification. Every profile field is written, so callers pass the current
+	// value of those they are not changing.
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	// Records the outcome of an attempt; a delivery left pending is attempted
+	// again at next_attempt_at
+	UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
+	// Only applies while the user still has the email the verification was sent
+	// to, so a token mailed to an old address cannot verify a new one
+	VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
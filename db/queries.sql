@@ -1,32 +1,184 @@
 -- name: GetUserByID :one
-SELECT id, name, email, created_at, updated_at
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetUserByIDIncludingDeleted :one
+-- For callers that must tell a soft-deleted user apart from a missing one
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
 FROM users
 WHERE id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, name, email, created_at, updated_at
+-- Includes soft-deleted users: their email stays taken until they are purged
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
 FROM users
 WHERE email = $1;
 
+-- name: GetUserByPublicID :one
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE public_id = $1 AND deleted_at IS NULL;
+
+-- name: GetCredentialsByEmail :one
+SELECT u.id, c.password_hash
+FROM users u
+JOIN user_credentials c ON c.user_id = u.id
+WHERE u.email = $1 AND u.deleted_at IS NULL;
+
+-- name: GetUsersByEmails :many
+-- Includes soft-deleted users, like GetUserByEmail
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE email = ANY(@emails::text[])
+ORDER BY id;
+
+-- name: GetUsersByIDs :many
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE id = ANY(@ids::int[]) AND deleted_at IS NULL
+ORDER BY id;
+
 -- name: ListUsers :many
-SELECT id, name, email, created_at, updated_at
+-- Sorts by the column named by sort, one of those listed in ORDER BY; any
+-- other value sorts by id. Ties are broken by id in the same direction, so
+-- ListUsersAfter can seek past the last row of a page. Every row carries the
+-- number of users matching the filters, so a page needs no CountUsers; a
+-- page past the end has no rows to carry it.
+SELECT sqlc.embed(users), COUNT(*) OVER() AS total
 FROM users
-ORDER BY id
-LIMIT $1 OFFSET $2;
+WHERE (sqlc.narg(corporate)::boolean IS NULL
+   OR (split_part(email, '@', 2) = ANY(@corporate_domains::text[])) = sqlc.narg(corporate)::boolean)
+  AND (sqlc.narg(name)::text IS NULL OR name ILIKE '%' || sqlc.narg(name)::text || '%')
+  AND (sqlc.narg(email_domain)::text IS NULL OR lower(split_part(email, '@', 2)) = sqlc.narg(email_domain)::text)
+  AND (@include_deleted::boolean OR deleted_at IS NULL)
+ORDER BY
+    CASE WHEN @sort::text = 'name' AND NOT @descending::boolean THEN name END,
+    CASE WHEN @sort::text = 'name' AND @descending::boolean THEN name END DESC,
+    CASE WHEN @sort::text = 'email' AND NOT @descending::boolean THEN email END,
+    CASE WHEN @sort::text = 'email' AND @descending::boolean THEN email END DESC,
+    CASE WHEN @sort::text = 'created_at' AND NOT @descending::boolean THEN created_at END,
+    CASE WHEN @sort::text = 'created_at' AND @descending::boolean THEN created_at END DESC,
+    CASE WHEN @sort::text = 'updated_at' AND NOT @descending::boolean THEN updated_at END,
+    CASE WHEN @sort::text = 'updated_at' AND @descending::boolean THEN updated_at END DESC,
+    CASE WHEN @descending::boolean THEN id END DESC,
+    id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListUsersAfter :many
+-- Keyset page of ListUsers continuing after the user with after_id, whose
+-- sort column holds after_text (name, email) or after_time (created_at,
+-- updated_at)
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE (sqlc.narg(corporate)::boolean IS NULL
+   OR (split_part(email, '@', 2) = ANY(@corporate_domains::text[])) = sqlc.narg(corporate)::boolean)
+  AND (sqlc.narg(name)::text IS NULL OR name ILIKE '%' || sqlc.narg(name)::text || '%')
+  AND (sqlc.narg(email_domain)::text IS NULL OR lower(split_part(email, '@', 2)) = sqlc.narg(email_domain)::text)
+  AND (@include_deleted::boolean OR deleted_at IS NULL)
+  AND CASE @sort::text
+        WHEN 'name' THEN CASE WHEN @descending::boolean THEN (name, id) < (@after_text::text, @after_id::int)
+             ELSE (name, id) > (@after_text::text, @after_id::int) END
+        WHEN 'email' THEN CASE WHEN @descending::boolean THEN (email, id) < (@after_text::text, @after_id::int)
+             ELSE (email, id) > (@after_text::text, @after_id::int) END
+        WHEN 'created_at' THEN CASE WHEN @descending::boolean THEN (created_at, id) < (@after_time::timestamptz, @after_id::int)
+             ELSE (created_at, id) > (@after_time::timestamptz, @after_id::int) END
+        WHEN 'updated_at' THEN CASE WHEN @descending::boolean THEN (updated_at, id) < (@after_time::timestamptz, @after_id::int)
+             ELSE (updated_at, id) > (@after_time::timestamptz, @after_id::int) END
+        ELSE CASE WHEN @descending::boolean THEN id < @after_id::int ELSE id > @after_id::int END
+      END
+ORDER BY
+    CASE WHEN @sort::text = 'name' AND NOT @descending::boolean THEN name END,
+    CASE WHEN @sort::text = 'name' AND @descending::boolean THEN name END DESC,
+    CASE WHEN @sort::text = 'email' AND NOT @descending::boolean THEN email END,
+    CASE WHEN @sort::text = 'email' AND @descending::boolean THEN email END DESC,
+    CASE WHEN @sort::text = 'created_at' AND NOT @descending::boolean THEN created_at END,
+    CASE WHEN @sort::text = 'created_at' AND @descending::boolean THEN created_at END DESC,
+    CASE WHEN @sort::text = 'updated_at' AND NOT @descending::boolean THEN updated_at END,
+    CASE WHEN @sort::text = 'updated_at' AND @descending::boolean THEN updated_at END DESC,
+    CASE WHEN @descending::boolean THEN id END DESC,
+    id
+LIMIT sqlc.arg('limit');
 
 -- name: CountUsers :one
-SELECT COUNT(*) FROM users;
+SELECT COUNT(*) FROM users
+WHERE (sqlc.narg(corporate)::boolean IS NULL
+   OR (split_part(email, '@', 2) = ANY(@corporate_domains::text[])) = sqlc.narg(corporate)::boolean)
+  AND (sqlc.narg(name)::text IS NULL OR name ILIKE '%' || sqlc.narg(name)::text || '%')
+  AND (sqlc.narg(email_domain)::text IS NULL OR lower(split_part(email, '@', 2)) = sqlc.narg(email_domain)::text)
+  AND (@include_deleted::boolean OR deleted_at IS NULL);
+
+-- name: GetUserStats :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE split_part(email, '@', 2) = ANY(@corporate_domains::text[])) AS corporate,
+    COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours') AS last_24h,
+    COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '7 days') AS last_7d,
+    COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days') AS last_30d
+FROM users
+WHERE deleted_at IS NULL;
 
 -- name: CreateUser :one
 INSERT INTO users (name, email)
 VALUES ($1, $2)
-RETURNING id, name, email, created_at, updated_at;
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url;
+
+-- name: ImportUsers :copyfrom
+INSERT INTO users (name, email)
+VALUES ($1, $2);
+
+-- name: CreateUserWithPassword :one
+-- Inserts the user and their credentials in one statement so a user is never
+-- left without the password they registered with
+WITH new_user AS (
+    INSERT INTO users (name, email)
+    VALUES (@name, @email)
+    RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+), credentials AS (
+    INSERT INTO user_credentials (user_id, password_hash)
+    SELECT id, @password_hash FROM new_user
+)
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM new_user;
 
 -- name: UpdateUser :one
+-- Only applies if the user is still at the version the caller read, so
+-- concurrent edits can't overwrite each other. Changing the email clears its
+-- verification. Every profile field is written, so callers pass the current
+-- value of those they are not changing.
+UPDATE users
+SET name = $1, email = $2, updated_at = NOW(), version = version + 1,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
+    twitch_handle = $5, youtube_handle = $6, twitter_handle = $7,
+    country_code = $8, pronouns = $9, bio = $10
+WHERE id = $3 AND deleted_at IS NULL AND version = $4
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url;
+
+-- name: DeleteUser :execrows
+-- Soft-deletes the user; RestoreUser undoes it and PurgeUser makes it permanent
+UPDATE users SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreUser :one
+UPDATE users
+SET deleted_at = NULL, updated_at = NOW(), version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url;
+
+-- name: SetUserAvatar :one
+-- Points the user at a newly uploaded avatar, or at none when avatar_url is
+-- NULL. Changes the version, so copies read before the upload are stale.
+UPDATE users
+SET avatar_url = $1, updated_at = NOW(), version = version + 1
+WHERE id = $2 AND deleted_at IS NULL
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url;
+
+-- name: VerifyUserEmail :one
+-- Only applies while the user still has the email the verification was sent
+-- to, so a token mailed to an old address cannot verify a new one
 UPDATE users
-SET name = $1, email = $2, updated_at = NOW()
-WHERE id = $3
-RETURNING id, name, email, created_at, updated_at;
+SET email_verified_at = NOW(), updated_at = NOW(), version = version + 1
+WHERE id = $1 AND email = $2 AND deleted_at IS NULL AND email_verified_at IS NULL
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url;
 
--- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1;
+-- name: PurgeUser :execrows
+DELETE FROM users WHERE id = $1 AND deleted_at IS NOT NULL;
@@ -7,14 +7,35 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
+WHERE ($1::boolean IS NULL
+   OR (split_part(email, '@', 2) = ANY($2::text[])) = $1::boolean)
+  AND ($3::text IS NULL OR name ILIKE '%' || $3::text || '%')
+  AND ($4::text IS NULL OR lower(split_part(email, '@', 2)) = $4::text)
+  AND ($5::boolean OR deleted_at IS NULL)
 `
 
-func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countUsers)
+type CountUsersParams struct {
+	Corporate        pgtype.Bool `json:"corporate"`
+	CorporateDomains []string    `json:"corporate_domains"`
+	Name             pgtype.Text `json:"name"`
+	EmailDomain      pgtype.Text `json:"email_domain"`
+	IncludeDeleted   bool        `json:"include_deleted"`
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers,
+		arg.Corporate,
+		arg.CorporateDomains,
+		arg.Name,
+		arg.EmailDomain,
+		arg.IncludeDeleted,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -23,7 +44,7 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (name, email)
 VALUES ($1, $2)
-RETURNING id, name, email, created_at, updated_at
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
 `
 
 type CreateUserParams struct {
@@ -40,25 +61,105 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1
+const createUserWithPassword = `-- name: CreateUserWithPassword :one
+WITH new_user AS (
+    INSERT INTO users (name, email)
+    VALUES ($1, $2)
+    RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+), credentials AS (
+    INSERT INTO user_credentials (user_id, password_hash)
+    SELECT id, $3 FROM new_user
+)
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM new_user
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteUser, id)
-	return err
+type CreateUserWithPasswordParams struct {
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"password_hash"`
+}
+
+// Inserts the user and their credentials in one statement so a user is never
+// left without the password they registered with
+func (q *Queries) CreateUserWithPassword(ctx context.Context, arg CreateUserWithPasswordParams) (User, error) {
+	row := q.db.QueryRow(ctx, createUserWithPassword, arg.Name, arg.Email, arg.PasswordHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const deleteUser = `-- name: DeleteUser :execrows
+UPDATE users SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL
+`
+
+// Soft-deletes the user; RestoreUser undoes it and PurgeUser makes it permanent
+func (q *Queries) DeleteUser(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCredentialsByEmail = `-- name: GetCredentialsByEmail :one
+SELECT u.id, c.password_hash
+FROM users u
+JOIN user_credentials c ON c.user_id = u.id
+WHERE u.email = $1 AND u.deleted_at IS NULL
+`
+
+type GetCredentialsByEmailRow struct {
+	ID           int32  `json:"id"`
+	PasswordHash string `json:"password_hash"`
+}
+
+func (q *Queries) GetCredentialsByEmail(ctx context.Context, email string) (GetCredentialsByEmailRow, error) {
+	row := q.db.QueryRow(ctx, getCredentialsByEmail, email)
+	var i GetCredentialsByEmailRow
+	err := row.Scan(&i.ID, &i.PasswordHash)
+	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, email, created_at, updated_at
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
 FROM users
 WHERE email = $1
 `
 
+// Includes soft-deleted users: their email stays taken until they are purged
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
 	row := q.db.QueryRow(ctx, getUserByEmail, email)
 	var i User
@@ -68,14 +169,25 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Email,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, email, created_at, updated_at
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
 FROM users
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
@@ -87,24 +199,364 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 		&i.Email,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
 
-const listUsers = `-- name: ListUsers :many
-SELECT id, name, email, created_at, updated_at
+const getUserByIDIncludingDeleted = `-- name: GetUserByIDIncludingDeleted :one
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
 FROM users
+WHERE id = $1
+`
+
+// For callers that must tell a soft-deleted user apart from a missing one
+func (q *Queries) GetUserByIDIncludingDeleted(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByIDIncludingDeleted, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const getUserByPublicID = `-- name: GetUserByPublicID :one
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE public_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByPublicID(ctx context.Context, publicID pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByPublicID, publicID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const getUserStats = `-- name: GetUserStats :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE split_part(email, '@', 2) = ANY($1::text[])) AS corporate,
+    COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours') AS last_24h,
+    COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '7 days') AS last_7d,
+    COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days') AS last_30d
+FROM users
+WHERE deleted_at IS NULL
+`
+
+type GetUserStatsRow struct {
+	Total     int64 `json:"total"`
+	Corporate int64 `json:"corporate"`
+	Last24h   int64 `json:"last_24h"`
+	Last7d    int64 `json:"last_7d"`
+	Last30d   int64 `json:"last_30d"`
+}
+
+func (q *Queries) GetUserStats(ctx context.Context, corporateDomains []string) (GetUserStatsRow, error) {
+	row := q.db.QueryRow(ctx, getUserStats, corporateDomains)
+	var i GetUserStatsRow
+	err := row.Scan(
+		&i.Total,
+		&i.Corporate,
+		&i.Last24h,
+		&i.Last7d,
+		&i.Last30d,
+	)
+	return i, err
+}
+
+const getUsersByEmails = `-- name: GetUsersByEmails :many
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE email = ANY($1::text[])
 ORDER BY id
-LIMIT $1 OFFSET $2
+`
+
+// Includes soft-deleted users, like GetUserByEmail
+func (q *Queries) GetUsersByEmails(ctx context.Context, emails []string) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByEmails, emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.PublicID,
+			&i.Version,
+			&i.EmailVerifiedAt,
+			&i.TwitchHandle,
+			&i.YoutubeHandle,
+			&i.TwitterHandle,
+			&i.CountryCode,
+			&i.Pronouns,
+			&i.Bio,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE id = ANY($1::int[]) AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []int32) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.PublicID,
+			&i.Version,
+			&i.EmailVerifiedAt,
+			&i.TwitchHandle,
+			&i.YoutubeHandle,
+			&i.TwitterHandle,
+			&i.CountryCode,
+			&i.Pronouns,
+			&i.Bio,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+type ImportUsersParams struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT users.id, users.name, users.email, users.created_at, users.updated_at, users.deleted_at, users.public_id, users.version, users.email_verified_at, users.twitch_handle, users.youtube_handle, users.twitter_handle, users.country_code, users.pronouns, users.bio, users.avatar_url, COUNT(*) OVER() AS total
+FROM users
+WHERE ($1::boolean IS NULL
+   OR (split_part(email, '@', 2) = ANY($2::text[])) = $1::boolean)
+  AND ($3::text IS NULL OR name ILIKE '%' || $3::text || '%')
+  AND ($4::text IS NULL OR lower(split_part(email, '@', 2)) = $4::text)
+  AND ($5::boolean OR deleted_at IS NULL)
+ORDER BY
+    CASE WHEN $6::text = 'name' AND NOT $7::boolean THEN name END,
+    CASE WHEN $6::text = 'name' AND $7::boolean THEN name END DESC,
+    CASE WHEN $6::text = 'email' AND NOT $7::boolean THEN email END,
+    CASE WHEN $6::text = 'email' AND $7::boolean THEN email END DESC,
+    CASE WHEN $6::text = 'created_at' AND NOT $7::boolean THEN created_at END,
+    CASE WHEN $6::text = 'created_at' AND $7::boolean THEN created_at END DESC,
+    CASE WHEN $6::text = 'updated_at' AND NOT $7::boolean THEN updated_at END,
+    CASE WHEN $6::text = 'updated_at' AND $7::boolean THEN updated_at END DESC,
+    CASE WHEN $7::boolean THEN id END DESC,
+    id
+LIMIT $8 OFFSET $9
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Corporate        pgtype.Bool `json:"corporate"`
+	CorporateDomains []string    `json:"corporate_domains"`
+	Name             pgtype.Text `json:"name"`
+	EmailDomain      pgtype.Text `json:"email_domain"`
+	IncludeDeleted   bool        `json:"include_deleted"`
+	Sort             string      `json:"sort"`
+	Descending       bool        `json:"descending"`
+	Limit            int32       `json:"limit"`
+	Offset           int32       `json:"offset"`
+}
+
+type ListUsersRow struct {
+	User  User  `json:"user"`
+	Total int64 `json:"total"`
+}
+
+// Sorts by the column named by sort, one of those listed in ORDER BY; any
+// other value sorts by id. Ties are broken by id in the same direction, so
+// ListUsersAfter can seek past the last row of a page. Every row carries the
+// number of users matching the filters, so a page needs no CountUsers; a
+// page past the end has no rows to carry it.
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.Corporate,
+		arg.CorporateDomains,
+		arg.Name,
+		arg.EmailDomain,
+		arg.IncludeDeleted,
+		arg.Sort,
+		arg.Descending,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUsersRow{}
+	for rows.Next() {
+		var i ListUsersRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.Name,
+			&i.User.Email,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+			&i.User.DeletedAt,
+			&i.User.PublicID,
+			&i.User.Version,
+			&i.User.EmailVerifiedAt,
+			&i.User.TwitchHandle,
+			&i.User.YoutubeHandle,
+			&i.User.TwitterHandle,
+			&i.User.CountryCode,
+			&i.User.Pronouns,
+			&i.User.Bio,
+			&i.User.AvatarUrl,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersAfter = `-- name: ListUsersAfter :many
+SELECT id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+FROM users
+WHERE ($1::boolean IS NULL
+   OR (split_part(email, '@', 2) = ANY($2::text[])) = $1::boolean)
+  AND ($3::text IS NULL OR name ILIKE '%' || $3::text || '%')
+  AND ($4::text IS NULL OR lower(split_part(email, '@', 2)) = $4::text)
+  AND ($5::boolean OR deleted_at IS NULL)
+  AND CASE $6::text
+        WHEN 'name' THEN CASE WHEN $7::boolean THEN (name, id) < ($8::text, $9::int)
+             ELSE (name, id) > ($8::text, $9::int) END
+        WHEN 'email' THEN CASE WHEN $7::boolean THEN (email, id) < ($8::text, $9::int)
+             ELSE (email, id) > ($8::text, $9::int) END
+        WHEN 'created_at' THEN CASE WHEN $7::boolean THEN (created_at, id) < ($10::timestamptz, $9::int)
+             ELSE (created_at, id) > ($10::timestamptz, $9::int) END
+        WHEN 'updated_at' THEN CASE WHEN $7::boolean THEN (updated_at, id) < ($10::timestamptz, $9::int)
+             ELSE (updated_at, id) > ($10::timestamptz, $9::int) END
+        ELSE CASE WHEN $7::boolean THEN id < $9::int ELSE id > $9::int END
+      END
+ORDER BY
+    CASE WHEN $6::text = 'name' AND NOT $7::boolean THEN name END,
+    CASE WHEN $6::text = 'name' AND $7::boolean THEN name END DESC,
+    CASE WHEN $6::text = 'email' AND NOT $7::boolean THEN email END,
+    CASE WHEN $6::text = 'email' AND $7::boolean THEN email END DESC,
+    CASE WHEN $6::text = 'created_at' AND NOT $7::boolean THEN created_at END,
+    CASE WHEN $6::text = 'created_at' AND $7::boolean THEN created_at END DESC,
+    CASE WHEN $6::text = 'updated_at' AND NOT $7::boolean THEN updated_at END,
+    CASE WHEN $6::text = 'updated_at' AND $7::boolean THEN updated_at END DESC,
+    CASE WHEN $7::boolean THEN id END DESC,
+    id
+LIMIT $11
+`
+
+type ListUsersAfterParams struct {
+	Corporate        pgtype.Bool        `json:"corporate"`
+	CorporateDomains []string           `json:"corporate_domains"`
+	Name             pgtype.Text        `json:"name"`
+	EmailDomain      pgtype.Text        `json:"email_domain"`
+	IncludeDeleted   bool               `json:"include_deleted"`
+	Sort             string             `json:"sort"`
+	Descending       bool               `json:"descending"`
+	AfterText        string             `json:"after_text"`
+	AfterID          int32              `json:"after_id"`
+	AfterTime        pgtype.Timestamptz `json:"after_time"`
+	Limit            int32              `json:"limit"`
 }
 
-func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
+// Keyset page of ListUsers continuing after the user with after_id, whose
+// sort column holds after_text (name, email) or after_time (created_at,
+// updated_at)
+func (q *Queries) ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersAfter,
+		arg.Corporate,
+		arg.CorporateDomains,
+		arg.Name,
+		arg.EmailDomain,
+		arg.IncludeDeleted,
+		arg.Sort,
+		arg.Descending,
+		arg.AfterText,
+		arg.AfterID,
+		arg.AfterTime,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -118,6 +570,17 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.Email,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.PublicID,
+			&i.Version,
+			&i.EmailVerifiedAt,
+			&i.TwitchHandle,
+			&i.YoutubeHandle,
+			&i.TwitterHandle,
+			&i.CountryCode,
+			&i.Pronouns,
+			&i.Bio,
+			&i.AvatarUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -129,21 +592,165 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
+const purgeUser = `-- name: PurgeUser :execrows
+DELETE FROM users WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeUser(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreUser = `-- name: RestoreUser :one
+UPDATE users
+SET deleted_at = NULL, updated_at = NOW(), version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+`
+
+func (q *Queries) RestoreUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRow(ctx, restoreUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const setUserAvatar = `-- name: SetUserAvatar :one
+UPDATE users
+SET avatar_url = $1, updated_at = NOW(), version = version + 1
+WHERE id = $2 AND deleted_at IS NULL
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+`
+
+type SetUserAvatarParams struct {
+	AvatarUrl pgtype.Text `json:"avatar_url"`
+	ID        int32       `json:"id"`
+}
+
+// Points the user at a newly uploaded avatar, or at none when avatar_url is
+// NULL. Changes the version, so copies read before the upload are stale.
+func (q *Queries) SetUserAvatar(ctx context.Context, arg SetUserAvatarParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserAvatar, arg.AvatarUrl, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET name = $1, email = $2, updated_at = NOW()
-WHERE id = $3
-RETURNING id, name, email, created_at, updated_at
+SET name = $1, email = $2, updated_at = NOW(), version = version + 1,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
+    twitch_handle = $5, youtube_handle = $6, twitter_handle = $7,
+    country_code = $8, pronouns = $9, bio = $10
+WHERE id = $3 AND deleted_at IS NULL AND version = $4
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
 `
 
 type UpdateUserParams struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	ID    int32  `json:"id"`
+	Name          string      `json:"name"`
+	Email         string      `json:"email"`
+	ID            int32       `json:"id"`
+	Version       int32       `json:"version"`
+	TwitchHandle  pgtype.Text `json:"twitch_handle"`
+	YoutubeHandle pgtype.Text `json:"youtube_handle"`
+	TwitterHandle pgtype.Text `json:"twitter_handle"`
+	CountryCode   pgtype.Text `json:"country_code"`
+	Pronouns      pgtype.Text `json:"pronouns"`
+	Bio           pgtype.Text `json:"bio"`
 }
 
+// Only applies if the user is still at the version the caller read, so
+// concurrent edits can't overwrite each other. Changing the email clears its
+// verification. Every profile field is written, so callers pass the current
+// value of those they are not changing.
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateUser, arg.Name, arg.Email, arg.ID)
+	row := q.db.QueryRow(ctx, updateUser,
+		arg.Name,
+		arg.Email,
+		arg.ID,
+		arg.Version,
+		arg.TwitchHandle,
+		arg.YoutubeHandle,
+		arg.TwitterHandle,
+		arg.CountryCode,
+		arg.Pronouns,
+		arg.Bio,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :one
+UPDATE users
+SET email_verified_at = NOW(), updated_at = NOW(), version = version + 1
+WHERE id = $1 AND email = $2 AND deleted_at IS NULL AND email_verified_at IS NULL
+RETURNING id, name, email, created_at, updated_at, deleted_at, public_id, version, email_verified_at, twitch_handle, youtube_handle, twitter_handle, country_code, pronouns, bio, avatar_url
+`
+
+type VerifyUserEmailParams struct {
+	ID    int32  `json:"id"`
+	Email string `json:"email"`
+}
+
+// Only applies while the user still has the email the verification was sent
+// to, so a token mailed to an old address cannot verify a new one
+func (q *Queries) VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error) {
+	row := q.db.QueryRow(ctx, verifyUserEmail, arg.ID, arg.Email)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -151,6 +758,17 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PublicID,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.TwitchHandle,
+		&i.YoutubeHandle,
+		&i.TwitterHandle,
+		&i.CountryCode,
+		&i.Pronouns,
+		&i.Bio,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -0,0 +1,76 @@
+-- name: CreateRace :one
+INSERT INTO races (category_id, created_by)
+VALUES ($1, $2)
+RETURNING id, category_id, created_by, status, starts_at, finished_at, created_at;
+
+-- name: GetRaceByID :one
+SELECT id, category_id, created_by, status, starts_at, finished_at, created_at
+FROM races
+WHERE id = $1;
+
+-- name: GetRaceByIDForUpdate :one
+-- Locks the race until the transaction ends, so changes to it and its
+-- participants are made one at a time
+SELECT id, category_id, created_by, status, starts_at, finished_at, created_at
+FROM races
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListActiveRacesByCategory :many
+-- The category's open and running races, oldest first
+SELECT id, category_id, created_by, status, starts_at, finished_at, created_at
+FROM races
+WHERE category_id = $1 AND status IN ('open', 'running')
+ORDER BY created_at, id;
+
+-- name: StartRace :one
+UPDATE races
+SET status = 'running', starts_at = $2
+WHERE id = $1
+RETURNING id, category_id, created_by, status, starts_at, finished_at, created_at;
+
+-- name: EndRace :one
+-- Sets status to finished or cancelled
+UPDATE races
+SET status = $2, finished_at = NOW()
+WHERE id = $1
+RETURNING id, category_id, created_by, status, starts_at, finished_at, created_at;
+
+-- name: AddRaceParticipant :execrows
+-- Affects no row when the user is already in the race
+INSERT INTO race_participants (race_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveRaceParticipant :execrows
+DELETE FROM race_participants
+WHERE race_id = $1 AND user_id = $2;
+
+-- name: GetRaceParticipant :one
+SELECT race_id, user_id, ready, joined_at, finished_at, time_ms, run_id, forfeited_at
+FROM race_participants
+WHERE race_id = $1 AND user_id = $2;
+
+-- name: SetRaceParticipantReady :execrows
+UPDATE race_participants
+SET ready = $3
+WHERE race_id = $1 AND user_id = $2;
+
+-- name: FinishRaceParticipant :execrows
+UPDATE race_participants
+SET finished_at = $3, time_ms = $4, run_id = $5
+WHERE race_id = $1 AND user_id = $2;
+
+-- name: ForfeitRaceParticipant :execrows
+UPDATE race_participants
+SET forfeited_at = NOW()
+WHERE race_id = $1 AND user_id = $2;
+
+-- name: ListRaceParticipants :many
+-- Finishers fastest first, then everyone else in the order they joined
+SELECT p.race_id, p.user_id, u.name AS user_name, p.ready, p.joined_at,
+       p.finished_at, p.time_ms, p.run_id, p.forfeited_at
+FROM race_participants p
+JOIN users u ON u.id = p.user_id
+WHERE p.race_id = $1
+ORDER BY p.time_ms ASC NULLS LAST, p.joined_at, p.user_id;
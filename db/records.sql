@@ -0,0 +1,15 @@
+-- name: CreateCategoryRecord :exec
+-- Records that the run took its category's record
+INSERT INTO category_records (category_id, run_id)
+VALUES ($1, $2)
+ON CONFLICT (run_id) DO NOTHING;
+
+-- name: ListCategoryRecords :many
+-- The category's record progression, oldest first; records set by users who
+-- have since been deleted are left out
+SELECT cr.run_id, r.user_id, u.name AS user_name, r.time_ms, r.video_url, r.platform, r.played_on, cr.set_at
+FROM category_records cr
+JOIN runs r ON r.id = cr.run_id
+JOIN users u ON u.id = r.user_id
+WHERE cr.category_id = $1 AND u.deleted_at IS NULL
+ORDER BY cr.set_at, cr.id;
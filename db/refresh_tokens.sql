@@ -0,0 +1,29 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, token_hash, family_id, expires_at, created_at, revoked_at;
+
+-- name: GetRefreshTokenByHash :one
+SELECT id, user_id, token_hash, family_id, expires_at, created_at, revoked_at
+FROM refresh_tokens
+WHERE token_hash = $1;
+
+-- name: RevokeRefreshToken :execrows
+-- Affects no rows when the token was already revoked, which tells a caller
+-- that a concurrent request used it first
+UPDATE refresh_tokens
+SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = NOW()
+WHERE family_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :execrows
+UPDATE refresh_tokens
+SET revoked_at = NOW()
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: DeleteExpiredRefreshTokens :execrows
+DELETE FROM refresh_tokens WHERE expires_at < $1;
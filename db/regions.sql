@@ -0,0 +1,24 @@
+-- name: ListRegions :many
+SELECT id, slug, name, created_at
+FROM regions
+ORDER BY name, id;
+
+-- name: GetRegionBySlug :one
+SELECT id, slug, name, created_at
+FROM regions
+WHERE slug = $1;
+
+-- name: CreateRegion :one
+INSERT INTO regions (slug, name)
+VALUES ($1, $2)
+RETURNING id, slug, name, created_at;
+
+-- name: UpdateRegion :one
+-- Only the name can change; runs refer to the region by its slug
+UPDATE regions
+SET name = @name
+WHERE slug = @slug
+RETURNING id, slug, name, created_at;
+
+-- name: DeleteRegion :execrows
+DELETE FROM regions WHERE slug = $1;
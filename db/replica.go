@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// primaryKey marks a context whose queries must see the primary's latest
+// writes
+type primaryKey struct{}
+
+// WithPrimary returns a context whose queries all run on the primary, for
+// reads that must see writes made moments before, which a replica may not
+// have applied yet
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsesPrimary reports whether WithPrimary was applied to ctx
+func UsesPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// NewReplicatedStore creates a Store that runs read-only queries on replica
+// and everything else, including every transaction, on primary
+//
+// Queries made with a context from WithPrimary always run on primary.
+func NewReplicatedStore(primary TxBeginner, replica DBTX) Store {
+	return &pgxStore{
+		Queries: New(&replicatedDBTX{primary: primary, replica: replica}),
+		db:      primary,
+	}
+}
+
+// replicatedDBTX routes each statement to the primary or the replica
+type replicatedDBTX struct {
+	primary DBTX
+	replica DBTX
+}
+
+func (r *replicatedDBTX) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return r.primary.Exec(ctx, sql, args...)
+}
+
+func (r *replicatedDBTX) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return r.route(ctx, sql).Query(ctx, sql, args...)
+}
+
+func (r *replicatedDBTX) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return r.route(ctx, sql).QueryRow(ctx, sql, args...)
+}
+
+// CopyFrom always runs on the primary, since a copy writes rows
+func (r *replicatedDBTX) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return r.primary.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// route picks the database sql runs on
+func (r *replicatedDBTX) route(ctx context.Context, sql string) DBTX {
+	if UsesPrimary(ctx) || !readOnly(sql) {
+		return r.primary
+	}
+	return r.replica
+}
+
+// readOnly reports whether sql only reads: it is a SELECT, or a WITH query
+// whose parts are all SELECTs, and takes no row locks. Anything it cannot
+// tell is treated as a write.
+func readOnly(sql string) bool {
+	words := strings.FieldsFunc(strings.ToUpper(stripComments(sql)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	if len(words) == 0 || (words[0] != "SELECT" && words[0] != "WITH") {
+		return false
+	}
+	for _, word := range words {
+		switch word {
+		case "INSERT", "UPDATE", "DELETE", "MERGE", "FOR", "INTO":
+			return false
+		}
+	}
+	return true
+}
+
+// stripComments removes "--" line comments, such as sqlc's
+// "-- name: GetUserByID :one" header, from sql
+func stripComments(sql string) string {
+	lines := strings.Split(sql, "\n")
+	for i, line := range lines {
+		if before, _, ok := strings.Cut(line, "--"); ok {
+			lines[i] = before
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// recordingDBTX records the statements sent to it and returns no rows
+type recordingDBTX struct {
+	statements []string
+}
+
+func (d *recordingDBTX) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	d.statements = append(d.statements, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (d *recordingDBTX) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	d.statements = append(d.statements, sql)
+	return nil, pgx.ErrNoRows
+}
+
+func (d *recordingDBTX) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	d.statements = append(d.statements, sql)
+	return noRow{}
+}
+
+func (d *recordingDBTX) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	d.statements = append(d.statements, "COPY "+tableName.Sanitize())
+	return 0, nil
+}
+
+type noRow struct{}
+
+func (noRow) Scan(dest ...any) error { return pgx.ErrNoRows }
+
+func TestReadOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want bool
+	}{
+		{"select", getUserByID, true},
+		{"select with window", listUsers, true},
+		{"with select", getLeaderboard, true},
+		{"insert", createRun, false},
+		{"insert from with", createUserWithPassword, false},
+		{"update", updateUser, false},
+		{"update with locking subquery", claimOutboxEvents, false},
+		{"delete", deleteWebhook, false},
+		{"row lock", "SELECT id FROM runs WHERE id = $1 FOR UPDATE", false},
+		{"comment only", "-- SELECT 1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readOnly(tt.sql); got != tt.want {
+				t.Errorf("expected readOnly to be %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReplicatedStore_Routes(t *testing.T) {
+	primary, replica := &recordingDBTX{}, &recordingDBTX{}
+	store := NewReplicatedStore(&primaryDBTX{primary}, replica)
+	ctx := context.Background()
+
+	store.GetUserByID(ctx, 1)
+	store.TouchAPIKey(ctx, 1)
+	store.GetUserByID(WithPrimary(ctx), 1)
+	store.ImportUsers(ctx, []ImportUsersParams{{Name: "Ada", Email: "ada@example.com"}})
+
+	if len(replica.statements) != 1 || replica.statements[0] != getUserByID {
+		t.Errorf("expected only the first read on the replica, got %q", replica.statements)
+	}
+	if len(primary.statements) != 3 || primary.statements[0] != touchAPIKey || primary.statements[1] != getUserByID || primary.statements[2] != `COPY "users"` {
+		t.Errorf("expected the writes and the read with WithPrimary on the primary, got %q", primary.statements)
+	}
+}
+
+// primaryDBTX is a TxBeginner that cannot start transactions
+type primaryDBTX struct {
+	*recordingDBTX
+}
+
+func (d *primaryDBTX) Begin(ctx context.Context) (pgx.Tx, error) {
+	return nil, pgx.ErrTxClosed
+}
@@ -0,0 +1,12 @@
+-- name: GrantUserRole :one
+-- Returns no row when the user already has the role
+INSERT INTO user_roles (user_id, role, game_id)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+RETURNING id, user_id, role, game_id, created_at;
+
+-- name: ListUserRoles :many
+SELECT id, user_id, role, game_id, created_at
+FROM user_roles
+WHERE user_id = $1
+ORDER BY id;
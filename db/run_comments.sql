@@ -0,0 +1,47 @@
+-- name: CreateRunComment :one
+INSERT INTO run_comments (run_id, user_id, body)
+VALUES ($1, $2, $3)
+RETURNING id, run_id, user_id, body, created_at, updated_at, deleted_at, deleted_by;
+
+-- name: GetRunCommentByID :one
+SELECT id, run_id, user_id, body, created_at, updated_at, deleted_at, deleted_by
+FROM run_comments
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListRunComments :many
+-- Comments of a run that have not been deleted, oldest first
+SELECT id, run_id, user_id, body, created_at, updated_at, deleted_at, deleted_by
+FROM run_comments
+WHERE run_id = $1 AND deleted_at IS NULL
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: ListRunCommentsAfter :many
+-- Keyset page of ListRunComments continuing after the comment with after_id
+SELECT id, run_id, user_id, body, created_at, updated_at, deleted_at, deleted_by
+FROM run_comments
+WHERE run_id = @run_id AND deleted_at IS NULL AND id > @after_id
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: CountRunComments :one
+SELECT COUNT(*) FROM run_comments
+WHERE run_id = $1 AND deleted_at IS NULL;
+
+-- name: CountRecentRunCommentsByUser :one
+-- Deleted comments still count, so removing spam does not make room for more
+SELECT COUNT(*) FROM run_comments
+WHERE user_id = $1 AND created_at >= @since::timestamptz;
+
+-- name: UpdateRunComment :one
+UPDATE run_comments
+SET body = $1, updated_at = NOW()
+WHERE id = $2 AND deleted_at IS NULL
+RETURNING id, run_id, user_id, body, created_at, updated_at, deleted_at, deleted_by;
+
+-- name: DeleteRunComment :one
+-- Returns no row when the comment was already deleted
+UPDATE run_comments
+SET deleted_at = NOW(), deleted_by = $1
+WHERE id = $2 AND deleted_at IS NULL
+RETURNING id, run_id, user_id, body, created_at, updated_at, deleted_at, deleted_by;
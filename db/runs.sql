@@ -0,0 +1,212 @@
+-- name: CreateRun :one
+INSERT INTO runs (user_id, category_id, time_ms, video_url, platform, played_on, level_id, region, rta_ms, igt_ms, lrt_ms, race_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id;
+
+-- name: GetFastestVerifiedRun :one
+-- The category's full-game record, ignoring the run with exclude_id; ties
+-- are broken the same way as on the leaderboard
+SELECT id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id
+FROM runs
+WHERE category_id = @category_id AND level_id IS NULL AND status = 'verified' AND id <> @exclude_id
+ORDER BY time_ms, played_on, id
+LIMIT 1;
+
+-- name: GetRunByID :one
+SELECT id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id
+FROM runs
+WHERE id = $1;
+
+-- name: GetRunSummary :one
+-- The names a notification about the run refers to; no row is returned once
+-- the runner's account is deleted
+SELECT u.name AS user_name, u.email, g.name AS game_name, c.name AS category_name
+FROM runs r
+JOIN users u ON u.id = r.user_id
+JOIN categories c ON c.id = r.category_id
+JOIN games g ON g.id = c.game_id
+WHERE r.id = $1 AND u.deleted_at IS NULL;
+
+-- name: UpdateRunStatus :one
+-- Only moves the run if it is still in from_status, so concurrent reviews
+-- cannot both succeed
+UPDATE runs
+SET status = @status, rejection_reason = sqlc.narg(rejection_reason), reviewed_at = NOW()
+WHERE id = @id AND status = @from_status
+RETURNING id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id;
+
+-- name: ObsoleteBeatenRuns :many
+-- Marks the runner's verified runs in the run's category and level with the
+-- same variable values obsolete, except their best, which is picked the same
+-- way as on the leaderboard
+WITH combination AS (
+    SELECT user_id, category_id, level_id,
+           ARRAY(SELECT value_id FROM run_variable_values WHERE run_id = runs.id ORDER BY value_id) AS value_ids
+    FROM runs
+    WHERE id = @run_id
+), candidates AS (
+    SELECT r.id, r.time_ms, r.played_on
+    FROM runs r, combination c
+    WHERE r.user_id = c.user_id AND r.category_id = c.category_id AND r.status = 'verified'
+      AND r.level_id IS NOT DISTINCT FROM c.level_id
+      AND ARRAY(SELECT value_id FROM run_variable_values WHERE run_id = r.id ORDER BY value_id) = c.value_ids
+)
+UPDATE runs
+SET obsolete = TRUE
+WHERE id IN (SELECT id FROM candidates) AND NOT obsolete
+  AND id <> (SELECT id FROM candidates ORDER BY time_ms, played_on, id LIMIT 1)
+RETURNING id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id;
+
+-- name: ListModerationQueue :many
+-- Pending runs of every category of a game, longest waiting first
+SELECT r.id, r.user_id, r.category_id, r.time_ms, r.video_url, r.platform, r.played_on, r.created_at, r.status, r.rejection_reason, r.reviewed_at, r.obsolete, r.level_id, r.region, r.rta_ms, r.igt_ms, r.lrt_ms, r.race_id
+FROM runs r
+JOIN categories c ON c.id = r.category_id
+WHERE c.game_id = @game_id AND r.status = 'pending'
+ORDER BY r.created_at, r.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListModerationQueueAfter :many
+-- Keyset page of ListModerationQueue continuing after the given run
+SELECT r.id, r.user_id, r.category_id, r.time_ms, r.video_url, r.platform, r.played_on, r.created_at, r.status, r.rejection_reason, r.reviewed_at, r.obsolete, r.level_id, r.region, r.rta_ms, r.igt_ms, r.lrt_ms, r.race_id
+FROM runs r
+JOIN categories c ON c.id = r.category_id
+WHERE c.game_id = @game_id AND r.status = 'pending'
+  AND (r.created_at, r.id) > (@after_created_at::timestamptz, @after_id::int)
+ORDER BY r.created_at, r.id
+LIMIT sqlc.arg('limit');
+
+-- name: CountModerationQueue :one
+SELECT COUNT(*) FROM runs r
+JOIN categories c ON c.id = r.category_id
+WHERE c.game_id = @game_id AND r.status = 'pending';
+
+-- name: ListRunsByCategory :many
+-- Obsolete runs are left out unless include_obsolete is set
+SELECT id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id
+FROM runs
+WHERE category_id = @category_id AND (@include_obsolete::bool OR NOT obsolete)
+ORDER BY time_ms, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListRunsByCategoryAfter :many
+-- Keyset page of ListRunsByCategory continuing after the given run
+SELECT id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id
+FROM runs
+WHERE category_id = @category_id AND (@include_obsolete::bool OR NOT obsolete)
+  AND (time_ms, id) > (@after_time_ms::bigint, @after_id::int)
+ORDER BY time_ms, id
+LIMIT sqlc.arg('limit');
+
+-- name: CountRunsByCategory :one
+SELECT COUNT(*) FROM runs
+WHERE category_id = @category_id AND (@include_obsolete::bool OR NOT obsolete);
+
+-- name: ListRunsByUser :many
+-- Obsolete runs are left out unless include_obsolete is set
+SELECT id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id
+FROM runs
+WHERE user_id = @user_id AND (@include_obsolete::bool OR NOT obsolete)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListRunsByUserAfter :many
+-- Keyset page of ListRunsByUser continuing after the given run, i.e. with
+-- runs submitted before it
+SELECT id, user_id, category_id, time_ms, video_url, platform, played_on, created_at, status, rejection_reason, reviewed_at, obsolete, level_id, region, rta_ms, igt_ms, lrt_ms, race_id
+FROM runs
+WHERE user_id = @user_id AND (@include_obsolete::bool OR NOT obsolete)
+  AND (created_at, id) < (@after_created_at::timestamptz, @after_id::int)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountRunsByUser :one
+SELECT COUNT(*) FROM runs
+WHERE user_id = @user_id AND (@include_obsolete::bool OR NOT obsolete);
+
+-- name: GetLeaderboard :many
+-- Ranks each runner's best run; equal times share a rank (1, 1, 3) and are
+-- listed by who played them first. Only runs of the given level (none for
+-- full-game runs) with all of the given variable values are ranked; an
+-- empty list of values ranks every run. Runs are limited to the given
+-- platform and region unless they are null.
+WITH best AS (
+    SELECT DISTINCT ON (user_id) id, user_id, time_ms, video_url, platform, played_on, rta_ms, igt_ms, lrt_ms
+    FROM runs
+    WHERE category_id = @category_id AND level_id IS NOT DISTINCT FROM sqlc.narg(level_id)::int AND status = 'verified'
+      AND (sqlc.narg(platform)::text IS NULL OR platform = sqlc.narg(platform))
+      AND (sqlc.narg(region)::text IS NULL OR region = sqlc.narg(region))
+      AND (SELECT COUNT(*) FROM run_variable_values rv
+           WHERE rv.run_id = runs.id AND rv.value_id = ANY(@value_ids::int[])) = cardinality(@value_ids::int[])
+    ORDER BY user_id, time_ms, played_on, id
+)
+SELECT RANK() OVER (ORDER BY best.time_ms)::int AS rank,
+       best.id, best.user_id, u.name AS user_name, best.time_ms, best.video_url, best.platform, best.played_on,
+       best.rta_ms, best.igt_ms, best.lrt_ms
+FROM best
+JOIN users u ON u.id = best.user_id
+WHERE u.deleted_at IS NULL
+ORDER BY rank, best.played_on, best.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetLeaderboardAfter :many
+-- Keyset page of GetLeaderboard continuing after the given entry; ranks are
+-- still computed over every runner, so they match the offset pages
+WITH best AS (
+    SELECT DISTINCT ON (user_id) id, user_id, time_ms, video_url, platform, played_on, rta_ms, igt_ms, lrt_ms
+    FROM runs
+    WHERE category_id = @category_id AND level_id IS NOT DISTINCT FROM sqlc.narg(level_id)::int AND status = 'verified'
+      AND (sqlc.narg(platform)::text IS NULL OR platform = sqlc.narg(platform))
+      AND (sqlc.narg(region)::text IS NULL OR region = sqlc.narg(region))
+      AND (SELECT COUNT(*) FROM run_variable_values rv
+           WHERE rv.run_id = runs.id AND rv.value_id = ANY(@value_ids::int[])) = cardinality(@value_ids::int[])
+    ORDER BY user_id, time_ms, played_on, id
+), ranked AS (
+    SELECT RANK() OVER (ORDER BY best.time_ms)::int AS rank,
+           best.id, best.user_id, u.name AS user_name, best.time_ms, best.video_url, best.platform, best.played_on,
+           best.rta_ms, best.igt_ms, best.lrt_ms
+    FROM best
+    JOIN users u ON u.id = best.user_id
+    WHERE u.deleted_at IS NULL
+)
+SELECT rank, id, user_id, user_name, time_ms, video_url, platform, played_on, rta_ms, igt_ms, lrt_ms
+FROM ranked
+WHERE (time_ms, played_on, id) > (@after_time_ms::bigint, @after_played_on::date, @after_id::int)
+ORDER BY time_ms, played_on, id
+LIMIT sqlc.arg('limit');
+
+-- name: GetPersonalBests :many
+-- The user's best full-game verified run in each category they have one
+-- in, ranked the same way as on that category's leaderboard, with the
+-- category's record
+WITH best AS (
+    SELECT DISTINCT ON (r.category_id, r.user_id) r.id, r.user_id, r.category_id, r.time_ms, r.video_url, r.platform, r.played_on
+    FROM runs r
+    JOIN users u ON u.id = r.user_id
+    WHERE r.status = 'verified' AND r.level_id IS NULL AND u.deleted_at IS NULL
+      AND r.category_id IN (SELECT category_id FROM runs WHERE user_id = $1 AND status = 'verified' AND level_id IS NULL)
+    ORDER BY r.category_id, r.user_id, r.time_ms, r.played_on, r.id
+), ranked AS (
+    SELECT best.id, best.user_id, best.category_id, best.time_ms, best.video_url, best.platform, best.played_on,
+           RANK() OVER (PARTITION BY best.category_id ORDER BY best.time_ms)::int AS rank,
+           MIN(best.time_ms) OVER (PARTITION BY best.category_id)::bigint AS record_time_ms
+    FROM best
+)
+SELECT ranked.rank, ranked.id, g.id AS game_id, g.slug AS game_slug, g.name AS game_name,
+       ranked.category_id, c.slug AS category_slug, c.name AS category_name,
+       ranked.time_ms, ranked.record_time_ms, (ranked.time_ms - ranked.record_time_ms)::bigint AS delta_ms,
+       ranked.video_url, ranked.platform, ranked.played_on
+FROM ranked
+JOIN categories c ON c.id = ranked.category_id
+JOIN games g ON g.id = c.game_id
+WHERE ranked.user_id = $1
+ORDER BY g.name, g.id, c.position, c.id;
+
+-- name: CountLeaderboard :one
+SELECT COUNT(DISTINCT r.user_id) FROM runs r
+JOIN users u ON u.id = r.user_id
+WHERE r.category_id = @category_id AND r.level_id IS NOT DISTINCT FROM sqlc.narg(level_id)::int AND r.status = 'verified' AND u.deleted_at IS NULL
+  AND (sqlc.narg(platform)::text IS NULL OR r.platform = sqlc.narg(platform))
+  AND (sqlc.narg(region)::text IS NULL OR r.region = sqlc.narg(region))
+  AND (SELECT COUNT(*) FROM run_variable_values rv
+       WHERE rv.run_id = r.id AND rv.value_id = ANY(@value_ids::int[])) = cardinality(@value_ids::int[]);
@@ -1,14 +0,0 @@
--- User management table
-CREATE TABLE users (
-    id SERIAL PRIMARY KEY,
-    name VARCHAR(255) NOT NULL,
-    email VARCHAR(255) UNIQUE NOT NULL,
-    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
-);
-
--- Index for email lookups
-CREATE INDEX idx_users_email ON users(email);
-
--- Index for pagination
-CREATE INDEX idx_users_id ON users(id);
@@ -0,0 +1,38 @@
+-- name: SearchUsers :many
+-- Users whose name matches a web search query, best match first. The
+-- expressions match idx_users_name_search.
+SELECT id, name, ts_rank(to_tsvector('simple', name), websearch_to_tsquery('simple', @query))::real AS rank
+FROM users
+WHERE deleted_at IS NULL AND to_tsvector('simple', name) @@ websearch_to_tsquery('simple', @query)
+ORDER BY rank DESC, id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchGames :many
+-- Games whose name or slug matches a web search query, best match first. The
+-- expressions match idx_games_search.
+SELECT id, slug, name,
+    ts_rank(setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', replace(slug, '-', ' ')), 'B'), websearch_to_tsquery('simple', @query))::real AS rank
+FROM games
+WHERE (setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', replace(slug, '-', ' ')), 'B')) @@ websearch_to_tsquery('simple', @query)
+ORDER BY rank DESC, id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchRuns :many
+-- Runs with a comment matching a web search query, best match first, each
+-- with an excerpt of its best matching comment. The expressions match
+-- idx_run_comments_body_search.
+SELECT best.run_id, g.slug AS game_slug, g.name AS game_name, c.slug AS category_slug, c.name AS category_name,
+    ts_headline('english', best.body, websearch_to_tsquery('english', @query), 'MaxFragments=1, MinWords=5, MaxWords=20, StartSel=**, StopSel=**')::text AS snippet,
+    best.rank
+FROM (
+    SELECT DISTINCT ON (rc.run_id) rc.run_id, rc.body,
+        ts_rank(to_tsvector('english', rc.body), websearch_to_tsquery('english', @query))::real AS rank
+    FROM run_comments rc
+    WHERE rc.deleted_at IS NULL AND to_tsvector('english', rc.body) @@ websearch_to_tsquery('english', @query)
+    ORDER BY rc.run_id, rank DESC, rc.id
+) best
+JOIN runs r ON r.id = best.run_id
+JOIN categories c ON c.id = r.category_id
+JOIN games g ON g.id = c.game_id
+ORDER BY best.rank DESC, best.run_id
+LIMIT sqlc.arg('limit');
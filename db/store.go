@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Store is a Querier that can also run several queries atomically and
+// stream exports
+type Store interface {
+	Querier
+	Exporter
+
+	// WithTx runs fn in a transaction, committing it if fn returns nil and
+	// rolling it back otherwise. Queries made through the Querier passed to
+	// fn are part of the transaction; queries made through the Store are not.
+	WithTx(ctx context.Context, fn func(q Querier) error) error
+}
+
+// TxBeginner is a database handle that can start transactions, such as a
+// *pgxpool.Pool
+type TxBeginner interface {
+	DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// pgxStore is a Store backed by a pgx connection pool
+type pgxStore struct {
+	*Queries
+	db TxBeginner
+}
+
+// NewStore creates a Store that runs queries and transactions on db
+func NewStore(db TxBeginner) Store {
+	return &pgxStore{Queries: New(db), db: db}
+}
+
+func (s *pgxStore) WithTx(ctx context.Context, fn func(q Querier) error) error {
+	return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		return fn(s.Queries.WithTx(tx))
+	})
+}
@@ -0,0 +1,4 @@
+-- name: NotifyListeners :exec
+-- Sends payload to every connection listening on channel; it is dropped if
+-- none are
+SELECT pg_notify(@channel::text, @payload::text);
@@ -0,0 +1,35 @@
+-- name: ListVariablesByGame :many
+SELECT id, game_id, category_id, slug, name, created_at
+FROM variables
+WHERE game_id = $1
+ORDER BY id;
+
+-- name: ListVariableValuesByGame :many
+SELECT vv.id, vv.variable_id, vv.slug, vv.label, vv.position
+FROM variable_values vv
+JOIN variables v ON v.id = vv.variable_id
+WHERE v.game_id = $1
+ORDER BY vv.variable_id, vv.position, vv.id;
+
+-- name: CreateVariable :one
+INSERT INTO variables (game_id, category_id, slug, name)
+VALUES (@game_id, sqlc.narg(category_id), @slug, @name)
+RETURNING id, game_id, category_id, slug, name, created_at;
+
+-- name: CreateVariableValue :one
+INSERT INTO variable_values (variable_id, slug, label, position)
+VALUES ($1, $2, $3, $4)
+RETURNING id, variable_id, slug, label, position;
+
+-- name: CreateRunVariableValue :exec
+INSERT INTO run_variable_values (run_id, variable_id, value_id)
+VALUES ($1, $2, $3);
+
+-- name: ListRunVariableValues :many
+-- The variable values of each of the runs, by slug
+SELECT rv.run_id, v.slug AS variable_slug, vv.slug AS value_slug
+FROM run_variable_values rv
+JOIN variables v ON v.id = rv.variable_id
+JOIN variable_values vv ON vv.id = rv.value_id
+WHERE rv.run_id = ANY(@run_ids::int[])
+ORDER BY rv.run_id, v.id;
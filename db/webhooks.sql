@@ -0,0 +1,78 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (url, secret, events, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING id, url, secret, events, created_by, created_at;
+
+-- name: GetWebhookByID :one
+SELECT id, url, secret, events, created_by, created_at
+FROM webhooks
+WHERE id = $1;
+
+-- name: ListWebhooks :many
+SELECT id, url, secret, events, created_by, created_at
+FROM webhooks
+ORDER BY id;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE id = $1;
+
+-- name: EnqueueWebhookDeliveries :exec
+-- Queues a delivery of the event to every webhook subscribed to it, skipping
+-- webhooks it was already queued for
+INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload)
+SELECT id, @event_id::integer, @event::text, @payload::jsonb
+FROM webhooks
+WHERE @event::text = ANY(events)
+ON CONFLICT (webhook_id, event_id) DO NOTHING;
+
+-- name: ClaimWebhookDeliveries :many
+-- Leases up to batch_size due deliveries to the caller by counting the attempt
+-- and pushing the next one to lease_until, so other dispatchers skip them
+-- while they are being sent and retry them if the caller dies
+UPDATE webhook_deliveries d
+SET attempts = d.attempts + 1, next_attempt_at = @lease_until, updated_at = NOW()
+FROM webhooks w
+WHERE w.id = d.webhook_id
+  AND d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.event, d.payload, d.attempts, w.url, w.secret;
+
+-- name: UpdateWebhookDelivery :exec
+-- Records the outcome of an attempt; a delivery left pending is attempted
+-- again at next_attempt_at
+UPDATE webhook_deliveries
+SET status = $2, next_attempt_at = $3, response_status = $4, last_error = $5, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+-- Newest first
+SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, last_error, created_at, updated_at, event_id
+FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListWebhookDeliveriesAfter :many
+-- Keyset page of ListWebhookDeliveries continuing after the delivery with
+-- after_id, i.e. with deliveries queued before it
+SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, last_error, created_at, updated_at, event_id
+FROM webhook_deliveries
+WHERE webhook_id = @webhook_id AND id < @after_id
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE webhook_id = $1;
+
+-- name: DeleteFinishedWebhookDeliveries :execrows
+-- Pending deliveries are kept however old they are, so none is dropped
+-- before it runs out of attempts
+DELETE FROM webhook_deliveries
+WHERE status <> 'pending' AND updated_at < $1;
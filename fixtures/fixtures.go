@@ -0,0 +1,227 @@
+// Package fixtures loads a small, fixed dataset of users, games, categories,
+// platforms, regions, and runs for local development and demos.
+//
+// Every row has a fixed ID, public ID, and timestamp, so a frontend can rely
+// on, say, user 3 being a runner with verified runs in every game. Rows are
+// written directly rather than through the services, so seeding records no
+// audit events and publishes no events.
+package fixtures
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password is the password every fixture user logs in with
+const Password = "speedrun-demo"
+
+// epoch is when the dataset's first row was created
+var epoch = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// Users are the fixture accounts, all with verified email addresses: an
+// admin, a moderator of Super Mario 64, and four runners
+var Users = []db.SeedUserParams{
+	user(1, "Ada Admin", "admin@example.com"),
+	user(2, "Max Moderator", "moderator@example.com"),
+	user(3, "Riley Speed", "riley@example.com"),
+	user(4, "Sam Frames", "sam@example.com"),
+	user(5, "Jordan Skip", "jordan@example.com"),
+	user(6, "Taylor Clip", "taylor@example.com"),
+}
+
+// Roles are the roles granted to fixture users
+var Roles = []db.GrantUserRoleParams{
+	{UserID: 1, Role: "admin"},
+	{UserID: 2, Role: "moderator", GameID: pgtype.Int4{Int32: 1, Valid: true}},
+}
+
+// Games are the fixture games
+var Games = []db.SeedGameParams{
+	{ID: 1, Slug: "super-mario-64", Name: "Super Mario 64", CreatedAt: at(0)},
+	{ID: 2, Slug: "celeste", Name: "Celeste", CreatedAt: at(0)},
+	{ID: 3, Slug: "portal", Name: "Portal", CreatedAt: at(0)},
+}
+
+// Categories are the fixture categories, with one default per game
+var Categories = []db.SeedCategoryParams{
+	category(1, 1, "120-star", "120 Star", 0, "Collect all 120 stars before beating Bowser."),
+	category(2, 1, "70-star", "70 Star", 1, "Beat the game with at least 70 stars."),
+	category(3, 1, "16-star", "16 Star", 2, "Beat the game with at least 16 stars."),
+	category(4, 2, "any-percent", "Any%", 0, "Reach the summit by any means."),
+	category(5, 2, "all-red-berries", "All Red Berries", 1, "Collect every red strawberry."),
+	category(6, 3, "inbounds", "Inbounds", 0, "Finish the game without leaving the level geometry."),
+	category(7, 3, "glitchless", "Glitchless", 1, "Finish the game without using any glitch."),
+}
+
+// Platforms are the fixture platforms
+var Platforms = []db.SeedPlatformParams{
+	{ID: 1, Slug: "n64", Name: "Nintendo 64", CreatedAt: at(0)},
+	{ID: 2, Slug: "wii-vc", Name: "Wii Virtual Console", CreatedAt: at(0)},
+	{ID: 3, Slug: "pc", Name: "PC", CreatedAt: at(0)},
+	{ID: 4, Slug: "switch", Name: "Nintendo Switch", CreatedAt: at(0)},
+}
+
+// Regions are the fixture regions; only the Super Mario 64 runs record one
+var Regions = []db.SeedRegionParams{
+	{ID: 1, Slug: "usa", Name: "USA / NTSC", CreatedAt: at(0)},
+	{ID: 2, Slug: "eur", Name: "EUR / PAL", CreatedAt: at(0)},
+	{ID: 3, Slug: "jpn", Name: "JPN / NTSC", CreatedAt: at(0)},
+}
+
+// Runs are the fixture runs: most verified, so every leaderboard has
+// entries, plus some pending for moderators to review and one rejected
+var Runs = []db.SeedRunParams{
+	run(1, 3, 1, 5843000, "n64", "jpn", "2024-01-20", "verified"),
+	run(2, 4, 1, 5921000, "n64", "usa", "2024-01-22", "verified"),
+	run(3, 5, 1, 6104000, "wii-vc", "usa", "2024-02-03", "verified"),
+	run(4, 3, 2, 2897000, "n64", "jpn", "2024-02-10", "verified"),
+	run(5, 6, 2, 3012000, "n64", "eur", "2024-02-14", "pending"),
+	run(6, 4, 3, 893000, "n64", "jpn", "2024-02-18", "verified"),
+	rejected(run(7, 5, 3, 871000, "n64", "usa", "2024-03-01", "rejected"), "The video cuts out before the final star."),
+	run(8, 4, 4, 1612000, "pc", "", "2024-01-28", "verified"),
+	run(9, 6, 4, 1658000, "switch", "", "2024-02-05", "verified"),
+	run(10, 3, 4, 1640000, "pc", "", "2024-03-09", "pending"),
+	run(11, 5, 5, 5402000, "pc", "", "2024-02-21", "verified"),
+	run(12, 6, 6, 457000, "pc", "", "2024-01-30", "verified"),
+	run(13, 3, 6, 463000, "pc", "", "2024-02-27", "verified"),
+	run(14, 4, 7, 1189000, "pc", "", "2024-03-04", "verified"),
+	run(15, 5, 7, 1205000, "pc", "", "2024-03-12", "pending"),
+}
+
+// Seed loads the dataset in a single transaction
+//
+// Rows whose ID, slug, or email is already taken are left alone, so seeding
+// a database twice changes nothing; seeding one that already has other data
+// may leave the dataset incomplete.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - store: The database to load the dataset into
+//
+// Returns:
+//   - error: Database errors
+func Seed(ctx context.Context, store db.Store) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(Password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return store.WithTx(ctx, func(q db.Querier) error {
+		for _, u := range Users {
+			if err := q.SeedUser(ctx, u); err != nil {
+				return fmt.Errorf("failed to seed user %d: %w", u.ID, err)
+			}
+			if err := q.SeedUserPassword(ctx, db.SeedUserPasswordParams{UserID: u.ID, PasswordHash: string(hash)}); err != nil {
+				return fmt.Errorf("failed to seed password of user %d: %w", u.ID, err)
+			}
+		}
+		for _, g := range Games {
+			if err := q.SeedGame(ctx, g); err != nil {
+				return fmt.Errorf("failed to seed game %s: %w", g.Slug, err)
+			}
+		}
+		for _, r := range Roles {
+			// No row means the user already has the role
+			if _, err := q.GrantUserRole(ctx, r); err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("failed to seed role of user %d: %w", r.UserID, err)
+			}
+		}
+		for _, c := range Categories {
+			if err := q.SeedCategory(ctx, c); err != nil {
+				return fmt.Errorf("failed to seed category %s: %w", c.Slug, err)
+			}
+		}
+		for _, p := range Platforms {
+			if err := q.SeedPlatform(ctx, p); err != nil {
+				return fmt.Errorf("failed to seed platform %s: %w", p.Slug, err)
+			}
+		}
+		for _, r := range Regions {
+			if err := q.SeedRegion(ctx, r); err != nil {
+				return fmt.Errorf("failed to seed region %s: %w", r.Slug, err)
+			}
+		}
+		for _, r := range Runs {
+			if err := q.SeedRun(ctx, r); err != nil {
+				return fmt.Errorf("failed to seed run %d: %w", r.ID, err)
+			}
+		}
+		if err := q.SeedCategoryRecords(ctx); err != nil {
+			return fmt.Errorf("failed to seed record history: %w", err)
+		}
+		if err := q.SyncSeededSequences(ctx); err != nil {
+			return fmt.Errorf("failed to advance sequences: %w", err)
+		}
+		return nil
+	})
+}
+
+// at returns the timestamp days after epoch
+func at(days int) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: epoch.AddDate(0, 0, days), Valid: true}
+}
+
+// user builds a fixture user whose public ID ends in its ID
+func user(id int32, name, email string) db.SeedUserParams {
+	publicID := uuid.MustParse(fmt.Sprintf("00000000-0000-4000-8000-%012d", id))
+	return db.SeedUserParams{
+		ID:        id,
+		PublicID:  pgtype.UUID{Bytes: publicID, Valid: true},
+		Name:      name,
+		Email:     email,
+		CreatedAt: at(0),
+	}
+}
+
+// category builds a fixture category; the first of each game is its default
+func category(id, gameID int32, slug, name string, position int32, rules string) db.SeedCategoryParams {
+	return db.SeedCategoryParams{
+		ID:        id,
+		GameID:    gameID,
+		Slug:      slug,
+		Name:      name,
+		Rules:     rules,
+		Position:  position,
+		IsDefault: position == 0,
+		CreatedAt: at(0),
+	}
+}
+
+// run builds a fixture run submitted the evening it was played on and, unless
+// it is pending, reviewed the next morning; an empty region records none
+func run(id, userID, categoryID int32, timeMs int64, platform, region, playedOn, status string) db.SeedRunParams {
+	played, err := time.Parse(time.DateOnly, playedOn)
+	if err != nil {
+		panic(err)
+	}
+	r := db.SeedRunParams{
+		ID:         id,
+		UserID:     userID,
+		CategoryID: categoryID,
+		TimeMs:     timeMs,
+		VideoUrl:   fmt.Sprintf("https://videos.example.com/runs/%d", id),
+		Platform:   platform,
+		Region:     pgtype.Text{String: region, Valid: region != ""},
+		PlayedOn:   pgtype.Date{Time: played, Valid: true},
+		Status:     status,
+		CreatedAt:  pgtype.Timestamptz{Time: played.Add(20 * time.Hour), Valid: true},
+	}
+	if status != "pending" {
+		r.ReviewedAt = pgtype.Timestamptz{Time: played.Add(33 * time.Hour), Valid: true}
+	}
+	return r
+}
+
+// rejected gives a rejected run the reason it was rejected for
+func rejected(r db.SeedRunParams, reason string) db.SeedRunParams {
+	r.RejectionReason = pgtype.Text{String: reason, Valid: true}
+	return r
+}
@@ -0,0 +1,150 @@
+package fixtures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// recordingStore records what Seed writes; queries it does not expect panic
+// through the embedded nil Querier and Exporter
+type recordingStore struct {
+	db.Querier
+	db.Exporter
+	calls     []string
+	passwords []db.SeedUserPasswordParams
+}
+
+func (s *recordingStore) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	return fn(s)
+}
+
+func (s *recordingStore) SeedUser(ctx context.Context, arg db.SeedUserParams) error {
+	s.calls = append(s.calls, "user")
+	return nil
+}
+
+func (s *recordingStore) SeedUserPassword(ctx context.Context, arg db.SeedUserPasswordParams) error {
+	s.passwords = append(s.passwords, arg)
+	return nil
+}
+
+func (s *recordingStore) SeedGame(ctx context.Context, arg db.SeedGameParams) error {
+	s.calls = append(s.calls, "game")
+	return nil
+}
+
+func (s *recordingStore) GrantUserRole(ctx context.Context, arg db.GrantUserRoleParams) (db.UserRole, error) {
+	s.calls = append(s.calls, "role")
+	return db.UserRole{}, nil
+}
+
+func (s *recordingStore) SeedCategory(ctx context.Context, arg db.SeedCategoryParams) error {
+	s.calls = append(s.calls, "category")
+	return nil
+}
+
+func (s *recordingStore) SeedPlatform(ctx context.Context, arg db.SeedPlatformParams) error {
+	s.calls = append(s.calls, "platform")
+	return nil
+}
+
+func (s *recordingStore) SeedRegion(ctx context.Context, arg db.SeedRegionParams) error {
+	s.calls = append(s.calls, "region")
+	return nil
+}
+
+func (s *recordingStore) SeedRun(ctx context.Context, arg db.SeedRunParams) error {
+	s.calls = append(s.calls, "run")
+	return nil
+}
+
+func (s *recordingStore) SeedCategoryRecords(ctx context.Context) error {
+	s.calls = append(s.calls, "records")
+	return nil
+}
+
+func (s *recordingStore) SyncSeededSequences(ctx context.Context) error {
+	s.calls = append(s.calls, "sequences")
+	return nil
+}
+
+func TestSeed(t *testing.T) {
+	store := &recordingStore{}
+	if err := Seed(context.Background(), store); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := len(Users) + len(Games) + len(Roles) + len(Categories) + len(Platforms) + len(Regions) + len(Runs) + 2
+	if len(store.calls) != want || store.calls[len(store.calls)-1] != "sequences" {
+		t.Errorf("expected every row seeded before the sequences advance, got %v", store.calls)
+	}
+	if len(store.passwords) != len(Users) {
+		t.Fatalf("expected a password for every user, got %d", len(store.passwords))
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(store.passwords[0].PasswordHash), []byte(Password)); err != nil {
+		t.Errorf("expected users to log in with Password, got %v", err)
+	}
+}
+
+func TestDataset_Consistent(t *testing.T) {
+	users := map[int32]bool{}
+	for _, u := range Users {
+		if users[u.ID] {
+			t.Errorf("duplicate user ID %d", u.ID)
+		}
+		users[u.ID] = true
+	}
+	games := map[int32]bool{}
+	for _, g := range Games {
+		games[g.ID] = true
+	}
+	for _, r := range Roles {
+		if !users[r.UserID] || (r.GameID.Valid && !games[r.GameID.Int32]) {
+			t.Errorf("role %+v refers to a missing user or game", r)
+		}
+	}
+
+	categories := map[int32]bool{}
+	defaults := map[int32]int{}
+	for _, c := range Categories {
+		if !games[c.GameID] {
+			t.Errorf("category %s refers to missing game %d", c.Slug, c.GameID)
+		}
+		categories[c.ID] = true
+		if c.IsDefault {
+			defaults[c.GameID]++
+		}
+	}
+	for id := range games {
+		if defaults[id] != 1 {
+			t.Errorf("expected game %d to have one default category, got %d", id, defaults[id])
+		}
+	}
+
+	platforms := map[string]bool{}
+	for _, p := range Platforms {
+		platforms[p.Slug] = true
+	}
+	regions := map[string]bool{}
+	for _, r := range Regions {
+		regions[r.Slug] = true
+	}
+
+	for _, r := range Runs {
+		if !users[r.UserID] || !categories[r.CategoryID] {
+			t.Errorf("run %d refers to a missing user or category", r.ID)
+		}
+		if !platforms[r.Platform] || (r.Region.Valid && !regions[r.Region.String]) {
+			t.Errorf("run %d refers to a missing platform or region", r.ID)
+		}
+		if (r.Status == "pending") == r.ReviewedAt.Valid {
+			t.Errorf("run %d: expected only reviewed runs to have reviewed_at", r.ID)
+		}
+		if (r.Status == "rejected") != r.RejectionReason.Valid {
+			t.Errorf("run %d: expected only rejected runs to have a reason", r.ID)
+		}
+	}
+}
@@ -1,4 +1,9 @@
-package main
+// Package speedrun holds the go:generate directives that rebuild the
+// generated API, database, GraphQL, gRPC, and Go client code.
+package speedrun
 
 //go:generate oapi-codegen -config config.yaml openapi.yaml
 //go:generate sqlc generate
+//go:generate gqlgen generate --config gqlgen.yml
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative rpc/speedrun.proto
+//go:generate oapi-codegen -config client/config.yaml openapi.yaml
@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// dateLayout formats the date a run was played
+const dateLayout = "2006-01-02"
+
+// toUser converts a database User to a GraphQL User, leaving out the email
+// address the way the REST API's public profiles do
+func toUser(user *db.User) *User {
+	return &User{
+		ID:            int(user.ID),
+		PublicID:      user.PublicID.Bytes,
+		Name:          user.Name,
+		CreatedAt:     user.CreatedAt.Time.UTC(),
+		TwitchHandle:  optionalText(user.TwitchHandle),
+		YoutubeHandle: optionalText(user.YoutubeHandle),
+		TwitterHandle: optionalText(user.TwitterHandle),
+		CountryCode:   optionalText(user.CountryCode),
+		Pronouns:      optionalText(user.Pronouns),
+		Bio:           optionalText(user.Bio),
+		AvatarURL:     optionalText(user.AvatarUrl),
+	}
+}
+
+// toGame converts a database Game to a GraphQL Game
+func toGame(game *db.Game) *Game {
+	return &Game{
+		ID:        int(game.ID),
+		Slug:      game.Slug,
+		Name:      game.Name,
+		CreatedAt: game.CreatedAt.Time.UTC(),
+		UpdatedAt: game.UpdatedAt.Time.UTC(),
+	}
+}
+
+// toCategory converts a database Category to a GraphQL Category
+func toCategory(category *db.Category) *Category {
+	return &Category{
+		ID:           int(category.ID),
+		GameID:       int(category.GameID),
+		Slug:         category.Slug,
+		Name:         category.Name,
+		Rules:        category.Rules,
+		Position:     int(category.Position),
+		IsDefault:    category.IsDefault,
+		TimingMethod: TimingMethod(strings.ToUpper(category.TimingMethod)),
+		CreatedAt:    category.CreatedAt.Time.UTC(),
+	}
+}
+
+// toRun converts a database Run to a GraphQL Run
+func toRun(run *db.Run) *Run {
+	return &Run{
+		ID:              int(run.ID),
+		UserID:          int(run.UserID),
+		CategoryID:      int(run.CategoryID),
+		LevelID:         optionalID(run.LevelID),
+		TimeMs:          int(run.TimeMs),
+		Times:           toRunTimes(run.RtaMs, run.IgtMs, run.LrtMs),
+		VideoURL:        run.VideoUrl,
+		Platform:        run.Platform,
+		Region:          optionalText(run.Region),
+		PlayedOn:        run.PlayedOn.Time.Format(dateLayout),
+		CreatedAt:       run.CreatedAt.Time.UTC(),
+		Status:          RunStatus(strings.ToUpper(run.Status)),
+		RejectionReason: optionalText(run.RejectionReason),
+		ReviewedAt:      optionalTime(run.ReviewedAt),
+		Obsolete:        run.Obsolete,
+		RaceID:          optionalID(run.RaceID),
+	}
+}
+
+// toRunConnection converts a page of runs to a GraphQL RunConnection
+func toRunConnection(page *service.RunPage) *RunConnection {
+	runs := make([]*Run, len(page.Runs))
+	for i := range page.Runs {
+		runs[i] = toRun(&page.Runs[i])
+	}
+	return &RunConnection{
+		Nodes:      runs,
+		TotalCount: int(page.Total),
+		NextCursor: optionalCursor(page.NextCursor),
+	}
+}
+
+// toLeaderboardConnection converts a page of standings to a GraphQL
+// LeaderboardConnection
+func toLeaderboardConnection(page *service.LeaderboardPage) *LeaderboardConnection {
+	entries := make([]*LeaderboardEntry, len(page.Entries))
+	for i, entry := range page.Entries {
+		entries[i] = &LeaderboardEntry{
+			Rank:     int(entry.Rank),
+			RunID:    int(entry.ID),
+			UserID:   int(entry.UserID),
+			TimeMs:   int(entry.TimeMs),
+			Times:    toRunTimes(entry.RtaMs, entry.IgtMs, entry.LrtMs),
+			VideoURL: entry.VideoUrl,
+			Platform: entry.Platform,
+			PlayedOn: entry.PlayedOn.Time.Format(dateLayout),
+		}
+	}
+	return &LeaderboardConnection{
+		Nodes:      entries,
+		TotalCount: int(page.Total),
+		NextCursor: optionalCursor(page.NextCursor),
+	}
+}
+
+// toPersonalBest converts a user's best run in a category to a GraphQL
+// PersonalBest
+func toPersonalBest(best *db.GetPersonalBestsRow) *PersonalBest {
+	return &PersonalBest{
+		Rank:         int(best.Rank),
+		RunID:        int(best.ID),
+		CategoryID:   int(best.CategoryID),
+		TimeMs:       int(best.TimeMs),
+		RecordTimeMs: int(best.RecordTimeMs),
+		DeltaMs:      int(best.DeltaMs),
+		VideoURL:     best.VideoUrl,
+		Platform:     best.Platform,
+		PlayedOn:     best.PlayedOn.Time.Format(dateLayout),
+	}
+}
+
+// toRunTimes converts a run's nullable times to a GraphQL RunTimes, leaving
+// out the methods the run was not timed by
+func toRunTimes(rta, igt, lrt pgtype.Int8) *RunTimes {
+	return &RunTimes{
+		RtaMs: optionalInt(rta),
+		IgtMs: optionalInt(igt),
+		LrtMs: optionalInt(lrt),
+	}
+}
+
+// pageRequest converts GraphQL pagination arguments to a service page request
+func pageRequest(first *int, after *string) service.PageRequest {
+	var page service.PageRequest
+	if first != nil {
+		page.Limit = *first
+	}
+	if after != nil {
+		page.Cursor = *after
+	}
+	return page
+}
+
+// optionalCursor converts a next page cursor, empty on the last page, to an
+// optional field
+func optionalCursor(cursor string) *string {
+	if cursor == "" {
+		return nil
+	}
+	return &cursor
+}
+
+// optionalText converts a nullable column to an optional field
+func optionalText(text pgtype.Text) *string {
+	if !text.Valid {
+		return nil
+	}
+	return &text.String
+}
+
+// optionalID converts a nullable reference to an optional ID field
+func optionalID(id pgtype.Int4) *int {
+	if !id.Valid {
+		return nil
+	}
+	value := int(id.Int32)
+	return &value
+}
+
+// optionalInt converts a nullable number to an optional field
+func optionalInt(n pgtype.Int8) *int {
+	if !n.Valid {
+		return nil
+	}
+	value := int(n.Int64)
+	return &value
+}
+
+// optionalTime converts a nullable timestamp to an optional field in UTC
+func optionalTime(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	utc := t.Time.UTC()
+	return &utc
+}
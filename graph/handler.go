@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// NewHandler creates an http.Handler serving GraphQL queries sent with GET
+// or POST
+//
+// maxComplexity rejects queries asking for more than that many fields, with
+// each list field counting once per row of the page it asks for, so one
+// request can't fan out into an unbounded number of queries.
+func NewHandler(resolver *Resolver, maxComplexity int) http.Handler {
+	srv := handler.New(NewExecutableSchema(Config{
+		Resolvers:  resolver,
+		Complexity: complexity(),
+	}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	srv.SetErrorPresenter(presentError)
+	srv.SetRecoverFunc(recoverPanic)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := withLoaders(r.Context(), resolver.newLoaders())
+		srv.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// complexity weighs each paginated field by the page size it asks for, or
+// the REST API's default page size when it asks for none
+func complexity() ComplexityRoot {
+	var c ComplexityRoot
+	c.Query.Users = func(childComplexity int, first *int, after *string, name *string) int {
+		return pageComplexity(childComplexity, first)
+	}
+	c.Query.Games = func(childComplexity int, first *int, after *string) int {
+		return pageComplexity(childComplexity, first)
+	}
+	c.User.Runs = func(childComplexity int, first *int, after *string, includeObsolete *bool) int {
+		return pageComplexity(childComplexity, first)
+	}
+	c.Category.Runs = func(childComplexity int, first *int, after *string, includeObsolete *bool) int {
+		return pageComplexity(childComplexity, first)
+	}
+	c.Category.Leaderboard = func(childComplexity int, first *int, after *string) int {
+		return pageComplexity(childComplexity, first)
+	}
+	return c
+}
+
+// defaultPageComplexity is the page size a paginated field is weighed by
+// when it doesn't ask for one
+const defaultPageComplexity = 10
+
+// pageComplexity weighs a paginated field by the rows it may return
+func pageComplexity(childComplexity int, first *int) int {
+	rows := defaultPageComplexity
+	if first != nil && *first > 0 {
+		rows = *first
+	}
+	return 1 + rows*childComplexity
+}
+
+// presentError gives errors the codes the REST API uses in the error's
+// extensions; internal errors are logged and reported without their details
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if gqlErr.Err == nil {
+		// Parse and validation errors describe the query and are safe to show
+		return gqlErr
+	}
+
+	code := ""
+	switch {
+	case errors.Is(err, service.ErrInvalidCursor):
+		gqlErr.Message, code = "Invalid cursor", "INVALID_CURSOR"
+	case errors.Is(err, service.ErrInvalidInput):
+		code = "INVALID_INPUT"
+	case errors.Is(err, service.ErrForbidden):
+		gqlErr.Message, code = "Forbidden", "FORBIDDEN"
+	case errors.Is(err, service.ErrCategoryNotFound):
+		gqlErr.Message, code = "Category not found", "CATEGORY_NOT_FOUND"
+	default:
+		slog.ErrorContext(ctx, "Error resolving GraphQL field", "path", gqlErr.Path.String(), "error", err)
+		gqlErr.Message, code = "Internal server error", "INTERNAL_ERROR"
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]any{}
+	}
+	gqlErr.Extensions["code"] = code
+	return gqlErr
+}
+
+// recoverPanic reports a panic in a resolver as an internal error of the
+// field rather than failing the whole response
+func recoverPanic(ctx context.Context, p any) error {
+	slog.ErrorContext(ctx, "Panic resolving GraphQL field", "panic", p)
+	return errors.New("internal server error")
+}
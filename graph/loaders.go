@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/vikstrous/dataloadgen"
+)
+
+// loaderWait is how long a loader collects lookups before running them as
+// one batch; resolvers of sibling fields run concurrently, so this only has
+// to cover the time it takes them all to ask
+const loaderWait = time.Millisecond
+
+// Loaders batch and cache the lookups made while resolving one request, so
+// a page of runs costs one query for all of their runners rather than one
+// per run
+type Loaders struct {
+	users          *dataloadgen.Loader[int32, *db.User]
+	games          *dataloadgen.Loader[int32, *db.Game]
+	categories     *dataloadgen.Loader[int32, *db.Category]
+	gameCategories *dataloadgen.Loader[int32, []db.Category]
+}
+
+// loadersKey is the context key of a request's Loaders
+type loadersKey struct{}
+
+// newLoaders creates the Loaders of one request
+func (r *Resolver) newLoaders() *Loaders {
+	return &Loaders{
+		users: newLoader(r.maxBatch, func(ctx context.Context, ids []int32) (map[int32]*db.User, error) {
+			users, _, err := r.users.GetUsersByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			return byID(users, func(user *db.User) int32 { return user.ID }), nil
+		}),
+		games: newLoader(r.maxBatch, func(ctx context.Context, ids []int32) (map[int32]*db.Game, error) {
+			games, err := r.games.GetGamesByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			return byID(games, func(game *db.Game) int32 { return game.ID }), nil
+		}),
+		categories: newLoader(r.maxBatch, func(ctx context.Context, ids []int32) (map[int32]*db.Category, error) {
+			categories, err := r.categories.GetCategoriesByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			return byID(categories, func(category *db.Category) int32 { return category.ID }), nil
+		}),
+		gameCategories: newLoader(r.maxBatch, r.categories.ListCategoriesByGames),
+	}
+}
+
+// withLoaders returns a context carrying loaders
+func withLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// loadersFrom returns the Loaders of the request ctx belongs to
+func loadersFrom(ctx context.Context) *Loaders {
+	return ctx.Value(loadersKey{}).(*Loaders)
+}
+
+// loadUser loads a user through the request's Loaders; deleted users load
+// as nil
+func (r *Resolver) loadUser(ctx context.Context, id int) (*User, error) {
+	user, err := loadersFrom(ctx).users.Load(ctx, int32(id))
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// loadCategory loads a category through the request's Loaders
+func (r *Resolver) loadCategory(ctx context.Context, id int) (*Category, error) {
+	category, err := loadersFrom(ctx).categories.Load(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, service.ErrCategoryNotFound
+	}
+	return toCategory(category), nil
+}
+
+// newLoader creates a loader that batches lookups by ID into calls of fetch,
+// which returns the rows it found keyed by ID; IDs it leaves out load the
+// zero value, such as a nil row, rather than failing
+func newLoader[V any](maxBatch int, fetch func(ctx context.Context, ids []int32) (map[int32]V, error)) *dataloadgen.Loader[int32, V] {
+	return dataloadgen.NewLoader(func(ctx context.Context, ids []int32) ([]V, []error) {
+		found, err := fetch(ctx, ids)
+		if err != nil {
+			return nil, []error{err}
+		}
+		values := make([]V, len(ids))
+		for i, id := range ids {
+			values[i] = found[id]
+		}
+		return values, nil
+	}, dataloadgen.WithBatchCapacity(maxBatch), dataloadgen.WithWait(loaderWait))
+}
+
+// byID indexes rows by the ID key returns
+func byID[T any](rows []T, key func(row *T) int32) map[int32]*T {
+	index := make(map[int32]*T, len(rows))
+	for i := range rows {
+		index[key(&rows[i])] = &rows[i]
+	}
+	return index
+}
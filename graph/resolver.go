@@ -0,0 +1,36 @@
+// Package graph serves the read-only GraphQL API described by schema.graphqls
+// on top of the same services as the REST API.
+//
+// generated.go and models_gen.go are generated by gqlgen from the schema;
+// resolvers fill in the fields that are not plain copies of a database row,
+// loading related rows through the request's Loaders so that nested lists
+// cost one query per level rather than one per item.
+package graph
+
+import (
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// Resolver resolves GraphQL queries against the service layer
+type Resolver struct {
+	users      *service.UserService
+	games      *service.GameService
+	categories *service.CategoryService
+	runs       *service.RunService
+
+	// maxBatch caps how many rows one batched lookup asks for, matching the
+	// largest batch the user service accepts
+	maxBatch int
+}
+
+// NewResolver creates a new Resolver; maxBatch is the largest number of IDs
+// a batched lookup may ask users for
+func NewResolver(users *service.UserService, games *service.GameService, categories *service.CategoryService, runs *service.RunService, maxBatch int) *Resolver {
+	return &Resolver{
+		users:      users,
+		games:      games,
+		categories: categories,
+		runs:       runs,
+		maxBatch:   maxBatch,
+	}
+}
@@ -0,0 +1,219 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.85
+
+import (
+	"context"
+	"errors"
+
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// Game is the resolver for the game field.
+func (r *categoryResolver) Game(ctx context.Context, obj *Category) (*Game, error) {
+	game, err := loadersFrom(ctx).games.Load(ctx, int32(obj.GameID))
+	if err != nil {
+		return nil, err
+	}
+	if game == nil {
+		return nil, service.ErrGameNotFound
+	}
+	return toGame(game), nil
+}
+
+// Leaderboard is the resolver for the leaderboard field.
+func (r *categoryResolver) Leaderboard(ctx context.Context, obj *Category, first *int, after *string) (*LeaderboardConnection, error) {
+	game, err := r.Category().Game(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	page, err := r.runs.Leaderboard(ctx, game.Slug, obj.Slug, pageRequest(first, after), service.LeaderboardFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return toLeaderboardConnection(page), nil
+}
+
+// Runs is the resolver for the runs field.
+func (r *categoryResolver) Runs(ctx context.Context, obj *Category, first *int, after *string, includeObsolete *bool) (*RunConnection, error) {
+	game, err := r.Category().Game(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	filter := service.ListRunsFilter{IncludeObsolete: includeObsolete != nil && *includeObsolete}
+	page, err := r.runs.ListCategoryRuns(ctx, game.Slug, obj.Slug, pageRequest(first, after), filter)
+	if err != nil {
+		return nil, err
+	}
+	return toRunConnection(page), nil
+}
+
+// Categories is the resolver for the categories field.
+func (r *gameResolver) Categories(ctx context.Context, obj *Game) ([]*Category, error) {
+	categories, err := loadersFrom(ctx).gameCategories.Load(ctx, int32(obj.ID))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Category, len(categories))
+	for i := range categories {
+		result[i] = toCategory(&categories[i])
+	}
+	return result, nil
+}
+
+// User is the resolver for the user field.
+func (r *leaderboardEntryResolver) User(ctx context.Context, obj *LeaderboardEntry) (*User, error) {
+	return r.loadUser(ctx, obj.UserID)
+}
+
+// Category is the resolver for the category field.
+func (r *personalBestResolver) Category(ctx context.Context, obj *PersonalBest) (*Category, error) {
+	return r.loadCategory(ctx, obj.CategoryID)
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id int) (*User, error) {
+	user, err := r.users.GetUserByID(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, first *int, after *string, name *string) (*UserConnection, error) {
+	var filter service.ListUsersFilter
+	if name != nil {
+		filter.Name = *name
+	}
+	page, err := r.users.ListUsers(ctx, pageRequest(first, after), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	loaders := loadersFrom(ctx)
+	users := make([]*User, len(page.Users))
+	for i := range page.Users {
+		loaders.users.Prime(page.Users[i].ID, &page.Users[i])
+		users[i] = toUser(&page.Users[i])
+	}
+	return &UserConnection{
+		Nodes:      users,
+		TotalCount: int(page.Total),
+		NextCursor: optionalCursor(page.NextCursor),
+	}, nil
+}
+
+// Game is the resolver for the game field.
+func (r *queryResolver) Game(ctx context.Context, slug string) (*Game, error) {
+	game, err := r.games.GetGameBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, service.ErrGameNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	loadersFrom(ctx).games.Prime(game.ID, game)
+	return toGame(game), nil
+}
+
+// Games is the resolver for the games field.
+func (r *queryResolver) Games(ctx context.Context, first *int, after *string) (*GameConnection, error) {
+	page, err := r.games.ListGames(ctx, pageRequest(first, after))
+	if err != nil {
+		return nil, err
+	}
+
+	loaders := loadersFrom(ctx)
+	games := make([]*Game, len(page.Games))
+	for i := range page.Games {
+		loaders.games.Prime(page.Games[i].ID, &page.Games[i])
+		games[i] = toGame(&page.Games[i])
+	}
+	return &GameConnection{
+		Nodes:      games,
+		TotalCount: int(page.Total),
+		NextCursor: optionalCursor(page.NextCursor),
+	}, nil
+}
+
+// Category is the resolver for the category field.
+func (r *queryResolver) Category(ctx context.Context, game string, slug string) (*Category, error) {
+	category, err := r.categories.GetCategory(ctx, game, slug)
+	if err != nil {
+		if errors.Is(err, service.ErrCategoryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	loadersFrom(ctx).categories.Prime(category.ID, category)
+	return toCategory(category), nil
+}
+
+// User is the resolver for the user field.
+func (r *runResolver) User(ctx context.Context, obj *Run) (*User, error) {
+	return r.loadUser(ctx, obj.UserID)
+}
+
+// Category is the resolver for the category field.
+func (r *runResolver) Category(ctx context.Context, obj *Run) (*Category, error) {
+	return r.loadCategory(ctx, obj.CategoryID)
+}
+
+// Runs is the resolver for the runs field.
+func (r *userResolver) Runs(ctx context.Context, obj *User, first *int, after *string, includeObsolete *bool) (*RunConnection, error) {
+	filter := service.ListRunsFilter{IncludeObsolete: includeObsolete != nil && *includeObsolete}
+	page, err := r.runs.ListUserRuns(ctx, int32(obj.ID), pageRequest(first, after), filter)
+	if err != nil {
+		return nil, err
+	}
+	return toRunConnection(page), nil
+}
+
+// PersonalBests is the resolver for the personalBests field.
+func (r *userResolver) PersonalBests(ctx context.Context, obj *User) ([]*PersonalBest, error) {
+	bests, err := r.runs.PersonalBests(ctx, int32(obj.ID))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PersonalBest, len(bests))
+	for i := range bests {
+		result[i] = toPersonalBest(&bests[i])
+	}
+	return result, nil
+}
+
+// Category returns CategoryResolver implementation.
+func (r *Resolver) Category() CategoryResolver { return &categoryResolver{r} }
+
+// Game returns GameResolver implementation.
+func (r *Resolver) Game() GameResolver { return &gameResolver{r} }
+
+// LeaderboardEntry returns LeaderboardEntryResolver implementation.
+func (r *Resolver) LeaderboardEntry() LeaderboardEntryResolver { return &leaderboardEntryResolver{r} }
+
+// PersonalBest returns PersonalBestResolver implementation.
+func (r *Resolver) PersonalBest() PersonalBestResolver { return &personalBestResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Run returns RunResolver implementation.
+func (r *Resolver) Run() RunResolver { return &runResolver{r} }
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
+type categoryResolver struct{ *Resolver }
+type gameResolver struct{ *Resolver }
+type leaderboardEntryResolver struct{ *Resolver }
+type personalBestResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type runResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
@@ -0,0 +1,88 @@
+// Package janitor periodically removes expired rows left behind by
+// features such as idempotency keys and audit logs.
+package janitor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Reaper deletes rows of one kind that are older than a cutoff
+type Reaper struct {
+	// Name identifies the reaper in log output, e.g. "idempotency keys"
+	Name string
+
+	// Reap deletes rows created before cutoff and reports how many were removed
+	Reap func(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// Retention overrides the janitor's retention for these rows when
+	// positive, for rows that are useful for longer
+	Retention time.Duration
+}
+
+// Janitor runs a set of reapers on a fixed interval
+type Janitor struct {
+	interval  time.Duration
+	retention time.Duration
+	reapers   []Reaper
+	now       func() time.Time
+}
+
+// New creates a Janitor that every interval deletes rows older than retention
+// Features register a Reaper only when they are enabled.
+func New(interval, retention time.Duration, reapers ...Reaper) *Janitor {
+	return &Janitor{
+		interval:  interval,
+		retention: retention,
+		reapers:   reapers,
+		now:       time.Now,
+	}
+}
+
+// Enabled reports whether there is anything for the janitor to clean up
+func (j *Janitor) Enabled() bool {
+	return len(j.reapers) > 0
+}
+
+// Run reaps on every tick until ctx is cancelled
+// It returns only after any cycle in progress has finished, so callers can
+// wait for Run to return before closing the database.
+func (j *Janitor) Run(ctx context.Context) {
+	if !j.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.reap(ctx)
+		}
+	}
+}
+
+// reap runs every reaper once, logging how many rows each removed
+func (j *Janitor) reap(ctx context.Context) {
+	now := j.now()
+	for _, reaper := range j.reapers {
+		if ctx.Err() != nil {
+			return
+		}
+		retention := j.retention
+		if reaper.Retention > 0 {
+			retention = reaper.Retention
+		}
+		cutoff := now.Add(-retention)
+		n, err := reaper.Reap(ctx, cutoff)
+		if err != nil {
+			slog.ErrorContext(ctx, "Janitor failed to reap", "reaper", reaper.Name, "error", err)
+			continue
+		}
+		slog.InfoContext(ctx, "Janitor reaped rows", "reaper", reaper.Name, "rows", n, "cutoff", cutoff.UTC())
+	}
+}
@@ -0,0 +1,92 @@
+package janitor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun_Disabled(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		New(time.Millisecond, time.Hour).Run(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return immediately without reapers")
+	}
+}
+
+func TestRun_ReapsUntilCancelled(t *testing.T) {
+	var calls atomic.Int32
+	var failures atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+
+	j := New(5*time.Millisecond, time.Hour,
+		Reaper{Name: "broken", Reap: func(ctx context.Context, cutoff time.Time) (int64, error) {
+			failures.Add(1)
+			return 0, errors.New("boom")
+		}},
+		Reaper{Name: "rows", Reap: func(ctx context.Context, cutoff time.Time) (int64, error) {
+			if calls.Add(1) == 3 {
+				cancel()
+			}
+			return 1, nil
+		}},
+	)
+
+	done := make(chan struct{})
+	go func() {
+		j.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to stop after cancellation")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 reap cycles, got %d", calls.Load())
+	}
+	if failures.Load() != 3 {
+		t.Errorf("expected a failing reaper not to stop the others, got %d failures", failures.Load())
+	}
+}
+
+func TestReap_UsesRetentionCutoff(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	var got time.Time
+
+	j := New(time.Minute, 24*time.Hour, Reaper{Name: "rows", Reap: func(ctx context.Context, cutoff time.Time) (int64, error) {
+		got = cutoff
+		return 0, nil
+	}})
+	j.now = func() time.Time { return now }
+	j.reap(context.Background())
+
+	if expected := now.Add(-24 * time.Hour); !got.Equal(expected) {
+		t.Errorf("expected cutoff %s, got %s", expected, got)
+	}
+}
+
+func TestReap_ReaperRetentionOverridesDefault(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	var got time.Time
+
+	j := New(time.Minute, 24*time.Hour, Reaper{Name: "rows", Retention: 30 * 24 * time.Hour, Reap: func(ctx context.Context, cutoff time.Time) (int64, error) {
+		got = cutoff
+		return 0, nil
+	}})
+	j.now = func() time.Time { return now }
+	j.reap(context.Background())
+
+	if expected := now.Add(-30 * 24 * time.Hour); !got.Equal(expected) {
+		t.Errorf("expected cutoff %s, got %s", expected, got)
+	}
+}
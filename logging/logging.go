@@ -0,0 +1,98 @@
+// Package logging sets up structured JSON logging and adds request-scoped
+// fields to every line logged while serving a request.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// level is shared by every logger from New, so SetLevel takes effect
+// everywhere at once
+var level slog.LevelVar
+
+// Level returns the minimum level currently logged
+func Level() slog.Level {
+	return level.Level()
+}
+
+// SetLevel changes the minimum level logged, without a restart
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// New creates a logger writing JSON lines to w at the level set by SetLevel
+func New(w io.Writer) *slog.Logger {
+	return slog.New(contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: &level})})
+}
+
+// requestKey is the context key under which the request being served is
+// stored
+type requestKey struct{}
+
+// request holds fields of the request being served that are learned after
+// its context was created
+type request struct {
+	start  time.Time
+	userID atomic.Int32
+}
+
+// WithRequest returns a copy of ctx for a request that started at start
+// Lines logged with the returned context, or one derived from it, carry the
+// request's latency so far.
+func WithRequest(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, requestKey{}, &request{start: start})
+}
+
+// SetUserID records the authenticated caller of the request in ctx, so that
+// lines logged with contexts created before authentication, such as the
+// access log, carry it too
+func SetUserID(ctx context.Context, userID int32) {
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		req.userID.Store(userID)
+	}
+}
+
+// contextHandler adds the request ID, route, user ID, and latency found in
+// a record's context to the record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds request-scoped fields and passes the record on
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := middleware.GetReqID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+		r.AddAttrs(slog.String("route", rctx.RoutePattern()))
+	}
+
+	req, _ := ctx.Value(requestKey{}).(*request)
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		r.AddAttrs(slog.Int("user_id", int(p.UserID)))
+	} else if req != nil && req.userID.Load() != 0 {
+		r.AddAttrs(slog.Int("user_id", int(req.userID.Load())))
+	}
+	if req != nil {
+		r.AddAttrs(slog.Float64("latency_ms", float64(r.Time.Sub(req.start).Microseconds())/1000))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler whose records carry attrs as well
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler that nests later attributes under name
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
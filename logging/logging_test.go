@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", buf.String(), err)
+	}
+	buf.Reset()
+	return line
+}
+
+func TestNew_AddsRequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	rctx := chi.NewRouteContext()
+	rctx.RoutePatterns = []string{"/games/{slug}"}
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	ctx = WithRequest(ctx, time.Now().Add(-50*time.Millisecond))
+
+	// Before authentication the user ID comes from SetUserID
+	SetUserID(ctx, 7)
+	logger.InfoContext(ctx, "hello", "game", "sm64")
+	line := decodeLine(t, &buf)
+
+	if line["msg"] != "hello" || line["game"] != "sm64" {
+		t.Errorf("expected message and attributes, got %v", line)
+	}
+	if line["request_id"] != "req-1" || line["route"] != "/games/{slug}" || line["user_id"] != float64(7) {
+		t.Errorf("expected request fields, got %v", line)
+	}
+	if latency, _ := line["latency_ms"].(float64); latency < 50 {
+		t.Errorf("expected latency of at least 50ms, got %v", line["latency_ms"])
+	}
+
+	// Within the handler the principal wins
+	logger.InfoContext(auth.WithPrincipal(ctx, auth.Principal{UserID: 9}), "hello")
+	if line := decodeLine(t, &buf); line["user_id"] != float64(9) {
+		t.Errorf("expected user_id 9, got %v", line["user_id"])
+	}
+
+	// Outside a request there is nothing to add
+	logger.Info("startup")
+	line = decodeLine(t, &buf)
+	for _, key := range []string{"request_id", "route", "user_id", "latency_ms"} {
+		if _, ok := line[key]; ok {
+			t.Errorf("expected no %s outside a request, got %v", key, line)
+		}
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	t.Cleanup(func() { SetLevel(slog.LevelInfo) })
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	logger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("expected debug to be dropped at info, got %q", buf.String())
+	}
+
+	SetLevel(slog.LevelDebug)
+	logger.Debug("shown")
+	if buf.Len() == 0 || Level() != slog.LevelDebug {
+		t.Error("expected debug to be logged after lowering the level")
+	}
+}
@@ -0,0 +1,44 @@
+// Package mailer renders and sends the transactional emails the API
+// generates, such as email verification messages, through a pluggable
+// driver: SMTP, SendGrid, or the log for development.
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Message is a plain-text email to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages
+// Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogMailer writes messages to a logger instead of delivering them
+// It is meant for development: bodies are logged in full, so any tokens they
+// carry end up in the log.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+// NewLogMailer creates a LogMailer that writes to logger
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+// Send logs msg and always succeeds
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.InfoContext(ctx, "Email not delivered; logging it instead",
+		"to", msg.To,
+		"subject", msg.Subject,
+		"body", msg.Body,
+	)
+	return nil
+}
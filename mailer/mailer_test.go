@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogMailer_LogsMessage(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewLogMailer(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	err := m.Send(context.Background(), Message{To: "jane@example.com", Subject: "Hello", Body: "token-123"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, want := range []string{"to=jane@example.com", "subject=Hello", "body=token-123"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected log to contain %q, got %s", want, buf.String())
+		}
+	}
+}
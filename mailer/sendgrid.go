@@ -0,0 +1,100 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+)
+
+// sendGridEndpoint is SendGrid's v3 mail send API
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridMailer delivers messages through the SendGrid API
+type SendGridMailer struct {
+	apiKey   string
+	from     string
+	endpoint string
+	client   *http.Client
+}
+
+// NewSendGridMailer creates a SendGridMailer that authenticates with apiKey
+// and sends from the address from, which must be a verified SendGrid sender
+func NewSendGridMailer(apiKey, from string) *SendGridMailer {
+	return &SendGridMailer{
+		apiKey:   apiKey,
+		from:     from,
+		endpoint: sendGridEndpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// sendGridAddress is an email address in a SendGrid request
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// sendGridRequest is the body of a mail send request
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+// sendGridPersonalization lists the recipients of one copy of a message
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+// sendGridContent is one representation of a message body
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Send delivers msg; SendGrid accepting it does not mean it was delivered
+func (m *SendGridMailer) Send(ctx context.Context, msg Message) error {
+	req := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{parseSendGridAddress(msg.To)}}},
+		From:             parseSendGridAddress(m.from),
+		Subject:          msg.Subject,
+		Content:          []sendGridContent{{Type: "text/plain", Value: msg.Body}},
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode SendGrid request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build SendGrid request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+m.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call SendGrid: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("SendGrid rejected message with status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// parseSendGridAddress splits a header value such as
+// "Speedrun <noreply@example.com>" into SendGrid's email and name fields
+func parseSendGridAddress(s string) sendGridAddress {
+	if a, err := mail.ParseAddress(s); err == nil {
+		return sendGridAddress{Email: a.Address, Name: a.Name}
+	}
+	return sendGridAddress{Email: s}
+}
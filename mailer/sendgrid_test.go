@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendGridMailer_Send(t *testing.T) {
+	var auth string
+	var req sendGridRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("expected a JSON body, got %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	m := NewSendGridMailer("sg-key", "Speedrun <noreply@example.com>")
+	m.endpoint = srv.URL
+	err := m.Send(context.Background(), Message{To: "jane@example.com", Subject: "Hello", Body: "Hi Jane"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if auth != "Bearer sg-key" {
+		t.Errorf("expected the API key as a bearer token, got %q", auth)
+	}
+	if req.From != (sendGridAddress{Email: "noreply@example.com", Name: "Speedrun"}) {
+		t.Errorf("expected the sender to be split into email and name, got %+v", req.From)
+	}
+	if len(req.Personalizations) != 1 || req.Personalizations[0].To[0].Email != "jane@example.com" {
+		t.Errorf("expected one recipient, got %+v", req.Personalizations)
+	}
+	if req.Subject != "Hello" || req.Content[0].Value != "Hi Jane" {
+		t.Errorf("unexpected message %+v", req)
+	}
+}
+
+func TestSendGridMailer_ReportsRejection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"message":"The from address does not match a verified Sender Identity"}]}`, http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	m := NewSendGridMailer("sg-key", "noreply@example.com")
+	m.endpoint = srv.URL
+	if err := m.Send(context.Background(), Message{To: "jane@example.com"}); err == nil {
+		t.Error("expected an error when SendGrid rejects the message")
+	}
+}
@@ -0,0 +1,137 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPMailer delivers messages through an SMTP relay
+// The connection is upgraded with STARTTLS whenever the server offers it,
+// and credentials are only ever sent over TLS.
+type SMTPMailer struct {
+	addr     string
+	username string
+	password string
+	from     string
+	now      func() time.Time
+}
+
+// NewSMTPMailer creates an SMTPMailer that relays through the server at addr
+// (host:port), sending from the address from
+// No authentication is attempted when username is empty.
+func NewSMTPMailer(addr, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		addr:     addr,
+		username: username,
+		password: password,
+		from:     from,
+		now:      time.Now,
+	}
+}
+
+// Send delivers msg, giving up when ctx is done
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	data, err := m.format(msg)
+	if err != nil {
+		return err
+	}
+
+	host, _, err := net.SplitHostPort(m.addr)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP address %q: %w", m.addr, err)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	// Unblock the conversation below if ctx is cancelled partway through
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if m.username != "" {
+		// PlainAuth refuses to send credentials over an unencrypted
+		// connection to anything but localhost
+		if err := c.Auth(smtp.PlainAuth("", m.username, m.password, host)); err != nil {
+			return fmt.Errorf("failed to authenticate with SMTP server: %w", err)
+		}
+	}
+
+	if err := c.Mail(envelopeAddress(m.from)); err != nil {
+		return fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	if err := c.Rcpt(envelopeAddress(msg.To)); err != nil {
+		return fmt.Errorf("SMTP server rejected recipient: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected message: %w", err)
+	}
+	return c.Quit()
+}
+
+// format renders msg as an RFC 5322 message with a quoted-printable body
+func (m *SMTPMailer) format(msg Message) ([]byte, error) {
+	for _, header := range []string{m.from, msg.To, msg.Subject} {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, fmt.Errorf("email headers may not contain line breaks")
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", m.now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// envelopeAddress returns the bare address of a header value such as
+// "Speedrun <noreply@example.com>", as SMTP's MAIL and RCPT commands need
+func envelopeAddress(s string) string {
+	if a, err := mail.ParseAddress(s); err == nil {
+		return a.Address
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+package mailer
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer accepts one SMTP session on a local port and records the
+// envelope and message it is sent
+type fakeSMTPServer struct {
+	addr string
+	from string
+	to   string
+	data string
+	done chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTPServer{addr: ln.Addr().String(), done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.serve(textproto.NewConn(conn))
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(c *textproto.Conn) {
+	c.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			c.PrintfLine("250 localhost")
+		case "MAIL":
+			s.from = arg
+			c.PrintfLine("250 OK")
+		case "RCPT":
+			s.to = arg
+			c.PrintfLine("250 OK")
+		case "DATA":
+			c.PrintfLine("354 Go ahead")
+			data, _ := io.ReadAll(c.DotReader())
+			s.data = string(data)
+			c.PrintfLine("250 Queued")
+		case "QUIT":
+			c.PrintfLine("221 Bye")
+			return
+		default:
+			c.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	m := NewSMTPMailer(srv.addr, "", "", "Speedrun <noreply@example.com>")
+	m.now = func() time.Time { return time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC) }
+
+	err := m.Send(context.Background(), Message{To: "jane@example.com", Subject: "Vérifiez", Body: "Hi Jane,\n\ntoken-123\n"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	<-srv.done
+
+	if srv.from != "FROM:<noreply@example.com>" || srv.to != "TO:<jane@example.com>" {
+		t.Errorf("expected bare envelope addresses, got %q and %q", srv.from, srv.to)
+	}
+	for _, want := range []string{
+		"From: Speedrun <noreply@example.com>\n",
+		"To: jane@example.com\n",
+		"Subject: =?utf-8?q?V=C3=A9rifiez?=\n",
+		"Date: Mon, 15 Jan 2024 10:30:00 +0000\n",
+		"\nHi Jane,\n\ntoken-123\n",
+	} {
+		if !strings.Contains(srv.data, want) {
+			t.Errorf("expected message to contain %q, got %q", want, srv.data)
+		}
+	}
+}
+
+func TestSMTPMailer_RejectsHeaderInjection(t *testing.T) {
+	m := NewSMTPMailer("127.0.0.1:1", "", "", "noreply@example.com")
+
+	err := m.Send(context.Background(), Message{To: "jane@example.com\r\nBcc: everyone@example.com", Subject: "Hi"})
+	if err == nil || !strings.Contains(err.Error(), "line breaks") {
+		t.Errorf("expected header injection to be rejected before connecting, got %v", err)
+	}
+}
+
+func TestSMTPMailer_HonorsContext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	// Accept the connection but never greet, like a hung relay
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	m := NewSMTPMailer(ln.Addr().String(), "", "", "noreply@example.com")
+	if err := m.Send(ctx, Message{To: "jane@example.com"}); err == nil {
+		t.Error("expected Send to give up once the context is done")
+	}
+}
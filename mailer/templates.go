@@ -0,0 +1,137 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Names of the emails Render can produce
+const (
+	TemplateVerification   = "verification"
+	TemplateWelcome        = "welcome"
+	TemplateRunVerified    = "run_verified"
+	TemplateRunRejected    = "run_rejected"
+	TemplateCommentCreated = "comment_created"
+	TemplateRecordBroken   = "record_broken"
+)
+
+// VerificationData fills in TemplateVerification
+type VerificationData struct {
+	Name      string
+	Token     string
+	ExpiresIn time.Duration
+}
+
+// WelcomeData fills in TemplateWelcome
+type WelcomeData struct {
+	Name string
+}
+
+// RunVerifiedData fills in TemplateRunVerified
+type RunVerifiedData struct {
+	Name     string
+	Game     string
+	Category string
+	TimeMs   int64
+}
+
+// RunRejectedData fills in TemplateRunRejected
+type RunRejectedData struct {
+	Name     string
+	Game     string
+	Category string
+	TimeMs   int64
+
+	// Reason is the moderator's explanation, if they gave one
+	Reason string
+}
+
+// CommentCreatedData fills in TemplateCommentCreated
+type CommentCreatedData struct {
+	Name      string
+	Game      string
+	Category  string
+	Commenter string
+	Body      string
+}
+
+// RecordBrokenData fills in TemplateRecordBroken; TimeMs is the beaten
+// record's time and NewTimeMs the time of the run by Runner that beat it
+type RecordBrokenData struct {
+	Name      string
+	Game      string
+	Category  string
+	TimeMs    int64
+	Runner    string
+	NewTimeMs int64
+}
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// templates holds a "<name>.subject" and a "<name>.body" template for every
+// email; the subject must render to a single line
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"duration": formatDuration,
+	"runTime":  formatRunTime,
+}).ParseFS(templateFiles, "templates/*.tmpl"))
+
+// Render fills in the email called name with data and addresses it to to
+func Render(name, to string, data any) (Message, error) {
+	subject := templates.Lookup(name + ".subject")
+	body := templates.Lookup(name + ".body")
+	if subject == nil || body == nil {
+		return Message{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var buf bytes.Buffer
+	if err := subject.Execute(&buf, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	msg := Message{To: to, Subject: strings.TrimSpace(buf.String())}
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return Message{}, fmt.Errorf("%s subject spans more than one line", name)
+	}
+
+	buf.Reset()
+	if err := body.Execute(&buf, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render %s body: %w", name, err)
+	}
+	msg.Body = buf.String()
+	return msg, nil
+}
+
+// formatDuration renders d in whole hours, e.g. "48 hours", or in minutes
+// when it is shorter than an hour
+func formatDuration(d time.Duration) string {
+	if d < time.Hour {
+		return plural(int(d/time.Minute), "minute")
+	}
+	return plural(int(d.Round(time.Hour)/time.Hour), "hour")
+}
+
+// plural renders n followed by unit, pluralized unless n is 1
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// formatRunTime renders a run time in milliseconds the way leaderboards
+// show it, e.g. "1:37:23.000" or "4:05.120"
+func formatRunTime(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	h := int64(d / time.Hour)
+	m := int64(d/time.Minute) % 60
+	s := int64(d/time.Second) % 60
+	frac := ms % 1000
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d.%03d", h, m, s, frac)
+	}
+	return fmt.Sprintf("%d:%02d.%03d", m, s, frac)
+}
@@ -0,0 +1,147 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender_Verification(t *testing.T) {
+	msg, err := Render(TemplateVerification, "jane@example.com", VerificationData{
+		Name:      "Jane",
+		Token:     "token-123",
+		ExpiresIn: 48 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if msg.To != "jane@example.com" || msg.Subject != "Verify your email address" {
+		t.Errorf("unexpected recipient or subject: %+v", msg)
+	}
+	for _, want := range []string{"Hi Jane,", "\ntoken-123\n", "48 hours"} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("expected body to contain %q, got %q", want, msg.Body)
+		}
+	}
+}
+
+func TestRender_RunVerified(t *testing.T) {
+	msg, err := Render(TemplateRunVerified, "jane@example.com", RunVerifiedData{
+		Name:     "Jane",
+		Game:     "Super Mario 64",
+		Category: "120 Star",
+		TimeMs:   5843120,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if msg.Subject != "Your Super Mario 64 run was verified" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Body, "1:37:23.120 run of Super Mario 64 (120 Star)") {
+		t.Errorf("expected the run to be described, got %q", msg.Body)
+	}
+}
+
+func TestRender_RunRejected(t *testing.T) {
+	data := RunRejectedData{Name: "Jane", Game: "Celeste", Category: "Any%", TimeMs: 1_654_000, Reason: "The timer is not visible"}
+	msg, err := Render(TemplateRunRejected, "jane@example.com", data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if msg.Subject != "Your Celeste run was rejected" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Body, "\n\nReason: The timer is not visible\n") {
+		t.Errorf("expected the reason in the body, got %q", msg.Body)
+	}
+
+	data.Reason = ""
+	msg, err = Render(TemplateRunRejected, "jane@example.com", data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(msg.Body, "Reason") {
+		t.Errorf("expected no reason in the body, got %q", msg.Body)
+	}
+}
+
+func TestRender_CommentCreated(t *testing.T) {
+	msg, err := Render(TemplateCommentCreated, "jane@example.com", CommentCreatedData{
+		Name:      "Jane",
+		Game:      "Celeste",
+		Category:  "Any%",
+		Commenter: "Bob",
+		Body:      "Great route!",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if msg.Subject != "Bob commented on your Celeste run" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Body, "on your Celeste (Any%) run:\n\nGreat route!\n") {
+		t.Errorf("expected the comment to be quoted, got %q", msg.Body)
+	}
+}
+
+func TestRender_RecordBroken(t *testing.T) {
+	msg, err := Render(TemplateRecordBroken, "jane@example.com", RecordBrokenData{
+		Name:      "Jane",
+		Game:      "Celeste",
+		Category:  "Any%",
+		TimeMs:    1_654_000,
+		Runner:    "Bob",
+		NewTimeMs: 1_650_500,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if msg.Subject != "Your Celeste record was beaten" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Body, "Bob beat your 27:34.000 record") || !strings.Contains(msg.Body, "time of 27:30.500") {
+		t.Errorf("expected both times in the body, got %q", msg.Body)
+	}
+}
+
+func TestRender_RejectsMultilineSubject(t *testing.T) {
+	_, err := Render(TemplateWelcome, "jane@example.com", WelcomeData{Name: "Jane\r\nBcc: everyone@example.com"})
+	if err == nil {
+		t.Error("expected a subject with a line break to be rejected")
+	}
+}
+
+func TestRender_UnknownTemplate(t *testing.T) {
+	if _, err := Render("missing", "jane@example.com", nil); err == nil {
+		t.Error("expected an unknown template to be an error")
+	}
+}
+
+func TestFormatRunTime(t *testing.T) {
+	tests := map[int64]string{
+		5843120: "1:37:23.120",
+		245120:  "4:05.120",
+		999:     "0:00.999",
+	}
+	for ms, want := range tests {
+		if got := formatRunTime(ms); got != want {
+			t.Errorf("formatRunTime(%d) = %q, expected %q", ms, got, want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		48 * time.Hour:   "48 hours",
+		time.Hour:        "1 hour",
+		30 * time.Minute: "30 minutes",
+	}
+	for d, want := range tests {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%s) = %q, expected %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"runtime"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// GoCollector reports Go runtime metrics under the names used by the
+// official Prometheus client, so existing dashboards work unchanged
+func GoCollector() Collector {
+	return CollectorFunc(func(w *Writer) {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+
+		w.Family("go_info", "gauge", "Information about the Go environment.")
+		w.Sample("go_info", 1, "version", runtime.Version())
+
+		w.Family("go_goroutines", "gauge", "Number of goroutines that currently exist.")
+		w.Sample("go_goroutines", float64(runtime.NumGoroutine()))
+
+		gauges := []struct {
+			name  string
+			help  string
+			value float64
+		}{
+			{"go_memstats_alloc_bytes", "Number of bytes allocated and still in use.", float64(m.Alloc)},
+			{"go_memstats_sys_bytes", "Number of bytes obtained from system.", float64(m.Sys)},
+			{"go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", float64(m.HeapInuse)},
+			{"go_memstats_heap_objects", "Number of allocated objects.", float64(m.HeapObjects)},
+			{"go_memstats_next_gc_bytes", "Number of heap bytes when next garbage collection will take place.", float64(m.NextGC)},
+			{"go_memstats_last_gc_time_seconds", "Number of seconds since 1970 of last garbage collection.", float64(m.LastGC) / 1e9},
+			{"go_threads", "Number of OS threads created.", float64(threads())},
+		}
+		for _, g := range gauges {
+			w.Family(g.name, "gauge", g.help)
+			w.Sample(g.name, g.value)
+		}
+
+		w.Family("go_memstats_alloc_bytes_total", "counter", "Total number of bytes allocated, even if freed.")
+		w.Sample("go_memstats_alloc_bytes_total", float64(m.TotalAlloc))
+
+		w.Family("go_gc_cycles_total", "counter", "Number of completed GC cycles.")
+		w.Sample("go_gc_cycles_total", float64(m.NumGC))
+
+		w.Family("go_gc_pause_seconds_total", "counter", "Total time the world was stopped for garbage collection.")
+		w.Sample("go_gc_pause_seconds_total", float64(m.PauseTotalNs)/1e9)
+	})
+}
+
+// threads returns the number of OS threads the runtime has created
+func threads() int {
+	n, _ := runtime.ThreadCreateProfile(nil)
+	return n
+}
+
+// PoolStater is implemented by *pgxpool.Pool
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolCollector reports connection pool statistics
+func PoolCollector(pool PoolStater) Collector {
+	return CollectorFunc(func(w *Writer) {
+		s := pool.Stat()
+
+		gauges := []struct {
+			name  string
+			help  string
+			value int32
+		}{
+			{"pgxpool_acquired_conns", "Number of connections currently checked out of the pool.", s.AcquiredConns()},
+			{"pgxpool_idle_conns", "Number of idle connections in the pool.", s.IdleConns()},
+			{"pgxpool_constructing_conns", "Number of connections being opened.", s.ConstructingConns()},
+			{"pgxpool_total_conns", "Number of connections in the pool, in any state.", s.TotalConns()},
+			{"pgxpool_max_conns", "Maximum size of the pool.", s.MaxConns()},
+		}
+		for _, g := range gauges {
+			w.Family(g.name, "gauge", g.help)
+			w.Sample(g.name, float64(g.value))
+		}
+
+		counters := []struct {
+			name  string
+			help  string
+			value float64
+		}{
+			{"pgxpool_acquires_total", "Number of successful connection acquires.", float64(s.AcquireCount())},
+			{"pgxpool_acquire_seconds_total", "Total time spent acquiring connections.", s.AcquireDuration().Seconds()},
+			{"pgxpool_empty_acquires_total", "Number of acquires that had to wait because the pool was empty.", float64(s.EmptyAcquireCount())},
+			{"pgxpool_empty_acquire_wait_seconds_total", "Total time acquires spent waiting on an empty pool.", s.EmptyAcquireWaitTime().Seconds()},
+			{"pgxpool_canceled_acquires_total", "Number of acquires cancelled by their context.", float64(s.CanceledAcquireCount())},
+			{"pgxpool_new_conns_total", "Number of connections opened.", float64(s.NewConnsCount())},
+			{"pgxpool_max_lifetime_destroys_total", "Number of connections closed for exceeding their maximum lifetime.", float64(s.MaxLifetimeDestroyCount())},
+			{"pgxpool_max_idle_destroys_total", "Number of connections closed for exceeding their maximum idle time.", float64(s.MaxIdleDestroyCount())},
+		}
+		for _, c := range counters {
+			w.Family(c.name, "counter", c.help)
+			w.Sample(c.name, c.value)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Package metrics collects counters, gauges, and histograms and exposes them
+// in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the Prometheus text format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Collector writes one or more metric families when scraped
+type Collector interface {
+	Collect(w *Writer)
+}
+
+// CollectorFunc adapts a function to a Collector
+type CollectorFunc func(w *Writer)
+
+// Collect calls f(w)
+func (f CollectorFunc) Collect(w *Writer) {
+	f(w)
+}
+
+// Registry holds the collectors exposed by a scrape
+type Registry struct {
+	mu         sync.Mutex
+	collectors []Collector
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds collectors, which are written in registration order
+func (r *Registry) Register(collectors ...Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collectors...)
+}
+
+// WriteTo writes every registered metric to out
+func (r *Registry) WriteTo(out io.Writer) (int64, error) {
+	r.mu.Lock()
+	collectors := append([]Collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	w := &Writer{w: bufio.NewWriter(out)}
+	for _, c := range collectors {
+		c.Collect(w)
+	}
+	if err := w.w.Flush(); err != nil {
+		return w.n, err
+	}
+	return w.n, nil
+}
+
+// Writer formats samples in the Prometheus text format
+type Writer struct {
+	w *bufio.Writer
+	n int64
+}
+
+// Family starts a metric family; its samples must follow before the next
+// family starts. kind is "counter", "gauge", or "histogram".
+func (w *Writer) Family(name, kind, help string) {
+	w.write("# HELP " + name + " " + escapeHelp(help) + "\n")
+	w.write("# TYPE " + name + " " + kind + "\n")
+}
+
+// Sample writes one sample; labels alternate names and values
+func (w *Writer) Sample(name string, value float64, labels ...string) {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(labels[i])
+			b.WriteString(`="`)
+			b.WriteString(escapeLabel(labels[i+1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(formatValue(value))
+	b.WriteByte('\n')
+	w.write(b.String())
+}
+
+func (w *Writer) write(s string) {
+	n, _ := w.w.WriteString(s)
+	w.n += int64(n)
+}
+
+// formatValue formats a sample value, spelling infinities the way
+// Prometheus expects
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// labelKey joins label values into a map key; values cannot contain \xff
+// once they are valid UTF-8
+func labelKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
+
+// labelPairs interleaves label names and values for Writer.Sample
+func labelPairs(names, values []string, extra ...string) []string {
+	pairs := make([]string, 0, 2*len(names)+len(extra))
+	for i, name := range names {
+		pairs = append(pairs, name, values[i])
+	}
+	return append(pairs, extra...)
+}
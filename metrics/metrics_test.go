@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T, collectors ...Collector) string {
+	t.Helper()
+	r := NewRegistry()
+	r.Register(collectors...)
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return b.String()
+}
+
+func TestCounterVec(t *testing.T) {
+	c := NewCounterVec("http_requests_total", "Requests served.", "route", "status")
+	c.Inc("/users", "200")
+	c.Inc("/users", "200")
+	c.Add(3, `/say "hi"`, "404")
+
+	expected := `# HELP http_requests_total Requests served.
+# TYPE http_requests_total counter
+http_requests_total{route="/say \"hi\"",status="404"} 3
+http_requests_total{route="/users",status="200"} 2
+`
+	if got := scrape(t, c); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	h := NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	h.Observe(0.05, "/a")
+	h.Observe(0.1, "/a")
+	h.Observe(0.5, "/a")
+	h.Observe(2, "/a")
+
+	expected := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/a",le="0.1"} 2
+latency_seconds_bucket{route="/a",le="1"} 3
+latency_seconds_bucket{route="/a",le="+Inf"} 4
+latency_seconds_sum{route="/a"} 2.65
+latency_seconds_count{route="/a"} 4
+`
+	if got := scrape(t, h); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestLabelCountMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	NewCounterVec("c", "", "route").Inc()
+}
+
+func TestGoCollector(t *testing.T) {
+	out := scrape(t, GoCollector())
+
+	for _, want := range []string{"# TYPE go_goroutines gauge\ngo_goroutines ", "go_memstats_alloc_bytes ", "go_info{version=\"go"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// DefaultBuckets are latency buckets in seconds suited to an HTTP API
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// CounterVec is a family of counters partitioned by label values
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*counter
+}
+
+type counter struct {
+	values []string
+	value  float64
+}
+
+// NewCounterVec creates a CounterVec with the given label names
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{name: name, help: help, labels: labels, series: make(map[string]*counter)}
+}
+
+// Inc adds one to the counter with the given label values
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add adds delta, which must not be negative, to the counter with the given
+// label values
+func (c *CounterVec) Add(delta float64, values ...string) {
+	checkLabels(c.name, c.labels, values)
+	key := labelKey(values)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.series[key]
+	if !ok {
+		s = &counter{values: slices.Clone(values)}
+		c.series[key] = s
+	}
+	s.value += delta
+}
+
+// Collect writes every counter in the family
+func (c *CounterVec) Collect(w *Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w.Family(c.name, "counter", c.help)
+	for _, key := range slices.Sorted(maps.Keys(c.series)) {
+		s := c.series[key]
+		w.Sample(c.name, s.value, labelPairs(c.labels, s.values)...)
+	}
+}
+
+// HistogramVec is a family of histograms partitioned by label values
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogram
+}
+
+type histogram struct {
+	values []string
+
+	// counts[i] is the number of observations in bucket i alone; they are
+	// summed when written, as Prometheus buckets are cumulative
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec creates a HistogramVec with the given upper bucket bounds,
+// which must be sorted, and label names
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if !slices.IsSorted(buckets) {
+		panic(fmt.Sprintf("metrics: buckets of %s are not sorted", name))
+	}
+	return &HistogramVec{name: name, help: help, labels: labels, buckets: buckets, series: make(map[string]*histogram)}
+}
+
+// Observe records v in the histogram with the given label values
+func (h *HistogramVec) Observe(v float64, values ...string) {
+	checkLabels(h.name, h.labels, values)
+	key := labelKey(values)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogram{values: slices.Clone(values), counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	if i, _ := slices.BinarySearch(h.buckets, v); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += v
+}
+
+// Collect writes every histogram in the family
+func (h *HistogramVec) Collect(w *Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w.Family(h.name, "histogram", h.help)
+	for _, key := range slices.Sorted(maps.Keys(h.series)) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			w.Sample(h.name+"_bucket", float64(cumulative), labelPairs(h.labels, s.values, "le", formatValue(bound))...)
+		}
+		w.Sample(h.name+"_bucket", float64(s.count), labelPairs(h.labels, s.values, "le", "+Inf")...)
+		w.Sample(h.name+"_sum", s.sum, labelPairs(h.labels, s.values)...)
+		w.Sample(h.name+"_count", float64(s.count), labelPairs(h.labels, s.values)...)
+	}
+}
+
+// checkLabels panics when a metric is used with the wrong number of label
+// values, which is a programming error
+func checkLabels(name string, labels, values []string) {
+	if len(labels) != len(values) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", name, len(labels), len(values)))
+	}
+}
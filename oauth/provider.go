@@ -0,0 +1,186 @@
+// Package oauth implements the OAuth 2.0 authorization code flow against the
+// external providers, such as Twitch and Discord, that speedrunners already
+// have accounts with.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrExchangeFailed is returned when a provider rejects an authorization code
+// or its user API does not identify the account
+var ErrExchangeFailed = errors.New("oauth exchange failed")
+
+// maxResponseBytes bounds how much of a provider response is read
+const maxResponseBytes = 1 << 20
+
+// Identity is the provider account a user authorized
+type Identity struct {
+	// Provider is the name of the provider, e.g. "twitch"
+	Provider string
+
+	// Subject is the provider's stable ID for the account
+	Subject string
+
+	// Name is the account's display name
+	Name string
+
+	// Email is the account's email address, if the provider shared one
+	Email string
+
+	// EmailVerified reports whether the provider has verified Email
+	EmailVerified bool
+}
+
+// Credentials are the client settings registered with a provider
+type Credentials struct {
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the callback URL registered with the provider
+	RedirectURL string
+}
+
+// endpoints locates a provider's OAuth and user APIs
+type endpoints struct {
+	authURL  string
+	tokenURL string
+	userURL  string
+}
+
+// Provider runs the authorization code flow against one provider
+type Provider struct {
+	name      string
+	creds     Credentials
+	scopes    []string
+	endpoints endpoints
+	client    *http.Client
+
+	// userHeaders are extra headers the provider's user API requires
+	userHeaders http.Header
+
+	// decodeUser turns a user API response into an Identity
+	decodeUser func(body []byte) (Identity, error)
+}
+
+// newProvider creates a Provider with a client that times out slow providers
+func newProvider(name string, creds Credentials, scopes []string, e endpoints) *Provider {
+	return &Provider{
+		name:        name,
+		creds:       creds,
+		scopes:      scopes,
+		endpoints:   e,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		userHeaders: http.Header{},
+	}
+}
+
+// Name returns the provider's name as used in URLs, e.g. "twitch"
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL returns the provider URL to send the user to; state is echoed
+// back to the callback and must be checked there
+func (p *Provider) AuthCodeURL(state string) string {
+	query := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.creds.ClientID},
+		"redirect_uri":  {p.creds.RedirectURL},
+		"scope":         {strings.Join(p.scopes, " ")},
+		"state":         {state},
+	}
+	return p.endpoints.authURL + "?" + query.Encode()
+}
+
+// Identify exchanges the authorization code from the callback for an access
+// token and looks up the account it belongs to
+func (p *Provider) Identify(ctx context.Context, code string) (Identity, error) {
+	accessToken, err := p.exchange(ctx, code)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoints.userURL, nil)
+	if err != nil {
+		return Identity{}, err
+	}
+	for key, values := range p.userHeaders {
+		req.Header[key] = values
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	body, err := p.do(req)
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to get %s user: %w", p.name, err)
+	}
+
+	identity, err := p.decodeUser(body)
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to decode %s user: %w", p.name, err)
+	}
+	if identity.Subject == "" {
+		return Identity{}, fmt.Errorf("%w: %s user has no id", ErrExchangeFailed, p.name)
+	}
+	identity.Provider = p.name
+	return identity, nil
+}
+
+// exchange trades an authorization code for an access token
+func (p *Provider) exchange(ctx context.Context, code string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.creds.RedirectURL},
+		"client_id":     {p.creds.ClientID},
+		"client_secret": {p.creds.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoints.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := p.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange %s code: %w", p.name, err)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("%w: %s returned no access token", ErrExchangeFailed, p.name)
+	}
+	return token.AccessToken, nil
+}
+
+// do sends a request expecting a JSON response and returns its body
+// A non-2xx status is reported as ErrExchangeFailed; the provider's message
+// is not included since it may echo the code or token back.
+func (p *Provider) do(req *http.Request) ([]byte, error) {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: status %d", ErrExchangeFailed, resp.StatusCode)
+	}
+	return body, nil
+}
@@ -0,0 +1,115 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// fakeProvider serves a token endpoint that accepts only code "good" and a
+// user endpoint that requires the token it issued
+func fakeProvider(t *testing.T, user string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "good" || r.FormValue("client_secret") != "secret" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"access_token":"provider-token","token_type":"bearer"}`))
+	})
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer provider-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Client-Id") != "" && r.Header.Get("Client-Id") != "client" {
+			http.Error(w, "wrong client", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(user))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// pointAt redirects a provider's token and user APIs to srv
+func pointAt(p *Provider, srv *httptest.Server) *Provider {
+	p.endpoints.tokenURL = srv.URL + "/token"
+	p.endpoints.userURL = srv.URL + "/user"
+	return p
+}
+
+var testCreds = Credentials{ClientID: "client", ClientSecret: "secret", RedirectURL: "https://api.example.com/auth/oauth/discord/callback"}
+
+func TestAuthCodeURL(t *testing.T) {
+	raw := Discord(testCreds).AuthCodeURL("xyz")
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("expected a valid URL, got %v", err)
+	}
+	q := u.Query()
+	if q.Get("state") != "xyz" || q.Get("client_id") != "client" || q.Get("response_type") != "code" {
+		t.Errorf("unexpected query %v", q)
+	}
+	if q.Get("redirect_uri") != testCreds.RedirectURL || q.Get("scope") != "identify email" {
+		t.Errorf("unexpected redirect or scope in %v", q)
+	}
+}
+
+func TestIdentify_Discord(t *testing.T) {
+	srv := fakeProvider(t, `{"id":"80351110224678912","username":"nelly","global_name":"Nelly","email":"nelly@example.com","verified":true}`)
+	p := pointAt(Discord(testCreds), srv)
+
+	identity, err := p.Identify(context.Background(), "good")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := Identity{Provider: "discord", Subject: "80351110224678912", Name: "Nelly", Email: "nelly@example.com", EmailVerified: true}
+	if identity != expected {
+		t.Errorf("expected %+v, got %+v", expected, identity)
+	}
+}
+
+func TestIdentify_Twitch(t *testing.T) {
+	srv := fakeProvider(t, `{"data":[{"id":"141981764","login":"twitchdev","display_name":"TwitchDev","email":"dev@example.com"}]}`)
+	p := pointAt(Twitch(testCreds), srv)
+
+	identity, err := p.Identify(context.Background(), "good")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := Identity{Provider: "twitch", Subject: "141981764", Name: "TwitchDev", Email: "dev@example.com", EmailVerified: true}
+	if identity != expected {
+		t.Errorf("expected %+v, got %+v", expected, identity)
+	}
+}
+
+func TestIdentify_RejectedCode(t *testing.T) {
+	srv := fakeProvider(t, `{}`)
+	p := pointAt(Discord(testCreds), srv)
+
+	_, err := p.Identify(context.Background(), "bad")
+
+	if !errors.Is(err, ErrExchangeFailed) {
+		t.Errorf("expected ErrExchangeFailed, got %v", err)
+	}
+}
+
+func TestIdentify_MissingUser(t *testing.T) {
+	srv := fakeProvider(t, `{"data":[]}`)
+	p := pointAt(Twitch(testCreds), srv)
+
+	_, err := p.Identify(context.Background(), "good")
+
+	if !errors.Is(err, ErrExchangeFailed) {
+		t.Errorf("expected ErrExchangeFailed, got %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+// Package operations runs the long-running tasks clients start through the
+// API, such as bulk imports and exports, in the background, recording their
+// progress and outcome for the clients polling them.
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Operation states stored in operations.status
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+const (
+	// leaseDuration is how long a claimed operation is skipped by other
+	// workers; the worker running it extends the lease every
+	// heartbeatInterval, so it only runs out when the worker has died
+	leaseDuration = time.Minute
+
+	// heartbeatInterval is how often a running operation's progress is
+	// recorded and its lease extended
+	heartbeatInterval = 5 * time.Second
+
+	// maxAttempts is how many workers may claim an operation before it is
+	// failed; an operation that keeps killing its worker would otherwise
+	// be picked up forever
+	maxAttempts = 3
+
+	// batchSize is how many operations are claimed, and run side by side,
+	// at a time
+	batchSize = 4
+)
+
+// Store is the part of db.Querier the worker needs
+type Store interface {
+	ClaimOperations(ctx context.Context, arg db.ClaimOperationsParams) ([]db.Operation, error)
+	UpdateOperationProgress(ctx context.Context, arg db.UpdateOperationProgressParams) error
+	RequeueOperation(ctx context.Context, id pgtype.UUID) error
+	FinishOperation(ctx context.Context, arg db.FinishOperationParams) error
+	SaveOperationOutput(ctx context.Context, arg db.SaveOperationOutputParams) error
+}
+
+// Task runs one kind of operation, reporting on its way through progress,
+// and returns what it produced
+// ctx is cancelled when the worker stops; the operation is then handed back
+// to be run again from the start, by this worker or another, so a task must
+// leave nothing half done when it returns early.
+type Task func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error)
+
+// Result is what a succeeded operation produced
+type Result struct {
+	// Value is stored, as JSON, as the operation's result
+	Value any
+
+	// Output is a file made for the client to download, or nil when the
+	// operation makes none
+	Output *Output
+}
+
+// Output is a file made by an operation
+type Output struct {
+	ContentType string
+	Filename    string
+	Data        []byte
+}
+
+// Error fails an operation with a message for the client polling it
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Fail returns an Error with the formatted message
+func Fail(format string, args ...any) error {
+	return &Error{Message: fmt.Sprintf(format, args...)}
+}
+
+// Progress is how far a running operation has got
+// It is safe for concurrent use; the worker records it periodically rather
+// than on every change.
+type Progress struct {
+	mu        sync.Mutex
+	completed int
+	total     int
+	known     bool
+}
+
+// SetTotal records how many items the operation has in all
+func (p *Progress) SetTotal(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total, p.known = total, true
+}
+
+// Add records that n more items are done
+func (p *Progress) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed += n
+}
+
+// values returns the progress as it is stored
+func (p *Progress) values() (int32, pgtype.Int4) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return int32(p.completed), pgtype.Int4{Int32: int32(p.total), Valid: p.known}
+}
+
+// Worker periodically runs the operations that are waiting, with the task
+// for each one's kind
+//
+// Any number of workers may share a database: each operation is leased to
+// one of them while it runs.
+type Worker struct {
+	store    Store
+	tasks    map[string]Task
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewWorker creates a Worker that every interval looks for operations to run
+// with tasks, keyed by the kinds of operation they run
+func NewWorker(store Store, interval time.Duration, tasks map[string]Task) *Worker {
+	return &Worker{store: store, tasks: tasks, interval: interval, now: time.Now}
+}
+
+// Run runs waiting operations on every tick until ctx is cancelled
+// It returns only after the operations it was running have stopped, so
+// callers can wait for Run to return before closing the database.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.work(ctx)
+		}
+	}
+}
+
+// work runs batches of waiting operations until none are left or ctx is
+// cancelled
+func (w *Worker) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		ops, err := w.store.ClaimOperations(ctx, db.ClaimOperationsParams{
+			LeaseUntil: w.leaseUntil(),
+			BatchSize:  batchSize,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to claim operations", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, op := range ops {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.run(ctx, op)
+			}()
+		}
+		wg.Wait()
+
+		if len(ops) < batchSize {
+			return
+		}
+	}
+}
+
+// run runs one claimed operation and records its outcome, or hands it back
+// when ctx is cancelled before it finishes
+func (w *Worker) run(ctx context.Context, op db.Operation) {
+	// The outcome is recorded even once ctx is cancelled
+	recordCtx := context.WithoutCancel(ctx)
+	log := slog.With("operation_id", op.ID.String(), "kind", op.Kind)
+
+	progress := &Progress{completed: int(op.Completed), total: int(op.Total.Int32), known: op.Total.Valid}
+	task, ok := w.tasks[op.Kind]
+	switch {
+	case !ok:
+		w.finish(recordCtx, op, progress, nil, Fail("Unknown kind of operation %q", op.Kind))
+		return
+	case op.Attempts > maxAttempts:
+		w.finish(recordCtx, op, progress, nil, Fail("Gave up after %d attempts", maxAttempts))
+		return
+	}
+
+	taskCtx, stop := context.WithCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		w.heartbeat(taskCtx, op.ID, progress)
+	}()
+	result, err := runTask(taskCtx, task, op, progress)
+	stop()
+	<-heartbeatDone
+
+	if ctx.Err() != nil {
+		log.InfoContext(recordCtx, "Handing back an operation the worker is stopping before it finished")
+		if err := w.store.RequeueOperation(recordCtx, op.ID); err != nil {
+			log.ErrorContext(recordCtx, "Failed to hand back operation; it runs again once its lease runs out", "error", err)
+		}
+		return
+	}
+	w.finish(recordCtx, op, progress, result, err)
+}
+
+// runTask runs task, turning a panic into an error so the operation is
+// failed rather than the worker killed
+func runTask(ctx context.Context, task Task, op db.Operation, progress *Progress) (result *Result, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("operation panicked: %v", v)
+		}
+	}()
+	return task(ctx, op, progress)
+}
+
+// heartbeat records progress and extends the lease of the operation with id
+// every heartbeatInterval until ctx is cancelled
+func (w *Worker) heartbeat(ctx context.Context, id pgtype.UUID, progress *Progress) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			completed, total := progress.values()
+			err := w.store.UpdateOperationProgress(ctx, db.UpdateOperationProgressParams{
+				ID:         id,
+				Completed:  completed,
+				Total:      total,
+				LeaseUntil: w.leaseUntil(),
+			})
+			if err != nil && ctx.Err() == nil {
+				slog.WarnContext(ctx, "Failed to record operation progress", "operation_id", id.String(), "error", err)
+			}
+		}
+	}
+}
+
+// finish records the outcome of an operation: result when err is nil, and
+// otherwise err's message when it is an Error or a generic one, logging err,
+// when it isn't
+func (w *Worker) finish(ctx context.Context, op db.Operation, progress *Progress, result *Result, err error) {
+	log := slog.With("operation_id", op.ID.String(), "kind", op.Kind)
+	completed, total := progress.values()
+	update := db.FinishOperationParams{ID: op.ID, Status: StatusSucceeded, Completed: completed, Total: total}
+
+	if err == nil {
+		err = w.save(ctx, op, result, &update)
+	}
+	if err != nil {
+		var failure *Error
+		if !errors.As(err, &failure) {
+			log.ErrorContext(ctx, "Operation failed", "error", err)
+			failure = &Error{Message: "Internal error"}
+		}
+		update.Status = StatusFailed
+		update.Result = nil
+		update.Error = pgtype.Text{String: failure.Message, Valid: true}
+	}
+
+	if err := w.store.FinishOperation(ctx, update); err != nil {
+		log.ErrorContext(ctx, "Failed to record operation outcome; it runs again once its lease runs out", "error", err)
+	}
+}
+
+// save stores the output of a succeeded operation and adds its result to
+// update
+func (w *Worker) save(ctx context.Context, op db.Operation, result *Result, update *db.FinishOperationParams) error {
+	if result == nil {
+		return nil
+	}
+	if result.Output != nil {
+		err := w.store.SaveOperationOutput(ctx, db.SaveOperationOutputParams{
+			OperationID: op.ID,
+			ContentType: result.Output.ContentType,
+			Filename:    result.Output.Filename,
+			Data:        result.Output.Data,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save output: %w", err)
+		}
+	}
+	if result.Value != nil {
+		value, err := json.Marshal(result.Value)
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		update.Result = value
+	}
+	return nil
+}
+
+// leaseUntil is when a lease taken now runs out
+func (w *Worker) leaseUntil() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: w.now().Add(leaseDuration), Valid: true}
+}
@@ -0,0 +1,191 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeStore hands out the operations it holds once and records what becomes
+// of each; operations run side by side, so it is locked
+type fakeStore struct {
+	mu       sync.Mutex
+	ops      []db.Operation
+	claims   []db.ClaimOperationsParams
+	finished map[pgtype.UUID]db.FinishOperationParams
+	outputs  map[pgtype.UUID]db.SaveOperationOutputParams
+	requeued []pgtype.UUID
+}
+
+func (s *fakeStore) ClaimOperations(ctx context.Context, arg db.ClaimOperationsParams) ([]db.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.claims = append(s.claims, arg)
+	n := min(int(arg.BatchSize), len(s.ops))
+	claimed := s.ops[:n]
+	s.ops = s.ops[n:]
+	return claimed, nil
+}
+
+func (s *fakeStore) UpdateOperationProgress(ctx context.Context, arg db.UpdateOperationProgressParams) error {
+	return nil
+}
+
+func (s *fakeStore) RequeueOperation(ctx context.Context, id pgtype.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requeued = append(s.requeued, id)
+	return nil
+}
+
+func (s *fakeStore) FinishOperation(ctx context.Context, arg db.FinishOperationParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished == nil {
+		s.finished = map[pgtype.UUID]db.FinishOperationParams{}
+	}
+	s.finished[arg.ID] = arg
+	return nil
+}
+
+func (s *fakeStore) SaveOperationOutput(ctx context.Context, arg db.SaveOperationOutputParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.outputs == nil {
+		s.outputs = map[pgtype.UUID]db.SaveOperationOutputParams{}
+	}
+	s.outputs[arg.OperationID] = arg
+	return nil
+}
+
+// operation returns a claimed operation of kind with an ID made from n
+func operation(n byte, kind string) db.Operation {
+	return db.Operation{ID: pgtype.UUID{Bytes: [16]byte{n}, Valid: true}, Kind: kind, Status: StatusRunning, Attempts: 1}
+}
+
+func TestWork_RecordsResultsAndOutputs(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	export, count := operation(1, "export"), operation(2, "count")
+	store := &fakeStore{ops: []db.Operation{export, count}}
+	w := NewWorker(store, time.Second, map[string]Task{
+		"export": func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+			progress.Add(2)
+			progress.SetTotal(2)
+			return &Result{
+				Value:  map[string]int{"rows": 2},
+				Output: &Output{ContentType: "text/csv", Filename: "users.csv", Data: []byte("id\n1\n2\n")},
+			}, nil
+		},
+		"count": func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+			progress.Add(1)
+			return &Result{Value: 1}, nil
+		},
+	})
+	w.now = func() time.Time { return now }
+	w.work(context.Background())
+
+	if len(store.claims) != 1 || !store.claims[0].LeaseUntil.Time.Equal(now.Add(leaseDuration)) {
+		t.Errorf("expected one claim leased from now, got %+v", store.claims)
+	}
+	finished := store.finished[export.ID]
+	if finished.Status != StatusSucceeded || string(finished.Result) != `{"rows":2}` || finished.Error.Valid {
+		t.Errorf("expected the export to succeed with its result, got %+v", finished)
+	}
+	if finished.Completed != 2 || !finished.Total.Valid || finished.Total.Int32 != 2 {
+		t.Errorf("expected the export's progress to be recorded, got %d of %+v", finished.Completed, finished.Total)
+	}
+	output := store.outputs[export.ID]
+	if output.Filename != "users.csv" || output.ContentType != "text/csv" || string(output.Data) != "id\n1\n2\n" {
+		t.Errorf("expected the export's file to be saved, got %+v", output)
+	}
+
+	finished = store.finished[count.ID]
+	if finished.Status != StatusSucceeded || string(finished.Result) != "1" || finished.Total.Valid {
+		t.Errorf("expected the count to succeed with an unknown total, got %+v", finished)
+	}
+	if _, ok := store.outputs[count.ID]; ok {
+		t.Error("expected no file for an operation that makes none")
+	}
+}
+
+func TestWork_Fails(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       db.Operation
+		task     Task
+		expected string
+	}{
+		{
+			"error", operation(1, "task"),
+			func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+				return nil, Fail("Row %d is invalid", 3)
+			},
+			"Row 3 is invalid",
+		},
+		{
+			"internal error", operation(1, "task"),
+			func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+				return nil, errors.New("connection reset")
+			},
+			"Internal error",
+		},
+		{
+			"panic", operation(1, "task"),
+			func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+				panic("nil map")
+			},
+			"Internal error",
+		},
+		{
+			"unknown kind", operation(1, "sync"),
+			func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+				t.Error("expected no task to run")
+				return nil, nil
+			},
+			`Unknown kind of operation "sync"`,
+		},
+		{
+			"too many attempts", db.Operation{ID: pgtype.UUID{Valid: true}, Kind: "task", Attempts: maxAttempts + 1},
+			func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+				t.Error("expected no task to run")
+				return nil, nil
+			},
+			"Gave up after 3 attempts",
+		},
+	}
+	for _, tt := range tests {
+		store := &fakeStore{ops: []db.Operation{tt.op}}
+		NewWorker(store, time.Second, map[string]Task{"task": tt.task}).work(context.Background())
+
+		finished, ok := store.finished[tt.op.ID]
+		if !ok || finished.Status != StatusFailed || finished.Error.String != tt.expected || finished.Result != nil {
+			t.Errorf("%s: expected the operation to fail with %q, got %+v", tt.name, tt.expected, finished)
+		}
+	}
+}
+
+func TestWork_HandsBackOperationsWhenStopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	op := operation(1, "task")
+	store := &fakeStore{ops: []db.Operation{op}}
+	w := NewWorker(store, time.Second, map[string]Task{
+		"task": func(ctx context.Context, op db.Operation, progress *Progress) (*Result, error) {
+			cancel()
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	w.work(ctx)
+
+	if len(store.requeued) != 1 || store.requeued[0] != op.ID {
+		t.Errorf("expected the operation to be handed back, got %v", store.requeued)
+	}
+	if _, ok := store.finished[op.ID]; ok {
+		t.Error("expected an operation that was stopped not to be finished")
+	}
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// FeedStore is the part of db.Querier the feed sink needs
+type FeedStore interface {
+	GetCategoryByID(ctx context.Context, id int32) (db.Category, error)
+	CreateFeedItems(ctx context.Context, arg db.CreateFeedItemsParams) error
+}
+
+// FeedSink copies the events that appear in activity feeds into the feed of
+// every user following the game or runner they are about, so feeds are read
+// without joining over follows
+type FeedSink struct {
+	store FeedStore
+}
+
+// feedRun is the part of a run in an event the feed sink needs
+type feedRun struct {
+	UserID     int32 `json:"user_id"`
+	CategoryID int32 `json:"category_id"`
+}
+
+// NewFeedSink creates a FeedSink that stores feed items in store
+func NewFeedSink(store FeedStore) *FeedSink {
+	return &FeedSink{store: store}
+}
+
+// Name implements Sink
+func (s *FeedSink) Name() string {
+	return "feed"
+}
+
+// Publish implements Sink; events that don't appear in feeds are ignored, as
+// are runs in categories that have since been deleted
+func (s *FeedSink) Publish(ctx context.Context, e Event) error {
+	item := db.CreateFeedItemsParams{
+		EventID:    e.ID,
+		Event:      e.Name,
+		Payload:    e.Data,
+		OccurredAt: pgtype.Timestamptz{Time: e.OccurredAt, Valid: true},
+	}
+
+	var run feedRun
+	switch e.Name {
+	case "run.verified":
+		if err := json.Unmarshal(e.Data, &run); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+	case "record.broken":
+		var record struct {
+			Run feedRun `json:"run"`
+		}
+		if err := json.Unmarshal(e.Data, &record); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+		run = record.Run
+	case "category.created":
+		var category struct {
+			GameID int32 `json:"game_id"`
+		}
+		if err := json.Unmarshal(e.Data, &category); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+		item.GameID = category.GameID
+	default:
+		return nil
+	}
+
+	if run.CategoryID != 0 {
+		category, err := s.store.GetCategoryByID(ctx, run.CategoryID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return fmt.Errorf("failed to get category: %w", err)
+		}
+		item.GameID = category.GameID
+		item.ActorID = pgtype.Int4{Int32: run.UserID, Valid: true}
+	}
+
+	if err := s.store.CreateFeedItems(ctx, item); err != nil {
+		return fmt.Errorf("failed to add feed items: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink publishes events to a Kafka topic
+//
+// Messages are keyed by event name, so events of one kind stay in order, and
+// carry the event's name and ID in the "event" and "event-id" headers.
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink creates a KafkaSink that writes to topic on brokers
+// No connection is made until the first event is published; the topic must
+// already exist.
+func NewKafkaSink(brokers []string, topic string) *KafkaSink {
+	return &KafkaSink{writer: &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Topic:    topic,
+		Balancer: &kafka.Hash{},
+		// Wait for every in-sync replica, so an accepted event survives the
+		// loss of a broker
+		RequiredAcks: kafka.RequireAll,
+		// Events are written one at a time, so don't wait for a batch to fill
+		BatchTimeout: 10 * time.Millisecond,
+	}}
+}
+
+// Name implements Sink
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+// Publish implements Sink
+func (s *KafkaSink) Publish(ctx context.Context, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	err = s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(e.Name),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "event", Value: []byte(e.Name)},
+			{Key: "event-id", Value: []byte(strconv.Itoa(int(e.ID)))},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %w", s.writer.Topic, err)
+	}
+	return nil
+}
+
+// Close flushes pending writes and closes the connections to Kafka
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
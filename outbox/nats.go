@@ -0,0 +1,68 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/example/speedrun-rest-api/version"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSSink publishes events to NATS JetStream, on the subject
+// "<prefix>.<event>", e.g. "speedrun.run.verified"
+//
+// A stream must capture the subjects: an event is only published once a
+// stream has stored it. Each message carries the event's ID as Nats-Msg-Id,
+// so the stream drops duplicates within its deduplication window.
+type NATSSink struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	prefix string
+}
+
+// NewNATSSink connects to the NATS server at url
+// The connection is retried in the background if the server is unreachable,
+// so events wait in the outbox rather than keeping the API from starting.
+func NewNATSSink(url, prefix string) (*NATSSink, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("speedrun-rest-api/"+version.Version),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	return &NATSSink{conn: conn, js: js, prefix: prefix}, nil
+}
+
+// Name implements Sink
+func (s *NATSSink) Name() string {
+	return "nats"
+}
+
+// Publish implements Sink
+func (s *NATSSink) Publish(ctx context.Context, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	msg := &nats.Msg{Subject: s.prefix + "." + e.Name, Data: payload}
+	if _, err := s.js.PublishMsg(ctx, msg, jetstream.WithMsgID(strconv.Itoa(int(e.ID)))); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", msg.Subject, err)
+	}
+	return nil
+}
+
+// Close closes the connection to NATS
+func (s *NATSSink) Close() error {
+	s.conn.Close()
+	return nil
+}
@@ -0,0 +1,280 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/mailer"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NotificationStore is the part of db.Querier the notification sink needs
+type NotificationStore interface {
+	PreferenceStore
+	GetRunSummary(ctx context.Context, id int32) (db.GetRunSummaryRow, error)
+	GetUserByID(ctx context.Context, id int32) (db.User, error)
+	CreateNotification(ctx context.Context, arg db.CreateNotificationParams) error
+}
+
+// PreferenceStore is the part of db.Querier that finds who an event is about
+// and which channels they want to be told through
+type PreferenceStore interface {
+	GetRunByID(ctx context.Context, id int32) (db.Run, error)
+	GetNotificationPreference(ctx context.Context, arg db.GetNotificationPreferenceParams) (db.NotificationPreference, error)
+}
+
+// NotificationSink notifies users of events about their runs: a run being
+// verified or rejected, a comment on it, and a record they held being beaten
+//
+// Each user chooses per event whether they are notified in-app, by email, or
+// both; both are on until they turn them off.
+type NotificationSink struct {
+	store  NotificationStore
+	mailer mailer.Mailer
+}
+
+// notifiedRun is the part of a run in an event the notification sink reads
+type notifiedRun struct {
+	ID              int32  `json:"id"`
+	UserID          int32  `json:"user_id"`
+	TimeMs          int64  `json:"time_ms"`
+	RejectionReason string `json:"rejection_reason"`
+}
+
+// notifiedRecord is the part of a record.broken event the notification sink
+// reads
+type notifiedRecord struct {
+	Run            notifiedRun `json:"run"`
+	PreviousRecord notifiedRun `json:"previous_record"`
+}
+
+// notifiedComment is the part of a comment.created event the notification
+// sink reads
+type notifiedComment struct {
+	RunID  int32  `json:"run_id"`
+	UserID int32  `json:"user_id"`
+	Body   string `json:"body"`
+}
+
+// NewNotificationSink creates a NotificationSink that stores notifications in
+// store and emails them through m; with a nil m no emails are sent
+func NewNotificationSink(store NotificationStore, m mailer.Mailer) *NotificationSink {
+	return &NotificationSink{store: store, mailer: m}
+}
+
+// Name implements Sink
+func (s *NotificationSink) Name() string {
+	return "notifications"
+}
+
+// Publish implements Sink; events no one is notified of are ignored, and
+// nobody is notified of their own actions
+//
+// Emails are best effort: a failure to send one is logged rather than
+// returned, since returning it would hold every later event back from the
+// sink.
+func (s *NotificationSink) Publish(ctx context.Context, e Event) error {
+	recipient, err := notifiedUser(ctx, s.store, e)
+	if err != nil {
+		return err
+	}
+	if recipient == 0 {
+		return nil
+	}
+	preference, err := notificationPreference(ctx, s.store, recipient, e.Name)
+	if err != nil {
+		return err
+	}
+
+	if preference.InApp {
+		err = s.store.CreateNotification(ctx, db.CreateNotificationParams{
+			EventID:    e.ID,
+			Event:      e.Name,
+			Payload:    e.Data,
+			OccurredAt: pgtype.Timestamptz{Time: e.OccurredAt, Valid: true},
+			UserID:     recipient,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store notification: %w", err)
+		}
+	}
+	if preference.Email && s.mailer != nil {
+		s.email(ctx, e)
+	}
+	return nil
+}
+
+// email sends the user notified of e an email about it
+func (s *NotificationSink) email(ctx context.Context, e Event) {
+	msg, ok, err := s.renderEmail(ctx, e)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to prepare notification email", "event_id", e.ID, "event", e.Name, "error", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		slog.ErrorContext(ctx, "Failed to send notification email", "event_id", e.ID, "event", e.Name, "error", err)
+	}
+}
+
+// renderEmail renders the email about e to the user notified of it; ok is
+// false when there is no one to email because an account the email names
+// has been deleted
+func (s *NotificationSink) renderEmail(ctx context.Context, e Event) (msg mailer.Message, ok bool, err error) {
+	var (
+		runID int32
+		name  string
+		data  func(summary db.GetRunSummaryRow) any
+	)
+	switch e.Name {
+	case "run.verified", "run.rejected":
+		var run notifiedRun
+		if err := json.Unmarshal(e.Data, &run); err != nil {
+			return msg, false, fmt.Errorf("failed to decode event: %w", err)
+		}
+		runID = run.ID
+		if e.Name == "run.verified" {
+			name = mailer.TemplateRunVerified
+			data = func(summary db.GetRunSummaryRow) any {
+				return mailer.RunVerifiedData{
+					Name:     summary.UserName,
+					Game:     summary.GameName,
+					Category: summary.CategoryName,
+					TimeMs:   run.TimeMs,
+				}
+			}
+		} else {
+			name = mailer.TemplateRunRejected
+			data = func(summary db.GetRunSummaryRow) any {
+				return mailer.RunRejectedData{
+					Name:     summary.UserName,
+					Game:     summary.GameName,
+					Category: summary.CategoryName,
+					TimeMs:   run.TimeMs,
+					Reason:   run.RejectionReason,
+				}
+			}
+		}
+	case "comment.created":
+		var comment notifiedComment
+		if err := json.Unmarshal(e.Data, &comment); err != nil {
+			return msg, false, fmt.Errorf("failed to decode event: %w", err)
+		}
+		commenter, err := s.store.GetUserByID(ctx, comment.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return msg, false, nil
+			}
+			return msg, false, fmt.Errorf("failed to get commenter: %w", err)
+		}
+		runID, name = comment.RunID, mailer.TemplateCommentCreated
+		data = func(summary db.GetRunSummaryRow) any {
+			return mailer.CommentCreatedData{
+				Name:      summary.UserName,
+				Game:      summary.GameName,
+				Category:  summary.CategoryName,
+				Commenter: commenter.Name,
+				Body:      comment.Body,
+			}
+		}
+	case "record.broken":
+		var record notifiedRecord
+		if err := json.Unmarshal(e.Data, &record); err != nil {
+			return msg, false, fmt.Errorf("failed to decode event: %w", err)
+		}
+		runner, err := s.store.GetUserByID(ctx, record.Run.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return msg, false, nil
+			}
+			return msg, false, fmt.Errorf("failed to get runner: %w", err)
+		}
+		runID, name = record.PreviousRecord.ID, mailer.TemplateRecordBroken
+		data = func(summary db.GetRunSummaryRow) any {
+			return mailer.RecordBrokenData{
+				Name:      summary.UserName,
+				Game:      summary.GameName,
+				Category:  summary.CategoryName,
+				TimeMs:    record.PreviousRecord.TimeMs,
+				Runner:    runner.Name,
+				NewTimeMs: record.Run.TimeMs,
+			}
+		}
+	default:
+		return msg, false, nil
+	}
+
+	// The summary names the owner of runID, who is always the user notified
+	summary, err := s.store.GetRunSummary(ctx, runID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return msg, false, nil
+		}
+		return msg, false, fmt.Errorf("failed to get run: %w", err)
+	}
+	msg, err = mailer.Render(name, summary.Email, data(summary))
+	if err != nil {
+		return msg, false, err
+	}
+	return msg, true, nil
+}
+
+// notifiedUser returns the user e is about and who is notified of it, or 0
+// when e notifies no one
+func notifiedUser(ctx context.Context, store PreferenceStore, e Event) (int32, error) {
+	switch e.Name {
+	case "run.verified", "run.rejected":
+		var run notifiedRun
+		if err := json.Unmarshal(e.Data, &run); err != nil {
+			return 0, fmt.Errorf("failed to decode event: %w", err)
+		}
+		return run.UserID, nil
+	case "record.broken":
+		var record notifiedRecord
+		if err := json.Unmarshal(e.Data, &record); err != nil {
+			return 0, fmt.Errorf("failed to decode event: %w", err)
+		}
+		if record.PreviousRecord.UserID == record.Run.UserID {
+			return 0, nil
+		}
+		return record.PreviousRecord.UserID, nil
+	case "comment.created":
+		var comment notifiedComment
+		if err := json.Unmarshal(e.Data, &comment); err != nil {
+			return 0, fmt.Errorf("failed to decode event: %w", err)
+		}
+		run, err := store.GetRunByID(ctx, comment.RunID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, nil
+			}
+			return 0, fmt.Errorf("failed to get run: %w", err)
+		}
+		if run.UserID == comment.UserID {
+			return 0, nil
+		}
+		return run.UserID, nil
+	default:
+		return 0, nil
+	}
+}
+
+// notificationPreference returns the channels userID wants to be told about
+// event through, with every channel on if they never changed them
+func notificationPreference(ctx context.Context, store PreferenceStore, userID int32, event string) (db.NotificationPreference, error) {
+	preference, err := store.GetNotificationPreference(ctx, db.GetNotificationPreferenceParams{UserID: userID, Event: event})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.NotificationPreference{UserID: userID, Event: event, Email: true, InApp: true, Webhooks: true}, nil
+		}
+		return db.NotificationPreference{}, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+	return preference, nil
+}
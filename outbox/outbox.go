@@ -0,0 +1,212 @@
+// Package outbox publishes the domain events services write to the
+// outbox_events table, in the same transaction as the change they describe,
+// to every configured sink, so side effects survive crashes and never run
+// for changes that were rolled back.
+//
+// Each event is published at least once to every sink; sinks and their
+// consumers should use Event.ID to drop the duplicates a crash or a failing
+// sink can cause.
+package outbox
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// publishTimeout bounds each attempt at publishing an event to a sink
+	publishTimeout = 10 * time.Second
+
+	// leaseDuration is how long a claimed batch is skipped by other
+	// dispatchers; it outlasts publishing a batch unless sinks are slow
+	// without failing, in which case the events may be published twice
+	leaseDuration = 2 * time.Minute
+
+	// retryBackoff is the wait before an event a sink failed to take is
+	// first tried again; it doubles with every further attempt
+	retryBackoff = 5 * time.Second
+
+	// maxRetryDelay caps the backoff, so events reach a sink soon after it
+	// recovers however long it was down
+	maxRetryDelay = 5 * time.Minute
+
+	// batchSize is how many events are claimed at a time
+	batchSize = 50
+)
+
+// Event is a domain event as sinks receive it
+type Event struct {
+	// ID is unique to the event and the same each time it is published
+	ID int32 `json:"id"`
+
+	// Name says what happened, e.g. "run.verified"
+	Name string `json:"event"`
+
+	// OccurredAt is when the change the event describes was committed
+	OccurredAt time.Time `json:"occurred_at"`
+
+	// Data describes what changed, e.g. the verified run
+	Data json.RawMessage `json:"data"`
+}
+
+// Sink is somewhere events are published to
+type Sink interface {
+	// Name identifies the sink in the outbox; renaming a sink publishes
+	// every unpublished event to it again
+	Name() string
+
+	// Publish delivers e, returning once the sink has durably accepted it
+	Publish(ctx context.Context, e Event) error
+}
+
+// Store is the part of db.Querier the dispatcher needs
+type Store interface {
+	ClaimOutboxEvents(ctx context.Context, arg db.ClaimOutboxEventsParams) ([]db.OutboxEvent, error)
+	UpdateOutboxEvent(ctx context.Context, arg db.UpdateOutboxEventParams) error
+}
+
+// Dispatcher periodically publishes unpublished events to its sinks
+//
+// Any number of dispatchers may share a database: each event is leased to
+// one of them while it is being published.
+type Dispatcher struct {
+	store    Store
+	sinks    []Sink
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewDispatcher creates a Dispatcher that every interval publishes the
+// events that are due to each of sinks
+func NewDispatcher(store Store, interval time.Duration, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		store:    store,
+		sinks:    sinks,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run publishes due events on every tick until ctx is cancelled
+// It returns only after the event being published has been recorded, so
+// callers can wait for Run to return before closing the database.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.dispatch(ctx)
+		}
+	}
+}
+
+// dispatch publishes batches of due events until none are left or ctx is
+// cancelled
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		events, err := d.store.ClaimOutboxEvents(ctx, db.ClaimOutboxEventsParams{
+			LeaseUntil: pgtype.Timestamptz{Time: d.now().Add(leaseDuration), Valid: true},
+			BatchSize:  batchSize,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to claim outbox events", "error", err)
+			return
+		}
+
+		// Events are published oldest first, and once a sink fails the rest
+		// of the batch is held back from it, so it doesn't receive them out
+		// of order or spend a timeout on each
+		slices.SortFunc(events, func(a, b db.OutboxEvent) int { return cmp.Compare(a.ID, b.ID) })
+		failed := map[string]error{}
+		for _, event := range events {
+			d.publish(ctx, event, failed)
+		}
+
+		if len(events) < batchSize {
+			return
+		}
+	}
+}
+
+// publish sends an event to each sink that doesn't have it yet and records
+// which ones took it; sinks in failed are skipped, and sinks that fail are
+// added to it
+func (d *Dispatcher) publish(ctx context.Context, event db.OutboxEvent, failed map[string]error) {
+	e := Event{
+		ID:         event.ID,
+		Name:       event.Event,
+		OccurredAt: event.CreatedAt.Time.UTC(),
+		Data:       event.Payload,
+	}
+
+	// A publish that has started is finished even if ctx is cancelled
+	// meanwhile; publishTimeout bounds how long that takes
+	publishCtx := context.WithoutCancel(ctx)
+	publishedTo := event.PublishedTo
+	var errs []error
+	for _, sink := range d.sinks {
+		name := sink.Name()
+		if slices.Contains(publishedTo, name) {
+			continue
+		}
+		if err, ok := failed[name]; ok {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if ctx.Err() != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, ctx.Err()))
+			continue
+		}
+
+		sinkCtx, cancel := context.WithTimeout(publishCtx, publishTimeout)
+		err := sink.Publish(sinkCtx, e)
+		cancel()
+		if err != nil {
+			failed[name] = err
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			slog.WarnContext(ctx, "Failed to publish event", "sink", name, "event_id", event.ID, "event", event.Event, "attempts", event.Attempts, "error", err)
+			continue
+		}
+		publishedTo = append(publishedTo, name)
+	}
+
+	update := db.UpdateOutboxEventParams{
+		ID:            event.ID,
+		PublishedTo:   publishedTo,
+		NextAttemptAt: pgtype.Timestamptz{Time: d.now(), Valid: true},
+	}
+	if len(errs) == 0 {
+		update.PublishedAt = pgtype.Timestamptz{Time: d.now(), Valid: true}
+	} else {
+		update.LastError = pgtype.Text{String: errors.Join(errs...).Error(), Valid: true}
+		update.NextAttemptAt.Time = d.now().Add(retryDelay(int(event.Attempts)))
+	}
+	if err := d.store.UpdateOutboxEvent(publishCtx, update); err != nil {
+		slog.ErrorContext(ctx, "Failed to record outbox event", "event_id", event.ID, "error", err)
+	}
+}
+
+// retryDelay is how long to wait after the given number of failed attempts
+func retryDelay(attempts int) time.Duration {
+	delay := retryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return min(delay, maxRetryDelay)
+}
@@ -0,0 +1,457 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/mailer"
+	"github.com/example/speedrun-rest-api/stream"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeStore hands out the events it holds once and records every update
+type fakeStore struct {
+	events  []db.OutboxEvent
+	updates map[int32]db.UpdateOutboxEventParams
+}
+
+func (s *fakeStore) ClaimOutboxEvents(ctx context.Context, arg db.ClaimOutboxEventsParams) ([]db.OutboxEvent, error) {
+	n := min(int(arg.BatchSize), len(s.events))
+	claimed := s.events[:n]
+	s.events = s.events[n:]
+	return claimed, nil
+}
+
+func (s *fakeStore) UpdateOutboxEvent(ctx context.Context, arg db.UpdateOutboxEventParams) error {
+	if s.updates == nil {
+		s.updates = map[int32]db.UpdateOutboxEventParams{}
+	}
+	s.updates[arg.ID] = arg
+	return nil
+}
+
+// fakeSink records the events published to it, failing with err if set
+type fakeSink struct {
+	name      string
+	err       error
+	published []Event
+	attempts  int
+}
+
+func (s *fakeSink) Name() string {
+	return s.name
+}
+
+func (s *fakeSink) Publish(ctx context.Context, e Event) error {
+	s.attempts++
+	if s.err != nil {
+		return s.err
+	}
+	s.published = append(s.published, e)
+	return nil
+}
+
+func TestDispatch_PublishesToEverySink(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	store := &fakeStore{events: []db.OutboxEvent{
+		{ID: 4, Event: "run.verified", Payload: []byte(`{"id":7}`), Attempts: 1, CreatedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		{ID: 3, Event: "user.created", Payload: []byte(`{"id":1}`), Attempts: 1, CreatedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+	}}
+	webhooks, kafka := &fakeSink{name: "webhooks"}, &fakeSink{name: "kafka"}
+	d := NewDispatcher(store, time.Second, webhooks, kafka)
+	d.now = func() time.Time { return now }
+	d.dispatch(context.Background())
+
+	if len(kafka.published) != 2 || kafka.published[0].ID != 3 || kafka.published[1].ID != 4 {
+		t.Fatalf("expected both events published oldest first, got %+v", kafka.published)
+	}
+	e := kafka.published[1]
+	if e.Name != "run.verified" || !e.OccurredAt.Equal(now) || string(e.Data) != `{"id":7}` {
+		t.Errorf("expected the stored event, got %+v", e)
+	}
+	for id, update := range store.updates {
+		if !update.PublishedAt.Valid || len(update.PublishedTo) != 2 || update.LastError.Valid {
+			t.Errorf("expected event %d recorded as published to both sinks, got %+v", id, update)
+		}
+	}
+}
+
+func TestDispatch_RetriesOnlyFailedSinks(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	store := &fakeStore{events: []db.OutboxEvent{
+		{ID: 1, Event: "run.verified", Payload: []byte(`{}`), PublishedTo: []string{"webhooks"}, Attempts: 3},
+		{ID: 2, Event: "run.verified", Payload: []byte(`{}`), Attempts: 1},
+	}}
+	webhooks := &fakeSink{name: "webhooks"}
+	nats := &fakeSink{name: "nats", err: errors.New("no responders")}
+	d := NewDispatcher(store, time.Second, webhooks, nats)
+	d.now = func() time.Time { return now }
+	d.dispatch(context.Background())
+
+	if len(webhooks.published) != 1 || webhooks.published[0].ID != 2 {
+		t.Errorf("expected only the event the webhooks sink lacked to be published to it, got %+v", webhooks.published)
+	}
+	if nats.attempts != 1 {
+		t.Errorf("expected the failing sink to be skipped for the rest of the batch, got %d attempts", nats.attempts)
+	}
+
+	first := store.updates[1]
+	if first.PublishedAt.Valid || first.LastError.String == "" {
+		t.Errorf("expected the event to stay unpublished with the error, got %+v", first)
+	}
+	if !first.NextAttemptAt.Time.Equal(now.Add(20 * time.Second)) {
+		t.Errorf("expected a retry in 20s after the third attempt, got %s", first.NextAttemptAt.Time.Sub(now))
+	}
+	if second := store.updates[2]; len(second.PublishedTo) != 1 || second.PublishedTo[0] != "webhooks" || second.PublishedAt.Valid {
+		t.Errorf("expected the webhooks sink to be recorded, got %+v", second)
+	}
+}
+
+func TestRetryDelay_Capped(t *testing.T) {
+	if got := retryDelay(50); got != maxRetryDelay {
+		t.Errorf("expected the delay to be capped at %s, got %s", maxRetryDelay, got)
+	}
+}
+
+// fakePreferenceStore has one run and the notification preferences users
+// have changed
+type fakePreferenceStore struct {
+	run         db.Run
+	preferences []db.NotificationPreference
+}
+
+func (s *fakePreferenceStore) GetRunByID(ctx context.Context, id int32) (db.Run, error) {
+	if id != s.run.ID {
+		return db.Run{}, sql.ErrNoRows
+	}
+	return s.run, nil
+}
+
+func (s *fakePreferenceStore) GetNotificationPreference(ctx context.Context, arg db.GetNotificationPreferenceParams) (db.NotificationPreference, error) {
+	for _, p := range s.preferences {
+		if p.UserID == arg.UserID && p.Event == arg.Event {
+			return p, nil
+		}
+	}
+	return db.NotificationPreference{}, sql.ErrNoRows
+}
+
+// webhookStore records the deliveries queued through it
+type webhookStore struct {
+	fakePreferenceStore
+	queued []db.EnqueueWebhookDeliveriesParams
+}
+
+func (s *webhookStore) EnqueueWebhookDeliveries(ctx context.Context, arg db.EnqueueWebhookDeliveriesParams) error {
+	s.queued = append(s.queued, arg)
+	return nil
+}
+
+func TestWebhookSink_QueuesEnvelope(t *testing.T) {
+	store := &webhookStore{}
+	occurredAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	err := NewWebhookSink(store).Publish(context.Background(), Event{
+		ID:         12,
+		Name:       "run.submitted",
+		OccurredAt: occurredAt,
+		Data:       json.RawMessage(`{"id":7}`),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(store.queued) != 1 || store.queued[0].EventID != 12 || store.queued[0].Event != "run.submitted" {
+		t.Fatalf("expected the event to be queued by ID, got %+v", store.queued)
+	}
+	want := `{"id":12,"event":"run.submitted","occurred_at":"2024-01-15T12:00:00Z","data":{"id":7}}`
+	if string(store.queued[0].Payload) != want {
+		t.Errorf("expected payload %s, got %s", want, store.queued[0].Payload)
+	}
+}
+
+func TestWebhookSink_SkipsUsersWhoOptedOut(t *testing.T) {
+	store := &webhookStore{fakePreferenceStore: fakePreferenceStore{
+		preferences: []db.NotificationPreference{{UserID: 5, Event: "run.verified", Email: true, InApp: true}},
+	}}
+	sink := NewWebhookSink(store)
+
+	events := []Event{
+		{ID: 1, Name: "run.verified", Data: json.RawMessage(`{"id":7,"user_id":5}`)},
+		{ID: 2, Name: "run.verified", Data: json.RawMessage(`{"id":8,"user_id":6}`)},
+		{ID: 3, Name: "run.rejected", Data: json.RawMessage(`{"id":7,"user_id":5}`)},
+	}
+	for _, e := range events {
+		if err := sink.Publish(context.Background(), e); err != nil {
+			t.Fatalf("event %d: expected no error, got %v", e.ID, err)
+		}
+	}
+
+	if len(store.queued) != 2 || store.queued[0].EventID != 2 || store.queued[1].EventID != 3 {
+		t.Errorf("expected only the run user 5 turned webhooks off for to be held back, got %+v", store.queued)
+	}
+}
+
+// fakeFeedStore has one category and records the feed items it is asked to add
+type fakeFeedStore struct {
+	category db.Category
+	items    []db.CreateFeedItemsParams
+}
+
+func (s *fakeFeedStore) GetCategoryByID(ctx context.Context, id int32) (db.Category, error) {
+	if id != s.category.ID {
+		return db.Category{}, sql.ErrNoRows
+	}
+	return s.category, nil
+}
+
+func (s *fakeFeedStore) CreateFeedItems(ctx context.Context, arg db.CreateFeedItemsParams) error {
+	s.items = append(s.items, arg)
+	return nil
+}
+
+func TestFeedSink_AddsFeedEvents(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	store := &fakeFeedStore{category: db.Category{ID: 3, GameID: 1}}
+	sink := NewFeedSink(store)
+
+	events := []Event{
+		{ID: 1, Name: "run.verified", OccurredAt: now, Data: json.RawMessage(`{"id":7,"user_id":5,"category_id":3}`)},
+		{ID: 2, Name: "record.broken", OccurredAt: now, Data: json.RawMessage(`{"run":{"id":7,"user_id":5,"category_id":3},"previous_record":{"id":6}}`)},
+		{ID: 3, Name: "category.created", OccurredAt: now, Data: json.RawMessage(`{"id":4,"game_id":2}`)},
+		{ID: 4, Name: "user.created", OccurredAt: now, Data: json.RawMessage(`{"id":9}`)},
+		{ID: 5, Name: "run.verified", OccurredAt: now, Data: json.RawMessage(`{"id":8,"user_id":5,"category_id":99}`)},
+	}
+	for _, e := range events {
+		if err := sink.Publish(context.Background(), e); err != nil {
+			t.Fatalf("event %d: expected no error, got %v", e.ID, err)
+		}
+	}
+
+	if len(store.items) != 3 {
+		t.Fatalf("expected feed items for the run, record, and category only, got %+v", store.items)
+	}
+	for _, item := range store.items[:2] {
+		if item.GameID != 1 || !item.ActorID.Valid || item.ActorID.Int32 != 5 || !item.OccurredAt.Time.Equal(now) {
+			t.Errorf("expected the run's game and runner, got %+v", item)
+		}
+	}
+	if item := store.items[2]; item.EventID != 3 || item.GameID != 2 || item.ActorID.Valid {
+		t.Errorf("expected the category's game and no actor, got %+v", item)
+	}
+}
+
+// fakeNotificationStore has one run, owned by the user in summary, and
+// records the notifications it is asked to store
+type fakeNotificationStore struct {
+	fakePreferenceStore
+	summary       db.GetRunSummaryRow
+	users         map[int32]string
+	notifications []db.CreateNotificationParams
+}
+
+func (s *fakeNotificationStore) GetRunSummary(ctx context.Context, id int32) (db.GetRunSummaryRow, error) {
+	if id != s.run.ID {
+		return db.GetRunSummaryRow{}, sql.ErrNoRows
+	}
+	return s.summary, nil
+}
+
+func (s *fakeNotificationStore) GetUserByID(ctx context.Context, id int32) (db.User, error) {
+	name, ok := s.users[id]
+	if !ok {
+		return db.User{}, sql.ErrNoRows
+	}
+	return db.User{ID: id, Name: name}, nil
+}
+
+func (s *fakeNotificationStore) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) error {
+	s.notifications = append(s.notifications, arg)
+	return nil
+}
+
+// recordingMailer records the emails sent through it
+type recordingMailer struct {
+	sent []mailer.Message
+	err  error
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+func TestNotificationSink_NotifiesRunners(t *testing.T) {
+	store := &fakeNotificationStore{fakePreferenceStore: fakePreferenceStore{run: db.Run{ID: 7, UserID: 5}}}
+	sink := NewNotificationSink(store, nil)
+
+	events := []Event{
+		{ID: 1, Name: "run.verified", Data: json.RawMessage(`{"id":7,"user_id":5}`)},
+		{ID: 2, Name: "run.rejected", Data: json.RawMessage(`{"id":8,"user_id":6}`)},
+		{ID: 3, Name: "record.broken", Data: json.RawMessage(`{"run":{"id":7,"user_id":5},"previous_record":{"id":6,"user_id":2}}`)},
+		{ID: 4, Name: "record.broken", Data: json.RawMessage(`{"run":{"id":7,"user_id":5},"previous_record":{"id":6,"user_id":5}}`)},
+		{ID: 5, Name: "comment.created", Data: json.RawMessage(`{"id":12,"run_id":7,"user_id":3}`)},
+		{ID: 6, Name: "comment.created", Data: json.RawMessage(`{"id":13,"run_id":7,"user_id":5}`)},
+		{ID: 7, Name: "comment.created", Data: json.RawMessage(`{"id":14,"run_id":99,"user_id":3}`)},
+		{ID: 8, Name: "user.created", Data: json.RawMessage(`{"id":9}`)},
+	}
+	for _, e := range events {
+		if err := sink.Publish(context.Background(), e); err != nil {
+			t.Fatalf("event %d: expected no error, got %v", e.ID, err)
+		}
+	}
+
+	got := map[int32]int32{}
+	for _, n := range store.notifications {
+		got[n.EventID] = n.UserID
+	}
+	want := map[int32]int32{1: 5, 2: 6, 3: 2, 5: 5}
+	if len(got) != len(want) {
+		t.Fatalf("expected notifications %v, got %v", want, got)
+	}
+	for eventID, userID := range want {
+		if got[eventID] != userID {
+			t.Errorf("event %d: expected user %d notified, got %d", eventID, userID, got[eventID])
+		}
+	}
+}
+
+func TestNotificationSink_RespectsPreferences(t *testing.T) {
+	store := &fakeNotificationStore{
+		fakePreferenceStore: fakePreferenceStore{
+			run: db.Run{ID: 7, UserID: 5},
+			preferences: []db.NotificationPreference{
+				{UserID: 5, Event: "run.verified", Email: true, Webhooks: true},
+				{UserID: 5, Event: "run.rejected", InApp: true, Webhooks: true},
+			},
+		},
+		summary: db.GetRunSummaryRow{UserName: "Jane", Email: "jane@example.com", GameName: "Celeste", CategoryName: "Any%"},
+	}
+	mail := &recordingMailer{}
+	sink := NewNotificationSink(store, mail)
+
+	for _, e := range []Event{
+		{ID: 1, Name: "run.verified", Data: json.RawMessage(`{"id":7,"user_id":5,"time_ms":754321}`)},
+		{ID: 2, Name: "run.rejected", Data: json.RawMessage(`{"id":7,"user_id":5,"time_ms":754321}`)},
+	} {
+		if err := sink.Publish(context.Background(), e); err != nil {
+			t.Fatalf("event %d: expected no error, got %v", e.ID, err)
+		}
+	}
+
+	if len(store.notifications) != 1 || store.notifications[0].EventID != 2 {
+		t.Errorf("expected only the rejection in-app, got %+v", store.notifications)
+	}
+	if len(mail.sent) != 1 || mail.sent[0].Subject != "Your Celeste run was verified" {
+		t.Fatalf("expected only the verification emailed, got %+v", mail.sent)
+	}
+	if msg := mail.sent[0]; msg.To != "jane@example.com" || !strings.Contains(msg.Body, "12:34.321 run of Celeste (Any%)") {
+		t.Errorf("expected the runner to be told about their run, got %+v", msg)
+	}
+}
+
+func TestNotificationSink_EmailsCommentsAndBeatenRecords(t *testing.T) {
+	store := &fakeNotificationStore{
+		fakePreferenceStore: fakePreferenceStore{run: db.Run{ID: 7, UserID: 5}},
+		summary:             db.GetRunSummaryRow{UserName: "Jane", Email: "jane@example.com", GameName: "Celeste", CategoryName: "Any%"},
+		users:               map[int32]string{3: "Bob"},
+	}
+	mail := &recordingMailer{}
+	sink := NewNotificationSink(store, mail)
+
+	for _, e := range []Event{
+		{ID: 1, Name: "comment.created", Data: json.RawMessage(`{"id":12,"run_id":7,"user_id":3,"body":"Nice!"}`)},
+		{ID: 2, Name: "record.broken", Data: json.RawMessage(`{"run":{"id":8,"user_id":3,"time_ms":750000},"previous_record":{"id":7,"user_id":5,"time_ms":754321}}`)},
+		{ID: 3, Name: "comment.created", Data: json.RawMessage(`{"id":13,"run_id":7,"user_id":4,"body":"Deleted account"}`)},
+	} {
+		if err := sink.Publish(context.Background(), e); err != nil {
+			t.Fatalf("event %d: expected no error, got %v", e.ID, err)
+		}
+	}
+
+	if len(mail.sent) != 2 {
+		t.Fatalf("expected no email naming a deleted account, got %+v", mail.sent)
+	}
+	if msg := mail.sent[0]; msg.Subject != "Bob commented on your Celeste run" || !strings.Contains(msg.Body, "Nice!") {
+		t.Errorf("expected the comment emailed, got %+v", msg)
+	}
+	if msg := mail.sent[1]; !strings.Contains(msg.Body, "Bob beat your 12:34.321 record") {
+		t.Errorf("expected the beaten record emailed, got %+v", msg)
+	}
+	if len(store.notifications) != 3 {
+		t.Errorf("expected every event notified in-app, got %+v", store.notifications)
+	}
+}
+
+func TestNotificationSink_SucceedsWhenMailFails(t *testing.T) {
+	store := &fakeNotificationStore{
+		fakePreferenceStore: fakePreferenceStore{run: db.Run{ID: 7, UserID: 5}},
+		summary:             db.GetRunSummaryRow{Email: "jane@example.com"},
+	}
+	sink := NewNotificationSink(store, &recordingMailer{err: errors.New("connection refused")})
+
+	err := sink.Publish(context.Background(), Event{ID: 1, Name: "run.verified", Data: json.RawMessage(`{"id":7,"user_id":5}`)})
+	if err != nil {
+		t.Errorf("expected the mail error not to fail the sink, got %v", err)
+	}
+	if len(store.notifications) != 1 {
+		t.Errorf("expected the notification stored, got %+v", store.notifications)
+	}
+}
+
+// fakeStreamStore has one category and records the notifications sent
+// through it
+type fakeStreamStore struct {
+	category db.Category
+	sent     []db.NotifyListenersParams
+}
+
+func (s *fakeStreamStore) GetCategoryByID(ctx context.Context, id int32) (db.Category, error) {
+	if id != s.category.ID {
+		return db.Category{}, sql.ErrNoRows
+	}
+	return s.category, nil
+}
+
+func (s *fakeStreamStore) NotifyListeners(ctx context.Context, arg db.NotifyListenersParams) error {
+	s.sent = append(s.sent, arg)
+	return nil
+}
+
+func TestStreamSink_SendsLeaderboardEvents(t *testing.T) {
+	store := &fakeStreamStore{category: db.Category{ID: 3, GameID: 2}}
+	sink := NewStreamSink(store)
+	occurredAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	events := []Event{
+		{ID: 1, Name: "run.verified", OccurredAt: occurredAt, Data: json.RawMessage(`{"id":7,"category_id":3}`)},
+		{ID: 2, Name: "record.broken", OccurredAt: occurredAt, Data: json.RawMessage(`{"run":{"id":7,"category_id":3}}`)},
+		{ID: 3, Name: "run.rejected", OccurredAt: occurredAt, Data: json.RawMessage(`{"id":8,"category_id":99}`)},
+		{ID: 4, Name: "user.created", OccurredAt: occurredAt, Data: json.RawMessage(`{"id":9}`)},
+	}
+	for _, e := range events {
+		if err := sink.Publish(context.Background(), e); err != nil {
+			t.Fatalf("event %d: expected no error, got %v", e.ID, err)
+		}
+	}
+
+	if len(store.sent) != 2 {
+		t.Fatalf("expected only the events in existing categories streamed, got %+v", store.sent)
+	}
+	var m stream.Message
+	if err := json.Unmarshal([]byte(store.sent[0].Payload), &m); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	if store.sent[0].Channel != stream.Channel || m.ID != 1 || m.Event != "run.verified" || m.GameID != 2 {
+		t.Errorf("expected the run's game on the stream channel, got %+v on %s", m, store.sent[0].Channel)
+	}
+	want := `{"id":1,"event":"run.verified","occurred_at":"2024-01-15T12:00:00Z","data":{"id":7,"category_id":3}}`
+	if string(m.Envelope) != want {
+		t.Errorf("expected envelope %s, got %s", want, m.Envelope)
+	}
+}
@@ -0,0 +1,94 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/stream"
+)
+
+// maxNotifyPayload is the largest payload Postgres accepts in a notification
+const maxNotifyPayload = 8000
+
+// StreamStore is the part of db.Querier the stream sink needs
+type StreamStore interface {
+	GetCategoryByID(ctx context.Context, id int32) (db.Category, error)
+	NotifyListeners(ctx context.Context, arg db.NotifyListenersParams) error
+}
+
+// StreamSink sends leaderboard changes and run reviews to every API
+// instance, which push them to the clients following GET /events/stream
+type StreamSink struct {
+	store StreamStore
+}
+
+// NewStreamSink creates a StreamSink that notifies listeners through store
+func NewStreamSink(store StreamStore) *StreamSink {
+	return &StreamSink{store: store}
+}
+
+// Name implements Sink
+func (s *StreamSink) Name() string {
+	return "stream"
+}
+
+// Publish implements Sink; events that aren't streamed are ignored, as are
+// runs in categories that have since been deleted
+//
+// Notifications only reach the instances listening when they are sent, so
+// clients that were disconnected miss the events sent meanwhile.
+func (s *StreamSink) Publish(ctx context.Context, e Event) error {
+	var run feedRun
+	switch e.Name {
+	case "run.verified", "run.rejected":
+		if err := json.Unmarshal(e.Data, &run); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+	case "record.broken":
+		var record struct {
+			Run feedRun `json:"run"`
+		}
+		if err := json.Unmarshal(e.Data, &record); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+		run = record.Run
+	default:
+		return nil
+	}
+
+	category, err := s.store.GetCategoryByID(ctx, run.CategoryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	envelope, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	payload, err := json.Marshal(stream.Message{
+		ID:       e.ID,
+		Event:    e.Name,
+		GameID:   category.GameID,
+		Envelope: envelope,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if len(payload) > maxNotifyPayload {
+		slog.WarnContext(ctx, "Event too large to stream; skipping it", "event_id", e.ID, "event", e.Name, "bytes", len(payload))
+		return nil
+	}
+
+	err = s.store.NotifyListeners(ctx, db.NotifyListenersParams{Channel: stream.Channel, Payload: string(payload)})
+	if err != nil {
+		return fmt.Errorf("failed to notify listeners: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// WebhookStore is the part of db.Querier the webhook sink needs
+type WebhookStore interface {
+	PreferenceStore
+	EnqueueWebhookDeliveries(ctx context.Context, arg db.EnqueueWebhookDeliveriesParams) error
+}
+
+// WebhookSink queues events for the webhooks subscribed to them, which the
+// webhook package then delivers
+type WebhookSink struct {
+	store WebhookStore
+}
+
+// NewWebhookSink creates a WebhookSink that queues deliveries in store
+func NewWebhookSink(store WebhookStore) *WebhookSink {
+	return &WebhookSink{store: store}
+}
+
+// Name implements Sink
+func (s *WebhookSink) Name() string {
+	return "webhooks"
+}
+
+// Publish implements Sink; an event already queued for a webhook is not
+// queued for it again, and an event is not queued at all when the user
+// notified of it turned webhooks off for it
+func (s *WebhookSink) Publish(ctx context.Context, e Event) error {
+	userID, err := notifiedUser(ctx, s.store, e)
+	if err != nil {
+		return err
+	}
+	if userID != 0 {
+		preference, err := notificationPreference(ctx, s.store, userID, e.Name)
+		if err != nil {
+			return err
+		}
+		if !preference.Webhooks {
+			return nil
+		}
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	err = s.store.EnqueueWebhookDeliveries(ctx, db.EnqueueWebhookDeliveriesParams{
+		EventID: e.ID,
+		Event:   e.Name,
+		Payload: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
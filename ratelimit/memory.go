@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops buckets that have
+// refilled, which behave the same as buckets that were never created
+const sweepInterval = time.Minute
+
+// bucket is the state of one key in the memory store
+type bucket struct {
+	tokens float64
+	last   time.Time
+
+	// full is when the bucket will have refilled completely
+	full time.Time
+}
+
+// Memory is a Store that keeps buckets in process
+// Limits are per replica, so a client spreading requests across N replicas
+// gets N times the limit.
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemory creates an empty in-process Store
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Take removes one token from the bucket named key
+func (m *Memory) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		m.buckets[key] = b
+	}
+
+	rate := limit.rate()
+	b.tokens = min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.full = now.Add(seconds((float64(limit.Burst) - b.tokens) / rate))
+
+	return limit.result(allowed, b.tokens), nil
+}
+
+// sweep drops refilled buckets at most once per sweepInterval
+// The caller must hold m.mu.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+	for key, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+// Close is a no-op; it exists to satisfy Store
+func (m *Memory) Close() error {
+	return nil
+}
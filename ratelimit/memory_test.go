@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock is a manually advanced time source
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestMemory() (*Memory, *clock) {
+	c := &clock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := NewMemory()
+	m.now = c.now
+	return m, c
+}
+
+func TestMemory_TakeUntilEmpty(t *testing.T) {
+	m, _ := newTestMemory()
+	limit := PerMinute(3)
+
+	for i := 2; i >= 0; i-- {
+		r, _ := m.Take(context.Background(), "ip:1", limit)
+		if !r.Allowed || r.Remaining != i {
+			t.Fatalf("expected take allowed with %d remaining, got %+v", i, r)
+		}
+	}
+
+	r, _ := m.Take(context.Background(), "ip:1", limit)
+	if r.Allowed {
+		t.Fatal("expected an empty bucket to deny")
+	}
+	if r.RetryAfter != 20*time.Second {
+		t.Errorf("expected retry after 20s, got %s", r.RetryAfter)
+	}
+	if r.Reset != time.Minute {
+		t.Errorf("expected reset after 1m, got %s", r.Reset)
+	}
+
+	// Other keys have their own bucket
+	if r, _ := m.Take(context.Background(), "ip:2", limit); !r.Allowed {
+		t.Error("expected a different key to be allowed")
+	}
+}
+
+func TestMemory_Refills(t *testing.T) {
+	m, c := newTestMemory()
+	limit := PerMinute(2)
+
+	m.Take(context.Background(), "k", limit)
+	m.Take(context.Background(), "k", limit)
+
+	c.advance(30 * time.Second)
+	r, _ := m.Take(context.Background(), "k", limit)
+	if !r.Allowed || r.Remaining != 0 {
+		t.Fatalf("expected one token refilled after 30s, got %+v", r)
+	}
+
+	c.advance(time.Hour)
+	r, _ = m.Take(context.Background(), "k", limit)
+	if !r.Allowed || r.Remaining != 1 {
+		t.Errorf("expected refill to stop at the burst, got %+v", r)
+	}
+}
+
+func TestMemory_SweepsRefilledBuckets(t *testing.T) {
+	m, c := newTestMemory()
+	limit := PerMinute(60)
+
+	m.Take(context.Background(), "idle", limit)
+	c.advance(2 * sweepInterval)
+	m.Take(context.Background(), "active", limit)
+
+	if _, ok := m.buckets["idle"]; ok {
+		t.Error("expected the refilled bucket to be swept")
+	}
+	if _, ok := m.buckets["active"]; !ok {
+		t.Error("expected the bucket just used to be kept")
+	}
+}
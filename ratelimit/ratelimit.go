@@ -0,0 +1,73 @@
+// Package ratelimit implements token-bucket rate limiting with pluggable
+// storage, so limits can be kept in process or shared between replicas.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit describes a token bucket: it holds up to Burst tokens and refills
+// at Burst tokens per Period
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// PerMinute returns a Limit allowing n requests a minute, all of which may
+// be spent at once
+func PerMinute(n int) Limit {
+	return Limit{Burst: n, Period: time.Minute}
+}
+
+// rate returns how many tokens are added back per second
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Period.Seconds()
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	// Allowed reports whether a token was available
+	Allowed bool
+
+	// Remaining is how many whole tokens are left
+	Remaining int
+
+	// RetryAfter is how long until the next token is available; zero when
+	// one already is
+	RetryAfter time.Duration
+
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+}
+
+// result derives a Result from the tokens left in a bucket after a take
+func (l Limit) result(allowed bool, tokens float64) Result {
+	rate := l.rate()
+	r := Result{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(l.Burst) - tokens) / rate),
+	}
+	if tokens < 1 {
+		r.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return r
+}
+
+// seconds converts fractional seconds to a Duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Store keeps token buckets
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Take removes one token from the bucket named key, creating a full
+	// bucket if none exists
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+
+	// Close releases any resources held by the store
+	Close() error
+}
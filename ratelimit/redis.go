@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/example/speedrun-rest-api/redis"
+)
+
+// takeScript refills and takes from a bucket atomically, using the Redis
+// server's clock so replicas with skewed clocks share one timeline. It
+// returns whether a token was taken and the tokens left, as a string because
+// Redis truncates Lua numbers in replies.
+const takeScript = `
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(state[1]) or burst
+local last = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - last) * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+// redisKeyPrefix namespaces bucket keys in a shared Redis
+const redisKeyPrefix = "ratelimit:"
+
+// Redis is a Store that keeps buckets in Redis, so every replica shares the
+// same limits
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis creates a Store for a URL of the form
+// redis://[[user]:password@]host[:port][/db]; use rediss:// for TLS
+// Connections are opened on first use.
+func NewRedis(rawURL string) (*Redis, error) {
+	client, err := redis.NewClient(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Redis{client: client}, nil
+}
+
+// Take removes one token from the bucket named key
+func (r *Redis) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	values, err := r.client.Eval(ctx, takeScript, []string{redisKeyPrefix + key},
+		limit.Burst, strconv.FormatFloat(limit.rate(), 'g', -1, 64)).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis EVAL: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected redis reply %v", values)
+	}
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(remaining, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected redis reply %v", values)
+	}
+	return limit.result(allowed == 1, tokens), nil
+}
+
+// Close closes the connections to Redis
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
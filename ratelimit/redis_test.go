@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestRedis_Take(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.SetTime(time.Unix(1700000000, 0))
+	r, err := NewRedis("redis://" + server.Addr())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer r.Close()
+
+	for i, remaining := range []int{1, 0} {
+		result, err := r.Take(context.Background(), "ip:1", PerMinute(2))
+		if err != nil {
+			t.Fatalf("take %d: expected no error, got %v", i+1, err)
+		}
+		if !result.Allowed || result.Remaining != remaining {
+			t.Errorf("take %d: unexpected result %+v", i+1, result)
+		}
+	}
+
+	result, err := r.Take(context.Background(), "ip:1", PerMinute(2))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Allowed || result.Remaining != 0 || result.RetryAfter != 30*time.Second {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if !server.Exists("ratelimit:ip:1") {
+		t.Error("expected the bucket to be stored under ratelimit:ip:1")
+	}
+}
+
+func TestRedis_ErrorReply(t *testing.T) {
+	server := miniredis.RunT(t)
+	r, _ := NewRedis("redis://" + server.Addr())
+	defer r.Close()
+
+	server.SetError("NOSCRIPT no scripts here")
+	if _, err := r.Take(context.Background(), "ip:1", PerMinute(60)); err == nil {
+		t.Error("expected the error reply to be returned")
+	}
+}
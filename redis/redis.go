@@ -0,0 +1,39 @@
+// Package redis connects to Redis with the settings rate limiting and
+// caching share. It keeps a small pool of connections and bounds every round
+// trip, so a stalled Redis slows requests down instead of hanging them.
+package redis
+
+import (
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// maxIdle bounds how many idle connections are kept for reuse
+const maxIdle = 8
+
+// timeout bounds each round trip, so a stalled Redis slows requests down
+// instead of hanging them
+const timeout = time.Second
+
+// Client sends commands to one Redis server
+type Client = goredis.Client
+
+// Nil is the error returned when a key does not exist
+const Nil = goredis.Nil
+
+// NewClient creates a Client for a URL of the form
+// redis://[[user]:password@]host[:port][/db]; use rediss:// for TLS
+// Connections are opened on first use.
+func NewClient(rawURL string) (*Client, error) {
+	opts, err := goredis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+	opts.DialTimeout = timeout
+	opts.ReadTimeout = timeout
+	opts.WriteTimeout = timeout
+	opts.MaxIdleConns = maxIdle
+	return goredis.NewClient(opts), nil
+}
@@ -0,0 +1,34 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/example/speedrun-rest-api/redis"
+)
+
+func TestNewClient_RejectsBadURL(t *testing.T) {
+	for _, bad := range []string{"http://cache:6379", "redis://cache:6379/main", "redis://%zz"} {
+		if _, err := redis.NewClient(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestNewClient_AuthenticatesAndSelects(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireUserAuth("bot", "hunter2")
+	r, err := redis.NewClient("redis://bot:hunter2@" + server.Addr() + "/2")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer r.Close()
+
+	if err := r.Set(context.Background(), "greeting", "hello", 0).Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value, err := server.DB(2).Get("greeting"); err != nil || value != "hello" {
+		t.Errorf("expected the key in database 2, got %q, %v", value, err)
+	}
+}
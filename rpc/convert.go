@@ -0,0 +1,172 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// dateLayout formats the date a run was played
+const dateLayout = "2006-01-02"
+
+// runStatuses maps the statuses stored for runs to RunStatus values
+var runStatuses = map[string]RunStatus{
+	"pending":  RunStatus_RUN_STATUS_PENDING,
+	"verified": RunStatus_RUN_STATUS_VERIFIED,
+	"rejected": RunStatus_RUN_STATUS_REJECTED,
+}
+
+// toUser converts a database User to a User message as the caller of ctx
+// may see it, leaving out the email address unless service.CanSeeEmail
+// allows it
+func toUser(ctx context.Context, user *db.User) *User {
+	email := user.Email
+	if !service.CanSeeEmail(ctx, user.ID) {
+		email = ""
+	}
+	return &User{
+		Id:              user.ID,
+		PublicId:        uuid.UUID(user.PublicID.Bytes).String(),
+		Name:            user.Name,
+		Email:           email,
+		CreatedAt:       timestamppb.New(user.CreatedAt.Time),
+		UpdatedAt:       timestamppb.New(user.UpdatedAt.Time),
+		EmailVerifiedAt: optionalTimestamp(user.EmailVerifiedAt),
+		TwitchHandle:    optionalText(user.TwitchHandle),
+		YoutubeHandle:   optionalText(user.YoutubeHandle),
+		TwitterHandle:   optionalText(user.TwitterHandle),
+		CountryCode:     optionalText(user.CountryCode),
+		Pronouns:        optionalText(user.Pronouns),
+		Bio:             optionalText(user.Bio),
+		AvatarUrl:       optionalText(user.AvatarUrl),
+	}
+}
+
+// toRun converts a database Run and its variable values to a Run message
+func toRun(run *db.Run, variables service.RunVariables) *Run {
+	return &Run{
+		Id:              run.ID,
+		UserId:          run.UserID,
+		CategoryId:      run.CategoryID,
+		LevelId:         optionalID(run.LevelID),
+		TimeMs:          run.TimeMs,
+		Times:           toRunTimes(run.RtaMs, run.IgtMs, run.LrtMs),
+		VideoUrl:        run.VideoUrl,
+		Platform:        run.Platform,
+		Region:          optionalText(run.Region),
+		PlayedOn:        run.PlayedOn.Time.Format(dateLayout),
+		CreatedAt:       timestamppb.New(run.CreatedAt.Time),
+		Status:          runStatuses[run.Status],
+		RejectionReason: optionalText(run.RejectionReason),
+		ReviewedAt:      optionalTimestamp(run.ReviewedAt),
+		Obsolete:        run.Obsolete,
+		Variables:       variables,
+		RaceId:          optionalID(run.RaceID),
+	}
+}
+
+// toListRunsResponse converts a page of runs to a ListRunsResponse
+func toListRunsResponse(page *service.RunPage) *ListRunsResponse {
+	response := &ListRunsResponse{
+		Runs:       make([]*Run, len(page.Runs)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Runs {
+		run := &page.Runs[i]
+		response.Runs[i] = toRun(run, page.Variables[run.ID])
+	}
+	return response
+}
+
+// toLeaderboardEntry converts a ranked standing to a LeaderboardEntry message
+func toLeaderboardEntry(entry *db.GetLeaderboardRow) *LeaderboardEntry {
+	return &LeaderboardEntry{
+		Rank:     entry.Rank,
+		RunId:    entry.ID,
+		UserId:   entry.UserID,
+		UserName: entry.UserName,
+		TimeMs:   entry.TimeMs,
+		Times:    toRunTimes(entry.RtaMs, entry.IgtMs, entry.LrtMs),
+		VideoUrl: entry.VideoUrl,
+		Platform: entry.Platform,
+		PlayedOn: entry.PlayedOn.Time.Format(dateLayout),
+	}
+}
+
+// toPersonalBest converts a user's best run in a category to a PersonalBest
+// message
+func toPersonalBest(best *db.GetPersonalBestsRow) *PersonalBest {
+	return &PersonalBest{
+		Rank:         best.Rank,
+		RunId:        best.ID,
+		GameId:       best.GameID,
+		GameSlug:     best.GameSlug,
+		GameName:     best.GameName,
+		CategoryId:   best.CategoryID,
+		CategorySlug: best.CategorySlug,
+		CategoryName: best.CategoryName,
+		TimeMs:       best.TimeMs,
+		RecordTimeMs: best.RecordTimeMs,
+		DeltaMs:      best.DeltaMs,
+		VideoUrl:     best.VideoUrl,
+		Platform:     best.Platform,
+		PlayedOn:     best.PlayedOn.Time.Format(dateLayout),
+	}
+}
+
+// toRunTimes converts a run's nullable times to a RunTimes message, leaving
+// out the methods the run was not timed by
+func toRunTimes(rta, igt, lrt pgtype.Int8) *RunTimes {
+	return &RunTimes{
+		RtaMs: optionalInt(rta),
+		IgtMs: optionalInt(igt),
+		LrtMs: optionalInt(lrt),
+	}
+}
+
+// pageRequest converts a Page message to a service page request
+func pageRequest(page *Page) service.PageRequest {
+	return service.PageRequest{
+		Limit:  int(page.GetLimit()),
+		Offset: int(page.GetOffset()),
+		Cursor: page.GetCursor(),
+	}
+}
+
+// optionalText converts a nullable column to an optional field
+func optionalText(text pgtype.Text) *string {
+	if !text.Valid {
+		return nil
+	}
+	return &text.String
+}
+
+// optionalID converts a nullable reference to an optional field
+func optionalID(id pgtype.Int4) *int32 {
+	if !id.Valid {
+		return nil
+	}
+	return &id.Int32
+}
+
+// optionalInt converts a nullable number to an optional field
+func optionalInt(n pgtype.Int8) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int64
+}
+
+// optionalTimestamp converts a nullable timestamp to a field that is unset
+// when it is null
+func optionalTimestamp(t pgtype.Timestamptz) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/example/speedrun-rest-api/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies this API in the ErrorInfo of its errors
+const errorDomain = "speedrun-rest-api"
+
+// serviceErrors maps the service layer's errors to status codes, with the
+// error code the REST API uses as the reason
+var serviceErrors = []struct {
+	err     error
+	code    codes.Code
+	reason  string
+	message string
+}{
+	{service.ErrInvalidCursor, codes.InvalidArgument, "INVALID_CURSOR", "Invalid cursor"},
+	{service.ErrTooManyIDs, codes.InvalidArgument, "TOO_MANY_IDS", "Too many user IDs requested"},
+	{service.ErrUserNotFound, codes.NotFound, "USER_NOT_FOUND", "User not found"},
+	{service.ErrCategoryNotFound, codes.NotFound, "CATEGORY_NOT_FOUND", "Category not found"},
+	{service.ErrLevelNotFound, codes.NotFound, "LEVEL_NOT_FOUND", "Level not found"},
+	{service.ErrRunNotFound, codes.NotFound, "RUN_NOT_FOUND", "Run not found"},
+	{service.ErrForbidden, codes.PermissionDenied, "FORBIDDEN", "Forbidden"},
+	{service.ErrEmailNotVerified, codes.FailedPrecondition, "EMAIL_NOT_VERIFIED", "Verify your email address before submitting runs"},
+	{service.ErrInvalidRunTransition, codes.FailedPrecondition, "INVALID_TRANSITION", "Run is not pending review"},
+}
+
+// toStatus converts an error from the service layer to a gRPC status error
+// Invalid input is reported with its details, as the REST API does, with a
+// BadRequest detail listing the fields that failed validation; errors
+// the caller can't act on are logged and reported as internal errors.
+func toStatus(ctx context.Context, err error) error {
+	var invalid *service.ValidationError
+	if errors.As(err, &invalid) {
+		return fieldError(invalid)
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		return Error(codes.InvalidArgument, "INVALID_INPUT", err.Error())
+	}
+	for _, known := range serviceErrors {
+		if errors.Is(err, known.err) {
+			return Error(known.code, known.reason, known.message)
+		}
+	}
+	slog.ErrorContext(ctx, "Error serving RPC", "error", err)
+	return Error(codes.Internal, "INTERNAL_ERROR", "Internal server error")
+}
+
+// Error creates a status error carrying reason, the REST API's error code,
+// in an ErrorInfo detail
+func Error(code codes.Code, reason, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// fieldError creates the InvalidArgument status error for input that failed
+// the service layer's validation
+func fieldError(invalid *service.ValidationError) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(invalid.Fields))
+	for i, field := range invalid.Fields {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message}
+	}
+	st, err := status.New(codes.InvalidArgument, invalid.Error()).WithDetails(
+		&errdetails.ErrorInfo{Reason: "INVALID_INPUT", Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: violations},
+	)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, invalid.Error())
+	}
+	return st.Err()
+}
+
+// Reason returns the REST API error code carried by a status error from
+// this API, or an empty string when it carries none
+func Reason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
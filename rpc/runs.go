@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/service"
+	"google.golang.org/grpc/codes"
+)
+
+// RunServer implements RunServiceServer over the run service
+type RunServer struct {
+	UnimplementedRunServiceServer
+	runs *service.RunService
+}
+
+// NewRunServer creates a RunServer backed by runs
+func NewRunServer(runs *service.RunService) *RunServer {
+	return &RunServer{runs: runs}
+}
+
+// SubmitRun records a run of the caller for review
+func (s *RunServer) SubmitRun(ctx context.Context, req *SubmitRunRequest) (*Run, error) {
+	if principal, _ := auth.PrincipalFromContext(ctx); principal.UserID != req.GetUserId() {
+		return nil, Error(codes.PermissionDenied, "FORBIDDEN", "Runs can only be submitted for yourself")
+	}
+	playedOn, err := time.Parse(dateLayout, req.GetPlayedOn())
+	if err != nil {
+		return nil, Error(codes.InvalidArgument, "INVALID_INPUT", "played_on must be a date as YYYY-MM-DD")
+	}
+
+	input := service.SubmitRunInput{
+		UserID: req.GetUserId(),
+		Times: service.RunTimes{
+			RTA: req.GetTimes().GetRtaMs(),
+			IGT: req.GetTimes().GetIgtMs(),
+			LRT: req.GetTimes().GetLrtMs(),
+		},
+		VideoURL:  req.GetVideoUrl(),
+		PlayedOn:  playedOn,
+		Platform:  req.GetPlatform(),
+		Region:    req.GetRegion(),
+		Variables: service.RunVariables{},
+		LevelSlug: req.GetLevel(),
+	}
+	for slug, value := range req.GetVariables() {
+		input.Variables[slug] = value
+	}
+
+	run, err := s.runs.SubmitRun(ctx, req.GetGame(), req.GetCategory(), input)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toRun(run, input.Variables), nil
+}
+
+// VerifyRun accepts a pending run
+func (s *RunServer) VerifyRun(ctx context.Context, req *VerifyRunRequest) (*Run, error) {
+	run, err := s.runs.VerifyRun(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toRun(run, nil), nil
+}
+
+// RejectRun rejects a pending run with a reason
+func (s *RunServer) RejectRun(ctx context.Context, req *RejectRunRequest) (*Run, error) {
+	run, err := s.runs.RejectRun(ctx, req.GetId(), req.GetReason())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toRun(run, nil), nil
+}
+
+// ListUserRuns returns a page of a user's runs, newest first
+func (s *RunServer) ListUserRuns(ctx context.Context, req *ListUserRunsRequest) (*ListRunsResponse, error) {
+	filter := service.ListRunsFilter{IncludeObsolete: req.GetIncludeObsolete()}
+	page, err := s.runs.ListUserRuns(ctx, req.GetUserId(), pageRequest(req.GetPage()), filter)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toListRunsResponse(page), nil
+}
+
+// ListCategoryRuns returns a page of a category's runs, fastest first
+func (s *RunServer) ListCategoryRuns(ctx context.Context, req *ListCategoryRunsRequest) (*ListRunsResponse, error) {
+	filter := service.ListRunsFilter{IncludeObsolete: req.GetIncludeObsolete()}
+	page, err := s.runs.ListCategoryRuns(ctx, req.GetGame(), req.GetCategory(), pageRequest(req.GetPage()), filter)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toListRunsResponse(page), nil
+}
+
+// GetLeaderboard returns a page of a category's standings
+func (s *RunServer) GetLeaderboard(ctx context.Context, req *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	filter := service.LeaderboardFilter{
+		Platform: req.GetPlatform(),
+		Region:   req.GetRegion(),
+	}
+	if len(req.GetVariables()) > 0 {
+		filter.Variables = service.RunVariables(req.GetVariables())
+	}
+
+	page, err := s.runs.Leaderboard(ctx, req.GetGame(), req.GetCategory(), pageRequest(req.GetPage()), filter)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	response := &GetLeaderboardResponse{
+		Entries:    make([]*LeaderboardEntry, len(page.Entries)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Entries {
+		response.Entries[i] = toLeaderboardEntry(&page.Entries[i])
+	}
+	return response, nil
+}
+
+// ListPersonalBests returns a user's best verified run in every category
+func (s *RunServer) ListPersonalBests(ctx context.Context, req *ListPersonalBestsRequest) (*ListPersonalBestsResponse, error) {
+	bests, err := s.runs.PersonalBests(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	response := &ListPersonalBestsResponse{PersonalBests: make([]*PersonalBest, len(bests))}
+	for i := range bests {
+		response.PersonalBests[i] = toPersonalBest(&bests[i])
+	}
+	return response, nil
+}
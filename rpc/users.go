@@ -0,0 +1,72 @@
+// Package rpc serves the user and run services over gRPC for internal
+// consumers that want strongly typed clients, such as operator tooling and
+// the Discord bot.
+//
+// The messages and services are defined in speedrun.proto and generated
+// into speedrun.pb.go and speedrun_grpc.pb.go. The servers here only map
+// between messages and the service layer; authentication, maintenance mode,
+// and logging are interceptors installed by the server package, so rules
+// the REST API enforces in the service layer hold here too.
+package rpc
+
+import (
+	"context"
+
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// UserServer implements UserServiceServer over the user service
+type UserServer struct {
+	UnimplementedUserServiceServer
+	users *service.UserService
+}
+
+// NewUserServer creates a UserServer backed by users
+func NewUserServer(users *service.UserService) *UserServer {
+	return &UserServer{users: users}
+}
+
+// GetUser returns a user by ID
+func (s *UserServer) GetUser(ctx context.Context, req *GetUserRequest) (*User, error) {
+	user, err := s.users.GetUserByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toUser(ctx, user), nil
+}
+
+// BatchGetUsers returns several users at once and reports which IDs were
+// not found
+func (s *UserServer) BatchGetUsers(ctx context.Context, req *BatchGetUsersRequest) (*BatchGetUsersResponse, error) {
+	users, missing, err := s.users.GetUsersByIDs(ctx, req.GetIds())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	response := &BatchGetUsersResponse{
+		Users:      make([]*User, len(users)),
+		MissingIds: missing,
+	}
+	for i := range users {
+		response.Users[i] = toUser(ctx, &users[i])
+	}
+	return response, nil
+}
+
+// ListUsers returns a page of users ordered by ID
+func (s *UserServer) ListUsers(ctx context.Context, req *ListUsersRequest) (*ListUsersResponse, error) {
+	page, err := s.users.ListUsers(ctx, pageRequest(req.GetPage()), service.ListUsersFilter{Name: req.GetName()})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	response := &ListUsersResponse{
+		Users:      make([]*User, len(page.Users)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Users {
+		response.Users[i] = toUser(ctx, &page.Users[i])
+	}
+	return response, nil
+}
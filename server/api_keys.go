@@ -0,0 +1,109 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListAPIKeys handles GET /users/me/api-keys
+// Lists the caller's active API keys
+func (s *Server) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := s.apiKeyService.ListAPIKeys(r.Context())
+	if err != nil {
+		s.writeAPIKeyError(w, r, err)
+		return
+	}
+	
+	apiKeys := make([]api.APIKey, len(keys))
+	for i, key := range keys {
+		apiKeys[i] = dbAPIKeyToAPIKey(&key)
+	}
+	
+	response := struct {
+		APIKeys []api.APIKey `json:"api_keys"`
+	}{
+		APIKeys: apiKeys,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateAPIKey handles POST /users/me/api-keys
+// Mints an API key for the caller; the key is only ever returned here
+func (s *Server) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateAPIKeyRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	scopes := make([]string, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = string(scope)
+	}
+	var expiresAt time.Time
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+	
+	stored, key, err := s.apiKeyService.CreateAPIKey(r.Context(), req.Name, scopes, expiresAt)
+	if err != nil {
+		s.writeAPIKeyError(w, r, err)
+		return
+	}
+	
+	apiKey := dbAPIKeyToAPIKey(stored)
+	apiKey.Key = &key
+	w.Header().Set("Cache-Control", "no-store")
+	s.writeJSON(w, r, http.StatusCreated, apiKey)
+}
+
+// RevokeAPIKey handles DELETE /users/me/api-keys/{id}
+// Revokes one of the caller's API keys
+func (s *Server) RevokeAPIKey(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.apiKeyService.RevokeAPIKey(r.Context(), int32(id)); err != nil {
+		s.writeAPIKeyError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAPIKeyError maps errors from managing API keys to responses
+func (s *Server) writeAPIKeyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, r, http.StatusForbidden, "API keys cannot manage API keys", "FORBIDDEN")
+	default:
+		writeServiceError(w, r, err, "Error managing API keys")
+	}
+}
+
+// dbAPIKeyToAPIKey converts a stored key to its API form, without the key
+func dbAPIKeyToAPIKey(key *db.ApiKey) api.APIKey {
+	scopes := make([]api.APIKeyScope, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = api.APIKeyScope(scope)
+	}
+	
+	apiKey := api.APIKey{
+		Id:        int(key.ID),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    scopes,
+		CreatedAt: key.CreatedAt.Time.UTC(),
+	}
+	if key.ExpiresAt.Valid {
+		expiresAt := key.ExpiresAt.Time.UTC()
+		apiKey.ExpiresAt = &expiresAt
+	}
+	if key.LastUsedAt.Valid {
+		lastUsedAt := key.LastUsedAt.Time.UTC()
+		apiKey.LastUsedAt = &lastUsedAt
+	}
+	return apiKey
+}
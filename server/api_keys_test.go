@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// apiKeysFor returns a GetAPIKeyByHash stub that knows the given keys
+func apiKeysFor(keys map[string]db.ApiKey) func(ctx context.Context, keyHash string) (db.ApiKey, error) {
+	return func(ctx context.Context, keyHash string) (db.ApiKey, error) {
+		for key, stored := range keys {
+			sum := sha256.Sum256([]byte(key))
+			if hex.EncodeToString(sum[:]) == keyHash {
+				return stored, nil
+			}
+		}
+		return db.ApiKey{}, sql.ErrNoRows
+	}
+}
+
+func TestAPIKey_ScopesRestrictOperations(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		getRunByID: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: "pending"}, nil
+		},
+		getCategoryByID: func(ctx context.Context, id int32) (db.Category, error) {
+			return db.Category{ID: id, GameID: 1}, nil
+		},
+		updateRunStatus: func(ctx context.Context, arg db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{ID: arg.ID, Status: arg.Status}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			5: {{UserID: 5, Role: "moderator"}},
+		}),
+		getAPIKeyByHash: apiKeysFor(map[string]db.ApiKey{
+			"srk_submit":   {ID: 1, UserID: 5, Scopes: []string{"runs:write"}},
+			"srk_moderate": {ID: 2, UserID: 5, Scopes: []string{"runs:moderate"}},
+		}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		header       string
+		want         int
+		expectedCode string
+	}{
+		{"unknown key", http.MethodPost, "/runs/7/verify", "ApiKey srk_unknown", http.StatusUnauthorized, "INVALID_API_KEY"},
+		{"missing scope", http.MethodPost, "/runs/7/verify", "ApiKey srk_submit", http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"operation without api keys", http.MethodGet, "/users/me/api-keys", "ApiKey srk_moderate", http.StatusForbidden, "FORBIDDEN"},
+		{"scoped key", http.MethodPost, "/runs/7/verify", "ApiKey srk_moderate", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", tt.header)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if tt.expectedCode == "" {
+				return
+			}
+			var resp api.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("expected JSON body, got error %v", err)
+			}
+			if resp.Code != tt.expectedCode {
+				t.Errorf("expected code %s, got %v", tt.expectedCode, resp.Code)
+			}
+		})
+	}
+}
+
+func TestAPIKey_OwnerRolesNeedScope(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Ada", Email: "ada@example.com"}, nil
+		},
+		listUsers: func(ctx context.Context, arg db.ListUsersParams) ([]db.ListUsersRow, error) {
+			return []db.ListUsersRow{{User: db.User{ID: 1, Name: "Ada", Email: "ada@example.com"}, Total: 1}}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			1: {{UserID: 1, Role: "admin"}},
+		}),
+		getAPIKeyByHash: apiKeysFor(map[string]db.ApiKey{
+			"srk_admin": {ID: 1, UserID: 1, Scopes: []string{"runs:write"}},
+		}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"include deleted users", "/users?include_deleted=true", http.StatusForbidden},
+		{"own profile", "/users/1", http.StatusOK},
+		{"user list", "/users", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "ApiKey srk_admin")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if strings.Contains(rec.Body.String(), "ada@example.com") {
+				t.Errorf("expected no email addresses, got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateAPIKey_ReturnsKeyOnce(t *testing.T) {
+	var stored db.CreateAPIKeyParams
+	queries := &stubQueries{
+		listAPIKeysByUser: func(ctx context.Context, userID int32) ([]db.ApiKey, error) {
+			return nil, nil
+		},
+		createAPIKey: func(ctx context.Context, arg db.CreateAPIKeyParams) (db.ApiKey, error) {
+			stored = arg
+			return db.ApiKey{ID: 1, UserID: arg.UserID, Name: arg.Name, Prefix: arg.Prefix, Scopes: arg.Scopes}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	req := httptest.NewRequest(http.MethodPost, "/users/me/api-keys", strings.NewReader(`{"name": "bot", "scopes": ["runs:write"]}`))
+	req.Header.Set("Authorization", bearerToken(t, 3))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.APIKey
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if resp.Key == nil || !strings.HasPrefix(*resp.Key, resp.Prefix) {
+		t.Fatalf("expected the key in the response, got %+v", resp)
+	}
+	if stored.UserID != 3 || stored.KeyHash == *resp.Key {
+		t.Errorf("expected a hashed key stored for user 3, got %+v", stored)
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected Cache-Control: no-store, got %q", rec.Header().Get("Cache-Control"))
+	}
+}
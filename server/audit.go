@@ -0,0 +1,82 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListAuditEvents handles GET /admin/audit-events
+// Returns the audit trail, newest first, narrowed by the query filters
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request, params api.ListAuditEventsParams) {
+	var filter service.AuditEventFilter
+	if params.ActorId != nil {
+		filter.ActorID = int32(*params.ActorId)
+	}
+	if params.EntityType != nil {
+		filter.EntityType = string(*params.EntityType)
+	}
+	if params.EntityId != nil {
+		filter.EntityID = int32(*params.EntityId)
+	}
+	if params.From != nil {
+		filter.From = *params.From
+	}
+	if params.To != nil {
+		filter.To = *params.To
+	}
+	
+	page, err := s.auditService.ListAuditEvents(r.Context(), pageRequest(params.Limit, params.Offset, params.Cursor), filter)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			writeError(w, r, http.StatusForbidden, "Admin access required", "FORBIDDEN")
+			return
+		}
+		writeServiceError(w, r, err, "Error listing audit events")
+		return
+	}
+	
+	events := make([]api.AuditEvent, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = dbAuditEventToAPIAuditEvent(&event)
+	}
+	
+	response := struct {
+		AuditEvents []api.AuditEvent `json:"audit_events"`
+		Total       int64            `json:"total"`
+		Limit       int32            `json:"limit"`
+		Offset      int32            `json:"offset"`
+		NextCursor  string           `json:"next_cursor,omitempty"`
+	}{
+		AuditEvents: events,
+		Total:       page.Total,
+		Limit:       page.Limit,
+		Offset:      page.Offset,
+		NextCursor:  page.NextCursor,
+	}
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// dbAuditEventToAPIAuditEvent converts a database AuditEvent model to an API
+// AuditEvent model
+func dbAuditEventToAPIAuditEvent(event *db.AuditEvent) api.AuditEvent {
+	apiEvent := api.AuditEvent{
+		Id:         int(event.ID),
+		Action:     event.Action,
+		EntityType: api.AuditEntityType(event.EntityType),
+		EntityId:   int(event.EntityID),
+		Changes:    event.Changes,
+		CreatedAt:  event.CreatedAt.Time.UTC(),
+	}
+	if event.ActorID.Valid {
+		actorID := int(event.ActorID.Int32)
+		apiEvent.ActorId = &actorID
+	}
+	if event.RequestID.Valid {
+		apiEvent.RequestId = &event.RequestID.String
+	}
+	return apiEvent
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	var params db.ListAuditEventsParams
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			1: {{UserID: 1, Role: "admin"}},
+		}),
+		listAuditEvents: func(ctx context.Context, arg db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+			params = arg
+			return []db.AuditEvent{{
+				ID:         4,
+				ActorID:    pgtype.Int4{Int32: 1, Valid: true},
+				Action:     "game.update",
+				EntityType: "game",
+				EntityID:   2,
+				Changes:    []byte(`{"name":{"before":"SM64","after":"Super Mario 64"}}`),
+				RequestID:  pgtype.Text{String: "req-1", Valid: true},
+				CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			}}, nil
+		},
+		countAuditEvents: func(ctx context.Context, arg db.CountAuditEventsParams) (int64, error) {
+			return 1, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit-events?entity_type=game&entity_id=2&from=2024-01-01T00:00:00Z", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if params.EntityType.String != "game" || params.EntityID.Int32 != 2 || !params.CreatedFrom.Valid || params.ActorID.Valid {
+		t.Errorf("expected filters to reach the query, got %+v", params)
+	}
+
+	var resp struct {
+		AuditEvents []struct {
+			ActorID   int                                   `json:"actor_id"`
+			Changes   map[string]map[string]json.RawMessage `json:"changes"`
+			RequestID string                                `json:"request_id"`
+		} `json:"audit_events"`
+		Total int `json:"total"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if resp.Total != 1 || len(resp.AuditEvents) != 1 {
+		t.Fatalf("expected one event, got %+v", resp)
+	}
+	event := resp.AuditEvents[0]
+	if event.ActorID != 1 || event.RequestID != "req-1" {
+		t.Errorf("expected actor 1 and request req-1, got %+v", event)
+	}
+	if string(event.Changes["name"]["after"]) != `"Super Mario 64"` {
+		t.Errorf("expected changes to be returned as recorded, got %v", event.Changes)
+	}
+}
+
+func TestListAuditEvents_RequiresAdmin(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit-events", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+func TestUpdateUser_AuditsRequestID(t *testing.T) {
+	var event db.CreateAuditEventParams
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Old Name", Email: "old@example.com", Version: 1}, nil
+		},
+		updateUser: func(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+			return db.User{ID: arg.ID, Name: arg.Name, Email: arg.Email, Version: 2}, nil
+		},
+		createAuditEvent: func(ctx context.Context, arg db.CreateAuditEventParams) error {
+			event = arg
+			return nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"name": "New Name"}`))
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	req.Header.Set("If-Match", `"1"`)
+	req.Header.Set("X-Request-Id", "req-42")
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if event.Action != "user.update" || event.RequestID.String != "req-42" {
+		t.Errorf("expected user.update audited with request req-42, got %+v", event)
+	}
+}
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/logging"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// PrincipalResolver loads the roles of an authenticated user
+type PrincipalResolver func(ctx context.Context, userID int32) (auth.Principal, error)
+
+// APIKeyResolver identifies the owner of an API key; it returns
+// service.ErrInvalidAPIKey for keys that are unknown, expired, or revoked
+type APIKeyResolver func(ctx context.Context, key string) (auth.Principal, error)
+
+// Authenticator identifies callers from the bearer token or API key on each
+// request
+type Authenticator struct {
+	signer     *auth.Signer
+	resolve    PrincipalResolver
+	resolveKey APIKeyResolver
+	limiter    *RateLimiter
+}
+
+// NewAuthenticator creates an Authenticator that accepts tokens from signer,
+// looks up each caller's roles with resolve, and API keys with resolveKey
+// Every refused credential is charged to the client IP's bucket in limiter.
+func NewAuthenticator(signer *auth.Signer, resolve PrincipalResolver, resolveKey APIKeyResolver, limiter *RateLimiter) *Authenticator {
+	return &Authenticator{signer: signer, resolve: resolve, resolveKey: resolveKey, limiter: limiter}
+}
+
+// Middleware verifies the Authorization header, if any, and stores the
+// caller in the request context
+// Requests without the header pass through anonymously; a header that is
+// present but invalid is rejected with 401 so a stale token never silently
+// downgrades to anonymous access. Use auth.PrincipalFromContext downstream.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		if key, ok := strings.CutPrefix(header, "ApiKey "); ok {
+			a.authenticateKey(w, r, next, key)
+			return
+		}
+		
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			a.reject(w, r, "Unsupported authorization scheme", "INVALID_TOKEN")
+			return
+		}
+		
+		ctx, ok := a.authenticateToken(w, r, token)
+		if !ok {
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authenticateToken stores the caller holding an access token in the
+// request's context; when ok is false the token was rejected and the
+// response has been written
+func (a *Authenticator) authenticateToken(w http.ResponseWriter, r *http.Request, token string) (ctx context.Context, ok bool) {
+	principal, err := a.signer.Verify(token)
+	if err != nil {
+		if errors.Is(err, auth.ErrExpiredToken) {
+			a.reject(w, r, "Access token has expired", "TOKEN_EXPIRED")
+			return nil, false
+		}
+		a.reject(w, r, "Invalid access token", "INVALID_TOKEN")
+		return nil, false
+	}
+	
+	principal, err = a.resolve(r.Context(), principal.UserID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error loading roles", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return nil, false
+	}
+	
+	logging.SetUserID(r.Context(), principal.UserID)
+	return auth.WithPrincipal(r.Context(), principal), true
+}
+
+// authenticateKey stores the owner of an API key in the request context
+func (a *Authenticator) authenticateKey(w http.ResponseWriter, r *http.Request, next http.Handler, key string) {
+	principal, err := a.resolveKey(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIKey) {
+			a.reject(w, r, "Invalid API key", "INVALID_API_KEY")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Error authenticating API key", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	logging.SetUserID(r.Context(), principal.UserID)
+	next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+}
+
+// Require rejects anonymous requests to operations the OpenAPI spec marks
+// with bearerAuth security, and callers missing every role the operation lists
+// as a scope
+// API keys are only accepted by operations that also list apiKeyAuth, and
+// only when the key holds one of its scopes.
+// It runs as an oapi-codegen handler middleware, where the generated wrapper
+// has already recorded the operation's security requirement in the context.
+// Checks that depend on the resource, such as owning an account or moderating
+// a game, are left to the service layer.
+func (a *Authenticator) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopes, secured := r.Context().Value(api.BearerAuthScopes).([]string)
+		if !secured {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			unauthorized(w, r, "Authentication required", "UNAUTHORIZED")
+			return
+		}
+		if len(scopes) > 0 && !slices.ContainsFunc(scopes, func(scope string) bool {
+			return principal.HasRole(auth.Role(scope))
+		}) {
+			writeError(w, r, http.StatusForbidden, "Insufficient role", "FORBIDDEN")
+			return
+		}
+		if principal.ViaAPIKey() {
+			keyScopes, accepted := r.Context().Value(api.ApiKeyAuthScopes).([]string)
+			if !accepted {
+				writeError(w, r, http.StatusForbidden, "API keys cannot be used for this operation", "FORBIDDEN")
+				return
+			}
+			if !slices.ContainsFunc(keyScopes, principal.HasScope) {
+				writeError(w, r, http.StatusForbidden, "API key is missing the required scope", "INSUFFICIENT_SCOPE")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// reject answers a request whose credentials were refused with 401, or with
+// 429 once the client has been refused too often
+func (a *Authenticator) reject(w http.ResponseWriter, r *http.Request, message, code string) {
+	if a.limiter.ChargeFailure(w, r) {
+		unauthorized(w, r, message, code)
+	}
+}
+
+// unauthorized writes a 401 response with a Bearer challenge
+func unauthorized(w http.ResponseWriter, r *http.Request, message, code string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	writeError(w, r, http.StatusUnauthorized, message, code)
+}
+
+// Login handles POST /auth/login
+// Exchanges an email and password for a signed access token
+func (s *Server) Login(w http.ResponseWriter, r *http.Request) {
+	var req api.LoginRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	token, err := s.authService.Login(r.Context(), string(req.Email), req.Password)
+	if err != nil {
+		writeServiceError(w, r, err, "Error logging in")
+		return
+	}
+	
+	s.writeToken(w, r, token)
+}
+
+// RefreshToken handles POST /auth/refresh
+// Rotates a refresh token into a new access token and refresh token
+func (s *Server) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req api.RefreshTokenRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	token, err := s.authService.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		writeServiceError(w, r, err, "Error refreshing token")
+		return
+	}
+	
+	s.writeToken(w, r, token)
+}
+
+// Logout handles POST /auth/logout
+// Revokes a refresh token and every token rotated from the same login
+func (s *Server) Logout(w http.ResponseWriter, r *http.Request) {
+	var req api.RefreshTokenRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	if err := s.authService.Logout(r.Context(), req.RefreshToken); err != nil {
+		slog.ErrorContext(r.Context(), "Error logging out", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeToken writes an issued token pair; token responses must never be cached
+func (s *Server) writeToken(w http.ResponseWriter, r *http.Request, token *service.Token) {
+	w.Header().Set("Cache-Control", "no-store")
+	s.writeJSON(w, r, http.StatusOK, api.TokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(token.ExpiresIn.Seconds()),
+		RefreshToken: token.RefreshToken,
+	})
+}
+
+// Register handles POST /auth/register
+// Creates a user who can log in with the given password
+func (s *Server) Register(w http.ResponseWriter, r *http.Request) {
+	var req api.RegisterRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	user, err := s.userService.Register(r.Context(), req.Name, string(req.Email), req.Password)
+	if err != nil {
+		writeServiceError(w, r, err, "Error registering user")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, dbUserToAPIUser(user))
+}
+
+// VerifyEmail handles POST /auth/verify-email
+// Marks the email address a verification token was sent to as verified
+func (s *Server) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req api.VerifyEmailRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	user, err := s.userService.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		writeServiceError(w, r, err, "Error verifying email")
+		return
+	}
+	
+	setUserETag(w, user)
+	s.writeJSON(w, r, http.StatusOK, dbUserToAPIUser(user))
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testJWTSecret = "test-secret"
+
+// testConfig returns the default config with a fixed signing key so tests
+// can mint tokens with bearerToken
+func testConfig() *config.Config {
+	cfg := config.Default()
+	cfg.JWTSecret = testJWTSecret
+	return cfg
+}
+
+// bearerToken returns an Authorization header value for userID
+func bearerToken(t *testing.T, userID int32) string {
+	t.Helper()
+	token, err := auth.NewSigner([]byte(testJWTSecret), time.Hour).Issue(userID)
+	if err != nil {
+		t.Fatalf("failed to issue token: %v", err)
+	}
+	return "Bearer " + token
+}
+
+func TestAuthenticator_RejectsBadTokens(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+	expired, _ := auth.NewSigner([]byte(testJWTSecret), -time.Minute).Issue(1)
+
+	tests := []struct {
+		name         string
+		method       string
+		header       string
+		expectedCode string
+	}{
+		{"anonymous write", http.MethodPost, "", "UNAUTHORIZED"},
+		{"malformed token", http.MethodPost, "Bearer not-a-token", "INVALID_TOKEN"},
+		{"expired token", http.MethodPost, "Bearer " + expired, "TOKEN_EXPIRED"},
+		{"other scheme", http.MethodPost, "Basic dXNlcjpwYXNz", "INVALID_TOKEN"},
+		{"bad token on read", http.MethodGet, "Bearer not-a-token", "INVALID_TOKEN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/games", strings.NewReader(`{}`))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("expected 401, got %d", rec.Code)
+			}
+			if rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate challenge")
+			}
+			var resp api.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("expected JSON body, got error %v", err)
+			}
+			if resp.Code != tt.expectedCode {
+				t.Errorf("expected code %s, got %v", tt.expectedCode, resp.Code)
+			}
+		})
+	}
+}
+
+func TestLogin_IssuesUsableToken(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("hunter22"), bcrypt.MinCost)
+	queries := &stubQueries{
+		getCredentialsByEmail: func(ctx context.Context, email string) (db.GetCredentialsByEmailRow, error) {
+			if email != "john@example.com" {
+				return db.GetCredentialsByEmailRow{}, sql.ErrNoRows
+			}
+			return db.GetCredentialsByEmailRow{ID: 3, PasswordHash: string(hash)}, nil
+		},
+		createRefreshToken: func(ctx context.Context, arg db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+			return db.RefreshToken{ID: 1, UserID: arg.UserID}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	body := strings.NewReader(`{"email": "john@example.com", "password": "hunter22"}`)
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/login", body))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.TokenResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	principal, err := auth.NewSigner([]byte(testJWTSecret), time.Hour).Verify(resp.AccessToken)
+	if err != nil || principal.UserID != 3 {
+		t.Errorf("expected a token for user 3, got %+v (%v)", principal, err)
+	}
+	if resp.RefreshToken == "" {
+		t.Error("expected a refresh token")
+	}
+
+	rec = httptest.NewRecorder()
+	body = strings.NewReader(`{"email": "john@example.com", "password": "wrong"}`)
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/login", body))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a wrong password, got %d", rec.Code)
+	}
+}
+
+func TestRegister_NeverReturnsPassword(t *testing.T) {
+	queries := &stubQueries{
+		getUserByEmail: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{}, sql.ErrNoRows
+		},
+		createUserWithPassword: func(ctx context.Context, arg db.CreateUserWithPasswordParams) (db.User, error) {
+			return db.User{ID: 1, Name: arg.Name, Email: arg.Email}, nil
+		},
+	}
+	cfg := testConfig()
+	cfg.PasswordHashCost = bcrypt.MinCost
+	router := SetupRouter(NewServer(queries, cfg))
+
+	rec := httptest.NewRecorder()
+	body := strings.NewReader(`{"name": "Jane Doe", "email": "jane@example.com", "password": "hunter22"}`)
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/register", body))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "password") || strings.Contains(rec.Body.String(), "hunter22") {
+		t.Errorf("expected no password material in the response, got %s", rec.Body.String())
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Email: "jane@example.com", Version: 1}, nil
+		},
+		verifyUserEmail: func(ctx context.Context, arg db.VerifyUserEmailParams) (db.User, error) {
+			verifiedAt := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+			return db.User{ID: arg.ID, Email: arg.Email, Version: 2, EmailVerifiedAt: verifiedAt}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+	signer := auth.NewSigner([]byte(testJWTSecret), time.Hour)
+	verification, _ := signer.IssueEmailVerification(1, "jane@example.com", time.Hour)
+	access, _ := signer.Issue(1)
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"verification token", verification, http.StatusOK},
+		{"access token", access, http.StatusBadRequest},
+		{"garbage", "not-a-token", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		body := strings.NewReader(`{"token": "` + tt.token + `"}`)
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/verify-email", body))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.status, rec.Code, rec.Body.String())
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		var user api.User
+		if err := json.NewDecoder(rec.Body).Decode(&user); err != nil {
+			t.Fatalf("expected JSON body, got error %v", err)
+		}
+		if user.EmailVerifiedAt == nil {
+			t.Errorf("%s: expected email_verified_at to be set", tt.name)
+		}
+	}
+}
+
+func TestAuthenticator_RejectsVerificationTokens(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+	token, _ := auth.NewSigner([]byte(testJWTSecret), time.Hour).IssueEmailVerification(1, "jane@example.com", time.Hour)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/me/api-keys", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// UploadAvatar handles PUT /users/me/avatar
+// Replaces the caller's avatar with the image in the avatar form field
+func (s *Server) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.avatarMaxBytes)
+	if err := r.ParseMultipartForm(s.avatarMaxBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Avatar must be at most %d bytes", s.avatarMaxBytes), "AVATAR_TOO_LARGE")
+			return
+		}
+		writeError(w, r, http.StatusBadRequest, "Request body must be a multipart form", "INVALID_REQUEST")
+		return
+	}
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "The avatar field must hold an image file", "INVALID_INPUT")
+		return
+	}
+	defer file.Close()
+	
+	user, err := s.userService.SetAvatar(r.Context(), file)
+	if err != nil {
+		writeServiceError(w, r, err, "Error uploading avatar")
+		return
+	}
+	
+	setUserETag(w, user)
+	s.writeJSON(w, r, http.StatusOK, dbUserToAPIUser(user))
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// encodeBinary encodes data, a response linked by addLinks, as message in
+// Protobuf when message isn't nil, and as MessagePack of its JSON otherwise
+// It returns the Content-Type to send the encoding with, which for Protobuf
+// names the message type so generic decoders know what to decode.
+func encodeBinary(data any, message proto.Message) ([]byte, string, error) {
+	if message != nil {
+		contentType := contentTypeProtobuf + "; messageType=" + string(proto.MessageName(message))
+		encoded, err := proto.Marshal(message)
+		return encoded, contentType, err
+	}
+	
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, contentTypeMsgPack, err
+	}
+	encoded, err := encodeMsgPack(body)
+	return encoded, contentTypeMsgPack, err
+}
+
+// encodeMsgPack re-encodes a JSON body as MessagePack, so it carries exactly
+// the fields and values the JSON would, under the same names
+// Integers stay integers rather than becoming floats.
+func encodeMsgPack(body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(msgpackValue(value))
+}
+
+// msgpackValue converts the numbers in a decoded JSON value to integers
+// where they are whole, and to floats otherwise, for MessagePack to encode
+// as such
+func msgpackValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = msgpackValue(v[i])
+		}
+	case map[string]any:
+		for key := range v {
+			v[key] = msgpackValue(v[key])
+		}
+	}
+	return value
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/rpc"
+	"github.com/jackc/pgx/v5/pgtype"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetGame_BinaryFormats(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{getGameBySlug: gamesBySlug(db.Game{ID: 7, Slug: "sm64", Name: "Super Mario 64"})}, testConfig()))
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64?fields=id,slug", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", accept, rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Header().Get("Vary"), "Accept") {
+			t.Errorf("%s: expected the response to vary by Accept", accept)
+		}
+		return rec
+	}
+
+	rec := get("application/msgpack")
+	if got := rec.Header().Get("Content-Type"); got != contentTypeMsgPack {
+		t.Errorf("expected MessagePack, got %q", got)
+	}
+	var game map[string]any
+	if err := msgpack.Unmarshal(rec.Body.Bytes(), &game); err != nil {
+		t.Fatalf("failed to decode MessagePack: %v", err)
+	}
+	_, isFloat := game["id"].(float64)
+	if game["slug"] != "sm64" || fmt.Sprint(game["id"]) != "7" || isFloat || game["name"] != nil || game["_links"] == nil {
+		t.Errorf("expected the same fields as the JSON, with whole numbers as integers, got %v", game)
+	}
+
+	// Games have no message type, so they aren't sent as Protobuf
+	if rec := get("application/x-protobuf, application/json;q=0.5"); rec.Header().Get("Content-Type") != contentTypeJSON {
+		t.Errorf("expected JSON when Protobuf is preferred, got %q", rec.Header().Get("Content-Type"))
+	}
+	req := httptest.NewRequest(http.MethodGet, "/games/sm64", nil)
+	req.Header.Set("Accept", contentTypeProtobuf)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotAcceptable || !strings.Contains(rec.Body.String(), "NOT_ACCEPTABLE") {
+		t.Errorf("expected 406 for Protobuf alone, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := get("*/*"); rec.Header().Get("Content-Type") != contentTypeJSON {
+		t.Errorf("expected JSON for any type, got %q", rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestGetUser_Protobuf(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Ada", Email: "ada@example.com", TwitchHandle: pgtype.Text{String: "ada", Valid: true}}, nil
+		},
+	}, testConfig()))
+
+	for _, target := range []string{"/users/1", "/users/1?fields=id"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept", contentTypeProtobuf)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Content-Type"); got != contentTypeProtobuf+"; messageType=speedrun.v1.User" {
+			t.Errorf("%s: expected a User message, got %q", target, got)
+		}
+		var user rpc.User
+		if err := proto.Unmarshal(rec.Body.Bytes(), &user); err != nil {
+			t.Fatalf("%s: failed to decode Protobuf: %v", target, err)
+		}
+		if user.GetId() != 1 || user.GetName() != "Ada" || user.GetTwitchHandle() != "ada" {
+			t.Errorf("%s: expected the user, got %v", target, &user)
+		}
+		if user.GetEmail() != "" {
+			t.Errorf("%s: expected the email to be left out for an anonymous caller, got %q", target, user.GetEmail())
+		}
+	}
+}
+
+func TestWriteJSON_WritesStayJSON(t *testing.T) {
+	s := NewServer(&stubQueries{}, testConfig())
+	req := httptest.NewRequest(http.MethodPost, "/games", nil)
+	req.Header.Set("Accept", contentTypeMsgPack)
+	rec := httptest.NewRecorder()
+
+	s.writeJSON(rec, req, http.StatusCreated, map[string]int{"id": 1})
+
+	if got := rec.Header().Get("Content-Type"); got != contentTypeJSON {
+		t.Errorf("expected a write answered with JSON, got %q", got)
+	}
+}
+
+func TestWriteTaggedJSON_TagsEachFormat(t *testing.T) {
+	s := NewServer(&stubQueries{}, testConfig())
+	write := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64/categories/any/leaderboard", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		s.writeTaggedJSON(rec, req, map[string]int{"total": 1})
+		return rec
+	}
+
+	plain, binary := write(contentTypeJSON), write(contentTypeMsgPack)
+	if binary.Header().Get("Content-Type") != contentTypeMsgPack {
+		t.Fatalf("expected MessagePack, got %q", binary.Header().Get("Content-Type"))
+	}
+	if plain.Header().Get("ETag") == binary.Header().Get("ETag") {
+		t.Errorf("expected each format to have its own ETag, got %q for both", plain.Header().Get("ETag"))
+	}
+	if err := msgpack.Unmarshal(binary.Body.Bytes(), new(map[string]int)); err != nil {
+		t.Errorf("failed to decode MessagePack: %v", err)
+	}
+}
+
+func TestWriteTaggedJSON_Protobuf(t *testing.T) {
+	s := NewServer(&stubQueries{}, testConfig())
+	write := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64/categories/any/leaderboard", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		s.writeTaggedJSON(rec, req, leaderboardResponse{
+			Entries: []api.LeaderboardEntry{{Rank: 1, RunId: 4, UserId: 2, UserName: "Grace", TimeMs: 90000, PlayedOn: openapi_types.Date{Time: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)}}},
+			Total:   1,
+		})
+		return rec
+	}
+
+	plain, binary := write(contentTypeJSON), write(contentTypeProtobuf)
+	if got := binary.Header().Get("Content-Type"); got != contentTypeProtobuf+"; messageType=speedrun.v1.GetLeaderboardResponse" {
+		t.Fatalf("expected a GetLeaderboardResponse message, got %q", got)
+	}
+	if plain.Header().Get("ETag") == binary.Header().Get("ETag") {
+		t.Errorf("expected each format to have its own ETag, got %q for both", plain.Header().Get("ETag"))
+	}
+	var leaderboard rpc.GetLeaderboardResponse
+	if err := proto.Unmarshal(binary.Body.Bytes(), &leaderboard); err != nil {
+		t.Fatalf("failed to decode Protobuf: %v", err)
+	}
+	entries := leaderboard.GetEntries()
+	if leaderboard.GetTotal() != 1 || len(entries) != 1 || entries[0].GetRunId() != 4 || entries[0].GetPlayedOn() != "2024-01-15" {
+		t.Errorf("expected the standings, got %v", &leaderboard)
+	}
+}
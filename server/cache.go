@@ -0,0 +1,27 @@
+package server
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/example/speedrun-rest-api/cache"
+	"github.com/example/speedrun-rest-api/config"
+)
+
+// newCache creates the cache selected by the configuration; nil when
+// caching is disabled
+func newCache(cfg *config.Config) *cache.Cache {
+	if !cfg.CacheEnabled {
+		return nil
+	}
+	if cfg.RedisURL == "" {
+		return cache.New(cache.NewMemory(), cfg.CacheTTL)
+	}
+	
+	store, err := cache.NewRedis(cfg.RedisURL)
+	if err != nil {
+		slog.Error("Unable to set up caching", "error", err)
+		os.Exit(1)
+	}
+	return cache.New(store, cfg.CacheTTL)
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// metadataRoutes describe games and change only when someone edits them
+var metadataRoutes = []string{
+	"/games",
+	"/games/suggest",
+	"/games/{slug}",
+	"/games/{slug}/categories",
+	"/games/{slug}/variables",
+	"/games/{slug}/levels",
+	"/platforms",
+	"/regions",
+}
+
+// leaderboardRoutes change whenever a run is verified or rejected
+var leaderboardRoutes = []string{
+	"/games/{slug}/categories/{category}/leaderboard",
+	"/games/{slug}/levels/{level}/leaderboard",
+	"/games/{slug}/categories/{category}/records/history",
+}
+
+// CacheControl tells browsers and CDNs how long they may reuse successful
+// reads of public resources, by the route that serves them
+type CacheControl struct {
+	maxAges map[string]time.Duration
+}
+
+// NewCacheControl creates a CacheControl letting game metadata be reused for
+// metadataMaxAge and leaderboards for leaderboardMaxAge; a zero max age
+// leaves those routes without a Cache-Control header
+func NewCacheControl(metadataMaxAge, leaderboardMaxAge time.Duration) *CacheControl {
+	maxAges := map[string]time.Duration{}
+	for _, route := range metadataRoutes {
+		maxAges[route] = metadataMaxAge
+	}
+	for _, route := range leaderboardRoutes {
+		maxAges[route] = leaderboardMaxAge
+	}
+	return &CacheControl{maxAges: maxAges}
+}
+
+// Middleware sets Cache-Control on 200 and 304 responses to GET and HEAD
+// requests for the routes in routes that have a max age; errors are never
+// cached. Responses to requests with credentials are marked private, so a
+// CDN never stores them, while anonymous ones are public.
+func (c *CacheControl) Middleware(routes chi.Routes) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			
+			// The middleware runs before routing, so the route is looked up
+			// the same way the router will
+			rctx := chi.NewRouteContext()
+			if !routes.Match(rctx, http.MethodGet, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			maxAge := c.maxAges[rctx.RoutePattern()]
+			if maxAge <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			
+			scope := "public"
+			if r.Header.Get("Authorization") != "" {
+				scope = "private"
+			}
+			value := scope + ", max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+			next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, value: value}, r)
+		})
+	}
+}
+
+// cacheControlWriter sets Cache-Control as the status is written, once it
+// is known to be a success
+type cacheControlWriter struct {
+	http.ResponseWriter
+	value       string
+	wroteHeader bool
+}
+
+func (w *cacheControlWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if (status == http.StatusOK || status == http.StatusNotModified) && w.Header().Get("Cache-Control") == "" {
+			w.Header().Set("Cache-Control", w.value)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheControlWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *cacheControlWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
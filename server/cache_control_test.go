@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestCacheControl(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(NewCacheControl(5*time.Minute, 30*time.Second).Middleware(r))
+	ok := func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("{}")) }
+	r.Get("/games/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		if chi.URLParam(r, "slug") == "missing" {
+			notFound(w, r)
+			return
+		}
+		ok(w, r)
+	})
+	r.Put("/games/{slug}", ok)
+	r.Get("/games/{slug}/categories/{category}/leaderboard", ok)
+	r.Get("/users/{id}", ok)
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		expected      string
+	}{
+		{"game metadata", http.MethodGet, "/games/sm64", "", "public, max-age=300"},
+		{"leaderboard", http.MethodGet, "/games/sm64/categories/any/leaderboard", "", "public, max-age=30"},
+		{"with credentials", http.MethodGet, "/games/sm64", "Bearer token", "private, max-age=300"},
+		{"error", http.MethodGet, "/games/missing", "", ""},
+		{"write", http.MethodPut, "/games/sm64", "", ""},
+		{"other route", http.MethodGet, "/users/1", "", ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.authorization != "" {
+			req.Header.Set("Authorization", tt.authorization)
+		}
+		r.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Cache-Control"); got != tt.expected {
+			t.Errorf("%s: expected Cache-Control %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestCacheControl_ZeroMaxAgeDisables(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(NewCacheControl(5*time.Minute, 0).Middleware(r))
+	r.Get("/games/{slug}/levels/{level}/leaderboard", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/sm64/levels/bob/leaderboard", nil))
+	if got := rec.Header().Get("Cache-Control"); got != "" {
+		t.Errorf("expected no Cache-Control, got %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListCategories handles GET /games/{slug}/categories
+// Retrieves a game's categories in display order
+func (s *Server) ListCategories(w http.ResponseWriter, r *http.Request, slug string) {
+	categories, err := s.categoryService.ListCategories(r.Context(), slug)
+	if err != nil {
+		writeServiceError(w, r, err, "Error listing categories")
+		return
+	}
+	
+	apiCategories := make([]api.Category, len(categories))
+	for i, category := range categories {
+		apiCategories[i] = dbCategoryToAPICategory(&category)
+	}
+	
+	response := struct {
+		Categories []api.Category `json:"categories"`
+	}{
+		Categories: apiCategories,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateCategory handles POST /games/{slug}/categories
+// Adds a category to a game
+func (s *Server) CreateCategory(w http.ResponseWriter, r *http.Request, slug string) {
+	var req api.CreateCategoryRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	input := service.CreateCategoryInput{
+		Slug: req.Slug,
+		Name: req.Name,
+	}
+	if req.Rules != nil {
+		input.Rules = *req.Rules
+	}
+	if req.Position != nil {
+		position := int32(*req.Position)
+		input.Position = &position
+	}
+	if req.IsDefault != nil {
+		input.IsDefault = *req.IsDefault
+	}
+	if req.TimingMethod != nil {
+		input.TimingMethod = string(*req.TimingMethod)
+	}
+	
+	category, err := s.categoryService.CreateCategory(r.Context(), slug, input)
+	if err != nil {
+		writeServiceError(w, r, err, "Error creating category")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, dbCategoryToAPICategory(category))
+}
+
+// dbCategoryToAPICategory converts a database Category model to an API Category model
+func dbCategoryToAPICategory(category *db.Category) api.Category {
+	return api.Category{
+		Id:           int(category.ID),
+		GameId:       int(category.GameID),
+		Slug:         category.Slug,
+		Name:         category.Name,
+		Rules:        category.Rules,
+		Position:     int(category.Position),
+		IsDefault:    category.IsDefault,
+		TimingMethod: api.TimingMethod(category.TimingMethod),
+		CreatedAt:    category.CreatedAt.Time.UTC(),
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// clientIPKey is the context key under which the resolved client IP is stored
+type clientIPKey struct{}
+
+// ClientIP resolves the address of the client that made a request
+//
+// X-Forwarded-For is only honored when the request arrives from a trusted
+// proxy; otherwise anyone could spoof their address by sending the header.
+type ClientIP struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIP creates a resolver that trusts forwarding headers from proxies
+// within the given networks
+func NewClientIP(trusted []netip.Prefix) *ClientIP {
+	return &ClientIP{trusted: trusted}
+}
+
+// Middleware stores the resolved client IP in the request context
+// Use ClientIPFromContext to read it in downstream handlers.
+func (c *ClientIP) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPKey{}, c.Resolve(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Resolve returns the client IP for a request
+//
+// When the direct peer is a trusted proxy, X-Forwarded-For is walked from
+// right to left, skipping further trusted proxies, and the first untrusted
+// address is the client. Otherwise the peer address itself is returned.
+func (c *ClientIP) Resolve(r *http.Request) string {
+	remote := remoteIP(r.RemoteAddr)
+	if !c.isTrusted(remote) {
+		return remote.String()
+	}
+	
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// A malformed hop means the rest of the chain can't be trusted
+			break
+		}
+		client = addr.Unmap()
+		if !c.isTrusted(client) {
+			break
+		}
+	}
+	
+	return client.String()
+}
+
+// isTrusted reports whether addr belongs to a trusted proxy network
+func (c *ClientIP) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range c.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP parses the IP from an http.Request RemoteAddr ("host:port")
+func remoteIP(remoteAddr string) netip.Addr {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap()
+}
+
+// ClientIPFromContext returns the client IP resolved by ClientIP.Middleware,
+// or an empty string if the middleware did not run
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
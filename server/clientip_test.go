@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestClientIP_Resolve(t *testing.T) {
+	resolver := NewClientIP([]netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("::1/128"),
+	})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		expected   string
+	}{
+		{"no proxy", "203.0.113.7:5432", nil, "203.0.113.7"},
+		{"spoofed header from untrusted source", "203.0.113.7:5432", []string{"1.2.3.4"}, "203.0.113.7"},
+		{"trusted proxy", "10.0.0.2:8080", []string{"198.51.100.9"}, "198.51.100.9"},
+		{"trusted proxy chain", "10.0.0.2:8080", []string{"198.51.100.9, 10.0.0.5"}, "198.51.100.9"},
+		{"client-supplied spoof behind trusted proxy", "10.0.0.2:8080", []string{"1.2.3.4, 198.51.100.9"}, "198.51.100.9"},
+		{"multiple headers", "10.0.0.2:8080", []string{"1.2.3.4", "198.51.100.9"}, "198.51.100.9"},
+		{"malformed hop", "10.0.0.2:8080", []string{"198.51.100.9, not-an-ip"}, "10.0.0.2"},
+		{"trusted proxy without header", "10.0.0.2:8080", nil, "10.0.0.2"},
+		{"ipv6 trusted proxy", "[::1]:8080", []string{"2001:db8::1"}, "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		r.RemoteAddr = tt.remoteAddr
+		for _, value := range tt.forwarded {
+			r.Header.Add("X-Forwarded-For", value)
+		}
+
+		if got := resolver.Resolve(r); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestClientIP_Middleware(t *testing.T) {
+	resolver := NewClientIP(nil)
+	var got string
+	handler := resolver.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ClientIPFromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.RemoteAddr = "203.0.113.7:5432"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if got != "203.0.113.7" {
+		t.Errorf("expected client IP from RemoteAddr, got %q", got)
+	}
+}
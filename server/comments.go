@@ -0,0 +1,117 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// commentRetryAfter is the Retry-After sent with throttled comments, the
+// length of the window comments are counted over
+const commentRetryAfter = "60"
+
+// ListRunComments handles GET /runs/{id}/comments
+// Lists a run's comments, oldest first
+func (s *Server) ListRunComments(w http.ResponseWriter, r *http.Request, id int, params api.ListRunCommentsParams) {
+	page, err := s.commentService.ListComments(r.Context(), int32(id), pageRequest(params.Limit, params.Offset, params.Cursor))
+	if err != nil {
+		s.writeCommentError(w, r, err)
+		return
+	}
+	
+	comments := make([]api.RunComment, len(page.Comments))
+	for i, comment := range page.Comments {
+		comments[i] = toAPIRunComment(&comment)
+	}
+	
+	response := struct {
+		Comments   []api.RunComment `json:"comments"`
+		Total      int64            `json:"total"`
+		Limit      int32            `json:"limit"`
+		Offset     int32            `json:"offset"`
+		NextCursor string           `json:"next_cursor,omitempty"`
+	}{
+		Comments:   comments,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateRunComment handles POST /runs/{id}/comments
+// Leaves a comment from the caller on a run
+func (s *Server) CreateRunComment(w http.ResponseWriter, r *http.Request, id int) {
+	var req api.RunCommentRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	comment, err := s.commentService.CreateComment(r.Context(), int32(id), req.Body)
+	if err != nil {
+		s.writeCommentError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, toAPIRunComment(comment))
+}
+
+// UpdateRunComment handles PUT /runs/{id}/comments/{commentId}
+// Edits one of the caller's comments while its edit window is open
+func (s *Server) UpdateRunComment(w http.ResponseWriter, r *http.Request, id int, commentId int) {
+	var req api.RunCommentRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	comment, err := s.commentService.UpdateComment(r.Context(), int32(id), int32(commentId), req.Body)
+	if err != nil {
+		s.writeCommentError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, toAPIRunComment(comment))
+}
+
+// DeleteRunComment handles DELETE /runs/{id}/comments/{commentId}
+// Removes a comment; requires a moderator of the run's game
+func (s *Server) DeleteRunComment(w http.ResponseWriter, r *http.Request, id int, commentId int) {
+	if err := s.commentService.DeleteComment(r.Context(), int32(id), int32(commentId)); err != nil {
+		s.writeCommentError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeCommentError maps CommentService errors to responses
+func (s *Server) writeCommentError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmailNotVerified):
+		writeError(w, r, http.StatusForbidden, "Verify your email address before commenting", "EMAIL_NOT_VERIFIED")
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, r, http.StatusForbidden, "Not allowed to change this comment", "FORBIDDEN")
+	case errors.Is(err, service.ErrTooManyComments):
+		w.Header().Set("Retry-After", commentRetryAfter)
+		writeError(w, r, http.StatusTooManyRequests, "Too many comments, try again later", "COMMENT_RATE_LIMITED")
+	default:
+		writeServiceError(w, r, err, "Error handling run comment")
+	}
+}
+
+// toAPIRunComment converts a database RunComment to an API RunComment model
+func toAPIRunComment(comment *db.RunComment) api.RunComment {
+	return api.RunComment{
+		Id:        int(comment.ID),
+		RunId:     int(comment.RunID),
+		UserId:    int(comment.UserID),
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt.Time.UTC(),
+		UpdatedAt: comment.UpdatedAt.Time.UTC(),
+	}
+}
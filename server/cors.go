@@ -0,0 +1,146 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/config"
+)
+
+// corsExposedHeaders are the response headers, beyond those every response
+// may show, that scripts on other origins are allowed to read
+var corsExposedHeaders = []string{
+	"ETag",
+	"Location",
+	"Retry-After",
+	"Content-Disposition",
+	"Idempotent-Replayed",
+	"Preference-Applied",
+	"Deprecation",
+	"Sunset",
+	"Link",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+}
+
+// CORS lets browsers call the API from the origins it is configured with
+type CORS struct {
+	origins     []string
+	methods     []string
+	headers     []string
+	credentials bool
+	maxAge      time.Duration
+}
+
+// NewCORS creates a CORS policy from the CORS_* settings; with no allowed
+// origins, cross-origin requests are left to the browser to refuse
+func NewCORS(cfg *config.Config) *CORS {
+	return &CORS{
+		origins:     cfg.CORSAllowedOrigins,
+		methods:     cfg.CORSAllowedMethods,
+		headers:     cfg.CORSAllowedHeaders,
+		credentials: cfg.CORSAllowCredentials,
+		maxAge:      cfg.CORSMaxAge,
+	}
+}
+
+// Middleware answers preflight requests and marks the responses to allowed
+// origins as readable by them
+//
+// It must run before the authenticator, maintenance, and the rate limiter:
+// preflights carry no credentials and must not be rejected or counted, and
+// the errors those write have to be readable by the page that caused them.
+func (c *CORS) Middleware(next http.Handler) http.Handler {
+	if len(c.origins) == 0 {
+		return next
+	}
+	
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		
+		// The allowed origin is echoed unless any origin is, so caches must
+		// not hand one origin's response to another
+		if !c.anyOrigin() {
+			h.Add("Vary", "Origin")
+		}
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			c.preflight(w, r, origin)
+			return
+		}
+		if c.allowsOrigin(origin) {
+			c.allowOrigin(h, origin)
+			h.Set("Access-Control-Expose-Headers", strings.Join(corsExposedHeaders, ", "))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight answers a preflight request with 204 No Content when the
+// origin, method, and headers it asks about are all allowed, and with 403
+// Forbidden and no CORS headers, which the browser reports as a failure,
+// when any is not
+func (c *CORS) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	h := w.Header()
+	h.Add("Vary", "Access-Control-Request-Method")
+	h.Add("Vary", "Access-Control-Request-Headers")
+	
+	if !c.allowsOrigin(origin) {
+		writeError(w, r, http.StatusForbidden, "Origin not allowed", "CORS_FORBIDDEN")
+		return
+	}
+	method := r.Header.Get("Access-Control-Request-Method")
+	if !slices.Contains(c.methods, method) {
+		writeError(w, r, http.StatusForbidden, "Method not allowed for cross-origin requests", "CORS_FORBIDDEN")
+		return
+	}
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !slices.ContainsFunc(c.headers, func(allowed string) bool { return strings.EqualFold(allowed, header) }) {
+			writeError(w, r, http.StatusForbidden, "Header "+header+" not allowed for cross-origin requests", "CORS_FORBIDDEN")
+			return
+		}
+	}
+	
+	c.allowOrigin(h, origin)
+	h.Set("Access-Control-Allow-Methods", strings.Join(c.methods, ", "))
+	if len(c.headers) > 0 {
+		h.Set("Access-Control-Allow-Headers", strings.Join(c.headers, ", "))
+	}
+	h.Set("Access-Control-Max-Age", strconv.Itoa(int(c.maxAge.Seconds())))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowOrigin sets the headers granting origin access to the response
+func (c *CORS) allowOrigin(h http.Header, origin string) {
+	if c.anyOrigin() {
+		h.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+	}
+	if c.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// anyOrigin reports whether every origin is allowed
+func (c *CORS) anyOrigin() bool {
+	return slices.Contains(c.origins, "*")
+}
+
+// allowsOrigin reports whether origin may call the API; origins are
+// compared case-insensitively, as their scheme and host are
+func (c *CORS) allowsOrigin(origin string) bool {
+	return c.anyOrigin() || slices.ContainsFunc(c.origins, func(allowed string) bool {
+		return strings.EqualFold(allowed, origin)
+	})
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// corsRouter serves sm64 with CORS allowed from origins
+func corsRouter(credentials bool, origins ...string) http.Handler {
+	cfg := testConfig()
+	cfg.CORSAllowedOrigins = origins
+	cfg.CORSAllowCredentials = credentials
+	return SetupRouter(NewServer(&stubQueries{getGameBySlug: gamesBySlug(db.Game{ID: 1, Slug: "sm64"})}, cfg))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	router := corsRouter(true, "https://app.example.com")
+
+	tests := []struct {
+		name     string
+		origin   string
+		method   string
+		headers  string
+		expected int
+	}{
+		{"allowed", "https://app.example.com", http.MethodPut, "authorization, if-match", http.StatusNoContent},
+		{"other origin", "https://evil.example.com", http.MethodPut, "", http.StatusForbidden},
+		{"method not allowed", "https://app.example.com", "TRACE", "", http.StatusForbidden},
+		{"header not allowed", "https://app.example.com", http.MethodPut, "x-debug", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodOptions, "/games/sm64", nil)
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("Access-Control-Request-Method", tt.method)
+		if tt.headers != "" {
+			req.Header.Set("Access-Control-Request-Headers", tt.headers)
+		}
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tt.expected {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.expected, rec.Code)
+		}
+		allowOrigin := rec.Header().Get("Access-Control-Allow-Origin")
+		if tt.expected != http.StatusNoContent {
+			if allowOrigin != "" {
+				t.Errorf("%s: expected no Access-Control-Allow-Origin, got %q", tt.name, allowOrigin)
+			}
+			continue
+		}
+		if allowOrigin != tt.origin || rec.Header().Get("Access-Control-Allow-Credentials") != "true" {
+			t.Errorf("%s: expected the origin allowed with credentials, got %v", tt.name, rec.Header())
+		}
+		if methods := rec.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(methods, "PUT") {
+			t.Errorf("%s: expected PUT among the allowed methods, got %q", tt.name, methods)
+		}
+		if headers := rec.Header().Get("Access-Control-Allow-Headers"); !strings.Contains(headers, "Idempotency-Key") || !strings.Contains(headers, "If-Match") {
+			t.Errorf("%s: expected Idempotency-Key and If-Match allowed, got %q", tt.name, headers)
+		}
+		if maxAge := rec.Header().Get("Access-Control-Max-Age"); maxAge != "600" {
+			t.Errorf("%s: expected Access-Control-Max-Age 600, got %q", tt.name, maxAge)
+		}
+	}
+}
+
+func TestCORS_Request(t *testing.T) {
+	router := corsRouter(false, "https://app.example.com")
+
+	for origin, allowed := range map[string]bool{"https://app.example.com": true, "https://evil.example.com": false, "": false} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("origin %q: expected the request served, got %d", origin, rec.Code)
+		}
+		expected := ""
+		if allowed {
+			expected = origin
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != expected {
+			t.Errorf("origin %q: expected Access-Control-Allow-Origin %q, got %q", origin, expected, got)
+		}
+		if allowed && !strings.Contains(rec.Header().Get("Access-Control-Expose-Headers"), "ETag") {
+			t.Errorf("origin %q: expected the ETag exposed, got %q", origin, rec.Header().Get("Access-Control-Expose-Headers"))
+		}
+		if rec.Header().Get("Access-Control-Allow-Credentials") != "" {
+			t.Errorf("origin %q: expected no credentials", origin)
+		}
+		if vary := rec.Header().Values("Vary"); !slices.Contains(vary, "Origin") {
+			t.Errorf("origin %q: expected Vary: Origin, got %v", origin, vary)
+		}
+	}
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	router := corsRouter(false, "*")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/games/sm64", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("expected any origin allowed, got %q", got)
+	}
+	if vary := rec.Header().Values("Vary"); slices.Contains(vary, "Origin") {
+		t.Errorf("expected no Vary: Origin for a wildcard, got %v", vary)
+	}
+}
+
+func TestCORS_DisabledWithoutOrigins(t *testing.T) {
+	router := corsRouter(false)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/games/sm64", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no CORS headers, got %q", got)
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/config"
+)
+
+// maxJSONBodyBytes caps the JSON request bodies read, whether to validate
+// them or to decode them
+const maxJSONBodyBytes = 1 << 20
+
+// maxRequestBodyBytes is the largest request body any handler accepts: an
+// import file, an avatar, or a JSON or GraphQL body
+func maxRequestBodyBytes(cfg *config.Config) int64 {
+	return max(maxImportBodyBytes, maxJSONBodyBytes, graphQLMaxBodyBytes, int64(cfg.AvatarMaxBytes))
+}
+
+// errTrailingData is returned when a request body holds more than one JSON
+// value
+var errTrailingData = errors.New("request body has data after its JSON value")
+
+// decodeJSONBody strictly decodes the request body into dst
+// A missing or whitespace-only body is reported as EMPTY_BODY so clients can
+// tell it apart from malformed JSON. Fields dst has no place for and data
+// after the JSON value are rejected rather than ignored, so a typo such as
+// "emial" isn't silently dropped, and bodies over maxJSONBodyBytes get
+// BODY_TOO_LARGE. Returns false once an error is written.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodyBytes))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(dst)
+	if errors.Is(err, io.EOF) {
+		writeError(w, r, http.StatusBadRequest, "Request body is required", "EMPTY_BODY")
+		return false
+	}
+	if err == nil {
+		if err = dec.Decode(&json.RawMessage{}); errors.Is(err, io.EOF) {
+			return true
+		}
+		var tooLarge *http.MaxBytesError
+		if !errors.As(err, &tooLarge) {
+			err = errTrailingData
+		}
+	}
+	
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeBodyTooLarge(w, r, tooLarge)
+		return false
+	}
+	details := []api.ErrorDetail{decodeErrorDetail(err)}
+	problem := newProblem(r, http.StatusBadRequest, "Invalid request body", "INVALID_REQUEST")
+	problem.Errors = &details
+	writeProblem(w, problem)
+	return false
+}
+
+// writeBodyTooLarge writes a 413 for a request body over its size limit
+func writeBodyTooLarge(w http.ResponseWriter, r *http.Request, err *http.MaxBytesError) {
+	writeError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must be at most %d bytes", err.Limit), "BODY_TOO_LARGE")
+}
+
+// decodeErrorDetail describes why a request body could not be decoded,
+// naming the offending field when there is one
+func decodeErrorDetail(err error) api.ErrorDetail {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, errTrailingData):
+		return api.ErrorDetail{In: api.Body, Message: "must hold a single JSON value"}
+	case errors.As(err, &syntaxErr):
+		return api.ErrorDetail{In: api.Body, Message: fmt.Sprintf("is not valid JSON at byte %d", syntaxErr.Offset)}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return api.ErrorDetail{In: api.Body, Message: "ends before its JSON value does"}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		field := typeErr.Field
+		return api.ErrorDetail{In: api.Body, Name: &field, Message: "must be " + jsonKind(typeErr.Type)}
+	}
+	
+	// encoding/json reports unknown fields only by their message
+	if quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if field, err := strconv.Unquote(quoted); err == nil {
+			return api.ErrorDetail{In: api.Body, Name: &field, Message: "is not a known field"}
+		}
+	}
+	return api.ErrorDetail{In: api.Body, Message: "is not a valid request body"}
+}
+
+// jsonKind names the kind of JSON value a Go type is decoded from
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	case reflect.Pointer:
+		return jsonKind(t.Elem())
+	}
+	return "a different type"
+}
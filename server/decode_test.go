@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestDecodeJSONBody_Strict(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+		expectedField  string
+	}{
+		{"unknown field", `{"name":"Ada","emial":"ada@example.com"}`, http.StatusBadRequest, "INVALID_REQUEST", "emial"},
+		{"wrong type", `{"name":"Ada","times":{"rta_ms":"fast"}}`, http.StatusBadRequest, "INVALID_REQUEST", "times.rta_ms"},
+		{"trailing data", `{"name":"Ada"} {"name":"Grace"}`, http.StatusBadRequest, "INVALID_REQUEST", ""},
+		{"malformed", `{"name":`, http.StatusBadRequest, "INVALID_REQUEST", ""},
+		{"empty", "  ", http.StatusBadRequest, "EMPTY_BODY", ""},
+		{"too large", `{"name":"` + strings.Repeat("a", maxJSONBodyBytes) + `"}`, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE", ""},
+	}
+
+	for _, tt := range tests {
+		var dst struct {
+			Name  string `json:"name"`
+			Times struct {
+				RtaMs int64 `json:"rta_ms"`
+			} `json:"times"`
+		}
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/runs", strings.NewReader(tt.body))
+		if decodeJSONBody(rec, r, &dst) {
+			t.Errorf("%s: expected the body to be rejected", tt.name)
+			continue
+		}
+
+		var problem api.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+			t.Fatalf("%s: failed to decode problem: %v", tt.name, err)
+		}
+		if rec.Code != tt.expectedStatus || problem.Code != tt.expectedCode {
+			t.Errorf("%s: expected %d %s, got %d %s", tt.name, tt.expectedStatus, tt.expectedCode, rec.Code, problem.Code)
+		}
+		if tt.expectedField == "" {
+			continue
+		}
+		if problem.Errors == nil || len(*problem.Errors) != 1 {
+			t.Fatalf("%s: expected one field error, got %+v", tt.name, problem)
+		}
+		if detail := (*problem.Errors)[0]; detail.Name == nil || *detail.Name != tt.expectedField {
+			t.Errorf("%s: expected an error for %s, got %+v", tt.name, tt.expectedField, detail)
+		}
+	}
+}
+
+func TestDecodeJSONBody_AcceptsSingleValue(t *testing.T) {
+	var dst struct {
+		Name string `json:"name"`
+	}
+	r := httptest.NewRequest(http.MethodPost, "/runs", strings.NewReader("{\"name\":\"Ada\"}\n"))
+	if !decodeJSONBody(httptest.NewRecorder(), r, &dst) || dst.Name != "Ada" {
+		t.Errorf("expected the body to decode, got %+v", dst)
+	}
+}
+
+func TestUpdateUser_RejectsUnknownField(t *testing.T) {
+	updated := false
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Old Name", Email: "old@example.com", Version: 1}, nil
+		},
+		updateUser: func(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+			updated = true
+			return db.User{ID: arg.ID, Name: arg.Name, Email: arg.Email, Version: 2}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"emial": "new@example.com"}`))
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	req.Header.Set("If-Match", `"1"`)
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if updated {
+		t.Error("expected the user not to be updated")
+	}
+	var problem api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if problem.Errors == nil || len(*problem.Errors) != 1 || (*problem.Errors)[0].Name == nil || *(*problem.Errors)[0].Name != "emial" {
+		t.Errorf("expected an error for emial, got %+v", problem)
+	}
+}
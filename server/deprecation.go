@@ -0,0 +1,177 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/metrics"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+)
+
+// Extensions of a deprecated operation in the spec, describing when it was
+// deprecated, when it is to be removed, and the route replacing it
+const (
+	deprecatedAtExtension = "x-deprecated-at"
+	sunsetExtension       = "x-sunset"
+	replacementExtension  = "x-replacement"
+)
+
+// routeDeprecation describes a deprecated route
+type routeDeprecation struct {
+	deprecated time.Time
+	
+	// sunset is zero until a removal date is set
+	sunset time.Time
+	
+	// replacement is the pattern of the route to use instead, such as
+	// /games/{slug}/categories/{category}/runs, or empty when there is none
+	replacement string
+}
+
+// Deprecations announces the deprecation of the operations the spec marks
+// deprecated, and logs and counts their use, so it is known when they can be
+// removed
+type Deprecations struct {
+	routes map[string]routeDeprecation
+	used   *metrics.CounterVec
+}
+
+// NewDeprecations creates Deprecations for the operations spec marks
+// deprecated; each needs an x-deprecated-at date, and may have an x-sunset
+// date and an x-replacement route
+//
+// Returns:
+//   - *Deprecations: The deprecations, keyed by method and route pattern
+//   - error: A deprecated operation's extensions are missing or invalid
+func NewDeprecations(spec *openapi3.T) (*Deprecations, error) {
+	d := &Deprecations{
+		routes: map[string]routeDeprecation{},
+		used: metrics.NewCounterVec("http_deprecated_requests_total",
+			"Number of HTTP requests served by deprecated routes.",
+			"method", "route",
+		),
+	}
+	for path, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			if !op.Deprecated {
+				continue
+			}
+			deprecation, err := parseDeprecation(op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			d.routes[method+" "+path] = deprecation
+		}
+	}
+	return d, nil
+}
+
+// parseDeprecation reads the deprecation extensions of op
+func parseDeprecation(op *openapi3.Operation) (routeDeprecation, error) {
+	deprecated, err := extensionDate(op, deprecatedAtExtension)
+	if err != nil {
+		return routeDeprecation{}, err
+	}
+	if deprecated.IsZero() {
+		return routeDeprecation{}, fmt.Errorf("deprecated operations need %s, the date they were deprecated", deprecatedAtExtension)
+	}
+	sunset, err := extensionDate(op, sunsetExtension)
+	if err != nil {
+		return routeDeprecation{}, err
+	}
+	if !sunset.IsZero() && sunset.Before(deprecated) {
+		return routeDeprecation{}, fmt.Errorf("%s must not be before %s", sunsetExtension, deprecatedAtExtension)
+	}
+	
+	var replacement string
+	if value, ok := op.Extensions[replacementExtension]; ok {
+		replacement, _ = value.(string)
+		if !strings.HasPrefix(replacement, "/") {
+			return routeDeprecation{}, fmt.Errorf("%s must be a route, such as /games/{slug}", replacementExtension)
+		}
+	}
+	return routeDeprecation{deprecated: deprecated, sunset: sunset, replacement: replacement}, nil
+}
+
+// extensionDate reads the date in the extension name of op, which is zero
+// when op doesn't have the extension
+func extensionDate(op *openapi3.Operation, name string) (time.Time, error) {
+	value, ok := op.Extensions[name]
+	if !ok {
+		return time.Time{}, nil
+	}
+	s, _ := value.(string)
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s must be a date, such as 2026-01-31", name)
+}
+
+// Middleware announces the deprecation of the route serving each request,
+// with the headers a deprecated API version gets, linking to the
+// replacement route filled in with the request's parameters
+// It runs inside each operation's handler, once the router has matched the
+// route, and logs each use with the caller, so they can be told to move.
+func (d *Deprecations) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rctx := chi.RouteContext(ctx)
+		if rctx == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		deprecation, ok := d.routes[r.Method+" "+rctx.RoutePattern()]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		var link string
+		if deprecation.replacement != "" {
+			link = servedPrefix(ctx) + expandRoute(deprecation.replacement, rctx)
+		}
+		setDeprecationHeaders(w.Header(), deprecation.deprecated, deprecation.sunset, link, "successor-version")
+		
+		route := routePattern(r)
+		d.used.Inc(r.Method, route)
+		attrs := []any{"method", r.Method, "route", route, "user_agent", r.UserAgent()}
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			attrs = append(attrs, "user_id", p.UserID)
+			if p.ViaAPIKey() {
+				attrs = append(attrs, "api_key_id", p.APIKeyID)
+			}
+		}
+		slog.InfoContext(ctx, "Deprecated route used", attrs...)
+		
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Collect writes the number of requests served by each deprecated route
+func (d *Deprecations) Collect(w *metrics.Writer) {
+	d.used.Collect(w)
+}
+
+// expandRoute fills the parameters of route, such as {slug}, with those of
+// the request matched by rctx
+func expandRoute(route string, rctx *chi.Context) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(route, '{')
+		end := strings.IndexByte(route, '}')
+		if start < 0 || end < start {
+			b.WriteString(route)
+			return b.String()
+		}
+		b.WriteString(route[:start])
+		b.WriteString(rctx.URLParam(route[start+1 : end]))
+		route = route[end+1:]
+	}
+}
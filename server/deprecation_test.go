@@ -0,0 +1,102 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/metrics"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+)
+
+// deprecationSpec builds a spec with GET /games/{slug}/old and the
+// deprecation extensions given, as YAML lines of the operation
+func deprecationSpec(t *testing.T, extensions ...string) *openapi3.T {
+	t.Helper()
+	doc := `openapi: 3.0.3
+info: {title: Test, version: "1"}
+paths:
+  /games/{slug}/old:
+    get:
+      deprecated: true
+` + strings.Join(extensions, "\n") + `
+      responses:
+        "200": {description: OK}
+  /games/{slug}:
+    get:
+      responses:
+        "200": {description: OK}
+`
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(doc))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return spec
+}
+
+func TestDeprecations_Middleware(t *testing.T) {
+	d, err := NewDeprecations(deprecationSpec(t,
+		`      x-deprecated-at: "2026-01-01"`,
+		`      x-sunset: "2026-07-01"`,
+		`      x-replacement: /games/{slug}`,
+	))
+	if err != nil {
+		t.Fatalf("NewDeprecations: %v", err)
+	}
+	r := chi.NewRouter()
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	r.With(d.Middleware).Get("/games/{slug}/old", ok)
+	r.With(d.Middleware).Get("/games/{slug}", ok)
+	handler := apiVersions[0].serve("/v1", r)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/games/sm64/old", nil))
+	for header, expected := range map[string]string{
+		"Deprecation": "@1767225600",
+		"Sunset":      "Wed, 01 Jul 2026 00:00:00 GMT",
+		"Link":        `</v1/games/sm64>; rel="successor-version"`,
+	} {
+		if got := rec.Header().Get(header); got != expected {
+			t.Errorf("expected %s %q, got %q", header, expected, got)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/games/sm64", nil))
+	if rec.Header().Get("Deprecation") != "" {
+		t.Errorf("expected a current route not to be deprecated, got %q", rec.Header().Get("Deprecation"))
+	}
+
+	registry := metrics.NewRegistry()
+	registry.Register(d)
+	var out bytes.Buffer
+	if _, err := registry.WriteTo(&out); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+	expected := `http_deprecated_requests_total{method="GET",route="/v1/games/{slug}/old"} 1`
+	if !strings.Contains(out.String(), expected) {
+		t.Errorf("expected %s in:\n%s", expected, out.String())
+	}
+}
+
+func TestNewDeprecations_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions []string
+	}{
+		{"no deprecation date", nil},
+		{"bad date", []string{`      x-deprecated-at: soon`}},
+		{"sunset first", []string{`      x-deprecated-at: "2026-07-01"`, `      x-sunset: "2026-01-01"`}},
+		{"replacement not a route", []string{`      x-deprecated-at: "2026-01-01"`, `      x-replacement: games`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDeprecations(deprecationSpec(t, tt.extensions...)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
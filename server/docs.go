@@ -0,0 +1,128 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/example/speedrun-rest-api/version"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// openAPISpecs caches the OpenAPI documents served by GetOpenAPISpec, by
+// version and the prefix they are served under
+var openAPISpecs sync.Map
+
+// openAPISpec returns the OpenAPI document v was generated from, encoded as
+// JSON with the build's metadata and the version's name
+// When served under prefix, its one server is that prefix, so requests
+// made from it, such as by the docs page, stay on the version.
+func openAPISpec(v apiVersion, prefix string) ([]byte, error) {
+	key := v.name + prefix
+	if cached, ok := openAPISpecs.Load(key); ok {
+		return cached.([]byte), nil
+	}
+	
+	spec, err := v.spec()
+	if err != nil {
+		return nil, err
+	}
+	if spec.Info.Extensions == nil {
+		spec.Info.Extensions = map[string]any{}
+	}
+	spec.Info.Extensions["x-build-version"] = version.Version
+	spec.Info.Extensions["x-build-commit"] = version.Commit
+	spec.Info.Extensions["x-api-version"] = v.name
+	if prefix != "" {
+		spec.Servers = openapi3.Servers{{URL: prefix, Description: "Version " + strings.TrimPrefix(v.name, "v") + " of the API"}}
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	openAPISpecs.Store(key, data)
+	return data, nil
+}
+
+// GetOpenAPISpec handles GET /openapi.json
+// Serves the spec the running binary was generated from, for the version
+// the request was sent to, so it always matches the routes being served.
+// The ETag is a hash of the document, so clients can cache it across
+// restarts of the same build.
+func (s *Server) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	spec, err := openAPISpec(servedVersion(r.Context()))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding OpenAPI spec", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	sum := sha256.Sum256(spec)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	buildTime, _ := time.Parse(time.RFC3339, version.BuildTime)
+	http.ServeContent(w, r, "openapi.json", buildTime, bytes.NewReader(spec))
+}
+
+// docsPath is where the explorer is served
+const docsPath = "/docs"
+
+// docsScript starts Swagger UI on the docs page
+const docsScript = `
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({url: "openapi.json", dom_id: "#swagger-ui", validatorUrl: null});
+    };
+  `
+
+// docsPage is the explorer served at /docs; Swagger UI is loaded from a CDN
+// so the binary doesn't have to embed its assets
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Speedrun API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>` + docsScript + `</script>
+</body>
+</html>
+`
+
+// defaultDocsCSP is the docs page's Content-Security-Policy unless one is
+// configured: Swagger UI may come from its CDN, only docsScript may run
+// inline, and requests may only go to the API itself. Swagger UI styles its
+// elements inline, so inline styles are allowed.
+var defaultDocsCSP = func() string {
+	sum := sha256.Sum256([]byte(docsScript))
+	return strings.Join([]string{
+		"default-src 'none'",
+		"script-src https://unpkg.com 'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'",
+		"style-src https://unpkg.com 'unsafe-inline'",
+		"img-src 'self' data:",
+		"connect-src 'self'",
+		"base-uri 'none'",
+		"form-action 'none'",
+		"frame-ancestors 'none'",
+	}, "; ")
+}()
+
+// GetDocs handles GET /docs
+// Serves an interactive explorer of the spec at openapi.json beside it, so
+// /v1/docs explores version 1
+func (s *Server) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(docsPage))
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/version"
+)
+
+func TestGetOpenAPISpec_ServesEmbeddedSpec(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != contentTypeJSON {
+		t.Errorf("expected Content-Type %s, got %q", contentTypeJSON, ct)
+	}
+
+	var spec struct {
+		OpenAPI string                     `json:"openapi"`
+		Info    map[string]any             `json:"info"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to decode spec: %v", err)
+	}
+	if spec.OpenAPI == "" {
+		t.Error("expected the openapi version in the spec")
+	}
+	if _, ok := spec.Paths["/users/{id}"]; !ok {
+		t.Error("expected /users/{id} in the spec's paths")
+	}
+	if spec.Info["x-build-version"] != version.Version || spec.Info["x-build-commit"] != version.Commit {
+		t.Errorf("expected the build's version and commit in info, got %v", spec.Info)
+	}
+}
+
+func TestGetOpenAPISpec_NotModified(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304, got %d", rec.Code)
+	}
+}
+
+func TestGetDocs_LoadsSpec(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected an HTML page, got %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `url: "openapi.json"`) {
+		t.Error("expected the page to load the openapi.json beside it")
+	}
+}
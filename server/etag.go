@@ -0,0 +1,95 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// userETag formats a user's version as a strong ETag, e.g. "3"
+func userETag(user *db.User) string {
+	return strconv.Quote(strconv.FormatInt(int64(user.Version), 10))
+}
+
+// setUserETag sets the ETag header for a response carrying user
+func setUserETag(w http.ResponseWriter, user *db.User) {
+	w.Header().Set("ETag", userETag(user))
+}
+
+// gameETag formats a game's last update as a weak ETag, e.g. W/"1705314600000000"
+// Games have no version column; every change to one sets its updated_at.
+func gameETag(game *db.Game) string {
+	return `W/"` + strconv.FormatInt(game.UpdatedAt.Time.UnixMicro(), 10) + `"`
+}
+
+// bodyETag formats a hash of a response body as a weak ETag, for responses
+// built from many rows that no one version covers
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified answers a GET or HEAD with 304 Not Modified when its
+// If-None-Match header lists the ETag already set on w, or when it has no
+// If-None-Match and its If-Modified-Since is no earlier than the
+// Last-Modified set on w, and reports whether it did; the caller then writes
+// nothing more
+//
+// If-None-Match uses weak comparison, so W/"3" matches "3". The 304 keeps
+// the headers set so far, which include the ETag.
+func notModified(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		etag := w.Header().Get("ETag")
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				w.WriteHeader(http.StatusNotModified)
+				return true
+			}
+		}
+		return false
+	}
+	
+	lastModified, err := http.ParseTime(w.Header().Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// ifMatchVersion reads the user version an If-Match header requires
+// If-Match uses strong comparison, so a weak ETag never matches. Lists of
+// ETags aren't supported and never match either, which fails safe with a 412.
+//
+// Returns:
+//   - int32: The required version, or 0 for "*", which matches any version
+//   - bool: Whether the header could match a version this server issued
+func ifMatchVersion(header string) (int32, bool) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, true
+	}
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 32)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return int32(version), true
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		method      string
+		ifNoneMatch string
+		expected    bool
+	}{
+		{http.MethodGet, `"3"`, true},
+		{http.MethodHead, `"3"`, true},
+		{http.MethodGet, `W/"3"`, true},
+		{http.MethodGet, `"2", "3"`, true},
+		{http.MethodGet, `*`, true},
+		{http.MethodGet, `"2"`, false},
+		{http.MethodGet, ``, false},
+		{http.MethodPut, `"3"`, false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("ETag", `"3"`)
+		req := httptest.NewRequest(tt.method, "/users/1", nil)
+		if tt.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+		}
+
+		if got := notModified(rec, req); got != tt.expected {
+			t.Errorf("%s with If-None-Match %q: expected %v, got %v", tt.method, tt.ifNoneMatch, tt.expected, got)
+		}
+		if tt.expected && rec.Code != http.StatusNotModified {
+			t.Errorf("%s with If-None-Match %q: expected 304, got %d", tt.method, tt.ifNoneMatch, rec.Code)
+		}
+	}
+}
+
+func TestGetUser_NotModified(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Ada", Email: "ada@example.com", Version: 3}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	for ifNoneMatch, expected := range map[string]int{`"3"`: http.StatusNotModified, `"2"`: http.StatusOK} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != expected {
+			t.Errorf("If-None-Match %s: expected %d, got %d", ifNoneMatch, expected, rec.Code)
+		}
+		if etag := rec.Header().Get("ETag"); etag != `"3"` {
+			t.Errorf("If-None-Match %s: expected ETag \"3\", got %q", ifNoneMatch, etag)
+		}
+		if expected == http.StatusNotModified && rec.Body.Len() != 0 {
+			t.Errorf("expected an empty 304, got %q", rec.Body.String())
+		}
+	}
+}
+
+func TestGetGame_NotModified(t *testing.T) {
+	updatedAt := pgtype.Timestamptz{Time: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), Valid: true}
+	game := db.Game{ID: 1, Slug: "sm64", Name: "Super Mario 64", UpdatedAt: updatedAt}
+	router := SetupRouter(NewServer(&stubQueries{getGameBySlug: gamesBySlug(game)}, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/sm64", nil))
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag != `W/"1705314600000000"` {
+		t.Fatalf("expected 200 with a weak ETag of updated_at, got %d with %q", rec.Code, etag)
+	}
+
+	if lastModified := rec.Header().Get("Last-Modified"); lastModified != "Mon, 15 Jan 2024 10:30:00 GMT" {
+		t.Errorf("expected Last-Modified of updated_at, got %q", lastModified)
+	}
+
+	for header, value := range map[string]string{"If-None-Match": etag, "If-Modified-Since": "Mon, 15 Jan 2024 10:30:00 GMT"} {
+		rec = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64", nil)
+		req.Header.Set(header, value)
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("expected 304 for the current %s, got %d", header, rec.Code)
+		}
+		if cacheControl := rec.Header().Get("Cache-Control"); cacheControl != "public, max-age=300" {
+			t.Errorf("expected the 304 to keep Cache-Control, got %q", cacheControl)
+		}
+	}
+}
+
+func TestNotModified_IfModifiedSince(t *testing.T) {
+	tests := []struct {
+		ifModifiedSince string
+		ifNoneMatch     string
+		expected        bool
+	}{
+		{"Mon, 15 Jan 2024 10:30:00 GMT", "", true},
+		{"Tue, 16 Jan 2024 00:00:00 GMT", "", true},
+		{"Mon, 15 Jan 2024 10:29:59 GMT", "", false},
+		{"yesterday", "", false},
+		{"Mon, 15 Jan 2024 10:30:00 GMT", `"2"`, false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("ETag", `"3"`)
+		rec.Header().Set("Last-Modified", "Mon, 15 Jan 2024 10:30:00 GMT")
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64", nil)
+		req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+		if tt.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+		}
+
+		if got := notModified(rec, req); got != tt.expected {
+			t.Errorf("If-Modified-Since %q, If-None-Match %q: expected %v, got %v", tt.ifModifiedSince, tt.ifNoneMatch, tt.expected, got)
+		}
+	}
+}
+
+func TestWriteTaggedJSON(t *testing.T) {
+	s := NewServer(&stubQueries{}, testConfig())
+	write := func(data any, ifNoneMatch string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64/categories/any/leaderboard", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		s.writeTaggedJSON(rec, req, data)
+		return rec
+	}
+
+	first := write(map[string]int{"total": 1}, "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || etag[:2] != "W/" {
+		t.Fatalf("expected 200 with a weak ETag, got %d with %q", first.Code, etag)
+	}
+	if rec := write(map[string]int{"total": 1}, etag); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected an empty 304 for an unchanged body, got %d: %q", rec.Code, rec.Body.String())
+	}
+	rec := write(map[string]int{"total": 2}, etag)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("expected 200 with a new ETag for a changed body, got %d with %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+)
+
+// exportContentTypes are the formats a list endpoint can stream every
+// matching row in, instead of a page of them
+var exportContentTypes = []string{contentTypeCSV, contentTypeNDJSON, contentTypeJSONL}
+
+// exportFlushRows is how many rows of an export are written between flushes,
+// so the client receives rows steadily without a flush per row
+const exportFlushRows = 100
+
+// exportFormat returns the export format the Accept header of r prefers to
+// offers, the formats the endpoint otherwise responds in, or "" when it
+// doesn't prefer one
+func exportFormat(r *http.Request, offers []string) string {
+	chosen := negotiateContentType(r.Header.Get("Accept"), slices.Concat(offers, exportContentTypes))
+	if slices.Contains(exportContentTypes, chosen) {
+		return chosen
+	}
+	return ""
+}
+
+// exportWriter streams the rows of an export as CSV, with a header row of
+// its columns, or as JSON Lines
+//
+// Nothing is sent until the first row is written or the export is closed,
+// so an error before then can still be reported as an error response.
+type exportWriter struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	rc       *http.ResponseController
+	format   string
+	filename string
+	columns  []string
+	csv      *csv.Writer
+	json     *json.Encoder
+	started  bool
+	rows     int
+}
+
+// newExportWriter creates an exportWriter sending rows in format, offering
+// them to be saved as filename with the format's extension
+func newExportWriter(w http.ResponseWriter, r *http.Request, format, filename string, columns []string) *exportWriter {
+	return &exportWriter{w: w, r: r, rc: http.NewResponseController(w), format: format, filename: filename, columns: columns}
+}
+
+// start sends the response headers, and the header row of a CSV export
+func (e *exportWriter) start() error {
+	e.started = true
+	
+	// An export of a large table can take longer than the server's write
+	// timeout
+	if err := e.rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(e.r.Context(), "Unable to lift the write timeout; the export may be cut off by it", "error", err)
+	}
+	e.w.Header().Set("Content-Type", e.format)
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.filename+exportExtension(e.format)))
+	e.w.Header().Set("X-Accel-Buffering", "no")
+	e.w.WriteHeader(http.StatusOK)
+	
+	if e.format != contentTypeCSV {
+		e.json = json.NewEncoder(e.w)
+		return nil
+	}
+	e.csv = csv.NewWriter(e.w)
+	return e.csv.Write(e.columns)
+}
+
+// write sends one row: record, which has a cell per column, in a CSV export
+// and value's JSON otherwise
+func (e *exportWriter) write(value any, record []string) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	
+	var err error
+	if e.csv != nil {
+		err = e.csv.Write(record)
+	} else {
+		err = e.json.Encode(value)
+	}
+	if err != nil {
+		return err
+	}
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// flush sends the rows written so far
+func (e *exportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return e.rc.Flush()
+}
+
+// close finishes the export with the error that ended it, if any
+//
+// An error from before anything was sent is returned for the caller to
+// report. Once rows have been sent an error can only cut the response
+// short, so the connection is aborted, making sure the client can't mistake
+// the rows it got for the whole export.
+func (e *exportWriter) close(err error) error {
+	if err != nil {
+		if !e.started {
+			return err
+		}
+		if e.r.Context().Err() == nil {
+			slog.ErrorContext(e.r.Context(), "Export failed after sending rows", "rows", e.rows, "error", err)
+		}
+		panic(http.ErrAbortHandler)
+	}
+	
+	// An export with no rows still sends a CSV file's header row. Errors
+	// sending it mean the client has gone, so there is no one to report them
+	// to.
+	if !e.started && e.start() != nil {
+		return nil
+	}
+	e.flush()
+	return nil
+}
+
+// exportExtension is the file extension of an export in format
+func exportExtension(format string) string {
+	if format == contentTypeCSV {
+		return ".csv"
+	}
+	return ".jsonl"
+}
+
+// userExportColumns are the columns of a CSV export of users
+var userExportColumns = []string{
+	"id", "public_id", "name", "email", "created_at", "updated_at", "deleted_at", "email_verified_at",
+	"twitch_handle", "youtube_handle", "twitter_handle", "country_code", "pronouns", "bio", "avatar_url",
+}
+
+// userExportRecord is the row of a CSV export for user
+func userExportRecord(user *api.User) []string {
+	return []string{
+		strconv.Itoa(user.Id),
+		user.PublicId.String(),
+		csvCell(user.Name),
+		csvOptional((*string)(user.Email)),
+		csvTime(&user.CreatedAt),
+		csvTime(&user.UpdatedAt),
+		csvTime(user.DeletedAt),
+		csvTime(user.EmailVerifiedAt),
+		csvOptional(user.TwitchHandle),
+		csvOptional(user.YoutubeHandle),
+		csvOptional(user.TwitterHandle),
+		csvOptional(user.CountryCode),
+		csvOptional(user.Pronouns),
+		csvOptional(user.Bio),
+		csvOptional(user.AvatarUrl),
+	}
+}
+
+// runExportColumns are the columns of a CSV export of runs
+var runExportColumns = []string{
+	"id", "user_id", "category_id", "level_id", "time_ms", "rta_ms", "igt_ms", "lrt_ms", "video_url",
+	"platform", "region", "played_on", "created_at", "status", "rejection_reason", "reviewed_at", "obsolete", "race_id",
+}
+
+// runExportRecord is the row of a CSV export for run
+func runExportRecord(run *api.Run) []string {
+	return []string{
+		strconv.Itoa(run.Id),
+		strconv.Itoa(run.UserId),
+		strconv.Itoa(run.CategoryId),
+		csvInt(run.LevelId),
+		strconv.FormatInt(run.TimeMs, 10),
+		csvInt64(run.Times.RtaMs),
+		csvInt64(run.Times.IgtMs),
+		csvInt64(run.Times.LrtMs),
+		csvCell(run.VideoUrl),
+		csvCell(run.Platform),
+		csvOptional(run.Region),
+		run.PlayedOn.String(),
+		csvTime(&run.CreatedAt),
+		string(run.Status),
+		csvOptional(run.RejectionReason),
+		csvTime(run.ReviewedAt),
+		strconv.FormatBool(run.Obsolete),
+		csvInt(run.RaceId),
+	}
+}
+
+// csvCell makes text safe to open in a spreadsheet, which would run a cell
+// starting with =, +, -, or @ as a formula; such cells are prefixed with a
+// single quote so they are shown as text
+func csvCell(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// csvOptional is the cell for optional text, empty when it is absent
+func csvOptional(text *string) string {
+	if text == nil {
+		return ""
+	}
+	return csvCell(*text)
+}
+
+// csvTime is the cell for an optional time, in RFC 3339 format
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// csvInt is the cell for an optional integer
+func csvInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// csvInt64 is the cell for an optional 64-bit integer
+func csvInt64(n *int64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatInt(*n, 10)
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// exportStub streams users to user 1, an admin
+func exportStub(users ...db.User) *stubQueries {
+	return &stubQueries{
+		listUserRoles: rolesFor(map[int32][]db.UserRole{1: {{UserID: 1, Role: "admin"}}}),
+		exportUsers: func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+			for _, user := range users {
+				if err := fn(user); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestListUsers_ExportsCSV(t *testing.T) {
+	router := SetupRouter(NewServer(exportStub(
+		db.User{ID: 1, Name: "=HYPERLINK(\"evil\")", Email: "ada@example.com"},
+		db.User{ID: 2, Name: "Grace, Hopper", Email: "grace@example.com"},
+	), testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users?limit=1", nil)
+	req.Header.Set("Accept", "text/csv")
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv" {
+		t.Errorf("expected text/csv, got %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="users.csv"` {
+		t.Errorf("expected the export offered as users.csv, got %q", cd)
+	}
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected a header and every user despite the limit, got %v", records)
+	}
+	if records[0][2] != "name" || records[0][3] != "email" {
+		t.Errorf("expected a header row, got %v", records[0])
+	}
+	if records[1][2] != "'=HYPERLINK(\"evil\")" {
+		t.Errorf("expected the formula to be escaped, got %q", records[1][2])
+	}
+	if records[2][0] != "2" || records[2][2] != "Grace, Hopper" {
+		t.Errorf("expected the second user, got %v", records[2])
+	}
+}
+
+func TestListUsers_ExportsJSONLines(t *testing.T) {
+	router := SetupRouter(NewServer(exportStub(
+		db.User{ID: 1, Name: "Ada", Email: "ada@example.com"},
+		db.User{ID: 2, Name: "Grace", Email: "grace@example.com"},
+	), testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("expected application/x-ndjson, got %q", ct)
+	}
+	var ids []int
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var user api.User
+		if err := json.Unmarshal(scanner.Bytes(), &user); err != nil {
+			t.Fatalf("failed to decode line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, user.Id)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("expected users 1 and 2, got %v", ids)
+	}
+}
+
+func TestListUsers_ExportsOnlyForAdmins(t *testing.T) {
+	queries := exportStub(db.User{ID: 1, Name: "Ada", Email: "ada@example.com"})
+	queries.exportUsers = func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+		t.Error("expected nothing to be exported")
+		return nil
+	}
+	queries.getUserByID = func(ctx context.Context, id int32) (db.User, error) {
+		return db.User{ID: id}, nil
+	}
+	queries.getAPIKeyByHash = apiKeysFor(map[string]db.ApiKey{
+		"srk_admin": {ID: 1, UserID: 1, Scopes: []string{"games:write", "runs:moderate"}},
+	})
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name     string
+		userID   int32
+		apiKey   string
+		prefer   string
+		expected int
+	}{
+		{"anonymous", 0, "", "", http.StatusUnauthorized},
+		{"anonymous respond-async", 0, "", "respond-async", http.StatusUnauthorized},
+		{"non-admin", 2, "", "", http.StatusForbidden},
+		{"non-admin respond-async", 2, "", "respond-async", http.StatusForbidden},
+		{"admin's api key", 0, "srk_admin", "", http.StatusForbidden},
+		{"admin's api key respond-async", 0, "srk_admin", "respond-async", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		for _, accept := range []string{contentTypeCSV, contentTypeNDJSON} {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			req.Header.Set("Accept", accept)
+			if tt.prefer != "" {
+				req.Header.Set("Prefer", tt.prefer)
+			}
+			if tt.userID != 0 {
+				req.Header.Set("Authorization", bearerToken(t, tt.userID))
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("Authorization", "ApiKey "+tt.apiKey)
+			}
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("%s as %s: expected %d, got %d: %s", tt.name, accept, tt.expected, rec.Code, rec.Body.String())
+			}
+			if strings.Contains(rec.Body.String(), "ada@example.com") {
+				t.Errorf("%s as %s: expected no email addresses, got %s", tt.name, accept, rec.Body.String())
+			}
+		}
+	}
+}
+
+func TestListUsers_ExportErrors(t *testing.T) {
+	t.Run("before any row", func(t *testing.T) {
+		queries := &stubQueries{
+			exportUsers: func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+				return errors.New("connection refused")
+			},
+			listUserRoles: rolesFor(map[int32][]db.UserRole{1: {{UserID: 1, Role: "admin"}}}),
+		}
+		router := SetupRouter(NewServer(queries, testConfig()))
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Header.Set("Accept", "text/csv")
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected 500, got %d", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("expected a problem response, got %q", ct)
+		}
+	})
+
+	t.Run("after rows were sent", func(t *testing.T) {
+		queries := &stubQueries{
+			exportUsers: func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+				if err := fn(db.User{ID: 1}); err != nil {
+					return err
+				}
+				return errors.New("connection reset")
+			},
+			listUserRoles: rolesFor(map[int32][]db.UserRole{1: {{UserID: 1, Role: "admin"}}}),
+		}
+		router := SetupRouter(NewServer(queries, testConfig()))
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Header.Set("Accept", "text/csv")
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		defer func() {
+			if recovered := recover(); recovered != http.ErrAbortHandler {
+				t.Errorf("expected the connection to be aborted, got %v", recovered)
+			}
+		}()
+		router.ServeHTTP(rec, req)
+	})
+}
+
+func TestExportFormat(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected string
+	}{
+		{"", ""},
+		{"*/*", ""},
+		{"application/json", ""},
+		{"text/*", contentTypeCSV},
+		{"text/csv", contentTypeCSV},
+		{"application/x-ndjson", contentTypeNDJSON},
+		{"application/json;q=0.5, application/jsonl", contentTypeJSONL},
+		{"text/csv;q=0.5, application/json", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		if got := exportFormat(r, responseContentTypes); got != tt.expected {
+			t.Errorf("Accept %q: expected %q, got %q", tt.accept, tt.expected, got)
+		}
+	}
+}
+
+func TestListCategoryRuns_Exports(t *testing.T) {
+	var params db.ExportRunsByCategoryParams
+	queries := &stubQueries{
+		getCategoryBySlug: func(ctx context.Context, arg db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		exportRunsByCategory: func(ctx context.Context, arg db.ExportRunsByCategoryParams, fn func(db.Run) error) error {
+			params = arg
+			return fn(db.Run{ID: 7, CategoryID: 3, TimeMs: 5000, Platform: "N64", Status: "verified"})
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	for _, accept := range []string{"text/csv", "application/jsonl"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/games/sm64/categories/120-star/runs?include_obsolete=true", nil)
+		req.Header.Set("Accept", accept)
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", accept, rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != accept {
+			t.Errorf("%s: expected %s, got %q", accept, accept, ct)
+		}
+		body := rec.Body.String()
+		if accept == "text/csv" && !strings.HasPrefix(body, "id,user_id,category_id,") {
+			t.Errorf("%s: expected a header row, got %q", accept, body)
+		}
+		if accept == "application/jsonl" && !strings.Contains(body, `"time_ms":5000`) {
+			t.Errorf("%s: expected the run as JSON, got %q", accept, body)
+		}
+	}
+	if params.CategoryID != 3 || !params.IncludeObsolete {
+		t.Errorf("expected category 3 with obsolete runs, got %+v", params)
+	}
+}
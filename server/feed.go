@@ -0,0 +1,67 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// GetFeed handles GET /users/me/feed
+// Lists recent activity in the games and from the runners the caller
+// follows, newest first
+func (s *Server) GetFeed(w http.ResponseWriter, r *http.Request, params api.GetFeedParams) {
+	page, err := s.feedService.ListFeed(r.Context(), pageRequest(params.Limit, params.Offset, params.Cursor))
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			writeError(w, r, http.StatusForbidden, "Only users have a feed", "FORBIDDEN")
+			return
+		}
+		writeServiceError(w, r, err, "Error listing feed")
+		return
+	}
+	
+	items := make([]api.FeedItem, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = toAPIFeedItem(&item)
+	}
+	
+	response := struct {
+		Items      []api.FeedItem `json:"items"`
+		Total      int64          `json:"total"`
+		Limit      int32          `json:"limit"`
+		Offset     int32          `json:"offset"`
+		NextCursor string         `json:"next_cursor,omitempty"`
+	}{
+		Items:      items,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// toAPIFeedItem converts a feed item to an API FeedItem model
+func toAPIFeedItem(item *db.ListFeedItemsRow) api.FeedItem {
+	apiItem := api.FeedItem{
+		Id:         int(item.ID),
+		Event:      api.FeedItemEvent(item.Event),
+		OccurredAt: item.CreatedAt.Time.UTC(),
+		GameId:     int(item.GameID),
+		GameSlug:   item.GameSlug,
+		GameName:   item.GameName,
+		Data:       item.Payload,
+	}
+	if item.ActorID.Valid {
+		actorID := int(item.ActorID.Int32)
+		apiItem.ActorId = &actorID
+	}
+	if item.ActorName.Valid {
+		apiItem.ActorName = &item.ActorName.String
+	}
+	return apiItem
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"slices"
+)
+
+// sparse is a response trimmed to the fields a client asked for with
+// ?fields=, either of the resource itself or, when list names the field
+// holding them, of each resource in a list envelope
+// Only its JSON is trimmed; its XML keeps every field, and the database
+// still reads whole rows.
+type sparse struct {
+	data   any
+	fields []string
+	list   string
+}
+
+// sparseFields trims data to fields, which are nil when the client asked
+// for every field
+func sparseFields[F ~string](data any, fields *[]F) any {
+	if fields == nil {
+		return data
+	}
+	names := make([]string, len(*fields))
+	for i, field := range *fields {
+		names[i] = string(field)
+	}
+	return sparse{data: data, fields: names}
+}
+
+// sparseList trims each resource in the list field of the envelope data to
+// fields, leaving the envelope's own fields, such as total, in place
+func sparseList[F ~string](data any, list string, fields *[]F) any {
+	s, ok := sparseFields(data, fields).(sparse)
+	if !ok {
+		return data
+	}
+	s.list = list
+	return s
+}
+
+// MarshalJSON encodes the data, keeping the order of the fields it keeps
+func (s sparse) MarshalJSON() ([]byte, error) {
+	body, err := json.Marshal(s.data)
+	if err != nil {
+		return nil, err
+	}
+	if s.list == "" {
+		return s.trim(body)
+	}
+	
+	return rewriteObject(body, func(key string, value json.RawMessage) (json.RawMessage, bool, error) {
+		if key != s.list {
+			return value, true, nil
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err != nil {
+			return nil, false, err
+		}
+		for i, item := range items {
+			if items[i], err = s.trim(item); err != nil {
+				return nil, false, err
+			}
+		}
+		value, err := json.Marshal(items)
+		return value, true, err
+	})
+}
+
+// MarshalXML encodes all of the data
+func (s sparse) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(s.data)
+}
+
+// trim drops the fields that weren't asked for from a JSON object, keeping
+// its _links
+func (s sparse) trim(object []byte) ([]byte, error) {
+	return rewriteObject(object, func(key string, value json.RawMessage) (json.RawMessage, bool, error) {
+		return value, key == "_links" || slices.Contains(s.fields, key), nil
+	})
+}
+
+// rewriteObject re-encodes a JSON object field by field, in order, with each
+// value replaced by the one rewrite returns, or dropped when it returns
+// false
+func rewriteObject(object []byte, rewrite func(key string, value json.RawMessage) (json.RawMessage, bool, error)) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(object))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		
+		value, keep, err := rewrite(key, value)
+		if err != nil {
+			return nil, err
+		}
+		if !keep {
+			continue
+		}
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		encodedKey, _ := json.Marshal(key)
+		out.Write(encodedKey)
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestGetUser_SparseFields(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "John Doe", Email: "john@example.com"}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1?fields=name,id", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := strings.TrimSpace(rec.Body.String()); !strings.HasPrefix(got, `{"_links":{`) || !strings.HasSuffix(got, `},"id":1,"name":"John Doe"}`) {
+		t.Errorf("expected only the id, name, and links, in the usual order, got %s", got)
+	}
+}
+
+func TestGetUser_SparseFieldsKeepXML(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "John Doe", Email: "john@example.com"}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1?fields=name", nil)
+	req.Header.Set("Accept", contentTypeXML)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<id>1</id>") || !strings.Contains(rec.Body.String(), "<email>john@example.com</email>") {
+		t.Errorf("expected the whole user as XML, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetUser_UnknownField(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1?fields=id,password_hash", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown field, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestListGames_SparseFields(t *testing.T) {
+	queries := &stubQueries{
+		listGames: func(ctx context.Context, arg db.ListGamesParams) ([]db.Game, error) {
+			return []db.Game{{ID: 1, Slug: "sm64", Name: "Super Mario 64"}, {ID: 2, Slug: "oot", Name: "Ocarina of Time"}}, nil
+		},
+		countGames: func(ctx context.Context) (int64, error) {
+			return 2, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games?fields=slug", nil))
+
+	var response struct {
+		Games []map[string]any `json:"games"`
+		Total int              `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Total != 2 || len(response.Games) != 2 {
+		t.Fatalf("expected the envelope untouched, got %s", rec.Body.String())
+	}
+	for _, game := range response.Games {
+		if len(game) != 2 || game["slug"] == nil || game["_links"] == nil {
+			t.Errorf("expected only the slug and links of each game, got %v", game)
+		}
+	}
+}
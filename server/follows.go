@@ -0,0 +1,164 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// FollowUser handles POST /users/{id}/follow
+// Makes the caller follow a user
+func (s *Server) FollowUser(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.followService.FollowUser(r.Context(), int32(id)); err != nil {
+		s.writeFollowError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnfollowUser handles DELETE /users/{id}/follow
+// Makes the caller stop following a user
+func (s *Server) UnfollowUser(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.followService.UnfollowUser(r.Context(), int32(id)); err != nil {
+		s.writeFollowError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FollowGame handles POST /games/{slug}/follow
+// Makes the caller follow a game
+func (s *Server) FollowGame(w http.ResponseWriter, r *http.Request, slug string) {
+	if err := s.followService.FollowGame(r.Context(), slug); err != nil {
+		s.writeFollowError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnfollowGame handles DELETE /games/{slug}/follow
+// Makes the caller stop following a game
+func (s *Server) UnfollowGame(w http.ResponseWriter, r *http.Request, slug string) {
+	if err := s.followService.UnfollowGame(r.Context(), slug); err != nil {
+		s.writeFollowError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListUserFollowers handles GET /users/{id}/followers
+// Lists the users following a user, most recent follow first
+func (s *Server) ListUserFollowers(w http.ResponseWriter, r *http.Request, id int, params api.ListUserFollowersParams) {
+	page, err := s.followService.ListFollowers(r.Context(), int32(id), pageRequest(params.Limit, params.Offset, params.Cursor))
+	s.writeFollowerPage(w, r, page, err)
+}
+
+// ListUserFollowing handles GET /users/{id}/following
+// Lists the users a user follows, most recent follow first
+func (s *Server) ListUserFollowing(w http.ResponseWriter, r *http.Request, id int, params api.ListUserFollowingParams) {
+	page, err := s.followService.ListFollowing(r.Context(), int32(id), pageRequest(params.Limit, params.Offset, params.Cursor))
+	s.writeFollowerPage(w, r, page, err)
+}
+
+// ListGameFollowers handles GET /games/{slug}/followers
+// Lists the users following a game, most recent follow first
+func (s *Server) ListGameFollowers(w http.ResponseWriter, r *http.Request, slug string, params api.ListGameFollowersParams) {
+	page, err := s.followService.ListGameFollowers(r.Context(), slug, pageRequest(params.Limit, params.Offset, params.Cursor))
+	s.writeFollowerPage(w, r, page, err)
+}
+
+// ListFollowedGames handles GET /users/{id}/followed-games
+// Lists the games a user follows, most recent follow first
+func (s *Server) ListFollowedGames(w http.ResponseWriter, r *http.Request, id int, params api.ListFollowedGamesParams) {
+	page, err := s.followService.ListFollowedGames(r.Context(), int32(id), pageRequest(params.Limit, params.Offset, params.Cursor))
+	if err != nil {
+		s.writeFollowError(w, r, err)
+		return
+	}
+	
+	games := make([]api.FollowedGame, len(page.Games))
+	for i, game := range page.Games {
+		games[i] = api.FollowedGame{
+			Id:         int(game.GameID),
+			Slug:       game.Slug,
+			Name:       game.Name,
+			FollowedAt: game.FollowedAt.Time.UTC(),
+		}
+	}
+	
+	response := struct {
+		Games      []api.FollowedGame `json:"games"`
+		Total      int64              `json:"total"`
+		Limit      int32              `json:"limit"`
+		Offset     int32              `json:"offset"`
+		NextCursor string             `json:"next_cursor,omitempty"`
+	}{
+		Games:      games,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// writeFollowerPage writes a page of users from a follow list, or the error
+// that listing it failed with
+func (s *Server) writeFollowerPage(w http.ResponseWriter, r *http.Request, page *service.FollowerPage, err error) {
+	if err != nil {
+		s.writeFollowError(w, r, err)
+		return
+	}
+	
+	users := make([]api.FollowedUser, len(page.Users))
+	for i, user := range page.Users {
+		users[i] = toAPIFollowedUser(&user)
+	}
+	
+	response := struct {
+		Users      []api.FollowedUser `json:"users"`
+		Total      int64              `json:"total"`
+		Limit      int32              `json:"limit"`
+		Offset     int32              `json:"offset"`
+		NextCursor string             `json:"next_cursor,omitempty"`
+	}{
+		Users:      users,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// writeFollowError maps follow service errors to responses
+func (s *Server) writeFollowError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, r, http.StatusForbidden, "Only users may follow", "FORBIDDEN")
+	default:
+		writeServiceError(w, r, err, "Error handling follow")
+	}
+}
+
+// toAPIFollowedUser converts a user from a follow list to an API FollowedUser
+// model
+func toAPIFollowedUser(user *db.ListUserFollowersRow) api.FollowedUser {
+	return api.FollowedUser{
+		Id:         int(user.UserID),
+		PublicId:   openapi_types.UUID(user.PublicID.Bytes),
+		Name:       user.Name,
+		AvatarUrl:  optionalText(user.AvatarUrl),
+		FollowedAt: user.FollowedAt.Time.UTC(),
+	}
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// ListGames handles GET /games
+// Retrieves a paginated list of games
+func (s *Server) ListGames(w http.ResponseWriter, r *http.Request, params api.ListGamesParams) {
+	ctx := r.Context()
+	
+	page, err := s.gameService.ListGames(ctx, pageRequest(params.Limit, params.Offset, params.Cursor))
+	if err != nil {
+		writeServiceError(w, r, err, "Error listing games")
+		return
+	}
+	
+	apiGames := make([]api.Game, len(page.Games))
+	for i, game := range page.Games {
+		apiGames[i] = dbGameToAPIGame(&game)
+	}
+	
+	response := gameListResponse{
+		Games:      apiGames,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, sparseList(response, "games", params.Fields))
+}
+
+// gameListResponse is the paginated body returned by GET /games
+type gameListResponse struct {
+	Games      []api.Game `json:"games"`
+	Total      int64      `json:"total"`
+	Limit      int32      `json:"limit"`
+	Offset     int32      `json:"offset"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	Links      *api.Links `json:"_links,omitempty"`
+}
+
+// SuggestGames handles GET /games/suggest
+// Suggests games for what has been typed into a search box, best match first
+func (s *Server) SuggestGames(w http.ResponseWriter, r *http.Request, params api.SuggestGamesParams) {
+	limit := 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	
+	games, err := s.gameService.SuggestGames(r.Context(), params.Q, limit)
+	if err != nil {
+		writeQueryServiceError(w, r, err, "Error suggesting games")
+		return
+	}
+	
+	apiGames := make([]api.Game, len(games))
+	for i, game := range games {
+		apiGames[i] = dbGameToAPIGame(&game)
+	}
+	s.writeJSON(w, r, http.StatusOK, api.GameSuggestions{Games: apiGames})
+}
+
+// GetGame handles GET /games/{slug}
+// Retrieves a single game by its slug
+func (s *Server) GetGame(w http.ResponseWriter, r *http.Request, slug string, params api.GetGameParams) {
+	game, err := s.gameService.GetGameBySlug(r.Context(), slug)
+	if err != nil {
+		writeServiceError(w, r, err, "Error getting game")
+		return
+	}
+	
+	w.Header().Set("ETag", gameETag(game))
+	w.Header().Set("Last-Modified", game.UpdatedAt.Time.UTC().Format(http.TimeFormat))
+	if notModified(w, r) {
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, sparseFields(dbGameToAPIGame(game), params.Fields))
+}
+
+// CreateGame handles POST /games
+// Creates a new game
+func (s *Server) CreateGame(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateGameRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	game, err := s.gameService.CreateGame(r.Context(), req.Slug, req.Name)
+	if err != nil {
+		writeServiceError(w, r, err, "Error creating game")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, dbGameToAPIGame(game))
+}
+
+// UpdateGame handles PUT /games/{slug}
+// Updates an existing game
+func (s *Server) UpdateGame(w http.ResponseWriter, r *http.Request, slug string) {
+	var req api.UpdateGameRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	newSlug := ""
+	name := ""
+	if req.Slug != nil {
+		newSlug = *req.Slug
+	}
+	if req.Name != nil {
+		name = *req.Name
+	}
+	
+	game, err := s.gameService.UpdateGame(r.Context(), slug, newSlug, name)
+	if err != nil {
+		writeServiceError(w, r, err, "Error updating game")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, dbGameToAPIGame(game))
+}
+
+// DeleteGame handles DELETE /games/{slug}
+// Deletes a game by its slug
+func (s *Server) DeleteGame(w http.ResponseWriter, r *http.Request, slug string) {
+	err := s.gameService.DeleteGame(r.Context(), slug)
+	if err != nil {
+		writeServiceError(w, r, err, "Error deleting game")
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dbGameToAPIGame converts a database Game model to an API Game model
+func dbGameToAPIGame(game *db.Game) api.Game {
+	return api.Game{
+		Id:        int(game.ID),
+		Slug:      game.Slug,
+		Name:      game.Name,
+		CreatedAt: game.CreatedAt.Time.UTC(),
+		UpdatedAt: game.UpdatedAt.Time.UTC(),
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestSuggestGames(t *testing.T) {
+	queries := &stubQueries{
+		getGameBySlug: func(ctx context.Context, slug string) (db.Game, error) {
+			t.Errorf("expected /games/suggest not to be read as the game %q", slug)
+			return db.Game{}, nil
+		},
+		suggestGames: func(ctx context.Context, arg db.SuggestGamesParams) ([]db.Game, error) {
+			if arg.Query != "sm6" || arg.Limit != 3 {
+				t.Errorf("expected the query and limit, got %+v", arg)
+			}
+			return []db.Game{{ID: 1, Slug: "sm64", Name: "Super Mario 64"}}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/suggest?q=SM6&limit=3", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var response api.GameSuggestions
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Games) != 1 || response.Games[0].Slug != "sm64" {
+		t.Errorf("expected sm64 to be suggested, got %+v", response.Games)
+	}
+}
+
+func TestSuggestGames_RejectsInvalidQueries(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	for _, target := range []string{"/games/suggest", "/games/suggest?q=%20", "/games/suggest?q=sm64&limit=11"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestUpdateGame_Errors(t *testing.T) {
+	queries := &stubQueries{
+		getGameBySlug: func(ctx context.Context, slug string) (db.Game, error) {
+			return db.Game{ID: 1, Slug: slug, Name: "Super Mario 64"}, nil
+		},
+		updateGame: func(ctx context.Context, arg db.UpdateGameParams) (db.Game, error) {
+			return db.Game{}, &pgconn.PgError{Code: "23505", ConstraintName: "games_slug_key"}
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{1: {{UserID: 1, Role: "admin"}}}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name         string
+		userID       int32
+		expectedCode int
+		expectedErr  string
+	}{
+		{"not a manager", 2, http.StatusForbidden, "FORBIDDEN"},
+		{"duplicate slug", 1, http.StatusConflict, "DUPLICATE_SLUG"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/games/sm64", strings.NewReader(`{"slug": "sm64-ds"}`))
+		req.Header.Set("Authorization", bearerToken(t, tt.userID))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tt.expectedCode {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.expectedCode, rec.Code, rec.Body.String())
+			continue
+		}
+		var resp api.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s: expected JSON body, got error %v", tt.name, err)
+		}
+		if resp.Code != tt.expectedErr {
+			t.Errorf("%s: expected code %s, got %v", tt.name, tt.expectedErr, resp.Code)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+)
+
+// graphQLPath is where the GraphQL API is served
+const graphQLPath = "/graphql"
+
+// graphQLMaxBodyBytes caps the size of a GraphQL request body
+const graphQLMaxBodyBytes = 1 << 20
+
+// QueryGraphQL handles GET /graphql
+// Runs a GraphQL query given in the query string
+func (s *Server) QueryGraphQL(w http.ResponseWriter, r *http.Request, params api.QueryGraphQLParams) {
+	s.graphql.ServeHTTP(w, r)
+}
+
+// PostGraphQL handles POST /graphql
+// Runs a GraphQL query given in the request body
+func (s *Server) PostGraphQL(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, graphQLMaxBodyBytes)
+	s.graphql.ServeHTTP(w, r)
+}
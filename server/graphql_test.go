@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// graphQLResult is the part of a GraphQL response the tests look at
+type graphQLResult struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// postGraphQL sends query to POST /graphql and decodes the response
+func postGraphQL(t *testing.T, router http.Handler, query string) (int, graphQLResult) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var result graphQLResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, result
+}
+
+func TestGraphQL_BatchesNestedLookups(t *testing.T) {
+	var categoryLookups atomic.Int32
+	queries := &stubQueries{
+		listGames: func(ctx context.Context, arg db.ListGamesParams) ([]db.Game, error) {
+			return []db.Game{{ID: 1, Slug: "super-mario-64"}, {ID: 2, Slug: "celeste"}}, nil
+		},
+		countGames: func(ctx context.Context) (int64, error) {
+			return 2, nil
+		},
+		listCategoriesByGames: func(ctx context.Context, gameIds []int32) ([]db.Category, error) {
+			categoryLookups.Add(1)
+			if len(gameIds) != 2 {
+				t.Errorf("expected both games in one batch, got %v", gameIds)
+			}
+			return []db.Category{
+				{ID: 10, GameID: 1, Slug: "120-star", TimingMethod: "rta"},
+				{ID: 11, GameID: 2, Slug: "any", TimingMethod: "igt"},
+			}, nil
+		},
+		getGamesByIDs: func(ctx context.Context, ids []int32) ([]db.Game, error) {
+			t.Errorf("expected listed games to be cached, got a lookup of %v", ids)
+			return nil, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	code, result := postGraphQL(t, router, `{ games { totalCount nodes { slug categories { slug timingMethod game { slug } } } } }`)
+	if code != http.StatusOK || len(result.Errors) != 0 {
+		t.Fatalf("expected 200 without errors, got %d: %+v", code, result.Errors)
+	}
+	want := `{"games":{"totalCount":2,"nodes":[` +
+		`{"slug":"super-mario-64","categories":[{"slug":"120-star","timingMethod":"RTA","game":{"slug":"super-mario-64"}}]},` +
+		`{"slug":"celeste","categories":[{"slug":"any","timingMethod":"IGT","game":{"slug":"celeste"}}]}]}}`
+	if string(result.Data) != want {
+		t.Errorf("expected %s, got %s", want, result.Data)
+	}
+	if n := categoryLookups.Load(); n != 1 {
+		t.Errorf("expected 1 category lookup, got %d", n)
+	}
+}
+
+func TestGraphQL_ErrorCodes(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	rec := httptest.NewRecorder()
+	query := url.Values{"query": {`{ games(after: "bogus") { totalCount } }`}}
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil))
+	var result graphQLResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != "INVALID_CURSOR" {
+		t.Errorf("expected an INVALID_CURSOR error, got %+v", result.Errors)
+	}
+
+	code, result := postGraphQL(t, router, `{ games { nodes { nope } } }`)
+	if code != http.StatusUnprocessableEntity || len(result.Errors) == 0 {
+		t.Errorf("expected 422 for an invalid query, got %d: %+v", code, result.Errors)
+	}
+}
+
+func TestGraphQL_RejectsComplexQueries(t *testing.T) {
+	cfg := testConfig()
+	cfg.GraphQLMaxComplexity = 50
+	router := SetupRouter(NewServer(&stubQueries{}, cfg))
+
+	code, result := postGraphQL(t, router, `{ games(first: 100) { nodes { slug } } }`)
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != "COMPLEXITY_LIMIT_EXCEEDED" {
+		t.Errorf("expected a COMPLEXITY_LIMIT_EXCEEDED error, got %d: %+v", code, result.Errors)
+	}
+}
+
+func TestGraphQL_AllowedInReadOnlyMaintenance(t *testing.T) {
+	queries := &stubQueries{
+		listGames: func(ctx context.Context, arg db.ListGamesParams) ([]db.Game, error) {
+			return nil, nil
+		},
+		countGames: func(ctx context.Context) (int64, error) {
+			return 0, nil
+		},
+	}
+	srv := NewServer(queries, testConfig())
+	srv.maintenance.Set(MaintenanceReadOnly)
+
+	code, result := postGraphQL(t, SetupRouter(srv), `{ games { totalCount } }`)
+	if code != http.StatusOK || len(result.Errors) != 0 {
+		t.Errorf("expected GraphQL queries to pass in read-only mode, got %d: %+v", code, result.Errors)
+	}
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/logging"
+	"github.com/example/speedrun-rest-api/rpc"
+	"github.com/example/speedrun-rest-api/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// grpcWriteMethods lists the RPCs that change state, with the API key scope
+// each accepts; they need an authenticated caller, while every other RPC
+// only reads and is public like the REST API's reads
+var grpcWriteMethods = map[string]string{
+	rpc.RunService_SubmitRun_FullMethodName: "runs:write",
+	rpc.RunService_VerifyRun_FullMethodName: "runs:moderate",
+	rpc.RunService_RejectRun_FullMethodName: "runs:moderate",
+}
+
+// NewGRPCServer creates the gRPC server for internal consumers, serving the
+// user and run services behind the same authentication and maintenance mode
+// as the REST API. Reflection is enabled so tools such as grpcurl can
+// discover the services.
+func (s *Server) NewGRPCServer() *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		logRPC,
+		recoverRPC,
+		primaryForWrites,
+		s.maintenance.UnaryInterceptor,
+		s.authenticator.UnaryInterceptor,
+	))
+	rpc.RegisterUserServiceServer(server, rpc.NewUserServer(s.userService))
+	rpc.RegisterRunServiceServer(server, rpc.NewRunServer(s.runService))
+	reflection.Register(server)
+	return server
+}
+
+// logRPC writes an access log line once each RPC has been served and starts
+// its logging scope, as requestLogger does for HTTP requests
+func logRPC(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx = logging.WithRequest(ctx, time.Now())
+	resp, err := handler(ctx, req)
+	
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		level = slog.LevelError
+	}
+	attrs := []slog.Attr{
+		slog.String("method", info.FullMethod),
+		slog.String("code", code.String()),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	slog.LogAttrs(ctx, level, "RPC served", attrs...)
+	return resp, err
+}
+
+// recoverRPC turns a panicking RPC into an internal error and logs the panic
+// with its stack trace
+func recoverRPC(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			slog.ErrorContext(ctx, "Panic serving RPC", "panic", rec, "stack", string(debug.Stack()))
+			resp, err = nil, rpc.Error(codes.Internal, "INTERNAL_ERROR", "Internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// primaryForWrites runs every query made while serving an RPC that changes
+// state on the primary database, as readYourWrites does for HTTP requests
+func primaryForWrites(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if _, writes := grpcWriteMethods[info.FullMethod]; writes {
+		ctx = db.WithPrimary(ctx)
+	}
+	return handler(ctx, req)
+}
+
+// UnaryInterceptor rejects RPCs with Unavailable while maintenance mode
+// forbids them, as Middleware does for HTTP requests
+func (m *Maintenance) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	switch m.Mode() {
+	case MaintenanceUnavailable:
+		return nil, rpc.Error(codes.Unavailable, "MAINTENANCE", "Service is temporarily unavailable for maintenance")
+	case MaintenanceReadOnly:
+		if _, writes := grpcWriteMethods[info.FullMethod]; writes {
+			return nil, rpc.Error(codes.Unavailable, "MAINTENANCE", "Service is in read-only mode for maintenance")
+		}
+	}
+	return handler(ctx, req)
+}
+
+// UnaryInterceptor identifies the caller from the authorization metadata,
+// which holds a bearer token or API key just like the Authorization header
+// of an HTTP request, and rejects anonymous calls to RPCs that change state
+// An API key is only accepted by the RPCs that change state when it holds
+// the scope the REST API requires for the same operation.
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		principal, err := a.authenticateRPC(ctx, values[0])
+		if err != nil {
+			return nil, err
+		}
+		logging.SetUserID(ctx, principal.UserID)
+		ctx = auth.WithPrincipal(ctx, principal)
+	}
+	
+	scope, writes := grpcWriteMethods[info.FullMethod]
+	if !writes {
+		return handler(ctx, req)
+	}
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, rpc.Error(codes.Unauthenticated, "UNAUTHORIZED", "Authentication required")
+	}
+	if principal.ViaAPIKey() && !principal.HasScope(scope) {
+		return nil, rpc.Error(codes.PermissionDenied, "INSUFFICIENT_SCOPE", "API key is missing the required scope")
+	}
+	return handler(ctx, req)
+}
+
+// authenticateRPC identifies the caller holding the bearer token or API key
+// in an authorization metadata value
+func (a *Authenticator) authenticateRPC(ctx context.Context, header string) (auth.Principal, error) {
+	if key, ok := strings.CutPrefix(header, "ApiKey "); ok {
+		principal, err := a.resolveKey(ctx, key)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidAPIKey) {
+				return auth.Principal{}, rpc.Error(codes.Unauthenticated, "INVALID_API_KEY", "Invalid API key")
+			}
+			slog.ErrorContext(ctx, "Error authenticating API key", "error", err)
+			return auth.Principal{}, rpc.Error(codes.Internal, "INTERNAL_ERROR", "Internal server error")
+		}
+		return principal, nil
+	}
+	
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return auth.Principal{}, rpc.Error(codes.Unauthenticated, "INVALID_TOKEN", "Unsupported authorization scheme")
+	}
+	principal, err := a.signer.Verify(token)
+	if err != nil {
+		if errors.Is(err, auth.ErrExpiredToken) {
+			return auth.Principal{}, rpc.Error(codes.Unauthenticated, "TOKEN_EXPIRED", "Access token has expired")
+		}
+		return auth.Principal{}, rpc.Error(codes.Unauthenticated, "INVALID_TOKEN", "Invalid access token")
+	}
+	principal, err = a.resolve(ctx, principal.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error loading roles", "error", err)
+		return auth.Principal{}, rpc.Error(codes.Internal, "INTERNAL_ERROR", "Internal server error")
+	}
+	return principal, nil
+}
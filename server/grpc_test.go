@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/rpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialGRPC serves srv's gRPC server in memory and returns a connection to it
+func dialGRPC(t *testing.T, srv *Server) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := srv.NewGRPCServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial gRPC server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// expectStatus fails the test unless err is a status error with code and
+// reason
+func expectStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	if got := status.Code(err); got != code {
+		t.Errorf("expected %s, got %s: %v", code, got, err)
+	}
+	if got := rpc.Reason(err); got != reason {
+		t.Errorf("expected reason %s, got %q", reason, got)
+	}
+}
+
+func TestGRPC_GetUser(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			if id != 4 {
+				return db.User{}, sql.ErrNoRows
+			}
+			return db.User{ID: 4, Name: "Riley", Email: "riley@example.com"}, nil
+		},
+	}
+	client := rpc.NewUserServiceClient(dialGRPC(t, NewServer(queries, testConfig())))
+	ctx := context.Background()
+
+	user, err := client.GetUser(ctx, &rpc.GetUserRequest{Id: 4})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.GetName() != "Riley" || user.GetEmail() != "" {
+		t.Errorf("expected Riley without their email, got %v", user)
+	}
+	user, err = client.GetUser(metadata.AppendToOutgoingContext(ctx, "authorization", bearerToken(t, 4)), &rpc.GetUserRequest{Id: 4})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.GetEmail() != "riley@example.com" {
+		t.Errorf("expected Riley to see their own email, got %v", user)
+	}
+
+	_, err = client.GetUser(ctx, &rpc.GetUserRequest{Id: 5})
+	expectStatus(t, err, codes.NotFound, "USER_NOT_FOUND")
+}
+
+func TestGRPC_Authentication(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		getRunByID: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: "pending"}, nil
+		},
+		getCategoryByID: func(ctx context.Context, id int32) (db.Category, error) {
+			return db.Category{ID: id, GameID: 1}, nil
+		},
+		updateRunStatus: func(ctx context.Context, arg db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{ID: arg.ID, Status: arg.Status}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			5: {{UserID: 5, Role: "moderator"}},
+		}),
+		getAPIKeyByHash: apiKeysFor(map[string]db.ApiKey{
+			"srk_submit":   {ID: 1, UserID: 5, Scopes: []string{"runs:write"}},
+			"srk_moderate": {ID: 2, UserID: 5, Scopes: []string{"runs:moderate"}},
+		}),
+	}
+	srv := NewServer(queries, testConfig())
+	client := rpc.NewRunServiceClient(dialGRPC(t, srv))
+	verify := func(authorization string) (*rpc.Run, error) {
+		ctx := context.Background()
+		if authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+		}
+		return client.VerifyRun(ctx, &rpc.VerifyRunRequest{Id: 7})
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		code          codes.Code
+		reason        string
+	}{
+		{"anonymous", "", codes.Unauthenticated, "UNAUTHORIZED"},
+		{"invalid token", "Bearer bogus", codes.Unauthenticated, "INVALID_TOKEN"},
+		{"unknown key", "ApiKey srk_unknown", codes.Unauthenticated, "INVALID_API_KEY"},
+		{"missing scope", "ApiKey srk_submit", codes.PermissionDenied, "INSUFFICIENT_SCOPE"},
+		{"not a moderator", bearerToken(t, 3), codes.PermissionDenied, "FORBIDDEN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verify(tt.authorization)
+			expectStatus(t, err, tt.code, tt.reason)
+		})
+	}
+
+	for _, authorization := range []string{"ApiKey srk_moderate", bearerToken(t, 5)} {
+		run, err := verify(authorization)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", authorization, err)
+		}
+		if run.GetStatus() != rpc.RunStatus_RUN_STATUS_VERIFIED {
+			t.Errorf("expected a verified run, got %s", run.GetStatus())
+		}
+	}
+
+	srv.Maintenance().Set(MaintenanceReadOnly)
+	_, err := verify(bearerToken(t, 5))
+	expectStatus(t, err, codes.Unavailable, "MAINTENANCE")
+}
+
+func TestGRPC_RejectRunFieldViolations(t *testing.T) {
+	queries := &stubQueries{
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			5: {{UserID: 5, Role: "moderator"}},
+		}),
+	}
+	client := rpc.NewRunServiceClient(dialGRPC(t, NewServer(queries, testConfig())))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", bearerToken(t, 5))
+
+	_, err := client.RejectRun(ctx, &rpc.RejectRunRequest{Id: 7, Reason: "  "})
+	expectStatus(t, err, codes.InvalidArgument, "INVALID_INPUT")
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = badRequest.GetFieldViolations()
+		}
+	}
+	if len(violations) != 1 || violations[0].GetField() != "reason" {
+		t.Errorf("expected a violation of reason, got %v", violations)
+	}
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+)
+
+// HealthCheck is a dependency that must be usable before the server reports
+// itself ready
+type HealthCheck struct {
+	// Name identifies the check in the readiness response, e.g. "database"
+	Name string
+	
+	// Check returns an error when the dependency is not usable; it must
+	// return promptly once ctx is done
+	Check func(ctx context.Context) error
+}
+
+// Health decides what the readiness probe reports
+// Checks are registered once at startup; draining is safe to flip from the
+// shutdown path while requests are being served.
+type Health struct {
+	draining atomic.Bool
+	checks   []HealthCheck
+	timeout  time.Duration
+}
+
+// NewHealth creates a Health that gives each check timeout to complete
+func NewHealth(timeout time.Duration) *Health {
+	return &Health{timeout: timeout}
+}
+
+// Register adds checks that readiness depends on
+// It must be called before the server starts serving requests.
+func (h *Health) Register(checks ...HealthCheck) {
+	h.checks = append(h.checks, checks...)
+}
+
+// Drain makes readiness fail from now on, so load balancers stop sending new
+// requests while those already in flight finish
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+// Draining reports whether Drain has been called
+func (h *Health) Draining() bool {
+	return h.draining.Load()
+}
+
+// run runs every check concurrently and returns the outcome of each, and
+// whether all of them passed
+func (h *Health) run(ctx context.Context) (map[string]api.HealthState, bool) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	
+	states := make([]api.HealthState, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			states[i] = api.Ok
+			if err := check.Check(ctx); err != nil {
+				// The error stays in the logs; probes are unauthenticated
+				slog.WarnContext(ctx, "Readiness check failed", "check", check.Name, "error", err)
+				states[i] = api.Failing
+			}
+		}()
+	}
+	wg.Wait()
+	
+	results := make(map[string]api.HealthState, len(h.checks))
+	healthy := true
+	for i, check := range h.checks {
+		results[check.Name] = states[i]
+		healthy = healthy && states[i] == api.Ok
+	}
+	return results, healthy
+}
+
+// probePaths are the health endpoints polled by orchestrators and load
+// balancers
+var probePaths = map[string]bool{
+	"/healthz": true,
+	"/livez":   true,
+	"/readyz":  true,
+}
+
+// isProbe reports whether r is a health probe
+// Probes bypass maintenance mode and rate limiting, and their access log
+// lines are logged at debug level, so frequent polling neither fails nor
+// floods the logs.
+func isProbe(r *http.Request) bool {
+	return r.Method == http.MethodGet && probePaths[r.URL.Path]
+}
+
+// GetHealthz handles GET /healthz
+// Reports that the process is up without touching the database
+func (s *Server) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, r, http.StatusOK, api.HealthStatus{Status: api.Ok})
+}
+
+// GetLivez handles GET /livez
+// Stays healthy while draining and while dependencies are down, so the
+// process is only restarted when it can no longer serve at all
+func (s *Server) GetLivez(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, r, http.StatusOK, api.HealthStatus{Status: api.Ok})
+}
+
+// GetReadyz handles GET /readyz
+// Runs the registered checks and reports 503 if any fails or the server is
+// draining for shutdown
+func (s *Server) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	checks, healthy := s.health.run(r.Context())
+	status := api.HealthStatus{Status: api.Ok, Checks: &checks}
+	switch {
+	case s.health.Draining():
+		status.Status = api.Draining
+	case !healthy:
+		status.Status = api.Failing
+	}
+	
+	code := http.StatusOK
+	if status.Status != api.Ok {
+		code = http.StatusServiceUnavailable
+	}
+	s.writeJSON(w, r, code, status)
+}
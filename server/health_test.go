@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// getHealth serves GET path and decodes the health status in the response
+func getHealth(t *testing.T, router http.Handler, path string) (int, api.HealthStatus) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var status api.HealthStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("GET %s: failed to decode response: %v", path, err)
+	}
+	return rec.Code, status
+}
+
+func TestGetReadyz_ReportsChecks(t *testing.T) {
+	srv := NewServer(db.NewStore(nil), testConfig())
+	dbErr := errors.New("connection refused")
+	srv.Health().Register(
+		HealthCheck{Name: "database", Check: func(context.Context) error { return dbErr }},
+		HealthCheck{Name: "cache", Check: func(context.Context) error { return nil }},
+	)
+	router := SetupRouter(srv)
+
+	code, status := getHealth(t, router, "/readyz")
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", code)
+	}
+	if status.Status != api.Failing {
+		t.Errorf("expected status failing, got %q", status.Status)
+	}
+	if status.Checks == nil {
+		t.Fatal("expected checks in the response")
+	}
+	checks := *status.Checks
+	if checks["database"] != api.Failing || checks["cache"] != api.Ok {
+		t.Errorf("expected database failing and cache ok, got %v", checks)
+	}
+
+	dbErr = nil
+	if code, status := getHealth(t, router, "/readyz"); code != http.StatusOK || status.Status != api.Ok {
+		t.Errorf("after recovery: expected 200 ok, got %d %q", code, status.Status)
+	}
+}
+
+func TestGetReadyz_TimesOutSlowChecks(t *testing.T) {
+	cfg := testConfig()
+	cfg.HealthCheckTimeout = 10 * time.Millisecond
+	srv := NewServer(db.NewStore(nil), cfg)
+	srv.Health().Register(HealthCheck{Name: "database", Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	code, status := getHealth(t, SetupRouter(srv), "/readyz")
+	if code != http.StatusServiceUnavailable || (*status.Checks)["database"] != api.Failing {
+		t.Errorf("expected 503 with database failing, got %d %v", code, status.Checks)
+	}
+}
+
+func TestDrain_FailsReadinessOnly(t *testing.T) {
+	srv := NewServer(db.NewStore(nil), testConfig())
+	router := SetupRouter(srv)
+
+	if code, _ := getHealth(t, router, "/readyz"); code != http.StatusOK {
+		t.Fatalf("before drain: expected 200, got %d", code)
+	}
+
+	srv.Health().Drain()
+
+	code, status := getHealth(t, router, "/readyz")
+	if code != http.StatusServiceUnavailable || status.Status != api.Draining {
+		t.Errorf("readyz while draining: expected 503 draining, got %d %q", code, status.Status)
+	}
+	for _, path := range []string{"/healthz", "/livez"} {
+		if code, status := getHealth(t, router, path); code != http.StatusOK || status.Status != api.Ok {
+			t.Errorf("%s while draining: expected 200 ok, got %d %q", path, code, status.Status)
+		}
+	}
+}
+
+func TestProbes_BypassMaintenance(t *testing.T) {
+	srv := NewServer(db.NewStore(nil), testConfig())
+	srv.Maintenance().Set(MaintenanceUnavailable)
+	router := SetupRouter(srv)
+
+	for _, path := range []string{"/healthz", "/livez", "/readyz"} {
+		if code, _ := getHealth(t, router, path); code != http.StatusOK {
+			t.Errorf("%s in maintenance: expected 200, got %d", path, code)
+		}
+	}
+}
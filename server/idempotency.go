@@ -0,0 +1,183 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	// idempotencyKeyHeader carries the client's idempotency key
+	idempotencyKeyHeader = "Idempotency-Key"
+	
+	// maxIdempotencyKeyLength matches the VARCHAR(255) idempotency_keys.key column
+	maxIdempotencyKeyLength = 255
+)
+
+// storedHeaders are the response headers recorded with an idempotent
+// response; any others are set afresh on every reply
+var storedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency replays the original response to a POST request that is
+// retried with the same Idempotency-Key header, so a client that times out
+// and retries does not repeat the operation
+type Idempotency struct {
+	service      *service.IdempotencyService
+	maxBodyBytes int64
+}
+
+// NewIdempotency creates an Idempotency middleware that stores responses
+// through svc and reads request bodies of up to maxBodyBytes, the largest
+// any handler accepts
+func NewIdempotency(svc *service.IdempotencyService, maxBodyBytes int64) *Idempotency {
+	return &Idempotency{service: svc, maxBodyBytes: maxBodyBytes}
+}
+
+// Middleware serves a POST request with an Idempotency-Key once and replays
+// the recorded response, marked with Idempotent-Replayed, to every retry
+//
+// Keys belong to the caller, so only authenticated requests are covered; the
+// header is ignored on anonymous ones. A retry whose method, path, or body
+// differs gets 422, and one that arrives while the first request is still
+// being served gets 409. 5xx responses and responses marked Cache-Control:
+// no-store, such as those carrying tokens or new API keys, are not recorded,
+// so a retry is served again. If the store fails the request is served
+// without a key, as the rate limiter does. The body is read to be hashed
+// before the handler's own size limit applies, so one over maxBodyBytes gets
+// 413 without being read whole.
+//
+// It must run after the authenticator.
+func (i *Idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if _, ok := auth.PrincipalFromContext(r.Context()); !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !validIdempotencyKey(key) {
+			writeError(w, r, http.StatusBadRequest, "Idempotency-Key must be 1 to 255 visible ASCII characters", "INVALID_IDEMPOTENCY_KEY")
+			return
+		}
+		
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, i.maxBodyBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeBodyTooLarge(w, r, tooLarge)
+			return
+		}
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "Unable to read request body", "INVALID_REQUEST")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		
+		// Record the outcome even if the client gives up waiting, since that
+		// is exactly when it will retry
+		ctx := context.WithoutCancel(r.Context())
+		
+		stored, err := i.service.Claim(ctx, key, requestHash(r, body))
+		switch {
+		case errors.Is(err, service.ErrIdempotencyKeyReused):
+			writeError(w, r, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request", "IDEMPOTENCY_KEY_REUSED")
+			return
+		case errors.Is(err, service.ErrIdempotencyKeyInProgress):
+			writeError(w, r, http.StatusConflict, "A request with this Idempotency-Key is still being served", "IDEMPOTENCY_KEY_IN_PROGRESS")
+			return
+		case err != nil:
+			slog.ErrorContext(ctx, "Error claiming idempotency key, serving request without it", "error", err)
+			next.ServeHTTP(w, r)
+			return
+		case stored != nil:
+			replay(w, stored)
+			return
+		}
+		
+		var recorded bytes.Buffer
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(&recorded)
+		
+		// Release the key if the handler panics, so the retry is served again
+		served := false
+		defer func() {
+			if !served {
+				i.release(ctx, key)
+			}
+		}()
+		next.ServeHTTP(ww, r)
+		served = true
+		
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if status >= http.StatusInternalServerError || strings.Contains(w.Header().Get("Cache-Control"), "no-store") {
+			i.release(ctx, key)
+			return
+		}
+		
+		response := service.StoredResponse{StatusCode: status, Header: map[string]string{}, Body: recorded.Bytes()}
+		for _, name := range storedHeaders {
+			if value := w.Header().Get(name); value != "" {
+				response.Header[name] = value
+			}
+		}
+		if err := i.service.Complete(ctx, key, response); err != nil {
+			slog.ErrorContext(ctx, "Error storing idempotent response", "error", err)
+			i.release(ctx, key)
+		}
+	})
+}
+
+// release gives up a claimed key, logging any failure; the janitor removes
+// keys that could not be released once they expire
+func (i *Idempotency) release(ctx context.Context, key string) {
+	if err := i.service.Release(ctx, key); err != nil {
+		slog.ErrorContext(ctx, "Error releasing idempotency key", "error", err)
+	}
+}
+
+// replay writes a recorded response
+func replay(w http.ResponseWriter, stored *service.StoredResponse) {
+	for name, value := range stored.Header {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Body)
+}
+
+// requestHash identifies a request by its method, path, query, and body
+func requestHash(r *http.Request, body []byte) []byte {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+// validIdempotencyKey reports whether key fits the idempotency_keys.key
+// column and is made of visible ASCII characters, as UUIDs and other
+// client-generated keys are
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < '!' || key[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// idempotencyQueries is an in-memory idempotency_keys table
+type idempotencyQueries struct {
+	db.Querier
+	keys map[db.GetIdempotencyKeyParams]db.IdempotencyKey
+}
+
+func (q *idempotencyQueries) ClaimIdempotencyKey(ctx context.Context, arg db.ClaimIdempotencyKeyParams) (int64, error) {
+	id := db.GetIdempotencyKeyParams{UserID: arg.UserID, Key: arg.Key}
+	if _, ok := q.keys[id]; ok {
+		return 0, nil
+	}
+	q.keys[id] = db.IdempotencyKey{UserID: arg.UserID, Key: arg.Key, RequestHash: arg.RequestHash}
+	return 1, nil
+}
+
+func (q *idempotencyQueries) GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	key, ok := q.keys[arg]
+	if !ok {
+		return db.IdempotencyKey{}, sql.ErrNoRows
+	}
+	return key, nil
+}
+
+func (q *idempotencyQueries) CompleteIdempotencyKey(ctx context.Context, arg db.CompleteIdempotencyKeyParams) error {
+	id := db.GetIdempotencyKeyParams{UserID: arg.UserID, Key: arg.Key}
+	key := q.keys[id]
+	key.StatusCode = arg.StatusCode
+	key.ResponseHeaders = arg.ResponseHeaders
+	key.ResponseBody = arg.ResponseBody
+	q.keys[id] = key
+	return nil
+}
+
+func (q *idempotencyQueries) DeleteIdempotencyKey(ctx context.Context, arg db.DeleteIdempotencyKeyParams) error {
+	delete(q.keys, db.GetIdempotencyKeyParams{UserID: arg.UserID, Key: arg.Key})
+	return nil
+}
+
+// idempotentHandler wraps handler in the idempotency middleware and counts
+// how often the handler is actually run
+func idempotentHandler(handler http.HandlerFunc) (http.Handler, *int) {
+	calls := 0
+	queries := &idempotencyQueries{keys: map[db.GetIdempotencyKeyParams]db.IdempotencyKey{}}
+	middleware := NewIdempotency(service.NewIdempotencyService(queries), maxJSONBodyBytes)
+	return middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		handler(w, r)
+	})), &calls
+}
+
+// postWithKey sends a POST with an Idempotency-Key as user 1, or
+// anonymously for user 0
+func postWithKey(handler http.Handler, userID int32, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/runs", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", key)
+	if userID != 0 {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{UserID: userID}))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	handler, calls := idempotentHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/runs/7")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":7}`))
+	})
+
+	first := postWithKey(handler, 1, "retry-1", `{"time_ms":1000}`)
+	second := postWithKey(handler, 1, "retry-1", `{"time_ms":1000}`)
+
+	if *calls != 1 {
+		t.Errorf("expected the handler to run once, ran %d times", *calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != `{"id":7}` {
+		t.Errorf("expected the original response, got %d %q", second.Code, second.Body.String())
+	}
+	if got := second.Header().Get("Location"); got != "/runs/7" {
+		t.Errorf("expected Location to be replayed, got %q", got)
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("expected the replay to be marked")
+	}
+	if first.Header().Get("Idempotent-Replayed") != "" {
+		t.Error("expected the original response not to be marked as a replay")
+	}
+}
+
+func TestIdempotency_RejectsReusedKey(t *testing.T) {
+	handler, _ := idempotentHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	postWithKey(handler, 1, "retry-1", `{"time_ms":1000}`)
+	rec := postWithKey(handler, 1, "retry-1", `{"time_ms":2000}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a different body, got %d", rec.Code)
+	}
+}
+
+func TestIdempotency_ServesAgainAfterFailureOrNoStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}},
+		{"no-store", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "no-store")
+			w.WriteHeader(http.StatusCreated)
+		}},
+		{"panic", func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}},
+	}
+
+	for _, tt := range tests {
+		handler, calls := idempotentHandler(tt.handler)
+		for i := 0; i < 2; i++ {
+			func() {
+				defer func() { recover() }()
+				postWithKey(handler, 1, "retry-1", `{}`)
+			}()
+		}
+		if *calls != 2 {
+			t.Errorf("%s: expected the retry to be served again, ran %d times", tt.name, *calls)
+		}
+	}
+}
+
+func TestIdempotency_IgnoresAnonymousAndInvalidKeys(t *testing.T) {
+	handler, calls := idempotentHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	postWithKey(handler, 0, "retry-1", `{}`)
+	postWithKey(handler, 0, "retry-1", `{}`)
+	if *calls != 2 {
+		t.Errorf("expected anonymous requests to be served every time, ran %d times", *calls)
+	}
+
+	if rec := postWithKey(handler, 1, "has spaces", `{}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid key, got %d", rec.Code)
+	}
+	if rec := postWithKey(handler, 1, strings.Repeat("k", maxIdempotencyKeyLength+1), `{}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an overlong key, got %d", rec.Code)
+	}
+}
+
+func TestIdempotency_RejectsOversizedBody(t *testing.T) {
+	queries := &idempotencyQueries{keys: map[db.GetIdempotencyKeyParams]db.IdempotencyKey{}}
+	handler := NewIdempotency(service.NewIdempotencyService(queries), 8).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the handler not to run")
+	}))
+
+	rec := postWithKey(handler, 1, "big-1", `{"time_ms":1000}`)
+
+	if rec.Code != http.StatusRequestEntityTooLarge || !strings.Contains(rec.Body.String(), "BODY_TOO_LARGE") {
+		t.Errorf("expected 413 BODY_TOO_LARGE, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(queries.keys) != 0 {
+		t.Errorf("expected no key to be claimed, got %v", queries.keys)
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"slices"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// includeRunRelations embeds the related resources named by include, the
+// ?include= of a run listing, in each of runs
+// Each kind is looked up with one query for the whole page, batched by the
+// largest batch lookup allowed, rather than one per run; runners who have
+// been deleted are left out.
+func (s *Server) includeRunRelations(ctx context.Context, runs []api.Run, include *[]api.RunInclude) error {
+	if include == nil || len(runs) == 0 {
+		return nil
+	}
+	
+	if slices.Contains(*include, api.RunIncludeRunner) {
+		runners := map[int]*api.Runner{}
+		for batch := range slices.Chunk(uniqueIDs(runs, func(run api.Run) int32 { return int32(run.UserId) }), s.maxBatchSize) {
+			users, _, err := s.userService.GetUsersByIDs(ctx, batch)
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
+				runner := toAPIRunner(&user)
+				runners[runner.Id] = &runner
+			}
+		}
+		for i := range runs {
+			runs[i].Runner = runners[runs[i].UserId]
+		}
+	}
+	
+	includeCategory := slices.Contains(*include, api.RunIncludeCategory)
+	includeGame := slices.Contains(*include, api.RunIncludeGame)
+	if !includeCategory && !includeGame {
+		return nil
+	}
+	
+	// Games are found through the runs' categories
+	rows, err := s.categoryService.GetCategoriesByIDs(ctx, uniqueIDs(runs, func(run api.Run) int32 { return int32(run.CategoryId) }))
+	if err != nil {
+		return err
+	}
+	categories := make(map[int]*api.Category, len(rows))
+	for _, row := range rows {
+		category := dbCategoryToAPICategory(&row)
+		categories[category.Id] = &category
+	}
+	if includeCategory {
+		for i := range runs {
+			runs[i].Category = categories[runs[i].CategoryId]
+		}
+	}
+	if !includeGame {
+		return nil
+	}
+	
+	gameRows, err := s.gameService.GetGamesByIDs(ctx, uniqueIDs(rows, func(row db.Category) int32 { return row.GameID }))
+	if err != nil {
+		return err
+	}
+	games := make(map[int]*api.Game, len(gameRows))
+	for _, row := range gameRows {
+		game := dbGameToAPIGame(&row)
+		games[game.Id] = &game
+	}
+	for i := range runs {
+		if category := categories[runs[i].CategoryId]; category != nil {
+			runs[i].Game = games[category.GameId]
+		}
+	}
+	return nil
+}
+
+// uniqueIDs returns the IDs id picks from rows, each once, in the order
+// they first appear
+func uniqueIDs[T any](rows []T, id func(row T) int32) []int32 {
+	seen := make(map[int32]struct{}, len(rows))
+	ids := make([]int32, 0, len(rows))
+	for _, row := range rows {
+		if _, ok := seen[id(row)]; ok {
+			continue
+		}
+		seen[id(row)] = struct{}{}
+		ids = append(ids, id(row))
+	}
+	return ids
+}
+
+// toAPIRunner converts a user to the public view of them embedded in runs
+func toAPIRunner(user *db.User) api.Runner {
+	return api.Runner{
+		Id:        int(user.ID),
+		PublicId:  openapi_types.UUID(user.PublicID.Bytes),
+		Name:      user.Name,
+		AvatarUrl: optionalText(user.AvatarUrl),
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestIncludeRunRelations(t *testing.T) {
+	var lookups []string
+	queries := &stubQueries{
+		getUsersByIDs: func(ctx context.Context, ids []int32) ([]db.User, error) {
+			lookups = append(lookups, "users")
+			if !slices.Equal(ids, []int32{1, 2}) {
+				t.Errorf("expected each runner looked up once, got %v", ids)
+			}
+			// User 2 has been deleted
+			return []db.User{{ID: 1, Name: "John Doe"}}, nil
+		},
+		getCategoriesByIDs: func(ctx context.Context, ids []int32) ([]db.Category, error) {
+			lookups = append(lookups, "categories")
+			return []db.Category{{ID: 10, GameID: 100, Slug: "any"}, {ID: 11, GameID: 100, Slug: "120-star"}}, nil
+		},
+		getGamesByIDs: func(ctx context.Context, ids []int32) ([]db.Game, error) {
+			lookups = append(lookups, "games")
+			if !slices.Equal(ids, []int32{100}) {
+				t.Errorf("expected each game looked up once, got %v", ids)
+			}
+			return []db.Game{{ID: 100, Slug: "sm64"}}, nil
+		},
+	}
+	s := NewServer(queries, testConfig())
+	runs := []api.Run{
+		{Id: 1, UserId: 1, CategoryId: 10},
+		{Id: 2, UserId: 2, CategoryId: 11},
+		{Id: 3, UserId: 1, CategoryId: 10},
+	}
+
+	include := []api.RunInclude{api.RunIncludeRunner, api.RunIncludeCategory, api.RunIncludeGame}
+	if err := s.includeRunRelations(context.Background(), runs, &include); err != nil {
+		t.Fatalf("includeRunRelations: %v", err)
+	}
+
+	if !slices.Equal(lookups, []string{"users", "categories", "games"}) {
+		t.Errorf("expected one lookup of each kind for the page, got %v", lookups)
+	}
+	if runs[0].Runner == nil || runs[0].Runner.Name != "John Doe" || runs[2].Runner == nil {
+		t.Errorf("expected the runner embedded, got %+v", runs[0].Runner)
+	}
+	if runs[1].Runner != nil {
+		t.Errorf("expected a deleted runner left out, got %+v", runs[1].Runner)
+	}
+	for _, run := range runs {
+		if run.Category == nil || run.Category.Id != run.CategoryId {
+			t.Errorf("run %d: expected its category embedded, got %+v", run.Id, run.Category)
+		}
+		if run.Game == nil || run.Game.Slug != "sm64" {
+			t.Errorf("run %d: expected its game embedded, got %+v", run.Id, run.Game)
+		}
+	}
+}
+
+func TestIncludeRunRelations_OnlyWhatIsAsked(t *testing.T) {
+	queries := &stubQueries{
+		getCategoriesByIDs: func(ctx context.Context, ids []int32) ([]db.Category, error) {
+			return []db.Category{{ID: 10, GameID: 100}}, nil
+		},
+	}
+	s := NewServer(queries, testConfig())
+	runs := []api.Run{{Id: 1, UserId: 1, CategoryId: 10}}
+
+	if err := s.includeRunRelations(context.Background(), runs, nil); err != nil {
+		t.Fatalf("includeRunRelations: %v", err)
+	}
+	include := []api.RunInclude{api.RunIncludeCategory}
+	if err := s.includeRunRelations(context.Background(), runs, &include); err != nil {
+		t.Fatalf("includeRunRelations: %v", err)
+	}
+
+	if runs[0].Category == nil || runs[0].Runner != nil || runs[0].Game != nil {
+		t.Errorf("expected only the category embedded, got %+v", runs[0])
+	}
+}
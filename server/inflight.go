@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// InFlight counts the requests that are currently being served
+// It lets the shutdown path report how much work was still running when the
+// server began draining.
+type InFlight struct {
+	active atomic.Int64
+}
+
+// Count returns the number of requests currently in flight
+func (f *InFlight) Count() int64 {
+	return f.active.Load()
+}
+
+// Middleware increments the counter when a request starts and decrements it
+// once the handler returns
+func (f *InFlight) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.active.Add(1)
+		defer f.active.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInFlightMiddleware(t *testing.T) {
+	tracker := &InFlight{}
+	var during int64
+	handler := tracker.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		during = tracker.Count()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	if during != 1 {
+		t.Errorf("expected 1 request in flight during the handler, got %d", during)
+	}
+	if got := tracker.Count(); got != 0 {
+		t.Errorf("expected 0 requests in flight after the handler, got %d", got)
+	}
+}
+
+func TestInFlightMiddleware_Panic(t *testing.T) {
+	tracker := &InFlight{}
+	handler := tracker.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	func() {
+		defer func() { _ = recover() }()
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+	}()
+
+	if got := tracker.Count(); got != 0 {
+		t.Errorf("expected counter to be released after a panic, got %d", got)
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListLevels handles GET /games/{slug}/levels
+// Retrieves a game's individual levels in display order
+func (s *Server) ListLevels(w http.ResponseWriter, r *http.Request, slug string) {
+	levels, err := s.levelService.ListLevels(r.Context(), slug)
+	if err != nil {
+		writeServiceError(w, r, err, "Error listing levels")
+		return
+	}
+	
+	apiLevels := make([]api.Level, len(levels))
+	for i, level := range levels {
+		apiLevels[i] = dbLevelToAPILevel(&level)
+	}
+	
+	response := struct {
+		Levels []api.Level `json:"levels"`
+	}{
+		Levels: apiLevels,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateLevel handles POST /games/{slug}/levels
+// Adds an individual level to a game
+func (s *Server) CreateLevel(w http.ResponseWriter, r *http.Request, slug string) {
+	var req api.CreateLevelRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	input := service.CreateLevelInput{
+		Slug: req.Slug,
+		Name: req.Name,
+	}
+	if req.Position != nil {
+		position := int32(*req.Position)
+		input.Position = &position
+	}
+	
+	level, err := s.levelService.CreateLevel(r.Context(), slug, input)
+	if err != nil {
+		writeServiceError(w, r, err, "Error creating level")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, dbLevelToAPILevel(level))
+}
+
+// GetLevelLeaderboard handles GET /games/{slug}/levels/{level}/leaderboard
+// Returns each runner's best run of a level, ranked fastest first
+func (s *Server) GetLevelLeaderboard(w http.ResponseWriter, r *http.Request, slug string, level string, params api.GetLevelLeaderboardParams) {
+	filter, err := leaderboardFilter(params.Variable, params.Platform, params.Region)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+	category := ""
+	if params.Category != nil {
+		category = *params.Category
+	}
+	
+	page, err := s.runService.LevelLeaderboard(r.Context(), slug, level, category, pageRequest(params.Limit, params.Offset, params.Cursor), filter)
+	if err != nil {
+		writeServiceError(w, r, err, "Error getting level leaderboard")
+		return
+	}
+	
+	s.writeLeaderboard(w, r, page)
+}
+
+// dbLevelToAPILevel converts a database Level model to an API Level model
+func dbLevelToAPILevel(level *db.Level) api.Level {
+	return api.Level{
+		Id:        int(level.ID),
+		GameId:    int(level.GameID),
+		Slug:      level.Slug,
+		Name:      level.Name,
+		Position:  int(level.Position),
+		CreatedAt: level.CreatedAt.Time.UTC(),
+	}
+}
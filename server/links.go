@@ -0,0 +1,144 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/example/speedrun-rest-api/api"
+)
+
+// addLinks fills in the _links of the users, games, runs, and operations in
+// data, and of the page data is when it is one of their lists, so clients
+// can navigate the API without building paths; data of other types is
+// returned as it is
+// writeJSON calls it for every response, so handlers never link by hand.
+func addLinks(r *http.Request, data any) any {
+	prefix := servedPrefix(r.Context())
+	switch v := data.(type) {
+	case sparse:
+		v.data = addLinks(r, v.data)
+		return v
+	case api.User:
+		v.Links = userLinks(prefix, v.Id)
+		return v
+	case api.Game:
+		v.Links = gameLinks(prefix, v.Slug)
+		return v
+	case api.Run:
+		linkRun(prefix, &v)
+		return v
+	case api.BatchGetUsersResponse:
+		for i := range v.Users {
+			v.Users[i].Links = userLinks(prefix, v.Users[i].Id)
+		}
+		return v
+	case api.GameSuggestions:
+		for i := range v.Games {
+			v.Games[i].Links = gameLinks(prefix, v.Games[i].Slug)
+		}
+		return v
+	case userListResponse:
+		for i := range v.Users {
+			v.Users[i].Links = userLinks(prefix, v.Users[i].Id)
+		}
+		v.Links = pageLinks(r, v.Limit, v.Offset, v.NextCursor)
+		return v
+	case gameListResponse:
+		for i := range v.Games {
+			v.Games[i].Links = gameLinks(prefix, v.Games[i].Slug)
+		}
+		v.Links = pageLinks(r, v.Limit, v.Offset, v.NextCursor)
+		return v
+	case api.Operation:
+		v.Links = operationLinks(prefix, v)
+		return v
+	case runListResponse:
+		for i := range v.Runs {
+			linkRun(prefix, &v.Runs[i])
+		}
+		v.Links = pageLinks(r, v.Limit, v.Offset, v.NextCursor)
+		return v
+	}
+	return data
+}
+
+// userLinks links a user to itself, its profile, its runs, and its personal
+// bests
+func userLinks(prefix string, id int) *api.Links {
+	user := prefix + "/users/" + strconv.Itoa(id)
+	return &api.Links{
+		"self":           {Href: user},
+		"profile":        {Href: user + "/profile"},
+		"runs":           {Href: user + "/runs"},
+		"personal_bests": {Href: user + "/personal-bests"},
+	}
+}
+
+// gameLinks links a game to itself and its categories, levels, and
+// variables
+func gameLinks(prefix, slug string) *api.Links {
+	game := prefix + "/games/" + url.PathEscape(slug)
+	return &api.Links{
+		"self":       {Href: game},
+		"categories": {Href: game + "/categories"},
+		"levels":     {Href: game + "/levels"},
+		"variables":  {Href: game + "/variables"},
+	}
+}
+
+// linkRun links a run to its runner and its comments, and the game embedded
+// in it, if any, to its own resources; runs have no route of their own to be
+// linked as self
+func linkRun(prefix string, run *api.Run) {
+	run.Links = &api.Links{
+		"runner":   {Href: prefix + "/users/" + strconv.Itoa(run.UserId)},
+		"comments": {Href: prefix + "/runs/" + strconv.Itoa(run.Id) + "/comments"},
+	}
+	if run.Game != nil {
+		run.Game.Links = gameLinks(prefix, run.Game.Slug)
+	}
+}
+
+// operationLinks links an operation to itself and, once it has succeeded, to
+// the file it made, if it makes one
+func operationLinks(prefix string, op api.Operation) *api.Links {
+	operation := prefix + "/operations/" + op.Id.String()
+	links := api.Links{"self": {Href: operation}}
+	if op.Status == api.OperationStatusSucceeded && op.Kind == api.UsersExport {
+		links["output"] = api.Link{Href: operation + "/output"}
+	}
+	return &links
+}
+
+// pageLinks links a page of a list to itself and the pages beside it: the
+// next page continues from the page's cursor, and offset pages past the
+// first link to the one before them
+func pageLinks(r *http.Request, limit, offset int32, nextCursor string) *api.Links {
+	links := api.Links{"self": {Href: pageHref(r, nil)}}
+	if nextCursor != "" {
+		links["next"] = api.Link{Href: pageHref(r, func(query url.Values) {
+			query.Del("offset")
+			query.Set("cursor", nextCursor)
+		})}
+	}
+	if offset > 0 {
+		links["prev"] = api.Link{Href: pageHref(r, func(query url.Values) {
+			query.Set("offset", strconv.Itoa(int(max(offset-limit, 0))))
+		})}
+	}
+	return &links
+}
+
+// pageHref returns the path r was sent to with its query, changed by edit
+// when it isn't nil
+func pageHref(r *http.Request, edit func(query url.Values)) string {
+	query := r.URL.Query()
+	if edit != nil {
+		edit(query)
+	}
+	if len(query) == 0 {
+		return requestPath(r)
+	}
+	return requestPath(r) + "?" + query.Encode()
+}
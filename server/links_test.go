@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestGetUser_Links(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "John Doe", Email: "john@example.com"}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/4", nil))
+
+	var user api.User
+	if err := json.Unmarshal(rec.Body.Bytes(), &user); err != nil || user.Links == nil {
+		t.Fatalf("expected a linked user, got %d: %s", rec.Code, rec.Body.String())
+	}
+	for rel, href := range map[string]string{
+		"self":           "/v1/users/4",
+		"runs":           "/v1/users/4/runs",
+		"personal_bests": "/v1/users/4/personal-bests",
+	} {
+		if got := (*user.Links)[rel].Href; got != href {
+			t.Errorf("expected %s linked to %s, got %q", rel, href, got)
+		}
+	}
+}
+
+func TestListGames_PageLinks(t *testing.T) {
+	queries := &stubQueries{
+		listGames: func(ctx context.Context, arg db.ListGamesParams) ([]db.Game, error) {
+			// One more than the limit, so there is a next page
+			return []db.Game{{ID: 1, Slug: "sm64"}, {ID: 2, Slug: "oot"}, {ID: 3, Slug: "botw"}}, nil
+		},
+		countGames: func(ctx context.Context) (int64, error) {
+			return 10, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games?limit=2&offset=3", nil))
+
+	var page gameListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil || page.Links == nil {
+		t.Fatalf("expected a linked page, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if page.NextCursor == "" {
+		t.Fatalf("expected a next page, got %s", rec.Body.String())
+	}
+	for rel, href := range map[string]string{
+		"self": "/games?limit=2&offset=3",
+		"next": "/games?cursor=" + page.NextCursor + "&limit=2",
+		"prev": "/games?limit=2&offset=1",
+	} {
+		if got := (*page.Links)[rel].Href; got != href {
+			t.Errorf("expected %s linked to %s, got %q", rel, href, got)
+		}
+	}
+	if len(page.Games) == 0 || page.Games[0].Links == nil || (*page.Games[0].Links)["self"].Href != "/games/sm64" {
+		t.Errorf("expected each game linked, got %s", rec.Body.String())
+	}
+}
+
+func TestPageLinks_FirstPage(t *testing.T) {
+	links := *pageLinks(httptest.NewRequest(http.MethodGet, "/users", nil), 10, 0, "")
+
+	if links["self"].Href != "/users" {
+		t.Errorf("expected the page linked to itself, got %q", links["self"].Href)
+	}
+	if _, ok := links["next"]; ok {
+		t.Error("expected no next link on the last page")
+	}
+	if _, ok := links["prev"]; ok {
+		t.Error("expected no prev link on the first page")
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/logging"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// requestLogger writes an access log line once each request has been served
+// It also starts the request's logging scope, so every line logged while
+// serving it carries its latency so far; it must run after the request ID
+// and client IP middleware.
+func requestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := logging.WithRequest(r.Context(), time.Now())
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+		
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case isProbe(r):
+			level = slog.LevelDebug
+		}
+		slog.LogAttrs(ctx, level, "Request served",
+			slog.String("method", r.Method),
+			slog.String("path", requestPath(r)),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.String("client_ip", ClientIPFromContext(ctx)),
+		)
+	})
+}
+
+// recoverer turns a panicking handler into a 500 response and logs the panic
+// with its stack trace
+func recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				// The server aborts the response quietly for this panic
+				panic(rec)
+			}
+			slog.ErrorContext(r.Context(), "Panic serving request", "panic", rec, "stack", string(debug.Stack()))
+			writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// logLevels maps the API's level names to slog levels
+var logLevels = map[api.LogLevel]slog.Level{
+	api.LogLevelDebug: slog.LevelDebug,
+	api.LogLevelInfo:  slog.LevelInfo,
+	api.LogLevelWarn:  slog.LevelWarn,
+	api.LogLevelError: slog.LevelError,
+}
+
+// GetLogLevel handles GET /admin/log-level
+// Reports the minimum level currently logged
+func (s *Server) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	current := logging.Level()
+	for name, level := range logLevels {
+		if level == current {
+			s.writeJSON(w, r, http.StatusOK, api.LogLevelSetting{Level: name})
+			return
+		}
+	}
+	
+	// Levels between the named ones can only come from code, not this API
+	slog.WarnContext(r.Context(), "Log level has no API name", "level", current.String())
+	writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+}
+
+// SetLogLevel handles PUT /admin/log-level
+// Changes the minimum level logged until the next change or restart
+func (s *Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req api.LogLevelSetting
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	level, ok := logLevels[req.Level]
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, "level must be one of debug, info, warn, error", "INVALID_INPUT")
+		return
+	}
+	
+	logging.SetLevel(level)
+	slog.WarnContext(r.Context(), "Log level changed", "level", level.String())
+	s.writeJSON(w, r, http.StatusOK, req)
+}
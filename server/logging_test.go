@@ -0,0 +1,59 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/logging"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	t.Cleanup(func() { logging.SetLevel(slog.LevelInfo) })
+	queries := &stubQueries{
+		listUserRoles: rolesFor(map[int32][]db.UserRole{1: {{UserID: 1, Role: "admin"}}}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+	serve := func(userID int32, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", bearerToken(t, userID))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve(2, `{"level": "debug"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a non-admin, got %d", rec.Code)
+	}
+	if rec := serve(1, `{"level": "verbose"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown level, got %d", rec.Code)
+	}
+
+	rec := serve(1, `{"level": "debug"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if logging.Level() != slog.LevelDebug {
+		t.Errorf("expected the level to be debug, got %s", logging.Level())
+	}
+}
+
+func TestRecoverer_ReturnsJSONError(t *testing.T) {
+	handler := recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "INTERNAL_ERROR") {
+		t.Errorf("expected a JSON error body, got %q", rec.Body)
+	}
+}
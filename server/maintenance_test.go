@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceMiddleware_ReadOnly(t *testing.T) {
+	m := NewMaintenance(30 * time.Second)
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/users", nil))
+		return rec
+	}
+
+	// Everything passes while maintenance is off
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		if rec := serve(method); rec.Code != http.StatusOK {
+			t.Errorf("%s with maintenance off: expected 200, got %d", method, rec.Code)
+		}
+	}
+
+	m.Set(MaintenanceReadOnly)
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		if rec := serve(method); rec.Code != http.StatusOK {
+			t.Errorf("%s in read-only mode: expected 200, got %d", method, rec.Code)
+		}
+	}
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		rec := serve(method)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s in read-only mode: expected 503, got %d", method, rec.Code)
+		}
+		if got := rec.Header().Get("Retry-After"); got != "30" {
+			t.Errorf("%s in read-only mode: expected Retry-After 30, got %q", method, got)
+		}
+	}
+
+	m.Set(MaintenanceOff)
+
+	if rec := serve(http.MethodPost); rec.Code != http.StatusOK {
+		t.Errorf("POST after maintenance ends: expected 200, got %d", rec.Code)
+	}
+}
+
+func TestMaintenanceMiddleware_Unavailable(t *testing.T) {
+	m := NewMaintenance(time.Minute)
+	m.Set(MaintenanceUnavailable)
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rec.Code)
+	}
+}
+
+func TestMaintenanceToggle(t *testing.T) {
+	m := NewMaintenance(time.Minute)
+
+	if mode := m.Toggle(MaintenanceReadOnly); mode != MaintenanceReadOnly {
+		t.Errorf("expected read-only after first toggle, got %s", mode)
+	}
+	if mode := m.Toggle(MaintenanceUnavailable); mode != MaintenanceUnavailable {
+		t.Errorf("expected unavailable after switching modes, got %s", mode)
+	}
+	if mode := m.Toggle(MaintenanceUnavailable); mode != MaintenanceOff {
+		t.Errorf("expected off after toggling the active mode, got %s", mode)
+	}
+}
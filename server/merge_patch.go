@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// userPatch is a JSON merge patch (RFC 7396) of a user
+type userPatch struct {
+	Name          patchString `json:"name"`
+	Email         patchString `json:"email"`
+	TwitchHandle  patchString `json:"twitch_handle"`
+	YoutubeHandle patchString `json:"youtube_handle"`
+	TwitterHandle patchString `json:"twitter_handle"`
+	CountryCode   patchString `json:"country_code"`
+	Pronouns      patchString `json:"pronouns"`
+	Bio           patchString `json:"bio"`
+}
+
+// clearedRequired reports the fields the patch clears that a user can't do
+// without, which are its name and email
+//
+// Returns:
+//   - error: A service.ValidationError listing them, or nil if there are none
+func (p userPatch) clearedRequired() error {
+	var invalid service.ValidationError
+	for _, field := range []struct {
+		name  string
+		value patchString
+	}{{"name", p.Name}, {"email", p.Email}} {
+		if field.value.set && field.value.orEmpty() == "" {
+			invalid.Fields = append(invalid.Fields, service.FieldError{Field: field.name, Message: "must not be cleared"})
+		}
+	}
+	if len(invalid.Fields) == 0 {
+		return nil
+	}
+	return &invalid
+}
+
+// patchString is a string field of a JSON merge patch, which tells a field
+// left out of the patch apart from one set to null
+type patchString struct {
+	// set is whether the patch has the field at all
+	set bool
+	
+	// value is the field's new value; nil when the patch sets it to null
+	value *string
+}
+
+// UnmarshalJSON implements json.Unmarshaler; encoding/json only calls it
+// for fields the patch has, null ones included
+func (p *patchString) UnmarshalJSON(data []byte) error {
+	p.set = true
+	if string(data) == "null" {
+		p.value = nil
+		return nil
+	}
+	return json.Unmarshal(data, &p.value)
+}
+
+// change converts the field to a service.ProfileUpdate change: nil when the
+// patch leaves it alone, and an empty string, which clears it, when the
+// patch sets it to null
+func (p patchString) change() *string {
+	if !p.set {
+		return nil
+	}
+	if p.value == nil {
+		cleared := ""
+		return &cleared
+	}
+	return p.value
+}
+
+// orEmpty returns the field's new value, or an empty string when the patch
+// leaves it alone or sets it to null
+func (p patchString) orEmpty() string {
+	if p.value == nil {
+		return ""
+	}
+	return *p.value
+}
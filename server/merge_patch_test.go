@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// patchableUser is the stored user the PATCH tests change
+func patchableUser(id int32) db.User {
+	return db.User{
+		ID:           id,
+		Name:         "Old Name",
+		Email:        "old@example.com",
+		Version:      1,
+		TwitchHandle: pgtype.Text{String: "old_twitch", Valid: true},
+		Pronouns:     pgtype.Text{String: "they/them", Valid: true},
+		Bio:          pgtype.Text{String: "Old bio", Valid: true},
+	}
+}
+
+func TestPatchUser_MergePatchSemantics(t *testing.T) {
+	var stored db.UpdateUserParams
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return patchableUser(id), nil
+		},
+		updateUser: func(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+			stored = arg
+			return db.User{ID: arg.ID, Name: arg.Name, Email: arg.Email, Version: arg.Version + 1}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(`{"bio": null, "pronouns": "she/her"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	req.Header.Set("If-Match", `"1"`)
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if etag := rec.Header().Get("ETag"); etag != `"2"` {
+		t.Errorf("expected ETag \"2\", got %q", etag)
+	}
+	if stored.Name != "Old Name" || stored.Email != "old@example.com" {
+		t.Errorf("expected the name and email left alone, got %q %q", stored.Name, stored.Email)
+	}
+	if stored.TwitchHandle.String != "old_twitch" || !stored.TwitchHandle.Valid {
+		t.Errorf("expected the omitted twitch handle left alone, got %+v", stored.TwitchHandle)
+	}
+	if stored.Bio.Valid {
+		t.Errorf("expected the null bio cleared, got %+v", stored.Bio)
+	}
+	if stored.Pronouns.String != "she/her" {
+		t.Errorf("expected pronouns she/her, got %+v", stored.Pronouns)
+	}
+}
+
+func TestPatchUser_RejectsInvalidPatches(t *testing.T) {
+	updated := false
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return patchableUser(id), nil
+		},
+		updateUser: func(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+			updated = true
+			return db.User{ID: arg.ID, Version: arg.Version + 1}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name           string
+		body           string
+		ifMatch        string
+		expectedStatus int
+	}{
+		{"null name", `{"name": null}`, `"1"`, http.StatusBadRequest},
+		{"empty email", `{"email": ""}`, `"1"`, http.StatusBadRequest},
+		{"unknown field", `{"bio": null, "boi": "typo"}`, `"1"`, http.StatusBadRequest},
+		{"missing If-Match", `{"bio": null}`, "", http.StatusPreconditionRequired},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		if tt.ifMatch != "" {
+			req.Header.Set("If-Match", tt.ifMatch)
+		}
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tt.expectedStatus {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.expectedStatus, rec.Code, rec.Body.String())
+		}
+	}
+	if updated {
+		t.Error("expected no invalid patch to be applied")
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/example/speedrun-rest-api/metrics"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// unmatchedRoute labels requests that matched no route, so probes for
+// random paths can't create unbounded label values
+const unmatchedRoute = "unmatched"
+
+// Metrics records request counts and latencies for the /metrics endpoint
+type Metrics struct {
+	registry *metrics.Registry
+	requests *metrics.CounterVec
+	duration *metrics.HistogramVec
+}
+
+// NewMetrics creates a registry exposing HTTP, in-flight, and Go runtime
+// metrics; other collectors such as the database pool are added with Register
+func NewMetrics(inFlight *InFlight) *Metrics {
+	m := &Metrics{
+		registry: metrics.NewRegistry(),
+		requests: metrics.NewCounterVec("http_requests_total",
+			"Number of HTTP requests served.",
+			"method", "route", "status",
+		),
+		duration: metrics.NewHistogramVec("http_request_duration_seconds",
+			"Time taken to serve HTTP requests.",
+			metrics.DefaultBuckets,
+			"method", "route", "status",
+		),
+	}
+	m.registry.Register(m.requests, m.duration,
+		metrics.CollectorFunc(func(w *metrics.Writer) {
+			w.Family("http_requests_in_flight", "gauge", "Number of HTTP requests currently being served.")
+			w.Sample("http_requests_in_flight", float64(inFlight.Count()))
+		}),
+		metrics.GoCollector(),
+	)
+	return m
+}
+
+// Register adds collectors to the /metrics output
+func (m *Metrics) Register(collectors ...metrics.Collector) {
+	m.registry.Register(collectors...)
+}
+
+// Middleware records every request under its route pattern, such as
+// /games/{slug}, rather than its path, so routes added to the spec are
+// covered without further wiring
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+		
+		route := routePattern(r)
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		
+		method := r.Method
+		if !slices.Contains(routeMethods, method) {
+			method = "other"
+		}
+		
+		labels := []string{method, route, strconv.Itoa(status)}
+		m.requests.Inc(labels...)
+		m.duration.Observe(time.Since(start).Seconds(), labels...)
+	})
+}
+
+// routePattern returns the pattern of the route that served r, with the
+// version prefix it was sent with, such as /v1/games/{slug}; it is only
+// known once the router has matched the request
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return servedPrefix(r.Context()) + rctx.RoutePattern()
+	}
+	return unmatchedRoute
+}
+
+// GetMetrics handles GET /metrics
+// Writes every registered metric in the Prometheus text format
+func (s *Server) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metrics.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := s.metrics.registry.WriteTo(w); err != nil {
+		slog.ErrorContext(r.Context(), "Error writing metrics", "error", err)
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/metrics"
+)
+
+func TestGetMetrics_RecordsRoutes(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	serve(http.MethodGet, "/version")
+	serve(http.MethodGet, "/version")
+	serve(http.MethodGet, "/no/such/path")
+	serve("BREW", "/version")
+
+	rec := serve(http.MethodGet, "/metrics")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != metrics.ContentType {
+		t.Errorf("expected Content-Type %q, got %q", metrics.ContentType, got)
+	}
+
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/version",status="200"} 2`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_requests_total{method="other",route="unmatched",status="405"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/version",status="200"} 2`,
+		"http_requests_in_flight 1",
+		"go_goroutines ",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListGameModerators handles GET /games/{slug}/moderators
+// Retrieves a game's moderation team
+func (s *Server) ListGameModerators(w http.ResponseWriter, r *http.Request, slug string) {
+	moderators, err := s.moderatorService.ListModerators(r.Context(), slug)
+	if err != nil {
+		s.writeModeratorError(w, r, err)
+		return
+	}
+	
+	apiModerators := make([]api.GameModerator, len(moderators))
+	for i, moderator := range moderators {
+		apiModerators[i] = toAPIGameModerator(&moderator)
+	}
+	
+	response := struct {
+		Moderators []api.GameModerator `json:"moderators"`
+	}{
+		Moderators: apiModerators,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// AddGameModerator handles POST /games/{slug}/moderators
+// Puts a user on a game's moderation team
+func (s *Server) AddGameModerator(w http.ResponseWriter, r *http.Request, slug string) {
+	var req api.AddGameModeratorRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	moderator, err := s.moderatorService.AddModerator(r.Context(), slug, int32(req.UserId), auth.Role(req.Level))
+	if err != nil {
+		s.writeModeratorError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, toAPIGameModerator(moderator))
+}
+
+// RemoveGameModerator handles DELETE /games/{slug}/moderators/{userId}
+// Takes a user off a game's moderation team
+func (s *Server) RemoveGameModerator(w http.ResponseWriter, r *http.Request, slug string, userId int) {
+	if err := s.moderatorService.RemoveModerator(r.Context(), slug, int32(userId)); err != nil {
+		s.writeModeratorError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeModeratorError maps ModeratorService errors to responses
+func (s *Server) writeModeratorError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, r, http.StatusForbidden, "Not allowed to manage this game's moderators", "FORBIDDEN")
+	default:
+		writeServiceError(w, r, err, "Error managing game moderators")
+	}
+}
+
+// toAPIGameModerator converts a place on a game's moderation team to an API
+// GameModerator model
+func toAPIGameModerator(moderator *db.ListGameModeratorsRow) api.GameModerator {
+	return api.GameModerator{
+		UserId:    int(moderator.UserID),
+		UserName:  moderator.UserName,
+		Level:     api.ModeratorLevel(moderator.Level),
+		CreatedAt: moderator.CreatedAt.Time.UTC(),
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeXML      = "application/xml"
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeMsgPack  = "application/msgpack"
+	contentTypeProblem  = "application/problem+json"
+	contentTypeCSV      = "text/csv"
+	contentTypeJSONL    = "application/jsonl"
+	contentTypeNDJSON   = "application/x-ndjson"
+)
+
+// responseContentTypes are the formats writeResponse can produce, in order of
+// preference when the client accepts several equally
+var responseContentTypes = []string{contentTypeJSON, contentTypeXML, contentTypeMsgPack, contentTypeProtobuf}
+
+// jsonContentTypes are the formats writeJSON can produce for reads: JSON, or
+// the compact binary encodings of it that high-volume bots may prefer
+// Protobuf is only produced for responses with a message type.
+var jsonContentTypes = []string{contentTypeJSON, contentTypeMsgPack, contentTypeProtobuf}
+
+// untypedContentTypes are the formats writeJSON can produce for reads with no
+// Protobuf message type
+var untypedContentTypes = []string{contentTypeJSON, contentTypeMsgPack}
+
+// negotiateContentType picks the offer that best matches an Accept header
+//
+// Media ranges are ranked by their q value; wildcards such as */* and
+// application/* match the first suitable offer. A missing or empty header
+// accepts the first offer.
+//
+// Returns the chosen content type, or "" if nothing offered is acceptable.
+func negotiateContentType(accept string, offers []string) string {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+	
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+		for _, offer := range offers {
+			if mediaTypeMatches(mediaType, offer) {
+				best, bestQ = offer, q
+				break
+			}
+		}
+	}
+	
+	return best
+}
+
+// mediaTypeMatches reports whether a media range such as "application/*"
+// covers a concrete content type
+func mediaTypeMatches(mediaRange, contentType string) bool {
+	if mediaRange == "*/*" || mediaRange == contentType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+	return ok && strings.HasPrefix(contentType, prefix+"/")
+}
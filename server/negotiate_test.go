@@ -0,0 +1,30 @@
+package server
+
+import "testing"
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{contentTypeJSON, contentTypeXML}
+
+	tests := []struct {
+		accept   string
+		expected string
+	}{
+		{"", contentTypeJSON},
+		{"application/json", contentTypeJSON},
+		{"application/xml", contentTypeXML},
+		{"application/*", contentTypeJSON},
+		{"*/*", contentTypeJSON},
+		{"text/html, application/xml;q=0.9, */*;q=0.8", contentTypeXML},
+		{"application/xml;q=0.4, application/json;q=0.6", contentTypeJSON},
+		{"application/json;q=0, application/xml", contentTypeXML},
+		{"application/json;q=0", ""},
+		{"text/csv", ""},
+		{"not a media type", ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateContentType(tt.accept, offers); got != tt.expected {
+			t.Errorf("negotiateContentType(%q) = %q, expected %q", tt.accept, got, tt.expected)
+		}
+	}
+}
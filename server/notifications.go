@@ -0,0 +1,160 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListNotifications handles GET /users/me/notifications
+// Lists the caller's notifications, newest first
+func (s *Server) ListNotifications(w http.ResponseWriter, r *http.Request, params api.ListNotificationsParams) {
+	unreadOnly := params.Unread != nil && *params.Unread
+	page, err := s.notificationService.ListNotifications(r.Context(), unreadOnly, pageRequest(params.Limit, params.Offset, params.Cursor))
+	if err != nil {
+		s.writeNotificationError(w, r, err)
+		return
+	}
+	
+	notifications := make([]api.Notification, len(page.Notifications))
+	for i, notification := range page.Notifications {
+		notifications[i] = toAPINotification(&notification)
+	}
+	
+	response := struct {
+		Notifications []api.Notification `json:"notifications"`
+		Total         int64              `json:"total"`
+		UnreadCount   int64              `json:"unread_count"`
+		Limit         int32              `json:"limit"`
+		Offset        int32              `json:"offset"`
+		NextCursor    string             `json:"next_cursor,omitempty"`
+	}{
+		Notifications: notifications,
+		Total:         page.Total,
+		UnreadCount:   page.Unread,
+		Limit:         page.Limit,
+		Offset:        page.Offset,
+		NextCursor:    page.NextCursor,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// GetUnreadNotificationCount handles GET /users/me/notifications/unread-count
+// Counts the caller's unread notifications
+func (s *Server) GetUnreadNotificationCount(w http.ResponseWriter, r *http.Request) {
+	count, err := s.notificationService.UnreadCount(r.Context())
+	if err != nil {
+		s.writeNotificationError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, struct {
+		UnreadCount int64 `json:"unread_count"`
+	}{UnreadCount: count})
+}
+
+// MarkAllNotificationsRead handles POST /users/me/notifications/read
+// Marks every one of the caller's notifications read
+func (s *Server) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.notificationService.MarkAllRead(r.Context()); err != nil {
+		s.writeNotificationError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkNotificationRead handles POST /users/me/notifications/{id}/read
+// Marks one of the caller's notifications read
+func (s *Server) MarkNotificationRead(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.notificationService.MarkRead(r.Context(), int32(id)); err != nil {
+		s.writeNotificationError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetNotificationSettings handles GET /users/me/settings/notifications
+// Retrieves which channels the caller is told about each event through
+func (s *Server) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	preferences, err := s.notificationService.NotificationPreferences(r.Context())
+	if err != nil {
+		s.writeNotificationError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, toAPINotificationSettings(preferences))
+}
+
+// UpdateNotificationSettings handles PUT /users/me/settings/notifications
+// Changes which channels the caller is told about the given events through
+func (s *Server) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	var req api.NotificationSettings
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	preferences := make([]service.NotificationPreference, len(req.Preferences))
+	for i, preference := range req.Preferences {
+		preferences[i] = service.NotificationPreference{
+			Event:    string(preference.Event),
+			Email:    preference.Email,
+			InApp:    preference.InApp,
+			Webhooks: preference.Webhooks,
+		}
+	}
+	updated, err := s.notificationService.UpdateNotificationPreferences(r.Context(), preferences)
+	if err != nil {
+		s.writeNotificationError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, toAPINotificationSettings(updated))
+}
+
+// writeNotificationError maps notification service errors to responses
+func (s *Server) writeNotificationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, r, http.StatusForbidden, "Only users have notifications", "FORBIDDEN")
+	default:
+		writeServiceError(w, r, err, "Error handling notifications")
+	}
+}
+
+// toAPINotification converts a database Notification model to an API
+// Notification model
+func toAPINotification(notification *db.Notification) api.Notification {
+	apiNotification := api.Notification{
+		Id:        int(notification.ID),
+		Event:     api.NotificationEvent(notification.Event),
+		Data:      notification.Payload,
+		Read:      notification.ReadAt.Valid,
+		CreatedAt: notification.CreatedAt.Time.UTC(),
+	}
+	if notification.ReadAt.Valid {
+		readAt := notification.ReadAt.Time.UTC()
+		apiNotification.ReadAt = &readAt
+	}
+	return apiNotification
+}
+
+// toAPINotificationSettings converts a user's notification preferences to
+// API NotificationSettings
+func toAPINotificationSettings(preferences []service.NotificationPreference) api.NotificationSettings {
+	settings := api.NotificationSettings{Preferences: make([]api.NotificationPreference, len(preferences))}
+	for i, preference := range preferences {
+		settings.Preferences[i] = api.NotificationPreference{
+			Event:    api.NotificationPreferenceEvent(preference.Event),
+			Email:    preference.Email,
+			InApp:    preference.InApp,
+			Webhooks: preference.Webhooks,
+		}
+	}
+	return settings
+}
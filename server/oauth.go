@@ -0,0 +1,128 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/oauth"
+)
+
+// oauthStateCookie carries the state of a login started in this browser, so
+// a callback can only complete a login its own browser began
+const oauthStateCookie = "oauth_state"
+
+// oauthStateTTL bounds how long a user has to get through a provider's
+// consent page
+const oauthStateTTL = 10 * time.Minute
+
+// newOAuthProviders creates the providers that have credentials configured
+func newOAuthProviders(cfg *config.Config) map[api.OAuthProvider]*oauth.Provider {
+	credentials := func(provider api.OAuthProvider, id, secret string) oauth.Credentials {
+		return oauth.Credentials{
+			ClientID:     id,
+			ClientSecret: secret,
+			RedirectURL:  cfg.PublicURL + "/auth/oauth/" + string(provider) + "/callback",
+		}
+	}
+	
+	providers := make(map[api.OAuthProvider]*oauth.Provider)
+	if cfg.TwitchClientID != "" && cfg.TwitchClientSecret != "" {
+		providers[api.Twitch] = oauth.Twitch(credentials(api.Twitch, cfg.TwitchClientID, cfg.TwitchClientSecret))
+	}
+	if cfg.DiscordClientID != "" && cfg.DiscordClientSecret != "" {
+		providers[api.Discord] = oauth.Discord(credentials(api.Discord, cfg.DiscordClientID, cfg.DiscordClientSecret))
+	}
+	return providers
+}
+
+// StartOAuth handles GET /auth/oauth/{provider}/start
+// Redirects the browser to the provider's consent page
+func (s *Server) StartOAuth(w http.ResponseWriter, r *http.Request, provider api.OAuthProvider) {
+	p, ok := s.oauthProviders[provider]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "OAuth provider not configured", "PROVIDER_NOT_FOUND")
+		return
+	}
+	
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		slog.ErrorContext(r.Context(), "Error generating OAuth state", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	state := base64.RawURLEncoding.EncodeToString(secret)
+	
+	http.SetCookie(w, s.stateCookie(provider, state, int(oauthStateTTL.Seconds())))
+	http.Redirect(w, r, p.AuthCodeURL(state), http.StatusFound)
+}
+
+// OAuthCallback handles GET /auth/oauth/{provider}/callback
+// Completes a provider login and issues a token pair for the linked user
+func (s *Server) OAuthCallback(w http.ResponseWriter, r *http.Request, provider api.OAuthProvider, params api.OAuthCallbackParams) {
+	p, ok := s.oauthProviders[provider]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "OAuth provider not configured", "PROVIDER_NOT_FOUND")
+		return
+	}
+	
+	// A state is good for one callback whatever its outcome
+	cookie, err := r.Cookie(oauthStateCookie)
+	http.SetCookie(w, s.stateCookie(provider, "", -1))
+	
+	if params.Error != nil {
+		writeError(w, r, http.StatusBadRequest, "Access was denied at the provider", "OAUTH_DENIED")
+		return
+	}
+	if err != nil || params.State == nil ||
+		subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(*params.State)) != 1 {
+		writeError(w, r, http.StatusBadRequest, "OAuth state does not match the login started in this browser", "INVALID_STATE")
+		return
+	}
+	if params.Code == nil || *params.Code == "" {
+		writeError(w, r, http.StatusBadRequest, "Missing authorization code", "INVALID_REQUEST")
+		return
+	}
+	
+	identity, err := p.Identify(r.Context(), *params.Code)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error identifying user", "provider", provider, "error", err)
+		writeError(w, r, http.StatusBadGateway, "Could not verify the login with the provider", "OAUTH_PROVIDER_ERROR")
+		return
+	}
+	
+	token, err := s.authService.LoginWithIdentity(r.Context(), identity)
+	if err != nil {
+		writeServiceError(w, r, err, "Error logging in", "provider", provider)
+		return
+	}
+	
+	s.writeToken(w, r, token)
+}
+
+// stateCookie builds the state cookie, scoped to one provider's paths;
+// a negative maxAge deletes it
+func (s *Server) stateCookie(provider api.OAuthProvider, state string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		Path:     "/auth/oauth/" + string(provider),
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   s.secureCookies,
+		// Lax still sends the cookie on the provider's top-level redirect back
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// isHTTPS reports whether a base URL is served over TLS, in which case
+// cookies are marked Secure
+func isHTTPS(baseURL string) bool {
+	return strings.HasPrefix(strings.ToLower(baseURL), "https://")
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func oauthTestRouter() http.Handler {
+	cfg := testConfig()
+	cfg.PublicURL = "https://api.example.com"
+	cfg.DiscordClientID = "client"
+	cfg.DiscordClientSecret = "secret"
+	return SetupRouter(NewServer(db.NewStore(nil), cfg))
+}
+
+func TestStartOAuth_RedirectsWithState(t *testing.T) {
+	rec := httptest.NewRecorder()
+	oauthTestRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/oauth/discord/start", nil))
+
+	if rec.Code != http.StatusFound {
+		t.Fatalf("expected 302, got %d", rec.Code)
+	}
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil || location.Host != "discord.com" {
+		t.Fatalf("expected a redirect to discord.com, got %q", rec.Header().Get("Location"))
+	}
+	if got := location.Query().Get("redirect_uri"); got != "https://api.example.com/auth/oauth/discord/callback" {
+		t.Errorf("expected callback under PUBLIC_URL, got %q", got)
+	}
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != oauthStateCookie {
+		t.Fatalf("expected a state cookie, got %v", cookies)
+	}
+	cookie := cookies[0]
+	if cookie.Value != location.Query().Get("state") {
+		t.Errorf("expected cookie to hold the state sent to the provider")
+	}
+	if !cookie.HttpOnly || !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("expected an HttpOnly, Secure, SameSite=Lax cookie, got %+v", cookie)
+	}
+}
+
+func TestStartOAuth_ProviderNotConfigured(t *testing.T) {
+	rec := httptest.NewRecorder()
+	oauthTestRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/oauth/twitch/start", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestOAuthCallback_RejectsBadState(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		cookie       string
+		expectedCode string
+	}{
+		{"no cookie", "?code=abc&state=xyz", "", "INVALID_STATE"},
+		{"mismatched state", "?code=abc&state=xyz", "other", "INVALID_STATE"},
+		{"missing state", "?code=abc", "xyz", "INVALID_STATE"},
+		{"denied", "?error=access_denied&state=xyz", "xyz", "OAUTH_DENIED"},
+		{"missing code", "?state=xyz", "xyz", "INVALID_REQUEST"},
+	}
+
+	router := oauthTestRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/auth/oauth/discord/callback"+tt.query, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: oauthStateCookie, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+			var resp api.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("expected JSON body, got error %v", err)
+			}
+			if resp.Code != tt.expectedCode {
+				t.Errorf("expected code %s, got %v", tt.expectedCode, resp.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/operations"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// GetOperation handles GET /operations/{id}
+// Retrieves one of the caller's operations, telling clients still waiting
+// for it to finish when to poll again
+func (s *Server) GetOperation(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	op, err := s.operationService.GetOperation(r.Context(), uuid.UUID(id))
+	if err != nil {
+		writeServiceError(w, r, err, "Error getting operation")
+		return
+	}
+	
+	if op.Status == operations.StatusPending || op.Status == operations.StatusRunning {
+		w.Header().Set("Retry-After", s.operationRetryAfter())
+	}
+	s.writeJSON(w, r, http.StatusOK, operationToAPI(op))
+}
+
+// GetOperationOutput handles GET /operations/{id}/output
+// Downloads the file one of the caller's operations made
+func (s *Server) GetOperationOutput(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	output, err := s.operationService.GetOperationOutput(r.Context(), uuid.UUID(id))
+	if err != nil {
+		writeServiceError(w, r, err, "Error getting operation output")
+		return
+	}
+	
+	w.Header().Set("Content-Type", output.ContentType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(output.Filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(output.Data)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(output.Data)
+	}
+}
+
+// prefersAsync reports whether prefer, a request's Prefer header, asks for
+// the request to be served in the background
+func prefersAsync(prefer *string) bool {
+	if prefer == nil {
+		return false
+	}
+	for _, preference := range strings.Split(*prefer, ",") {
+		token, _, _ := strings.Cut(preference, ";")
+		if strings.EqualFold(strings.TrimSpace(token), "respond-async") {
+			return true
+		}
+	}
+	return false
+}
+
+// loggedIn reports whether the caller of ctx logged in, as callers must to
+// start operations they can poll
+func loggedIn(ctx context.Context) bool {
+	p, ok := auth.PrincipalFromContext(ctx)
+	return ok && !p.ViaAPIKey()
+}
+
+// writeAccepted answers a request served in the background with 202, the
+// operation serving it, and where it can be polled
+func (s *Server) writeAccepted(w http.ResponseWriter, r *http.Request, op *db.Operation) {
+	operation := operationToAPI(op)
+	w.Header().Set("Location", servedPrefix(r.Context())+"/operations/"+operation.Id.String())
+	w.Header().Set("Preference-Applied", "respond-async")
+	w.Header().Set("Retry-After", s.operationRetryAfter())
+	s.writeJSON(w, r, http.StatusAccepted, operation)
+}
+
+// operationRetryAfter is the Retry-After sent to clients polling operations,
+// how often workers look for operations to run
+func (s *Server) operationRetryAfter() string {
+	return strconv.Itoa(max(1, int(math.Ceil(s.operationPollInterval.Seconds()))))
+}
+
+// operationToAPI converts a database operation to the API's operation
+func operationToAPI(op *db.Operation) api.Operation {
+	operation := api.Operation{
+		Id:         openapi_types.UUID(op.ID.Bytes),
+		Kind:       api.OperationKind(op.Kind),
+		Status:     api.OperationStatus(op.Status),
+		Progress:   api.OperationProgress{Completed: int(op.Completed)},
+		Error:      optionalText(op.Error),
+		CreatedAt:  op.CreatedAt.Time,
+		StartedAt:  optionalTimestamp(op.StartedAt),
+		FinishedAt: optionalTimestamp(op.FinishedAt),
+	}
+	if op.Total.Valid {
+		total := int(op.Total.Int32)
+		operation.Progress.Total = &total
+	}
+	if len(op.Result) > 0 {
+		var result any = json.RawMessage(op.Result)
+		operation.Result = &result
+	}
+	return operation
+}
+
+// OperationTasks returns the tasks background workers run operations with,
+// keyed by the kind of operation each runs
+func (s *Server) OperationTasks() map[string]operations.Task {
+	return map[string]operations.Task{
+		service.OperationUserImport: s.runUserImport,
+		service.OperationUserExport: s.runUserExport,
+	}
+}
+
+// runUserImport imports the users of an operation started by ImportUsers,
+// reporting the rows all at once since they are imported in one
+// transaction
+func (s *Server) runUserImport(ctx context.Context, op db.Operation, progress *operations.Progress) (*operations.Result, error) {
+	var input service.UserImportInput
+	ctx, err := s.operationService.ResumeOperation(ctx, op, &input)
+	if err != nil {
+		return nil, err
+	}
+	
+	report, err := s.userService.ImportUsers(ctx, input.Rows)
+	if err != nil {
+		return nil, operationError(err)
+	}
+	progress.Add(len(input.Rows))
+	return &operations.Result{Value: importReportToAPI(report)}, nil
+}
+
+// runUserExport writes the users of an operation started by ListUsers to a
+// file in the format it was asked for, which is downloaded from the
+// operation once it has succeeded
+func (s *Server) runUserExport(ctx context.Context, op db.Operation, progress *operations.Progress) (*operations.Result, error) {
+	var input service.UserExportInput
+	ctx, err := s.operationService.ResumeOperation(ctx, op, &input)
+	if err != nil {
+		return nil, err
+	}
+	
+	var buf bytes.Buffer
+	var encode func(user *api.User) error
+	flush := func() error { return nil }
+	if input.Format == contentTypeCSV {
+		out := csv.NewWriter(&buf)
+		if err := out.Write(userExportColumns); err != nil {
+			return nil, err
+		}
+		encode = func(user *api.User) error {
+			return out.Write(userExportRecord(user))
+		}
+		flush = func() error {
+			out.Flush()
+			return out.Error()
+		}
+	} else {
+		enc := json.NewEncoder(&buf)
+		encode = func(user *api.User) error {
+			return enc.Encode(user)
+		}
+	}
+	
+	rows := 0
+	err = s.userService.ExportUsers(ctx, input.Filter, func(user db.User) error {
+		apiUser := visibleUser(ctx, &user)
+		if err := encode(&apiUser); err != nil {
+			return err
+		}
+		rows++
+		progress.Add(1)
+		return nil
+	})
+	if err != nil {
+		return nil, operationError(err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	
+	// The total is only known once every user has been read
+	progress.SetTotal(rows)
+	return &operations.Result{
+		Value: map[string]int{"rows": rows},
+		Output: &operations.Output{
+			ContentType: input.Format,
+			Filename:    "users" + exportExtension(input.Format),
+			Data:        buf.Bytes(),
+		},
+	}, nil
+}
+
+// operationError describes the service errors an operation's caller can act
+// on, such as losing the role it needs, to them; other errors are left for
+// the worker to log
+func operationError(err error) error {
+	if errors.Is(err, service.ErrInvalidInput) {
+		return operations.Fail("%s", err.Error())
+	}
+	for _, known := range serviceErrors {
+		if errors.Is(err, known.err) {
+			return operations.Fail("%s", known.detail)
+		}
+	}
+	return err
+}
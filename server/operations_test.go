@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/operations"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// operationStub holds one operation of kind, started by user 1, with the
+// file output when it isn't nil
+func operationStub(id uuid.UUID, kind, status string, output *db.OperationOutput) *stubQueries {
+	op := db.Operation{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		Kind:      kind,
+		CreatedBy: 1,
+		Status:    status,
+		Completed: 3,
+		CreatedAt: pgtype.Timestamptz{Time: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), Valid: true},
+	}
+	return &stubQueries{
+		getUserByID: func(ctx context.Context, userID int32) (db.User, error) {
+			return db.User{ID: userID}, nil
+		},
+		getOperationByID: func(ctx context.Context, opID pgtype.UUID) (db.Operation, error) {
+			if opID != op.ID {
+				return db.Operation{}, sql.ErrNoRows
+			}
+			return op, nil
+		},
+		getOperationOutput: func(ctx context.Context, operationID pgtype.UUID) (db.OperationOutput, error) {
+			if output == nil || operationID != op.ID {
+				return db.OperationOutput{}, sql.ErrNoRows
+			}
+			return *output, nil
+		},
+	}
+}
+
+func TestGetOperation_PollsUntilFinished(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		status     string
+		retryAfter string
+		output     bool
+	}{
+		{operations.StatusRunning, "2", false},
+		{operations.StatusSucceeded, "", true},
+		{operations.StatusFailed, "", false},
+	}
+
+	for _, tt := range tests {
+		router := SetupRouter(NewServer(operationStub(id, service.OperationUserExport, tt.status, nil), testConfig()))
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations/"+id.String(), nil)
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", tt.status, rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Retry-After"); got != tt.retryAfter {
+			t.Errorf("%s: expected Retry-After %q, got %q", tt.status, tt.retryAfter, got)
+		}
+		var op api.Operation
+		if err := json.NewDecoder(rec.Body).Decode(&op); err != nil {
+			t.Fatalf("%s: failed to decode operation: %v", tt.status, err)
+		}
+		if op.Id.String() != id.String() || string(op.Status) != tt.status || op.Progress.Completed != 3 || op.Progress.Total != nil {
+			t.Errorf("%s: expected the operation, got %+v", tt.status, op)
+		}
+		if op.Links == nil || (*op.Links)["self"].Href != "/operations/"+id.String() {
+			t.Fatalf("%s: expected the operation to link to itself, got %+v", tt.status, op.Links)
+		}
+		if _, ok := (*op.Links)["output"]; ok != tt.output {
+			t.Errorf("%s: expected an output link %v, got %+v", tt.status, tt.output, *op.Links)
+		}
+	}
+}
+
+func TestGetOperation_HidesOtherUsersOperations(t *testing.T) {
+	id := uuid.New()
+	router := SetupRouter(NewServer(operationStub(id, service.OperationUserImport, operations.StatusRunning, nil), testConfig()))
+
+	for _, path := range []string{"/operations/" + id.String(), "/operations/" + uuid.NewString()} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", bearerToken(t, 2))
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), "OPERATION_NOT_FOUND") {
+			t.Errorf("%s: expected 404 OPERATION_NOT_FOUND, got %d: %s", path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestGetOperationOutput_Downloads(t *testing.T) {
+	id := uuid.New()
+	output := &db.OperationOutput{ContentType: contentTypeCSV, Filename: "users.csv", Data: []byte("id,name\n1,Ada\n")}
+	router := SetupRouter(NewServer(operationStub(id, service.OperationUserExport, operations.StatusSucceeded, output), testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/operations/"+id.String()+"/output", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != contentTypeCSV {
+		t.Errorf("expected Content-Type %s, got %q", contentTypeCSV, ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="users.csv"` {
+		t.Errorf("expected the file to be downloaded as users.csv, got %q", cd)
+	}
+	if rec.Body.String() != "id,name\n1,Ada\n" {
+		t.Errorf("expected the file, got %q", rec.Body.String())
+	}
+}
+
+func TestGetOperationOutput_NotFoundUntilSucceeded(t *testing.T) {
+	id := uuid.New()
+	router := SetupRouter(NewServer(operationStub(id, service.OperationUserExport, operations.StatusRunning, nil), testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/operations/"+id.String()+"/output", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), "OPERATION_OUTPUT_NOT_FOUND") {
+		t.Errorf("expected 404 OPERATION_OUTPUT_NOT_FOUND, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestImportUsers_RespondAsync(t *testing.T) {
+	var imported []db.ImportUsersParams
+	var created db.CreateOperationParams
+	id := uuid.New()
+	queries := importStub(&imported)
+	queries.createOperation = func(ctx context.Context, arg db.CreateOperationParams) (db.Operation, error) {
+		created = arg
+		return db.Operation{ID: pgtype.UUID{Bytes: id, Valid: true}, Kind: arg.Kind, CreatedBy: arg.CreatedBy, Status: operations.StatusPending, Total: arg.Total}, nil
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/import", strings.NewReader("name,email\nGrace,grace@example.com\nAda,ada@example.com\n"))
+	req.Header.Set("Content-Type", contentTypeCSV)
+	req.Header.Set("Prefer", "wait=10, respond-async")
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if loc := rec.Header().Get("Location"); loc != "/operations/"+id.String() {
+		t.Errorf("expected the operation's Location, got %q", loc)
+	}
+	if rec.Header().Get("Preference-Applied") != "respond-async" || rec.Header().Get("Retry-After") != "2" {
+		t.Errorf("expected Preference-Applied and Retry-After, got %v", rec.Header())
+	}
+	var op api.Operation
+	if err := json.NewDecoder(rec.Body).Decode(&op); err != nil {
+		t.Fatalf("failed to decode operation: %v", err)
+	}
+	if op.Kind != api.UsersImport || op.Status != api.OperationStatusPending || op.Progress.Total == nil || *op.Progress.Total != 2 {
+		t.Errorf("expected a pending import of 2 rows, got %+v", op)
+	}
+	if created.CreatedBy != 1 || len(imported) != 0 {
+		t.Errorf("expected the import to be left to a worker, got %+v and %+v", created, imported)
+	}
+}
+
+func TestListUsers_ExportRespondAsync(t *testing.T) {
+	var created db.CreateOperationParams
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{3: {{UserID: 3, Role: "admin"}}}),
+		createOperation: func(ctx context.Context, arg db.CreateOperationParams) (db.Operation, error) {
+			created = arg
+			return db.Operation{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Kind: arg.Kind, CreatedBy: arg.CreatedBy, Status: operations.StatusPending}, nil
+		},
+		exportUsers: func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+			t.Error("expected the export to be left to a worker")
+			return nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users?name=fan", nil)
+	req.Header.Set("Accept", contentTypeCSV)
+	req.Header.Set("Prefer", "respond-async")
+	req.Header.Set("Authorization", bearerToken(t, 3))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var input service.UserExportInput
+	if err := json.Unmarshal(created.Input, &input); err != nil {
+		t.Fatalf("failed to decode input: %v", err)
+	}
+	if created.Kind != service.OperationUserExport || created.CreatedBy != 3 || input.Filter.Name != "fan" || input.Format != contentTypeCSV {
+		t.Errorf("expected a CSV export of the filtered users, got %+v with %+v", created, input)
+	}
+}
+
+func TestOperationTasks_ExportWritesFile(t *testing.T) {
+	queries := &stubQueries{
+		listUserRoles: rolesFor(map[int32][]db.UserRole{3: {{UserID: 3, Role: "admin"}}}),
+		exportUsers: func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+			for _, user := range []db.User{{ID: 1, Name: "Ada", Email: "ada@example.com"}, {ID: 2, Name: "Grace", Email: "grace@example.com"}} {
+				if err := fn(user); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	srv := NewServer(queries, testConfig())
+
+	op := db.Operation{Kind: service.OperationUserExport, CreatedBy: 3, Input: []byte(`{"filter":{},"format":"application/x-ndjson"}`)}
+	progress := &operations.Progress{}
+	result, err := srv.OperationTasks()[service.OperationUserExport](context.Background(), op, progress)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Output == nil || result.Output.Filename != "users.jsonl" || result.Output.ContentType != contentTypeNDJSON {
+		t.Fatalf("expected a JSON Lines file, got %+v", result.Output)
+	}
+	lines := strings.Split(strings.TrimSpace(string(result.Output.Data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"name":"Grace"`) {
+		t.Errorf("expected a line per user, got %q", result.Output.Data)
+	}
+	if rows, _ := json.Marshal(result.Value); string(rows) != `{"rows":2}` {
+		t.Errorf("expected the number of rows as the result, got %s", rows)
+	}
+}
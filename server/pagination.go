@@ -0,0 +1,19 @@
+package server
+
+import "github.com/example/speedrun-rest-api/service"
+
+// pageRequest collects the pagination query parameters of a list endpoint
+// Omitted values are passed as zero; the service applies its defaults.
+func pageRequest(limit, offset *int, cursor *string) service.PageRequest {
+	var page service.PageRequest
+	if limit != nil {
+		page.Limit = *limit
+	}
+	if offset != nil {
+		page.Offset = *offset
+	}
+	if cursor != nil {
+		page.Cursor = *cursor
+	}
+	return page
+}
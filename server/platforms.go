@@ -0,0 +1,89 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// ListPlatforms handles GET /platforms
+// Retrieves every platform runs can be played on
+func (s *Server) ListPlatforms(w http.ResponseWriter, r *http.Request) {
+	platforms, err := s.platformService.ListPlatforms(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error listing platforms", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	apiPlatforms := make([]api.Platform, len(platforms))
+	for i, platform := range platforms {
+		apiPlatforms[i] = dbPlatformToAPIPlatform(&platform)
+	}
+	
+	response := struct {
+		Platforms []api.Platform `json:"platforms"`
+	}{
+		Platforms: apiPlatforms,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreatePlatform handles POST /platforms
+// Adds a platform runs can be played on
+func (s *Server) CreatePlatform(w http.ResponseWriter, r *http.Request) {
+	var req api.CreatePlatformRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	platform, err := s.platformService.CreatePlatform(r.Context(), req.Slug, req.Name)
+	if err != nil {
+		writeServiceError(w, r, err, "Error creating platform")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, dbPlatformToAPIPlatform(platform))
+}
+
+// UpdatePlatform handles PATCH /platforms/{slug}
+// Renames a platform
+func (s *Server) UpdatePlatform(w http.ResponseWriter, r *http.Request, slug string) {
+	var req api.UpdatePlatformRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	platform, err := s.platformService.UpdatePlatform(r.Context(), slug, req.Name)
+	if err != nil {
+		writeServiceError(w, r, err, "Error updating platform")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, dbPlatformToAPIPlatform(platform))
+}
+
+// DeletePlatform handles DELETE /platforms/{slug}
+// Deletes a platform no run was played on
+func (s *Server) DeletePlatform(w http.ResponseWriter, r *http.Request, slug string) {
+	err := s.platformService.DeletePlatform(r.Context(), slug)
+	if err != nil {
+		writeServiceError(w, r, err, "Error deleting platform")
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dbPlatformToAPIPlatform converts a database Platform model to an API Platform model
+func dbPlatformToAPIPlatform(platform *db.Platform) api.Platform {
+	return api.Platform{
+		Id:        int(platform.ID),
+		Slug:      platform.Slug,
+		Name:      platform.Name,
+		CreatedAt: platform.CreatedAt.Time.UTC(),
+	}
+}
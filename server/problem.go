@@ -0,0 +1,152 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// problemTypePrefix is prefixed to an error code, in kebab case, to form
+// the type of its problems
+const problemTypePrefix = "/problems/"
+
+// serviceErrors maps the service layer's errors to the status, error code,
+// and detail of the problem reported for them; handlers that can say more
+// about an error write their own detail before falling back to this table
+var serviceErrors = []struct {
+	err    error
+	status int
+	code   string
+	detail string
+}{
+	{service.ErrInvalidCursor, http.StatusBadRequest, "INVALID_CURSOR", "Invalid cursor"},
+	{service.ErrTooManyIDs, http.StatusBadRequest, "TOO_MANY_IDS", "Too many user IDs requested"},
+	{service.ErrTooManyImportRows, http.StatusBadRequest, "TOO_MANY_IMPORT_ROWS", fmt.Sprintf("Import file must have at most %d rows", service.MaxImportRows)},
+	{service.ErrInvalidVerificationToken, http.StatusBadRequest, "INVALID_TOKEN", "Verification token is invalid or expired"},
+	{service.ErrInvalidCredentials, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid email or password"},
+	{service.ErrInvalidRefreshToken, http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "Invalid refresh token"},
+	{service.ErrForbidden, http.StatusForbidden, "FORBIDDEN", "Forbidden"},
+	{service.ErrAccountDeleted, http.StatusForbidden, "ACCOUNT_DELETED", "This account has been deleted"},
+	{service.ErrEmailNotVerified, http.StatusForbidden, "EMAIL_NOT_VERIFIED", "Verify your email address first"},
+	{service.ErrCommentEditWindowClosed, http.StatusForbidden, "EDIT_WINDOW_CLOSED", "Comment can no longer be edited"},
+	{service.ErrUserNotFound, http.StatusNotFound, "USER_NOT_FOUND", "User not found"},
+	{service.ErrGameNotFound, http.StatusNotFound, "GAME_NOT_FOUND", "Game not found"},
+	{service.ErrCategoryNotFound, http.StatusNotFound, "CATEGORY_NOT_FOUND", "Category not found"},
+	{service.ErrLevelNotFound, http.StatusNotFound, "LEVEL_NOT_FOUND", "Level not found"},
+	{service.ErrPlatformNotFound, http.StatusNotFound, "PLATFORM_NOT_FOUND", "Platform not found"},
+	{service.ErrRegionNotFound, http.StatusNotFound, "REGION_NOT_FOUND", "Region not found"},
+	{service.ErrRunNotFound, http.StatusNotFound, "RUN_NOT_FOUND", "Run not found"},
+	{service.ErrCommentNotFound, http.StatusNotFound, "COMMENT_NOT_FOUND", "Comment not found"},
+	{service.ErrNotificationNotFound, http.StatusNotFound, "NOTIFICATION_NOT_FOUND", "Notification not found"},
+	{service.ErrModeratorNotFound, http.StatusNotFound, "MODERATOR_NOT_FOUND", "User does not moderate this game"},
+	{service.ErrAPIKeyNotFound, http.StatusNotFound, "API_KEY_NOT_FOUND", "API key not found"},
+	{service.ErrWebhookNotFound, http.StatusNotFound, "WEBHOOK_NOT_FOUND", "Webhook not found"},
+	{service.ErrOperationNotFound, http.StatusNotFound, "OPERATION_NOT_FOUND", "Operation not found"},
+	{service.ErrOperationOutputNotFound, http.StatusNotFound, "OPERATION_OUTPUT_NOT_FOUND", "Operation has no output"},
+	{service.ErrDuplicateEmail, http.StatusConflict, "DUPLICATE_EMAIL", "User with this email already exists"},
+	{service.ErrDuplicateSlug, http.StatusConflict, "DUPLICATE_SLUG", "Game with this slug already exists"},
+	{service.ErrDuplicateCategorySlug, http.StatusConflict, "DUPLICATE_SLUG", "Game already has a category with this slug"},
+	{service.ErrDuplicateLevelSlug, http.StatusConflict, "DUPLICATE_SLUG", "Game already has a level with this slug"},
+	{service.ErrDuplicateVariableSlug, http.StatusConflict, "DUPLICATE_SLUG", "Game already has a variable with this slug"},
+	{service.ErrDuplicatePlatformSlug, http.StatusConflict, "DUPLICATE_SLUG", "Platform with this slug already exists"},
+	{service.ErrDuplicateRegionSlug, http.StatusConflict, "DUPLICATE_SLUG", "Region with this slug already exists"},
+	{service.ErrAlreadyModerator, http.StatusConflict, "ALREADY_MODERATOR", "User already moderates this game"},
+	{service.ErrPlatformInUse, http.StatusConflict, "PLATFORM_IN_USE", "Runs were played on this platform"},
+	{service.ErrRegionInUse, http.StatusConflict, "REGION_IN_USE", "Runs were played in this region"},
+	{service.ErrInvalidRunTransition, http.StatusConflict, "INVALID_TRANSITION", "Run is not pending review"},
+	{service.ErrUserActive, http.StatusConflict, "USER_ACTIVE", "User has not been deleted"},
+	{service.ErrTooManyAPIKeys, http.StatusConflict, "TOO_MANY_API_KEYS", "Revoke an existing API key before creating another"},
+	{service.ErrIdempotencyKeyInProgress, http.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS", "A request with this Idempotency-Key is still being served"},
+	{service.ErrVersionMismatch, http.StatusPreconditionFailed, "VERSION_MISMATCH", "User has been modified since it was read"},
+	{service.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used for a different request"},
+	{service.ErrUnverifiedEmail, http.StatusUnprocessableEntity, "UNVERIFIED_EMAIL", "The provider account has no verified email"},
+	{service.ErrAvatarsDisabled, http.StatusServiceUnavailable, "AVATARS_DISABLED", "Avatar uploads are not configured"},
+}
+
+// newProblem creates the problem reported for a request
+func newProblem(r *http.Request, status int, detail, code string) api.Problem {
+	return api.Problem{
+		Type:     problemTypePrefix + strings.ReplaceAll(strings.ToLower(code), "_", "-"),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: requestPath(r),
+		Code:     code,
+	}
+}
+
+// writeProblem writes a problem as an application/problem+json response
+func writeProblem(w http.ResponseWriter, problem api.Problem) {
+	w.Header().Set("Content-Type", contentTypeProblem)
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		slog.Error("Error encoding error response", "error", err)
+	}
+}
+
+// writeError writes an error response for a request
+func writeError(w http.ResponseWriter, r *http.Request, status int, detail, code string) {
+	writeProblem(w, newProblem(r, status, detail, code))
+}
+
+// writeServiceError writes the error response for an error from the
+// service layer
+// Invalid input is reported with its details, listing each invalid field of
+// a service.ValidationError against the body. Errors the table doesn't know
+// are logged with msg and args and reported as internal errors, without
+// their details.
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error, msg string, args ...any) {
+	var invalid *service.ValidationError
+	if errors.As(err, &invalid) {
+		writeProblem(w, fieldProblem(r, invalid, api.Body))
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		writeError(w, r, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+	for _, known := range serviceErrors {
+		if errors.Is(err, known.err) {
+			writeError(w, r, known.status, known.detail, known.code)
+			return
+		}
+	}
+	slog.ErrorContext(r.Context(), msg, append(args, "error", err)...)
+	writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+}
+
+// writeQueryServiceError writes the error response for an error from the
+// service layer, as writeServiceError does, for handlers whose input is their
+// query string; the invalid fields of a service.ValidationError are reported
+// against it
+func writeQueryServiceError(w http.ResponseWriter, r *http.Request, err error, msg string, args ...any) {
+	var invalid *service.ValidationError
+	if errors.As(err, &invalid) {
+		writeProblem(w, fieldProblem(r, invalid, api.Query))
+		return
+	}
+	writeServiceError(w, r, err, msg, args...)
+}
+
+// fieldProblem creates the problem reported for input that failed the
+// service layer's validation, whose fields are found in in
+func fieldProblem(r *http.Request, invalid *service.ValidationError, in api.ErrorDetailIn) api.Problem {
+	problem := newProblem(r, http.StatusBadRequest, invalid.Error(), "INVALID_INPUT")
+	problem.Errors = fieldDetails(invalid.Fields, in)
+	return problem
+}
+
+// fieldDetails describes each invalid field, found in in
+func fieldDetails(fields []service.FieldError, in api.ErrorDetailIn) *[]api.ErrorDetail {
+	details := make([]api.ErrorDetail, len(fields))
+	for i, field := range fields {
+		details[i] = api.ErrorDetail{In: in, Name: &field.Field, Message: field.Message}
+	}
+	return &details
+}
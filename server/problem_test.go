@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+func TestGetUser_NotFoundProblem(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{}, sql.ErrNoRows
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/999", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != contentTypeProblem {
+		t.Errorf("expected Content-Type %s, got %q", contentTypeProblem, ct)
+	}
+
+	var problem api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	expected := api.Problem{
+		Type:     "/problems/user-not-found",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "User not found",
+		Instance: "/users/999",
+		Code:     "USER_NOT_FOUND",
+	}
+	if problem.Errors != nil {
+		t.Errorf("expected no field errors, got %v", *problem.Errors)
+	}
+	problem.Errors = nil
+	if problem != expected {
+		t.Errorf("expected %+v, got %+v", expected, problem)
+	}
+}
+
+func TestWriteServiceError_MapsErrors(t *testing.T) {
+	tests := []struct {
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedDetail string
+	}{
+		{fmt.Errorf("loading run: %w", service.ErrRunNotFound), http.StatusNotFound, "RUN_NOT_FOUND", "Run not found"},
+		{service.ErrDuplicateLevelSlug, http.StatusConflict, "DUPLICATE_SLUG", "Game already has a level with this slug"},
+		{fmt.Errorf("%w: name is required", service.ErrInvalidInput), http.StatusBadRequest, "INVALID_INPUT", "invalid input: name is required"},
+		{errors.New("connection refused"), http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error"},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		writeServiceError(rec, httptest.NewRequest(http.MethodGet, "/runs/1", nil), tt.err, "Error loading run")
+
+		var problem api.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+			t.Fatalf("%v: failed to decode problem: %v", tt.err, err)
+		}
+		if rec.Code != tt.expectedStatus || problem.Status != tt.expectedStatus {
+			t.Errorf("%v: expected status %d, got %d (body %d)", tt.err, tt.expectedStatus, rec.Code, problem.Status)
+		}
+		if problem.Code != tt.expectedCode || problem.Detail != tt.expectedDetail {
+			t.Errorf("%v: expected %s %q, got %s %q", tt.err, tt.expectedCode, tt.expectedDetail, problem.Code, problem.Detail)
+		}
+	}
+}
+
+func TestUpdateUser_FieldErrors(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Old Name", Email: "old@example.com", Version: 1}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"name": "   ", "twitch_handle": "_mario"}`))
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	req.Header.Set("If-Match", `"1"`)
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var problem api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if problem.Code != "INVALID_INPUT" || problem.Errors == nil {
+		t.Fatalf("expected INVALID_INPUT with field errors, got %+v", problem)
+	}
+
+	var fields []string
+	for _, detail := range *problem.Errors {
+		if detail.In != api.Body || detail.Name == nil {
+			t.Errorf("expected a body field error, got %+v", detail)
+			continue
+		}
+		fields = append(fields, *detail.Name)
+	}
+	if !slices.Equal(fields, []string{"name", "twitch_handle"}) {
+		t.Errorf("expected errors for name and twitch_handle, got %v", fields)
+	}
+}
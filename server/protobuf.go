@@ -0,0 +1,182 @@
+package server
+
+import (
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/rpc"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// runStatusMessages maps the statuses of runs to RunStatus values
+var runStatusMessages = map[api.RunStatus]rpc.RunStatus{
+	api.RunStatusPending:  rpc.RunStatus_RUN_STATUS_PENDING,
+	api.RunStatusVerified: rpc.RunStatus_RUN_STATUS_VERIFIED,
+	api.RunStatusRejected: rpc.RunStatus_RUN_STATUS_REJECTED,
+}
+
+// protoMessage converts data, a response writeJSON sends, to the message the
+// gRPC API returns for the same resources, for clients that accept Protobuf;
+// it reports false for data with no message type
+// A sparse response keeps every field, as its XML does, and links, which the
+// messages have no field for, are left out.
+func protoMessage(data any) (proto.Message, bool) {
+	switch v := data.(type) {
+	case sparse:
+		return protoMessage(v.data)
+	case api.User:
+		return userMessage(&v), true
+	case api.BatchGetUsersResponse:
+		response := &rpc.BatchGetUsersResponse{
+			Users:      make([]*rpc.User, len(v.Users)),
+			MissingIds: make([]int32, len(v.MissingIds)),
+		}
+		for i := range v.Users {
+			response.Users[i] = userMessage(&v.Users[i])
+		}
+		for i, id := range v.MissingIds {
+			response.MissingIds[i] = int32(id)
+		}
+		return response, true
+	case userListResponse:
+		response := &rpc.ListUsersResponse{
+			Users:      make([]*rpc.User, len(v.Users)),
+			Total:      v.Total,
+			NextCursor: v.NextCursor,
+		}
+		for i := range v.Users {
+			response.Users[i] = userMessage(&v.Users[i])
+		}
+		return response, true
+	case runListResponse:
+		response := &rpc.ListRunsResponse{
+			Runs:       make([]*rpc.Run, len(v.Runs)),
+			Total:      v.Total,
+			NextCursor: v.NextCursor,
+		}
+		for i := range v.Runs {
+			response.Runs[i] = runMessage(&v.Runs[i])
+		}
+		return response, true
+	case leaderboardResponse:
+		response := &rpc.GetLeaderboardResponse{
+			Entries:    make([]*rpc.LeaderboardEntry, len(v.Entries)),
+			Total:      v.Total,
+			NextCursor: v.NextCursor,
+		}
+		for i, entry := range v.Entries {
+			response.Entries[i] = &rpc.LeaderboardEntry{
+				Rank:     int32(entry.Rank),
+				RunId:    int32(entry.RunId),
+				UserId:   int32(entry.UserId),
+				UserName: entry.UserName,
+				TimeMs:   entry.TimeMs,
+				Times:    runTimesMessage(entry.Times),
+				VideoUrl: entry.VideoUrl,
+				Platform: entry.Platform,
+				PlayedOn: entry.PlayedOn.Format(openapi_types.DateFormat),
+			}
+		}
+		return response, true
+	case personalBestsResponse:
+		response := &rpc.ListPersonalBestsResponse{
+			PersonalBests: make([]*rpc.PersonalBest, len(v.PersonalBests)),
+		}
+		for i, best := range v.PersonalBests {
+			response.PersonalBests[i] = &rpc.PersonalBest{
+				Rank:         int32(best.Rank),
+				RunId:        int32(best.RunId),
+				GameId:       int32(best.GameId),
+				GameSlug:     best.GameSlug,
+				GameName:     best.GameName,
+				CategoryId:   int32(best.CategoryId),
+				CategorySlug: best.CategorySlug,
+				CategoryName: best.CategoryName,
+				TimeMs:       best.TimeMs,
+				RecordTimeMs: best.RecordTimeMs,
+				DeltaMs:      best.DeltaMs,
+				VideoUrl:     best.VideoUrl,
+				Platform:     best.Platform,
+				PlayedOn:     best.PlayedOn.Format(openapi_types.DateFormat),
+			}
+		}
+		return response, true
+	}
+	return nil, false
+}
+
+// userMessage converts an API User to a User message; an email left out for
+// the caller stays empty
+func userMessage(user *api.User) *rpc.User {
+	message := &rpc.User{
+		Id:              int32(user.Id),
+		PublicId:        user.PublicId.String(),
+		Name:            user.Name,
+		CreatedAt:       timestamppb.New(user.CreatedAt),
+		UpdatedAt:       timestamppb.New(user.UpdatedAt),
+		EmailVerifiedAt: timestampMessage(user.EmailVerifiedAt),
+		TwitchHandle:    user.TwitchHandle,
+		YoutubeHandle:   user.YoutubeHandle,
+		TwitterHandle:   user.TwitterHandle,
+		CountryCode:     user.CountryCode,
+		Pronouns:        user.Pronouns,
+		Bio:             user.Bio,
+		AvatarUrl:       user.AvatarUrl,
+	}
+	if user.Email != nil {
+		message.Email = string(*user.Email)
+	}
+	return message
+}
+
+// runMessage converts an API Run to a Run message, leaving out the runner,
+// game, and category it may embed
+func runMessage(run *api.Run) *rpc.Run {
+	message := &rpc.Run{
+		Id:              int32(run.Id),
+		UserId:          int32(run.UserId),
+		CategoryId:      int32(run.CategoryId),
+		LevelId:         optionalInt32(run.LevelId),
+		TimeMs:          run.TimeMs,
+		Times:           runTimesMessage(run.Times),
+		VideoUrl:        run.VideoUrl,
+		Platform:        run.Platform,
+		Region:          run.Region,
+		PlayedOn:        run.PlayedOn.Format(openapi_types.DateFormat),
+		CreatedAt:       timestamppb.New(run.CreatedAt),
+		Status:          runStatusMessages[run.Status],
+		RejectionReason: run.RejectionReason,
+		ReviewedAt:      timestampMessage(run.ReviewedAt),
+		Obsolete:        run.Obsolete,
+		RaceId:          optionalInt32(run.RaceId),
+	}
+	if run.Variables != nil {
+		message.Variables = *run.Variables
+	}
+	return message
+}
+
+// runTimesMessage converts a run's times to a RunTimes message
+func runTimesMessage(times api.RunTimes) *rpc.RunTimes {
+	return &rpc.RunTimes{RtaMs: times.RtaMs, IgtMs: times.IgtMs, LrtMs: times.LrtMs}
+}
+
+// optionalInt32 converts an optional ID to an optional message field
+func optionalInt32(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+	id := int32(*n)
+	return &id
+}
+
+// timestampMessage converts an optional time to a message field that is
+// unset when the time is
+func timestampMessage(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,354 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/example/speedrun-rest-api/stream"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// maxRaceCommandBytes bounds the messages a client may send in a race
+	// room; commands are a few short fields
+	maxRaceCommandBytes = 4 << 10
+	
+	// raceRoomWriteTimeout is how long a message to a race room client may
+	// take to send before the client is disconnected
+	raceRoomWriteTimeout = 10 * time.Second
+)
+
+// Types of the messages sent in a race room
+const (
+	raceRoomMessageRace  = "race"
+	raceRoomMessageError = "error"
+)
+
+// raceCommand is a RaceCommand a client sends in a race room
+type raceCommand struct {
+	Type     string `json:"type"`
+	VideoURL string `json:"video_url"`
+	Platform string `json:"platform"`
+	Region   string `json:"region"`
+}
+
+// raceRoomMessage is a RaceRoomMessage the server sends in a race room
+type raceRoomMessage struct {
+	Type       string       `json:"type"`
+	ServerTime time.Time    `json:"server_time"`
+	Race       *api.Race    `json:"race,omitempty"`
+	Error      *api.Problem `json:"error,omitempty"`
+}
+
+// ListRaces handles GET /games/{slug}/categories/{category}/races
+// Lists a category's open and running races, oldest first
+func (s *Server) ListRaces(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	races, err := s.raceService.ListRaces(r.Context(), slug, category)
+	if err != nil {
+		s.writeRaceError(w, r, err)
+		return
+	}
+	
+	apiRaces := make([]api.Race, len(races))
+	for i, race := range races {
+		apiRaces[i] = toAPIRace(&race)
+	}
+	
+	response := struct {
+		Races []api.Race `json:"races"`
+	}{
+		Races: apiRaces,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateRace handles POST /games/{slug}/categories/{category}/races
+// Opens a race room for a category with the caller as its first participant
+func (s *Server) CreateRace(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	race, err := s.raceService.CreateRace(r.Context(), slug, category)
+	if err != nil {
+		s.writeRaceError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, toAPIRace(race))
+}
+
+// GetRace handles GET /races/{id}
+// Retrieves a race and its participants
+func (s *Server) GetRace(w http.ResponseWriter, r *http.Request, id int) {
+	race, err := s.raceService.GetRace(r.Context(), int32(id))
+	if err != nil {
+		s.writeRaceError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, toAPIRace(race))
+}
+
+// EnterRaceRoom handles GET /races/{id}/live
+// Upgrades to a WebSocket that sends the race's state whenever it changes and
+// takes commands from its participants
+func (s *Server) EnterRaceRoom(w http.ResponseWriter, r *http.Request, id int, params api.EnterRaceRoomParams) {
+	ctx := r.Context()
+	if _, ok := auth.PrincipalFromContext(ctx); !ok && params.AccessToken != nil {
+		if ctx, ok = s.authenticator.authenticateToken(w, r, *params.AccessToken); !ok {
+			return
+		}
+	}
+	race, err := s.raceService.GetRace(ctx, int32(id))
+	if err != nil {
+		s.writeRaceError(w, r, err)
+		return
+	}
+	
+	// Subscribing before the upgrade means no change made meanwhile is missed
+	updates, unsubscribe := s.stream.Subscribe(stream.Filter{Events: []string{stream.RaceUpdated}, RaceID: race.ID})
+	defer unsubscribe()
+	
+	// The room stays open for longer than the server's read and write timeouts
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		slog.WarnContext(ctx, "Unable to lift the read timeout; the race room will be cut off by it", "error", err)
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(ctx, "Unable to lift the write timeout; the race room will be cut off by it", "error", err)
+	}
+	conn, err := websocket.Accept(w, r, s.raceRoomAcceptOptions())
+	if err != nil {
+		// Accept has already responded
+		return
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(maxRaceCommandBytes)
+	
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		defer cancel()
+		s.readRaceCommands(ctx, r, conn, race.ID)
+	}()
+	
+	apiRace := toAPIRace(race)
+	if err := sendRaceRoomMessage(ctx, conn, raceRoomMessage{Type: raceRoomMessageRace, Race: &apiRace}); err != nil {
+		return
+	}
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-updates:
+			if !ok {
+				conn.Close(websocket.StatusGoingAway, "Reconnect to follow the race")
+				return
+			}
+			if err := s.sendRace(ctx, conn, race.ID); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			pingCtx, cancelPing := context.WithTimeout(ctx, raceRoomWriteTimeout)
+			err := conn.Ping(pingCtx)
+			cancelPing()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readRaceCommands carries out the commands a client sends in a race room
+// until it disconnects
+// Refused commands are answered with an error message; accepted ones with
+// the race's new state, which the room as a whole also receives once the
+// change reaches every instance. r is the request that opened the room.
+func (s *Server) readRaceCommands(ctx context.Context, r *http.Request, conn *websocket.Conn, raceID int32) {
+	for {
+		var cmd raceCommand
+		if err := wsjson.Read(ctx, conn, &cmd); err != nil {
+			var syntaxErr *json.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				return
+			}
+			cmd = raceCommand{}
+		}
+		
+		race, err := s.runRaceCommand(ctx, raceID, cmd)
+		message := raceRoomMessage{Type: raceRoomMessageRace}
+		if err != nil {
+			status, text, code := raceErrorResponse(err, cmd.Type)
+			if status == http.StatusInternalServerError {
+				slog.ErrorContext(ctx, "Error handling race command", "race_id", raceID, "command", cmd.Type, "error", err)
+			}
+			problem := newProblem(r, status, text, code)
+			message = raceRoomMessage{Type: raceRoomMessageError, Error: &problem}
+		} else {
+			apiRace := toAPIRace(race)
+			message.Race = &apiRace
+		}
+		if err := sendRaceRoomMessage(ctx, conn, message); err != nil {
+			return
+		}
+	}
+}
+
+// runRaceCommand carries out one command in a race room
+func (s *Server) runRaceCommand(ctx context.Context, raceID int32, cmd raceCommand) (*service.Race, error) {
+	switch cmd.Type {
+	case "join":
+		return s.raceService.JoinRace(ctx, raceID)
+	case "leave":
+		return s.raceService.LeaveRace(ctx, raceID)
+	case "ready":
+		return s.raceService.SetReady(ctx, raceID, true)
+	case "unready":
+		return s.raceService.SetReady(ctx, raceID, false)
+	case "finish":
+		return s.raceService.FinishRace(ctx, raceID, service.FinishRaceInput{
+			VideoURL: cmd.VideoURL,
+			Platform: cmd.Platform,
+			Region:   cmd.Region,
+		})
+	case "cancel":
+		return s.raceService.CancelRace(ctx, raceID)
+	default:
+		return nil, errUnknownRaceCommand
+	}
+}
+
+// errUnknownRaceCommand is returned for a race room message that is not a
+// RaceCommand
+var errUnknownRaceCommand = errors.New("unknown race command")
+
+// sendRace sends a race room client the race's current state
+func (s *Server) sendRace(ctx context.Context, conn *websocket.Conn, raceID int32) error {
+	race, err := s.raceService.GetRace(ctx, raceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting race", "race_id", raceID, "error", err)
+		return err
+	}
+	apiRace := toAPIRace(race)
+	return sendRaceRoomMessage(ctx, conn, raceRoomMessage{Type: raceRoomMessageRace, Race: &apiRace})
+}
+
+// sendRaceRoomMessage sends message stamped with the server's clock
+func sendRaceRoomMessage(ctx context.Context, conn *websocket.Conn, message raceRoomMessage) error {
+	message.ServerTime = time.Now().UTC()
+	ctx, cancel := context.WithTimeout(ctx, raceRoomWriteTimeout)
+	defer cancel()
+	return wsjson.Write(ctx, conn, message)
+}
+
+// raceRoomAcceptOptions allows browsers to enter race rooms from the origins
+// CORS_ALLOWED_ORIGINS lists, besides the API's own
+func (s *Server) raceRoomAcceptOptions() *websocket.AcceptOptions {
+	if slices.Contains(s.raceRoomOrigins, "*") {
+		return &websocket.AcceptOptions{InsecureSkipVerify: true}
+	}
+	return &websocket.AcceptOptions{OriginPatterns: s.raceRoomOrigins}
+}
+
+// writeRaceError maps race service errors to responses
+// The only field a race is created with is its category, from the path.
+func (s *Server) writeRaceError(w http.ResponseWriter, r *http.Request, err error) {
+	var invalid *service.ValidationError
+	if errors.As(err, &invalid) {
+		writeProblem(w, fieldProblem(r, invalid, api.Path))
+		return
+	}
+	status, message, code := raceErrorResponse(err, "")
+	if status == http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "Error handling race", "error", err)
+	}
+	writeError(w, r, status, message, code)
+}
+
+// raceErrorResponse maps a race service error to a status, message, and
+// code; command is the race room command that failed, if any
+func raceErrorResponse(err error, command string) (status int, message, code string) {
+	switch {
+	case errors.Is(err, errUnknownRaceCommand):
+		return http.StatusBadRequest, "type must be join, leave, ready, unready, finish, or cancel", "INVALID_INPUT"
+	case errors.Is(err, service.ErrInvalidInput):
+		return http.StatusBadRequest, err.Error(), "INVALID_INPUT"
+	case errors.Is(err, service.ErrRaceNotFound):
+		return http.StatusNotFound, "Race not found", "RACE_NOT_FOUND"
+	case errors.Is(err, service.ErrCategoryNotFound):
+		return http.StatusNotFound, "Category not found", "CATEGORY_NOT_FOUND"
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound, "User not found", "USER_NOT_FOUND"
+	case errors.Is(err, service.ErrEmailNotVerified):
+		return http.StatusForbidden, "Verify your email address before racing", "EMAIL_NOT_VERIFIED"
+	case errors.Is(err, service.ErrForbidden) && command == "cancel":
+		return http.StatusForbidden, "Only the race's creator and admins may cancel it", "FORBIDDEN"
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden, "Log in with an access token to race", "FORBIDDEN"
+	case errors.Is(err, service.ErrRaceClosed):
+		return http.StatusConflict, "Race is no longer open", "RACE_CLOSED"
+	case errors.Is(err, service.ErrRaceNotRunning):
+		return http.StatusConflict, "Race is not running", "RACE_NOT_RUNNING"
+	case errors.Is(err, service.ErrNotInRace):
+		return http.StatusConflict, "Join the race first", "NOT_IN_RACE"
+	case errors.Is(err, service.ErrRaceDone):
+		return http.StatusConflict, "You have already finished or forfeited this race", "RACE_DONE"
+	default:
+		return http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR"
+	}
+}
+
+// toAPIRace converts a race and its participants to an API Race model
+func toAPIRace(race *service.Race) api.Race {
+	apiRace := api.Race{
+		Id:           int(race.ID),
+		CategoryId:   int(race.CategoryID),
+		Status:       api.RaceStatus(race.Status),
+		StartsAt:     optionalTimestamp(race.StartsAt),
+		FinishedAt:   optionalTimestamp(race.FinishedAt),
+		CreatedAt:    race.CreatedAt.Time.UTC(),
+		Participants: make([]api.RaceParticipant, len(race.Participants)),
+	}
+	if race.CreatedBy.Valid {
+		createdBy := int(race.CreatedBy.Int32)
+		apiRace.CreatedBy = &createdBy
+	}
+	for i, participant := range race.Participants {
+		apiRace.Participants[i] = api.RaceParticipant{
+			UserId:      int(participant.UserID),
+			Name:        participant.UserName,
+			Ready:       participant.Ready,
+			JoinedAt:    participant.JoinedAt.Time.UTC(),
+			FinishedAt:  optionalTimestamp(participant.FinishedAt),
+			ForfeitedAt: optionalTimestamp(participant.ForfeitedAt),
+		}
+		if participant.TimeMs.Valid {
+			apiRace.Participants[i].TimeMs = &participant.TimeMs.Int64
+		}
+		if participant.RunID.Valid {
+			runID := int(participant.RunID.Int32)
+			apiRace.Participants[i].RunId = &runID
+		}
+	}
+	return apiRace
+}
+
+// optionalTimestamp converts a nullable timestamp to an optional API field in
+// UTC
+func optionalTimestamp(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	utc := t.Time.UTC()
+	return &utc
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/stream"
+)
+
+// raceQueries stubs a single race with the given status and no participants
+func raceQueries(race *db.Race) *stubQueries {
+	return &stubQueries{
+		getRaceByID: func(ctx context.Context, id int32) (db.Race, error) {
+			if id != race.ID {
+				return db.Race{}, sql.ErrNoRows
+			}
+			return *race, nil
+		},
+		listRaceParticipants: func(ctx context.Context, raceID int32) ([]db.ListRaceParticipantsRow, error) {
+			return nil, nil
+		},
+	}
+}
+
+func TestGetRace(t *testing.T) {
+	race := &db.Race{ID: 7, CategoryID: 3, Status: "open"}
+	router := SetupRouter(NewServer(raceQueries(race), testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/races/7", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"participants":[]`) {
+		t.Errorf("expected the race with no participants, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	for path, want := range map[string]int{
+		"/races/8":                         http.StatusNotFound,
+		"/races/8/live":                    http.StatusNotFound,
+		"/races/7/live?access_token=bogus": http.StatusUnauthorized,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: expected %d, got %d: %s", path, want, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestEnterRaceRoom_SendsUpdates(t *testing.T) {
+	race := &db.Race{ID: 7, CategoryID: 3, Status: "open"}
+	srv := NewServer(raceQueries(race), testConfig())
+	ts := httptest.NewServer(SetupRouter(srv))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(ts.URL, "http")+"/races/7/live", nil)
+	if err != nil {
+		t.Fatalf("failed to enter race room: %v", err)
+	}
+	defer conn.CloseNow()
+
+	var message raceRoomMessage
+	if err := wsjson.Read(ctx, conn, &message); err != nil {
+		t.Fatalf("failed to read race: %v", err)
+	}
+	if message.Type != "race" || message.Race == nil || message.Race.Status != "open" || message.ServerTime.IsZero() {
+		t.Fatalf("expected the race's state, got %+v", message)
+	}
+
+	race.Status = "running"
+	srv.Stream().Publish(stream.Message{Event: stream.RaceUpdated, RaceID: 8})
+	srv.Stream().Publish(stream.Message{Event: stream.RaceUpdated, RaceID: 7})
+	if err := wsjson.Read(ctx, conn, &message); err != nil {
+		t.Fatalf("failed to read update: %v", err)
+	}
+	if message.Race == nil || message.Race.Status != "running" {
+		t.Errorf("expected the race reloaded on its update, got %+v", message)
+	}
+
+	// Spectators may watch but not race
+	if err := wsjson.Write(ctx, conn, raceCommand{Type: "join"}); err != nil {
+		t.Fatalf("failed to send command: %v", err)
+	}
+	if err := wsjson.Read(ctx, conn, &message); err != nil {
+		t.Fatalf("failed to read reply: %v", err)
+	}
+	if message.Type != "error" || message.Error == nil || message.Error.Code != "FORBIDDEN" {
+		t.Errorf("expected the join refused, got %+v", message)
+	}
+}
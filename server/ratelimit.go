@@ -0,0 +1,131 @@
+package server
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/ratelimit"
+)
+
+// RateLimiter throttles each caller to a token-bucket limit
+//
+// Authenticated requests draw from a bucket per API key or per user, so
+// clients behind a shared NAT don't throttle each other; anonymous requests
+// draw from a bucket per client IP.
+type RateLimiter struct {
+	store  ratelimit.Store
+	perIP  ratelimit.Limit
+	perKey ratelimit.Limit
+}
+
+// NewRateLimiter creates a limiter backed by store; a nil store disables
+// rate limiting
+func NewRateLimiter(store ratelimit.Store, perIP, perKey ratelimit.Limit) *RateLimiter {
+	return &RateLimiter{store: store, perIP: perIP, perKey: perKey}
+}
+
+// newRateLimitStore creates the store selected by the configuration
+func newRateLimitStore(cfg *config.Config) ratelimit.Store {
+	if !cfg.RateLimitEnabled {
+		return nil
+	}
+	if cfg.RedisURL == "" {
+		return ratelimit.NewMemory()
+	}
+	
+	store, err := ratelimit.NewRedis(cfg.RedisURL)
+	if err != nil {
+		slog.Error("Unable to set up rate limiting", "error", err)
+		os.Exit(1)
+	}
+	return store
+}
+
+// Middleware rejects callers that have used up their bucket with 429 Too
+// Many Requests and a Retry-After header. Every limited response carries
+// X-RateLimit-Limit, X-RateLimit-Remaining, and X-RateLimit-Reset.
+//
+// It must run after the authenticator so authenticated callers are
+// recognized; requests the authenticator refuses never reach it and are
+// charged through ChargeFailure instead. Health probes are never limited. If the store fails the
+// request is let through, so an outage of a shared store does not take the
+// API down with it.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	if l.store == nil {
+		return next
+	}
+	
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isProbe(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		key, limit := l.bucket(r)
+		if l.take(w, r, key, limit) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// ChargeFailure takes a token from the client IP's bucket for a request
+// whose credentials were refused, so guessing tokens or keys is throttled
+// like anonymous traffic rather than by the bucket of the caller it claims
+// to be. It reports false, having answered 429, once the bucket is empty.
+func (l *RateLimiter) ChargeFailure(w http.ResponseWriter, r *http.Request) bool {
+	if l == nil || l.store == nil {
+		return true
+	}
+	return l.take(w, r, "ip:"+ClientIPFromContext(r.Context()), l.perIP)
+}
+
+// take removes a token from the bucket named key and sets the rate limit
+// headers; when it reports false the response has been written
+func (l *RateLimiter) take(w http.ResponseWriter, r *http.Request, key string, limit ratelimit.Limit) bool {
+	result, err := l.store.Take(r.Context(), key, limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error checking rate limit, allowing request", "error", err)
+		return true
+	}
+	
+	h := w.Header()
+	h.Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	if !result.Allowed {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		writeError(w, r, http.StatusTooManyRequests, "Rate limit exceeded", "RATE_LIMITED")
+		return false
+	}
+	return true
+}
+
+// Close releases the store's resources
+func (l *RateLimiter) Close() error {
+	if l.store == nil {
+		return nil
+	}
+	return l.store.Close()
+}
+
+// bucket picks the bucket a request draws from
+func (l *RateLimiter) bucket(r *http.Request) (string, ratelimit.Limit) {
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		if p.ViaAPIKey() {
+			return "key:" + strconv.Itoa(int(p.APIKeyID)), l.perKey
+		}
+		return "user:" + strconv.Itoa(int(p.UserID)), l.perKey
+	}
+	return "ip:" + ClientIPFromContext(r.Context()), l.perIP
+}
+
+// ceilSeconds rounds a duration up to whole seconds, as the headers use
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/ratelimit"
+)
+
+// failingStore is a rate limit store whose backend is down
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func (failingStore) Close() error { return nil }
+
+func rateLimitedHandler(l *RateLimiter) http.Handler {
+	// The client IP middleware runs first in SetupRouter
+	return NewClientIP(nil).Middleware(l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+}
+
+func TestRateLimiter_PerIP(t *testing.T) {
+	handler := rateLimitedHandler(NewRateLimiter(ratelimit.NewMemory(), ratelimit.PerMinute(2), ratelimit.PerMinute(10)))
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/games", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i, remaining := range []string{"1", "0"} {
+		rec := serve("192.0.2.1:1234")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, rec.Code)
+		}
+		if got := rec.Header().Get("X-RateLimit-Remaining"); got != remaining {
+			t.Errorf("request %d: expected %s remaining, got %q", i+1, remaining, got)
+		}
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "2" {
+			t.Errorf("request %d: expected limit 2, got %q", i+1, got)
+		}
+	}
+
+	rec := serve("192.0.2.1:5678")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the bucket is empty, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+	if got := rec.Header().Get("X-RateLimit-Reset"); got != "60" {
+		t.Errorf("expected X-RateLimit-Reset 60, got %q", got)
+	}
+
+	if rec := serve("192.0.2.2:1234"); rec.Code != http.StatusOK {
+		t.Errorf("expected another IP to be allowed, got %d", rec.Code)
+	}
+}
+
+func TestRateLimiter_AuthenticatedCallersHaveOwnBuckets(t *testing.T) {
+	handler := rateLimitedHandler(NewRateLimiter(ratelimit.NewMemory(), ratelimit.PerMinute(1), ratelimit.PerMinute(1)))
+	serve := func(p *auth.Principal) int {
+		req := httptest.NewRequest(http.MethodPost, "/games", nil)
+		if p != nil {
+			req = req.WithContext(auth.WithPrincipal(req.Context(), *p))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Same user and IP, but a token, an API key, and no credentials are
+	// each limited separately
+	callers := []*auth.Principal{{UserID: 1}, {UserID: 1, APIKeyID: 7}, nil}
+	for _, p := range callers {
+		if code := serve(p); code != http.StatusOK {
+			t.Errorf("%+v: expected first request to be allowed, got %d", p, code)
+		}
+	}
+	for _, p := range callers {
+		if code := serve(p); code != http.StatusTooManyRequests {
+			t.Errorf("%+v: expected second request to be limited, got %d", p, code)
+		}
+	}
+}
+
+func TestRateLimiter_StoreErrorAllowsRequest(t *testing.T) {
+	handler := rateLimitedHandler(NewRateLimiter(failingStore{}, ratelimit.PerMinute(1), ratelimit.PerMinute(1)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 when the store fails, got %d", rec.Code)
+	}
+	if rec.Header().Get("X-RateLimit-Limit") != "" {
+		t.Error("expected no rate limit headers when the store fails")
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	handler := rateLimitedHandler(NewRateLimiter(nil, ratelimit.Limit{}, ratelimit.Limit{}))
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 with rate limiting disabled, got %d", rec.Code)
+		}
+	}
+}
+
+func TestRateLimiter_ChargesRefusedCredentialsToIP(t *testing.T) {
+	cfg := testConfig()
+	cfg.RateLimitPerIP = 3
+	queries := &stubQueries{
+		getAPIKeyByHash: apiKeysFor(map[string]db.ApiKey{}),
+	}
+	router := SetupRouter(NewServer(queries, cfg))
+	serve := func(header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/games", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("Authorization", header)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i, header := range []string{"ApiKey srk_guess1", "ApiKey srk_guess2", "Bearer not-a-token"} {
+		if rec := serve(header); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d: %s", i+1, rec.Code, rec.Body.String())
+		}
+	}
+	rec := serve("ApiKey srk_guess3")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the IP's bucket is empty, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// ListRegions handles GET /regions
+// Retrieves every region runs can record they were played in
+func (s *Server) ListRegions(w http.ResponseWriter, r *http.Request) {
+	regions, err := s.regionService.ListRegions(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error listing regions", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	apiRegions := make([]api.Region, len(regions))
+	for i, region := range regions {
+		apiRegions[i] = dbRegionToAPIRegion(&region)
+	}
+	
+	response := struct {
+		Regions []api.Region `json:"regions"`
+	}{
+		Regions: apiRegions,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateRegion handles POST /regions
+// Adds a region runs can record they were played in
+func (s *Server) CreateRegion(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateRegionRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	region, err := s.regionService.CreateRegion(r.Context(), req.Slug, req.Name)
+	if err != nil {
+		writeServiceError(w, r, err, "Error creating region")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, dbRegionToAPIRegion(region))
+}
+
+// UpdateRegion handles PATCH /regions/{slug}
+// Renames a region
+func (s *Server) UpdateRegion(w http.ResponseWriter, r *http.Request, slug string) {
+	var req api.UpdateRegionRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	region, err := s.regionService.UpdateRegion(r.Context(), slug, req.Name)
+	if err != nil {
+		writeServiceError(w, r, err, "Error updating region")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, dbRegionToAPIRegion(region))
+}
+
+// DeleteRegion handles DELETE /regions/{slug}
+// Deletes a region no run was played in
+func (s *Server) DeleteRegion(w http.ResponseWriter, r *http.Request, slug string) {
+	err := s.regionService.DeleteRegion(r.Context(), slug)
+	if err != nil {
+		writeServiceError(w, r, err, "Error deleting region")
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dbRegionToAPIRegion converts a database Region model to an API Region model
+func dbRegionToAPIRegion(region *db.Region) api.Region {
+	return api.Region{
+		Id:        int(region.ID),
+		Slug:      region.Slug,
+		Name:      region.Name,
+		CreatedAt: region.CreatedAt.Time.UTC(),
+	}
+}
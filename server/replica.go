@@ -0,0 +1,19 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// readYourWrites runs every query made while serving a mutating request on
+// the primary database, so the lookups and checks around a write never see a
+// read replica's older copy of the rows it changes
+func readYourWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isMutatingRequest(r) {
+			r = r.WithContext(db.WithPrimary(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
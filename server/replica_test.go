@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	var primary bool
+	handler := readYourWrites(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primary = db.UsesPrimary(r.Context())
+	}))
+
+	for method, want := range map[string]bool{
+		http.MethodGet:    false,
+		http.MethodHead:   false,
+		http.MethodPost:   true,
+		http.MethodPatch:  true,
+		http.MethodDelete: true,
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/users/1", nil))
+		if primary != want {
+			t.Errorf("%s: expected primary to be %v, got %v", method, want, primary)
+		}
+	}
+}
@@ -0,0 +1,451 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+	"github.com/jackc/pgx/v5/pgtype"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// runListResponse is the paginated body returned by the run list endpoints
+type runListResponse struct {
+	Runs       []api.Run  `json:"runs"`
+	Total      int64      `json:"total"`
+	Limit      int32      `json:"limit"`
+	Offset     int32      `json:"offset"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	Links      *api.Links `json:"_links,omitempty"`
+}
+
+// SubmitRun handles POST /games/{slug}/categories/{category}/runs
+// Records a run for a game category
+func (s *Server) SubmitRun(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	var req api.SubmitRunRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	if principal, _ := auth.PrincipalFromContext(r.Context()); int(principal.UserID) != req.UserId {
+		writeError(w, r, http.StatusForbidden, "Runs can only be submitted for yourself", "FORBIDDEN")
+		return
+	}
+	
+	input := service.SubmitRunInput{
+		UserID:    int32(req.UserId),
+		VideoURL:  req.VideoUrl,
+		Platform:  req.Platform,
+		PlayedOn:  req.PlayedOn.Time,
+		Variables: service.RunVariables{},
+	}
+	if req.Variables != nil {
+		input.Variables = *req.Variables
+	}
+	if req.Level != nil {
+		input.LevelSlug = *req.Level
+	}
+	if req.Region != nil {
+		input.Region = *req.Region
+	}
+	if req.Times != nil {
+		if req.Times.RtaMs != nil {
+			input.Times.RTA = *req.Times.RtaMs
+		}
+		if req.Times.IgtMs != nil {
+			input.Times.IGT = *req.Times.IgtMs
+		}
+		if req.Times.LrtMs != nil {
+			input.Times.LRT = *req.Times.LrtMs
+		}
+	}
+	// time_ms is shorthand for the real time, kept from before runs had
+	// more than one time
+	if req.TimeMs != nil {
+		if req.Times != nil && req.Times.RtaMs != nil {
+			writeError(w, r, http.StatusBadRequest, "Give the real time as time_ms or times.rta_ms, not both", "INVALID_INPUT")
+			return
+		}
+		input.Times.RTA = *req.TimeMs
+	}
+	
+	run, err := s.runService.SubmitRun(r.Context(), slug, category, input)
+	if err != nil {
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			writeError(w, r, http.StatusForbidden, "Verify your email address before submitting runs", "EMAIL_NOT_VERIFIED")
+			return
+		}
+		writeServiceError(w, r, err, "Error submitting run")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, dbRunToAPIRun(run, input.Variables))
+}
+
+// ListCategoryRuns handles GET /games/{slug}/categories/{category}/runs
+// Retrieves a paginated list of a category's runs, fastest first, or streams
+// all of them when the Accept header asks for CSV or JSON Lines
+func (s *Server) ListCategoryRuns(w http.ResponseWriter, r *http.Request, slug string, category string, params api.ListCategoryRunsParams) {
+	filter := service.ListRunsFilter{IncludeObsolete: params.IncludeObsolete != nil && *params.IncludeObsolete}
+	if format := exportFormat(r, jsonContentTypes); format != "" {
+		export := newExportWriter(w, r, format, slug+"-"+category+"-runs", runExportColumns)
+		err := export.close(s.runService.ExportCategoryRuns(r.Context(), slug, category, filter, func(run db.Run) error {
+			apiRun := dbRunToAPIRun(&run, nil)
+			return export.write(apiRun, runExportRecord(&apiRun))
+		}))
+		if err != nil {
+			writeServiceError(w, r, err, "Error exporting category runs")
+		}
+		return
+	}
+	
+	page, err := s.runService.ListCategoryRuns(r.Context(), slug, category, pageRequest(params.Limit, params.Offset, params.Cursor), filter)
+	if err != nil {
+		writeServiceError(w, r, err, "Error listing category runs")
+		return
+	}
+	
+	response := toRunListResponse(page)
+	if err := s.includeRunRelations(r.Context(), response.Runs, params.Include); err != nil {
+		writeServiceError(w, r, err, "Error listing category runs")
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// ListUserRuns handles GET /users/{id}/runs
+// Retrieves a paginated list of a user's runs, newest first
+func (s *Server) ListUserRuns(w http.ResponseWriter, r *http.Request, id int, params api.ListUserRunsParams) {
+	filter := service.ListRunsFilter{IncludeObsolete: params.IncludeObsolete != nil && *params.IncludeObsolete}
+	page, err := s.runService.ListUserRuns(r.Context(), int32(id), pageRequest(params.Limit, params.Offset, params.Cursor), filter)
+	if err != nil {
+		writeServiceError(w, r, err, "Error listing user runs")
+		return
+	}
+	
+	response := toRunListResponse(page)
+	if err := s.includeRunRelations(r.Context(), response.Runs, params.Include); err != nil {
+		writeServiceError(w, r, err, "Error listing user runs")
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// ListUserPersonalBests handles GET /users/{id}/personal-bests
+// Returns a user's best verified run in every category, with its rank and
+// gap to the world record
+func (s *Server) ListUserPersonalBests(w http.ResponseWriter, r *http.Request, id int) {
+	bests, err := s.runService.PersonalBests(r.Context(), int32(id))
+	if err != nil {
+		writeServiceError(w, r, err, "Error listing personal bests")
+		return
+	}
+	
+	personalBests := make([]api.PersonalBest, len(bests))
+	for i, best := range bests {
+		personalBests[i] = api.PersonalBest{
+			Rank:         int(best.Rank),
+			RunId:        int(best.ID),
+			GameId:       int(best.GameID),
+			GameSlug:     best.GameSlug,
+			GameName:     best.GameName,
+			CategoryId:   int(best.CategoryID),
+			CategorySlug: best.CategorySlug,
+			CategoryName: best.CategoryName,
+			TimeMs:       best.TimeMs,
+			RecordTimeMs: best.RecordTimeMs,
+			DeltaMs:      best.DeltaMs,
+			VideoUrl:     best.VideoUrl,
+			Platform:     best.Platform,
+			PlayedOn:     openapi_types.Date{Time: best.PlayedOn.Time},
+		}
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, personalBestsResponse{PersonalBests: personalBests})
+}
+
+// personalBestsResponse is the body of a user's personal bests
+type personalBestsResponse struct {
+	PersonalBests []api.PersonalBest `json:"personal_bests"`
+}
+
+// GetLeaderboard handles GET /games/{slug}/categories/{category}/leaderboard
+// Returns each runner's best full-game run in a category, ranked fastest first
+func (s *Server) GetLeaderboard(w http.ResponseWriter, r *http.Request, slug string, category string, params api.GetLeaderboardParams) {
+	filter, err := leaderboardFilter(params.Variable, params.Platform, params.Region)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+	
+	page, err := s.runService.Leaderboard(r.Context(), slug, category, pageRequest(params.Limit, params.Offset, params.Cursor), filter)
+	if err != nil {
+		writeServiceError(w, r, err, "Error getting leaderboard")
+		return
+	}
+	
+	s.writeLeaderboard(w, r, page)
+}
+
+// writeLeaderboard writes a page of ranked standings as the response to a
+// leaderboard request, tagged so that clients polling it are sent 304 Not
+// Modified until the standings change
+func (s *Server) writeLeaderboard(w http.ResponseWriter, r *http.Request, page *service.LeaderboardPage) {
+	entries := make([]api.LeaderboardEntry, len(page.Entries))
+	for i, entry := range page.Entries {
+		entries[i] = api.LeaderboardEntry{
+			Rank:     int(entry.Rank),
+			RunId:    int(entry.ID),
+			UserId:   int(entry.UserID),
+			UserName: entry.UserName,
+			TimeMs:   entry.TimeMs,
+			Times:    toAPIRunTimes(entry.RtaMs, entry.IgtMs, entry.LrtMs),
+			VideoUrl: entry.VideoUrl,
+			Platform: entry.Platform,
+			PlayedOn: openapi_types.Date{Time: entry.PlayedOn.Time},
+		}
+	}
+	
+	response := leaderboardResponse{
+		Entries:    entries,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+	
+	s.writeTaggedJSON(w, r, response)
+}
+
+// leaderboardResponse is the body of a page of a leaderboard
+type leaderboardResponse struct {
+	Entries    []api.LeaderboardEntry `json:"entries"`
+	Total      int64                  `json:"total"`
+	Limit      int32                  `json:"limit"`
+	Offset     int32                  `json:"offset"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// GetRecordHistory handles GET /games/{slug}/categories/{category}/records/history
+// Returns every run that held a category's record, oldest first
+func (s *Server) GetRecordHistory(w http.ResponseWriter, r *http.Request, slug string, category string) {
+	history, err := s.runService.RecordHistory(r.Context(), slug, category)
+	if err != nil {
+		writeServiceError(w, r, err, "Error getting record history")
+		return
+	}
+	
+	records := make([]api.RecordHistoryEntry, len(history))
+	for i, record := range history {
+		records[i] = api.RecordHistoryEntry{
+			RunId:    int(record.RunID),
+			UserId:   int(record.UserID),
+			UserName: record.UserName,
+			TimeMs:   record.TimeMs,
+			VideoUrl: record.VideoUrl,
+			Platform: record.Platform,
+			PlayedOn: openapi_types.Date{Time: record.PlayedOn.Time},
+			SetAt:    record.SetAt.Time,
+		}
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, struct {
+		Records []api.RecordHistoryEntry `json:"records"`
+	}{records})
+}
+
+// GetModerationQueue handles GET /games/{slug}/moderation/queue
+// Lists a game's runs waiting for review, longest waiting first; requires a
+// moderator of the game
+func (s *Server) GetModerationQueue(w http.ResponseWriter, r *http.Request, slug string, params api.GetModerationQueueParams) {
+	page, err := s.runService.ModerationQueue(r.Context(), slug, pageRequest(params.Limit, params.Offset, params.Cursor))
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			writeError(w, r, http.StatusForbidden, "Moderator access required", "FORBIDDEN")
+			return
+		}
+		writeServiceError(w, r, err, "Error listing moderation queue")
+		return
+	}
+	
+	entries := make([]api.ModerationQueueEntry, len(page.Entries))
+	for i, entry := range page.Entries {
+		variables := page.Variables[entry.Run.ID]
+		if variables == nil {
+			variables = service.RunVariables{}
+		}
+		entries[i] = api.ModerationQueueEntry{
+			Run:            dbRunToAPIRun(&entry.Run, variables),
+			WaitingSeconds: int64(entry.Waiting.Seconds()),
+			Overdue:        entry.Overdue,
+		}
+	}
+	
+	response := struct {
+		Entries    []api.ModerationQueueEntry `json:"entries"`
+		Total      int64                      `json:"total"`
+		Limit      int32                      `json:"limit"`
+		Offset     int32                      `json:"offset"`
+		NextCursor string                     `json:"next_cursor,omitempty"`
+		SLASeconds int64                      `json:"sla_seconds"`
+	}{
+		Entries:    entries,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+		SLASeconds: int64(page.SLA.Seconds()),
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// VerifyRun handles POST /runs/{id}/verify
+// Marks a pending run as verified; requires a moderator of the run's game
+func (s *Server) VerifyRun(w http.ResponseWriter, r *http.Request, id int) {
+	run, err := s.runService.VerifyRun(r.Context(), int32(id))
+	if err != nil {
+		s.writeReviewError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, dbRunToAPIRun(run, nil))
+}
+
+// RejectRun handles POST /runs/{id}/reject
+// Marks a pending run as rejected with a reason; requires a moderator of the
+// run's game
+func (s *Server) RejectRun(w http.ResponseWriter, r *http.Request, id int) {
+	var req api.RejectRunRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	run, err := s.runService.RejectRun(r.Context(), int32(id), req.Reason)
+	if err != nil {
+		s.writeReviewError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, dbRunToAPIRun(run, nil))
+}
+
+// writeReviewError maps errors from verifying or rejecting a run to responses
+func (s *Server) writeReviewError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, r, http.StatusForbidden, "Moderator access required", "FORBIDDEN")
+	default:
+		writeServiceError(w, r, err, "Error reviewing run")
+	}
+}
+
+// toRunListResponse maps a page of database runs to the API response
+func toRunListResponse(page *service.RunPage) runListResponse {
+	runs := make([]api.Run, len(page.Runs))
+	for i, run := range page.Runs {
+		variables := page.Variables[run.ID]
+		if variables == nil {
+			variables = service.RunVariables{}
+		}
+		runs[i] = dbRunToAPIRun(&run, variables)
+	}
+	return runListResponse{
+		Runs:       runs,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// leaderboardFilter parses the filter query parameters of a leaderboard
+// request; each variable is a variable slug and a value slug separated by a
+// colon
+func leaderboardFilter(variables *[]string, platform, region *string) (service.LeaderboardFilter, error) {
+	filter := service.LeaderboardFilter{}
+	if platform != nil {
+		filter.Platform = *platform
+	}
+	if region != nil {
+		filter.Region = *region
+	}
+	if variables == nil {
+		return filter, nil
+	}
+	
+	filter.Variables = service.RunVariables{}
+	for _, variable := range *variables {
+		slug, value, ok := strings.Cut(variable, ":")
+		if !ok || slug == "" || value == "" {
+			return filter, fmt.Errorf("variable must be a variable slug and a value slug separated by a colon, got %q", variable)
+		}
+		if _, ok := filter.Variables[slug]; ok {
+			return filter, fmt.Errorf("variable %s is given more than once", slug)
+		}
+		filter.Variables[slug] = value
+	}
+	return filter, nil
+}
+
+// dbRunToAPIRun converts a database Run model to an API Run model; variables
+// are left out of the response when nil
+func dbRunToAPIRun(run *db.Run, variables service.RunVariables) api.Run {
+	apiRun := api.Run{
+		Id:         int(run.ID),
+		UserId:     int(run.UserID),
+		CategoryId: int(run.CategoryID),
+		TimeMs:     run.TimeMs,
+		Times:      toAPIRunTimes(run.RtaMs, run.IgtMs, run.LrtMs),
+		VideoUrl:   run.VideoUrl,
+		Platform:   run.Platform,
+		PlayedOn:   openapi_types.Date{Time: run.PlayedOn.Time},
+		CreatedAt:  run.CreatedAt.Time.UTC(),
+		Status:     api.RunStatus(run.Status),
+		Obsolete:   run.Obsolete,
+	}
+	if run.LevelID.Valid {
+		levelID := int(run.LevelID.Int32)
+		apiRun.LevelId = &levelID
+	}
+	if run.Region.Valid {
+		apiRun.Region = &run.Region.String
+	}
+	if run.RejectionReason.Valid {
+		apiRun.RejectionReason = &run.RejectionReason.String
+	}
+	if run.ReviewedAt.Valid {
+		reviewedAt := run.ReviewedAt.Time.UTC()
+		apiRun.ReviewedAt = &reviewedAt
+	}
+	if run.RaceID.Valid {
+		raceID := int(run.RaceID.Int32)
+		apiRun.RaceId = &raceID
+	}
+	if variables != nil {
+		values := map[string]string(variables)
+		apiRun.Variables = &values
+	}
+	return apiRun
+}
+
+// toAPIRunTimes converts a run's nullable times to an API RunTimes model,
+// leaving out the methods the run was not timed by
+func toAPIRunTimes(rta, igt, lrt pgtype.Int8) api.RunTimes {
+	var times api.RunTimes
+	if rta.Valid {
+		times.RtaMs = &rta.Int64
+	}
+	if igt.Valid {
+		times.IgtMs = &igt.Int64
+	}
+	if lrt.Valid {
+		times.LrtMs = &lrt.Int64
+	}
+	return times
+}
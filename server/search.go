@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// Search handles GET /search
+// Finds the users, games, and runs matching a full-text query, best match
+// first
+func (s *Server) Search(w http.ResponseWriter, r *http.Request, params api.SearchParams) {
+	var types []string
+	if params.Type != nil {
+		for _, t := range *params.Type {
+			types = append(types, string(t))
+		}
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	
+	results, err := s.searchService.Search(r.Context(), params.Q, types, limit)
+	if err != nil {
+		writeQueryServiceError(w, r, err, "Error searching")
+		return
+	}
+	
+	response := api.SearchResults{
+		Query:   strings.TrimSpace(params.Q),
+		Results: make([]api.SearchResult, len(results)),
+	}
+	for i, result := range results {
+		response.Results[i] = searchResultToAPI(&result)
+	}
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// searchResultToAPI converts a service SearchResult to the API's model,
+// leaving out the fields its type doesn't have
+func searchResultToAPI(result *service.SearchResult) api.SearchResult {
+	apiResult := api.SearchResult{
+		Type:  api.SearchResultType(result.Type),
+		Id:    int(result.ID),
+		Title: result.Title,
+		Rank:  result.Rank,
+	}
+	if result.GameSlug != "" {
+		apiResult.GameSlug = &result.GameSlug
+	}
+	if result.CategorySlug != "" {
+		apiResult.CategorySlug = &result.CategorySlug
+	}
+	if result.Snippet != "" {
+		apiResult.Snippet = &result.Snippet
+	}
+	return apiResult
+}
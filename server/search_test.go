@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestSearch(t *testing.T) {
+	queries := &stubQueries{
+		searchUsers: func(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error) {
+			t.Error("expected users not to be searched")
+			return nil, nil
+		},
+		searchGames: func(ctx context.Context, arg db.SearchGamesParams) ([]db.SearchGamesRow, error) {
+			return []db.SearchGamesRow{{ID: 3, Slug: "sm64", Name: "Super Mario 64", Rank: 0.2}}, nil
+		},
+		searchRuns: func(ctx context.Context, arg db.SearchRunsParams) ([]db.SearchRunsRow, error) {
+			if arg.Query != "blj" || arg.Limit != 5 {
+				t.Errorf("expected the query and limit, got %+v", arg)
+			}
+			return []db.SearchRunsRow{{
+				RunID: 7, GameSlug: "sm64", GameName: "Super Mario 64", CategorySlug: "120-star", CategoryName: "120 Star",
+				Snippet: "the **BLJ** saves ten seconds", Rank: 0.6,
+			}}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search?q=blj&type=game,run&limit=5", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var response api.SearchResults
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Query != "blj" || len(response.Results) != 2 {
+		t.Fatalf("expected two results for blj, got %+v", response)
+	}
+	run, game := response.Results[0], response.Results[1]
+	if run.Type != api.SearchResultTypeRun || run.Id != 7 || run.Snippet == nil || run.CategorySlug == nil || *run.CategorySlug != "120-star" {
+		t.Errorf("expected the better ranked run first, got %+v", run)
+	}
+	if game.Type != api.SearchResultTypeGame || game.GameSlug == nil || *game.GameSlug != "sm64" || game.Snippet != nil || game.CategorySlug != nil {
+		t.Errorf("expected the game without run fields, got %+v", game)
+	}
+}
+
+func TestSearch_RejectsInvalidQueries(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	for _, target := range []string{"/search", "/search?q=", "/search?q=%20%20", "/search?q=mario&type=comment"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestSearch_ReportsInvalidQueryFields(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search?q=%20%20", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var problem api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if problem.Errors == nil || len(*problem.Errors) != 1 {
+		t.Fatalf("expected one field error, got %+v", problem)
+	}
+	if detail := (*problem.Errors)[0]; detail.In != api.Query || detail.Name == nil || *detail.Name != "q" {
+		t.Errorf("expected q to be reported in the query, got %+v", detail)
+	}
+}
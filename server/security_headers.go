@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/example/speedrun-rest-api/config"
+)
+
+// SecurityHeaders sets the response headers that keep browsers from
+// misusing the API's responses
+type SecurityHeaders struct {
+	hsts    string
+	docsCSP string
+}
+
+// NewSecurityHeaders creates the headers from the HSTS_MAX_AGE and
+// DOCS_CONTENT_SECURITY_POLICY settings; HSTS is only sent when the API is
+// served over HTTPS, as browsers ignore it otherwise
+func NewSecurityHeaders(cfg *config.Config) *SecurityHeaders {
+	headers := &SecurityHeaders{docsCSP: cfg.DocsContentSecurityPolicy}
+	if isHTTPS(cfg.PublicURL) && cfg.HSTSMaxAge > 0 {
+		headers.hsts = "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge.Seconds()))
+	}
+	if headers.docsCSP == "" {
+		headers.docsCSP = defaultDocsCSP
+	}
+	return headers
+}
+
+// Middleware sets the headers on every response, errors included
+// Responses are never to be sniffed as another type, framed, or named in
+// the Referer of requests they lead to; the docs page, the only HTML served,
+// also gets its Content-Security-Policy.
+func (s *SecurityHeaders) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("X-Frame-Options", "DENY")
+		h.Set("Referrer-Policy", "no-referrer")
+		if s.hsts != "" {
+			h.Set("Strict-Transport-Security", s.hsts)
+		}
+		if r.URL.Path == docsPath {
+			h.Set("Content-Security-Policy", s.docsCSP)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
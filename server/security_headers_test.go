@@ -0,0 +1,73 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/no-such-route", nil))
+
+	for header, expected := range map[string]string{
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Referrer-Policy":           "no-referrer",
+		"Strict-Transport-Security": "",
+		"Content-Security-Policy":   "",
+	} {
+		if got := rec.Header().Get(header); got != expected {
+			t.Errorf("expected %s %q on an error over HTTP, got %q", header, expected, got)
+		}
+	}
+}
+
+func TestSecurityHeaders_HSTS(t *testing.T) {
+	cfg := testConfig()
+	cfg.PublicURL = "https://api.example.com"
+	router := SetupRouter(NewServer(db.NewStore(nil), cfg))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if got := rec.Header().Get("Strict-Transport-Security"); got != "max-age=31536000" {
+		t.Errorf("expected a year of HSTS, got %q", got)
+	}
+
+	cfg.HSTSMaxAge = 0
+	rec = httptest.NewRecorder()
+	SetupRouter(NewServer(db.NewStore(nil), cfg)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if got := rec.Header().Get("Strict-Transport-Security"); got != "" {
+		t.Errorf("expected no HSTS with a zero max age, got %q", got)
+	}
+}
+
+func TestSecurityHeaders_DocsCSP(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SetupRouter(NewServer(db.NewStore(nil), testConfig())).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	// The policy allows the page's own inline script, and only it
+	sum := sha256.Sum256([]byte(docsScript))
+	csp := rec.Header().Get("Content-Security-Policy")
+	if !strings.Contains(csp, "'sha256-"+base64.StdEncoding.EncodeToString(sum[:])+"'") || strings.Contains(csp, "script-src 'unsafe-inline'") {
+		t.Errorf("expected the inline script allowed by its hash, got %q", csp)
+	}
+	if !strings.Contains(rec.Body.String(), "<script>"+docsScript+"</script>") {
+		t.Error("expected the hashed script in the page")
+	}
+
+	cfg := testConfig()
+	cfg.DocsContentSecurityPolicy = "default-src 'self'"
+	rec = httptest.NewRecorder()
+	SetupRouter(NewServer(db.NewStore(nil), cfg)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if got := rec.Header().Get("Content-Security-Policy"); got != "default-src 'self'" {
+		t.Errorf("expected the configured policy, got %q", got)
+	}
+}
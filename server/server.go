@@ -1,95 +1,541 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/cache"
+	"github.com/example/speedrun-rest-api/config"
 	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/graph"
+	"github.com/example/speedrun-rest-api/mailer"
+	"github.com/example/speedrun-rest-api/oauth"
+	"github.com/example/speedrun-rest-api/ratelimit"
 	"github.com/example/speedrun-rest-api/service"
+	"github.com/example/speedrun-rest-api/storage"
+	"github.com/example/speedrun-rest-api/stream"
+	"github.com/example/speedrun-rest-api/version"
+	"github.com/example/speedrun-rest-api/video"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	openapi_types "github.com/oapi-codegen/runtime/types"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/protobuf/proto"
 )
 
 // Server implements the ServerInterface from oapi-codegen
 type Server struct {
-	userService *service.UserService
+	userService           *service.UserService
+	gameService           *service.GameService
+	categoryService       *service.CategoryService
+	variableService       *service.VariableService
+	levelService          *service.LevelService
+	moderatorService      *service.ModeratorService
+	platformService       *service.PlatformService
+	regionService         *service.RegionService
+	runService            *service.RunService
+	commentService        *service.CommentService
+	followService         *service.FollowService
+	feedService           *service.FeedService
+	notificationService   *service.NotificationService
+	raceService           *service.RaceService
+	authService           *service.AuthService
+	apiKeyService         *service.APIKeyService
+	auditService          *service.AuditService
+	webhookService        *service.WebhookService
+	searchService         *service.SearchService
+	operationService      *service.OperationService
+	authenticator         *Authenticator
+	oauthProviders        map[api.OAuthProvider]*oauth.Provider
+	secureCookies         bool
+	avatarMaxBytes        int64
+	maxBatchSize          int
+	operationPollInterval time.Duration
+	maintenance           *Maintenance
+	inFlight              *InFlight
+	metrics               *Metrics
+	clientIP              *ClientIP
+	rateLimiter           *RateLimiter
+	cache                 *cache.Cache
+	idempotency           *Idempotency
+	cacheControl          *CacheControl
+	cors                  *CORS
+	securityHeaders       *SecurityHeaders
+	validator             *RequestValidator
+	deprecations          *Deprecations
+	health                *Health
+	mailer                mailer.Mailer
+	stream                *stream.Hub
+	raceRoomOrigins       []string
+	graphql               http.Handler
+	prettyJSON            bool
 }
 
 // NewServer creates a new Server instance
-func NewServer(queries *db.Queries) *Server {
-	return &Server{
-		userService: service.NewUserService(queries),
+func NewServer(queries db.Store, cfg *config.Config) *Server {
+	signer := auth.NewSigner(signingKey(cfg.JWTSecret), cfg.AccessTokenTTL)
+	authService := service.NewAuthService(queries, signer,
+		service.WithRefreshTokenTTL(cfg.RefreshTokenTTL),
+	)
+	apiKeyService := service.NewAPIKeyService(queries)
+	rateLimiter := NewRateLimiter(newRateLimitStore(cfg),
+		ratelimit.PerMinute(cfg.RateLimitPerIP),
+		ratelimit.PerMinute(cfg.RateLimitPerKey),
+	)
+	mail := newMailer(cfg)
+	inFlight := &InFlight{}
+	metrics := NewMetrics(inFlight)
+	readCache := newCache(cfg)
+	if readCache != nil {
+		metrics.Register(readCache)
+	}
+	runService := service.NewRunService(queries,
+		service.WithRunPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		service.WithRunVideoChecker(newVideoChecker(cfg)),
+		service.WithModerationSLA(cfg.ModerationSLA),
+		service.WithRunCache(readCache),
+	)
+	// The spec is embedded at build time, so loading it only fails if the
+	// generated code is broken
+	validator, err := NewRequestValidator()
+	if err != nil {
+		panic(fmt.Sprintf("loading the embedded OpenAPI spec: %v", err))
+	}
+	spec, err := api.GetSwagger()
+	if err != nil {
+		panic(fmt.Sprintf("loading the embedded OpenAPI spec: %v", err))
+	}
+	deprecations, err := NewDeprecations(spec)
+	if err != nil {
+		panic(fmt.Sprintf("reading the deprecations in the embedded OpenAPI spec: %v", err))
+	}
+	metrics.Register(deprecations)
+	
+	s := &Server{
+		userService: service.NewUserService(queries,
+			service.WithMaxBatchSize(cfg.MaxBatchSize),
+			service.WithMaxNameLength(cfg.MaxNameLength),
+			service.WithCorporateDomains(cfg.CorporateDomains),
+			service.WithPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+			service.WithPasswordCost(cfg.PasswordHashCost),
+			service.WithEmailVerification(signer, cfg.EmailVerificationTTL),
+			service.WithMailer(mail),
+			service.WithCache(readCache),
+			service.WithAvatarStore(newAvatarStore(cfg)),
+			service.WithAvatarSize(cfg.AvatarSize),
+		),
+		gameService: service.NewGameService(queries,
+			service.WithGamePageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+			service.WithGameCache(readCache),
+		),
+		categoryService:  service.NewCategoryService(queries),
+		variableService:  service.NewVariableService(queries),
+		levelService:     service.NewLevelService(queries),
+		moderatorService: service.NewModeratorService(queries),
+		platformService:  service.NewPlatformService(queries),
+		regionService:    service.NewRegionService(queries),
+		runService:       runService,
+		commentService: service.NewCommentService(queries,
+			service.WithCommentPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+			service.WithCommentEditWindow(cfg.CommentEditWindow),
+			service.WithCommentRateLimit(cfg.CommentRateLimit),
+		),
+		followService: service.NewFollowService(queries,
+			service.WithFollowPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		),
+		feedService: service.NewFeedService(queries,
+			service.WithFeedPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		),
+		notificationService: service.NewNotificationService(queries,
+			service.WithNotificationPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		),
+		raceService: service.NewRaceService(queries, runService,
+			service.WithRaceCountdown(cfg.RaceCountdown),
+		),
+		authService:   authService,
+		apiKeyService: apiKeyService,
+		auditService: service.NewAuditService(queries,
+			service.WithAuditPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		),
+		webhookService: service.NewWebhookService(queries,
+			service.WithWebhookPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		),
+		searchService: service.NewSearchService(queries,
+			service.WithSearchPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		),
+		operationService:      service.NewOperationService(queries),
+		authenticator:         NewAuthenticator(signer, authService.Principal, apiKeyService.Authenticate, rateLimiter),
+		oauthProviders:        newOAuthProviders(cfg),
+		secureCookies:         isHTTPS(cfg.PublicURL),
+		avatarMaxBytes:        int64(cfg.AvatarMaxBytes),
+		maxBatchSize:          cfg.MaxBatchSize,
+		operationPollInterval: cfg.OperationPollInterval,
+		maintenance:           NewMaintenance(cfg.MaintenanceRetryAfter),
+		inFlight:              inFlight,
+		metrics:               metrics,
+		clientIP:              NewClientIP(cfg.TrustedProxies),
+		rateLimiter:           rateLimiter,
+		cache:                 readCache,
+		idempotency:           NewIdempotency(service.NewIdempotencyService(queries), maxRequestBodyBytes(cfg)),
+		cacheControl:          NewCacheControl(cfg.HTTPCacheMaxAge, cfg.HTTPCacheLeaderboardMaxAge),
+		cors:                  NewCORS(cfg),
+		securityHeaders:       NewSecurityHeaders(cfg),
+		validator:             validator,
+		deprecations:          deprecations,
+		health:                NewHealth(cfg.HealthCheckTimeout),
+		mailer:                mail,
+		stream:                stream.NewHub(),
+		raceRoomOrigins:       cfg.CORSAllowedOrigins,
+		prettyJSON:            cfg.PrettyJSON,
+	}
+	s.graphql = graph.NewHandler(
+		graph.NewResolver(s.userService, s.gameService, s.categoryService, s.runService, cfg.MaxBatchSize),
+		cfg.GraphQLMaxComplexity,
+	)
+	return s
+}
+
+// signingKey returns the configured JWT secret, or a random key when none is
+// set. A random key keeps local development working but invalidates every
+// token on restart and cannot be shared between replicas.
+func signingKey(secret string) []byte {
+	if secret != "" {
+		return []byte(secret)
+	}
+	
+	slog.Warn("JWT_SECRET is not set; using a random signing key, so tokens will not survive a restart")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		slog.Error("Unable to generate signing key", "error", err)
+		os.Exit(1)
+	}
+	return key
+}
+
+// newMailer creates the mail driver selected by the configuration
+func newMailer(cfg *config.Config) mailer.Mailer {
+	switch cfg.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case "sendgrid":
+		return mailer.NewSendGridMailer(cfg.SendGridAPIKey, cfg.MailFrom)
+	default:
+		return mailer.NewLogMailer(slog.Default())
 	}
 }
 
+// newVideoChecker creates a checker for each video provider that has
+// credentials configured, or nil when video checks are disabled
+func newVideoChecker(cfg *config.Config) video.Checker {
+	if !cfg.VideoCheckEnabled {
+		return nil
+	}
+	
+	checkers := video.Checkers{}
+	if cfg.YouTubeAPIKey != "" {
+		checkers[video.YouTube] = video.NewYouTubeChecker(cfg.YouTubeAPIKey)
+	}
+	if cfg.TwitchClientID != "" && cfg.TwitchClientSecret != "" {
+		checkers[video.Twitch] = video.NewTwitchChecker(cfg.TwitchClientID, cfg.TwitchClientSecret)
+	}
+	return checkers
+}
+
+// newAvatarStore creates the bucket avatars are stored in, or nil when none
+// is configured, which disables avatar uploads
+func newAvatarStore(cfg *config.Config) storage.Store {
+	if cfg.S3Bucket == "" {
+		return nil
+	}
+	
+	store, err := storage.NewS3Store(storage.S3Config{
+		Endpoint:        cfg.S3Endpoint,
+		Region:          cfg.S3Region,
+		Bucket:          cfg.S3Bucket,
+		AccessKeyID:     cfg.S3AccessKeyID,
+		SecretAccessKey: cfg.S3SecretAccessKey,
+		PathStyle:       cfg.S3PathStyle,
+		PublicURL:       cfg.AvatarPublicURL,
+	})
+	if err != nil {
+		slog.Error("Unable to configure avatar storage", "error", err)
+		os.Exit(1)
+	}
+	return store
+}
+
+// Maintenance returns the server's maintenance mode flag
+func (s *Server) Maintenance() *Maintenance {
+	return s.maintenance
+}
+
+// Metrics returns the registry behind GET /metrics
+func (s *Server) Metrics() *Metrics {
+	return s.metrics
+}
+
+// RateLimiter returns the server's rate limiter
+func (s *Server) RateLimiter() *RateLimiter {
+	return s.rateLimiter
+}
+
+// Cache returns the cache in front of the database, or nil when caching is
+// disabled
+func (s *Server) Cache() *cache.Cache {
+	return s.cache
+}
+
+// Mailer returns the mail driver the server sends email through, which the
+// notification sink sends through too
+func (s *Server) Mailer() mailer.Mailer {
+	return s.mailer
+}
+
+// Stream returns the hub behind GET /events/stream
+func (s *Server) Stream() *stream.Hub {
+	return s.stream
+}
+
+// Health returns the checks and drain flag behind GET /readyz
+func (s *Server) Health() *Health {
+	return s.health
+}
+
+// InFlight returns the tracker counting requests currently being served
+func (s *Server) InFlight() *InFlight {
+	return s.inFlight
+}
+
 // GetUser handles GET /users/{id}
 // Retrieves a specific user by their ID
-func (s *Server) GetUser(w http.ResponseWriter, r *http.Request, id int) {
+func (s *Server) GetUser(w http.ResponseWriter, r *http.Request, id string, params api.GetUserParams) {
 	ctx := r.Context()
 	
-	user, err := s.userService.GetUserByID(ctx, int32(id))
+	user, err := s.lookupUser(ctx, id)
 	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			writeError(w, r, http.StatusBadRequest, "Invalid user ID", "INVALID_ID")
+			return
+		}
 		if errors.Is(err, service.ErrUserNotFound) {
-			writeError(w, http.StatusNotFound, "User not found", "USER_NOT_FOUND")
+			writeError(w, r, http.StatusNotFound, "User not found", "USER_NOT_FOUND")
 			return
 		}
-		log.Printf("Error getting user: %v", err)
-		writeError(w, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		slog.ErrorContext(r.Context(), "Error getting user", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
 		return
 	}
 	
 	// Map database model to API model
-	apiUser := dbUserToAPIUser(user)
-	writeJSON(w, http.StatusOK, apiUser)
+	apiUser := visibleUser(ctx, user)
+	setUserETag(w, user)
+	if notModified(w, r) {
+		return
+	}
+	s.writeResponse(w, r, http.StatusOK, sparseFields(apiUser, params.Fields))
+}
+
+// GetUserProfile handles GET /users/{id}/profile
+// Retrieves a user's public profile, which leaves out their email address
+func (s *Server) GetUserProfile(w http.ResponseWriter, r *http.Request, id string) {
+	user, err := s.lookupUser(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			writeError(w, r, http.StatusBadRequest, "Invalid user ID", "INVALID_ID")
+			return
+		}
+		if errors.Is(err, service.ErrUserNotFound) {
+			writeError(w, r, http.StatusNotFound, "User not found", "USER_NOT_FOUND")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Error getting user profile", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	counts, err := s.followService.FollowCounts(r.Context(), user.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error getting user profile", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, toAPIUserProfile(user, counts))
+}
+
+// errInvalidUserID is returned by lookupUser when the ID is neither form
+var errInvalidUserID = errors.New("invalid user id")
+
+// lookupUser resolves a path ID that is either a numeric user ID or a
+// public UUID
+func (s *Server) lookupUser(ctx context.Context, id string) (*db.User, error) {
+	if n, err := strconv.ParseInt(id, 10, 32); err == nil {
+		if n < 1 {
+			return nil, errInvalidUserID
+		}
+		return s.userService.GetUserByID(ctx, int32(n))
+	}
+	
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errInvalidUserID
+	}
+	return s.userService.GetUserByPublicID(ctx, publicID)
 }
 
 // ListUsers handles GET /users
-// Retrieves a paginated list of users
+// Retrieves a paginated list of users, or, for admins, streams all of them
+// when the Accept header asks for CSV or JSON Lines; admins preferring to
+// respond-async have the export made in the background instead
 func (s *Server) ListUsers(w http.ResponseWriter, r *http.Request, params api.ListUsersParams) {
 	ctx := r.Context()
 	
-	// Set defaults
-	limit := int32(10)
-	offset := int32(0)
-	
-	if params.Limit != nil {
-		limit = int32(*params.Limit)
+	filter := service.ListUsersFilter{
+		Corporate: params.Corporate,
+	}
+	if params.Name != nil {
+		filter.Name = *params.Name
 	}
-	if params.Offset != nil {
-		offset = int32(*params.Offset)
+	if params.EmailDomain != nil {
+		filter.EmailDomain = *params.EmailDomain
+	}
+	if params.Sort != nil {
+		filter.Sort = *params.Sort
+	}
+	if params.IncludeDeleted != nil {
+		filter.IncludeDeleted = *params.IncludeDeleted
 	}
 	
-	users, total, err := s.userService.ListUsers(ctx, limit, offset)
+	if format := exportFormat(r, responseContentTypes); format != "" {
+		if _, ok := auth.PrincipalFromContext(ctx); !ok {
+			unauthorized(w, r, "Authentication required to export users", "UNAUTHORIZED")
+			return
+		}
+		if prefersAsync(params.Prefer) && loggedIn(ctx) {
+			op, err := s.userService.StartExportUsers(ctx, filter, format)
+			if err != nil {
+				writeExportUsersError(w, r, err)
+				return
+			}
+			s.writeAccepted(w, r, op)
+			return
+		}
+		
+		export := newExportWriter(w, r, format, "users", userExportColumns)
+		err := export.close(s.userService.ExportUsers(ctx, filter, func(user db.User) error {
+			apiUser := visibleUser(ctx, &user)
+			return export.write(apiUser, userExportRecord(&apiUser))
+		}))
+		if err != nil {
+			writeExportUsersError(w, r, err)
+		}
+		return
+	}
+	
+	page, err := s.userService.ListUsers(ctx, pageRequest(params.Limit, params.Offset, params.Cursor), filter)
 	if err != nil {
-		log.Printf("Error listing users: %v", err)
-		writeError(w, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		writeListUsersError(w, r, err)
 		return
 	}
 	
 	// Map database models to API models
-	apiUsers := make([]api.User, len(users))
-	for i, user := range users {
-		apiUsers[i] = dbUserToAPIUser(&user)
+	apiUsers := make([]api.User, len(page.Users))
+	for i, user := range page.Users {
+		apiUsers[i] = visibleUser(ctx, &user)
 	}
 	
-	response := struct {
-		Users  []api.User `json:"users"`
-		Total  int64      `json:"total"`
-		Limit  int32      `json:"limit"`
-		Offset int32      `json:"offset"`
-	}{
-		Users:  apiUsers,
-		Total:  total,
-		Limit:  limit,
-		Offset: offset,
+	response := userListResponse{
+		Users:      apiUsers,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
 	}
 	
-	writeJSON(w, http.StatusOK, response)
+	s.writeResponse(w, r, http.StatusOK, sparseList(response, "users", params.Fields))
+}
+
+// userListResponse is the paginated body returned by GET /users
+type userListResponse struct {
+	XMLName    xml.Name   `json:"-" xml:"UserList"`
+	Users      []api.User `json:"users" xml:"User"`
+	Total      int64      `json:"total" xml:"total"`
+	Limit      int32      `json:"limit" xml:"limit"`
+	Offset     int32      `json:"offset" xml:"offset"`
+	NextCursor string     `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+	Links      *api.Links `json:"_links,omitempty" xml:"-"`
+}
+
+// writeListUsersError reports an error listing users
+func writeListUsersError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrForbidden) {
+		writeError(w, r, http.StatusForbidden, "Admin access required to include deleted users or to sort or filter by email", "FORBIDDEN")
+		return
+	}
+	writeServiceError(w, r, err, "Error listing users")
+}
+
+// writeExportUsersError reports an error exporting users
+func writeExportUsersError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrForbidden) {
+		writeError(w, r, http.StatusForbidden, "Admin access required to export users", "FORBIDDEN")
+		return
+	}
+	writeServiceError(w, r, err, "Error exporting users")
+}
+
+// BatchGetUsers handles GET /users/batch
+// Retrieves several users at once and reports which IDs were not found
+func (s *Server) BatchGetUsers(w http.ResponseWriter, r *http.Request, params api.BatchGetUsersParams) {
+	ctx := r.Context()
+	
+	ids := make([]int32, len(params.Ids))
+	for i, id := range params.Ids {
+		ids[i] = int32(id)
+	}
+	
+	users, missing, err := s.userService.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			writeError(w, r, http.StatusBadRequest, "At least one user ID is required", "INVALID_INPUT")
+			return
+		}
+		if errors.Is(err, service.ErrTooManyIDs) {
+			writeError(w, r, http.StatusBadRequest, "Too many user IDs requested", "TOO_MANY_IDS")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Error batch getting users", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	response := api.BatchGetUsersResponse{
+		Users:      make([]api.User, len(users)),
+		MissingIds: make([]int, len(missing)),
+	}
+	for i, user := range users {
+		response.Users[i] = visibleUser(ctx, &user)
+	}
+	for i, id := range missing {
+		response.MissingIds[i] = int(id)
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
 }
 
 // CreateUser handles POST /users
@@ -98,38 +544,30 @@ func (s *Server) CreateUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	
 	var req api.CreateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "Invalid request body", "INVALID_REQUEST")
+	if !decodeJSONBody(w, r, &req) {
 		return
 	}
 	
 	user, err := s.userService.CreateUser(ctx, req.Name, string(req.Email))
 	if err != nil {
-		if errors.Is(err, service.ErrDuplicateEmail) {
-			writeError(w, http.StatusConflict, "User with this email already exists", "DUPLICATE_EMAIL")
-			return
-		}
-		if errors.Is(err, service.ErrInvalidInput) {
-			writeError(w, http.StatusBadRequest, "Invalid input", "INVALID_INPUT")
-			return
-		}
-		log.Printf("Error creating user: %v", err)
-		writeError(w, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		writeServiceError(w, r, err, "Error creating user")
 		return
 	}
 	
 	apiUser := dbUserToAPIUser(user)
-	writeJSON(w, http.StatusCreated, apiUser)
+	s.writeJSON(w, r, http.StatusCreated, apiUser)
 }
 
 // UpdateUser handles PUT /users/{id}
-// Updates an existing user's information
-func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, id int) {
-	ctx := r.Context()
+// Updates an existing user's information if it still matches If-Match
+func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, id int, params api.UpdateUserParams) {
+	version, ok := requireIfMatch(w, r, params.IfMatch)
+	if !ok {
+		return
+	}
 	
 	var req api.UpdateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "Invalid request body", "INVALID_REQUEST")
+	if !decodeJSONBody(w, r, &req) {
 		return
 	}
 	
@@ -141,24 +579,81 @@ func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, id int) {
 	if req.Email != nil {
 		email = string(*req.Email)
 	}
+	profile := service.ProfileUpdate{
+		TwitchHandle:  req.TwitchHandle,
+		YoutubeHandle: req.YoutubeHandle,
+		TwitterHandle: req.TwitterHandle,
+		CountryCode:   req.CountryCode,
+		Pronouns:      req.Pronouns,
+		Bio:           req.Bio,
+	}
+	
+	s.updateUser(w, r, id, name, email, profile, version)
+}
+
+// PatchUser handles PATCH /users/{id}
+// Applies a JSON merge patch to a user if it still matches If-Match
+func (s *Server) PatchUser(w http.ResponseWriter, r *http.Request, id int, params api.PatchUserParams) {
+	version, ok := requireIfMatch(w, r, params.IfMatch)
+	if !ok {
+		return
+	}
+	
+	var patch userPatch
+	if !decodeJSONBody(w, r, &patch) {
+		return
+	}
+	
+	if err := patch.clearedRequired(); err != nil {
+		writeServiceError(w, r, err, "Error patching user")
+		return
+	}
+	profile := service.ProfileUpdate{
+		TwitchHandle:  patch.TwitchHandle.change(),
+		YoutubeHandle: patch.YoutubeHandle.change(),
+		TwitterHandle: patch.TwitterHandle.change(),
+		CountryCode:   patch.CountryCode.change(),
+		Pronouns:      patch.Pronouns.change(),
+		Bio:           patch.Bio.change(),
+	}
 	
-	user, err := s.userService.UpdateUser(ctx, int32(id), name, email)
+	s.updateUser(w, r, id, patch.Name.orEmpty(), patch.Email.orEmpty(), profile, version)
+}
+
+// requireIfMatch reads the version an update of a user is based on from its
+// If-Match header. Returns false once an error is written.
+func requireIfMatch(w http.ResponseWriter, r *http.Request, ifMatch *string) (int32, bool) {
+	if ifMatch == nil {
+		writeError(w, r, http.StatusPreconditionRequired, "If-Match header is required", "PRECONDITION_REQUIRED")
+		return 0, false
+	}
+	version, ok := ifMatchVersion(*ifMatch)
+	if !ok {
+		writeError(w, r, http.StatusPreconditionFailed, "User has been modified since it was read", "VERSION_MISMATCH")
+		return 0, false
+	}
+	return version, true
+}
+
+// updateUser applies an update or patch of a user and writes the result
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request, id int, name, email string, profile service.ProfileUpdate, version int32) {
+	user, err := s.userService.UpdateUser(r.Context(), int32(id), name, email, profile, version)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			writeError(w, http.StatusNotFound, "User not found", "USER_NOT_FOUND")
+		if errors.Is(err, service.ErrForbidden) {
+			writeError(w, r, http.StatusForbidden, "You may only update your own account", "FORBIDDEN")
 			return
 		}
 		if errors.Is(err, service.ErrDuplicateEmail) {
-			writeError(w, http.StatusConflict, "Email already in use by another user", "DUPLICATE_EMAIL")
+			writeError(w, r, http.StatusConflict, "Email already in use by another user", "DUPLICATE_EMAIL")
 			return
 		}
-		log.Printf("Error updating user: %v", err)
-		writeError(w, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		writeServiceError(w, r, err, "Error updating user")
 		return
 	}
 	
 	apiUser := dbUserToAPIUser(user)
-	writeJSON(w, http.StatusOK, apiUser)
+	setUserETag(w, user)
+	s.writeJSON(w, r, http.StatusOK, apiUser)
 }
 
 // DeleteUser handles DELETE /users/{id}
@@ -168,68 +663,412 @@ func (s *Server) DeleteUser(w http.ResponseWriter, r *http.Request, id int) {
 	
 	err := s.userService.DeleteUser(ctx, int32(id))
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			writeError(w, http.StatusNotFound, "User not found", "USER_NOT_FOUND")
+		if errors.Is(err, service.ErrForbidden) {
+			writeError(w, r, http.StatusForbidden, "You may only delete your own account", "FORBIDDEN")
 			return
 		}
-		log.Printf("Error deleting user: %v", err)
-		writeError(w, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		writeServiceError(w, r, err, "Error deleting user")
 		return
 	}
 	
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// PurgeUser handles DELETE /users/{id}/purge
+// Permanently removes a user that has already been soft-deleted; admin only
+func (s *Server) PurgeUser(w http.ResponseWriter, r *http.Request, id int) {
+	ctx := r.Context()
+	
+	err := s.userService.PurgeUser(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			writeError(w, r, http.StatusForbidden, "Admin access required", "FORBIDDEN")
+			return
+		}
+		if errors.Is(err, service.ErrUserActive) {
+			writeError(w, r, http.StatusConflict, "User must be soft-deleted before it can be purged", "USER_ACTIVE")
+			return
+		}
+		writeServiceError(w, r, err, "Error purging user")
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreUser handles POST /users/{id}/restore
+// Undoes a soft delete; admin only
+func (s *Server) RestoreUser(w http.ResponseWriter, r *http.Request, id int) {
+	user, err := s.userService.RestoreUser(r.Context(), int32(id))
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			writeError(w, r, http.StatusForbidden, "Admin access required", "FORBIDDEN")
+			return
+		}
+		writeServiceError(w, r, err, "Error restoring user")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, dbUserToAPIUser(user))
+}
+
+// GetUserStats handles GET /users/stats
+func (s *Server) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.userService.GetUserStats(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error getting user stats", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, api.UserStats{
+		Total:        stats.Total,
+		Corporate:    stats.Corporate,
+		NonCorporate: stats.NonCorporate,
+		NewUsers: api.NewUserCounts{
+			Last24h: stats.NewLast24h,
+			Last7d:  stats.NewLast7d,
+			Last30d: stats.NewLast30d,
+		},
+	})
+}
+
+// GetVersion handles GET /version
+// Reports build metadata for the running binary without touching the database
+func (s *Server) GetVersion(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, r, http.StatusOK, api.VersionInfo{
+		Version:   version.Version,
+		Commit:    version.Commit,
+		BuildTime: version.BuildTime,
+	})
+}
+
 // SetupRouter creates and configures the HTTP router
+// Each version of the API is served under its prefix, such as /v1, and
+// paths without a prefix are served by unversionedAPI.
 func SetupRouter(server *Server) http.Handler {
+	versioned := make(map[string]http.Handler, len(apiVersions))
+	var unversioned http.Handler
+	for _, v := range apiVersions {
+		routes := v.routes(server)
+		versioned[v.prefix()] = v.serve(v.prefix(), routes)
+		if v.name == unversionedAPI.name {
+			unversioned = v.serve("", routes)
+		}
+	}
+	
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if routes, ok := versioned["/"+segment]; ok {
+			routes.ServeHTTP(w, r)
+			return
+		}
+		unversioned.ServeHTTP(w, r)
+	})
+}
+
+// v1Routes creates the router serving version 1 of the API, which expects
+// the version prefix to have been stripped from request paths
+func (s *Server) v1Routes() http.Handler {
 	r := chi.NewRouter()
 	
 	// Middleware
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	r.Use(otelhttp.NewMiddleware("http.server", otelhttp.WithFilter(func(r *http.Request) bool {
+		return r.URL.Path != "/metrics"
+	})))
+	r.Use(traceRoute)
 	r.Use(middleware.RequestID)
+	r.Use(s.securityHeaders.Middleware)
+	r.Use(s.clientIP.Middleware)
+	r.Use(requestLogger)
+	r.Use(s.inFlight.Middleware)
+	r.Use(s.metrics.Middleware)
+	r.Use(recoverer)
+	r.Use(s.cors.Middleware)
+	r.Use(readYourWrites)
+	r.Use(s.maintenance.Middleware)
+	r.Use(s.authenticator.Middleware)
+	r.Use(s.rateLimiter.Middleware)
+	r.Use(s.idempotency.Middleware)
+	r.Use(s.cacheControl.Middleware(r))
 	
-	// Register handlers using oapi-codegen
-	api.HandlerFromMux(server, r)
+	// Unknown routes and methods get the same JSON error shape as handlers
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed(r))
+	
+	// Register handlers using oapi-codegen; operations marked with bearerAuth
+	// in the spec require an authenticated caller, and requests are checked
+	// against the spec once they have one. Deprecated operations announce it
+	// on every response, errors included.
+	api.HandlerWithOptions(s, api.ChiServerOptions{
+		BaseRouter:       r,
+		Middlewares:      []api.MiddlewareFunc{s.validator.Middleware, s.authenticator.Require, s.deprecations.Middleware},
+		ErrorHandlerFunc: s.validator.ParamError,
+	})
 	
 	return r
 }
 
+// notFound handles requests for routes that are not registered
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusNotFound, "Resource not found", "NOT_FOUND")
+}
+
+// routeMethods lists the methods probed when building the Allow header
+var routeMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// methodNotAllowed handles requests whose path exists but not for the
+// requested method. The Allow header lists the methods the route supports.
+func methodNotAllowed(routes chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		for _, method := range routeMethods {
+			if routes.Match(chi.NewRouteContext(), method, r.URL.Path) {
+				allowed = append(allowed, method)
+			}
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeError(w, r, http.StatusMethodNotAllowed, "Method not allowed", "METHOD_NOT_ALLOWED")
+	}
+}
+
 // Helper functions
 
 // dbUserToAPIUser converts a database User model to an API User model
+// Timestamps are normalized to UTC so responses always carry a "Z" offset
 func dbUserToAPIUser(user *db.User) api.User {
-	return api.User{
+	email := openapi_types.Email(user.Email)
+	apiUser := api.User{
 		Id:        int(user.ID),
+		PublicId:  openapi_types.UUID(user.PublicID.Bytes),
 		Name:      user.Name,
-		Email:     openapi_types.Email(user.Email),
-		CreatedAt: user.CreatedAt.Time,
-		UpdatedAt: user.UpdatedAt.Time,
+		Email:     &email,
+		CreatedAt: user.CreatedAt.Time.UTC(),
+		UpdatedAt: user.UpdatedAt.Time.UTC(),
+	}
+	if user.DeletedAt.Valid {
+		deletedAt := user.DeletedAt.Time.UTC()
+		apiUser.DeletedAt = &deletedAt
+	}
+	if user.EmailVerifiedAt.Valid {
+		verifiedAt := user.EmailVerifiedAt.Time.UTC()
+		apiUser.EmailVerifiedAt = &verifiedAt
 	}
+	apiUser.TwitchHandle = optionalText(user.TwitchHandle)
+	apiUser.YoutubeHandle = optionalText(user.YoutubeHandle)
+	apiUser.TwitterHandle = optionalText(user.TwitterHandle)
+	apiUser.CountryCode = optionalText(user.CountryCode)
+	apiUser.Pronouns = optionalText(user.Pronouns)
+	apiUser.Bio = optionalText(user.Bio)
+	apiUser.AvatarUrl = optionalText(user.AvatarUrl)
+	return apiUser
 }
 
-// writeJSON writes a JSON response
-func writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
+// visibleUser converts a database User model to an API User model as the
+// caller of ctx may see it, leaving out the email address unless
+// service.CanSeeEmail allows it
+// Handlers answering with a user the caller just created, or proved they
+// own, use dbUserToAPIUser instead.
+func visibleUser(ctx context.Context, user *db.User) api.User {
+	apiUser := dbUserToAPIUser(user)
+	if !service.CanSeeEmail(ctx, user.ID) {
+		apiUser.Email = nil
+	}
+	return apiUser
+}
+
+// toAPIUserProfile converts a database User and their follow counts to the
+// public API UserProfile model, leaving out the email address
+func toAPIUserProfile(user *db.User, counts *db.GetUserFollowCountsRow) api.UserProfile {
+	return api.UserProfile{
+		Id:                int(user.ID),
+		PublicId:          openapi_types.UUID(user.PublicID.Bytes),
+		Name:              user.Name,
+		CreatedAt:         user.CreatedAt.Time.UTC(),
+		TwitchHandle:      optionalText(user.TwitchHandle),
+		YoutubeHandle:     optionalText(user.YoutubeHandle),
+		TwitterHandle:     optionalText(user.TwitterHandle),
+		CountryCode:       optionalText(user.CountryCode),
+		Pronouns:          optionalText(user.Pronouns),
+		Bio:               optionalText(user.Bio),
+		AvatarUrl:         optionalText(user.AvatarUrl),
+		FollowerCount:     int(counts.FollowerCount),
+		FollowingCount:    int(counts.FollowingCount),
+		FollowedGameCount: int(counts.FollowedGameCount),
+	}
+}
+
+// optionalText converts a nullable column to an optional API field
+func optionalText(text pgtype.Text) *string {
+	if !text.Valid {
+		return nil
+	}
+	return &text.String
+}
+
+// writeResponse writes data in the format negotiated from the Accept header
+// JSON is the default; XML, MessagePack, or Protobuf is sent when the client
+// prefers it. Clients that accept none of them get 406 Not Acceptable.
+func (s *Server) writeResponse(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	w.Header().Add("Vary", "Accept")
+	
+	switch negotiateContentType(r.Header.Get("Accept"), responseContentTypes) {
+	case contentTypeJSON, contentTypeMsgPack, contentTypeProtobuf:
+		s.writeJSON(w, r, status, data)
+	case contentTypeXML:
+		s.writeXML(w, r, status, data)
+	default:
+		writeError(w, r, http.StatusNotAcceptable, "Supported response types are "+strings.Join(responseContentTypes, ", "), "NOT_ACCEPTABLE")
+	}
+}
+
+// writeJSON writes a JSON response, with the links of the resources in it
+// Reads are sent as MessagePack or Protobuf instead when the Accept header
+// prefers one. JSON is indented when pretty-printing is enabled for the
+// server or the request asks for it with ?pretty=true; otherwise it stays
+// compact.
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	data = addLinks(r, data)
+	contentType, message := readContentType(w, r, data)
+	if contentType == "" {
+		writeNotAcceptable(w, r)
+		return
+	}
+	if contentType != contentTypeJSON {
+		writeBinary(w, r, status, data, message)
+		return
+	}
+	
+	w.Header().Set("Content-Type", contentTypeJSON)
 	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		log.Printf("Error encoding JSON: %v", err)
+	enc := json.NewEncoder(w)
+	if s.pretty(r) {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(data); err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding JSON", "error", err)
 	}
 }
 
-// writeError writes an error response
-func writeError(w http.ResponseWriter, status int, message, code string) {
-	w.Header().Set("Content-Type", "application/json")
+// readContentType returns the format writeJSON sends data in, in response to
+// r, and the message sending it as Protobuf takes: JSON, unless r is a read
+// whose Accept header prefers a binary encoding of it
+// Only data with a message type can be sent as Protobuf; other data is sent
+// in the next format the client accepts, or "" when it accepts none, to be
+// answered with 406 Not Acceptable. Reads vary by Accept; JSON is sent to
+// clients that accept none of the formats, as it always has been.
+func readContentType(w http.ResponseWriter, r *http.Request, data any) (string, proto.Message) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return contentTypeJSON, nil
+	}
+	if !slices.Contains(w.Header().Values("Vary"), "Accept") {
+		w.Header().Add("Vary", "Accept")
+	}
+	
+	accept := r.Header.Get("Accept")
+	contentType := negotiateContentType(accept, jsonContentTypes)
+	if contentType == contentTypeProtobuf {
+		if message, ok := protoMessage(data); ok {
+			return contentType, message
+		}
+		if contentType = negotiateContentType(accept, untypedContentTypes); contentType == "" {
+			return "", nil
+		}
+	}
+	if contentType == "" {
+		return contentTypeJSON, nil
+	}
+	return contentType, nil
+}
+
+// writeNotAcceptable answers a read asking only for Protobuf of a response
+// with no message type
+func writeNotAcceptable(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusNotAcceptable, "Supported response types are "+strings.Join(untypedContentTypes, ", "), "NOT_ACCEPTABLE")
+}
+
+// writeBinary writes data as MessagePack, or as message in Protobuf when it
+// isn't nil
+func writeBinary(w http.ResponseWriter, r *http.Request, status int, data any, message proto.Message) {
+	encoded, contentType, err := encodeBinary(data, message)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding response", "content_type", contentType, "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write(encoded)
+}
+
+// writeTaggedJSON writes a 200 JSON response with an ETag hashed from its
+// body, or 304 Not Modified when the request's If-None-Match has that ETag;
+// the body is linked the same way as by writeJSON
+func (s *Server) writeTaggedJSON(w http.ResponseWriter, r *http.Request, data interface{}) {
+	data = addLinks(r, data)
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	if s.pretty(r) {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(data); err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding JSON", "error", err)
+		writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+		return
+	}
+	
+	// Each format is tagged by its own bytes
+	contentType, message := readContentType(w, r, data)
+	if contentType == "" {
+		writeNotAcceptable(w, r)
+		return
+	}
+	encoded := body.Bytes()
+	if contentType != contentTypeJSON {
+		var err error
+		if encoded, contentType, err = encodeBinary(data, message); err != nil {
+			slog.ErrorContext(r.Context(), "Error encoding response", "content_type", contentType, "error", err)
+			writeError(w, r, http.StatusInternalServerError, "Internal server error", "INTERNAL_ERROR")
+			return
+		}
+	}
+	
+	w.Header().Set("ETag", bodyETag(encoded))
+	if notModified(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
+
+// writeXML writes an XML response, indented under the same rules as writeJSON
+func (s *Server) writeXML(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	w.Header().Set("Content-Type", contentTypeXML)
 	w.WriteHeader(status)
-	err := api.Error{
-		Message: message,
-		Code:    &code,
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	if s.pretty(r) {
+		enc.Indent("", "  ")
 	}
-	if err := json.NewEncoder(w).Encode(err); err != nil {
-		log.Printf("Error encoding error response: %v", err)
+	if err := enc.Encode(data); err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding XML", "error", err)
 	}
 }
 
+// pretty reports whether a response should be indented
+func (s *Server) pretty(r *http.Request) bool {
+	return s.prettyJSON || r.URL.Query().Get("pretty") == "true"
+}
+
 // Helper to parse int from path parameter
 func parseIntParam(r *http.Request, key string) (int, error) {
 	param := chi.URLParam(r, key)
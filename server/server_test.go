@@ -0,0 +1,1165 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/config"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// stubQueries is a db.Store for handler tests; any method a test does not
+// stub panics through the nil embedded interface, except ListUserRoles and
+// ListGameModeratorsByUser, which grant no roles unless stubbed,
+// ObsoleteBeatenRuns, which finds no runs to mark, and CreateAuditEvent,
+// CreateOutboxEvent, and CreateCategoryRecord, which discard what they are
+// given unless stubbed
+type stubQueries struct {
+	db.Querier
+	getUserByID            func(ctx context.Context, id int32) (db.User, error)
+	getUserByEmail         func(ctx context.Context, email string) (db.User, error)
+	updateUser             func(ctx context.Context, arg db.UpdateUserParams) (db.User, error)
+	verifyUserEmail        func(ctx context.Context, arg db.VerifyUserEmailParams) (db.User, error)
+	getRunByID             func(ctx context.Context, id int32) (db.Run, error)
+	getRunSummary          func(ctx context.Context, id int32) (db.GetRunSummaryRow, error)
+	updateRunStatus        func(ctx context.Context, arg db.UpdateRunStatusParams) (db.Run, error)
+	getCredentialsByEmail  func(ctx context.Context, email string) (db.GetCredentialsByEmailRow, error)
+	createUserWithPassword func(ctx context.Context, arg db.CreateUserWithPasswordParams) (db.User, error)
+	createRefreshToken     func(ctx context.Context, arg db.CreateRefreshTokenParams) (db.RefreshToken, error)
+	listUserRoles          func(ctx context.Context, userID int32) ([]db.UserRole, error)
+	listModeratedGames     func(ctx context.Context, userID int32) ([]db.GameModerator, error)
+	getCategoryByID        func(ctx context.Context, id int32) (db.Category, error)
+	getAPIKeyByHash        func(ctx context.Context, keyHash string) (db.ApiKey, error)
+	listAPIKeysByUser      func(ctx context.Context, userID int32) ([]db.ApiKey, error)
+	createAPIKey           func(ctx context.Context, arg db.CreateAPIKeyParams) (db.ApiKey, error)
+	createAuditEvent       func(ctx context.Context, arg db.CreateAuditEventParams) error
+	listAuditEvents        func(ctx context.Context, arg db.ListAuditEventsParams) ([]db.AuditEvent, error)
+	countAuditEvents       func(ctx context.Context, arg db.CountAuditEventsParams) (int64, error)
+	getFastestVerifiedRun  func(ctx context.Context, arg db.GetFastestVerifiedRunParams) (db.Run, error)
+	createOutboxEvent      func(ctx context.Context, arg db.CreateOutboxEventParams) error
+	createCategoryRecord   func(ctx context.Context, arg db.CreateCategoryRecordParams) error
+	createWebhook          func(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error)
+	getWebhookByID         func(ctx context.Context, id int32) (db.Webhook, error)
+	listWebhookDeliveries  func(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)
+	countWebhookDeliveries func(ctx context.Context, webhookID int32) (int64, error)
+	getUserFollowCounts    func(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error)
+	listFeedItems          func(ctx context.Context, arg db.ListFeedItemsParams) ([]db.ListFeedItemsRow, error)
+	countFeedItems         func(ctx context.Context, userID int32) (int64, error)
+	listNotifications      func(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error)
+	countNotifications     func(ctx context.Context, arg db.CountNotificationsParams) (int64, error)
+	markNotificationRead   func(ctx context.Context, arg db.MarkNotificationReadParams) (int64, error)
+	getRaceByID            func(ctx context.Context, id int32) (db.Race, error)
+	listRaceParticipants   func(ctx context.Context, raceID int32) ([]db.ListRaceParticipantsRow, error)
+	listGames              func(ctx context.Context, arg db.ListGamesParams) ([]db.Game, error)
+	countGames             func(ctx context.Context) (int64, error)
+	getGamesByIDs          func(ctx context.Context, ids []int32) ([]db.Game, error)
+	listCategoriesByGames  func(ctx context.Context, gameIds []int32) ([]db.Category, error)
+	getUsersByIDs          func(ctx context.Context, ids []int32) ([]db.User, error)
+	listUsers              func(ctx context.Context, arg db.ListUsersParams) ([]db.ListUsersRow, error)
+	getCategoriesByIDs     func(ctx context.Context, ids []int32) ([]db.Category, error)
+
+	getGameBySlug     func(ctx context.Context, slug string) (db.Game, error)
+	updateGame        func(ctx context.Context, arg db.UpdateGameParams) (db.Game, error)
+	suggestGames      func(ctx context.Context, arg db.SuggestGamesParams) ([]db.Game, error)
+	getCategoryBySlug func(ctx context.Context, arg db.GetCategoryBySlugParams) (db.Category, error)
+
+	getUsersByEmails func(ctx context.Context, emails []string) ([]db.User, error)
+	importUsers      func(ctx context.Context, arg []db.ImportUsersParams) (int64, error)
+
+	exportUsers          func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error
+	exportRunsByCategory func(ctx context.Context, arg db.ExportRunsByCategoryParams, fn func(db.Run) error) error
+
+	createOperation    func(ctx context.Context, arg db.CreateOperationParams) (db.Operation, error)
+	getOperationByID   func(ctx context.Context, id pgtype.UUID) (db.Operation, error)
+	getOperationOutput func(ctx context.Context, operationID pgtype.UUID) (db.OperationOutput, error)
+
+	searchUsers func(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error)
+	searchGames func(ctx context.Context, arg db.SearchGamesParams) ([]db.SearchGamesRow, error)
+	searchRuns  func(ctx context.Context, arg db.SearchRunsParams) ([]db.SearchRunsRow, error)
+
+	// notificationPreferences backs ListNotificationPreferences and
+	// UpsertNotificationPreference
+	notificationPreferences []db.NotificationPreference
+}
+
+// WithTx runs fn against the stub itself; handler tests don't observe rollbacks
+func (q *stubQueries) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	return fn(q)
+}
+
+func (q *stubQueries) GetUserByID(ctx context.Context, id int32) (db.User, error) {
+	return q.getUserByID(ctx, id)
+}
+
+// GetUserByIDIncludingDeleted shares the getUserByID stub, which decides
+// whether the user it returns is deleted
+func (q *stubQueries) GetUserByIDIncludingDeleted(ctx context.Context, id int32) (db.User, error) {
+	return q.getUserByID(ctx, id)
+}
+
+func (q *stubQueries) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	return q.getUserByEmail(ctx, email)
+}
+
+func (q *stubQueries) GetUsersByEmails(ctx context.Context, emails []string) ([]db.User, error) {
+	return q.getUsersByEmails(ctx, emails)
+}
+
+func (q *stubQueries) ImportUsers(ctx context.Context, arg []db.ImportUsersParams) (int64, error) {
+	return q.importUsers(ctx, arg)
+}
+
+func (q *stubQueries) ExportUsers(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+	return q.exportUsers(ctx, arg, fn)
+}
+
+func (q *stubQueries) SuggestGames(ctx context.Context, arg db.SuggestGamesParams) ([]db.Game, error) {
+	return q.suggestGames(ctx, arg)
+}
+
+func (q *stubQueries) GetCategoryBySlug(ctx context.Context, arg db.GetCategoryBySlugParams) (db.Category, error) {
+	return q.getCategoryBySlug(ctx, arg)
+}
+
+func (q *stubQueries) ExportRunsByCategory(ctx context.Context, arg db.ExportRunsByCategoryParams, fn func(db.Run) error) error {
+	return q.exportRunsByCategory(ctx, arg, fn)
+}
+
+func (q *stubQueries) CreateOperation(ctx context.Context, arg db.CreateOperationParams) (db.Operation, error) {
+	return q.createOperation(ctx, arg)
+}
+
+func (q *stubQueries) GetOperationByID(ctx context.Context, id pgtype.UUID) (db.Operation, error) {
+	return q.getOperationByID(ctx, id)
+}
+
+func (q *stubQueries) GetOperationOutput(ctx context.Context, operationID pgtype.UUID) (db.OperationOutput, error) {
+	return q.getOperationOutput(ctx, operationID)
+}
+
+func (q *stubQueries) SearchUsers(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error) {
+	return q.searchUsers(ctx, arg)
+}
+
+func (q *stubQueries) SearchGames(ctx context.Context, arg db.SearchGamesParams) ([]db.SearchGamesRow, error) {
+	return q.searchGames(ctx, arg)
+}
+
+func (q *stubQueries) SearchRuns(ctx context.Context, arg db.SearchRunsParams) ([]db.SearchRunsRow, error) {
+	return q.searchRuns(ctx, arg)
+}
+
+func (q *stubQueries) UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+	return q.updateUser(ctx, arg)
+}
+
+func (q *stubQueries) VerifyUserEmail(ctx context.Context, arg db.VerifyUserEmailParams) (db.User, error) {
+	return q.verifyUserEmail(ctx, arg)
+}
+
+func (q *stubQueries) GetRunByID(ctx context.Context, id int32) (db.Run, error) {
+	return q.getRunByID(ctx, id)
+}
+
+func (q *stubQueries) GetRunSummary(ctx context.Context, id int32) (db.GetRunSummaryRow, error) {
+	if q.getRunSummary == nil {
+		return db.GetRunSummaryRow{}, sql.ErrNoRows
+	}
+	return q.getRunSummary(ctx, id)
+}
+
+func (q *stubQueries) GetFastestVerifiedRun(ctx context.Context, arg db.GetFastestVerifiedRunParams) (db.Run, error) {
+	if q.getFastestVerifiedRun == nil {
+		return db.Run{}, sql.ErrNoRows
+	}
+	return q.getFastestVerifiedRun(ctx, arg)
+}
+
+func (q *stubQueries) CreateCategoryRecord(ctx context.Context, arg db.CreateCategoryRecordParams) error {
+	if q.createCategoryRecord == nil {
+		return nil
+	}
+	return q.createCategoryRecord(ctx, arg)
+}
+
+func (q *stubQueries) ObsoleteBeatenRuns(ctx context.Context, runID int32) ([]db.Run, error) {
+	return nil, nil
+}
+
+func (q *stubQueries) UpdateRunStatus(ctx context.Context, arg db.UpdateRunStatusParams) (db.Run, error) {
+	return q.updateRunStatus(ctx, arg)
+}
+
+func (q *stubQueries) GetCredentialsByEmail(ctx context.Context, email string) (db.GetCredentialsByEmailRow, error) {
+	return q.getCredentialsByEmail(ctx, email)
+}
+
+func (q *stubQueries) CreateUserWithPassword(ctx context.Context, arg db.CreateUserWithPasswordParams) (db.User, error) {
+	return q.createUserWithPassword(ctx, arg)
+}
+
+func (q *stubQueries) CreateRefreshToken(ctx context.Context, arg db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+	return q.createRefreshToken(ctx, arg)
+}
+
+func (q *stubQueries) ListUserRoles(ctx context.Context, userID int32) ([]db.UserRole, error) {
+	if q.listUserRoles == nil {
+		return nil, nil
+	}
+	return q.listUserRoles(ctx, userID)
+}
+
+func (q *stubQueries) ListGameModeratorsByUser(ctx context.Context, userID int32) ([]db.GameModerator, error) {
+	if q.listModeratedGames == nil {
+		return nil, nil
+	}
+	return q.listModeratedGames(ctx, userID)
+}
+
+func (q *stubQueries) GetCategoryByID(ctx context.Context, id int32) (db.Category, error) {
+	return q.getCategoryByID(ctx, id)
+}
+
+func (q *stubQueries) GetAPIKeyByHash(ctx context.Context, keyHash string) (db.ApiKey, error) {
+	return q.getAPIKeyByHash(ctx, keyHash)
+}
+
+func (q *stubQueries) TouchAPIKey(ctx context.Context, id int32) error {
+	return nil
+}
+
+func (q *stubQueries) ListAPIKeysByUser(ctx context.Context, userID int32) ([]db.ApiKey, error) {
+	return q.listAPIKeysByUser(ctx, userID)
+}
+
+func (q *stubQueries) CreateAPIKey(ctx context.Context, arg db.CreateAPIKeyParams) (db.ApiKey, error) {
+	return q.createAPIKey(ctx, arg)
+}
+
+func (q *stubQueries) CreateAuditEvent(ctx context.Context, arg db.CreateAuditEventParams) error {
+	if q.createAuditEvent == nil {
+		return nil
+	}
+	return q.createAuditEvent(ctx, arg)
+}
+
+func (q *stubQueries) ListAuditEvents(ctx context.Context, arg db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	return q.listAuditEvents(ctx, arg)
+}
+
+func (q *stubQueries) CountAuditEvents(ctx context.Context, arg db.CountAuditEventsParams) (int64, error) {
+	return q.countAuditEvents(ctx, arg)
+}
+
+func (q *stubQueries) CreateOutboxEvent(ctx context.Context, arg db.CreateOutboxEventParams) error {
+	if q.createOutboxEvent == nil {
+		return nil
+	}
+	return q.createOutboxEvent(ctx, arg)
+}
+
+func (q *stubQueries) CreateWebhook(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+	return q.createWebhook(ctx, arg)
+}
+
+func (q *stubQueries) GetWebhookByID(ctx context.Context, id int32) (db.Webhook, error) {
+	return q.getWebhookByID(ctx, id)
+}
+
+func (q *stubQueries) ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	return q.listWebhookDeliveries(ctx, arg)
+}
+
+func (q *stubQueries) CountWebhookDeliveries(ctx context.Context, webhookID int32) (int64, error) {
+	return q.countWebhookDeliveries(ctx, webhookID)
+}
+
+func (q *stubQueries) GetUserFollowCounts(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error) {
+	return q.getUserFollowCounts(ctx, userID)
+}
+
+func (q *stubQueries) ListFeedItems(ctx context.Context, arg db.ListFeedItemsParams) ([]db.ListFeedItemsRow, error) {
+	return q.listFeedItems(ctx, arg)
+}
+
+func (q *stubQueries) CountFeedItems(ctx context.Context, userID int32) (int64, error) {
+	return q.countFeedItems(ctx, userID)
+}
+
+func (q *stubQueries) ListNotifications(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error) {
+	return q.listNotifications(ctx, arg)
+}
+
+func (q *stubQueries) CountNotifications(ctx context.Context, arg db.CountNotificationsParams) (int64, error) {
+	return q.countNotifications(ctx, arg)
+}
+
+func (q *stubQueries) MarkNotificationRead(ctx context.Context, arg db.MarkNotificationReadParams) (int64, error) {
+	return q.markNotificationRead(ctx, arg)
+}
+
+func (q *stubQueries) GetRaceByID(ctx context.Context, id int32) (db.Race, error) {
+	return q.getRaceByID(ctx, id)
+}
+
+func (q *stubQueries) ListRaceParticipants(ctx context.Context, raceID int32) ([]db.ListRaceParticipantsRow, error) {
+	return q.listRaceParticipants(ctx, raceID)
+}
+
+func (q *stubQueries) ListGames(ctx context.Context, arg db.ListGamesParams) ([]db.Game, error) {
+	return q.listGames(ctx, arg)
+}
+
+func (q *stubQueries) CountGames(ctx context.Context) (int64, error) {
+	return q.countGames(ctx)
+}
+
+func (q *stubQueries) GetGamesByIDs(ctx context.Context, ids []int32) ([]db.Game, error) {
+	return q.getGamesByIDs(ctx, ids)
+}
+
+func (q *stubQueries) ListCategoriesByGames(ctx context.Context, gameIds []int32) ([]db.Category, error) {
+	return q.listCategoriesByGames(ctx, gameIds)
+}
+
+func (q *stubQueries) GetUsersByIDs(ctx context.Context, ids []int32) ([]db.User, error) {
+	return q.getUsersByIDs(ctx, ids)
+}
+
+func (q *stubQueries) ListUsers(ctx context.Context, arg db.ListUsersParams) ([]db.ListUsersRow, error) {
+	return q.listUsers(ctx, arg)
+}
+
+func (q *stubQueries) GetCategoriesByIDs(ctx context.Context, ids []int32) ([]db.Category, error) {
+	return q.getCategoriesByIDs(ctx, ids)
+}
+
+func (q *stubQueries) GetGameBySlug(ctx context.Context, slug string) (db.Game, error) {
+	return q.getGameBySlug(ctx, slug)
+}
+
+func (q *stubQueries) UpdateGame(ctx context.Context, arg db.UpdateGameParams) (db.Game, error) {
+	return q.updateGame(ctx, arg)
+}
+
+func (q *stubQueries) ListNotificationPreferences(ctx context.Context, userID int32) ([]db.NotificationPreference, error) {
+	return q.notificationPreferences, nil
+}
+
+func (q *stubQueries) UpsertNotificationPreference(ctx context.Context, arg db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+	preference := db.NotificationPreference{
+		UserID:   arg.UserID,
+		Event:    arg.Event,
+		Email:    arg.Email,
+		InApp:    arg.InApp,
+		Webhooks: arg.Webhooks,
+	}
+	q.notificationPreferences = append(q.notificationPreferences, preference)
+	return preference, nil
+}
+
+// rolesFor returns a ListUserRoles stub backed by a fixed set of grants per user
+func rolesFor(roles map[int32][]db.UserRole) func(ctx context.Context, userID int32) ([]db.UserRole, error) {
+	return func(ctx context.Context, userID int32) ([]db.UserRole, error) {
+		return roles[userID], nil
+	}
+}
+
+func TestUpdateUser_DuplicateEmailRaceReturnsConflict(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Old Name", Email: "old@example.com", Version: 1}, nil
+		},
+		getUserByEmail: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{}, sql.ErrNoRows
+		},
+		updateUser: func(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+			return db.User{}, &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"email": "taken@example.com"}`))
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	req.Header.Set("If-Match", `"1"`)
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rec.Code)
+	}
+	var resp api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if resp.Code != "DUPLICATE_EMAIL" {
+		t.Errorf("expected code DUPLICATE_EMAIL, got %v", resp.Code)
+	}
+}
+
+func TestUpdateUser_IfMatch(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Old Name", Email: "old@example.com", Version: 3}, nil
+		},
+		updateUser: func(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+			return db.User{ID: arg.ID, Name: arg.Name, Email: arg.Email, Version: arg.Version + 1}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name       string
+		ifMatch    string
+		wantStatus int
+		wantETag   string
+	}{
+		{"missing", "", http.StatusPreconditionRequired, ""},
+		{"stale", `"2"`, http.StatusPreconditionFailed, ""},
+		{"weak", `W/"3"`, http.StatusPreconditionFailed, ""},
+		{"current", `"3"`, http.StatusOK, `"4"`},
+		{"any", "*", http.StatusOK, `"4"`},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"name": "New Name"}`))
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		if tt.ifMatch != "" {
+			req.Header.Set("If-Match", tt.ifMatch)
+		}
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.wantStatus, rec.Code)
+		}
+		if got := rec.Header().Get("ETag"); got != tt.wantETag {
+			t.Errorf("%s: expected ETag %q, got %q", tt.name, tt.wantETag, got)
+		}
+	}
+}
+
+func TestGetUser_SetsETag(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "John Doe", Email: "john@example.com", Version: 7}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("ETag"); got != `"7"` {
+		t.Errorf("expected ETag \"7\", got %q", got)
+	}
+}
+
+func TestGetUserProfile_HidesEmail(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{
+				ID:          id,
+				Name:        "John Doe",
+				Email:       "john@example.com",
+				CountryCode: pgtype.Text{String: "SE", Valid: true},
+			}, nil
+		},
+		getUserFollowCounts: func(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error) {
+			return db.GetUserFollowCountsRow{FollowerCount: 12, FollowingCount: 3}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1/profile", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "john@example.com") {
+		t.Errorf("expected the profile to leave out the email, got %s", rec.Body.String())
+	}
+	var profile api.UserProfile
+	if err := json.Unmarshal(rec.Body.Bytes(), &profile); err != nil {
+		t.Fatalf("failed to decode profile: %v", err)
+	}
+	if profile.Name != "John Doe" || profile.CountryCode == nil || *profile.CountryCode != "SE" || profile.Bio != nil || profile.FollowerCount != 12 {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+}
+
+func TestGetFeed_ReturnsEventData(t *testing.T) {
+	queries := &stubQueries{
+		listFeedItems: func(ctx context.Context, arg db.ListFeedItemsParams) ([]db.ListFeedItemsRow, error) {
+			if arg.UserID != 1 {
+				t.Errorf("expected the caller's feed, got %+v", arg)
+			}
+			return []db.ListFeedItemsRow{{
+				ID:        5,
+				Event:     "run.verified",
+				Payload:   []byte(`{"id":7,"time_ms":5400000}`),
+				GameID:    4,
+				GameSlug:  "super-mario-64",
+				GameName:  "Super Mario 64",
+				ActorID:   pgtype.Int4{Int32: 2, Valid: true},
+				ActorName: pgtype.Text{String: "Jane Doe", Valid: true},
+			}}, nil
+		},
+		countFeedItems: func(ctx context.Context, userID int32) (int64, error) {
+			return 1, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/me/feed", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var feed struct {
+		Items []api.FeedItem `json:"items"`
+		Total int64          `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	if feed.Total != 1 || len(feed.Items) != 1 {
+		t.Fatalf("expected one item, got %+v", feed)
+	}
+	item := feed.Items[0]
+	if item.GameSlug != "super-mario-64" || item.ActorName == nil || *item.ActorName != "Jane Doe" || string(item.Data) != `{"id":7,"time_ms":5400000}` {
+		t.Errorf("unexpected item %+v", item)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/me/feed", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous: expected 401, got %d", rec.Code)
+	}
+}
+
+func TestNotifications_ListAndMarkRead(t *testing.T) {
+	readAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	queries := &stubQueries{
+		listNotifications: func(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error) {
+			if arg.UserID != 1 || !arg.UnreadOnly {
+				t.Errorf("expected the caller's unread notifications, got %+v", arg)
+			}
+			return []db.Notification{
+				{ID: 9, Event: "comment.created", Payload: []byte(`{"id":12}`)},
+				{ID: 8, Event: "run.verified", Payload: []byte(`{"id":7}`), ReadAt: pgtype.Timestamptz{Time: readAt, Valid: true}},
+			}, nil
+		},
+		countNotifications: func(ctx context.Context, arg db.CountNotificationsParams) (int64, error) {
+			return 2, nil
+		},
+		markNotificationRead: func(ctx context.Context, arg db.MarkNotificationReadParams) (int64, error) {
+			if arg.ID == 9 && arg.UserID == 1 {
+				return 1, nil
+			}
+			return 0, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/me/notifications?unread=true", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var list struct {
+		Notifications []api.Notification `json:"notifications"`
+		UnreadCount   int64              `json:"unread_count"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to decode notifications: %v", err)
+	}
+	if list.UnreadCount != 2 || len(list.Notifications) != 2 || list.Notifications[0].Read || !list.Notifications[1].Read {
+		t.Errorf("unexpected notifications %+v", list)
+	}
+
+	for id, want := range map[string]int{"9": http.StatusNoContent, "10": http.StatusNotFound} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/users/me/notifications/"+id+"/read", nil)
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		router.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("marking %s read: expected %d, got %d: %s", id, want, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestNotificationSettings_Update(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/users/me/settings/notifications", strings.NewReader(body))
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send(`{"preferences":[{"event":"comment.created","email":false,"in_app":true,"webhooks":true}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var settings api.NotificationSettings
+	if err := json.Unmarshal(rec.Body.Bytes(), &settings); err != nil {
+		t.Fatalf("failed to decode settings: %v", err)
+	}
+	if len(settings.Preferences) != 4 {
+		t.Fatalf("expected every event's channels, got %+v", settings.Preferences)
+	}
+	for _, p := range settings.Preferences {
+		if p.Email != (p.Event != api.NotificationPreferenceEventCommentCreated) || !p.InApp || !p.Webhooks {
+			t.Errorf("expected only comment emails turned off, got %+v", p)
+		}
+	}
+
+	if rec := send(`{"preferences":[{"event":"user.created","email":false,"in_app":true,"webhooks":true}]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown event: expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUploadAvatar_Errors(t *testing.T) {
+	cfg := testConfig()
+	cfg.AvatarMaxBytes = 1024
+	router := SetupRouter(NewServer(&stubQueries{}, cfg))
+
+	form := func(field string, size int) (string, string) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, _ := mw.CreateFormFile(field, "avatar.png")
+		part.Write(bytes.Repeat([]byte{0}, size))
+		mw.Close()
+		return body.String(), mw.FormDataContentType()
+	}
+	avatar, contentType := form("avatar", 10)
+	other, otherType := form("picture", 10)
+	large, largeType := form("avatar", 2048)
+
+	tests := []struct {
+		name         string
+		body         string
+		contentType  string
+		expectedCode int
+		expectedErr  string
+	}{
+		{"not multipart", `{"avatar":""}`, "application/json", http.StatusBadRequest, "INVALID_REQUEST"},
+		{"missing field", other, otherType, http.StatusBadRequest, "INVALID_INPUT"},
+		{"too large", large, largeType, http.StatusRequestEntityTooLarge, "AVATAR_TOO_LARGE"},
+		{"no storage", avatar, contentType, http.StatusServiceUnavailable, "AVATARS_DISABLED"},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/users/me/avatar", strings.NewReader(tt.body))
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		req.Header.Set("Content-Type", tt.contentType)
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tt.expectedCode {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.expectedCode, rec.Code, rec.Body.String())
+			continue
+		}
+		var resp api.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s: expected JSON body, got error %v", tt.name, err)
+		}
+		if resp.Code != tt.expectedErr {
+			t.Errorf("%s: expected code %s, got %v", tt.name, tt.expectedErr, resp.Code)
+		}
+	}
+}
+
+func TestDecodeJSONBody_Errors(t *testing.T) {
+	queries := &stubQueries{
+		listUserRoles: rolesFor(map[int32][]db.UserRole{1: {{UserID: 1, Role: "admin"}}}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		expectedCode string
+	}{
+		{"empty create", http.MethodPost, "/users", "", "EMPTY_BODY"},
+		{"whitespace create", http.MethodPost, "/users", "  \n\t ", "EMPTY_BODY"},
+		{"malformed create", http.MethodPost, "/users", `{"name":`, "INVALID_REQUEST"},
+		{"empty update", http.MethodPut, "/users/1", "", "EMPTY_BODY"},
+		{"whitespace update", http.MethodPut, "/users/1", "\n", "EMPTY_BODY"},
+		{"malformed update", http.MethodPut, "/users/1", "not json", "INVALID_REQUEST"},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		req.Header.Set("If-Match", "*")
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", tt.name, rec.Code)
+		}
+		var resp api.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s: expected JSON body, got error %v", tt.name, err)
+		}
+		if resp.Code != tt.expectedCode {
+			t.Errorf("%s: expected code %s, got %v", tt.name, tt.expectedCode, resp.Code)
+		}
+	}
+}
+
+func TestDBUserToAPIUser_ConvertsTimestampsToUTC(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	created := time.Date(2024, 1, 15, 12, 30, 0, 0, loc)
+	updated := time.Date(2024, 1, 16, 8, 0, 0, 0, loc)
+
+	user := dbUserToAPIUser(&db.User{
+		ID:        1,
+		Name:      "John Doe",
+		Email:     "john@example.com",
+		CreatedAt: pgtype.Timestamptz{Time: created, Valid: true},
+		UpdatedAt: pgtype.Timestamptz{Time: updated, Valid: true},
+	})
+
+	if user.CreatedAt.Location() != time.UTC {
+		t.Errorf("expected CreatedAt in UTC, got %v", user.CreatedAt.Location())
+	}
+	if !user.CreatedAt.Equal(created) {
+		t.Errorf("expected CreatedAt to represent the same instant, got %v", user.CreatedAt)
+	}
+	if user.UpdatedAt.Location() != time.UTC {
+		t.Errorf("expected UpdatedAt in UTC, got %v", user.UpdatedAt.Location())
+	}
+}
+
+func TestDBUserToAPIUser_EmitsRFC3339WithZ(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	ts := pgtype.Timestamptz{Time: time.Date(2024, 1, 15, 5, 30, 0, 0, loc), Valid: true}
+
+	body, err := json.Marshal(dbUserToAPIUser(&db.User{ID: 1, Email: "john@example.com", CreatedAt: ts, UpdatedAt: ts}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(string(body), `"created_at":"2024-01-15T10:30:00Z"`) {
+		t.Errorf("expected created_at in RFC3339 UTC, got %s", body)
+	}
+	if !strings.Contains(string(body), `"updated_at":"2024-01-15T10:30:00Z"`) {
+		t.Errorf("expected updated_at in RFC3339 UTC, got %s", body)
+	}
+}
+
+func TestSetupRouter_NotFound(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), config.Default()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/does-not-exist", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("expected problem content type, got %q", ct)
+	}
+	var body api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if body.Code != "NOT_FOUND" {
+		t.Errorf("expected code NOT_FOUND, got %v", body.Code)
+	}
+}
+
+func TestSetupRouter_MethodNotAllowed(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), config.Default()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/1", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET, PUT, PATCH, DELETE" {
+		t.Errorf("expected Allow header %q, got %q", "GET, PUT, PATCH, DELETE", allow)
+	}
+	var body api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if body.Code != "METHOD_NOT_ALLOWED" {
+		t.Errorf("expected code METHOD_NOT_ALLOWED, got %v", body.Code)
+	}
+}
+
+func TestGetUser_InvalidID(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), config.Default()))
+
+	for _, id := range []string{"abc", "0", "-1", "99999999999", "0b8f6c3e-2a41"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/"+id, nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", id, rec.Code)
+		}
+	}
+}
+
+func TestListEndpoints_RejectBadPageRequests(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), config.Default()))
+
+	tests := []struct {
+		target string
+		code   string
+	}{
+		{"/users?cursor=not-a-cursor", "INVALID_CURSOR"},
+		{"/users?sort=password_hash", "INVALID_INPUT"},
+		{"/games?cursor=WyJ1c2VycyIsMV0", "INVALID_CURSOR"},
+		{"/games?cursor=WyJnYW1lcyIsMV0&offset=5", "INVALID_INPUT"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		var body api.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", tt.target, err)
+		}
+		if rec.Code != http.StatusBadRequest || body.Code != tt.code {
+			t.Errorf("%s: expected 400 %s, got %d %v", tt.target, tt.code, rec.Code, body.Code)
+		}
+	}
+}
+
+func TestDBUserToAPIUser_IncludesPublicID(t *testing.T) {
+	publicID := uuid.MustParse("0b8f6c3e-2a41-4d7b-9c55-3f1e8a2d6b90")
+	user := db.User{ID: 1, Email: "john@example.com", PublicID: pgtype.UUID{Bytes: publicID, Valid: true}}
+
+	if got := dbUserToAPIUser(&user).PublicId; got != publicID {
+		t.Errorf("expected public ID %s, got %s", publicID, got)
+	}
+}
+
+func TestGetUser_ShowsEmailOnlyToSelfAndAdmins(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "John Doe", Email: fmt.Sprintf("user%d@example.com", id)}, nil
+		},
+		getUsersByIDs: func(ctx context.Context, ids []int32) ([]db.User, error) {
+			users := make([]db.User, len(ids))
+			for i, id := range ids {
+				users[i] = db.User{ID: id, Name: "John Doe", Email: fmt.Sprintf("user%d@example.com", id)}
+			}
+			return users, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			9: {{UserID: 9, Role: "admin"}},
+		}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name   string
+		caller int32
+		shown  []int
+	}{
+		{"anonymous", 0, nil},
+		{"other user", 2, []int{2}},
+		{"self", 1, []int{1}},
+		{"admin", 9, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		get := func(target string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			if tt.caller != 0 {
+				req.Header.Set("Authorization", bearerToken(t, tt.caller))
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("%s: expected 200 for %s, got %d: %s", tt.name, target, rec.Code, rec.Body.String())
+			}
+			return rec
+		}
+
+		var user api.User
+		if err := json.NewDecoder(get("/users/1").Body).Decode(&user); err != nil {
+			t.Fatalf("%s: failed to decode user: %v", tt.name, err)
+		}
+		if shown := user.Email != nil; shown != slices.Contains(tt.shown, 1) {
+			t.Errorf("%s: expected emails only for users %v, but user 1's was shown: %t", tt.name, tt.shown, shown)
+		}
+
+		var batch api.BatchGetUsersResponse
+		if err := json.NewDecoder(get("/users/batch?ids=1,2").Body).Decode(&batch); err != nil {
+			t.Fatalf("%s: failed to decode users: %v", tt.name, err)
+		}
+		for _, user := range batch.Users {
+			if shown := user.Email != nil; shown != slices.Contains(tt.shown, user.Id) {
+				t.Errorf("%s: expected emails only for users %v, but user %d's was shown: %t", tt.name, tt.shown, user.Id, shown)
+			}
+		}
+	}
+}
+
+func TestListUsers_SortsAndFiltersByEmailOnlyForAdmins(t *testing.T) {
+	queries := &stubQueries{
+		listUsers: func(ctx context.Context, arg db.ListUsersParams) ([]db.ListUsersRow, error) {
+			// Two rows for a limit of one, so the page has a next_cursor
+			return []db.ListUsersRow{
+				{User: db.User{ID: 1, Name: "Ada", Email: "ada@example.com"}, Total: 2},
+				{User: db.User{ID: 2, Name: "Grace", Email: "grace@example.com"}, Total: 2},
+			}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{9: {{UserID: 9, Role: "admin"}}}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	for _, target := range []string{"/users?sort=email&limit=1", "/users?sort=email:desc&limit=1", "/users?email_domain=example.com&limit=1"} {
+		for _, caller := range []int32{0, 2} {
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			if caller != 0 {
+				req.Header.Set("Authorization", bearerToken(t, caller))
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("%s as %d: expected 403, got %d: %s", target, caller, rec.Code, rec.Body.String())
+			}
+			if body := rec.Body.String(); strings.Contains(body, "example.com") {
+				t.Errorf("%s as %d: expected no email addresses, got %s", target, caller, body)
+			}
+		}
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", bearerToken(t, 9))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s as an admin: expected 200, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Sorted any other way, the cursor carries no email address either
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users?sort=name&limit=1", nil))
+	var page struct {
+		NextCursor string `json:"next_cursor"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil || page.NextCursor == "" {
+		t.Fatalf("expected a next_cursor, got %v", err)
+	}
+	if cursor, _ := base64.RawURLEncoding.DecodeString(page.NextCursor); strings.Contains(string(cursor), "example.com") || strings.Contains(rec.Body.String(), "example.com") {
+		t.Errorf("expected no email addresses, got cursor %q", cursor)
+	}
+}
+
+func TestWriteResponse_NegotiatesFormat(t *testing.T) {
+	s := NewServer(db.NewStore(nil), config.Default())
+	email := openapi_types.Email("john@example.com")
+	user := api.User{Id: 1, Name: "John Doe", Email: &email}
+
+	tests := []struct {
+		accept       string
+		expectedCode int
+		expectedType string
+	}{
+		{"", http.StatusOK, "application/json"},
+		{"*/*", http.StatusOK, "application/json"},
+		{"application/xml", http.StatusOK, "application/xml"},
+		{"application/json;q=0.5, application/xml", http.StatusOK, "application/xml"},
+		{"text/csv", http.StatusNotAcceptable, "application/problem+json"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		s.writeResponse(rec, r, http.StatusOK, user)
+
+		if rec.Code != tt.expectedCode {
+			t.Errorf("Accept %q: expected %d, got %d", tt.accept, tt.expectedCode, rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != tt.expectedType {
+			t.Errorf("Accept %q: expected content type %q, got %q", tt.accept, tt.expectedType, ct)
+		}
+	}
+}
+
+func TestWriteResponse_XMLBody(t *testing.T) {
+	s := NewServer(db.NewStore(nil), config.Default())
+	email := openapi_types.Email("john@example.com")
+	user := api.User{Id: 1, Name: "John Doe", Email: &email}
+
+	r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	r.Header.Set("Accept", "application/xml")
+	rec := httptest.NewRecorder()
+	s.writeResponse(rec, r, http.StatusOK, user)
+
+	var decoded api.User
+	if err := xml.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid XML, got error %v", err)
+	}
+	if decoded.Id != 1 || decoded.Name != "John Doe" || decoded.Email == nil || *decoded.Email != email {
+		t.Errorf("expected user to round-trip through XML, got %+v", decoded)
+	}
+	if !strings.Contains(rec.Body.String(), "<name>John Doe</name>") {
+		t.Errorf("expected snake_case XML elements, got %s", rec.Body.String())
+	}
+}
+
+func TestWriteJSON_Pretty(t *testing.T) {
+	data := map[string]int{"total": 1}
+
+	tests := []struct {
+		name   string
+		pretty bool
+		target string
+		want   string
+	}{
+		{"compact by default", false, "/users", "{\"total\":1}\n"},
+		{"query param", false, "/users?pretty=true", "{\n  \"total\": 1\n}\n"},
+		{"server flag", true, "/users", "{\n  \"total\": 1\n}\n"},
+	}
+
+	for _, tt := range tests {
+		s := &Server{prettyJSON: tt.pretty}
+		rec := httptest.NewRecorder()
+		s.writeJSON(rec, httptest.NewRequest(http.MethodGet, tt.target, nil), http.StatusOK, data)
+
+		if got := rec.Body.String(); got != tt.want {
+			t.Errorf("%s: expected body %q, got %q", tt.name, tt.want, got)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: expected Content-Type application/json, got %q", tt.name, ct)
+		}
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), config.Default()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var info api.VersionInfo
+	if err := json.NewDecoder(rec.Body).Decode(&info); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if info.Version != "dev" || info.Commit != "unknown" || info.BuildTime != "unknown" {
+		t.Errorf("expected default build metadata, got %+v", info)
+	}
+}
+
+func TestReviewRun_RequiresModerator(t *testing.T) {
+	queries := &stubQueries{
+		getRunByID: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: "pending"}, nil
+		},
+		getCategoryByID: func(ctx context.Context, id int32) (db.Category, error) {
+			return db.Category{ID: id, GameID: 1}, nil
+		},
+		updateRunStatus: func(ctx context.Context, arg db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{ID: arg.ID, Status: arg.Status}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			3: {{UserID: 3, Role: "moderator", GameID: pgtype.Int4{Int32: 2, Valid: true}}},
+			5: {{UserID: 5, Role: "moderator", GameID: pgtype.Int4{Int32: 1, Valid: true}}},
+			6: {{UserID: 6, Role: "admin"}},
+		}),
+		listModeratedGames: func(ctx context.Context, userID int32) ([]db.GameModerator, error) {
+			if userID == 8 {
+				return []db.GameModerator{{GameID: 1, UserID: 8, Level: "verifier"}}, nil
+			}
+			return nil, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	tests := []struct {
+		name   string
+		userID int32
+		want   int
+	}{
+		{name: "anonymous", want: http.StatusUnauthorized},
+		{name: "not a moderator", userID: 2, want: http.StatusForbidden},
+		{name: "moderator of another game", userID: 3, want: http.StatusForbidden},
+		{name: "moderator", userID: 5, want: http.StatusOK},
+		{name: "verifier of the game", userID: 8, want: http.StatusOK},
+		{name: "admin", userID: 6, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/runs/7/verify", nil)
+			if tt.userID != 0 {
+				req.Header.Set("Authorization", bearerToken(t, tt.userID))
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminScope_RequiresAdminRole(t *testing.T) {
+	queries := &stubQueries{
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			5: {{UserID: 5, Role: "moderator"}},
+		}),
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	for _, userID := range []int32{2, 5} {
+		req := httptest.NewRequest(http.MethodDelete, "/users/1/purge", nil)
+		req.Header.Set("Authorization", bearerToken(t, userID))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("user %d: expected 403, got %d: %s", userID, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/stream"
+)
+
+// streamKeepAlive is how often a comment is sent on an idle event stream, so
+// proxies don't close it for being idle
+const streamKeepAlive = 15 * time.Second
+
+// StreamEvents handles GET /events/stream
+// Streams leaderboard changes and run reviews as Server-Sent Events
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request, params api.StreamEventsParams) {
+	var filter stream.Filter
+	if params.Event != nil {
+		for _, event := range *params.Event {
+			switch event {
+			case api.RunVerified, api.RunRejected, api.RecordBroken:
+				filter.Events = append(filter.Events, string(event))
+			default:
+				writeError(w, r, http.StatusBadRequest, fmt.Sprintf("%s is not a streamed event", event), "INVALID_INPUT")
+				return
+			}
+		}
+	}
+	// Race changes share the stream's channel, so they are left out
+	// explicitly when no events were asked for
+	if len(filter.Events) == 0 {
+		filter.Events = []string{string(api.RunVerified), string(api.RunRejected), string(api.RecordBroken)}
+	}
+	if params.Game != nil {
+		for _, slug := range *params.Game {
+			game, err := s.gameService.GetGameBySlug(r.Context(), slug)
+			if err != nil {
+				writeServiceError(w, r, err, "Error getting game")
+				return
+			}
+			filter.GameIDs = append(filter.GameIDs, game.ID)
+		}
+	}
+	
+	messages, unsubscribe := s.stream.Subscribe(filter)
+	defer unsubscribe()
+	
+	// The stream stays open for longer than the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(r.Context(), "Unable to lift the write timeout; the event stream will be cut off by it", "error", err)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case m, ok := <-messages:
+			if !ok {
+				return
+			}
+			_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", m.ID, m.Event, m.Envelope)
+		case <-keepAlive.C:
+			_, err = io.WriteString(w, ": keepalive\n\n")
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
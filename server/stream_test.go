@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/stream"
+)
+
+// gamesBySlug returns a GetGameBySlug stub that finds only the given games
+func gamesBySlug(games ...db.Game) func(ctx context.Context, slug string) (db.Game, error) {
+	return func(ctx context.Context, slug string) (db.Game, error) {
+		for _, game := range games {
+			if game.Slug == slug {
+				return game, nil
+			}
+		}
+		return db.Game{}, sql.ErrNoRows
+	}
+}
+
+func TestStreamEvents_SendsMatchingEvents(t *testing.T) {
+	srv := NewServer(&stubQueries{getGameBySlug: gamesBySlug(db.Game{ID: 2, Slug: "celeste"})}, testConfig())
+	ts := httptest.NewServer(SetupRouter(srv))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/events/stream?game=celeste&event=run.verified&event=record.broken")
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+
+	hub := srv.Stream()
+	hub.Publish(stream.Message{ID: 1, Event: "run.verified", GameID: 3, Envelope: []byte(`{"id":1}`)})
+	hub.Publish(stream.Message{ID: 2, Event: "run.rejected", GameID: 2, Envelope: []byte(`{"id":2}`)})
+	hub.Publish(stream.Message{ID: 3, Event: "run.verified", GameID: 2, Envelope: []byte(`{"id":3}`)})
+	hub.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read stream: %v", err)
+	}
+	want := "id: 3\nevent: run.verified\ndata: {\"id\":3}\n\n"
+	if string(body) != want {
+		t.Errorf("expected only the verified Celeste run, got %q", body)
+	}
+}
+
+func TestStreamEvents_InvalidFilters(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{getGameBySlug: gamesBySlug()}, testConfig()))
+
+	for query, want := range map[string]int{
+		"game=missing":       http.StatusNotFound,
+		"event=user.created": http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events/stream?"+query, nil))
+		if rec.Code != want {
+			t.Errorf("%s: expected %d, got %d: %s", query, want, rec.Code, rec.Body.String())
+		}
+		if strings.HasPrefix(rec.Header().Get("Content-Type"), "text/event-stream") {
+			t.Errorf("%s: expected an error rather than a stream", query)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceRoute names each request's span after the route that served it,
+// such as "GET /games/{slug}", so traces group by endpoint rather than by
+// path. It must run inside the otelhttp middleware.
+func traceRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+		
+		// Without a route, semantic conventions name the span by method alone
+		span := trace.SpanFromContext(r.Context())
+		route := routePattern(r)
+		if route == unmatchedRoute {
+			span.SetName(r.Method)
+			return
+		}
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route))
+	})
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceRoute_NamesSpanAfterRoute(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+	for _, path := range []string{"/version", "/no/such/path", "/metrics"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected a span per request except /metrics, got %d", len(spans))
+	}
+	if got := spans[0].Name(); got != "GET /version" {
+		t.Errorf("expected span named after the route, got %q", got)
+	}
+	var route string
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == "http.route" {
+			route = attr.Value.AsString()
+		}
+	}
+	if route != "/version" {
+		t.Errorf("expected http.route /version, got %q", route)
+	}
+	if got := spans[1].Name(); got != "GET" {
+		t.Errorf("expected an unmatched request to be named by method, got %q", got)
+	}
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// maxImportBodyBytes caps the files read by ImportUsers, which are far
+// larger than other request bodies
+const maxImportBodyBytes = 10 << 20
+
+// importFileError describes why an import file could not be parsed
+type importFileError struct {
+	detail api.ErrorDetail
+}
+
+func (e *importFileError) Error() string {
+	return e.detail.Message
+}
+
+// importLineError reports a problem on line of an import file, naming the
+// column or field it is in when there is one
+func importLineError(line int, name, format string, args ...any) error {
+	detail := api.ErrorDetail{In: api.Body, Message: fmt.Sprintf(format+" on line %d", append(args, line)...)}
+	if name != "" {
+		detail.Name = &name
+	}
+	return &importFileError{detail: detail}
+}
+
+// ImportUsers handles POST /admin/users/import
+// Parses a CSV or JSON Lines file of users and reports what became of each
+// row, or starts importing them in the background when the caller prefers
+// to respond-async; a file that can't be parsed is rejected before anything
+// is imported
+func (s *Server) ImportUsers(w http.ResponseWriter, r *http.Request, params api.ImportUsersParams) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	body := http.MaxBytesReader(w, r.Body, maxImportBodyBytes)
+	
+	var rows []service.ImportRow
+	var err error
+	switch mediaType {
+	case contentTypeCSV:
+		rows, err = parseImportCSV(body)
+	case contentTypeJSONL, contentTypeNDJSON:
+		rows, err = parseImportJSONL(body)
+	default:
+		writeError(w, r, http.StatusBadRequest, "Request body must be CSV or JSON Lines", "INVALID_REQUEST")
+		return
+	}
+	
+	var tooLarge *http.MaxBytesError
+	var fileErr *importFileError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeBodyTooLarge(w, r, tooLarge)
+		return
+	case errors.As(err, &fileErr):
+		details := []api.ErrorDetail{fileErr.detail}
+		problem := newProblem(r, http.StatusBadRequest, "Invalid import file", "INVALID_REQUEST")
+		problem.Errors = &details
+		writeProblem(w, problem)
+		return
+	case err != nil:
+		writeError(w, r, http.StatusBadRequest, "Unable to read request body", "INVALID_REQUEST")
+		return
+	case len(rows) == 0:
+		writeError(w, r, http.StatusBadRequest, "Import file has no rows", "EMPTY_BODY")
+		return
+	}
+	
+	if prefersAsync(params.Prefer) && loggedIn(r.Context()) {
+		op, err := s.userService.StartImportUsers(r.Context(), rows)
+		if err != nil {
+			writeImportError(w, r, err)
+			return
+		}
+		s.writeAccepted(w, r, op)
+		return
+	}
+	
+	report, err := s.userService.ImportUsers(r.Context(), rows)
+	if err != nil {
+		writeImportError(w, r, err)
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, importReportToAPI(report))
+}
+
+// writeImportError reports an error importing users
+func writeImportError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrForbidden) {
+		writeError(w, r, http.StatusForbidden, "Admin access required", "FORBIDDEN")
+		return
+	}
+	writeServiceError(w, r, err, "Error importing users")
+}
+
+// parseImportCSV reads users from a CSV file whose header row names its name
+// and email columns, in either order
+func parseImportCSV(body io.Reader) ([]service.ImportRow, error) {
+	reader := csv.NewReader(body)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+	
+	columns := map[string]int{}
+	for i, column := range header {
+		if i == 0 {
+			// Spreadsheets often start their exports with a byte order mark
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column != "name" && column != "email" {
+			return nil, importLineError(1, column, "is not a known column")
+		}
+		if _, ok := columns[column]; ok {
+			return nil, importLineError(1, column, "is repeated")
+		}
+		columns[column] = i
+	}
+	for _, column := range []string{"name", "email"} {
+		if _, ok := columns[column]; !ok {
+			return nil, importLineError(1, column, "column is missing")
+		}
+	}
+	
+	var rows []service.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, csvError(err)
+		}
+		rows = append(rows, service.ImportRow{Name: record[columns["name"]], Email: record[columns["email"]]})
+	}
+}
+
+// csvError describes a CSV parse error by the line it is on
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		if errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			return importLineError(parseErr.Line, "", "has a different number of columns than the header")
+		}
+		return importLineError(parseErr.Line, "", "is not valid CSV: %v", parseErr.Err)
+	}
+	return err
+}
+
+// parseImportJSONL reads users from a JSON Lines file, decoding each line as
+// strictly as a JSON request body; blank lines are skipped
+func parseImportJSONL(body io.Reader) ([]service.ImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	var rows []service.ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		
+		var row struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err := dec.Decode(&row)
+		if err == nil && !errors.Is(dec.Decode(&json.RawMessage{}), io.EOF) {
+			err = errTrailingData
+		}
+		if err != nil {
+			detail := decodeErrorDetail(err)
+			name := ""
+			if detail.Name != nil {
+				name = *detail.Name
+			}
+			return nil, importLineError(line, name, "%s", detail.Message)
+		}
+		rows = append(rows, service.ImportRow{Name: row.Name, Email: row.Email})
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return nil, &importFileError{detail: api.ErrorDetail{In: api.Body, Message: "has a line longer than 64 KiB"}}
+	}
+	return rows, scanner.Err()
+}
+
+// importReportToAPI converts a service ImportReport to the API's report
+func importReportToAPI(report *service.ImportReport) api.UserImportReport {
+	rows := make([]api.UserImportRow, len(report.Rows))
+	for i, result := range report.Rows {
+		row := api.UserImportRow{Row: result.Row, Email: result.Email, Status: api.Imported}
+		if result.UserID != 0 {
+			userID := int(result.UserID)
+			row.UserId = &userID
+		}
+		if len(result.Errors) > 0 {
+			row.Status = api.Failed
+			row.Errors = fieldDetails(result.Errors, api.Body)
+		}
+		rows[i] = row
+	}
+	return api.UserImportReport{Imported: report.Imported, Failed: report.Failed, Rows: rows}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// importStub is an admin's view of an in-memory users table holding
+// taken@example.com
+func importStub(imported *[]db.ImportUsersParams) *stubQueries {
+	users := []db.User{{ID: 1, Email: "admin@example.com"}, {ID: 2, Email: "taken@example.com"}}
+	return &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			1: {{UserID: 1, Role: "admin"}},
+		}),
+		getUsersByEmails: func(ctx context.Context, emails []string) ([]db.User, error) {
+			var found []db.User
+			for _, user := range users {
+				if slices.Contains(emails, user.Email) {
+					found = append(found, user)
+				}
+			}
+			return found, nil
+		},
+		importUsers: func(ctx context.Context, arg []db.ImportUsersParams) (int64, error) {
+			*imported = append(*imported, arg...)
+			for _, row := range arg {
+				users = append(users, db.User{ID: int32(len(users) + 1), Name: row.Name, Email: row.Email})
+			}
+			return int64(len(arg)), nil
+		},
+	}
+}
+
+func TestImportUsers_Formats(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"csv", "text/csv", "\ufeffEmail,Name\ngrace@example.com,Grace\ntaken@example.com,Taken\n"},
+		{"jsonl", "application/x-ndjson", "{\"name\": \"Grace\", \"email\": \"grace@example.com\"}\n\n{\"name\": \"Taken\", \"email\": \"taken@example.com\"}\n"},
+	}
+
+	for _, tt := range tests {
+		var imported []db.ImportUsersParams
+		router := SetupRouter(NewServer(importStub(&imported), testConfig()))
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/import", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", tt.contentType)
+		req.Header.Set("Authorization", bearerToken(t, 1))
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", tt.name, rec.Code, rec.Body.String())
+		}
+		var report api.UserImportReport
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatalf("%s: failed to decode report: %v", tt.name, err)
+		}
+		if report.Imported != 1 || report.Failed != 1 || len(report.Rows) != 2 {
+			t.Fatalf("%s: expected one row imported and one failed, got %+v", tt.name, report)
+		}
+		if row := report.Rows[0]; row.Status != api.Imported || row.UserId == nil || *row.UserId != 3 {
+			t.Errorf("%s: expected row 1 imported as user 3, got %+v", tt.name, row)
+		}
+		if row := report.Rows[1]; row.Status != api.Failed || row.Errors == nil || *(*row.Errors)[0].Name != "email" {
+			t.Errorf("%s: expected row 2 to fail on its email, got %+v", tt.name, row)
+		}
+		if len(imported) != 1 || imported[0].Name != "Grace" {
+			t.Errorf("%s: expected only Grace to be copied, got %+v", tt.name, imported)
+		}
+	}
+}
+
+func TestImportUsers_RejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		token          int32
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"unknown column", "text/csv", "name,email,bio\nAda,ada@example.com,hi\n", 1, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"missing column", "text/csv", "name\nAda\n", 1, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"short row", "text/csv", "name,email\nAda\n", 1, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"header only", "text/csv", "name,email\n", 1, http.StatusBadRequest, "EMPTY_BODY"},
+		{"unknown field", "application/jsonl", `{"name": "Ada", "emial": "ada@example.com"}`, 1, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"two values on a line", "application/jsonl", `{"name": "Ada"} {"name": "Grace"}`, 1, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"json", "application/json", `[{"name": "Ada", "email": "ada@example.com"}]`, 1, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"too large", "text/csv", "name,email\n" + strings.Repeat("a", maxImportBodyBytes), 1, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE"},
+		{"not admin", "text/csv", "name,email\nAda,ada@example.com\n", 2, http.StatusForbidden, "FORBIDDEN"},
+	}
+
+	for _, tt := range tests {
+		var imported []db.ImportUsersParams
+		router := SetupRouter(NewServer(importStub(&imported), testConfig()))
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/import", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", tt.contentType)
+		req.Header.Set("Authorization", bearerToken(t, tt.token))
+		router.ServeHTTP(rec, req)
+
+		var problem api.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+			t.Fatalf("%s: failed to decode problem: %v", tt.name, err)
+		}
+		if rec.Code != tt.expectedStatus || problem.Code != tt.expectedCode {
+			t.Errorf("%s: expected %d %s, got %d %+v", tt.name, tt.expectedStatus, tt.expectedCode, rec.Code, problem)
+		}
+		if len(imported) != 0 {
+			t.Errorf("%s: expected nothing to be imported, got %+v", tt.name, imported)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// RequestValidator rejects requests that don't match the OpenAPI spec, such
+// as malformed bodies, unknown enum values, and out-of-range parameters,
+// before they reach a handler
+type RequestValidator struct {
+	router routers.Router
+}
+
+// NewRequestValidator creates a RequestValidator for the spec embedded in
+// the api package
+//
+// Returns:
+//   - *RequestValidator: The validator
+//   - error: The embedded spec could not be loaded
+func NewRequestValidator() (*RequestValidator, error) {
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	// Match routes by path alone, whichever host the server is reached at
+	spec.Servers = nil
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestValidator{router: router}, nil
+}
+
+// Middleware validates each request against the operation it is routed to
+// It runs inside each operation's handler, after the generated wrapper has
+// parsed the parameters and the Authenticator has required a caller where
+// the spec asks for one. Bodies are validated as JSON whatever Content-Type
+// they were sent with, as handlers decode them that way; multipart bodies
+// and the other non-JSON bodies an operation accepts, such as CSV imports,
+// are left to their handlers, which cap their size while reading.
+func (v *RequestValidator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.validate(w, r); err != nil {
+			writeValidationError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ParamError handles parameters the generated wrappers could not parse,
+// describing them the same way Middleware does
+func (v *RequestValidator) ParamError(w http.ResponseWriter, r *http.Request, err error) {
+	if err := v.validate(w, r); err != nil {
+		writeValidationError(w, r, err)
+		return
+	}
+	writeError(w, r, http.StatusBadRequest, err.Error(), "INVALID_REQUEST")
+}
+
+// validate checks r against the spec, leaving its body to be read again
+func (v *RequestValidator) validate(w http.ResponseWriter, r *http.Request) error {
+	if !slices.Contains(routeMethods, r.Method) {
+		return nil
+	}
+	route, pathParams, err := v.router.FindRoute(r)
+	if err != nil {
+		return nil
+	}
+	
+	input := &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			SkipSettingDefaults: true,
+		},
+	}
+	if body := route.Operation.RequestBody; body != nil && body.Value != nil && r.Body != nil {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if strings.HasPrefix(mediaType, "multipart/") || (body.Value.Content.Get(mediaType) != nil && !isJSONMediaType(mediaType)) {
+			input.Options.ExcludeRequestBody = true
+			return openapi3filter.ValidateRequest(r.Context(), input)
+		}
+		
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONBodyBytes))
+		r.Body.Close()
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return err
+			}
+			return &openapi3filter.RequestError{RequestBody: body.Value, Reason: "unable to read request body", Err: err}
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		switch {
+		case len(bytes.TrimSpace(data)) == 0:
+			// Handlers report a missing body as EMPTY_BODY
+			input.Options.ExcludeRequestBody = true
+		case body.Value.Content.Get(mediaType) == nil && body.Value.Content.Get(contentTypeJSON) != nil:
+			input.Request = r.Clone(r.Context())
+			input.Request.Header.Set("Content-Type", contentTypeJSON)
+			input.Request.Body = io.NopCloser(bytes.NewReader(data))
+		}
+	}
+	return openapi3filter.ValidateRequest(r.Context(), input)
+}
+
+// isJSONMediaType reports whether mediaType is JSON or a JSON-based type,
+// such as application/merge-patch+json
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == contentTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+// writeValidationError writes a 400 INVALID_REQUEST listing what was wrong
+// with each part of the request, or a 413 for a body too large to validate
+func writeValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeBodyTooLarge(w, r, tooLarge)
+		return
+	}
+	
+	details := validationDetails(err)
+	problem := newProblem(r, http.StatusBadRequest, "Request does not match the API specification", "INVALID_REQUEST")
+	problem.Errors = &details
+	writeProblem(w, problem)
+}
+
+// validationDetails flattens the errors of a failed validation into one
+// detail per invalid value
+func validationDetails(err error) []api.ErrorDetail {
+	// Only unwrap a MultiError at the top; a RequestError wraps one too
+	if multi, ok := err.(openapi3.MultiError); ok {
+		var details []api.ErrorDetail
+		for _, err := range multi {
+			details = append(details, validationDetails(err)...)
+		}
+		return details
+	}
+	
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return []api.ErrorDetail{{In: api.Body, Message: err.Error()}}
+	}
+	if param := reqErr.Parameter; param != nil {
+		name := param.Name
+		return []api.ErrorDetail{{
+			In:      api.ErrorDetailIn(param.In),
+			Name:    &name,
+			Message: requestErrorReason(reqErr),
+		}}
+	}
+	
+	// Body errors hold one schema error per invalid field
+	var fields openapi3.MultiError
+	if !errors.As(reqErr.Err, &fields) {
+		fields = openapi3.MultiError{reqErr.Err}
+	}
+	var details []api.ErrorDetail
+	for _, err := range fields {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(err, &schemaErr) {
+			details = append(details, api.ErrorDetail{In: api.Body, Message: requestErrorReason(reqErr)})
+			continue
+		}
+		detail := api.ErrorDetail{In: api.Body, Message: schemaErr.Reason}
+		if path := schemaErr.JSONPointer(); len(path) > 0 {
+			name := strings.Join(path, ".")
+			detail.Name = &name
+		}
+		details = append(details, detail)
+	}
+	return details
+}
+
+// requestErrorReason describes a request error without echoing the invalid
+// value back
+func requestErrorReason(err *openapi3filter.RequestError) string {
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err.Err, &schemaErr) {
+		return schemaErr.Reason
+	}
+	if err.Err != nil && err.Reason == "" {
+		return err.Err.Error()
+	}
+	return err.Reason
+}
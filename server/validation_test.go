@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// validatingRouter creates a router where user 1 is an admin, so requests
+// get past authorization to the validator
+func validatingRouter() http.Handler {
+	queries := &stubQueries{
+		listUserRoles: rolesFor(map[int32][]db.UserRole{1: {{UserID: 1, Role: "admin"}}}),
+	}
+	return SetupRouter(NewServer(queries, testConfig()))
+}
+
+// sendInvalid serves a request as user 1 and decodes the error response
+func sendInvalid(t *testing.T, router http.Handler, method, path, body string) (int, api.Problem) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("%s %s: failed to decode response: %v", method, path, err)
+	}
+	return rec.Code, resp
+}
+
+func TestRequestValidator_RejectsInvalidRequests(t *testing.T) {
+	router := validatingRouter()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		in     api.ErrorDetailIn
+		field  string
+	}{
+		{"limit below minimum", http.MethodGet, "/users?limit=0", "", api.Query, "limit"},
+		{"limit not a number", http.MethodGet, "/users?limit=ten", "", api.Query, "limit"},
+		{"negative offset", http.MethodGet, "/games?offset=-1", "", api.Query, "offset"},
+		{"unknown enum value", http.MethodPost, "/games/sm64/categories", `{"slug":"any","name":"Any%","timing_method":"hms"}`, api.Body, "timing_method"},
+		{"wrong field type", http.MethodPost, "/users", `{"name":5,"email":"ada@example.com"}`, api.Body, "name"},
+		{"missing required field", http.MethodPost, "/users", `{"name":"Ada"}`, api.Body, "email"},
+	}
+	for _, tt := range tests {
+		code, resp := sendInvalid(t, router, tt.method, tt.path, tt.body)
+		if code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", tt.name, code)
+			continue
+		}
+		if resp.Code != "INVALID_REQUEST" {
+			t.Errorf("%s: expected code INVALID_REQUEST, got %v", tt.name, resp.Code)
+		}
+		if resp.Errors == nil || len(*resp.Errors) == 0 {
+			t.Errorf("%s: expected details, got none", tt.name)
+			continue
+		}
+		detail := (*resp.Errors)[0]
+		if detail.In != tt.in || detail.Name == nil || *detail.Name != tt.field {
+			t.Errorf("%s: expected a detail for %s %s, got %+v", tt.name, tt.in, tt.field, *resp.Errors)
+		}
+		if detail.Message == "" {
+			t.Errorf("%s: expected a message in the detail", tt.name)
+		}
+	}
+}
+
+func TestRequestValidator_ReportsEveryInvalidField(t *testing.T) {
+	router := validatingRouter()
+
+	code, resp := sendInvalid(t, router, http.MethodPost, "/users", `{"name":"","email":7}`)
+	if code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", code)
+	}
+	if resp.Errors == nil || len(*resp.Errors) != 2 {
+		t.Fatalf("expected a detail for name and email, got %v", resp.Errors)
+	}
+}
+
+func TestRequestValidator_ValidatesBodiesAsJSON(t *testing.T) {
+	router := validatingRouter()
+
+	// Handlers decode bodies as JSON whatever their Content-Type, so the
+	// validator checks them as JSON too
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ada"}`))
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rec.Code != http.StatusBadRequest || resp.Errors == nil || len(*resp.Errors) != 1 {
+		t.Fatalf("expected 400 with one detail, got %d %+v", rec.Code, resp)
+	}
+	if detail := (*resp.Errors)[0]; detail.Name == nil || *detail.Name != "email" {
+		t.Errorf("expected a detail for the missing email, got %+v", detail)
+	}
+}
+
+func TestRequestValidator_AuthenticatesFirst(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	req := httptest.NewRequest(http.MethodPost, "/games", strings.NewReader(`{"slug":5}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for an anonymous invalid write, got %d", rec.Code)
+	}
+}
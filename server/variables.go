@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListVariables handles GET /games/{slug}/variables
+// Retrieves a game's variables along with their values
+func (s *Server) ListVariables(w http.ResponseWriter, r *http.Request, slug string) {
+	variables, err := s.variableService.ListVariables(r.Context(), slug)
+	if err != nil {
+		writeServiceError(w, r, err, "Error listing variables")
+		return
+	}
+	
+	apiVariables := make([]api.Variable, len(variables))
+	for i, variable := range variables {
+		apiVariables[i] = toAPIVariable(&variable)
+	}
+	
+	response := struct {
+		Variables []api.Variable `json:"variables"`
+	}{
+		Variables: apiVariables,
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateVariable handles POST /games/{slug}/variables
+// Adds a variable and its allowed values to a game
+func (s *Server) CreateVariable(w http.ResponseWriter, r *http.Request, slug string) {
+	var req api.CreateVariableRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	input := service.CreateVariableInput{
+		Slug:   req.Slug,
+		Name:   req.Name,
+		Values: make([]service.CreateVariableValueInput, len(req.Values)),
+	}
+	if req.Category != nil {
+		input.CategorySlug = *req.Category
+	}
+	for i, value := range req.Values {
+		input.Values[i] = service.CreateVariableValueInput{Slug: value.Slug, Label: value.Label}
+	}
+	
+	variable, err := s.variableService.CreateVariable(r.Context(), slug, input)
+	if err != nil {
+		writeServiceError(w, r, err, "Error creating variable")
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusCreated, toAPIVariable(variable))
+}
+
+// toAPIVariable converts a variable and its values to an API Variable model
+func toAPIVariable(variable *service.Variable) api.Variable {
+	values := make([]api.VariableValue, len(variable.Values))
+	for i, value := range variable.Values {
+		values[i] = api.VariableValue{
+			Id:    int(value.ID),
+			Slug:  value.Slug,
+			Label: value.Label,
+		}
+	}
+	
+	apiVariable := api.Variable{
+		Id:        int(variable.ID),
+		GameId:    int(variable.GameID),
+		Slug:      variable.Slug,
+		Name:      variable.Name,
+		Values:    values,
+		CreatedAt: variable.CreatedAt.Time.UTC(),
+	}
+	if variable.CategoryID.Valid {
+		categoryID := int(variable.CategoryID.Int32)
+		apiVariable.CategoryId = &categoryID
+	}
+	return apiVariable
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// apiVersion is a version of the API, served under its own path prefix
+// A version with breaking changes gets its own spec and generated package,
+// is added to apiVersions, and is mounted by SetupRouter beside the others.
+type apiVersion struct {
+	// name is the version's path segment, such as "v1"
+	name string
+	
+	// spec loads the OpenAPI document the version's handlers were generated
+	// from
+	spec func() (*openapi3.T, error)
+	
+	// routes creates the version's router, with its middleware and handlers
+	routes func(*Server) http.Handler
+	
+	// deprecated is when the version was deprecated; zero while it is
+	// current
+	deprecated time.Time
+	
+	// sunset is when the version is to stop being served; zero while no
+	// date is set
+	sunset time.Time
+	
+	// successor names the version replacing this one once it is deprecated
+	successor string
+}
+
+// apiVersions lists every version of the API being served, oldest first
+var apiVersions = []apiVersion{
+	{name: "v1", spec: api.GetSwagger, routes: (*Server).v1Routes},
+}
+
+// unversionedAPI is the version served at paths without a version prefix,
+// as every path was before versions existed; it stays v1 so those clients
+// keep working when later versions are added
+var unversionedAPI = apiVersions[0]
+
+// prefix returns the path the version is mounted at, e.g. /v1
+func (v apiVersion) prefix() string {
+	return "/" + v.name
+}
+
+// served is the version serving a request, and the prefix stripped from the
+// request's path before it was routed
+type served struct {
+	version apiVersion
+	prefix  string
+}
+
+// servedKey is the context key for the served version of a request
+type servedKey struct{}
+
+// serve hands requests for the version to next, once prefix has been
+// stripped from their path, and announces the version's deprecation on
+// every response once it has one
+func (v apiVersion) serve(prefix string, next http.Handler) http.Handler {
+	if prefix != "" {
+		next = http.StripPrefix(prefix, next)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !v.deprecated.IsZero() {
+			successor := ""
+			if v.successor != "" {
+				successor = "/" + v.successor
+			}
+			setDeprecationHeaders(w.Header(), v.deprecated, v.sunset, successor, "successor-version")
+		}
+		ctx := context.WithValue(r.Context(), servedKey{}, served{version: v, prefix: prefix})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// servedVersion returns the version serving the request with ctx and the
+// prefix its path was sent with, which is empty for unversioned paths
+func servedVersion(ctx context.Context) (apiVersion, string) {
+	if s, ok := ctx.Value(servedKey{}).(served); ok {
+		return s.version, s.prefix
+	}
+	return unversionedAPI, ""
+}
+
+// servedPrefix returns the version prefix stripped from the path of the
+// request with ctx before it was routed
+func servedPrefix(ctx context.Context) string {
+	s, _ := ctx.Value(servedKey{}).(served)
+	return s.prefix
+}
+
+// requestPath returns the path r was sent to, including its version prefix
+func requestPath(r *http.Request) string {
+	return servedPrefix(r.Context()) + r.URL.Path
+}
+
+// setDeprecationHeaders announces that what a response describes is
+// deprecated, with a Deprecation header (RFC 9745), a Sunset header (RFC
+// 8594) when a removal date is set, and a Link to its replacement, related
+// to it by rel, when there is one
+func setDeprecationHeaders(h http.Header, deprecated, sunset time.Time, link, rel string) {
+	h.Set("Deprecation", "@"+strconv.FormatInt(deprecated.Unix(), 10))
+	if !sunset.IsZero() {
+		h.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+	if link != "" {
+		h.Add("Link", "<"+link+`>; rel="`+rel+`"`)
+	}
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestSetupRouter_ServesVersionPrefix(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{getGameBySlug: gamesBySlug(db.Game{ID: 1, Slug: "sm64", Name: "Super Mario 64"})}, testConfig()))
+
+	for _, path := range []string{"/v1/games/sm64", "/games/sm64"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		var game api.Game
+		if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &game) != nil || game.Slug != "sm64" {
+			t.Errorf("%s: expected the game, got %d: %s", path, rec.Code, rec.Body.String())
+		}
+		if rec.Header().Get("Deprecation") != "" {
+			t.Errorf("%s: expected the current version not to be deprecated", path)
+		}
+	}
+}
+
+func TestSetupRouter_VersionedErrors(t *testing.T) {
+	router := SetupRouter(NewServer(&stubQueries{}, testConfig()))
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{"/v1/no-such-route", http.StatusNotFound},
+		{"/v1", http.StatusNotFound},
+		{"/v1/users/abc", http.StatusBadRequest},
+		{"/v2/games", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		var problem api.Problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("%s: failed to decode problem: %v", tt.path, err)
+		}
+		if rec.Code != tt.expected || problem.Instance != tt.path {
+			t.Errorf("%s: expected %d for the path sent, got %d for %q", tt.path, tt.expected, rec.Code, problem.Instance)
+		}
+	}
+}
+
+func TestGetOpenAPISpec_ByVersion(t *testing.T) {
+	router := SetupRouter(NewServer(db.NewStore(nil), testConfig()))
+
+	tests := []struct {
+		path    string
+		servers []string
+	}{
+		{"/v1/openapi.json", []string{"/v1"}},
+		{"/openapi.json", []string{"http://localhost:8080/v1", "http://localhost:8080"}},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		var spec struct {
+			Info    map[string]any `json:"info"`
+			Servers []struct {
+				URL string `json:"url"`
+			} `json:"servers"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("%s: failed to decode spec: %v", tt.path, err)
+		}
+		var servers []string
+		for _, server := range spec.Servers {
+			servers = append(servers, server.URL)
+		}
+		if strings.Join(servers, " ") != strings.Join(tt.servers, " ") {
+			t.Errorf("%s: expected servers %v, got %v", tt.path, tt.servers, servers)
+		}
+		if spec.Info["x-api-version"] != "v1" {
+			t.Errorf("%s: expected x-api-version v1, got %v", tt.path, spec.Info["x-api-version"])
+		}
+	}
+}
+
+func TestAPIVersion_AnnouncesDeprecation(t *testing.T) {
+	v := apiVersion{
+		name:       "v0",
+		deprecated: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		sunset:     time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		successor:  "v1",
+	}
+	var path string
+	handler := v.serve(v.prefix(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if got := requestPath(r); got != "/v0/games" {
+			t.Errorf("expected the request path with its prefix, got %q", got)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v0/games", nil))
+
+	if path != "/games" {
+		t.Errorf("expected the prefix stripped before routing, got %q", path)
+	}
+	for header, expected := range map[string]string{
+		"Deprecation": "@1767225600",
+		"Sunset":      "Wed, 01 Jul 2026 00:00:00 GMT",
+		"Link":        `</v1>; rel="successor-version"`,
+	} {
+		if got := rec.Header().Get(header); got != expected {
+			t.Errorf("expected %s %q, got %q", header, expected, got)
+		}
+	}
+}
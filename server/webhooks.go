@@ -0,0 +1,167 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/example/speedrun-rest-api/api"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/service"
+)
+
+// ListWebhooks handles GET /webhooks
+// Lists the registered webhooks without their secrets
+func (s *Server) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := s.webhookService.ListWebhooks(r.Context())
+	if err != nil {
+		s.writeWebhookError(w, r, err)
+		return
+	}
+	
+	apiWebhooks := make([]api.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		apiWebhooks[i] = dbWebhookToAPIWebhook(&webhook)
+	}
+	
+	response := struct {
+		Webhooks []api.Webhook `json:"webhooks"`
+	}{
+		Webhooks: apiWebhooks,
+	}
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// CreateWebhook handles POST /webhooks
+// Registers a webhook; its secret is only ever returned here
+func (s *Server) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateWebhookRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	
+	events := make([]string, len(req.Events))
+	for i, event := range req.Events {
+		events[i] = string(event)
+	}
+	
+	webhook, err := s.webhookService.CreateWebhook(r.Context(), req.Url, events)
+	if err != nil {
+		s.writeWebhookError(w, r, err)
+		return
+	}
+	
+	apiWebhook := dbWebhookToAPIWebhook(webhook)
+	apiWebhook.Secret = &webhook.Secret
+	w.Header().Set("Cache-Control", "no-store")
+	s.writeJSON(w, r, http.StatusCreated, apiWebhook)
+}
+
+// GetWebhook handles GET /webhooks/{id}
+// Returns a webhook without its secret
+func (s *Server) GetWebhook(w http.ResponseWriter, r *http.Request, id int) {
+	webhook, err := s.webhookService.GetWebhook(r.Context(), int32(id))
+	if err != nil {
+		s.writeWebhookError(w, r, err)
+		return
+	}
+	
+	s.writeJSON(w, r, http.StatusOK, dbWebhookToAPIWebhook(webhook))
+}
+
+// DeleteWebhook handles DELETE /webhooks/{id}
+// Stops sending events to a webhook
+func (s *Server) DeleteWebhook(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.webhookService.DeleteWebhook(r.Context(), int32(id)); err != nil {
+		s.writeWebhookError(w, r, err)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries handles GET /webhooks/{id}/deliveries
+// Returns the webhook's delivery log, newest first
+func (s *Server) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, id int, params api.ListWebhookDeliveriesParams) {
+	page, err := s.webhookService.ListWebhookDeliveries(r.Context(), int32(id), pageRequest(params.Limit, params.Offset, params.Cursor))
+	if err != nil {
+		s.writeWebhookError(w, r, err)
+		return
+	}
+	
+	deliveries := make([]api.WebhookDelivery, len(page.Deliveries))
+	for i, delivery := range page.Deliveries {
+		deliveries[i] = dbWebhookDeliveryToAPIWebhookDelivery(&delivery)
+	}
+	
+	response := struct {
+		Deliveries []api.WebhookDelivery `json:"deliveries"`
+		Total      int64                 `json:"total"`
+		Limit      int32                 `json:"limit"`
+		Offset     int32                 `json:"offset"`
+		NextCursor string                `json:"next_cursor,omitempty"`
+	}{
+		Deliveries: deliveries,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+	s.writeJSON(w, r, http.StatusOK, response)
+}
+
+// writeWebhookError maps errors from managing webhooks to responses
+func (s *Server) writeWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, r, http.StatusForbidden, "Admin access required", "FORBIDDEN")
+	default:
+		writeServiceError(w, r, err, "Error managing webhooks")
+	}
+}
+
+// dbWebhookToAPIWebhook converts a stored webhook to its API form, without
+// the secret
+func dbWebhookToAPIWebhook(webhook *db.Webhook) api.Webhook {
+	events := make([]api.WebhookEvent, len(webhook.Events))
+	for i, event := range webhook.Events {
+		events[i] = api.WebhookEvent(event)
+	}
+	
+	apiWebhook := api.Webhook{
+		Id:        int(webhook.ID),
+		Url:       webhook.Url,
+		Events:    events,
+		CreatedAt: webhook.CreatedAt.Time.UTC(),
+	}
+	if webhook.CreatedBy.Valid {
+		createdBy := int(webhook.CreatedBy.Int32)
+		apiWebhook.CreatedBy = &createdBy
+	}
+	return apiWebhook
+}
+
+// dbWebhookDeliveryToAPIWebhookDelivery converts a database WebhookDelivery
+// model to an API WebhookDelivery model
+func dbWebhookDeliveryToAPIWebhookDelivery(delivery *db.WebhookDelivery) api.WebhookDelivery {
+	apiDelivery := api.WebhookDelivery{
+		Id:        int(delivery.ID),
+		Event:     api.WebhookEvent(delivery.Event),
+		Payload:   delivery.Payload,
+		Status:    api.WebhookDeliveryStatus(delivery.Status),
+		Attempts:  int(delivery.Attempts),
+		CreatedAt: delivery.CreatedAt.Time.UTC(),
+		UpdatedAt: delivery.UpdatedAt.Time.UTC(),
+	}
+	if delivery.Status == string(api.WebhookDeliveryStatusPending) {
+		nextAttemptAt := delivery.NextAttemptAt.Time.UTC()
+		apiDelivery.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.ResponseStatus.Valid {
+		responseStatus := int(delivery.ResponseStatus.Int32)
+		apiDelivery.ResponseStatus = &responseStatus
+	}
+	if delivery.LastError.Valid {
+		apiDelivery.LastError = &delivery.LastError.String
+	}
+	return apiDelivery
+}
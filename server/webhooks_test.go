@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestCreateWebhook_ReturnsSecretOnce(t *testing.T) {
+	var created db.CreateWebhookParams
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			1: {{UserID: 1, Role: "admin"}},
+		}),
+		createWebhook: func(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+			created = arg
+			return db.Webhook{
+				ID:        5,
+				Url:       arg.Url,
+				Secret:    arg.Secret,
+				Events:    arg.Events,
+				CreatedBy: arg.CreatedBy,
+				CreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			}, nil
+		},
+		getWebhookByID: func(ctx context.Context, id int32) (db.Webhook, error) {
+			return db.Webhook{ID: id, Url: created.Url, Secret: created.Secret, Events: created.Events}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"https://example.com/hooks","events":["run.verified"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected the response not to be cached, got %q", rec.Header().Get("Cache-Control"))
+	}
+	var body struct {
+		Secret    string `json:"secret"`
+		CreatedBy int    `json:"created_by"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if body.Secret == "" || body.Secret != created.Secret {
+		t.Errorf("expected the stored secret to be returned, got %q", body.Secret)
+	}
+	if body.CreatedBy != 1 {
+		t.Errorf("expected the webhook to be created by user 1, got %d", body.CreatedBy)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/webhooks/5", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "secret") {
+		t.Errorf("expected the secret to be left out, got %s", rec.Body.String())
+	}
+}
+
+func TestCreateWebhook_RequiresAdmin(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"https://example.com/hooks","events":["run.verified"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearerToken(t, 2))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	queries := &stubQueries{
+		getUserByID: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		listUserRoles: rolesFor(map[int32][]db.UserRole{
+			1: {{UserID: 1, Role: "admin"}},
+		}),
+		getWebhookByID: func(ctx context.Context, id int32) (db.Webhook, error) {
+			return db.Webhook{ID: id}, nil
+		},
+		listWebhookDeliveries: func(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+			now := time.Now()
+			return []db.WebhookDelivery{
+				{
+					ID:             9,
+					WebhookID:      arg.WebhookID,
+					Event:          "run.verified",
+					Payload:        []byte(`{"event":"run.verified"}`),
+					Status:         "pending",
+					Attempts:       2,
+					NextAttemptAt:  pgtype.Timestamptz{Time: now.Add(time.Minute), Valid: true},
+					ResponseStatus: pgtype.Int4{Int32: 503, Valid: true},
+					LastError:      pgtype.Text{String: "endpoint responded 503 Service Unavailable", Valid: true},
+					CreatedAt:      pgtype.Timestamptz{Time: now, Valid: true},
+					UpdatedAt:      pgtype.Timestamptz{Time: now, Valid: true},
+				},
+				{
+					ID:        8,
+					WebhookID: arg.WebhookID,
+					Event:     "user.created",
+					Payload:   []byte(`{"event":"user.created"}`),
+					Status:    "succeeded",
+					Attempts:  1,
+					CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+					UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+				},
+			}, nil
+		},
+		countWebhookDeliveries: func(ctx context.Context, webhookID int32) (int64, error) {
+			return 2, nil
+		},
+	}
+	router := SetupRouter(NewServer(queries, testConfig()))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/5/deliveries", nil)
+	req.Header.Set("Authorization", bearerToken(t, 1))
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Deliveries []struct {
+			Payload        json.RawMessage `json:"payload"`
+			NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+			ResponseStatus int             `json:"response_status"`
+		} `json:"deliveries"`
+		Total int `json:"total"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("expected JSON body, got error %v", err)
+	}
+	if resp.Total != 2 || len(resp.Deliveries) != 2 {
+		t.Fatalf("expected two deliveries, got %+v", resp)
+	}
+	pending, succeeded := resp.Deliveries[0], resp.Deliveries[1]
+	if string(pending.Payload) != `{"event":"run.verified"}` {
+		t.Errorf("expected the payload to be returned as sent, got %s", pending.Payload)
+	}
+	if pending.NextAttemptAt == nil || pending.ResponseStatus != 503 {
+		t.Errorf("expected the pending delivery's retry and last response, got %+v", pending)
+	}
+	if succeeded.NextAttemptAt != nil {
+		t.Errorf("expected no next attempt for a finished delivery, got %s", succeeded.NextAttemptAt)
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// API key scopes; each operation that accepts API keys lists the scopes that
+// may call it in the OpenAPI spec
+const (
+	// ScopeRunsWrite allows submitting runs
+	ScopeRunsWrite = "runs:write"
+	
+	// ScopeRunsModerate allows verifying and rejecting runs
+	ScopeRunsModerate = "runs:moderate"
+	
+	// ScopeGamesWrite allows creating and editing games and categories
+	ScopeGamesWrite = "games:write"
+)
+
+// APIKeyScopes are the scopes a key may be granted
+var APIKeyScopes = []string{ScopeRunsWrite, ScopeRunsModerate, ScopeGamesWrite}
+
+var (
+	// ErrAPIKeyNotFound is returned when a key does not exist, belongs to
+	// another user, or was already revoked
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	
+	// ErrInvalidAPIKey is returned when a presented key is unknown, expired,
+	// or revoked
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	
+	// ErrTooManyAPIKeys is returned when a user already has the maximum
+	// number of active keys
+	ErrTooManyAPIKeys = errors.New("too many api keys")
+)
+
+const (
+	// apiKeyPrefix starts every key so leaked keys are easy to spot in logs
+	// and by secret scanners
+	apiKeyPrefix = "srk_"
+	
+	// apiKeyDisplayLength is how much of a key is kept in the clear to tell
+	// keys apart
+	apiKeyDisplayLength = 12
+	
+	// maxAPIKeyNameLength matches the VARCHAR(100) api_keys.name column
+	maxAPIKeyNameLength = 100
+	
+	// maxAPIKeysPerUser bounds how many active keys one user may hold
+	maxAPIKeysPerUser = 25
+)
+
+// APIKeyService handles business logic for API keys
+type APIKeyService struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewAPIKeyService creates a new APIKeyService
+func NewAPIKeyService(queries db.Store) *APIKeyService {
+	return &APIKeyService{queries: queries, now: time.Now}
+}
+
+// CreateAPIKey mints a key for the caller
+//
+// Only the hash of the key is stored, so the returned key cannot be shown
+// again. Keys cannot be used to mint further keys.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - name: A label for the key, e.g. the bot it is for
+//   - scopes: The operations the key may call; see APIKeyScopes
+//   - expiresAt: When the key stops working; the zero time never expires
+//
+// Returns:
+//   - *db.ApiKey: The stored key
+//   - string: The key itself
+//   - error: ErrForbidden, ErrInvalidInput, ErrTooManyAPIKeys, or database errors
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, name string, scopes []string, expiresAt time.Time) (*db.ApiKey, string, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", invalidField("name", "must not be empty")
+	}
+	if utf8.RuneCountInString(name) > maxAPIKeyNameLength {
+		return nil, "", invalidField("name", "must be at most %d characters", maxAPIKeyNameLength)
+	}
+	if len(scopes) == 0 {
+		return nil, "", invalidField("scopes", "must include at least one scope")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(APIKeyScopes, scope) {
+			return nil, "", invalidField("scopes", "includes unknown scope %q", scope)
+		}
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(s.now()) {
+		return nil, "", invalidField("expires_at", "must be in the future")
+	}
+	
+	existing, err := s.queries.ListAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list api keys: %w", err)
+	}
+	if len(existing) >= maxAPIKeysPerUser {
+		return nil, "", ErrTooManyAPIKeys
+	}
+	
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	
+	var stored db.ApiKey
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		stored, err = q.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+			UserID:    userID,
+			Name:      name,
+			Prefix:    key[:apiKeyDisplayLength],
+			KeyHash:   hashSecret(key),
+			Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: !expiresAt.IsZero()},
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "api_key.create", AuditEntityAPIKey, stored.ID, nil, stored)
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to store api key: %w", err)
+	}
+	
+	return &stored, key, nil
+}
+
+// ListAPIKeys returns the caller's active keys, oldest first
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//
+// Returns:
+//   - []db.ApiKey: The caller's keys that have not been revoked
+//   - error: ErrForbidden, or database errors
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]db.ApiKey, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	keys, err := s.queries.ListAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes one of the caller's keys
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: The key to revoke
+//
+// Returns:
+//   - error: ErrForbidden, ErrAPIKeyNotFound, or database errors
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id int32) error {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		revoked, err := q.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{ID: id, UserID: userID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAPIKeyNotFound
+			}
+			return fmt.Errorf("failed to revoke api key: %w", err)
+		}
+		
+		// The query only matches keys that were not yet revoked
+		before := revoked
+		before.RevokedAt = pgtype.Timestamptz{}
+		return recordAudit(ctx, q, "api_key.revoke", AuditEntityAPIKey, id, before, revoked)
+	})
+}
+
+// Authenticate identifies the owner of a presented key
+// The returned principal carries the owner's roles and the key's scopes.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - key: The key from the Authorization header
+//
+// Returns:
+//   - auth.Principal: The key's owner, limited to the key's scopes
+//   - error: ErrInvalidAPIKey, or database errors
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	stored, err := s.queries.GetAPIKeyByHash(ctx, hashSecret(key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.Principal{}, ErrInvalidAPIKey
+		}
+		return auth.Principal{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+	if stored.RevokedAt.Valid || (stored.ExpiresAt.Valid && !s.now().Before(stored.ExpiresAt.Time)) {
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+	
+	user, err := s.queries.GetUserByIDIncludingDeleted(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.Principal{}, ErrInvalidAPIKey
+		}
+		return auth.Principal{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.DeletedAt.Valid {
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+	
+	principal, err := loadPrincipal(ctx, s.queries, stored.UserID)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	principal.APIKeyID = stored.ID
+	principal.Scopes = stored.Scopes
+	
+	// Last use is informational, so failing to record it does not fail the request
+	if err := s.queries.TouchAPIKey(ctx, stored.ID); err != nil {
+		slog.WarnContext(ctx, "Failed to record use of api key", "api_key_id", stored.ID, "error", err)
+	}
+	return principal, nil
+}
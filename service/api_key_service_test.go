@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) CreateAPIKey(ctx context.Context, params db.CreateAPIKeyParams) (db.ApiKey, error) {
+	if m.CreateAPIKeyFunc != nil {
+		return m.CreateAPIKeyFunc(ctx, params)
+	}
+	return db.ApiKey{}, nil
+}
+
+func (m *MockQueries) GetAPIKeyByHash(ctx context.Context, keyHash string) (db.ApiKey, error) {
+	if m.GetAPIKeyByHashFunc != nil {
+		return m.GetAPIKeyByHashFunc(ctx, keyHash)
+	}
+	return db.ApiKey{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) ListAPIKeysByUser(ctx context.Context, userID int32) ([]db.ApiKey, error) {
+	if m.ListAPIKeysByUserFunc != nil {
+		return m.ListAPIKeysByUserFunc(ctx, userID)
+	}
+	return []db.ApiKey{}, nil
+}
+
+func (m *MockQueries) RevokeAPIKey(ctx context.Context, params db.RevokeAPIKeyParams) (db.ApiKey, error) {
+	if m.RevokeAPIKeyFunc != nil {
+		return m.RevokeAPIKeyFunc(ctx, params)
+	}
+	return db.ApiKey{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) TouchAPIKey(ctx context.Context, id int32) error {
+	if m.TouchAPIKeyFunc != nil {
+		return m.TouchAPIKeyFunc(ctx, id)
+	}
+	return nil
+}
+
+// apiKeyStore is an in-memory api_keys table wired into a MockQueries
+type apiKeyStore struct {
+	keys []db.ApiKey
+}
+
+func (a *apiKeyStore) install(m *MockQueries) {
+	m.CreateAPIKeyFunc = func(ctx context.Context, p db.CreateAPIKeyParams) (db.ApiKey, error) {
+		key := db.ApiKey{
+			ID:        int32(len(a.keys) + 1),
+			UserID:    p.UserID,
+			Name:      p.Name,
+			Prefix:    p.Prefix,
+			KeyHash:   p.KeyHash,
+			Scopes:    p.Scopes,
+			ExpiresAt: p.ExpiresAt,
+		}
+		a.keys = append(a.keys, key)
+		return key, nil
+	}
+	m.GetAPIKeyByHashFunc = func(ctx context.Context, keyHash string) (db.ApiKey, error) {
+		for _, key := range a.keys {
+			if key.KeyHash == keyHash {
+				return key, nil
+			}
+		}
+		return db.ApiKey{}, sql.ErrNoRows
+	}
+	m.GetUserByIDFunc = func(ctx context.Context, id int32) (db.User, error) {
+		return db.User{ID: id}, nil
+	}
+}
+
+func TestCreateAPIKey_AuthenticatesWithScopes(t *testing.T) {
+	store := &apiKeyStore{}
+	mockQueries := &MockQueries{
+		ListUserRolesFunc: func(ctx context.Context, userID int32) ([]db.UserRole, error) {
+			return []db.UserRole{{UserID: userID, Role: "moderator"}}, nil
+		},
+	}
+	store.install(mockQueries)
+	service := NewAPIKeyService(mockQueries)
+
+	stored, key, err := service.CreateAPIKey(asUser(3), "  run bot ", []string{ScopeRunsWrite, ScopeRunsWrite}, time.Time{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(key, apiKeyPrefix) || stored.Prefix != key[:apiKeyDisplayLength] {
+		t.Errorf("expected key %q to start with its display prefix %q", key, stored.Prefix)
+	}
+	if stored.KeyHash == key || stored.Name != "run bot" || !slices.Equal(stored.Scopes, []string{ScopeRunsWrite}) {
+		t.Errorf("unexpected stored key %+v", stored)
+	}
+
+	principal, err := service.Authenticate(context.Background(), key)
+	if err != nil {
+		t.Fatalf("expected key to authenticate, got %v", err)
+	}
+	if principal.UserID != 3 || !principal.ViaAPIKey() || !principal.HasScope(ScopeRunsWrite) || principal.HasScope(ScopeGamesWrite) {
+		t.Errorf("unexpected principal %+v", principal)
+	}
+	if !principal.HasRole(auth.RoleModerator) {
+		t.Errorf("expected the owner's roles to carry over, got %+v", principal.Grants)
+	}
+}
+
+func TestCreateAPIKey_InvalidInput(t *testing.T) {
+	service := NewAPIKeyService(&MockQueries{})
+
+	tests := []struct {
+		name      string
+		keyName   string
+		scopes    []string
+		expiresAt time.Time
+	}{
+		{"blank name", "  ", []string{ScopeRunsWrite}, time.Time{}},
+		{"long name", strings.Repeat("a", maxAPIKeyNameLength+1), []string{ScopeRunsWrite}, time.Time{}},
+		{"no scopes", "bot", nil, time.Time{}},
+		{"unknown scope", "bot", []string{"users:write"}, time.Time{}},
+		{"expired", "bot", []string{ScopeRunsWrite}, time.Now().Add(-time.Minute)},
+	}
+
+	for _, tt := range tests {
+		_, _, err := service.CreateAPIKey(asUser(3), tt.keyName, tt.scopes, tt.expiresAt)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestCreateAPIKey_RequiresAccessToken(t *testing.T) {
+	service := NewAPIKeyService(&MockQueries{})
+	viaKey := auth.WithPrincipal(context.Background(), auth.Principal{UserID: 3, APIKeyID: 1, Scopes: APIKeyScopes})
+
+	for _, ctx := range []context.Context{context.Background(), viaKey} {
+		if _, _, err := service.CreateAPIKey(ctx, "bot", []string{ScopeRunsWrite}, time.Time{}); !errors.Is(err, ErrForbidden) {
+			t.Errorf("expected ErrForbidden, got %v", err)
+		}
+	}
+}
+
+func TestCreateAPIKey_TooMany(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListAPIKeysByUserFunc: func(ctx context.Context, userID int32) ([]db.ApiKey, error) {
+			return make([]db.ApiKey, maxAPIKeysPerUser), nil
+		},
+	}
+
+	service := NewAPIKeyService(mockQueries)
+	_, _, err := service.CreateAPIKey(asUser(3), "bot", []string{ScopeRunsWrite}, time.Time{})
+
+	if !errors.Is(err, ErrTooManyAPIKeys) {
+		t.Errorf("expected ErrTooManyAPIKeys, got %v", err)
+	}
+}
+
+func TestAuthenticate_RejectsRevokedAndExpired(t *testing.T) {
+	store := &apiKeyStore{}
+	mockQueries := &MockQueries{}
+	store.install(mockQueries)
+	service := NewAPIKeyService(mockQueries)
+
+	_, revokedKey, _ := service.CreateAPIKey(asUser(3), "revoked", []string{ScopeRunsWrite}, time.Time{})
+	store.keys[0].RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	_, expiredKey, _ := service.CreateAPIKey(asUser(3), "expired", []string{ScopeRunsWrite}, time.Now().Add(time.Hour))
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	for _, key := range []string{revokedKey, expiredKey, "srk_unknown"} {
+		if _, err := service.Authenticate(context.Background(), key); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Errorf("expected ErrInvalidAPIKey, got %v", err)
+		}
+	}
+}
+
+func TestRevokeAPIKey_ScopedToOwner(t *testing.T) {
+	var params db.RevokeAPIKeyParams
+	mockQueries := &MockQueries{
+		RevokeAPIKeyFunc: func(ctx context.Context, p db.RevokeAPIKeyParams) (db.ApiKey, error) {
+			params = p
+			return db.ApiKey{}, sql.ErrNoRows
+		},
+	}
+
+	service := NewAPIKeyService(mockQueries)
+	err := service.RevokeAPIKey(asUser(3), 8)
+
+	if !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+	if params.ID != 8 || params.UserID != 3 {
+		t.Errorf("expected revoke of key 8 for user 3, got %+v", params)
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Entity types recorded in audit_events.entity_type
+const (
+	AuditEntityUser       = "user"
+	AuditEntityGame       = "game"
+	AuditEntityCategory   = "category"
+	AuditEntityRun        = "run"
+	AuditEntityRunComment = "run_comment"
+	AuditEntityAPIKey     = "api_key"
+	AuditEntityWebhook    = "webhook"
+	AuditEntityVariable   = "variable"
+	AuditEntityLevel      = "level"
+	AuditEntityPlatform   = "platform"
+	AuditEntityRegion     = "region"
+	AuditEntityRace       = "race"
+)
+
+// redactedAuditFields are never stored in an audit event's changes, since
+// they are or are derived from secrets
+var redactedAuditFields = []string{"key_hash", "secret"}
+
+// AuditService lists the audit trail of changes made through the other
+// services; only admins may read it
+type AuditService struct {
+	queries db.Querier
+	pages   pageSizes
+}
+
+// AuditEventFilter narrows the events returned by ListAuditEvents
+// A zero field applies no filtering for that attribute.
+type AuditEventFilter struct {
+	// ActorID restricts results to changes made by this user
+	ActorID int32
+	
+	// EntityType restricts results to changes to this kind of entity, one of
+	// the AuditEntity constants
+	EntityType string
+	
+	// EntityID restricts results to changes to the entity with this ID
+	EntityID int32
+	
+	// From restricts results to events recorded at or after it
+	From time.Time
+	
+	// To restricts results to events recorded before it
+	To time.Time
+}
+
+// AuditEventPage is one page of audit events along with the pagination that
+// was actually applied
+type AuditEventPage struct {
+	Events []db.AuditEvent
+	Total  int64
+	Limit  int32
+	Offset int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// AuditOption configures optional AuditService behavior
+type AuditOption func(*AuditService)
+
+// WithAuditPageSizes sets the limit applied when a list request omits one
+// and the largest limit a list request may ask for
+func WithAuditPageSizes(defaultSize, maxSize int) AuditOption {
+	return func(s *AuditService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// NewAuditService creates a new AuditService instance
+func NewAuditService(queries db.Querier, opts ...AuditOption) *AuditService {
+	s := &AuditService{
+		queries: queries,
+		pages:   defaultPageSizes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListAuditEvents retrieves a filtered, paginated list of audit events,
+// newest first
+//
+// Pagination follows the same policy as ListUsers.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - page: Requested page size and either an offset or a cursor
+//   - filter: Optional criteria to narrow the results
+//
+// Returns:
+//   - *AuditEventPage: The events, total count matching the filter, the
+//     effective limit and offset, and the cursor of the next page
+//   - error: ErrForbidden, ErrInvalidInput or ErrInvalidCursor for a bad
+//     page request, or database errors
+func (s *AuditService) ListAuditEvents(ctx context.Context, page PageRequest, filter AuditEventFilter) (*AuditEventPage, error) {
+	ctx, span := tracer.Start(ctx, "AuditService.ListAuditEvents")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidInput)
+	}
+	
+	actorID := pgtype.Int4{Int32: filter.ActorID, Valid: filter.ActorID != 0}
+	entityType := pgtype.Text{String: filter.EntityType, Valid: filter.EntityType != ""}
+	entityID := pgtype.Int4{Int32: filter.EntityID, Valid: filter.EntityID != 0}
+	from := pgtype.Timestamptz{Time: filter.From, Valid: !filter.From.IsZero()}
+	to := pgtype.Timestamptz{Time: filter.To, Valid: !filter.To.IsZero()}
+	
+	var events []db.AuditEvent
+	if page.Cursor == "" {
+		events, err = s.queries.ListAuditEvents(ctx, db.ListAuditEventsParams{
+			ActorID:     actorID,
+			EntityType:  entityType,
+			EntityID:    entityID,
+			CreatedFrom: from,
+			CreatedTo:   to,
+			Limit:       pageLimit + 1,
+			Offset:      pageOffset,
+		})
+	} else {
+		var afterID int32
+		if err := decodeCursor(page.Cursor, "audit_events", &afterID); err != nil {
+			return nil, err
+		}
+		events, err = s.queries.ListAuditEventsAfter(ctx, db.ListAuditEventsAfterParams{
+			ActorID:     actorID,
+			EntityType:  entityType,
+			EntityID:    entityID,
+			CreatedFrom: from,
+			CreatedTo:   to,
+			AfterID:     afterID,
+			Limit:       pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	events, more := trimPage(events, pageLimit)
+	
+	count, err := s.queries.CountAuditEvents(ctx, db.CountAuditEventsParams{
+		ActorID:     actorID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		CreatedFrom: from,
+		CreatedTo:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	
+	result := &AuditEventPage{
+		Events: events,
+		Total:  count,
+		Limit:  pageLimit,
+		Offset: pageOffset,
+	}
+	if more {
+		result.NextCursor = encodeCursor("audit_events", events[len(events)-1].ID)
+	}
+	return result, nil
+}
+
+// recordAudit records that the caller in ctx performed action on an entity,
+// along with the request ID chi assigned to the request being served
+//
+// It should be called with the Querier of the transaction making the change,
+// so the change and its audit event are committed together. Callers without
+// a principal, such as someone registering, are recorded with no actor.
+//
+// Parameters:
+//   - q: The Querier to record the event with
+//   - action: What was done, e.g. "user.update"
+//   - entityType: One of the AuditEntity constants
+//   - entityID: ID of the entity that was changed
+//   - before: The entity before the change, or nil if it was created
+//   - after: The entity after the change, or nil if it was deleted
+func recordAudit(ctx context.Context, q db.Querier, action, entityType string, entityID int32, before, after any) error {
+	changes, err := auditChanges(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to compute audit changes: %w", err)
+	}
+	
+	params := db.CreateAuditEventParams{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		ActorID:    callerID(ctx),
+	}
+	if id := middleware.GetReqID(ctx); id != "" {
+		params.RequestID = pgtype.Text{String: id, Valid: true}
+	}
+	
+	if err := q.CreateAuditEvent(ctx, params); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+// auditChange is how one field of an entity changed; Before is omitted for
+// a field of a created entity and After for a field of a deleted one
+type auditChange struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// auditChanges returns the JSON object mapping each field that differs
+// between before and after to its auditChange
+// Fields are named by their JSON tags, so they match the audit_events
+// columns and the API's field names alike.
+func auditChanges(before, after any) ([]byte, error) {
+	old, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+	
+	changes := map[string]auditChange{}
+	for name, value := range old {
+		if next, ok := updated[name]; !ok || !bytes.Equal(value, next) {
+			changes[name] = auditChange{Before: value, After: next}
+		}
+	}
+	for name, value := range updated {
+		if _, ok := old[name]; !ok {
+			changes[name] = auditChange{After: value}
+		}
+	}
+	for _, name := range redactedAuditFields {
+		delete(changes, name)
+	}
+	return json.Marshal(changes)
+}
+
+// auditFields returns the JSON encoding of each field of entity, or nothing
+// for a nil entity
+func auditFields(entity any) (map[string]json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func (m *MockQueries) CreateAuditEvent(ctx context.Context, params db.CreateAuditEventParams) error {
+	if m.CreateAuditEventFunc != nil {
+		return m.CreateAuditEventFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockQueries) ListAuditEvents(ctx context.Context, params db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	if m.ListAuditEventsFunc != nil {
+		return m.ListAuditEventsFunc(ctx, params)
+	}
+	return []db.AuditEvent{}, nil
+}
+
+func (m *MockQueries) ListAuditEventsAfter(ctx context.Context, params db.ListAuditEventsAfterParams) ([]db.AuditEvent, error) {
+	if m.ListAuditEventsAfterFunc != nil {
+		return m.ListAuditEventsAfterFunc(ctx, params)
+	}
+	return []db.AuditEvent{}, nil
+}
+
+func (m *MockQueries) CountAuditEvents(ctx context.Context, params db.CountAuditEventsParams) (int64, error) {
+	if m.CountAuditEventsFunc != nil {
+		return m.CountAuditEventsFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+// recordedChanges decodes the changes of an audit event
+func recordedChanges(t *testing.T, event db.CreateAuditEventParams) map[string]map[string]any {
+	t.Helper()
+	var changes map[string]map[string]any
+	if err := json.Unmarshal(event.Changes, &changes); err != nil {
+		t.Fatalf("failed to decode changes %s: %v", event.Changes, err)
+	}
+	return changes
+}
+
+func TestUpdateUser_RecordsAuditEvent(t *testing.T) {
+	var events []db.CreateAuditEventParams
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Old Name", Email: "john@example.com", Version: 1}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			return db.User{ID: params.ID, Name: params.Name, Email: params.Email, Version: 2}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, params db.CreateAuditEventParams) error {
+			events = append(events, params)
+			return nil
+		},
+	}
+
+	ctx := context.WithValue(asUser(1), middleware.RequestIDKey, "req-1")
+	service := NewUserService(mockQueries)
+	if _, err := service.UpdateUser(ctx, 1, "New Name", "", ProfileUpdate{}, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected one audit event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Action != "user.update" || event.EntityType != AuditEntityUser || event.EntityID != 1 {
+		t.Errorf("expected user.update of user 1, got %+v", event)
+	}
+	if !event.ActorID.Valid || event.ActorID.Int32 != 1 {
+		t.Errorf("expected actor 1, got %+v", event.ActorID)
+	}
+	if event.RequestID.String != "req-1" {
+		t.Errorf("expected request ID req-1, got %+v", event.RequestID)
+	}
+
+	changes := recordedChanges(t, event)
+	if name := changes["name"]; name["before"] != "Old Name" || name["after"] != "New Name" {
+		t.Errorf("expected name change to be recorded, got %v", name)
+	}
+	if _, ok := changes["email"]; ok {
+		t.Errorf("expected unchanged email to be left out, got %v", changes)
+	}
+}
+
+func TestRegister_RecordsAnonymousActor(t *testing.T) {
+	var event db.CreateAuditEventParams
+	mockQueries := &MockQueries{
+		CreateUserWithPasswordFunc: func(ctx context.Context, params db.CreateUserWithPasswordParams) (db.User, error) {
+			return db.User{ID: 5, Name: params.Name, Email: params.Email}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, params db.CreateAuditEventParams) error {
+			event = params
+			return nil
+		},
+	}
+
+	service := NewUserService(mockQueries, WithPasswordCost(4))
+	if _, err := service.Register(context.Background(), "Jane", "jane@example.com", "correct horse battery"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if event.Action != "user.register" || event.EntityID != 5 {
+		t.Errorf("expected user.register of user 5, got %+v", event)
+	}
+	if event.ActorID.Valid {
+		t.Errorf("expected no actor, got %d", event.ActorID.Int32)
+	}
+	if email := recordedChanges(t, event)["email"]; email["after"] != "jane@example.com" || email["before"] != nil {
+		t.Errorf("expected created email to be recorded, got %v", email)
+	}
+}
+
+func TestCreateGame_FailsWhenAuditFails(t *testing.T) {
+	committed := false
+	mockQueries := &MockQueries{
+		CreateAuditEventFunc: func(ctx context.Context, params db.CreateAuditEventParams) error {
+			return errors.New("connection reset")
+		},
+	}
+	mockQueries.WithTxFunc = func(ctx context.Context, fn func(q db.Querier) error) error {
+		err := fn(mockQueries)
+		committed = err == nil
+		return err
+	}
+
+	service := NewGameService(mockQueries)
+	_, err := service.CreateGame(asAdmin(), "super-mario-64", "Super Mario 64")
+
+	if err == nil {
+		t.Fatal("expected an error when the audit event cannot be recorded")
+	}
+	if committed {
+		t.Error("expected the game not to be committed without its audit event")
+	}
+}
+
+func TestRevokeAPIKey_RecordsRevocation(t *testing.T) {
+	revokedAt := timeToTimestamptz(time.Now())
+	var event db.CreateAuditEventParams
+	mockQueries := &MockQueries{
+		RevokeAPIKeyFunc: func(ctx context.Context, p db.RevokeAPIKeyParams) (db.ApiKey, error) {
+			return db.ApiKey{ID: p.ID, UserID: p.UserID, Name: "bot", RevokedAt: revokedAt}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, params db.CreateAuditEventParams) error {
+			event = params
+			return nil
+		},
+	}
+
+	service := NewAPIKeyService(mockQueries)
+	if err := service.RevokeAPIKey(asUser(3), 8); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	changes := recordedChanges(t, event)
+	if event.Action != "api_key.revoke" || len(changes) != 1 {
+		t.Errorf("expected only revoked_at to change, got %s %v", event.Action, changes)
+	}
+	if revoked := changes["revoked_at"]; revoked["before"] != nil || revoked["after"] == nil {
+		t.Errorf("expected revoked_at to go from null to set, got %v", revoked)
+	}
+}
+
+func TestAuditChanges_RedactsSecrets(t *testing.T) {
+	data, err := auditChanges(nil, db.ApiKey{ID: 1, Name: "bot", KeyHash: "secret-hash"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var changes map[string]map[string]any
+	if err := json.Unmarshal(data, &changes); err != nil {
+		t.Fatalf("failed to decode changes: %v", err)
+	}
+	if _, ok := changes["key_hash"]; ok {
+		t.Error("expected key_hash to be redacted")
+	}
+	if changes["name"]["after"] != "bot" {
+		t.Errorf("expected name to be recorded, got %v", changes["name"])
+	}
+}
+
+func TestListAuditEvents_RequiresAdmin(t *testing.T) {
+	service := NewAuditService(&MockQueries{})
+
+	if _, err := service.ListAuditEvents(asUser(1), PageRequest{}, AuditEventFilter{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestListAuditEvents_Filters(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var params db.ListAuditEventsParams
+	mockQueries := &MockQueries{
+		ListAuditEventsFunc: func(ctx context.Context, p db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+			params = p
+			return []db.AuditEvent{{ID: 9}, {ID: 8}, {ID: 7}}, nil
+		},
+	}
+
+	service := NewAuditService(mockQueries)
+	page, err := service.ListAuditEvents(asAdmin(), PageRequest{Limit: 2}, AuditEventFilter{
+		ActorID:    3,
+		EntityType: AuditEntityGame,
+		From:       from,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if params.ActorID.Int32 != 3 || params.EntityType.String != AuditEntityGame || !params.CreatedFrom.Time.Equal(from) {
+		t.Errorf("expected filters to reach the query, got %+v", params)
+	}
+	if params.EntityID.Valid || params.CreatedTo.Valid {
+		t.Errorf("expected unset filters to match everything, got %+v", params)
+	}
+	if len(page.Events) != 2 || page.NextCursor == "" {
+		t.Fatalf("expected a page of 2 with a next cursor, got %+v", page)
+	}
+
+	var after db.ListAuditEventsAfterParams
+	mockQueries.ListAuditEventsAfterFunc = func(ctx context.Context, p db.ListAuditEventsAfterParams) ([]db.AuditEvent, error) {
+		after = p
+		return []db.AuditEvent{}, nil
+	}
+	if _, err := service.ListAuditEvents(asAdmin(), PageRequest{Cursor: page.NextCursor}, AuditEventFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.AfterID != 8 {
+		t.Errorf("expected the next page to continue after event 8, got %d", after.AfterID)
+	}
+}
+
+func TestListAuditEvents_RejectsEmptyTimeRange(t *testing.T) {
+	now := time.Now()
+	service := NewAuditService(&MockQueries{})
+
+	_, err := service.ListAuditEvents(asAdmin(), PageRequest{}, AuditEventFilter{From: now, To: now})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
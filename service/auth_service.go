@@ -0,0 +1,432 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/oauth"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrInvalidCredentials is returned when an email and password do not
+	// match an account; it deliberately does not say which of the two was wrong
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown,
+	// expired, or has already been used or revoked
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	
+	// ErrUnverifiedEmail is returned when an OAuth provider account has no
+	// verified email to create or link a local user with
+	ErrUnverifiedEmail = errors.New("provider account has no verified email")
+	
+	// ErrAccountDeleted is returned when an OAuth login resolves to a user
+	// that has been soft-deleted
+	ErrAccountDeleted = errors.New("account has been deleted")
+)
+
+// dummyPasswordHash is compared against when an email has no credentials, so
+// a login for an unknown account takes as long as one with a wrong password
+const dummyPasswordHash = "$2a$10$.KFSfxxNINpseTm6b8K6rurpHhITHbsDSxfYhw7tzvRAjqsMEmXau"
+
+// defaultRefreshTokenTTL is used when no refresh token lifetime is configured
+const defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// AuthService handles business logic for authenticating users
+type AuthService struct {
+	queries    db.Store
+	signer     *auth.Signer
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+// Token is the pair of tokens issued by a successful login or refresh
+type Token struct {
+	AccessToken  string
+	ExpiresIn    time.Duration
+	RefreshToken string
+}
+
+// AuthOption configures optional AuthService behavior
+type AuthOption func(*AuthService)
+
+// WithRefreshTokenTTL sets how long a refresh token stays usable
+func WithRefreshTokenTTL(ttl time.Duration) AuthOption {
+	return func(s *AuthService) {
+		if ttl > 0 {
+			s.refreshTTL = ttl
+		}
+	}
+}
+
+// NewAuthService creates a new AuthService that signs tokens with signer
+func NewAuthService(queries db.Store, signer *auth.Signer, opts ...AuthOption) *AuthService {
+	s := &AuthService{
+		queries:    queries,
+		signer:     signer,
+		refreshTTL: defaultRefreshTokenTTL,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Login checks an email and password and issues an access token along with
+// a refresh token that starts a new token family
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - email: The account's email address
+//   - password: The account's password
+//
+// Returns:
+//   - *Token: A signed access token and a refresh token for the user
+//   - error: ErrInvalidCredentials, or database errors
+func (s *AuthService) Login(ctx context.Context, email, password string) (*Token, error) {
+	creds, err := s.queries.GetCredentialsByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	
+	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	
+	return s.issue(ctx, s.queries, creds.ID, pgtype.UUID{Bytes: uuid.New(), Valid: true})
+}
+
+// LoginWithIdentity logs in the local user linked to an OAuth provider
+// account, linking or creating one on first login
+//
+// A provider account not seen before is linked to the user with the same
+// email, so someone who registered with a password can start logging in
+// through Twitch or Discord. Only emails the provider has verified are
+// trusted for this; otherwise anyone could claim an account by setting its
+// email at the provider. The user must have verified the email too, or
+// whoever registered it with a password, without owning it, would keep that
+// password to the account once its owner linked a provider to it. When no
+// user has the email, a new one is created.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - identity: The provider account the user authorized
+//
+// Returns:
+//   - *Token: A signed access token and a refresh token for the user
+//   - error: ErrUnverifiedEmail, ErrEmailNotVerified if the user with the
+//     email hasn't verified it, ErrAccountDeleted, or database errors
+func (s *AuthService) LoginWithIdentity(ctx context.Context, identity oauth.Identity) (*Token, error) {
+	userID, err := s.resolveIdentity(ctx, identity)
+	if isUniqueViolation(err) {
+		// A concurrent login for the same provider account or email linked
+		// it first; resolving again finds their row
+		userID, err = s.resolveIdentity(ctx, identity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.issue(ctx, s.queries, userID, pgtype.UUID{Bytes: uuid.New(), Valid: true})
+}
+
+// resolveIdentity finds, links, or creates the user for a provider account
+func (s *AuthService) resolveIdentity(ctx context.Context, identity oauth.Identity) (int32, error) {
+	linked, err := s.queries.GetUserIdentity(ctx, db.GetUserIdentityParams{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+	})
+	if err == nil {
+		user, err := s.queries.GetUserByIDIncludingDeleted(ctx, linked.UserID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.DeletedAt.Valid {
+			return 0, ErrAccountDeleted
+		}
+		return user.ID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get identity: %w", err)
+	}
+	
+	email := strings.TrimSpace(identity.Email)
+	if email == "" || !identity.EmailVerified {
+		return 0, ErrUnverifiedEmail
+	}
+	
+	user, err := s.queries.GetUserByEmail(ctx, email)
+	if err == nil {
+		if user.DeletedAt.Valid {
+			return 0, ErrAccountDeleted
+		}
+		if !user.EmailVerifiedAt.Valid {
+			return 0, ErrEmailNotVerified
+		}
+		_, err := s.queries.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+			UserID:   user.ID,
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to link identity: %w", err)
+		}
+		return user.ID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		user, err = q.CreateUserWithIdentity(ctx, db.CreateUserWithIdentityParams{
+			Name:     name,
+			Email:    email,
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, q, "user.register", AuditEntityUser, user.ID, nil, user); err != nil {
+			return err
+		}
+		return publishEvent(ctx, q, EventUserCreated, newEventUser(user))
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create user: %w", err)
+	}
+	return user.ID, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token, revoking the one presented
+//
+// Every refresh token can be used once. Presenting one that was already used
+// means it was copied, so the whole family descended from that login is
+// revoked and both the attacker and the legitimate client must log in again.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - refreshToken: The refresh token from the last login or refresh
+//
+// Returns:
+//   - *Token: A new access token and refresh token
+//   - error: ErrInvalidRefreshToken, or database errors
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	stored, err := s.queries.GetRefreshTokenByHash(ctx, hashSecret(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	
+	if stored.RevokedAt.Valid {
+		slog.WarnContext(ctx, "Refresh token reuse detected; revoking token family", "token_user_id", stored.UserID)
+		return nil, s.revokeFamily(ctx, stored.FamilyID)
+	}
+	if !s.now().Before(stored.ExpiresAt.Time) {
+		return nil, ErrInvalidRefreshToken
+	}
+	
+	user, err := s.queries.GetUserByIDIncludingDeleted(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.DeletedAt.Valid {
+		return nil, s.revokeFamily(ctx, stored.FamilyID)
+	}
+	
+	// Revoke the old token and store its replacement together, so a failure
+	// in between can't leave the client with no usable refresh token
+	var token *Token
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		revoked, err := q.RevokeRefreshToken(ctx, stored.ID)
+		if err != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+		if revoked == 0 {
+			return nil
+		}
+		token, err = s.issue(ctx, q, stored.UserID, stored.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		// Another request rotated this token between our read and update
+		slog.WarnContext(ctx, "Concurrent refresh token use; revoking token family", "token_user_id", stored.UserID)
+		return nil, s.revokeFamily(ctx, stored.FamilyID)
+	}
+	return token, nil
+}
+
+// Logout revokes a refresh token and every token rotated from the same login
+// Unknown tokens are ignored so logging out twice is not an error.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - refreshToken: The refresh token to revoke
+//
+// Returns:
+//   - error: Database errors
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := s.queries.GetRefreshTokenByHash(ctx, hashSecret(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	
+	if err := s.queries.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserTokens signs a user out everywhere by revoking every refresh
+// token issued to them; only admins may revoke another user's tokens
+//
+// Access tokens already issued stay valid until they expire, which is at
+// most the access token TTL.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - userID: The user whose tokens are revoked
+//
+// Returns:
+//   - int64: How many tokens were revoked
+//   - error: ErrForbidden or database errors
+func (s *AuthService) RevokeUserTokens(ctx context.Context, userID int32) (int64, error) {
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return 0, err
+	}
+	
+	revoked, err := s.queries.RevokeUserRefreshTokens(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return revoked, nil
+}
+
+// Principal loads the roles granted to a user, for attaching to a request
+// once its access token has been verified
+//
+// Roles are read on every request rather than baked into the token, so
+// revoking a role takes effect immediately instead of when the token expires.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: The user the access token was issued to
+//
+// Returns:
+//   - auth.Principal: The user and their grants
+//   - error: Database errors
+func (s *AuthService) Principal(ctx context.Context, userID int32) (auth.Principal, error) {
+	return loadPrincipal(ctx, s.queries, userID)
+}
+
+// loadPrincipal builds the principal for userID from their granted roles and
+// their places on games' moderation teams
+func loadPrincipal(ctx context.Context, queries db.Querier, userID int32) (auth.Principal, error) {
+	roles, err := queries.ListUserRoles(ctx, userID)
+	if err != nil {
+		return auth.Principal{}, fmt.Errorf("failed to list roles: %w", err)
+	}
+	moderated, err := queries.ListGameModeratorsByUser(ctx, userID)
+	if err != nil {
+		return auth.Principal{}, fmt.Errorf("failed to list moderated games: %w", err)
+	}
+	
+	principal := auth.Principal{UserID: userID}
+	for _, role := range roles {
+		principal.Grants = append(principal.Grants, auth.Grant{
+			Role:   auth.Role(role.Role),
+			GameID: role.GameID.Int32,
+		})
+	}
+	for _, moderator := range moderated {
+		principal.Grants = append(principal.Grants, auth.Grant{
+			Role:   auth.Role(moderator.Level),
+			GameID: moderator.GameID,
+		})
+	}
+	return principal, nil
+}
+
+// issue creates an access token and a refresh token in the given family,
+// storing the refresh token through q
+func (s *AuthService) issue(ctx context.Context, q db.Querier, userID int32, familyID pgtype.UUID) (*Token, error) {
+	accessToken, err := s.signer.Issue(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue token: %w", err)
+	}
+	
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(secret)
+	
+	_, err = q.CreateRefreshToken(ctx, db.CreateRefreshTokenParams{
+		UserID:    userID,
+		TokenHash: hashSecret(refreshToken),
+		FamilyID:  familyID,
+		ExpiresAt: pgtype.Timestamptz{Time: s.now().Add(s.refreshTTL), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	
+	return &Token{
+		AccessToken:  accessToken,
+		ExpiresIn:    s.signer.TTL(),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// revokeFamily revokes every token in a family and reports the refresh as
+// invalid
+func (s *AuthService) revokeFamily(ctx context.Context, familyID pgtype.UUID) error {
+	if err := s.queries.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return ErrInvalidRefreshToken
+}
+
+// hashSecret returns the form a refresh token or API key is stored in. Both
+// are random, so a fast unsalted hash is enough to keep a database leak from
+// exposing usable credentials.
+func hashSecret(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,493 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/oauth"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (m *MockQueries) GetCredentialsByEmail(ctx context.Context, email string) (db.GetCredentialsByEmailRow, error) {
+	if m.GetCredentialsByEmailFunc != nil {
+		return m.GetCredentialsByEmailFunc(ctx, email)
+	}
+	return db.GetCredentialsByEmailRow{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) CreateRefreshToken(ctx context.Context, params db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+	if m.CreateRefreshTokenFunc != nil {
+		return m.CreateRefreshTokenFunc(ctx, params)
+	}
+	return db.RefreshToken{}, nil
+}
+
+func (m *MockQueries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (db.RefreshToken, error) {
+	if m.GetRefreshTokenByHashFunc != nil {
+		return m.GetRefreshTokenByHashFunc(ctx, tokenHash)
+	}
+	return db.RefreshToken{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) RevokeRefreshToken(ctx context.Context, id int32) (int64, error) {
+	if m.RevokeRefreshTokenFunc != nil {
+		return m.RevokeRefreshTokenFunc(ctx, id)
+	}
+	return 1, nil
+}
+
+func (m *MockQueries) RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error {
+	if m.RevokeRefreshTokenFamilyFunc != nil {
+		return m.RevokeRefreshTokenFamilyFunc(ctx, familyID)
+	}
+	return nil
+}
+
+func (m *MockQueries) RevokeUserRefreshTokens(ctx context.Context, userID int32) (int64, error) {
+	if m.RevokeUserRefreshTokensFunc != nil {
+		return m.RevokeUserRefreshTokensFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) DeleteExpiredRefreshTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error) {
+	if m.DeleteExpiredRefreshTokensFunc != nil {
+		return m.DeleteExpiredRefreshTokensFunc(ctx, expiresAt)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) GetUserIdentity(ctx context.Context, params db.GetUserIdentityParams) (db.UserIdentity, error) {
+	if m.GetUserIdentityFunc != nil {
+		return m.GetUserIdentityFunc(ctx, params)
+	}
+	return db.UserIdentity{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) CreateUserIdentity(ctx context.Context, params db.CreateUserIdentityParams) (db.UserIdentity, error) {
+	if m.CreateUserIdentityFunc != nil {
+		return m.CreateUserIdentityFunc(ctx, params)
+	}
+	return db.UserIdentity{}, nil
+}
+
+func (m *MockQueries) CreateUserWithIdentity(ctx context.Context, params db.CreateUserWithIdentityParams) (db.User, error) {
+	if m.CreateUserWithIdentityFunc != nil {
+		return m.CreateUserWithIdentityFunc(ctx, params)
+	}
+	return db.User{}, nil
+}
+
+// refreshTokenStore is an in-memory refresh_tokens table wired into a
+// MockQueries, so rotation and revocation can be tested end to end
+type refreshTokenStore struct {
+	tokens []db.RefreshToken
+}
+
+func (r *refreshTokenStore) install(m *MockQueries) {
+	m.CreateRefreshTokenFunc = func(ctx context.Context, p db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+		token := db.RefreshToken{
+			ID:        int32(len(r.tokens) + 1),
+			UserID:    p.UserID,
+			TokenHash: p.TokenHash,
+			FamilyID:  p.FamilyID,
+			ExpiresAt: p.ExpiresAt,
+		}
+		r.tokens = append(r.tokens, token)
+		return token, nil
+	}
+	m.GetRefreshTokenByHashFunc = func(ctx context.Context, tokenHash string) (db.RefreshToken, error) {
+		for _, token := range r.tokens {
+			if token.TokenHash == tokenHash {
+				return token, nil
+			}
+		}
+		return db.RefreshToken{}, sql.ErrNoRows
+	}
+	m.RevokeRefreshTokenFunc = func(ctx context.Context, id int32) (int64, error) {
+		token := &r.tokens[id-1]
+		if token.RevokedAt.Valid {
+			return 0, nil
+		}
+		token.RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		return 1, nil
+	}
+	m.RevokeRefreshTokenFamilyFunc = func(ctx context.Context, familyID pgtype.UUID) error {
+		for i := range r.tokens {
+			if r.tokens[i].FamilyID == familyID && !r.tokens[i].RevokedAt.Valid {
+				r.tokens[i].RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+			}
+		}
+		return nil
+	}
+	m.GetUserByIDFunc = func(ctx context.Context, id int32) (db.User, error) {
+		return db.User{ID: id}, nil
+	}
+	m.WithTxFunc = func(ctx context.Context, fn func(q db.Querier) error) error {
+		snapshot := slices.Clone(r.tokens)
+		err := fn(m)
+		if err != nil {
+			r.tokens = snapshot
+		}
+		return err
+	}
+}
+
+func newTestAuthService(t *testing.T, password string) (*AuthService, *auth.Signer) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	mockQueries := &MockQueries{
+		GetCredentialsByEmailFunc: func(ctx context.Context, email string) (db.GetCredentialsByEmailRow, error) {
+			if email != "john@example.com" {
+				return db.GetCredentialsByEmailRow{}, sql.ErrNoRows
+			}
+			return db.GetCredentialsByEmailRow{ID: 7, PasswordHash: string(hash)}, nil
+		},
+	}
+	(&refreshTokenStore{}).install(mockQueries)
+	signer := auth.NewSigner([]byte("test-secret"), time.Hour)
+	return NewAuthService(mockQueries, signer), signer
+}
+
+func TestLogin(t *testing.T) {
+	service, signer := newTestAuthService(t, "hunter22")
+
+	token, err := service.Login(context.Background(), " john@example.com ", "hunter22")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if token.ExpiresIn != time.Hour {
+		t.Errorf("expected 1h expiry, got %s", token.ExpiresIn)
+	}
+
+	principal, err := signer.Verify(token.AccessToken)
+	if err != nil {
+		t.Fatalf("expected issued token to verify, got %v", err)
+	}
+	if principal.UserID != 7 {
+		t.Errorf("expected user 7, got %d", principal.UserID)
+	}
+}
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	service, _ := newTestAuthService(t, "hunter22")
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+	}{
+		{name: "wrong password", email: "john@example.com", password: "hunter23"},
+		{name: "unknown email", email: "jane@example.com", password: "hunter22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Login(context.Background(), tt.email, tt.password)
+			if !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("expected ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	service, _ := newTestAuthService(t, "hunter22")
+	login, err := service.Login(context.Background(), "john@example.com", "hunter22")
+	if err != nil {
+		t.Fatalf("expected login to succeed, got %v", err)
+	}
+
+	refreshed, err := service.Refresh(context.Background(), login.RefreshToken)
+	if err != nil {
+		t.Fatalf("expected refresh to succeed, got %v", err)
+	}
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Error("expected a new refresh token")
+	}
+
+	if _, err := service.Refresh(context.Background(), refreshed.RefreshToken); err != nil {
+		t.Errorf("expected the rotated token to be usable, got %v", err)
+	}
+}
+
+func TestRefresh_FailedRotationKeepsOldToken(t *testing.T) {
+	service, _ := newTestAuthService(t, "hunter22")
+	login, err := service.Login(context.Background(), "john@example.com", "hunter22")
+	if err != nil {
+		t.Fatalf("expected login to succeed, got %v", err)
+	}
+
+	// Storing the replacement fails after the old token has been revoked
+	mockQueries := service.queries.(*MockQueries)
+	create := mockQueries.CreateRefreshTokenFunc
+	mockQueries.CreateRefreshTokenFunc = func(ctx context.Context, p db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+		return db.RefreshToken{}, errors.New("connection reset")
+	}
+	if _, err := service.Refresh(context.Background(), login.RefreshToken); err == nil {
+		t.Fatal("expected the refresh to fail")
+	}
+
+	mockQueries.CreateRefreshTokenFunc = create
+	if _, err := service.Refresh(context.Background(), login.RefreshToken); err != nil {
+		t.Errorf("expected the old token to survive the rolled-back rotation, got %v", err)
+	}
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	service, _ := newTestAuthService(t, "hunter22")
+	login, _ := service.Login(context.Background(), "john@example.com", "hunter22")
+	refreshed, _ := service.Refresh(context.Background(), login.RefreshToken)
+
+	// Replaying the first token means it leaked, so the newer one must die too
+	if _, err := service.Refresh(context.Background(), login.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected ErrInvalidRefreshToken on reuse, got %v", err)
+	}
+	if _, err := service.Refresh(context.Background(), refreshed.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expected the family to be revoked after reuse, got %v", err)
+	}
+}
+
+func TestRefresh_Expired(t *testing.T) {
+	service, _ := newTestAuthService(t, "hunter22")
+	login, _ := service.Login(context.Background(), "john@example.com", "hunter22")
+
+	service.now = func() time.Time { return time.Now().Add(defaultRefreshTokenTTL) }
+	if _, err := service.Refresh(context.Background(), login.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expected ErrInvalidRefreshToken, got %v", err)
+	}
+}
+
+func TestLogout_RevokesFamily(t *testing.T) {
+	service, _ := newTestAuthService(t, "hunter22")
+	login, _ := service.Login(context.Background(), "john@example.com", "hunter22")
+	refreshed, _ := service.Refresh(context.Background(), login.RefreshToken)
+
+	if err := service.Logout(context.Background(), refreshed.RefreshToken); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := service.Refresh(context.Background(), refreshed.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expected ErrInvalidRefreshToken after logout, got %v", err)
+	}
+	if err := service.Logout(context.Background(), "unknown"); err != nil {
+		t.Errorf("expected logging out an unknown token to succeed, got %v", err)
+	}
+}
+
+func TestPrincipal_LoadsGrants(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListUserRolesFunc: func(ctx context.Context, userID int32) ([]db.UserRole, error) {
+			return []db.UserRole{
+				{UserID: userID, Role: "admin"},
+				{UserID: userID, Role: "moderator", GameID: pgtype.Int4{Int32: 4, Valid: true}},
+			}, nil
+		},
+		ListGameModeratorsByUserFunc: func(ctx context.Context, userID int32) ([]db.GameModerator, error) {
+			return []db.GameModerator{{GameID: 9, UserID: userID, Level: "super_moderator"}}, nil
+		},
+	}
+
+	service := NewAuthService(mockQueries, auth.NewSigner([]byte("test-secret"), time.Hour))
+	principal, err := service.Principal(context.Background(), 7)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []auth.Grant{
+		{Role: auth.RoleAdmin},
+		{Role: auth.RoleModerator, GameID: 4},
+		{Role: auth.RoleSuperModerator, GameID: 9},
+	}
+	if principal.UserID != 7 || !slices.Equal(principal.Grants, expected) {
+		t.Errorf("expected grants %+v for user 7, got %+v", expected, principal)
+	}
+}
+
+// twitchIdentity is a provider account with a verified email
+var twitchIdentity = oauth.Identity{
+	Provider:      "twitch",
+	Subject:       "141981764",
+	Name:          "TwitchDev",
+	Email:         "dev@example.com",
+	EmailVerified: true,
+}
+
+func newOAuthTestService(m *MockQueries) *AuthService {
+	(&refreshTokenStore{}).install(m)
+	return NewAuthService(m, auth.NewSigner([]byte("test-secret"), time.Hour))
+}
+
+func TestLoginWithIdentity_Linked(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserIdentityFunc: func(ctx context.Context, params db.GetUserIdentityParams) (db.UserIdentity, error) {
+			if params.Provider != "twitch" || params.Subject != "141981764" {
+				t.Errorf("unexpected identity lookup %+v", params)
+			}
+			return db.UserIdentity{UserID: 9}, nil
+		},
+		CreateUserWithIdentityFunc: func(ctx context.Context, params db.CreateUserWithIdentityParams) (db.User, error) {
+			t.Fatal("CreateUserWithIdentity should not be called")
+			return db.User{}, nil
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+
+	token, err := service.LoginWithIdentity(context.Background(), twitchIdentity)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if token.AccessToken == "" || token.RefreshToken == "" {
+		t.Errorf("expected both tokens, got %+v", token)
+	}
+}
+
+func TestLoginWithIdentity_LinksByVerifiedEmail(t *testing.T) {
+	var linked db.CreateUserIdentityParams
+	mockQueries := &MockQueries{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{ID: 4, Email: email, EmailVerifiedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}}, nil
+		},
+		CreateUserIdentityFunc: func(ctx context.Context, params db.CreateUserIdentityParams) (db.UserIdentity, error) {
+			linked = params
+			return db.UserIdentity{UserID: params.UserID}, nil
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+
+	if _, err := service.LoginWithIdentity(context.Background(), twitchIdentity); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if linked.UserID != 4 || linked.Provider != "twitch" || linked.Subject != "141981764" {
+		t.Errorf("expected identity linked to user 4, got %+v", linked)
+	}
+}
+
+func TestLoginWithIdentity_RefusesUserWithUnverifiedEmail(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{ID: 4, Email: email}, nil
+		},
+		CreateUserIdentityFunc: func(ctx context.Context, params db.CreateUserIdentityParams) (db.UserIdentity, error) {
+			t.Error("expected the identity not to be linked to a user who hasn't verified the email")
+			return db.UserIdentity{}, nil
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+
+	if _, err := service.LoginWithIdentity(context.Background(), twitchIdentity); !errors.Is(err, ErrEmailNotVerified) {
+		t.Errorf("expected ErrEmailNotVerified, got %v", err)
+	}
+}
+
+func TestLoginWithIdentity_CreatesUser(t *testing.T) {
+	var created db.CreateUserWithIdentityParams
+	mockQueries := &MockQueries{
+		CreateUserWithIdentityFunc: func(ctx context.Context, params db.CreateUserWithIdentityParams) (db.User, error) {
+			created = params
+			return db.User{ID: 11, Name: params.Name, Email: params.Email}, nil
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+
+	if _, err := service.LoginWithIdentity(context.Background(), twitchIdentity); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := db.CreateUserWithIdentityParams{Name: "TwitchDev", Email: "dev@example.com", Provider: "twitch", Subject: "141981764"}
+	if created != expected {
+		t.Errorf("expected %+v, got %+v", expected, created)
+	}
+}
+
+func TestLoginWithIdentity_UnverifiedEmail(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			t.Fatal("an unverified email must not be used to find a user")
+			return db.User{}, nil
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+
+	identity := twitchIdentity
+	identity.EmailVerified = false
+	_, err := service.LoginWithIdentity(context.Background(), identity)
+
+	if !errors.Is(err, ErrUnverifiedEmail) {
+		t.Errorf("expected ErrUnverifiedEmail, got %v", err)
+	}
+}
+
+func TestLoginWithIdentity_DeletedUser(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserIdentityFunc: func(ctx context.Context, params db.GetUserIdentityParams) (db.UserIdentity, error) {
+			return db.UserIdentity{UserID: 9}, nil
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+	mockQueries.GetUserByIDFunc = func(ctx context.Context, id int32) (db.User, error) {
+		return db.User{ID: id, DeletedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}}, nil
+	}
+
+	_, err := service.LoginWithIdentity(context.Background(), twitchIdentity)
+
+	if !errors.Is(err, ErrAccountDeleted) {
+		t.Errorf("expected ErrAccountDeleted, got %v", err)
+	}
+}
+
+func TestLoginWithIdentity_ConcurrentFirstLogin(t *testing.T) {
+	lookups := 0
+	mockQueries := &MockQueries{
+		GetUserIdentityFunc: func(ctx context.Context, params db.GetUserIdentityParams) (db.UserIdentity, error) {
+			lookups++
+			if lookups == 1 {
+				return db.UserIdentity{}, sql.ErrNoRows
+			}
+			return db.UserIdentity{UserID: 12}, nil
+		},
+		CreateUserWithIdentityFunc: func(ctx context.Context, params db.CreateUserWithIdentityParams) (db.User, error) {
+			return db.User{}, &pgconn.PgError{Code: "23505", ConstraintName: "user_identities_provider_subject_key"}
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+
+	if _, err := service.LoginWithIdentity(context.Background(), twitchIdentity); err != nil {
+		t.Fatalf("expected the retry to find the winning login, got %v", err)
+	}
+	if lookups != 2 {
+		t.Errorf("expected 2 identity lookups, got %d", lookups)
+	}
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	var revokedFor int32
+	mockQueries := &MockQueries{
+		RevokeUserRefreshTokensFunc: func(ctx context.Context, userID int32) (int64, error) {
+			revokedFor = userID
+			return 3, nil
+		},
+	}
+	service := newOAuthTestService(mockQueries)
+
+	revoked, err := service.RevokeUserTokens(asAdmin(), 12)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if revoked != 3 || revokedFor != 12 {
+		t.Errorf("expected 3 tokens of user 12 revoked, got %d of user %d", revoked, revokedFor)
+	}
+	if _, err := service.RevokeUserTokens(asUser(12), 12); !errors.Is(err, ErrForbidden) {
+		t.Errorf("non-admin: expected ErrForbidden, got %v", err)
+	}
+}
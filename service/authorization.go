@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/example/speedrun-rest-api/auth"
+)
+
+// ErrForbidden is returned when the caller in the context is not allowed to
+// perform an operation; a context without a caller is always forbidden
+var ErrForbidden = errors.New("forbidden")
+
+// requireSelfOrAdmin allows the user identified by userID and admins
+func requireSelfOrAdmin(ctx context.Context, userID int32) error {
+	p, ok := actingPrincipal(ctx, "")
+	if !ok || (p.UserID != userID && !p.HasRole(auth.RoleAdmin)) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// CanSeeEmail reports whether the caller of ctx may see the email address of
+// the user identified by userID, as only that user and admins may
+func CanSeeEmail(ctx context.Context, userID int32) bool {
+	return requireSelfOrAdmin(ctx, userID) == nil
+}
+
+// requireAccessToken allows any caller that logged in, as opposed to one
+// using an API key, and returns their user ID
+func requireAccessToken(ctx context.Context) (int32, error) {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok || p.ViaAPIKey() {
+		return 0, ErrForbidden
+	}
+	return p.UserID, nil
+}
+
+// requireRole allows callers holding role for every game
+func requireRole(ctx context.Context, role auth.Role) error {
+	p, ok := actingPrincipal(ctx, "")
+	if !ok || !p.HasRole(role) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// requireGameModerator allows callers that may moderate runs for gameID
+func requireGameModerator(ctx context.Context, gameID int32) error {
+	p, ok := actingPrincipal(ctx, ScopeRunsModerate)
+	if !ok || !p.CanModerate(gameID) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// requireGameManager allows callers that may edit gameID, as admins and the
+// game's super moderators may
+func requireGameManager(ctx context.Context, gameID int32) error {
+	p, ok := actingPrincipal(ctx, ScopeGamesWrite)
+	if !ok || !p.CanManageGame(gameID) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// actingPrincipal returns the caller of ctx when their roles may be used
+// for what scope covers
+// An API key carries its owner's roles but may only act with them under a
+// scope it holds, so a narrowly scoped key can't pass every check its owner
+// would. The empty scope stands for checks no scope covers, which API keys
+// never pass.
+func actingPrincipal(ctx context.Context, scope string) (auth.Principal, bool) {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok || (p.ViaAPIKey() && (scope == "" || !p.HasScope(scope))) {
+		return auth.Principal{}, false
+	}
+	return p, true
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrAvatarsDisabled is returned when uploading an avatar while no
+	// avatar storage is configured
+	ErrAvatarsDisabled = errors.New("avatar uploads are disabled")
+)
+
+const (
+	// defaultAvatarSize is the width and height avatars are resized to when
+	// no size is configured
+	defaultAvatarSize = 256
+	
+	// maxAvatarPixels bounds the images accepted as avatars, so a small file
+	// that decodes to a huge image cannot exhaust memory
+	maxAvatarPixels = 4096 * 4096
+	
+	// avatarCacheControl lets browsers and CDNs keep avatars for good; each
+	// upload is stored under a new key, so a cached copy is never stale
+	avatarCacheControl = "public, max-age=31536000, immutable"
+)
+
+// SetAvatar replaces the caller's avatar with an uploaded image
+//
+// JPEG, PNG, and GIF images are accepted. The image is cropped to a centered
+// square, resized, and stored as a PNG named after its content; the previous
+// avatar, if any, is then removed from storage.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - upload: The image file
+//
+// Returns:
+//   - *db.User: The caller, with the URL of their new avatar
+//   - error: ErrForbidden, ErrAvatarsDisabled, ErrInvalidInput if the file is
+//     not a supported image, ErrUserNotFound, or storage and database errors
+func (s *UserService) SetAvatar(ctx context.Context, upload io.Reader) (*db.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetAvatar")
+	defer span.End()
+	
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.avatars == nil {
+		return nil, ErrAvatarsDisabled
+	}
+	
+	data, err := processAvatar(upload, s.avatarSize)
+	if err != nil {
+		return nil, err
+	}
+	
+	existing, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	key := avatarKey(existing.PublicID, data)
+	err = s.avatars.Put(ctx, storage.Object{
+		Key:          key,
+		ContentType:  "image/png",
+		CacheControl: avatarCacheControl,
+		Data:         data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store avatar: %w", err)
+	}
+	
+	var user db.User
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		user, err = q.SetUserAvatar(ctx, db.SetUserAvatarParams{
+			AvatarUrl: pgtype.Text{String: s.avatars.URL(key), Valid: true},
+			ID:        userID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to set avatar: %w", err)
+		}
+		return recordAudit(ctx, q, "user.set_avatar", AuditEntityUser, userID, existing, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	s.cache.Delete(ctx, userCacheKey(userID))
+	if existing.AvatarUrl.Valid && existing.AvatarUrl.String != user.AvatarUrl.String {
+		s.deleteAvatar(ctx, avatarKeyFromURL(existing.PublicID, existing.AvatarUrl.String))
+	}
+	return &user, nil
+}
+
+// deleteAvatar removes a replaced avatar from storage
+// Failures are only logged: the user already points at the new avatar, and
+// a leftover file does no harm beyond the space it takes.
+func (s *UserService) deleteAvatar(ctx context.Context, key string) {
+	if err := s.avatars.Delete(ctx, key); err != nil {
+		slog.WarnContext(ctx, "Failed to delete replaced avatar", "key", key, "error", err)
+	}
+}
+
+// avatarKey names the stored avatar of the user with publicID after a digest
+// of its content, so every upload gets a URL of its own
+func avatarKey(publicID pgtype.UUID, data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("avatars/%s/%x.png", uuid.UUID(publicID.Bytes), sum[:8])
+}
+
+// avatarKeyFromURL recovers the key an avatar of the user with publicID was
+// stored under from the URL it is served from
+func avatarKeyFromURL(publicID pgtype.UUID, url string) string {
+	return fmt.Sprintf("avatars/%s/%s", uuid.UUID(publicID.Bytes), path.Base(url))
+}
+
+// processAvatar decodes an uploaded image, crops it to a centered square,
+// and resizes it to size by size pixels
+//
+// Returns:
+//   - []byte: The avatar encoded as PNG
+//   - error: ErrInvalidInput if the upload is not a supported image or is too
+//     large once decoded
+func processAvatar(upload io.Reader, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	config, format, err := image.DecodeConfig(io.TeeReader(upload, &buf))
+	if err != nil {
+		return nil, invalidField("avatar", "must be a JPEG, PNG, or GIF image")
+	}
+	if config.Width*config.Height > maxAvatarPixels {
+		return nil, invalidField("avatar", "must be at most %d pixels", maxAvatarPixels)
+	}
+	
+	img, _, err := image.Decode(io.MultiReader(&buf, upload))
+	if err != nil {
+		return nil, invalidField("avatar", "is not a valid %s image", strings.ToUpper(format))
+	}
+	
+	var out bytes.Buffer
+	if err := png.Encode(&out, resizeAvatar(img, size)); err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// resizeAvatar crops src to its largest centered square and scales it to
+// size by size pixels, averaging the source pixels behind each one
+func resizeAvatar(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+	
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		sy0, sy1 := avatarSpan(y0, side, size, y)
+		for x := 0; x < size; x++ {
+			sx0, sx1 := avatarSpan(x0, side, size, x)
+			
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// avatarSpan returns the source pixels, starting at origin, behind pixel i
+// of a side-long source scaled to size; at least one pixel, so images
+// smaller than size are enlarged
+func avatarSpan(origin, side, size, i int) (int, int) {
+	start := origin + i*side/size
+	end := origin + (i+1)*side/size
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/example/speedrun-rest-api/cache"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// Names of the service caches, which label their hit and miss counts
+const (
+	cacheUsers        = "users"
+	cacheGames        = "games"
+	cacheLeaderboards = "leaderboards"
+	
+	cacheGameSuggestions = "game_suggestions"
+)
+
+// leaderboardRunnersKey is the version shared by every leaderboard page; it
+// is bumped when a runner is renamed, deleted, or restored, since any
+// leaderboard might list them
+const leaderboardRunnersKey = "leaderboards:runners"
+
+// userCacheKey is where GetUserByID caches a user
+func userCacheKey(id int32) string {
+	return "user:" + strconv.Itoa(int(id))
+}
+
+// gameCacheKey is where GetGameBySlug caches a game
+func gameCacheKey(slug string) string {
+	return "game:" + slug
+}
+
+// gameSuggestionsVersionKey is the version of every cached list of game
+// suggestions; it is bumped when a game is created, updated, or deleted
+const gameSuggestionsVersionKey = "games:suggestions"
+
+// gameSuggestionsCacheKey is where SuggestGames caches the suggestions for a
+// normalized query under the given version
+func gameSuggestionsCacheKey(version string, limit int32, query string) string {
+	return fmt.Sprintf("games:suggest:%s:%d:%s", version, limit, query)
+}
+
+// leaderboardVersionKey is the version of a category's leaderboard pages;
+// it is bumped when a run in the category is verified
+func leaderboardVersionKey(categoryID int32) string {
+	return "leaderboard:" + strconv.Itoa(int(categoryID))
+}
+
+// cacheFillContext returns the context to load an entry of c with: when
+// caching is enabled entries are read from the primary, since rows a lagging
+// replica returns would stay cached after the invalidation meant to drop them
+func cacheFillContext(ctx context.Context, c *cache.Cache) context.Context {
+	if c == nil {
+		return ctx
+	}
+	return db.WithPrimary(ctx)
+}
+
+// leaderboardCacheKey is where Leaderboard and LevelLeaderboard cache a page
+// of a category's leaderboard; ranked identifies which of the category's runs
+// the page ranks, such as those of one level or platform
+//
+// The key includes the category's and the runners' versions, so bumping
+// either invalidates every page at once. ok is false if the versions could
+// not be read, in which case the page must not be cached.
+func (s *RunService) leaderboardCacheKey(ctx context.Context, categoryID int32, ranked string, limit, offset int32, cursor string) (key string, ok bool) {
+	category, ok := s.cache.Version(ctx, leaderboardVersionKey(categoryID))
+	if !ok {
+		return "", false
+	}
+	runners, ok := s.cache.Version(ctx, leaderboardRunnersKey)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("leaderboard:%d:%s.%s:%s:%d:%d:%s", categoryID, category, runners, ranked, limit, offset, cursor), true
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrCategoryNotFound is returned when a game has no category with the given slug
+	ErrCategoryNotFound = errors.New("category not found")
+	
+	// ErrDuplicateCategorySlug is returned when a game already has a category with the slug
+	ErrDuplicateCategorySlug = errors.New("category with this slug already exists")
+)
+
+const (
+	// categoriesGameSlugKey is the unique constraint on (categories.game_id, categories.slug)
+	categoriesGameSlugKey = "categories_game_id_slug_key"
+	
+	// maxRulesLength keeps category rules to a readable size
+	maxRulesLength = 10000
+)
+
+// Timing methods a category can rank its runs by
+const (
+	// TimingMethodRTA is real time, from the first input to the last
+	TimingMethodRTA = "rta"
+	
+	// TimingMethodIGT is the time shown by the game itself
+	TimingMethodIGT = "igt"
+	
+	// TimingMethodLRT is real time with loading screens removed
+	TimingMethodLRT = "lrt"
+)
+
+// CategoryService handles business logic for the categories of a game
+type CategoryService struct {
+	queries db.Store
+}
+
+// CreateCategoryInput holds the details of a category being created
+type CreateCategoryInput struct {
+	Slug  string
+	Name  string
+	Rules string
+	
+	// Position is the display order; nil places the category after the
+	// game's existing categories
+	Position *int32
+	
+	// IsDefault makes this the game's default category, replacing any
+	// previous default
+	IsDefault bool
+	
+	// TimingMethod is the method the category's leaderboards rank runs by;
+	// empty means TimingMethodRTA
+	TimingMethod string
+}
+
+// NewCategoryService creates a new CategoryService instance
+func NewCategoryService(queries db.Store) *CategoryService {
+	return &CategoryService{queries: queries}
+}
+
+// ListCategories retrieves every category of a game in display order
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//
+// Returns:
+//   - []db.Category: The categories, ordered by position
+//   - error: ErrGameNotFound, or database errors
+func (s *CategoryService) ListCategories(ctx context.Context, gameSlug string) ([]db.Category, error) {
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	categories, err := s.queries.ListCategoriesByGame(ctx, game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	
+	return categories, nil
+}
+
+// GetCategory retrieves a category of a game by their slugs
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - categorySlug: Slug of the category within that game
+//
+// Returns:
+//   - *db.Category: The category
+//   - error: ErrCategoryNotFound if the game or category doesn't exist, or
+//     database errors
+func (s *CategoryService) GetCategory(ctx context.Context, gameSlug, categorySlug string) (*db.Category, error) {
+	category, err := s.queries.GetCategoryBySlug(ctx, db.GetCategoryBySlugParams{
+		GameSlug:     gameSlug,
+		CategorySlug: categorySlug,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	
+	return &category, nil
+}
+
+// GetCategoriesByIDs retrieves several categories with a single query, for
+// callers resolving the categories of many runs at once
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - ids: The category IDs to look up
+//
+// Returns:
+//   - []db.Category: Categories that were found, ordered by ID
+//   - error: Database errors
+func (s *CategoryService) GetCategoriesByIDs(ctx context.Context, ids []int32) ([]db.Category, error) {
+	categories, err := s.queries.GetCategoriesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	
+	return categories, nil
+}
+
+// ListCategoriesByGames retrieves the categories of several games with a
+// single query
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameIDs: IDs of the games
+//
+// Returns:
+//   - map[int32][]db.Category: Each game's categories in display order,
+//     keyed by game ID; games without any are left out
+//   - error: Database errors
+func (s *CategoryService) ListCategoriesByGames(ctx context.Context, gameIDs []int32) (map[int32][]db.Category, error) {
+	categories, err := s.queries.ListCategoriesByGames(ctx, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	
+	byGame := make(map[int32][]db.Category, len(gameIDs))
+	for _, category := range categories {
+		byGame[category.GameID] = append(byGame[category.GameID], category)
+	}
+	return byGame, nil
+}
+
+// CreateCategory adds a category to a game; only admins and the game's super
+// moderators may
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game the category belongs to
+//   - input: The category details
+//
+// Returns:
+//   - *db.Category: The created category
+//   - error: ErrInvalidInput, ErrGameNotFound, ErrForbidden,
+//     ErrDuplicateCategorySlug, or database errors
+func (s *CategoryService) CreateCategory(ctx context.Context, gameSlug string, input CreateCategoryInput) (*db.Category, error) {
+	if err := validateSlug(input.Slug); err != nil {
+		return nil, err
+	}
+	name, err := validateDisplayName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(input.Rules) > maxRulesLength {
+		return nil, invalidField("rules", "must be at most %d characters", maxRulesLength)
+	}
+	position := pgtype.Int4{}
+	if input.Position != nil {
+		if *input.Position < 0 {
+			return nil, invalidField("position", "must not be negative")
+		}
+		position = pgtype.Int4{Int32: *input.Position, Valid: true}
+	}
+	timingMethod := input.TimingMethod
+	if timingMethod == "" {
+		timingMethod = TimingMethodRTA
+	}
+	if !isTimingMethod(timingMethod) {
+		return nil, invalidField("timing_method", "must be one of %s, %s, or %s", TimingMethodRTA, TimingMethodIGT, TimingMethodLRT)
+	}
+	
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireGameManager(ctx, game.ID); err != nil {
+		return nil, err
+	}
+	
+	var category db.Category
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		category, err = q.CreateCategory(ctx, db.CreateCategoryParams{
+			GameID:       game.ID,
+			IsDefault:    input.IsDefault,
+			Slug:         input.Slug,
+			Name:         name,
+			Rules:        input.Rules,
+			Position:     position,
+			TimingMethod: timingMethod,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, q, "category.create", AuditEntityCategory, category.ID, nil, category); err != nil {
+			return err
+		}
+		return publishEvent(ctx, q, EventCategoryCreated, newEventCategory(category))
+	})
+	if err != nil {
+		if isDuplicateCategorySlugError(err) {
+			return nil, ErrDuplicateCategorySlug
+		}
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+	
+	return &category, nil
+}
+
+// getGame looks up the game that owns a set of categories
+func (s *CategoryService) getGame(ctx context.Context, slug string) (*db.Game, error) {
+	game, err := s.queries.GetGameBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	return &game, nil
+}
+
+// isTimingMethod reports whether method is one of the known timing methods
+func isTimingMethod(method string) bool {
+	switch method {
+	case TimingMethodRTA, TimingMethodIGT, TimingMethodLRT:
+		return true
+	}
+	return false
+}
+
+// isDuplicateCategorySlugError reports whether err is a unique violation on
+// a game's category slugs
+func isDuplicateCategorySlugError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == categoriesGameSlugKey
+}
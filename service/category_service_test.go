@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (m *MockQueries) GetCategoryBySlug(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+	if m.GetCategoryBySlugFunc != nil {
+		return m.GetCategoryBySlugFunc(ctx, params)
+	}
+	return db.Category{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetCategoryByID(ctx context.Context, id int32) (db.Category, error) {
+	if m.GetCategoryByIDFunc != nil {
+		return m.GetCategoryByIDFunc(ctx, id)
+	}
+	return db.Category{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetDefaultCategory(ctx context.Context, gameID int32) (db.Category, error) {
+	if m.GetDefaultCategoryFunc != nil {
+		return m.GetDefaultCategoryFunc(ctx, gameID)
+	}
+	return db.Category{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) ListCategoriesByGame(ctx context.Context, gameID int32) ([]db.Category, error) {
+	if m.ListCategoriesByGameFunc != nil {
+		return m.ListCategoriesByGameFunc(ctx, gameID)
+	}
+	return []db.Category{}, nil
+}
+
+func (m *MockQueries) GetCategoriesByIDs(ctx context.Context, ids []int32) ([]db.Category, error) {
+	if m.GetCategoriesByIDsFunc != nil {
+		return m.GetCategoriesByIDsFunc(ctx, ids)
+	}
+	return []db.Category{}, nil
+}
+
+func (m *MockQueries) ListCategoriesByGames(ctx context.Context, gameIds []int32) ([]db.Category, error) {
+	if m.ListCategoriesByGamesFunc != nil {
+		return m.ListCategoriesByGamesFunc(ctx, gameIds)
+	}
+	return []db.Category{}, nil
+}
+
+func (m *MockQueries) CreateCategory(ctx context.Context, params db.CreateCategoryParams) (db.Category, error) {
+	if m.CreateCategoryFunc != nil {
+		return m.CreateCategoryFunc(ctx, params)
+	}
+	return db.Category{}, nil
+}
+
+// gameLookup returns a GetGameBySlugFunc that finds a single game
+func gameLookup(game db.Game) func(ctx context.Context, slug string) (db.Game, error) {
+	return func(ctx context.Context, slug string) (db.Game, error) {
+		if slug != game.Slug {
+			return db.Game{}, sql.ErrNoRows
+		}
+		return game, nil
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		ListCategoriesByGameFunc: func(ctx context.Context, gameID int32) ([]db.Category, error) {
+			if gameID != 4 {
+				t.Errorf("expected game 4, got %d", gameID)
+			}
+			return []db.Category{{ID: 1, Slug: "120-star"}, {ID: 2, Slug: "16-star"}}, nil
+		},
+	}
+
+	service := NewCategoryService(mockQueries)
+	categories, err := service.ListCategories(context.Background(), "super-mario-64")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(categories) != 2 {
+		t.Errorf("expected 2 categories, got %d", len(categories))
+	}
+
+	if _, err := service.ListCategories(context.Background(), "missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestListCategoriesByGames(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListCategoriesByGamesFunc: func(ctx context.Context, gameIds []int32) ([]db.Category, error) {
+			return []db.Category{
+				{ID: 1, GameID: 4, Slug: "120-star"},
+				{ID: 2, GameID: 4, Slug: "16-star"},
+				{ID: 3, GameID: 7, Slug: "any"},
+			}, nil
+		},
+	}
+
+	service := NewCategoryService(mockQueries)
+	byGame, err := service.ListCategoriesByGames(context.Background(), []int32{4, 7, 9})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(byGame[4]) != 2 || byGame[4][0].Slug != "120-star" || byGame[4][1].Slug != "16-star" {
+		t.Errorf("expected game 4's categories in order, got %+v", byGame[4])
+	}
+	if len(byGame[7]) != 1 {
+		t.Errorf("expected 1 category for game 7, got %d", len(byGame[7]))
+	}
+	if _, ok := byGame[9]; ok {
+		t.Error("expected no entry for a game without categories")
+	}
+}
+
+func TestCreateCategory_Success(t *testing.T) {
+	var params db.CreateCategoryParams
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		CreateCategoryFunc: func(ctx context.Context, p db.CreateCategoryParams) (db.Category, error) {
+			params = p
+			return db.Category{ID: 1, GameID: p.GameID, Slug: p.Slug, Name: p.Name, IsDefault: p.IsDefault}, nil
+		},
+	}
+
+	service := NewCategoryService(mockQueries)
+	position := int32(2)
+	_, err := service.CreateCategory(asAdmin(), "super-mario-64", CreateCategoryInput{
+		Slug:      "120-star",
+		Name:      " 120 Star ",
+		Rules:     "Collect all 120 stars.",
+		Position:  &position,
+		IsDefault: true,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.GameID != 4 || params.Name != "120 Star" || !params.IsDefault || params.TimingMethod != TimingMethodRTA {
+		t.Errorf("unexpected insert params %+v", params)
+	}
+	if !params.Position.Valid || params.Position.Int32 != 2 {
+		t.Errorf("expected position 2, got %+v", params.Position)
+	}
+}
+
+func TestCreateCategory_DefaultsPositionToEnd(t *testing.T) {
+	var params db.CreateCategoryParams
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		CreateCategoryFunc: func(ctx context.Context, p db.CreateCategoryParams) (db.Category, error) {
+			params = p
+			return db.Category{}, nil
+		},
+	}
+
+	service := NewCategoryService(mockQueries)
+	if _, err := service.CreateCategory(asAdmin(), "super-mario-64", CreateCategoryInput{Slug: "any", Name: "Any%"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.Position.Valid {
+		t.Errorf("expected position to be left to the database, got %+v", params.Position)
+	}
+}
+
+func TestCreateCategory_InvalidInput(t *testing.T) {
+	negative := int32(-1)
+	tests := []struct {
+		name  string
+		input CreateCategoryInput
+	}{
+		{"bad slug", CreateCategoryInput{Slug: "Any%", Name: "Any%"}},
+		{"blank name", CreateCategoryInput{Slug: "any", Name: "  "}},
+		{"long rules", CreateCategoryInput{Slug: "any", Name: "Any%", Rules: strings.Repeat("a", 10001)}},
+		{"negative position", CreateCategoryInput{Slug: "any", Name: "Any%", Position: &negative}},
+		{"unknown timing method", CreateCategoryInput{Slug: "any", Name: "Any%", TimingMethod: "gametime"}},
+	}
+
+	service := NewCategoryService(&MockQueries{})
+	for _, tt := range tests {
+		_, err := service.CreateCategory(context.Background(), "super-mario-64", tt.input)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestCreateCategory_DuplicateSlug(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		CreateCategoryFunc: func(ctx context.Context, p db.CreateCategoryParams) (db.Category, error) {
+			return db.Category{}, &pgconn.PgError{Code: "23505", ConstraintName: "categories_game_id_slug_key"}
+		},
+	}
+
+	service := NewCategoryService(mockQueries)
+	_, err := service.CreateCategory(asAdmin(), "super-mario-64", CreateCategoryInput{Slug: "any", Name: "Any%"})
+
+	if !errors.Is(err, ErrDuplicateCategorySlug) {
+		t.Errorf("expected ErrDuplicateCategorySlug, got %v", err)
+	}
+}
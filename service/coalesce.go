@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// coalesce runs fetch once for every concurrent caller passing the same key
+// to g and hands each of them its result, so a cold cache entry costs one
+// database query however many requests miss it at once
+//
+// fetch runs detached from any one caller's cancellation so that one client
+// giving up does not fail everyone else waiting on the same result; each
+// caller still stops waiting as soon as its own context is done.
+func coalesce[T any](ctx context.Context, g *singleflight.Group, key string, fetch func(ctx context.Context) (T, error)) (T, error) {
+	ch := g.DoChan(key, func() (interface{}, error) {
+		return fetch(context.WithoutCancel(ctx))
+	})
+	
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrCommentNotFound is returned when a run has no comment with the given
+	// ID, including once the comment has been deleted
+	ErrCommentNotFound = errors.New("comment not found")
+	
+	// ErrCommentEditWindowClosed is returned when editing a comment after the
+	// edit window since it was posted has passed
+	ErrCommentEditWindowClosed = errors.New("comment can no longer be edited")
+	
+	// ErrTooManyComments is returned when a user has already posted as many
+	// comments as they may within the last minute
+	ErrTooManyComments = errors.New("too many comments")
+)
+
+const (
+	// maxCommentLength matches the check on run_comments.body
+	maxCommentLength = 2000
+	
+	// defaultCommentEditWindow is how long after posting a comment its author
+	// may edit it when no window is configured
+	defaultCommentEditWindow = 15 * time.Minute
+	
+	// defaultCommentRateLimit is how many comments a user may post a minute
+	// when no limit is configured
+	defaultCommentRateLimit = 5
+)
+
+// CommentService handles business logic for the comments users leave on runs
+type CommentService struct {
+	queries    db.Store
+	pages      pageSizes
+	editWindow time.Duration
+	rateLimit  int
+	now        func() time.Time
+}
+
+// CommentPage is one page of a run's comments along with the pagination that
+// was actually applied
+type CommentPage struct {
+	Comments []db.RunComment
+	Total    int64
+	Limit    int32
+	Offset   int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// CommentOption configures optional CommentService behavior
+type CommentOption func(*CommentService)
+
+// WithCommentPageSizes sets the limit applied when a list request omits one
+// and the largest limit a list request may ask for
+func WithCommentPageSizes(defaultSize, maxSize int) CommentOption {
+	return func(s *CommentService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// WithCommentEditWindow sets how long after posting a comment its author may
+// edit it; non-positive windows keep the default
+func WithCommentEditWindow(window time.Duration) CommentOption {
+	return func(s *CommentService) {
+		if window > 0 {
+			s.editWindow = window
+		}
+	}
+}
+
+// WithCommentRateLimit sets how many comments a user may post a minute;
+// non-positive limits keep the default
+func WithCommentRateLimit(perMinute int) CommentOption {
+	return func(s *CommentService) {
+		if perMinute > 0 {
+			s.rateLimit = perMinute
+		}
+	}
+}
+
+// NewCommentService creates a new CommentService instance
+func NewCommentService(queries db.Store, opts ...CommentOption) *CommentService {
+	s := &CommentService{
+		queries:    queries,
+		pages:      defaultPageSizes,
+		editWindow: defaultCommentEditWindow,
+		rateLimit:  defaultCommentRateLimit,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// EditWindow returns how long after posting a comment its author may edit it
+func (s *CommentService) EditWindow() time.Duration {
+	return s.editWindow
+}
+
+// ListComments retrieves a page of a run's comments, oldest first
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - runID: The run the comments are on
+//   - page: Pagination parameters
+//
+// Returns:
+//   - *CommentPage: The comments along with the applied pagination
+//   - error: ErrInvalidPagination, ErrInvalidCursor, ErrRunNotFound, or
+//     database errors
+func (s *CommentService) ListComments(ctx context.Context, runID int32, page PageRequest) (*CommentPage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getRun(ctx, s.queries, runID); err != nil {
+		return nil, err
+	}
+	
+	var comments []db.RunComment
+	if page.Cursor == "" {
+		comments, err = s.queries.ListRunComments(ctx, db.ListRunCommentsParams{
+			RunID:  runID,
+			Limit:  pageLimit + 1,
+			Offset: pageOffset,
+		})
+	} else {
+		var afterID int32
+		if err := decodeCursor(page.Cursor, "run_comments", &afterID); err != nil {
+			return nil, err
+		}
+		comments, err = s.queries.ListRunCommentsAfter(ctx, db.ListRunCommentsAfterParams{
+			RunID:   runID,
+			AfterID: afterID,
+			Limit:   pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	comments, more := trimPage(comments, pageLimit)
+	
+	count, err := s.queries.CountRunComments(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+	
+	result := &CommentPage{
+		Comments: comments,
+		Total:    count,
+		Limit:    pageLimit,
+		Offset:   pageOffset,
+	}
+	if more {
+		result.NextCursor = encodeCursor("run_comments", comments[len(comments)-1].ID)
+	}
+	return result, nil
+}
+
+// CreateComment leaves a comment from the caller on a run
+//
+// Like submitting runs, commenting requires a verified email address, and
+// each user may only post so many comments a minute to keep spam in check.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - runID: The run to comment on
+//   - body: The comment text
+//
+// Returns:
+//   - *db.RunComment: The stored comment
+//   - error: ErrForbidden, ErrInvalidInput, ErrRunNotFound, ErrUserNotFound,
+//     ErrEmailNotVerified, ErrTooManyComments, or database errors
+func (s *CommentService) CreateComment(ctx context.Context, runID int32, body string) (*db.RunComment, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err = validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	
+	if _, err := getRun(ctx, s.queries, runID); err != nil {
+		return nil, err
+	}
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.EmailVerifiedAt.Valid {
+		return nil, ErrEmailNotVerified
+	}
+	
+	recent, err := s.queries.CountRecentRunCommentsByUser(ctx, db.CountRecentRunCommentsByUserParams{
+		UserID: userID,
+		Since:  pgtype.Timestamptz{Time: s.now().Add(-time.Minute), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent comments: %w", err)
+	}
+	if recent >= int64(s.rateLimit) {
+		return nil, ErrTooManyComments
+	}
+	
+	var comment db.RunComment
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		comment, err = q.CreateRunComment(ctx, db.CreateRunCommentParams{
+			RunID:  runID,
+			UserID: userID,
+			Body:   body,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, q, "run_comment.create", AuditEntityRunComment, comment.ID, nil, comment); err != nil {
+			return err
+		}
+		return publishEvent(ctx, q, EventCommentCreated, newEventComment(comment))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+	
+	return &comment, nil
+}
+
+// UpdateComment replaces the text of one of the caller's comments, which is
+// only allowed within the edit window since it was posted
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - runID: The run the comment is on
+//   - commentID: The comment to edit
+//   - body: The new comment text
+//
+// Returns:
+//   - *db.RunComment: The updated comment
+//   - error: ErrForbidden if the caller did not write the comment,
+//     ErrInvalidInput, ErrCommentNotFound, ErrCommentEditWindowClosed, or
+//     database errors
+func (s *CommentService) UpdateComment(ctx context.Context, runID, commentID int32, body string) (*db.RunComment, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err = validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	
+	var comment db.RunComment
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		existing, err := getRunComment(ctx, q, runID, commentID)
+		if err != nil {
+			return err
+		}
+		if existing.UserID != userID {
+			return ErrForbidden
+		}
+		if s.now().Sub(existing.CreatedAt.Time) > s.editWindow {
+			return ErrCommentEditWindowClosed
+		}
+		
+		comment, err = q.UpdateRunComment(ctx, db.UpdateRunCommentParams{Body: body, ID: commentID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		return recordAudit(ctx, q, "run_comment.update", AuditEntityRunComment, comment.ID, existing, comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return &comment, nil
+}
+
+// DeleteComment removes a comment; only moderators of the run's game may
+//
+// The comment is soft deleted, recording who removed it, and is no longer
+// listed or editable.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - runID: The run the comment is on
+//   - commentID: The comment to delete
+//
+// Returns:
+//   - error: ErrForbidden, ErrCommentNotFound, or database errors
+func (s *CommentService) DeleteComment(ctx context.Context, runID, commentID int32) error {
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		existing, err := getRunComment(ctx, q, runID, commentID)
+		if err != nil {
+			return err
+		}
+		run, err := getRun(ctx, q, runID)
+		if err != nil {
+			return err
+		}
+		category, err := q.GetCategoryByID(ctx, run.CategoryID)
+		if err != nil {
+			return fmt.Errorf("failed to get category: %w", err)
+		}
+		if err := requireGameModerator(ctx, category.GameID); err != nil {
+			return err
+		}
+		
+		deleted, err := q.DeleteRunComment(ctx, db.DeleteRunCommentParams{DeletedBy: callerID(ctx), ID: commentID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		return recordAudit(ctx, q, "run_comment.delete", AuditEntityRunComment, deleted.ID, existing, nil)
+	})
+}
+
+// getRun looks up a run by its ID
+func getRun(ctx context.Context, q db.Querier, id int32) (*db.Run, error) {
+	run, err := q.GetRunByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRunNotFound
+		}
+		return nil, fmt.Errorf("failed to get run: %w", err)
+	}
+	
+	return &run, nil
+}
+
+// getRunComment looks up a comment that has not been deleted, making sure it
+// is on the given run
+func getRunComment(ctx context.Context, q db.Querier, runID, commentID int32) (*db.RunComment, error) {
+	comment, err := q.GetRunCommentByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment.RunID != runID {
+		return nil, ErrCommentNotFound
+	}
+	
+	return &comment, nil
+}
+
+// validateCommentBody trims a comment's text and checks its length
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLength {
+		return "", invalidField("body", "must be 1 to %d characters", maxCommentLength)
+	}
+	return body, nil
+}
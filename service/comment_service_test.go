@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) ListRunComments(ctx context.Context, params db.ListRunCommentsParams) ([]db.RunComment, error) {
+	if m.ListRunCommentsFunc != nil {
+		return m.ListRunCommentsFunc(ctx, params)
+	}
+	return []db.RunComment{}, nil
+}
+
+func (m *MockQueries) ListRunCommentsAfter(ctx context.Context, params db.ListRunCommentsAfterParams) ([]db.RunComment, error) {
+	if m.ListRunCommentsAfterFunc != nil {
+		return m.ListRunCommentsAfterFunc(ctx, params)
+	}
+	return []db.RunComment{}, nil
+}
+
+func (m *MockQueries) CountRunComments(ctx context.Context, runID int32) (int64, error) {
+	if m.CountRunCommentsFunc != nil {
+		return m.CountRunCommentsFunc(ctx, runID)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) CountRecentRunCommentsByUser(ctx context.Context, params db.CountRecentRunCommentsByUserParams) (int64, error) {
+	if m.CountRecentRunCommentsByUserFunc != nil {
+		return m.CountRecentRunCommentsByUserFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) GetRunCommentByID(ctx context.Context, id int32) (db.RunComment, error) {
+	if m.GetRunCommentByIDFunc != nil {
+		return m.GetRunCommentByIDFunc(ctx, id)
+	}
+	return db.RunComment{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) CreateRunComment(ctx context.Context, params db.CreateRunCommentParams) (db.RunComment, error) {
+	if m.CreateRunCommentFunc != nil {
+		return m.CreateRunCommentFunc(ctx, params)
+	}
+	return db.RunComment{ID: 1, RunID: params.RunID, UserID: params.UserID, Body: params.Body}, nil
+}
+
+func (m *MockQueries) UpdateRunComment(ctx context.Context, params db.UpdateRunCommentParams) (db.RunComment, error) {
+	if m.UpdateRunCommentFunc != nil {
+		return m.UpdateRunCommentFunc(ctx, params)
+	}
+	return db.RunComment{ID: params.ID, Body: params.Body}, nil
+}
+
+func (m *MockQueries) DeleteRunComment(ctx context.Context, params db.DeleteRunCommentParams) (db.RunComment, error) {
+	if m.DeleteRunCommentFunc != nil {
+		return m.DeleteRunCommentFunc(ctx, params)
+	}
+	return db.RunComment{ID: params.ID, DeletedBy: params.DeletedBy}, nil
+}
+
+// commentNow is the time comment tests run at
+var commentNow = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+// commentLookup returns a GetRunCommentByIDFunc finding comment 12 on run 1 by
+// user 3, posted age before commentNow
+func commentLookup(age time.Duration) func(ctx context.Context, id int32) (db.RunComment, error) {
+	return func(ctx context.Context, id int32) (db.RunComment, error) {
+		if id != 12 {
+			return db.RunComment{}, sql.ErrNoRows
+		}
+		return db.RunComment{
+			ID:        12,
+			RunID:     1,
+			UserID:    3,
+			Body:      "Nice run",
+			CreatedAt: pgtype.Timestamptz{Time: commentNow.Add(-age), Valid: true},
+		}, nil
+	}
+}
+
+// runLookup is a GetRunByIDFunc finding run 1 in category 3
+func runLookup(ctx context.Context, id int32) (db.Run, error) {
+	if id != 1 {
+		return db.Run{}, sql.ErrNoRows
+	}
+	return db.Run{ID: 1, CategoryID: 3}, nil
+}
+
+func TestListComments(t *testing.T) {
+	var afterID int32
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: runLookup,
+		ListRunCommentsFunc: func(ctx context.Context, params db.ListRunCommentsParams) ([]db.RunComment, error) {
+			if params.RunID != 1 || params.Limit != 3 {
+				t.Errorf("unexpected list params %+v", params)
+			}
+			return []db.RunComment{{ID: 4}, {ID: 7}, {ID: 9}}, nil
+		},
+		ListRunCommentsAfterFunc: func(ctx context.Context, params db.ListRunCommentsAfterParams) ([]db.RunComment, error) {
+			afterID = params.AfterID
+			return []db.RunComment{{ID: 9}}, nil
+		},
+		CountRunCommentsFunc: func(ctx context.Context, runID int32) (int64, error) {
+			return 3, nil
+		},
+	}
+
+	service := NewCommentService(mockQueries)
+	page, err := service.ListComments(context.Background(), 1, PageRequest{Limit: 2})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Comments) != 2 || page.Total != 3 || page.NextCursor == "" {
+		t.Fatalf("expected a first page of 2 of 3 comments with a cursor, got %+v", page)
+	}
+
+	page, err = service.ListComments(context.Background(), 1, PageRequest{Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if afterID != 7 || len(page.Comments) != 1 || page.NextCursor != "" {
+		t.Errorf("expected the last page to continue after comment 7, got after %d and %+v", afterID, page)
+	}
+
+	if _, err := service.ListComments(context.Background(), 2, PageRequest{}); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("expected ErrRunNotFound, got %v", err)
+	}
+}
+
+func TestCreateComment_Success(t *testing.T) {
+	var params db.CreateRunCommentParams
+	var since time.Time
+	var audited bool
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: runLookup,
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: pgtype.Timestamptz{Time: commentNow, Valid: true}}, nil
+		},
+		CountRecentRunCommentsByUserFunc: func(ctx context.Context, p db.CountRecentRunCommentsByUserParams) (int64, error) {
+			since = p.Since.Time
+			return 4, nil
+		},
+		CreateRunCommentFunc: func(ctx context.Context, p db.CreateRunCommentParams) (db.RunComment, error) {
+			params = p
+			return db.RunComment{ID: 12, RunID: p.RunID, UserID: p.UserID, Body: p.Body}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = p.Action == "run_comment.create" && p.EntityType == AuditEntityRunComment && p.EntityID == 12
+			return nil
+		},
+	}
+
+	service := NewCommentService(mockQueries)
+	service.now = func() time.Time { return commentNow }
+	comment, err := service.CreateComment(asUser(3), 1, "  Nice run  ")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.RunID != 1 || params.UserID != 3 || params.Body != "Nice run" {
+		t.Errorf("unexpected insert params %+v", params)
+	}
+	if !since.Equal(commentNow.Add(-time.Minute)) {
+		t.Errorf("expected comments to be counted over the last minute, got since %v", since)
+	}
+	if comment.ID != 12 {
+		t.Errorf("expected the stored comment, got %+v", comment)
+	}
+	if !audited {
+		t.Error("expected the comment to be audited")
+	}
+}
+
+func TestCreateComment_Errors(t *testing.T) {
+	verified := pgtype.Timestamptz{Time: commentNow, Valid: true}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		runID    int32
+		body     string
+		verified pgtype.Timestamptz
+		recent   int64
+		want     error
+	}{
+		{"anonymous", context.Background(), 1, "Nice run", verified, 0, ErrForbidden},
+		{"api key", auth.WithPrincipal(context.Background(), auth.Principal{UserID: 3, APIKeyID: 5}), 1, "Nice run", verified, 0, ErrForbidden},
+		{"blank body", asUser(3), 1, "   ", verified, 0, ErrInvalidInput},
+		{"long body", asUser(3), 1, strings.Repeat("a", maxCommentLength+1), verified, 0, ErrInvalidInput},
+		{"missing run", asUser(3), 2, "Nice run", verified, 0, ErrRunNotFound},
+		{"unverified email", asUser(3), 1, "Nice run", pgtype.Timestamptz{}, 0, ErrEmailNotVerified},
+		{"throttled", asUser(3), 1, "Nice run", verified, defaultCommentRateLimit, ErrTooManyComments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			mockQueries := &MockQueries{
+				GetRunByIDFunc: runLookup,
+				GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+					return db.User{ID: id, EmailVerifiedAt: tt.verified}, nil
+				},
+				CountRecentRunCommentsByUserFunc: func(ctx context.Context, p db.CountRecentRunCommentsByUserParams) (int64, error) {
+					return tt.recent, nil
+				},
+				CreateRunCommentFunc: func(ctx context.Context, p db.CreateRunCommentParams) (db.RunComment, error) {
+					created = true
+					return db.RunComment{}, nil
+				},
+			}
+
+			service := NewCommentService(mockQueries)
+			_, err := service.CreateComment(tt.ctx, tt.runID, tt.body)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if created {
+				t.Error("expected no comment to be stored")
+			}
+		})
+	}
+}
+
+func TestCreateComment_RateLimitOption(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: runLookup,
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: pgtype.Timestamptz{Time: commentNow, Valid: true}}, nil
+		},
+		CountRecentRunCommentsByUserFunc: func(ctx context.Context, p db.CountRecentRunCommentsByUserParams) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	service := NewCommentService(mockQueries, WithCommentRateLimit(1))
+	if _, err := service.CreateComment(asUser(3), 1, "Nice run"); !errors.Is(err, ErrTooManyComments) {
+		t.Errorf("expected ErrTooManyComments, got %v", err)
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		commentID int32
+		age       time.Duration
+		window    time.Duration
+		want      error
+	}{
+		{"author within the window", asUser(3), 12, 10 * time.Minute, 0, nil},
+		{"another user", asUser(4), 12, time.Minute, 0, ErrForbidden},
+		{"admin", asAdmin(), 12, time.Minute, 0, ErrForbidden},
+		{"window closed", asUser(3), 12, 16 * time.Minute, 0, ErrCommentEditWindowClosed},
+		{"configured window", asUser(3), 12, 16 * time.Minute, time.Hour, nil},
+		{"missing comment", asUser(3), 13, time.Minute, 0, ErrCommentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params db.UpdateRunCommentParams
+			mockQueries := &MockQueries{
+				GetRunCommentByIDFunc: commentLookup(tt.age),
+				UpdateRunCommentFunc: func(ctx context.Context, p db.UpdateRunCommentParams) (db.RunComment, error) {
+					params = p
+					return db.RunComment{ID: p.ID, RunID: 1, UserID: 3, Body: p.Body}, nil
+				},
+			}
+
+			service := NewCommentService(mockQueries, WithCommentEditWindow(tt.window))
+			service.now = func() time.Time { return commentNow }
+			_, err := service.UpdateComment(tt.ctx, 1, tt.commentID, "Nice run!")
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if updated := params.ID != 0; updated != (tt.want == nil) {
+				t.Errorf("expected the update to happen only when allowed, params %+v", params)
+			}
+		})
+	}
+}
+
+func TestUpdateComment_WrongRun(t *testing.T) {
+	mockQueries := &MockQueries{GetRunCommentByIDFunc: commentLookup(time.Minute)}
+
+	service := NewCommentService(mockQueries)
+	service.now = func() time.Time { return commentNow }
+	if _, err := service.UpdateComment(asUser(3), 2, 12, "Nice run!"); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("expected ErrCommentNotFound for a comment on another run, got %v", err)
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"moderator of the game", asUser(8, auth.Grant{Role: auth.RoleVerifier, GameID: 4}), nil},
+		{"global moderator", asUser(8, auth.Grant{Role: auth.RoleModerator}), nil},
+		{"moderator of another game", asUser(8, auth.Grant{Role: auth.RoleVerifier, GameID: 5}), ErrForbidden},
+		{"author", asUser(3), ErrForbidden},
+		{"anonymous", context.Background(), ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params db.DeleteRunCommentParams
+			mockQueries := &MockQueries{
+				GetRunCommentByIDFunc: commentLookup(time.Minute),
+				GetRunByIDFunc:        runLookup,
+				GetCategoryByIDFunc:   categoryInGame(4),
+				DeleteRunCommentFunc: func(ctx context.Context, p db.DeleteRunCommentParams) (db.RunComment, error) {
+					params = p
+					return db.RunComment{ID: p.ID, DeletedBy: p.DeletedBy}, nil
+				},
+			}
+
+			service := NewCommentService(mockQueries)
+			err := service.DeleteComment(tt.ctx, 1, 12)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if tt.want == nil && (params.ID != 12 || params.DeletedBy.Int32 != 8) {
+				t.Errorf("expected comment 12 to be deleted by user 8, got %+v", params)
+			}
+			if tt.want != nil && params.ID != 0 {
+				t.Error("expected no deletion")
+			}
+		})
+	}
+}
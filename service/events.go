@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// Domain events written to the outbox, which publishes them to webhooks and
+// the other configured sinks
+const (
+	// EventUserCreated is published when an account is created, whether by
+	// an admin, by registering, or by a first login through a provider
+	EventUserCreated = "user.created"
+	
+	// EventRunSubmitted is published when a runner submits a run for review
+	EventRunSubmitted = "run.submitted"
+	
+	// EventRunVerified is published when a moderator verifies a run
+	EventRunVerified = "run.verified"
+	
+	// EventRunRejected is published when a moderator rejects a run
+	EventRunRejected = "run.rejected"
+	
+	// EventRecordBroken is published when a verified run is faster than its
+	// category's previous record
+	EventRecordBroken = "record.broken"
+	
+	// EventCategoryCreated is published when a category is added to a game
+	EventCategoryCreated = "category.created"
+	
+	// EventCommentCreated is published when a user comments on a run
+	EventCommentCreated = "comment.created"
+)
+
+// Events are every event that is published, all of which webhooks may
+// subscribe to
+var Events = []string{
+	EventUserCreated,
+	EventRunSubmitted,
+	EventRunVerified,
+	EventRunRejected,
+	EventRecordBroken,
+	EventCategoryCreated,
+	EventCommentCreated,
+}
+
+// eventUser is a user in an event; the email address is left out so it is
+// not shared with integrators
+type eventUser struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// eventRun is a run in an event
+type eventRun struct {
+	ID         int32     `json:"id"`
+	UserID     int32     `json:"user_id"`
+	CategoryID int32     `json:"category_id"`
+	LevelID    *int32    `json:"level_id,omitempty"`
+	TimeMs     int64     `json:"time_ms"`
+	VideoURL   string    `json:"video_url"`
+	Platform   string    `json:"platform"`
+	Region     *string   `json:"region,omitempty"`
+	PlayedOn   string    `json:"played_on"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	
+	// RejectionReason is set once a moderator has rejected the run
+	RejectionReason *string `json:"rejection_reason,omitempty"`
+	
+	// RaceID is set on a race result, to the race the run was played in
+	RaceID *int32 `json:"race_id,omitempty"`
+}
+
+// eventRecord is the data of a record.broken event
+type eventRecord struct {
+	Run            eventRun `json:"run"`
+	PreviousRecord eventRun `json:"previous_record"`
+}
+
+// eventCategory is a category in an event
+type eventCategory struct {
+	ID           int32     `json:"id"`
+	GameID       int32     `json:"game_id"`
+	Slug         string    `json:"slug"`
+	Name         string    `json:"name"`
+	TimingMethod string    `json:"timing_method"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// eventComment is a comment on a run in an event
+type eventComment struct {
+	ID        int32     `json:"id"`
+	RunID     int32     `json:"run_id"`
+	UserID    int32     `json:"user_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// newEventUser converts a stored user to its event form
+func newEventUser(user db.User) eventUser {
+	return eventUser{ID: user.ID, Name: user.Name, CreatedAt: user.CreatedAt.Time.UTC()}
+}
+
+// newEventRun converts a stored run to its event form
+func newEventRun(run db.Run) eventRun {
+	event := eventRun{
+		ID:         run.ID,
+		UserID:     run.UserID,
+		CategoryID: run.CategoryID,
+		TimeMs:     run.TimeMs,
+		VideoURL:   run.VideoUrl,
+		Platform:   run.Platform,
+		PlayedOn:   run.PlayedOn.Time.Format(time.DateOnly),
+		Status:     run.Status,
+		CreatedAt:  run.CreatedAt.Time.UTC(),
+	}
+	if run.LevelID.Valid {
+		event.LevelID = &run.LevelID.Int32
+	}
+	if run.Region.Valid {
+		event.Region = &run.Region.String
+	}
+	if run.RejectionReason.Valid {
+		event.RejectionReason = &run.RejectionReason.String
+	}
+	if run.RaceID.Valid {
+		event.RaceID = &run.RaceID.Int32
+	}
+	return event
+}
+
+// newEventCategory converts a stored category to its event form
+func newEventCategory(category db.Category) eventCategory {
+	return eventCategory{
+		ID:           category.ID,
+		GameID:       category.GameID,
+		Slug:         category.Slug,
+		Name:         category.Name,
+		TimingMethod: category.TimingMethod,
+		CreatedAt:    category.CreatedAt.Time.UTC(),
+	}
+}
+
+// newEventComment converts a stored comment to its event form
+func newEventComment(comment db.RunComment) eventComment {
+	return eventComment{
+		ID:        comment.ID,
+		RunID:     comment.RunID,
+		UserID:    comment.UserID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt.Time.UTC(),
+	}
+}
+
+// publishEvent writes event to the outbox, to be published by the outbox
+// dispatcher
+//
+// It should be called with the Querier of the transaction making the change
+// the event is about, so the event is only published if it commits.
+//
+// Parameters:
+//   - q: The Querier to write the event with
+//   - event: One of Events
+//   - data: The event's data, e.g. an eventRun
+func publishEvent(ctx context.Context, q db.Querier, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+	if err := q.CreateOutboxEvent(ctx, db.CreateOutboxEventParams{Event: event, Payload: payload}); err != nil {
+		return fmt.Errorf("failed to write %s event to the outbox: %w", event, err)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) CreateOutboxEvent(ctx context.Context, params db.CreateOutboxEventParams) error {
+	if m.CreateOutboxEventFunc != nil {
+		return m.CreateOutboxEventFunc(ctx, params)
+	}
+	return nil
+}
+
+// The dispatcher's queries are not used by any service
+
+func (m *MockQueries) ClaimOutboxEvents(ctx context.Context, params db.ClaimOutboxEventsParams) ([]db.OutboxEvent, error) {
+	return []db.OutboxEvent{}, nil
+}
+
+func (m *MockQueries) UpdateOutboxEvent(ctx context.Context, params db.UpdateOutboxEventParams) error {
+	return nil
+}
+
+func (m *MockQueries) DeletePublishedOutboxEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockQueries) NotifyListeners(ctx context.Context, params db.NotifyListenersParams) error {
+	if m.NotifyListenersFunc != nil {
+		return m.NotifyListenersFunc(ctx, params)
+	}
+	return nil
+}
+
+// publishedEvents collects the data of the events written through m by event
+// name
+func publishedEvents(t *testing.T, m *MockQueries) map[string]map[string]any {
+	t.Helper()
+	events := map[string]map[string]any{}
+	m.CreateOutboxEventFunc = func(ctx context.Context, params db.CreateOutboxEventParams) error {
+		var data map[string]any
+		if err := json.Unmarshal(params.Payload, &data); err != nil {
+			t.Fatalf("failed to decode payload %s: %v", params.Payload, err)
+		}
+		events[params.Event] = data
+		return nil
+	}
+	return events
+}
+
+func TestCreateUser_PublishesUserCreated(t *testing.T) {
+	mockQueries := &MockQueries{
+		CreateUserFunc: func(ctx context.Context, params db.CreateUserParams) (db.User, error) {
+			return db.User{ID: 7, Name: params.Name, Email: params.Email, CreatedAt: timeToTimestamptz(time.Now())}, nil
+		},
+	}
+	events := publishedEvents(t, mockQueries)
+
+	service := NewUserService(mockQueries)
+	if _, err := service.CreateUser(asAdmin(), "Jane Doe", "jane@example.com"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, ok := events[EventUserCreated]
+	if !ok {
+		t.Fatalf("expected user.created to be published, got %v", events)
+	}
+	if data["id"] != float64(7) || data["name"] != "Jane Doe" {
+		t.Errorf("expected the new user in the payload, got %v", data)
+	}
+	if _, ok := data["email"]; ok {
+		t.Errorf("expected the email address to be left out, got %v", data)
+	}
+}
+
+func TestSubmitRun_PublishesRunSubmitted(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			return db.Run{ID: 9, UserID: params.UserID, CategoryID: params.CategoryID, Status: RunStatusPending}, nil
+		},
+	}
+	events := publishedEvents(t, mockQueries)
+
+	service := NewRunService(mockQueries)
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", validRun()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if run := events[EventRunSubmitted]; run["id"] != float64(9) || run["status"] != RunStatusPending {
+		t.Errorf("expected run.submitted with the new run, got %v", events)
+	}
+}
+
+func TestCreateCategory_PublishesCategoryCreated(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		CreateCategoryFunc: func(ctx context.Context, params db.CreateCategoryParams) (db.Category, error) {
+			return db.Category{ID: 2, GameID: params.GameID, Slug: params.Slug, Name: params.Name, TimingMethod: params.TimingMethod}, nil
+		},
+	}
+	events := publishedEvents(t, mockQueries)
+
+	service := NewCategoryService(mockQueries)
+	if _, err := service.CreateCategory(asAdmin(), "super-mario-64", CreateCategoryInput{Slug: "16-star", Name: "16 Star"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if category := events[EventCategoryCreated]; category["id"] != float64(2) || category["game_id"] != float64(4) || category["slug"] != "16-star" {
+		t.Errorf("expected category.created with the new category, got %v", events)
+	}
+}
+
+func TestCreateComment_PublishesCommentCreated(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: runLookup,
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunCommentFunc: func(ctx context.Context, params db.CreateRunCommentParams) (db.RunComment, error) {
+			return db.RunComment{ID: 12, RunID: params.RunID, UserID: params.UserID, Body: params.Body}, nil
+		},
+	}
+	events := publishedEvents(t, mockQueries)
+
+	service := NewCommentService(mockQueries)
+	if _, err := service.CreateComment(asUser(3), 1, "Nice run"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if comment := events[EventCommentCreated]; comment["id"] != float64(12) || comment["run_id"] != float64(1) || comment["user_id"] != float64(3) {
+		t.Errorf("expected comment.created with the new comment, got %v", events)
+	}
+}
+
+func TestVerifyRun_PublishesRecordBroken(t *testing.T) {
+	tests := []struct {
+		name        string
+		record      func(ctx context.Context, params db.GetFastestVerifiedRunParams) (db.Run, error)
+		wantRecord  bool
+		wantHistory bool
+	}{
+		{"faster than the record", func(ctx context.Context, p db.GetFastestVerifiedRunParams) (db.Run, error) {
+			return db.Run{ID: 2, CategoryID: p.CategoryID, TimeMs: 60_000, Status: RunStatusVerified}, nil
+		}, true, true},
+		{"slower than the record", func(ctx context.Context, p db.GetFastestVerifiedRunParams) (db.Run, error) {
+			return db.Run{ID: 2, CategoryID: p.CategoryID, TimeMs: 40_000, Status: RunStatusVerified}, nil
+		}, false, false},
+		{"first verified run", nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueries := &MockQueries{
+				GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+					return db.Run{ID: id, CategoryID: 3, TimeMs: 50_000, Status: RunStatusPending}, nil
+				},
+				GetCategoryByIDFunc: categoryInGame(1),
+				UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+					return db.Run{ID: p.ID, CategoryID: 3, TimeMs: 50_000, Status: p.Status}, nil
+				},
+				GetFastestVerifiedRunFunc: tt.record,
+			}
+			var history []db.CreateCategoryRecordParams
+			mockQueries.CreateCategoryRecordFunc = func(ctx context.Context, p db.CreateCategoryRecordParams) error {
+				history = append(history, p)
+				return nil
+			}
+			events := publishedEvents(t, mockQueries)
+
+			service := NewRunService(mockQueries)
+			if _, err := service.VerifyRun(asAdmin(), 7); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if run := events[EventRunVerified]; run["id"] != float64(7) || run["status"] != RunStatusVerified {
+				t.Errorf("expected run.verified with the run, got %v", run)
+			}
+			record, broken := events[EventRecordBroken]
+			if broken != tt.wantRecord {
+				t.Fatalf("expected record.broken published to be %v, got %v", tt.wantRecord, events)
+			}
+			if broken && record["previous_record"].(map[string]any)["id"] != float64(2) {
+				t.Errorf("expected the previous record in the payload, got %v", record)
+			}
+			if (len(history) == 1) != tt.wantHistory || (tt.wantHistory && history[0] != db.CreateCategoryRecordParams{CategoryID: 3, RunID: 7}) {
+				t.Errorf("expected run 7 added to the record history to be %v, got %+v", tt.wantHistory, history)
+			}
+		})
+	}
+}
+
+func TestVerifyRun_LevelRunSetsNoRecord(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: RunStatusPending}, nil
+		},
+		GetCategoryByIDFunc: categoryInGame(1),
+		UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{ID: p.ID, CategoryID: 3, LevelID: pgtype.Int4{Int32: 2, Valid: true}, Status: p.Status}, nil
+		},
+		GetFastestVerifiedRunFunc: func(ctx context.Context, p db.GetFastestVerifiedRunParams) (db.Run, error) {
+			t.Error("expected no record lookup for a level run")
+			return db.Run{}, sql.ErrNoRows
+		},
+		CreateCategoryRecordFunc: func(ctx context.Context, p db.CreateCategoryRecordParams) error {
+			t.Error("expected a level run not to enter the category's record history")
+			return nil
+		},
+	}
+	events := publishedEvents(t, mockQueries)
+
+	service := NewRunService(mockQueries)
+	if _, err := service.VerifyRun(asAdmin(), 7); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if run := events[EventRunVerified]; run["level_id"] != float64(2) {
+		t.Errorf("expected run.verified with the run's level, got %v", events)
+	}
+}
+
+func TestRejectRun_PublishesRunRejected(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: RunStatusPending}, nil
+		},
+		GetCategoryByIDFunc: categoryInGame(1),
+		UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{ID: p.ID, Status: p.Status, RejectionReason: p.RejectionReason}, nil
+		},
+	}
+	events := publishedEvents(t, mockQueries)
+
+	service := NewRunService(mockQueries)
+	if _, err := service.RejectRun(asAdmin(), 7, "Video is private"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected only run.rejected, got %v", events)
+	}
+	if run := events[EventRunRejected]; run["id"] != float64(7) || run["rejection_reason"] != "Video is private" {
+		t.Errorf("expected run.rejected with the reason, got %v", events)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// FeedService handles business logic for users' activity feeds of the new
+// records, verified runs, and new categories of the games and runners they
+// follow
+//
+// Feeds are written by the outbox's feed sink as events are published, so
+// an event shows up in feeds shortly after it happens rather than at once,
+// and only in the feeds of users who followed its game or runner by then.
+type FeedService struct {
+	queries db.Store
+	pages   pageSizes
+}
+
+// FeedPage is one page of a user's feed along with the pagination that was
+// actually applied
+type FeedPage struct {
+	Items  []db.ListFeedItemsRow
+	Total  int64
+	Limit  int32
+	Offset int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// FeedOption configures optional FeedService behavior
+type FeedOption func(*FeedService)
+
+// WithFeedPageSizes sets the limit applied when a list request omits one
+// and the largest limit a list request may ask for
+func WithFeedPageSizes(defaultSize, maxSize int) FeedOption {
+	return func(s *FeedService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// NewFeedService creates a new FeedService instance
+func NewFeedService(queries db.Store, opts ...FeedOption) *FeedService {
+	s := &FeedService{
+		queries: queries,
+		pages:   defaultPageSizes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListFeed retrieves a page of the caller's feed, newest first
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - page: Pagination parameters
+//
+// Returns:
+//   - *FeedPage: The feed items along with the applied pagination
+//   - error: ErrForbidden, ErrInvalidPagination, ErrInvalidCursor, or
+//     database errors
+func (s *FeedService) ListFeed(ctx context.Context, page PageRequest) (*FeedPage, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	
+	var items []db.ListFeedItemsRow
+	if page.Cursor == "" {
+		items, err = s.queries.ListFeedItems(ctx, db.ListFeedItemsParams{
+			UserID: userID,
+			Limit:  pageLimit + 1,
+			Offset: pageOffset,
+		})
+	} else {
+		var afterID int32
+		if err := decodeCursor(page.Cursor, "feed", &afterID); err != nil {
+			return nil, err
+		}
+		var rows []db.ListFeedItemsAfterRow
+		rows, err = s.queries.ListFeedItemsAfter(ctx, db.ListFeedItemsAfterParams{
+			UserID:  userID,
+			AfterID: afterID,
+			Limit:   pageLimit + 1,
+		})
+		for _, row := range rows {
+			items = append(items, db.ListFeedItemsRow(row))
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed: %w", err)
+	}
+	items, more := trimPage(items, pageLimit)
+	
+	count, err := s.queries.CountFeedItems(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count feed items: %w", err)
+	}
+	
+	result := &FeedPage{
+		Items:  items,
+		Total:  count,
+		Limit:  pageLimit,
+		Offset: pageOffset,
+	}
+	if more {
+		result.NextCursor = encodeCursor("feed", items[len(items)-1].ID)
+	}
+	return result, nil
+}
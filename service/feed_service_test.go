@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) ListFeedItems(ctx context.Context, params db.ListFeedItemsParams) ([]db.ListFeedItemsRow, error) {
+	if m.ListFeedItemsFunc != nil {
+		return m.ListFeedItemsFunc(ctx, params)
+	}
+	return []db.ListFeedItemsRow{}, nil
+}
+
+func (m *MockQueries) ListFeedItemsAfter(ctx context.Context, params db.ListFeedItemsAfterParams) ([]db.ListFeedItemsAfterRow, error) {
+	if m.ListFeedItemsAfterFunc != nil {
+		return m.ListFeedItemsAfterFunc(ctx, params)
+	}
+	return []db.ListFeedItemsAfterRow{}, nil
+}
+
+func (m *MockQueries) CountFeedItems(ctx context.Context, userID int32) (int64, error) {
+	if m.CountFeedItemsFunc != nil {
+		return m.CountFeedItemsFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+// The feed sink's queries are not used by any service
+
+func (m *MockQueries) CreateFeedItems(ctx context.Context, params db.CreateFeedItemsParams) error {
+	return nil
+}
+
+func (m *MockQueries) DeleteExpiredFeedItems(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error) {
+	return 0, nil
+}
+
+func TestListFeed_Paginates(t *testing.T) {
+	var after db.ListFeedItemsAfterParams
+	mockQueries := &MockQueries{
+		ListFeedItemsFunc: func(ctx context.Context, p db.ListFeedItemsParams) ([]db.ListFeedItemsRow, error) {
+			if p.UserID != 1 || p.Limit != 3 {
+				t.Errorf("expected one more item of user 1's feed than the limit, got %+v", p)
+			}
+			return []db.ListFeedItemsRow{
+				{ID: 9, Event: EventRecordBroken},
+				{ID: 7, Event: EventRunVerified},
+				{ID: 4, Event: EventCategoryCreated},
+			}, nil
+		},
+		ListFeedItemsAfterFunc: func(ctx context.Context, p db.ListFeedItemsAfterParams) ([]db.ListFeedItemsAfterRow, error) {
+			after = p
+			return []db.ListFeedItemsAfterRow{{ID: 4, Event: EventCategoryCreated}}, nil
+		},
+		CountFeedItemsFunc: func(ctx context.Context, userID int32) (int64, error) {
+			return 3, nil
+		},
+	}
+	service := NewFeedService(mockQueries)
+
+	page, err := service.ListFeed(asUser(1), PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Items) != 2 || page.Total != 3 || page.NextCursor == "" {
+		t.Fatalf("expected two of three items and a cursor, got %+v", page)
+	}
+
+	next, err := service.ListFeed(asUser(1), PageRequest{Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.UserID != 1 || after.AfterID != 7 {
+		t.Errorf("expected the next page after item 7, got %+v", after)
+	}
+	if len(next.Items) != 1 || next.Items[0].ID != 4 || next.NextCursor != "" {
+		t.Errorf("expected the last item and no cursor, got %+v", next)
+	}
+}
+
+func TestListFeed_RequiresUser(t *testing.T) {
+	service := NewFeedService(&MockQueries{})
+
+	if _, err := service.ListFeed(context.Background(), PageRequest{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
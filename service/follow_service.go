@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// FollowService handles business logic for users following other runners and
+// games, which feeds and notifications build on
+type FollowService struct {
+	queries db.Store
+	pages   pageSizes
+}
+
+// FollowerPage is one page of users from a follow list, such as a user's
+// followers, along with the pagination that was actually applied
+type FollowerPage struct {
+	Users  []db.ListUserFollowersRow
+	Total  int64
+	Limit  int32
+	Offset int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// FollowedGamePage is one page of the games a user follows along with the
+// pagination that was actually applied
+type FollowedGamePage struct {
+	Games  []db.ListFollowedGamesRow
+	Total  int64
+	Limit  int32
+	Offset int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// FollowOption configures optional FollowService behavior
+type FollowOption func(*FollowService)
+
+// WithFollowPageSizes sets the limit applied when a list request omits one
+// and the largest limit a list request may ask for
+func WithFollowPageSizes(defaultSize, maxSize int) FollowOption {
+	return func(s *FollowService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// NewFollowService creates a new FollowService instance
+func NewFollowService(queries db.Store, opts ...FollowOption) *FollowService {
+	s := &FollowService{
+		queries: queries,
+		pages:   defaultPageSizes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// FollowUser makes the caller follow another user; following a user twice is
+// not an error
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - userID: The user to follow
+//
+// Returns:
+//   - error: ErrForbidden, ErrInvalidInput when following oneself,
+//     ErrUserNotFound, or database errors
+func (s *FollowService) FollowUser(ctx context.Context, userID int32) error {
+	followerID, err := requireAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	if followerID == userID {
+		return fmt.Errorf("%w: users cannot follow themselves", ErrInvalidInput)
+	}
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return err
+	}
+	
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		follow := db.FollowUserParams{FollowerID: followerID, FollowedID: userID}
+		added, err := q.FollowUser(ctx, follow)
+		if err != nil {
+			return fmt.Errorf("failed to follow user: %w", err)
+		}
+		if added == 0 {
+			return nil
+		}
+		return recordAudit(ctx, q, "user.follow", AuditEntityUser, userID, nil, db.UserFollow{FollowerID: followerID, FollowedID: userID})
+	})
+}
+
+// UnfollowUser makes the caller stop following a user; unfollowing a user
+// the caller does not follow is not an error
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - userID: The user to unfollow
+//
+// Returns:
+//   - error: ErrForbidden, or database errors
+func (s *FollowService) UnfollowUser(ctx context.Context, userID int32) error {
+	followerID, err := requireAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		removed, err := q.UnfollowUser(ctx, db.UnfollowUserParams{FollowerID: followerID, FollowedID: userID})
+		if err != nil {
+			return fmt.Errorf("failed to unfollow user: %w", err)
+		}
+		if removed == 0 {
+			return nil
+		}
+		return recordAudit(ctx, q, "user.unfollow", AuditEntityUser, userID, db.UserFollow{FollowerID: followerID, FollowedID: userID}, nil)
+	})
+}
+
+// FollowGame makes the caller follow a game; following a game twice is not
+// an error
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game to follow
+//
+// Returns:
+//   - error: ErrForbidden, ErrGameNotFound, or database errors
+func (s *FollowService) FollowGame(ctx context.Context, gameSlug string) error {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return err
+	}
+	
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		added, err := q.FollowGame(ctx, db.FollowGameParams{UserID: userID, GameID: game.ID})
+		if err != nil {
+			return fmt.Errorf("failed to follow game: %w", err)
+		}
+		if added == 0 {
+			return nil
+		}
+		return recordAudit(ctx, q, "game.follow", AuditEntityGame, game.ID, nil, db.GameFollow{UserID: userID, GameID: game.ID})
+	})
+}
+
+// UnfollowGame makes the caller stop following a game; unfollowing a game the
+// caller does not follow is not an error
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game to unfollow
+//
+// Returns:
+//   - error: ErrForbidden, ErrGameNotFound, or database errors
+func (s *FollowService) UnfollowGame(ctx context.Context, gameSlug string) error {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return err
+	}
+	
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		removed, err := q.UnfollowGame(ctx, db.UnfollowGameParams{UserID: userID, GameID: game.ID})
+		if err != nil {
+			return fmt.Errorf("failed to unfollow game: %w", err)
+		}
+		if removed == 0 {
+			return nil
+		}
+		return recordAudit(ctx, q, "game.unfollow", AuditEntityGame, game.ID, db.GameFollow{UserID: userID, GameID: game.ID}, nil)
+	})
+}
+
+// FollowCounts counts a user's followers and the users and games they follow
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: The user to count the follows of
+//
+// Returns:
+//   - *db.GetUserFollowCountsRow: The counts, leaving out deleted users
+//   - error: Database errors
+func (s *FollowService) FollowCounts(ctx context.Context, userID int32) (*db.GetUserFollowCountsRow, error) {
+	counts, err := s.queries.GetUserFollowCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count follows: %w", err)
+	}
+	
+	return &counts, nil
+}
+
+// ListFollowers retrieves a page of the users following a user, most recent
+// follow first
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: The followed user
+//   - page: Pagination parameters
+//
+// Returns:
+//   - *FollowerPage: The followers along with the applied pagination
+//   - error: ErrInvalidPagination, ErrInvalidCursor, ErrUserNotFound, or
+//     database errors
+func (s *FollowService) ListFollowers(ctx context.Context, userID int32, page PageRequest) (*FollowerPage, error) {
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	
+	return s.listUsers("user-followers", page, followerQueries{
+		first: func(limit, offset int32) ([]db.ListUserFollowersRow, error) {
+			return s.queries.ListUserFollowers(ctx, db.ListUserFollowersParams{
+				FollowedID: userID,
+				Limit:      limit,
+				Offset:     offset,
+			})
+		},
+		after: func(followedAt pgtype.Timestamptz, afterID, limit int32) ([]db.ListUserFollowersRow, error) {
+			rows, err := s.queries.ListUserFollowersAfter(ctx, db.ListUserFollowersAfterParams{
+				FollowedID:      userID,
+				AfterFollowedAt: followedAt,
+				AfterID:         afterID,
+				Limit:           limit,
+			})
+			return toFollowerRows(rows, err)
+		},
+		count: func() (int64, error) {
+			counts, err := s.queries.GetUserFollowCounts(ctx, userID)
+			return counts.FollowerCount, err
+		},
+	})
+}
+
+// ListFollowing retrieves a page of the users a user follows, most recent
+// follow first
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: The following user
+//   - page: Pagination parameters
+//
+// Returns:
+//   - *FollowerPage: The followed users along with the applied pagination
+//   - error: ErrInvalidPagination, ErrInvalidCursor, ErrUserNotFound, or
+//     database errors
+func (s *FollowService) ListFollowing(ctx context.Context, userID int32, page PageRequest) (*FollowerPage, error) {
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	
+	return s.listUsers("user-following", page, followerQueries{
+		first: func(limit, offset int32) ([]db.ListUserFollowersRow, error) {
+			rows, err := s.queries.ListUserFollowing(ctx, db.ListUserFollowingParams{
+				FollowerID: userID,
+				Limit:      limit,
+				Offset:     offset,
+			})
+			return toFollowerRows(rows, err)
+		},
+		after: func(followedAt pgtype.Timestamptz, afterID, limit int32) ([]db.ListUserFollowersRow, error) {
+			rows, err := s.queries.ListUserFollowingAfter(ctx, db.ListUserFollowingAfterParams{
+				FollowerID:      userID,
+				AfterFollowedAt: followedAt,
+				AfterID:         afterID,
+				Limit:           limit,
+			})
+			return toFollowerRows(rows, err)
+		},
+		count: func() (int64, error) {
+			counts, err := s.queries.GetUserFollowCounts(ctx, userID)
+			return counts.FollowingCount, err
+		},
+	})
+}
+
+// ListGameFollowers retrieves a page of the users following a game, most
+// recent follow first
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the followed game
+//   - page: Pagination parameters
+//
+// Returns:
+//   - *FollowerPage: The followers along with the applied pagination
+//   - error: ErrInvalidPagination, ErrInvalidCursor, ErrGameNotFound, or
+//     database errors
+func (s *FollowService) ListGameFollowers(ctx context.Context, gameSlug string, page PageRequest) (*FollowerPage, error) {
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.listUsers("game-followers", page, followerQueries{
+		first: func(limit, offset int32) ([]db.ListUserFollowersRow, error) {
+			rows, err := s.queries.ListGameFollowers(ctx, db.ListGameFollowersParams{
+				GameID: game.ID,
+				Limit:  limit,
+				Offset: offset,
+			})
+			return toFollowerRows(rows, err)
+		},
+		after: func(followedAt pgtype.Timestamptz, afterID, limit int32) ([]db.ListUserFollowersRow, error) {
+			rows, err := s.queries.ListGameFollowersAfter(ctx, db.ListGameFollowersAfterParams{
+				GameID:          game.ID,
+				AfterFollowedAt: followedAt,
+				AfterID:         afterID,
+				Limit:           limit,
+			})
+			return toFollowerRows(rows, err)
+		},
+		count: func() (int64, error) {
+			return s.queries.CountGameFollowers(ctx, game.ID)
+		},
+	})
+}
+
+// ListFollowedGames retrieves a page of the games a user follows, most recent
+// follow first
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: The following user
+//   - page: Pagination parameters
+//
+// Returns:
+//   - *FollowedGamePage: The games along with the applied pagination
+//   - error: ErrInvalidPagination, ErrInvalidCursor, ErrUserNotFound, or
+//     database errors
+func (s *FollowService) ListFollowedGames(ctx context.Context, userID int32, page PageRequest) (*FollowedGamePage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	
+	var games []db.ListFollowedGamesRow
+	if page.Cursor == "" {
+		games, err = s.queries.ListFollowedGames(ctx, db.ListFollowedGamesParams{
+			UserID: userID,
+			Limit:  pageLimit + 1,
+			Offset: pageOffset,
+		})
+	} else {
+		var afterFollowedAt time.Time
+		var afterID int32
+		if err := decodeCursor(page.Cursor, "followed-games", &afterFollowedAt, &afterID); err != nil {
+			return nil, err
+		}
+		var rows []db.ListFollowedGamesAfterRow
+		rows, err = s.queries.ListFollowedGamesAfter(ctx, db.ListFollowedGamesAfterParams{
+			UserID:          userID,
+			AfterFollowedAt: pgtype.Timestamptz{Time: afterFollowedAt, Valid: true},
+			AfterID:         afterID,
+			Limit:           pageLimit + 1,
+		})
+		for _, row := range rows {
+			games = append(games, db.ListFollowedGamesRow(row))
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list followed games: %w", err)
+	}
+	games, more := trimPage(games, pageLimit)
+	
+	counts, err := s.queries.GetUserFollowCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count followed games: %w", err)
+	}
+	
+	result := &FollowedGamePage{
+		Games:  games,
+		Total:  counts.FollowedGameCount,
+		Limit:  pageLimit,
+		Offset: pageOffset,
+	}
+	if more {
+		last := games[len(games)-1]
+		result.NextCursor = encodeCursor("followed-games", last.FollowedAt.Time, last.GameID)
+	}
+	return result, nil
+}
+
+// followerQueries load one of the lists of users a FollowerPage is made of
+type followerQueries struct {
+	// first loads a page by limit and offset
+	first func(limit, offset int32) ([]db.ListUserFollowersRow, error)
+	
+	// after loads the page following the follow at followedAt by afterID
+	after func(followedAt pgtype.Timestamptz, afterID, limit int32) ([]db.ListUserFollowersRow, error)
+	
+	// count counts the whole list
+	count func() (int64, error)
+}
+
+// listUsers pages through a list of users, most recent follow first, with
+// cursors named after list
+func (s *FollowService) listUsers(list string, page PageRequest, queries followerQueries) (*FollowerPage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	
+	var users []db.ListUserFollowersRow
+	if page.Cursor == "" {
+		users, err = queries.first(pageLimit+1, pageOffset)
+	} else {
+		var afterFollowedAt time.Time
+		var afterID int32
+		if err := decodeCursor(page.Cursor, list, &afterFollowedAt, &afterID); err != nil {
+			return nil, err
+		}
+		users, err = queries.after(pgtype.Timestamptz{Time: afterFollowedAt, Valid: true}, afterID, pageLimit+1)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+	users, more := trimPage(users, pageLimit)
+	
+	count, err := queries.count()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count follows: %w", err)
+	}
+	
+	result := &FollowerPage{
+		Users:  users,
+		Total:  count,
+		Limit:  pageLimit,
+		Offset: pageOffset,
+	}
+	if more {
+		last := users[len(users)-1]
+		result.NextCursor = encodeCursor(list, last.FollowedAt.Time, last.UserID)
+	}
+	return result, nil
+}
+
+// getUser looks up a user taking part in a follow
+func (s *FollowService) getUser(ctx context.Context, id int32) (*db.User, error) {
+	user, err := s.queries.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	return &user, nil
+}
+
+// getGame looks up a followed game
+func (s *FollowService) getGame(ctx context.Context, slug string) (*db.Game, error) {
+	game, err := s.queries.GetGameBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	return &game, nil
+}
+
+// followerRow is any of the row types of the queries listing users by
+// follow, which all have the fields of db.ListUserFollowersRow
+type followerRow interface {
+	db.ListUserFollowersRow | db.ListUserFollowersAfterRow |
+		db.ListUserFollowingRow | db.ListUserFollowingAfterRow |
+		db.ListGameFollowersRow | db.ListGameFollowersAfterRow
+}
+
+// toFollowerRows converts rows to db.ListUserFollowersRow, passing err through
+func toFollowerRows[T followerRow](rows []T, err error) ([]db.ListUserFollowersRow, error) {
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.ListUserFollowersRow, len(rows))
+	for i, row := range rows {
+		result[i] = db.ListUserFollowersRow(row)
+	}
+	return result, nil
+}
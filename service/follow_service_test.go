@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) FollowUser(ctx context.Context, params db.FollowUserParams) (int64, error) {
+	if m.FollowUserFunc != nil {
+		return m.FollowUserFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) UnfollowUser(ctx context.Context, params db.UnfollowUserParams) (int64, error) {
+	if m.UnfollowUserFunc != nil {
+		return m.UnfollowUserFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) FollowGame(ctx context.Context, params db.FollowGameParams) (int64, error) {
+	if m.FollowGameFunc != nil {
+		return m.FollowGameFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) UnfollowGame(ctx context.Context, params db.UnfollowGameParams) (int64, error) {
+	if m.UnfollowGameFunc != nil {
+		return m.UnfollowGameFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) ListUserFollowers(ctx context.Context, params db.ListUserFollowersParams) ([]db.ListUserFollowersRow, error) {
+	if m.ListUserFollowersFunc != nil {
+		return m.ListUserFollowersFunc(ctx, params)
+	}
+	return []db.ListUserFollowersRow{}, nil
+}
+
+func (m *MockQueries) ListUserFollowersAfter(ctx context.Context, params db.ListUserFollowersAfterParams) ([]db.ListUserFollowersAfterRow, error) {
+	if m.ListUserFollowersAfterFunc != nil {
+		return m.ListUserFollowersAfterFunc(ctx, params)
+	}
+	return []db.ListUserFollowersAfterRow{}, nil
+}
+
+func (m *MockQueries) ListUserFollowing(ctx context.Context, params db.ListUserFollowingParams) ([]db.ListUserFollowingRow, error) {
+	if m.ListUserFollowingFunc != nil {
+		return m.ListUserFollowingFunc(ctx, params)
+	}
+	return []db.ListUserFollowingRow{}, nil
+}
+
+func (m *MockQueries) ListUserFollowingAfter(ctx context.Context, params db.ListUserFollowingAfterParams) ([]db.ListUserFollowingAfterRow, error) {
+	if m.ListUserFollowingAfterFunc != nil {
+		return m.ListUserFollowingAfterFunc(ctx, params)
+	}
+	return []db.ListUserFollowingAfterRow{}, nil
+}
+
+func (m *MockQueries) ListGameFollowers(ctx context.Context, params db.ListGameFollowersParams) ([]db.ListGameFollowersRow, error) {
+	if m.ListGameFollowersFunc != nil {
+		return m.ListGameFollowersFunc(ctx, params)
+	}
+	return []db.ListGameFollowersRow{}, nil
+}
+
+func (m *MockQueries) ListGameFollowersAfter(ctx context.Context, params db.ListGameFollowersAfterParams) ([]db.ListGameFollowersAfterRow, error) {
+	if m.ListGameFollowersAfterFunc != nil {
+		return m.ListGameFollowersAfterFunc(ctx, params)
+	}
+	return []db.ListGameFollowersAfterRow{}, nil
+}
+
+func (m *MockQueries) ListFollowedGames(ctx context.Context, params db.ListFollowedGamesParams) ([]db.ListFollowedGamesRow, error) {
+	if m.ListFollowedGamesFunc != nil {
+		return m.ListFollowedGamesFunc(ctx, params)
+	}
+	return []db.ListFollowedGamesRow{}, nil
+}
+
+func (m *MockQueries) ListFollowedGamesAfter(ctx context.Context, params db.ListFollowedGamesAfterParams) ([]db.ListFollowedGamesAfterRow, error) {
+	if m.ListFollowedGamesAfterFunc != nil {
+		return m.ListFollowedGamesAfterFunc(ctx, params)
+	}
+	return []db.ListFollowedGamesAfterRow{}, nil
+}
+
+func (m *MockQueries) GetUserFollowCounts(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error) {
+	if m.GetUserFollowCountsFunc != nil {
+		return m.GetUserFollowCountsFunc(ctx, userID)
+	}
+	return db.GetUserFollowCountsRow{}, nil
+}
+
+func (m *MockQueries) CountGameFollowers(ctx context.Context, gameID int32) (int64, error) {
+	if m.CountGameFollowersFunc != nil {
+		return m.CountGameFollowersFunc(ctx, gameID)
+	}
+	return 0, nil
+}
+
+// userLookup finds the users with the given IDs and no others
+func userLookup(ids ...int32) func(ctx context.Context, id int32) (db.User, error) {
+	return func(ctx context.Context, id int32) (db.User, error) {
+		for _, known := range ids {
+			if id == known {
+				return db.User{ID: id}, nil
+			}
+		}
+		return db.User{}, sql.ErrNoRows
+	}
+}
+
+func TestFollowUser(t *testing.T) {
+	var params db.FollowUserParams
+	var audited []string
+	added := int64(1)
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: userLookup(1, 2),
+		FollowUserFunc: func(ctx context.Context, p db.FollowUserParams) (int64, error) {
+			params = p
+			return added, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = append(audited, p.Action)
+			return nil
+		},
+	}
+	service := NewFollowService(mockQueries)
+
+	if err := service.FollowUser(asUser(1), 2); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.FollowerID != 1 || params.FollowedID != 2 {
+		t.Errorf("expected user 1 to follow user 2, got %+v", params)
+	}
+	added = 0
+	if err := service.FollowUser(asUser(1), 2); err != nil {
+		t.Errorf("following again: expected no error, got %v", err)
+	}
+	if len(audited) != 1 || audited[0] != "user.follow" {
+		t.Errorf("expected only the new follow audited, got %v", audited)
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		userID int32
+		want   error
+	}{
+		{"self", asUser(1), 1, ErrInvalidInput},
+		{"missing user", asUser(1), 3, ErrUserNotFound},
+		{"anonymous", context.Background(), 2, ErrForbidden},
+	}
+	for _, tt := range tests {
+		if err := service.FollowUser(tt.ctx, tt.userID); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestUnfollowUser(t *testing.T) {
+	var audited []string
+	removed := int64(1)
+	mockQueries := &MockQueries{
+		UnfollowUserFunc: func(ctx context.Context, p db.UnfollowUserParams) (int64, error) {
+			if p.FollowerID != 1 || p.FollowedID != 2 {
+				t.Errorf("expected user 1 to unfollow user 2, got %+v", p)
+			}
+			return removed, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = append(audited, p.Action)
+			return nil
+		},
+	}
+	service := NewFollowService(mockQueries)
+
+	if err := service.UnfollowUser(asUser(1), 2); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	removed = 0
+	if err := service.UnfollowUser(asUser(1), 2); err != nil {
+		t.Errorf("not following: expected no error, got %v", err)
+	}
+	if len(audited) != 1 || audited[0] != "user.unfollow" {
+		t.Errorf("expected only the removed follow audited, got %v", audited)
+	}
+}
+
+func TestFollowGame(t *testing.T) {
+	var params db.FollowGameParams
+	var unfollowed db.UnfollowGameParams
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		FollowGameFunc: func(ctx context.Context, p db.FollowGameParams) (int64, error) {
+			params = p
+			return 1, nil
+		},
+		UnfollowGameFunc: func(ctx context.Context, p db.UnfollowGameParams) (int64, error) {
+			unfollowed = p
+			return 1, nil
+		},
+	}
+	service := NewFollowService(mockQueries)
+
+	if err := service.FollowGame(asUser(1), "super-mario-64"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.UserID != 1 || params.GameID != 4 {
+		t.Errorf("expected user 1 to follow game 4, got %+v", params)
+	}
+	if err := service.UnfollowGame(asUser(1), "super-mario-64"); err != nil || unfollowed.GameID != 4 {
+		t.Errorf("expected game 4 unfollowed, got %+v, %v", unfollowed, err)
+	}
+	if err := service.FollowGame(asUser(1), "missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("missing game: expected ErrGameNotFound, got %v", err)
+	}
+	if err := service.FollowGame(context.Background(), "super-mario-64"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("anonymous: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestListFollowers_Paginates(t *testing.T) {
+	followedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var after db.ListUserFollowersAfterParams
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: userLookup(1),
+		ListUserFollowersFunc: func(ctx context.Context, p db.ListUserFollowersParams) ([]db.ListUserFollowersRow, error) {
+			if p.FollowedID != 1 || p.Limit != 3 {
+				t.Errorf("expected one more follower of user 1 than the limit, got %+v", p)
+			}
+			return []db.ListUserFollowersRow{
+				{UserID: 9, FollowedAt: pgtype.Timestamptz{Time: followedAt.Add(time.Hour), Valid: true}},
+				{UserID: 7, FollowedAt: pgtype.Timestamptz{Time: followedAt, Valid: true}},
+				{UserID: 5, FollowedAt: pgtype.Timestamptz{Time: followedAt, Valid: true}},
+			}, nil
+		},
+		ListUserFollowersAfterFunc: func(ctx context.Context, p db.ListUserFollowersAfterParams) ([]db.ListUserFollowersAfterRow, error) {
+			after = p
+			return []db.ListUserFollowersAfterRow{{UserID: 5}}, nil
+		},
+		GetUserFollowCountsFunc: func(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error) {
+			return db.GetUserFollowCountsRow{FollowerCount: 3, FollowingCount: 8}, nil
+		},
+	}
+	service := NewFollowService(mockQueries)
+
+	page, err := service.ListFollowers(context.Background(), 1, PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Users) != 2 || page.Total != 3 || page.NextCursor == "" {
+		t.Fatalf("expected two of three followers and a cursor, got %+v", page)
+	}
+
+	next, err := service.ListFollowers(context.Background(), 1, PageRequest{Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.AfterID != 7 || !after.AfterFollowedAt.Time.Equal(followedAt) {
+		t.Errorf("expected the next page after follower 7, got %+v", after)
+	}
+	if len(next.Users) != 1 || next.Users[0].UserID != 5 || next.NextCursor != "" {
+		t.Errorf("expected the last follower and no cursor, got %+v", next)
+	}
+
+	if _, err := service.ListFollowing(context.Background(), 1, PageRequest{Cursor: page.NextCursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cursor from another list: expected ErrInvalidCursor, got %v", err)
+	}
+	if _, err := service.ListFollowers(context.Background(), 2, PageRequest{}); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("missing user: expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestListFollowing(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: userLookup(1),
+		ListUserFollowingFunc: func(ctx context.Context, p db.ListUserFollowingParams) ([]db.ListUserFollowingRow, error) {
+			if p.FollowerID != 1 {
+				t.Errorf("expected users followed by user 1, got %+v", p)
+			}
+			return []db.ListUserFollowingRow{{UserID: 2, Name: "Jane Doe"}}, nil
+		},
+		GetUserFollowCountsFunc: func(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error) {
+			return db.GetUserFollowCountsRow{FollowerCount: 5, FollowingCount: 1}, nil
+		},
+	}
+	service := NewFollowService(mockQueries)
+
+	page, err := service.ListFollowing(context.Background(), 1, PageRequest{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Users) != 1 || page.Users[0].Name != "Jane Doe" || page.Total != 1 {
+		t.Errorf("expected the one followed user, got %+v", page)
+	}
+}
+
+func TestListGameFollowers(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		ListGameFollowersFunc: func(ctx context.Context, p db.ListGameFollowersParams) ([]db.ListGameFollowersRow, error) {
+			if p.GameID != 4 {
+				t.Errorf("expected followers of game 4, got %+v", p)
+			}
+			return []db.ListGameFollowersRow{{UserID: 2}, {UserID: 3}}, nil
+		},
+		CountGameFollowersFunc: func(ctx context.Context, gameID int32) (int64, error) {
+			return 2, nil
+		},
+	}
+	service := NewFollowService(mockQueries)
+
+	page, err := service.ListGameFollowers(context.Background(), "super-mario-64", PageRequest{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Users) != 2 || page.Total != 2 {
+		t.Errorf("expected both followers, got %+v", page)
+	}
+	if _, err := service.ListGameFollowers(context.Background(), "missing", PageRequest{}); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("missing game: expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestListFollowedGames(t *testing.T) {
+	followedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var after db.ListFollowedGamesAfterParams
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: userLookup(1),
+		ListFollowedGamesFunc: func(ctx context.Context, p db.ListFollowedGamesParams) ([]db.ListFollowedGamesRow, error) {
+			return []db.ListFollowedGamesRow{
+				{GameID: 4, Slug: "super-mario-64", FollowedAt: pgtype.Timestamptz{Time: followedAt, Valid: true}},
+				{GameID: 2, Slug: "celeste", FollowedAt: pgtype.Timestamptz{Time: followedAt, Valid: true}},
+			}, nil
+		},
+		ListFollowedGamesAfterFunc: func(ctx context.Context, p db.ListFollowedGamesAfterParams) ([]db.ListFollowedGamesAfterRow, error) {
+			after = p
+			return []db.ListFollowedGamesAfterRow{{GameID: 2, Slug: "celeste"}}, nil
+		},
+		GetUserFollowCountsFunc: func(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error) {
+			return db.GetUserFollowCountsRow{FollowedGameCount: 2}, nil
+		},
+	}
+	service := NewFollowService(mockQueries)
+
+	page, err := service.ListFollowedGames(context.Background(), 1, PageRequest{Limit: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Games) != 1 || page.Games[0].Slug != "super-mario-64" || page.Total != 2 || page.NextCursor == "" {
+		t.Fatalf("expected the first of two games and a cursor, got %+v", page)
+	}
+
+	next, err := service.ListFollowedGames(context.Background(), 1, PageRequest{Limit: 1, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.AfterID != 4 || !after.AfterFollowedAt.Time.Equal(followedAt) || len(next.Games) != 1 || next.Games[0].Slug != "celeste" {
+		t.Errorf("expected the page after game 4, got %+v from %+v", next, after)
+	}
+}
@@ -0,0 +1,473 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/example/speedrun-rest-api/cache"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	// ErrGameNotFound is returned when a game is not found
+	ErrGameNotFound = errors.New("game not found")
+	
+	// ErrDuplicateSlug is returned when a slug is already used by another game
+	ErrDuplicateSlug = errors.New("game with this slug already exists")
+)
+
+const (
+	// gamesSlugKey is the unique constraint on games.slug
+	gamesSlugKey = "games_slug_key"
+	
+	// maxSlugLength matches the VARCHAR(100) games.slug column
+	maxSlugLength = 100
+	
+	// maxDisplayNameLength matches the VARCHAR(255) games.name and
+	// categories.name columns
+	maxDisplayNameLength = 255
+	
+	// defaultGameSuggestions is how many games SuggestGames returns when no
+	// limit is given, and maxGameSuggestions the most it returns
+	defaultGameSuggestions = 5
+	maxGameSuggestions     = 10
+	
+	// maxSuggestQueryLength caps what SuggestGames matches, which is typed
+	// into a search box rather than being a whole name
+	maxSuggestQueryLength = 100
+)
+
+// reservedGameSlugs are paths under /games that would hide a game with the
+// same slug
+var reservedGameSlugs = []string{"suggest"}
+
+// slugPattern allows lowercase words of letters and digits joined by hyphens
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// GameService handles business logic for game operations
+type GameService struct {
+	queries db.Store
+	pages   pageSizes
+	
+	// cache holds games read by GetGameBySlug; nil disables caching
+	cache *cache.Cache
+	
+	// gameLookups coalesces concurrent GetGameBySlug calls for the same slug
+	gameLookups singleflight.Group
+}
+
+// GamePage is one page of games along with the pagination that was
+// actually applied
+type GamePage struct {
+	Games  []db.Game
+	Total  int64
+	Limit  int32
+	Offset int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// GameOption configures optional GameService behavior
+type GameOption func(*GameService)
+
+// WithGamePageSizes sets the limit applied when a list request omits one and
+// the largest limit a list request may ask for
+func WithGamePageSizes(defaultSize, maxSize int) GameOption {
+	return func(s *GameService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// WithGameCache caches the games GetGameBySlug returns in c; updating or
+// deleting a game invalidates its entry
+func WithGameCache(c *cache.Cache) GameOption {
+	return func(s *GameService) {
+		s.cache = c
+	}
+}
+
+// NewGameService creates a new GameService instance
+func NewGameService(queries db.Store, opts ...GameOption) *GameService {
+	s := &GameService{
+		queries: queries,
+		pages:   defaultPageSizes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetGameBySlug retrieves a game by its slug
+//
+// Games are served from the cache when WithGameCache configured one, and
+// concurrent lookups of the same slug share one query.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: The game's URL-safe identifier
+//
+// Returns:
+//   - *db.Game: The game object if found
+//   - error: ErrGameNotFound if the game doesn't exist, or database errors
+func (s *GameService) GetGameBySlug(ctx context.Context, slug string) (*db.Game, error) {
+	var game db.Game
+	if s.cache.Get(ctx, cacheGames, gameCacheKey(slug), &game) {
+		return &game, nil
+	}
+	
+	game, err := coalesce(ctx, &s.gameLookups, slug, func(ctx context.Context) (db.Game, error) {
+		ctx = cacheFillContext(ctx, s.cache)
+		game, err := s.queries.GetGameBySlug(ctx, slug)
+		if err == nil {
+			s.cache.Set(ctx, gameCacheKey(slug), game)
+		}
+		return game, err
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	return &game, nil
+}
+
+// GetGamesByIDs retrieves several games with a single query, for callers
+// resolving the games of many categories at once
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - ids: The game IDs to look up
+//
+// Returns:
+//   - []db.Game: Games that were found, ordered by ID
+//   - error: Database errors
+func (s *GameService) GetGamesByIDs(ctx context.Context, ids []int32) ([]db.Game, error) {
+	games, err := s.queries.GetGamesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get games: %w", err)
+	}
+	
+	return games, nil
+}
+
+// ListGames retrieves a paginated list of games, ordered by ID
+//
+// Pagination follows the same policy as ListUsers.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - page: Requested page size and either an offset or a cursor
+//
+// Returns:
+//   - *GamePage: The games, total count, the effective limit and offset,
+//     and the cursor of the next page
+//   - error: ErrInvalidInput or ErrInvalidCursor for a bad page request,
+//     or database errors
+func (s *GameService) ListGames(ctx context.Context, page PageRequest) (*GamePage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	
+	var games []db.Game
+	if page.Cursor == "" {
+		games, err = s.queries.ListGames(ctx, db.ListGamesParams{
+			Limit:  pageLimit + 1,
+			Offset: pageOffset,
+		})
+	} else {
+		var afterID int32
+		if err := decodeCursor(page.Cursor, "games", &afterID); err != nil {
+			return nil, err
+		}
+		games, err = s.queries.ListGamesAfter(ctx, db.ListGamesAfterParams{
+			AfterID: afterID,
+			Limit:   pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list games: %w", err)
+	}
+	games, more := trimPage(games, pageLimit)
+	
+	count, err := s.queries.CountGames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count games: %w", err)
+	}
+	
+	result := &GamePage{
+		Games:  games,
+		Total:  count,
+		Limit:  pageLimit,
+		Offset: pageOffset,
+	}
+	if more {
+		result.NextCursor = encodeCursor("games", games[len(games)-1].ID)
+	}
+	return result, nil
+}
+
+// SuggestGames retrieves the games a search box should suggest for what has
+// been typed into it so far
+//
+// Games whose name or slug starts with the query come first, followed by
+// games with a word like it anywhere in their name, so that "zeld" finds
+// The Legend of Zelda. Results
+// are cached when WithGameCache configured a cache, until a game changes.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - query: What has been typed; case is ignored
+//   - limit: Maximum number of games; zero selects the default of 5, and
+//     more than 10 is clamped to 10
+//
+// Returns:
+//   - []db.Game: The suggested games, best match first
+//   - error: ErrInvalidInput for a blank or overlong query, or database
+//     errors
+func (s *GameService) SuggestGames(ctx context.Context, query string, limit int) ([]db.Game, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, invalidField("q", "must not be blank")
+	}
+	if utf8.RuneCountInString(query) > maxSuggestQueryLength {
+		return nil, invalidField("q", "must be at most %d characters", maxSuggestQueryLength)
+	}
+	if limit <= 0 {
+		limit = defaultGameSuggestions
+	}
+	limit = min(limit, maxGameSuggestions)
+	
+	version, cacheable := s.cache.Version(ctx, gameSuggestionsVersionKey)
+	cacheKey := gameSuggestionsCacheKey(version, int32(limit), query)
+	var games []db.Game
+	if cacheable && s.cache.Get(ctx, cacheGameSuggestions, cacheKey, &games) {
+		return games, nil
+	}
+	
+	games, err := s.queries.SuggestGames(cacheFillContext(ctx, s.cache), db.SuggestGamesParams{
+		Prefix: escapeLike(query),
+		Query:  query,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest games: %w", err)
+	}
+	if cacheable {
+		s.cache.Set(ctx, cacheKey, games)
+	}
+	return games, nil
+}
+
+// CreateGame creates a new game
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: URL-safe identifier for the game
+//   - name: Display name of the game
+//
+// Returns:
+//   - *db.Game: The created game
+//   - error: ErrInvalidInput, ErrDuplicateSlug, or database errors
+func (s *GameService) CreateGame(ctx context.Context, slug, name string) (*db.Game, error) {
+	if err := validateGameSlug(slug); err != nil {
+		return nil, err
+	}
+	name, err := validateDisplayName(name)
+	if err != nil {
+		return nil, err
+	}
+	
+	var game db.Game
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		game, err = q.CreateGame(ctx, db.CreateGameParams{
+			Slug: slug,
+			Name: name,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "game.create", AuditEntityGame, game.ID, nil, game)
+	})
+	if err != nil {
+		if isDuplicateSlugError(err) {
+			return nil, ErrDuplicateSlug
+		}
+		return nil, fmt.Errorf("failed to create game: %w", err)
+	}
+	
+	s.cache.Bump(ctx, gameSuggestionsVersionKey)
+	return &game, nil
+}
+
+// UpdateGame updates an existing game's information; only admins and the
+// game's super moderators may
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - slug: Current slug of the game to update
+//   - newSlug: New slug (optional, empty string means no change)
+//   - name: New name (optional, empty string means no change)
+//
+// Returns:
+//   - *db.Game: The updated game
+//   - error: ErrGameNotFound, ErrForbidden, ErrDuplicateSlug, ErrInvalidInput,
+//     or database errors
+func (s *GameService) UpdateGame(ctx context.Context, slug, newSlug, name string) (*db.Game, error) {
+	if newSlug != "" {
+		if err := validateGameSlug(newSlug); err != nil {
+			return nil, err
+		}
+	}
+	if name != "" {
+		validated, err := validateDisplayName(name)
+		if err != nil {
+			return nil, err
+		}
+		name = validated
+	}
+	
+	// Read past the cache, so a stale entry can't supply the unchanged fields
+	existing, err := s.queries.GetGameBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	if err := requireGameManager(ctx, existing.ID); err != nil {
+		return nil, err
+	}
+	
+	// Use existing values if not provided
+	if newSlug == "" {
+		newSlug = existing.Slug
+	}
+	if name == "" {
+		name = existing.Name
+	}
+	
+	var game db.Game
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		game, err = q.UpdateGame(ctx, db.UpdateGameParams{
+			Slug: newSlug,
+			Name: name,
+			ID:   existing.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "game.update", AuditEntityGame, game.ID, existing, game)
+	})
+	if err != nil {
+		if isDuplicateSlugError(err) {
+			return nil, ErrDuplicateSlug
+		}
+		return nil, fmt.Errorf("failed to update game: %w", err)
+	}
+	
+	s.cache.Delete(ctx, gameCacheKey(slug), gameCacheKey(game.Slug))
+	s.cache.Bump(ctx, gameSuggestionsVersionKey)
+	return &game, nil
+}
+
+// DeleteGame deletes a game by its slug
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: Slug of the game to delete
+//
+// Returns:
+//   - error: ErrGameNotFound if the game doesn't exist, or database errors
+func (s *GameService) DeleteGame(ctx context.Context, slug string) error {
+	err := s.queries.WithTx(ctx, func(q db.Querier) error {
+		game, err := q.GetGameBySlug(ctx, slug)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrGameNotFound
+			}
+			return fmt.Errorf("failed to get game: %w", err)
+		}
+		
+		deleted, err := q.DeleteGame(ctx, slug)
+		if err != nil {
+			return fmt.Errorf("failed to delete game: %w", err)
+		}
+		if deleted == 0 {
+			return ErrGameNotFound
+		}
+		
+		return recordAudit(ctx, q, "game.delete", AuditEntityGame, game.ID, game, nil)
+	})
+	if err != nil {
+		return err
+	}
+	
+	s.cache.Delete(ctx, gameCacheKey(slug))
+	s.cache.Bump(ctx, gameSuggestionsVersionKey)
+	return nil
+}
+
+// validateSlug checks that a slug is non-empty, URL-safe, and fits the column
+func validateSlug(slug string) error {
+	if slug == "" {
+		return invalidField("slug", "must not be empty")
+	}
+	if len(slug) > maxSlugLength {
+		return invalidField("slug", "must be at most %d characters", maxSlugLength)
+	}
+	if !slugPattern.MatchString(slug) {
+		return invalidField("slug", "may only contain lowercase letters, digits, and single hyphens")
+	}
+	return nil
+}
+
+// validateGameSlug checks a game's slug like validateSlug and that it isn't
+// reserved
+func validateGameSlug(slug string) error {
+	if err := validateSlug(slug); err != nil {
+		return err
+	}
+	if slices.Contains(reservedGameSlugs, slug) {
+		return invalidField("slug", "is reserved")
+	}
+	return nil
+}
+
+// validateDisplayName trims the name of a game or category and checks it is
+// non-empty and fits the VARCHAR(255) column
+func validateDisplayName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	
+	if name == "" {
+		return "", invalidField("name", "must not be empty")
+	}
+	if utf8.RuneCountInString(name) > maxDisplayNameLength {
+		return "", invalidField("name", "must be at most %d characters", maxDisplayNameLength)
+	}
+	
+	return name, nil
+}
+
+// isDuplicateSlugError reports whether err is a unique violation on the
+// games.slug constraint
+func isDuplicateSlugError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == gamesSlugKey
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/cache"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (m *MockQueries) GetGameBySlug(ctx context.Context, slug string) (db.Game, error) {
+	if m.GetGameBySlugFunc != nil {
+		return m.GetGameBySlugFunc(ctx, slug)
+	}
+	return db.Game{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetGamesByIDs(ctx context.Context, ids []int32) ([]db.Game, error) {
+	if m.GetGamesByIDsFunc != nil {
+		return m.GetGamesByIDsFunc(ctx, ids)
+	}
+	return []db.Game{}, nil
+}
+
+func (m *MockQueries) ListGames(ctx context.Context, params db.ListGamesParams) ([]db.Game, error) {
+	if m.ListGamesFunc != nil {
+		return m.ListGamesFunc(ctx, params)
+	}
+	return []db.Game{}, nil
+}
+
+func (m *MockQueries) ListGamesAfter(ctx context.Context, params db.ListGamesAfterParams) ([]db.Game, error) {
+	if m.ListGamesAfterFunc != nil {
+		return m.ListGamesAfterFunc(ctx, params)
+	}
+	return []db.Game{}, nil
+}
+
+func (m *MockQueries) SuggestGames(ctx context.Context, params db.SuggestGamesParams) ([]db.Game, error) {
+	if m.SuggestGamesFunc != nil {
+		return m.SuggestGamesFunc(ctx, params)
+	}
+	return []db.Game{}, nil
+}
+
+func (m *MockQueries) CountGames(ctx context.Context) (int64, error) {
+	if m.CountGamesFunc != nil {
+		return m.CountGamesFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) CreateGame(ctx context.Context, params db.CreateGameParams) (db.Game, error) {
+	if m.CreateGameFunc != nil {
+		return m.CreateGameFunc(ctx, params)
+	}
+	return db.Game{}, nil
+}
+
+func (m *MockQueries) UpdateGame(ctx context.Context, params db.UpdateGameParams) (db.Game, error) {
+	if m.UpdateGameFunc != nil {
+		return m.UpdateGameFunc(ctx, params)
+	}
+	return db.Game{}, nil
+}
+
+func (m *MockQueries) DeleteGame(ctx context.Context, slug string) (int64, error) {
+	if m.DeleteGameFunc != nil {
+		return m.DeleteGameFunc(ctx, slug)
+	}
+	return 0, nil
+}
+
+func TestGetGameBySlug_NotFound(t *testing.T) {
+	service := NewGameService(&MockQueries{})
+	_, err := service.GetGameBySlug(context.Background(), "missing")
+
+	if !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestListGames_NormalizesPagination(t *testing.T) {
+	var params db.ListGamesParams
+	mockQueries := &MockQueries{
+		ListGamesFunc: func(ctx context.Context, p db.ListGamesParams) ([]db.Game, error) {
+			params = p
+			return []db.Game{{ID: 1, Slug: "super-mario-64"}}, nil
+		},
+		CountGamesFunc: func(ctx context.Context) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	service := NewGameService(mockQueries, WithGamePageSizes(20, 50))
+	page, err := service.ListGames(context.Background(), PageRequest{Limit: 500, Offset: -1})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if page.Limit != 50 || page.Offset != 0 {
+		t.Errorf("expected limit=50 offset=0, got limit=%d offset=%d", page.Limit, page.Offset)
+	}
+	// One row past the page is fetched to detect a next page
+	if params.Limit != 51 || params.Offset != 0 {
+		t.Errorf("expected query to use effective values, got %+v", params)
+	}
+	if page.Total != 1 || len(page.Games) != 1 {
+		t.Errorf("expected 1 game, got %d (total %d)", len(page.Games), page.Total)
+	}
+}
+
+func TestCreateGame_Success(t *testing.T) {
+	mockQueries := &MockQueries{
+		CreateGameFunc: func(ctx context.Context, params db.CreateGameParams) (db.Game, error) {
+			return db.Game{ID: 1, Slug: params.Slug, Name: params.Name}, nil
+		},
+	}
+
+	service := NewGameService(mockQueries)
+	game, err := service.CreateGame(context.Background(), "super-mario-64", "  Super Mario 64 ")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if game.Name != "Super Mario 64" {
+		t.Errorf("expected trimmed name, got %q", game.Name)
+	}
+}
+
+func TestCreateGame_InvalidInput(t *testing.T) {
+	tests := []struct {
+		slug string
+		name string
+	}{
+		{"", "Super Mario 64"},
+		{"Super-Mario", "Super Mario 64"},
+		{"super--mario", "Super Mario 64"},
+		{"-mario", "Super Mario 64"},
+		{"super mario", "Super Mario 64"},
+		{strings.Repeat("a", 101), "Super Mario 64"},
+		{"super-mario-64", "   "},
+		{"super-mario-64", strings.Repeat("a", 256)},
+	}
+
+	service := NewGameService(&MockQueries{})
+	for _, tt := range tests {
+		_, err := service.CreateGame(context.Background(), tt.slug, tt.name)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("CreateGame(%q, %q): expected ErrInvalidInput, got %v", tt.slug, tt.name, err)
+		}
+	}
+}
+
+func TestCreateGame_DuplicateSlug(t *testing.T) {
+	mockQueries := &MockQueries{
+		CreateGameFunc: func(ctx context.Context, params db.CreateGameParams) (db.Game, error) {
+			return db.Game{}, &pgconn.PgError{Code: "23505", ConstraintName: "games_slug_key"}
+		},
+	}
+
+	service := NewGameService(mockQueries)
+	_, err := service.CreateGame(context.Background(), "super-mario-64", "Super Mario 64")
+
+	if !errors.Is(err, ErrDuplicateSlug) {
+		t.Errorf("expected ErrDuplicateSlug, got %v", err)
+	}
+}
+
+func TestUpdateGame_KeepsUnchangedFields(t *testing.T) {
+	var params db.UpdateGameParams
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: func(ctx context.Context, slug string) (db.Game, error) {
+			return db.Game{ID: 7, Slug: slug, Name: "Super Mario 64"}, nil
+		},
+		UpdateGameFunc: func(ctx context.Context, p db.UpdateGameParams) (db.Game, error) {
+			params = p
+			return db.Game{ID: p.ID, Slug: p.Slug, Name: p.Name}, nil
+		},
+	}
+
+	service := NewGameService(mockQueries)
+	_, err := service.UpdateGame(asAdmin(), "super-mario-64", "sm64", "")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := db.UpdateGameParams{Slug: "sm64", Name: "Super Mario 64", ID: 7}
+	if params != expected {
+		t.Errorf("expected %+v, got %+v", expected, params)
+	}
+}
+
+func TestUpdateGame_Authorization(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"anonymous", context.Background(), ErrForbidden},
+		{"global moderator", asUser(5, auth.Grant{Role: auth.RoleModerator}), ErrForbidden},
+		{"verifier", asUser(5, auth.Grant{Role: auth.RoleVerifier, GameID: 7}), ErrForbidden},
+		{"super moderator of another game", asUser(5, auth.Grant{Role: auth.RoleSuperModerator, GameID: 8}), ErrForbidden},
+		{"super moderator", asUser(5, auth.Grant{Role: auth.RoleSuperModerator, GameID: 7}), nil},
+		{"admin", asAdmin(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			mockQueries := &MockQueries{
+				GetGameBySlugFunc: func(ctx context.Context, slug string) (db.Game, error) {
+					return db.Game{ID: 7, Slug: slug, Name: "Super Mario 64"}, nil
+				},
+				UpdateGameFunc: func(ctx context.Context, p db.UpdateGameParams) (db.Game, error) {
+					updated = true
+					return db.Game{ID: p.ID, Slug: p.Slug, Name: p.Name}, nil
+				},
+			}
+
+			service := NewGameService(mockQueries)
+			_, err := service.UpdateGame(tt.ctx, "super-mario-64", "", "Super Mario 64 DS")
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if updated != (tt.want == nil) {
+				t.Errorf("expected update to happen only when allowed, updated = %v", updated)
+			}
+		})
+	}
+}
+
+func TestUpdateGame_NotFound(t *testing.T) {
+	service := NewGameService(&MockQueries{})
+	_, err := service.UpdateGame(context.Background(), "missing", "", "New Name")
+
+	if !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestUpdateGame_InvalidatesCachedGame(t *testing.T) {
+	games := map[string]db.Game{"super-mario-64": {ID: 7, Slug: "super-mario-64", Name: "Super Mario 64"}}
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: func(ctx context.Context, slug string) (db.Game, error) {
+			if game, ok := games[slug]; ok {
+				return game, nil
+			}
+			return db.Game{}, sql.ErrNoRows
+		},
+		UpdateGameFunc: func(ctx context.Context, p db.UpdateGameParams) (db.Game, error) {
+			delete(games, "super-mario-64")
+			games[p.Slug] = db.Game{ID: p.ID, Slug: p.Slug, Name: p.Name}
+			return games[p.Slug], nil
+		},
+	}
+
+	service := NewGameService(mockQueries, WithGameCache(cache.New(cache.NewMemory(), time.Minute)))
+	if _, err := service.GetGameBySlug(context.Background(), "super-mario-64"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := service.UpdateGame(asAdmin(), "super-mario-64", "sm64", ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := service.GetGameBySlug(context.Background(), "super-mario-64"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected the old slug to be invalidated, got %v", err)
+	}
+	if game, err := service.GetGameBySlug(context.Background(), "sm64"); err != nil || game.ID != 7 {
+		t.Errorf("expected the game under its new slug, got %+v, %v", game, err)
+	}
+}
+
+func TestSuggestGames(t *testing.T) {
+	var params []db.SuggestGamesParams
+	mockQueries := &MockQueries{
+		SuggestGamesFunc: func(ctx context.Context, p db.SuggestGamesParams) ([]db.Game, error) {
+			params = append(params, p)
+			return []db.Game{{ID: 1, Slug: "sm64", Name: "Super Mario 64"}}, nil
+		},
+		CreateGameFunc: func(ctx context.Context, p db.CreateGameParams) (db.Game, error) {
+			return db.Game{ID: 2, Slug: p.Slug, Name: p.Name}, nil
+		},
+	}
+	service := NewGameService(mockQueries, WithGameCache(cache.New(cache.NewMemory(), time.Minute)))
+
+	for _, query := range []string{" SM_64 ", "sm_64"} {
+		games, err := service.SuggestGames(context.Background(), query, 50)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(games) != 1 || games[0].Slug != "sm64" {
+			t.Errorf("expected sm64 to be suggested, got %+v", games)
+		}
+	}
+	if len(params) != 1 {
+		t.Fatalf("expected the repeated query to be served from the cache, got %d queries", len(params))
+	}
+	if p := params[0]; p.Query != "sm_64" || p.Prefix != `sm\_64` || p.Limit != maxGameSuggestions {
+		t.Errorf("expected the query lowercased, escaped for LIKE, and the limit clamped, got %+v", p)
+	}
+
+	if _, err := service.CreateGame(context.Background(), "sm64-ds", "Super Mario 64 DS"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := service.SuggestGames(context.Background(), "sm_64", 50); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(params) != 2 {
+		t.Errorf("expected creating a game to invalidate the cached suggestions, got %d queries", len(params))
+	}
+
+	_, err := service.SuggestGames(context.Background(), "   ", 0)
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || invalid.Fields[0].Field != "q" {
+		t.Errorf("expected q to be invalid when it is blank, got %v", err)
+	}
+}
+
+func TestCreateGame_RejectsReservedSlug(t *testing.T) {
+	service := NewGameService(&MockQueries{})
+	_, err := service.CreateGame(context.Background(), "suggest", "Suggest")
+
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || invalid.Fields[0].Field != "slug" {
+		t.Errorf("expected the slug to be rejected, got %v", err)
+	}
+}
+
+func TestDeleteGame(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: func(ctx context.Context, slug string) (db.Game, error) {
+			if slug == "super-mario-64" {
+				return db.Game{ID: 1, Slug: slug}, nil
+			}
+			return db.Game{}, sql.ErrNoRows
+		},
+		DeleteGameFunc: func(ctx context.Context, slug string) (int64, error) {
+			if slug == "super-mario-64" {
+				return 1, nil
+			}
+			return 0, nil
+		},
+	}
+
+	service := NewGameService(mockQueries)
+	if err := service.DeleteGame(context.Background(), "super-mario-64"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := service.DeleteGame(context.Background(), "missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
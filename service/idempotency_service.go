@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent
+	// again with a different request than the one it was first used for
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+	
+	// ErrIdempotencyKeyInProgress is returned when the request an idempotency
+	// key was first used for is still being served
+	ErrIdempotencyKeyInProgress = errors.New("idempotency key is in use by a request in progress")
+)
+
+// StoredResponse is a response recorded for an idempotency key, replayed
+// when the request is retried
+type StoredResponse struct {
+	StatusCode int
+	Header     map[string]string
+	Body       []byte
+}
+
+// IdempotencyService records responses to requests sent with an
+// idempotency key, so retries get the original response instead of
+// repeating the operation
+//
+// Keys belong to the calling user: the same key sent by two users refers to
+// two unrelated requests.
+type IdempotencyService struct {
+	queries db.Querier
+}
+
+// NewIdempotencyService creates a new IdempotencyService
+func NewIdempotencyService(queries db.Querier) *IdempotencyService {
+	return &IdempotencyService{queries: queries}
+}
+
+// Claim reserves key for a request, unless the caller has used it before
+//
+// A caller that claims a key must call Complete or Release once the request
+// has been served.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - key: The client's idempotency key
+//   - requestHash: A hash of the request, so a key reused for a different
+//     request is detected
+//
+// Returns:
+//   - *StoredResponse: The response to replay, or nil if the key was claimed
+//     and the request should be served
+//   - error: ErrForbidden, ErrIdempotencyKeyReused,
+//     ErrIdempotencyKeyInProgress, or database errors
+func (s *IdempotencyService) Claim(ctx context.Context, key string, requestHash []byte) (*StoredResponse, error) {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	
+	claimed, err := s.queries.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+		UserID:      p.UserID,
+		Key:         key,
+		RequestHash: requestHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	if claimed == 1 {
+		return nil, nil
+	}
+	
+	existing, err := s.queries.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{UserID: p.UserID, Key: key})
+	if err != nil {
+		// The key was released between the claim and this lookup; the
+		// client's next retry can claim it
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if !bytes.Equal(existing.RequestHash, requestHash) {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if !existing.StatusCode.Valid {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	
+	response := &StoredResponse{
+		StatusCode: int(existing.StatusCode.Int32),
+		Body:       existing.ResponseBody,
+	}
+	if err := json.Unmarshal(existing.ResponseHeaders, &response.Header); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response headers: %w", err)
+	}
+	return response, nil
+}
+
+// Complete records the response to the request that claimed key
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - key: The key passed to Claim
+//   - response: The response to replay on retries
+//
+// Returns:
+//   - error: ErrForbidden or database errors
+func (s *IdempotencyService) Complete(ctx context.Context, key string, response StoredResponse) error {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+	
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return fmt.Errorf("failed to encode response headers: %w", err)
+	}
+	err = s.queries.CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		UserID:          p.UserID,
+		Key:             key,
+		StatusCode:      pgtype.Int4{Int32: int32(response.StatusCode), Valid: true},
+		ResponseHeaders: header,
+		ResponseBody:    response.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Release gives up the claim on key without recording a response, so the
+// next retry is served again
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - key: The key passed to Claim
+//
+// Returns:
+//   - error: ErrForbidden or database errors
+func (s *IdempotencyService) Release(ctx context.Context, key string) error {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+	
+	if err := s.queries.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{UserID: p.UserID, Key: key}); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) ClaimIdempotencyKey(ctx context.Context, params db.ClaimIdempotencyKeyParams) (int64, error) {
+	if m.ClaimIdempotencyKeyFunc != nil {
+		return m.ClaimIdempotencyKeyFunc(ctx, params)
+	}
+	return 1, nil
+}
+
+func (m *MockQueries) GetIdempotencyKey(ctx context.Context, params db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	if m.GetIdempotencyKeyFunc != nil {
+		return m.GetIdempotencyKeyFunc(ctx, params)
+	}
+	return db.IdempotencyKey{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) CompleteIdempotencyKey(ctx context.Context, params db.CompleteIdempotencyKeyParams) error {
+	if m.CompleteIdempotencyKeyFunc != nil {
+		return m.CompleteIdempotencyKeyFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockQueries) DeleteIdempotencyKey(ctx context.Context, params db.DeleteIdempotencyKeyParams) error {
+	if m.DeleteIdempotencyKeyFunc != nil {
+		return m.DeleteIdempotencyKeyFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockQueries) DeleteExpiredIdempotencyKeys(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error) {
+	if m.DeleteExpiredIdempotencyKeysFunc != nil {
+		return m.DeleteExpiredIdempotencyKeysFunc(ctx, createdAt)
+	}
+	return 0, nil
+}
+
+// idempotencyStore is an in-memory idempotency_keys table wired into a
+// MockQueries
+type idempotencyStore struct {
+	keys map[db.GetIdempotencyKeyParams]db.IdempotencyKey
+}
+
+func (s *idempotencyStore) install(m *MockQueries) {
+	s.keys = map[db.GetIdempotencyKeyParams]db.IdempotencyKey{}
+	m.ClaimIdempotencyKeyFunc = func(ctx context.Context, p db.ClaimIdempotencyKeyParams) (int64, error) {
+		id := db.GetIdempotencyKeyParams{UserID: p.UserID, Key: p.Key}
+		if _, ok := s.keys[id]; ok {
+			return 0, nil
+		}
+		s.keys[id] = db.IdempotencyKey{UserID: p.UserID, Key: p.Key, RequestHash: p.RequestHash}
+		return 1, nil
+	}
+	m.GetIdempotencyKeyFunc = func(ctx context.Context, p db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+		key, ok := s.keys[p]
+		if !ok {
+			return db.IdempotencyKey{}, sql.ErrNoRows
+		}
+		return key, nil
+	}
+	m.CompleteIdempotencyKeyFunc = func(ctx context.Context, p db.CompleteIdempotencyKeyParams) error {
+		id := db.GetIdempotencyKeyParams{UserID: p.UserID, Key: p.Key}
+		key := s.keys[id]
+		key.StatusCode = p.StatusCode
+		key.ResponseHeaders = p.ResponseHeaders
+		key.ResponseBody = p.ResponseBody
+		s.keys[id] = key
+		return nil
+	}
+	m.DeleteIdempotencyKeyFunc = func(ctx context.Context, p db.DeleteIdempotencyKeyParams) error {
+		delete(s.keys, db.GetIdempotencyKeyParams{UserID: p.UserID, Key: p.Key})
+		return nil
+	}
+}
+
+func TestIdempotency_ReplaysCompletedResponse(t *testing.T) {
+	store := &idempotencyStore{}
+	mockQueries := &MockQueries{}
+	store.install(mockQueries)
+	service := NewIdempotencyService(mockQueries)
+	ctx := asUser(1)
+
+	stored, err := service.Claim(ctx, "key-1", []byte("hash"))
+	if err != nil || stored != nil {
+		t.Fatalf("expected the first claim to succeed, got %v, %v", stored, err)
+	}
+	if _, err := service.Claim(ctx, "key-1", []byte("hash")); !errors.Is(err, ErrIdempotencyKeyInProgress) {
+		t.Errorf("expected ErrIdempotencyKeyInProgress while serving, got %v", err)
+	}
+
+	response := StoredResponse{StatusCode: 201, Header: map[string]string{"Content-Type": "application/json"}, Body: []byte(`{"id":7}`)}
+	if err := service.Complete(ctx, "key-1", response); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	stored, err = service.Claim(ctx, "key-1", []byte("hash"))
+	if err != nil {
+		t.Fatalf("expected a replay, got %v", err)
+	}
+	if stored == nil || stored.StatusCode != 201 || string(stored.Body) != `{"id":7}` || stored.Header["Content-Type"] != "application/json" {
+		t.Errorf("expected the recorded response, got %+v", stored)
+	}
+}
+
+func TestIdempotency_RejectsReuseForDifferentRequest(t *testing.T) {
+	store := &idempotencyStore{}
+	mockQueries := &MockQueries{}
+	store.install(mockQueries)
+	service := NewIdempotencyService(mockQueries)
+
+	if _, err := service.Claim(asUser(1), "key-1", []byte("hash")); err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if _, err := service.Claim(asUser(1), "key-1", []byte("other")); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("expected ErrIdempotencyKeyReused, got %v", err)
+	}
+}
+
+func TestIdempotency_KeysAreScopedToTheCaller(t *testing.T) {
+	store := &idempotencyStore{}
+	mockQueries := &MockQueries{}
+	store.install(mockQueries)
+	service := NewIdempotencyService(mockQueries)
+
+	if _, err := service.Claim(asUser(1), "key-1", []byte("hash")); err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	stored, err := service.Claim(asUser(2), "key-1", []byte("hash"))
+	if err != nil || stored != nil {
+		t.Errorf("expected another user to claim the same key, got %v, %v", stored, err)
+	}
+	if _, err := service.Claim(context.Background(), "key-1", []byte("hash")); !errors.Is(err, ErrForbidden) {
+		t.Errorf("anonymous: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestIdempotency_ReleaseAllowsRetry(t *testing.T) {
+	store := &idempotencyStore{}
+	mockQueries := &MockQueries{}
+	store.install(mockQueries)
+	service := NewIdempotencyService(mockQueries)
+	ctx := asUser(1)
+
+	if _, err := service.Claim(ctx, "key-1", []byte("hash")); err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if err := service.Release(ctx, "key-1"); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	stored, err := service.Claim(ctx, "key-1", []byte("hash"))
+	if err != nil || stored != nil {
+		t.Errorf("expected the key to be claimable again, got %v, %v", stored, err)
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrLevelNotFound is returned when a game has no level with the given slug
+	ErrLevelNotFound = errors.New("level not found")
+	
+	// ErrDuplicateLevelSlug is returned when a game already has a level with the slug
+	ErrDuplicateLevelSlug = errors.New("level with this slug already exists")
+)
+
+// levelsGameSlugKey is the unique constraint on (levels.game_id, levels.slug)
+const levelsGameSlugKey = "levels_game_id_slug_key"
+
+// LevelService handles business logic for the individual levels of a game,
+// which runs can be submitted for and ranked on apart from full-game runs
+type LevelService struct {
+	queries db.Store
+}
+
+// CreateLevelInput holds the details of a level being created
+type CreateLevelInput struct {
+	Slug string
+	Name string
+	
+	// Position is the display order; nil places the level after the game's
+	// existing levels
+	Position *int32
+}
+
+// NewLevelService creates a new LevelService instance
+func NewLevelService(queries db.Store) *LevelService {
+	return &LevelService{queries: queries}
+}
+
+// ListLevels retrieves every level of a game in display order
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//
+// Returns:
+//   - []db.Level: The levels, ordered by position
+//   - error: ErrGameNotFound, or database errors
+func (s *LevelService) ListLevels(ctx context.Context, gameSlug string) ([]db.Level, error) {
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	levels, err := s.queries.ListLevelsByGame(ctx, game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list levels: %w", err)
+	}
+	
+	return levels, nil
+}
+
+// CreateLevel adds a level to a game; only admins and the game's super
+// moderators may
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game the level belongs to
+//   - input: The level details
+//
+// Returns:
+//   - *db.Level: The created level
+//   - error: ErrInvalidInput, ErrGameNotFound, ErrForbidden, ErrDuplicateLevelSlug,
+//     or database errors
+func (s *LevelService) CreateLevel(ctx context.Context, gameSlug string, input CreateLevelInput) (*db.Level, error) {
+	if err := validateSlug(input.Slug); err != nil {
+		return nil, err
+	}
+	name, err := validateDisplayName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	position := pgtype.Int4{}
+	if input.Position != nil {
+		if *input.Position < 0 {
+			return nil, invalidField("position", "must not be negative")
+		}
+		position = pgtype.Int4{Int32: *input.Position, Valid: true}
+	}
+	
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireGameManager(ctx, game.ID); err != nil {
+		return nil, err
+	}
+	
+	var level db.Level
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		level, err = q.CreateLevel(ctx, db.CreateLevelParams{
+			GameID:   game.ID,
+			Slug:     input.Slug,
+			Name:     name,
+			Position: position,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "level.create", AuditEntityLevel, level.ID, nil, level)
+	})
+	if err != nil {
+		if isDuplicateLevelSlugError(err) {
+			return nil, ErrDuplicateLevelSlug
+		}
+		return nil, fmt.Errorf("failed to create level: %w", err)
+	}
+	
+	return &level, nil
+}
+
+// getGame looks up the game that owns a set of levels
+func (s *LevelService) getGame(ctx context.Context, slug string) (*db.Game, error) {
+	game, err := s.queries.GetGameBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	return &game, nil
+}
+
+// getLevel looks up a level by its game and level slugs
+func getLevel(ctx context.Context, q db.Querier, gameSlug, levelSlug string) (*db.Level, error) {
+	level, err := q.GetLevelBySlug(ctx, db.GetLevelBySlugParams{
+		GameSlug:  gameSlug,
+		LevelSlug: levelSlug,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLevelNotFound
+		}
+		return nil, fmt.Errorf("failed to get level: %w", err)
+	}
+	
+	return &level, nil
+}
+
+// isDuplicateLevelSlugError reports whether err is a unique violation on a
+// game's level slugs
+func isDuplicateLevelSlugError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == levelsGameSlugKey
+}
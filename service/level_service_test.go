@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (m *MockQueries) GetLevelBySlug(ctx context.Context, params db.GetLevelBySlugParams) (db.Level, error) {
+	if m.GetLevelBySlugFunc != nil {
+		return m.GetLevelBySlugFunc(ctx, params)
+	}
+	return db.Level{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) ListLevelsByGame(ctx context.Context, gameID int32) ([]db.Level, error) {
+	if m.ListLevelsByGameFunc != nil {
+		return m.ListLevelsByGameFunc(ctx, gameID)
+	}
+	return []db.Level{}, nil
+}
+
+func (m *MockQueries) CreateLevel(ctx context.Context, params db.CreateLevelParams) (db.Level, error) {
+	if m.CreateLevelFunc != nil {
+		return m.CreateLevelFunc(ctx, params)
+	}
+	return db.Level{}, nil
+}
+
+// levelLookup returns a GetLevelBySlugFunc that finds a single level of the
+// game with gameSlug
+func levelLookup(gameSlug string, level db.Level) func(ctx context.Context, params db.GetLevelBySlugParams) (db.Level, error) {
+	return func(ctx context.Context, params db.GetLevelBySlugParams) (db.Level, error) {
+		if params.GameSlug != gameSlug || params.LevelSlug != level.Slug {
+			return db.Level{}, sql.ErrNoRows
+		}
+		return level, nil
+	}
+}
+
+func TestListLevels(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		ListLevelsByGameFunc: func(ctx context.Context, gameID int32) ([]db.Level, error) {
+			if gameID != 4 {
+				t.Errorf("expected game 4, got %d", gameID)
+			}
+			return []db.Level{{ID: 1, Slug: "bob-omb-battlefield"}, {ID: 2, Slug: "whomps-fortress"}}, nil
+		},
+	}
+
+	service := NewLevelService(mockQueries)
+	levels, err := service.ListLevels(context.Background(), "super-mario-64")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(levels) != 2 || levels[0].Slug != "bob-omb-battlefield" {
+		t.Errorf("expected both levels in order, got %+v", levels)
+	}
+
+	if _, err := service.ListLevels(context.Background(), "missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestCreateLevel_Success(t *testing.T) {
+	var params db.CreateLevelParams
+	var audited bool
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		CreateLevelFunc: func(ctx context.Context, p db.CreateLevelParams) (db.Level, error) {
+			params = p
+			return db.Level{ID: 7, GameID: p.GameID, Slug: p.Slug, Name: p.Name}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = p.Action == "level.create" && p.EntityType == AuditEntityLevel && p.EntityID == 7
+			return nil
+		},
+	}
+
+	service := NewLevelService(mockQueries)
+	position := int32(3)
+	level, err := service.CreateLevel(asAdmin(), "super-mario-64", CreateLevelInput{
+		Slug:     "bob-omb-battlefield",
+		Name:     " Bob-omb Battlefield ",
+		Position: &position,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.GameID != 4 || params.Name != "Bob-omb Battlefield" {
+		t.Errorf("unexpected insert params %+v", params)
+	}
+	if !params.Position.Valid || params.Position.Int32 != 3 {
+		t.Errorf("expected position 3, got %+v", params.Position)
+	}
+	if level.ID != 7 {
+		t.Errorf("expected the created level, got %+v", level)
+	}
+	if !audited {
+		t.Error("expected the creation to be audited")
+	}
+}
+
+func TestCreateLevel_InvalidInput(t *testing.T) {
+	negative := int32(-1)
+	tests := []struct {
+		name  string
+		input CreateLevelInput
+	}{
+		{"bad slug", CreateLevelInput{Slug: "Level 1", Name: "Level 1"}},
+		{"blank name", CreateLevelInput{Slug: "level-1", Name: "  "}},
+		{"negative position", CreateLevelInput{Slug: "level-1", Name: "Level 1", Position: &negative}},
+	}
+
+	service := NewLevelService(&MockQueries{})
+	for _, tt := range tests {
+		_, err := service.CreateLevel(context.Background(), "super-mario-64", tt.input)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestCreateLevel_DuplicateSlug(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		CreateLevelFunc: func(ctx context.Context, p db.CreateLevelParams) (db.Level, error) {
+			return db.Level{}, &pgconn.PgError{Code: uniqueViolation, ConstraintName: levelsGameSlugKey}
+		},
+	}
+
+	service := NewLevelService(mockQueries)
+	_, err := service.CreateLevel(asAdmin(), "super-mario-64", CreateLevelInput{Slug: "level-1", Name: "Level 1"})
+
+	if !errors.Is(err, ErrDuplicateLevelSlug) {
+		t.Errorf("expected ErrDuplicateLevelSlug, got %v", err)
+	}
+}
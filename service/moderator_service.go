@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+var (
+	// ErrAlreadyModerator is returned when adding a user to a game's
+	// moderation team they are already on
+	ErrAlreadyModerator = errors.New("user already moderates this game")
+	
+	// ErrModeratorNotFound is returned when removing a user from a game's
+	// moderation team they are not on
+	ErrModeratorNotFound = errors.New("user does not moderate this game")
+)
+
+// ModeratorService handles business logic for a game's own moderation team of
+// super moderators, who may edit the game and manage its verifiers, and
+// verifiers, who may only review its runs
+type ModeratorService struct {
+	queries db.Store
+}
+
+// NewModeratorService creates a new ModeratorService instance
+func NewModeratorService(queries db.Store) *ModeratorService {
+	return &ModeratorService{queries: queries}
+}
+
+// ListModerators retrieves the moderation team of a game
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//
+// Returns:
+//   - []db.ListGameModeratorsRow: Super moderators first, then verifiers
+//   - error: ErrGameNotFound, or database errors
+func (s *ModeratorService) ListModerators(ctx context.Context, gameSlug string) ([]db.ListGameModeratorsRow, error) {
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	moderators, err := s.queries.ListGameModerators(ctx, game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderators: %w", err)
+	}
+	
+	return moderators, nil
+}
+
+// AddModerator puts a user on a game's moderation team; admins may add super
+// moderators and verifiers, while the game's super moderators may only add
+// verifiers
+//
+// Like roles, the place takes effect on the user's next request. To change a
+// moderator's level, remove them and add them again.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game
+//   - userID: User to add
+//   - level: auth.RoleSuperModerator or auth.RoleVerifier
+//
+// Returns:
+//   - *db.ListGameModeratorsRow: The stored place on the team, as listed by
+//     ListModerators
+//   - error: ErrInvalidInput for an unknown level, ErrGameNotFound,
+//     ErrForbidden, ErrUserNotFound, ErrAlreadyModerator, or database errors
+func (s *ModeratorService) AddModerator(ctx context.Context, gameSlug string, userID int32, level auth.Role) (*db.ListGameModeratorsRow, error) {
+	if level != auth.RoleSuperModerator && level != auth.RoleVerifier {
+		return nil, invalidField("level", "must be %s or %s", auth.RoleSuperModerator, auth.RoleVerifier)
+	}
+	
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireModeratorManager(ctx, game.ID, level); err != nil {
+		return nil, err
+	}
+	
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	var moderator db.GameModerator
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		moderator, err = q.AddGameModerator(ctx, db.AddGameModeratorParams{
+			GameID: game.ID,
+			UserID: userID,
+			Level:  string(level),
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAlreadyModerator
+			}
+			return fmt.Errorf("failed to add moderator: %w", err)
+		}
+		return recordAudit(ctx, q, "game.add_moderator", AuditEntityGame, game.ID, nil, moderator)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return &db.ListGameModeratorsRow{
+		GameID:    moderator.GameID,
+		UserID:    moderator.UserID,
+		UserName:  user.Name,
+		Level:     moderator.Level,
+		CreatedAt: moderator.CreatedAt,
+	}, nil
+}
+
+// RemoveModerator takes a user off a game's moderation team; admins may
+// remove anyone, while the game's super moderators may only remove verifiers
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game
+//   - userID: User to remove
+//
+// Returns:
+//   - error: ErrGameNotFound, ErrForbidden, ErrModeratorNotFound, or database errors
+func (s *ModeratorService) RemoveModerator(ctx context.Context, gameSlug string, userID int32) error {
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return err
+	}
+	if err := requireGameManager(ctx, game.ID); err != nil {
+		return err
+	}
+	
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		params := db.GetGameModeratorParams{GameID: game.ID, UserID: userID}
+		existing, err := q.GetGameModerator(ctx, params)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrModeratorNotFound
+			}
+			return fmt.Errorf("failed to get moderator: %w", err)
+		}
+		if err := requireModeratorManager(ctx, game.ID, auth.Role(existing.Level)); err != nil {
+			return err
+		}
+		
+		removed, err := q.RemoveGameModerator(ctx, db.RemoveGameModeratorParams(params))
+		if err != nil {
+			return fmt.Errorf("failed to remove moderator: %w", err)
+		}
+		if removed == 0 {
+			return ErrModeratorNotFound
+		}
+		
+		return recordAudit(ctx, q, "game.remove_moderator", AuditEntityGame, game.ID, existing, nil)
+	})
+}
+
+// getGame looks up the game whose moderation team is being managed
+func (s *ModeratorService) getGame(ctx context.Context, slug string) (*db.Game, error) {
+	game, err := s.queries.GetGameBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	return &game, nil
+}
+
+// requireModeratorManager allows callers that may add or remove moderators of
+// gameID at level: admins for any level, and the game's super moderators for
+// verifiers
+func requireModeratorManager(ctx context.Context, gameID int32, level auth.Role) error {
+	if level == auth.RoleVerifier {
+		return requireGameManager(ctx, gameID)
+	}
+	return requireRole(ctx, auth.RoleAdmin)
+}
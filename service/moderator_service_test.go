@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func (m *MockQueries) ListGameModerators(ctx context.Context, gameID int32) ([]db.ListGameModeratorsRow, error) {
+	if m.ListGameModeratorsFunc != nil {
+		return m.ListGameModeratorsFunc(ctx, gameID)
+	}
+	return []db.ListGameModeratorsRow{}, nil
+}
+
+func (m *MockQueries) ListGameModeratorsByUser(ctx context.Context, userID int32) ([]db.GameModerator, error) {
+	if m.ListGameModeratorsByUserFunc != nil {
+		return m.ListGameModeratorsByUserFunc(ctx, userID)
+	}
+	return []db.GameModerator{}, nil
+}
+
+func (m *MockQueries) GetGameModerator(ctx context.Context, params db.GetGameModeratorParams) (db.GameModerator, error) {
+	if m.GetGameModeratorFunc != nil {
+		return m.GetGameModeratorFunc(ctx, params)
+	}
+	return db.GameModerator{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) AddGameModerator(ctx context.Context, params db.AddGameModeratorParams) (db.GameModerator, error) {
+	if m.AddGameModeratorFunc != nil {
+		return m.AddGameModeratorFunc(ctx, params)
+	}
+	return db.GameModerator{GameID: params.GameID, UserID: params.UserID, Level: params.Level}, nil
+}
+
+func (m *MockQueries) RemoveGameModerator(ctx context.Context, params db.RemoveGameModeratorParams) (int64, error) {
+	if m.RemoveGameModeratorFunc != nil {
+		return m.RemoveGameModeratorFunc(ctx, params)
+	}
+	return 1, nil
+}
+
+// asSuperModerator returns a context authenticated as a super moderator of
+// game 4
+func asSuperModerator() context.Context {
+	return asUser(8, auth.Grant{Role: auth.RoleSuperModerator, GameID: 4})
+}
+
+func TestListModerators(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		ListGameModeratorsFunc: func(ctx context.Context, gameID int32) ([]db.ListGameModeratorsRow, error) {
+			if gameID != 4 {
+				t.Errorf("expected game 4, got %d", gameID)
+			}
+			return []db.ListGameModeratorsRow{{UserID: 8, Level: "super_moderator"}, {UserID: 9, Level: "verifier"}}, nil
+		},
+	}
+
+	service := NewModeratorService(mockQueries)
+	moderators, err := service.ListModerators(context.Background(), "super-mario-64")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(moderators) != 2 || moderators[0].UserID != 8 {
+		t.Errorf("expected both moderators in order, got %+v", moderators)
+	}
+
+	if _, err := service.ListModerators(context.Background(), "missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestAddModerator_Success(t *testing.T) {
+	var params db.AddGameModeratorParams
+	var audited bool
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Jane Doe"}, nil
+		},
+		AddGameModeratorFunc: func(ctx context.Context, p db.AddGameModeratorParams) (db.GameModerator, error) {
+			params = p
+			return db.GameModerator{GameID: p.GameID, UserID: p.UserID, Level: p.Level}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = p.Action == "game.add_moderator" && p.EntityType == AuditEntityGame && p.EntityID == 4
+			return nil
+		},
+	}
+
+	service := NewModeratorService(mockQueries)
+	moderator, err := service.AddModerator(asAdmin(), "super-mario-64", 9, auth.RoleSuperModerator)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.GameID != 4 || params.UserID != 9 || params.Level != "super_moderator" {
+		t.Errorf("unexpected insert params %+v", params)
+	}
+	if moderator.UserName != "Jane Doe" || moderator.Level != "super_moderator" {
+		t.Errorf("expected the added moderator with their name, got %+v", moderator)
+	}
+	if !audited {
+		t.Error("expected the addition to be audited")
+	}
+}
+
+func TestAddModerator_Errors(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			if id != 9 {
+				return db.User{}, sql.ErrNoRows
+			}
+			return db.User{ID: id}, nil
+		},
+		AddGameModeratorFunc: func(ctx context.Context, p db.AddGameModeratorParams) (db.GameModerator, error) {
+			return db.GameModerator{}, sql.ErrNoRows
+		},
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		game   string
+		userID int32
+		level  auth.Role
+		want   error
+	}{
+		{"unknown level", asAdmin(), "super-mario-64", 9, auth.RoleModerator, ErrInvalidInput},
+		{"missing game", asAdmin(), "missing", 9, auth.RoleVerifier, ErrGameNotFound},
+		{"anonymous", context.Background(), "super-mario-64", 9, auth.RoleVerifier, ErrForbidden},
+		{"verifier", asUser(8, auth.Grant{Role: auth.RoleVerifier, GameID: 4}), "super-mario-64", 9, auth.RoleVerifier, ErrForbidden},
+		{"super moderator of another game", asUser(8, auth.Grant{Role: auth.RoleSuperModerator, GameID: 5}), "super-mario-64", 9, auth.RoleVerifier, ErrForbidden},
+		{"super moderator adding a super moderator", asSuperModerator(), "super-mario-64", 9, auth.RoleSuperModerator, ErrForbidden},
+		{"missing user", asSuperModerator(), "super-mario-64", 10, auth.RoleVerifier, ErrUserNotFound},
+		{"already a moderator", asSuperModerator(), "super-mario-64", 9, auth.RoleVerifier, ErrAlreadyModerator},
+	}
+
+	service := NewModeratorService(mockQueries)
+	for _, tt := range tests {
+		_, err := service.AddModerator(tt.ctx, tt.game, tt.userID, tt.level)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestRemoveModerator(t *testing.T) {
+	levels := map[int32]string{8: "super_moderator", 9: "verifier"}
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		userID int32
+		want   error
+	}{
+		{"super moderator removing a verifier", asSuperModerator(), 9, nil},
+		{"super moderator removing a super moderator", asSuperModerator(), 8, ErrForbidden},
+		{"admin removing a super moderator", asAdmin(), 8, nil},
+		{"verifier", asUser(9, auth.Grant{Role: auth.RoleVerifier, GameID: 4}), 9, ErrForbidden},
+		{"not a moderator", asAdmin(), 10, ErrModeratorNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removed := false
+			mockQueries := &MockQueries{
+				GetGameBySlugFunc: gameLookup(db.Game{ID: 4, Slug: "super-mario-64"}),
+				GetGameModeratorFunc: func(ctx context.Context, p db.GetGameModeratorParams) (db.GameModerator, error) {
+					level, ok := levels[p.UserID]
+					if !ok || p.GameID != 4 {
+						return db.GameModerator{}, sql.ErrNoRows
+					}
+					return db.GameModerator{GameID: p.GameID, UserID: p.UserID, Level: level}, nil
+				},
+				RemoveGameModeratorFunc: func(ctx context.Context, p db.RemoveGameModeratorParams) (int64, error) {
+					removed = true
+					return 1, nil
+				},
+			}
+
+			service := NewModeratorService(mockQueries)
+			err := service.RemoveModerator(tt.ctx, "super-mario-64", tt.userID)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if removed != (tt.want == nil) {
+				t.Errorf("expected removal to happen only when allowed, removed = %v", removed)
+			}
+		})
+	}
+}
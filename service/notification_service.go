@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+var (
+	// ErrNotificationNotFound is returned when the caller has no notification
+	// with the given ID
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// NotificationEvents are the events users are told about and may choose the
+// channels for
+var NotificationEvents = []string{
+	EventRunVerified,
+	EventRunRejected,
+	EventCommentCreated,
+	EventRecordBroken,
+}
+
+// NotificationService handles business logic for the in-app notifications
+// users receive about their runs: reviews, comments, and records they held
+// being beaten
+//
+// Notifications are written by the outbox's notification sink as events are
+// published, so they arrive shortly after what they describe.
+type NotificationService struct {
+	queries db.Store
+	pages   pageSizes
+}
+
+// NotificationPage is one page of a user's notifications along with the
+// pagination that was actually applied
+type NotificationPage struct {
+	Notifications []db.Notification
+	Total         int64
+	Limit         int32
+	Offset        int32
+	
+	// Unread is how many of the user's notifications are unread, whether or
+	// not the page is limited to them
+	Unread int64
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// NotificationPreference is which channels a user is told about an event
+// through; every channel is on until the user turns it off
+type NotificationPreference struct {
+	Event string `json:"event"`
+	
+	// Email sends the user an email about the event
+	Email bool `json:"email"`
+	
+	// InApp adds the event to the user's notifications
+	InApp bool `json:"in_app"`
+	
+	// Webhooks delivers the event to webhooks subscribed to it; turning it
+	// off keeps what happens to the user's runs out of integrations
+	Webhooks bool `json:"webhooks"`
+}
+
+// NotificationOption configures optional NotificationService behavior
+type NotificationOption func(*NotificationService)
+
+// WithNotificationPageSizes sets the limit applied when a list request omits
+// one and the largest limit a list request may ask for
+func WithNotificationPageSizes(defaultSize, maxSize int) NotificationOption {
+	return func(s *NotificationService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(queries db.Store, opts ...NotificationOption) *NotificationService {
+	s := &NotificationService{
+		queries: queries,
+		pages:   defaultPageSizes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListNotifications retrieves a page of the caller's notifications, newest
+// first
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - unreadOnly: Whether to leave out notifications that have been read
+//   - page: Pagination parameters
+//
+// Returns:
+//   - *NotificationPage: The notifications along with the applied pagination
+//   - error: ErrForbidden, ErrInvalidPagination, ErrInvalidCursor, or
+//     database errors
+func (s *NotificationService) ListNotifications(ctx context.Context, unreadOnly bool, page PageRequest) (*NotificationPage, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	
+	var notifications []db.Notification
+	if page.Cursor == "" {
+		notifications, err = s.queries.ListNotifications(ctx, db.ListNotificationsParams{
+			UserID:     userID,
+			UnreadOnly: unreadOnly,
+			Limit:      pageLimit + 1,
+			Offset:     pageOffset,
+		})
+	} else {
+		var afterID int32
+		if err := decodeCursor(page.Cursor, "notifications", &afterID); err != nil {
+			return nil, err
+		}
+		notifications, err = s.queries.ListNotificationsAfter(ctx, db.ListNotificationsAfterParams{
+			UserID:     userID,
+			UnreadOnly: unreadOnly,
+			AfterID:    afterID,
+			Limit:      pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	notifications, more := trimPage(notifications, pageLimit)
+	
+	total, err := s.queries.CountNotifications(ctx, db.CountNotificationsParams{UserID: userID, UnreadOnly: unreadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	unread := total
+	if !unreadOnly {
+		unread, err = s.queries.CountNotifications(ctx, db.CountNotificationsParams{UserID: userID, UnreadOnly: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+		}
+	}
+	
+	result := &NotificationPage{
+		Notifications: notifications,
+		Total:         total,
+		Limit:         pageLimit,
+		Offset:        pageOffset,
+		Unread:        unread,
+	}
+	if more {
+		result.NextCursor = encodeCursor("notifications", notifications[len(notifications)-1].ID)
+	}
+	return result, nil
+}
+
+// UnreadCount returns how many of the caller's notifications are unread
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//
+// Returns:
+//   - int64: The number of unread notifications
+//   - error: ErrForbidden, or database errors
+func (s *NotificationService) UnreadCount(ctx context.Context) (int64, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return 0, err
+	}
+	
+	count, err := s.queries.CountNotifications(ctx, db.CountNotificationsParams{UserID: userID, UnreadOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks one of the caller's notifications read; marking a
+// notification read again is not an error
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: The notification to mark read
+//
+// Returns:
+//   - error: ErrForbidden, ErrNotificationNotFound, or database errors
+func (s *NotificationService) MarkRead(ctx context.Context, id int32) error {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	
+	marked, err := s.queries.MarkNotificationRead(ctx, db.MarkNotificationReadParams{ID: id, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if marked == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead marks every one of the caller's notifications read
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//
+// Returns:
+//   - int64: How many notifications were unread until now
+//   - error: ErrForbidden, or database errors
+func (s *NotificationService) MarkAllRead(ctx context.Context) (int64, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return 0, err
+	}
+	
+	marked, err := s.queries.MarkAllNotificationsRead(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return marked, nil
+}
+
+// NotificationPreferences retrieves the channels the caller is told about
+// each of NotificationEvents through
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//
+// Returns:
+//   - []NotificationPreference: One per event, in the order of
+//     NotificationEvents
+//   - error: ErrForbidden, or database errors
+func (s *NotificationService) NotificationPreferences(ctx context.Context) ([]NotificationPreference, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	return listNotificationPreferences(ctx, s.queries, userID)
+}
+
+// UpdateNotificationPreferences changes the channels the caller is told
+// about events through; events left out keep their channels
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - preferences: The channels to use for each event given
+//
+// Returns:
+//   - []NotificationPreference: Every event's channels after the update, in
+//     the order of NotificationEvents
+//   - error: ErrForbidden, ErrInvalidInput for an unknown or repeated event,
+//     or database errors
+func (s *NotificationService) UpdateNotificationPreferences(ctx context.Context, preferences []NotificationPreference) ([]NotificationPreference, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(preferences))
+	for i, preference := range preferences {
+		if !slices.Contains(NotificationEvents, preference.Event) {
+			return nil, invalidField(fmt.Sprintf("preferences.%d.event", i), "%q is not an event users are notified of", preference.Event)
+		}
+		if seen[preference.Event] {
+			return nil, invalidField(fmt.Sprintf("preferences.%d.event", i), "%q is given more than once", preference.Event)
+		}
+		seen[preference.Event] = true
+	}
+	
+	var updated []NotificationPreference
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		existing, err := listNotificationPreferences(ctx, q, userID)
+		if err != nil {
+			return err
+		}
+		for _, preference := range preferences {
+			_, err := q.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+				UserID:   userID,
+				Event:    preference.Event,
+				Email:    preference.Email,
+				InApp:    preference.InApp,
+				Webhooks: preference.Webhooks,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update notification preference: %w", err)
+			}
+		}
+		updated, err = listNotificationPreferences(ctx, q, userID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "user.update_notification_preferences", AuditEntityUser, userID, preferencesByEvent(existing), preferencesByEvent(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return updated, nil
+}
+
+// listNotificationPreferences loads a user's channels for each of
+// NotificationEvents, filling in every channel for events they never changed
+func listNotificationPreferences(ctx context.Context, q db.Querier, userID int32) ([]NotificationPreference, error) {
+	stored, err := q.ListNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+	
+	result := make([]NotificationPreference, len(NotificationEvents))
+	for i, event := range NotificationEvents {
+		result[i] = NotificationPreference{Event: event, Email: true, InApp: true, Webhooks: true}
+		j := slices.IndexFunc(stored, func(p db.NotificationPreference) bool { return p.Event == event })
+		if j >= 0 {
+			result[i].Email = stored[j].Email
+			result[i].InApp = stored[j].InApp
+			result[i].Webhooks = stored[j].Webhooks
+		}
+	}
+	return result, nil
+}
+
+// preferencesByEvent keys preferences by their event, so audit events show
+// which events' channels changed
+func preferencesByEvent(preferences []NotificationPreference) map[string]NotificationPreference {
+	result := make(map[string]NotificationPreference, len(preferences))
+	for _, preference := range preferences {
+		result[preference.Event] = preference
+	}
+	return result
+}
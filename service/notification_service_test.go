@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) ListNotifications(ctx context.Context, params db.ListNotificationsParams) ([]db.Notification, error) {
+	if m.ListNotificationsFunc != nil {
+		return m.ListNotificationsFunc(ctx, params)
+	}
+	return []db.Notification{}, nil
+}
+
+func (m *MockQueries) ListNotificationsAfter(ctx context.Context, params db.ListNotificationsAfterParams) ([]db.Notification, error) {
+	if m.ListNotificationsAfterFunc != nil {
+		return m.ListNotificationsAfterFunc(ctx, params)
+	}
+	return []db.Notification{}, nil
+}
+
+func (m *MockQueries) CountNotifications(ctx context.Context, params db.CountNotificationsParams) (int64, error) {
+	if m.CountNotificationsFunc != nil {
+		return m.CountNotificationsFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) MarkNotificationRead(ctx context.Context, params db.MarkNotificationReadParams) (int64, error) {
+	if m.MarkNotificationReadFunc != nil {
+		return m.MarkNotificationReadFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) MarkAllNotificationsRead(ctx context.Context, userID int32) (int64, error) {
+	if m.MarkAllNotificationsReadFunc != nil {
+		return m.MarkAllNotificationsReadFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) ListNotificationPreferences(ctx context.Context, userID int32) ([]db.NotificationPreference, error) {
+	if m.ListNotificationPreferencesFunc != nil {
+		return m.ListNotificationPreferencesFunc(ctx, userID)
+	}
+	return []db.NotificationPreference{}, nil
+}
+
+func (m *MockQueries) UpsertNotificationPreference(ctx context.Context, params db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+	if m.UpsertNotificationPreferenceFunc != nil {
+		return m.UpsertNotificationPreferenceFunc(ctx, params)
+	}
+	return storedPreference(params), nil
+}
+
+// The notification sink's queries are not used by any service
+
+func (m *MockQueries) CreateNotification(ctx context.Context, params db.CreateNotificationParams) error {
+	return nil
+}
+
+func (m *MockQueries) DeleteExpiredNotifications(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockQueries) GetNotificationPreference(ctx context.Context, params db.GetNotificationPreferenceParams) (db.NotificationPreference, error) {
+	return db.NotificationPreference{}, sql.ErrNoRows
+}
+
+// storedPreference returns the row UpsertNotificationPreference stores
+func storedPreference(p db.UpsertNotificationPreferenceParams) db.NotificationPreference {
+	return db.NotificationPreference{
+		UserID:   p.UserID,
+		Event:    p.Event,
+		Email:    p.Email,
+		InApp:    p.InApp,
+		Webhooks: p.Webhooks,
+	}
+}
+
+// notificationCounts returns a CountNotifications stub with fixed counts of
+// all and of unread notifications
+func notificationCounts(all, unread int64) func(ctx context.Context, params db.CountNotificationsParams) (int64, error) {
+	return func(ctx context.Context, params db.CountNotificationsParams) (int64, error) {
+		if params.UnreadOnly {
+			return unread, nil
+		}
+		return all, nil
+	}
+}
+
+func TestListNotifications_Paginates(t *testing.T) {
+	var after db.ListNotificationsAfterParams
+	mockQueries := &MockQueries{
+		ListNotificationsFunc: func(ctx context.Context, p db.ListNotificationsParams) ([]db.Notification, error) {
+			if p.UserID != 1 || p.UnreadOnly || p.Limit != 3 {
+				t.Errorf("expected one more of user 1's notifications than the limit, got %+v", p)
+			}
+			return []db.Notification{{ID: 9}, {ID: 7}, {ID: 4}}, nil
+		},
+		ListNotificationsAfterFunc: func(ctx context.Context, p db.ListNotificationsAfterParams) ([]db.Notification, error) {
+			after = p
+			return []db.Notification{{ID: 4}}, nil
+		},
+		CountNotificationsFunc: notificationCounts(3, 2),
+	}
+	service := NewNotificationService(mockQueries)
+
+	page, err := service.ListNotifications(asUser(1), false, PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Notifications) != 2 || page.Total != 3 || page.Unread != 2 || page.NextCursor == "" {
+		t.Fatalf("expected two of three notifications, the unread count, and a cursor, got %+v", page)
+	}
+
+	next, err := service.ListNotifications(asUser(1), false, PageRequest{Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.UserID != 1 || after.AfterID != 7 {
+		t.Errorf("expected the next page after notification 7, got %+v", after)
+	}
+	if len(next.Notifications) != 1 || next.NextCursor != "" {
+		t.Errorf("expected the last notification and no cursor, got %+v", next)
+	}
+}
+
+func TestListNotifications_UnreadOnly(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListNotificationsFunc: func(ctx context.Context, p db.ListNotificationsParams) ([]db.Notification, error) {
+			if !p.UnreadOnly {
+				t.Errorf("expected only unread notifications, got %+v", p)
+			}
+			return []db.Notification{{ID: 9}}, nil
+		},
+		CountNotificationsFunc: notificationCounts(3, 1),
+	}
+	service := NewNotificationService(mockQueries)
+
+	page, err := service.ListNotifications(asUser(1), true, PageRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if page.Total != 1 || page.Unread != 1 {
+		t.Errorf("expected the unread notification counted once, got %+v", page)
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	var marked db.MarkNotificationReadParams
+	mockQueries := &MockQueries{
+		MarkNotificationReadFunc: func(ctx context.Context, p db.MarkNotificationReadParams) (int64, error) {
+			marked = p
+			if p.ID != 5 {
+				return 0, nil
+			}
+			return 1, nil
+		},
+	}
+	service := NewNotificationService(mockQueries)
+
+	if err := service.MarkRead(asUser(1), 5); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if marked.UserID != 1 {
+		t.Errorf("expected only the caller's notification marked, got %+v", marked)
+	}
+	if err := service.MarkRead(asUser(1), 6); !errors.Is(err, ErrNotificationNotFound) {
+		t.Errorf("someone else's notification: expected ErrNotificationNotFound, got %v", err)
+	}
+}
+
+func TestNotifications_RequireUser(t *testing.T) {
+	service := NewNotificationService(&MockQueries{})
+	apiKey := auth.WithPrincipal(context.Background(), auth.Principal{UserID: 1, APIKeyID: 5})
+
+	if _, err := service.ListNotifications(apiKey, false, PageRequest{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("list: expected ErrForbidden, got %v", err)
+	}
+	if _, err := service.UnreadCount(context.Background()); !errors.Is(err, ErrForbidden) {
+		t.Errorf("unread count: expected ErrForbidden, got %v", err)
+	}
+	if err := service.MarkRead(apiKey, 5); !errors.Is(err, ErrForbidden) {
+		t.Errorf("mark read: expected ErrForbidden, got %v", err)
+	}
+	if _, err := service.MarkAllRead(apiKey); !errors.Is(err, ErrForbidden) {
+		t.Errorf("mark all read: expected ErrForbidden, got %v", err)
+	}
+	if _, err := service.NotificationPreferences(apiKey); !errors.Is(err, ErrForbidden) {
+		t.Errorf("preferences: expected ErrForbidden, got %v", err)
+	}
+	if _, err := service.UpdateNotificationPreferences(apiKey, nil); !errors.Is(err, ErrForbidden) {
+		t.Errorf("update preferences: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestNotificationPreferences_DefaultsToEveryChannel(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListNotificationPreferencesFunc: func(ctx context.Context, userID int32) ([]db.NotificationPreference, error) {
+			return []db.NotificationPreference{{UserID: userID, Event: EventRunRejected, InApp: true}}, nil
+		},
+	}
+	service := NewNotificationService(mockQueries)
+
+	preferences, err := service.NotificationPreferences(asUser(1))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(preferences) != len(NotificationEvents) {
+		t.Fatalf("expected one preference per event, got %+v", preferences)
+	}
+	for i, p := range preferences {
+		want := NotificationPreference{Event: NotificationEvents[i], Email: true, InApp: true, Webhooks: true}
+		if p.Event == EventRunRejected {
+			want = NotificationPreference{Event: EventRunRejected, InApp: true}
+		}
+		if p != want {
+			t.Errorf("expected %+v, got %+v", want, p)
+		}
+	}
+}
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	var stored []db.NotificationPreference
+	var actions []string
+	mockQueries := &MockQueries{
+		ListNotificationPreferencesFunc: func(ctx context.Context, userID int32) ([]db.NotificationPreference, error) {
+			return slices.Clone(stored), nil
+		},
+		UpsertNotificationPreferenceFunc: func(ctx context.Context, p db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+			stored = append(stored, storedPreference(p))
+			return storedPreference(p), nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			actions = append(actions, p.Action)
+			return nil
+		},
+	}
+	service := NewNotificationService(mockQueries)
+
+	preferences, err := service.UpdateNotificationPreferences(asUser(1), []NotificationPreference{
+		{Event: EventCommentCreated, InApp: true},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(stored) != 1 || stored[0].UserID != 1 || stored[0].Event != EventCommentCreated || stored[0].Email || !stored[0].InApp {
+		t.Errorf("expected only the comment preference stored for the caller, got %+v", stored)
+	}
+	i := slices.Index(NotificationEvents, EventCommentCreated)
+	if preferences[i] != (NotificationPreference{Event: EventCommentCreated, InApp: true}) || !preferences[0].Email {
+		t.Errorf("expected the other events to keep every channel, got %+v", preferences)
+	}
+	if !slices.Equal(actions, []string{"user.update_notification_preferences"}) {
+		t.Errorf("expected the update to be audited, got %v", actions)
+	}
+}
+
+func TestUpdateNotificationPreferences_RejectsUnknownEvents(t *testing.T) {
+	service := NewNotificationService(&MockQueries{})
+
+	tests := []struct {
+		preferences []NotificationPreference
+		field       string
+	}{
+		{[]NotificationPreference{{Event: EventUserCreated}}, "preferences.0.event"},
+		{[]NotificationPreference{{Event: EventRunVerified}, {Event: EventRunVerified, Email: true}}, "preferences.1.event"},
+	}
+	for _, tt := range tests {
+		_, err := service.UpdateNotificationPreferences(asUser(1), tt.preferences)
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) || len(invalid.Fields) != 1 || invalid.Fields[0].Field != tt.field {
+			t.Errorf("%+v: expected %s to be invalid, got %v", tt.preferences, tt.field, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrOperationNotFound is returned when the caller has no operation with
+	// the given ID
+	ErrOperationNotFound = errors.New("operation not found")
+	
+	// ErrOperationOutputNotFound is returned when an operation has made no
+	// file, as one that hasn't succeeded hasn't
+	ErrOperationOutputNotFound = errors.New("operation has no output")
+)
+
+// Kinds of operation, stored in operations.kind
+const (
+	OperationUserImport = "users.import"
+	OperationUserExport = "users.export"
+)
+
+// UserImportInput is what an import of users started by StartImportUsers
+// runs with
+type UserImportInput struct {
+	Rows []ImportRow `json:"rows"`
+}
+
+// UserExportInput is what an export of users started by StartExportUsers
+// runs with
+type UserExportInput struct {
+	Filter ListUsersFilter `json:"filter"`
+	
+	// Format is the media type the export is written in
+	Format string `json:"format"`
+}
+
+// OperationService lets callers follow the long-running operations they
+// started, which background workers run
+type OperationService struct {
+	queries db.Store
+}
+
+// NewOperationService creates a new OperationService instance
+func NewOperationService(queries db.Store) *OperationService {
+	return &OperationService{queries: queries}
+}
+
+// GetOperation retrieves an operation as it is on the primary, since it is
+// polled for changes workers make moments before; only the user who started
+// it and admins may see it
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the operation
+//
+// Returns:
+//   - *db.Operation: The operation
+//   - error: ErrOperationNotFound, or database errors
+func (s *OperationService) GetOperation(ctx context.Context, id uuid.UUID) (*db.Operation, error) {
+	ctx, span := tracer.Start(ctx, "OperationService.GetOperation")
+	defer span.End()
+	
+	op, err := s.queries.GetOperationByID(db.WithPrimary(ctx), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOperationNotFound
+		}
+		return nil, fmt.Errorf("failed to get operation: %w", err)
+	}
+	// Other users' operations are reported missing rather than forbidden,
+	// so their IDs can't be confirmed
+	if requireSelfOrAdmin(ctx, op.CreatedBy) != nil {
+		return nil, ErrOperationNotFound
+	}
+	return &op, nil
+}
+
+// GetOperationOutput retrieves the file an operation made
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the operation
+//
+// Returns:
+//   - *db.OperationOutput: The file
+//   - error: ErrOperationNotFound, ErrOperationOutputNotFound, or database
+//     errors
+func (s *OperationService) GetOperationOutput(ctx context.Context, id uuid.UUID) (*db.OperationOutput, error) {
+	ctx, span := tracer.Start(ctx, "OperationService.GetOperationOutput")
+	defer span.End()
+	
+	if _, err := s.GetOperation(ctx, id); err != nil {
+		return nil, err
+	}
+	output, err := s.queries.GetOperationOutput(db.WithPrimary(ctx), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOperationOutputNotFound
+		}
+		return nil, fmt.Errorf("failed to get operation output: %w", err)
+	}
+	return &output, nil
+}
+
+// ResumeOperation decodes the input op was started with into input and
+// returns ctx carrying the user who started it, with their roles as they are
+// now, for a worker to run op as that user would have run it themselves
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - op: The operation being run
+//   - input: Where its input is decoded to, such as a *UserImportInput
+//
+// Returns:
+//   - context.Context: ctx carrying the user who started op
+//   - error: Invalid input, or database errors
+func (s *OperationService) ResumeOperation(ctx context.Context, op db.Operation, input any) (context.Context, error) {
+	if err := json.Unmarshal(op.Input, input); err != nil {
+		return nil, fmt.Errorf("invalid %s input: %w", op.Kind, err)
+	}
+	principal, err := loadPrincipal(ctx, s.queries, op.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// startOperation creates an operation of kind for the caller, which must
+// have logged in to be able to poll it, to be run by a worker with input;
+// total is how many items it has, or negative when that isn't known yet
+func startOperation(ctx context.Context, queries db.Querier, kind string, input any, total int) (*db.Operation, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s input: %w", kind, err)
+	}
+	
+	op, err := queries.CreateOperation(ctx, db.CreateOperationParams{
+		Kind:      kind,
+		Input:     encoded,
+		CreatedBy: userID,
+		Total:     pgtype.Int4{Int32: int32(total), Valid: total >= 0},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operation: %w", err)
+	}
+	return &op, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) CreateOperation(ctx context.Context, params db.CreateOperationParams) (db.Operation, error) {
+	if m.CreateOperationFunc != nil {
+		return m.CreateOperationFunc(ctx, params)
+	}
+	return db.Operation{Kind: params.Kind, Input: params.Input, CreatedBy: params.CreatedBy, Total: params.Total, Status: "pending"}, nil
+}
+
+func (m *MockQueries) GetOperationByID(ctx context.Context, id pgtype.UUID) (db.Operation, error) {
+	if m.GetOperationByIDFunc != nil {
+		return m.GetOperationByIDFunc(ctx, id)
+	}
+	return db.Operation{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetOperationOutput(ctx context.Context, operationID pgtype.UUID) (db.OperationOutput, error) {
+	if m.GetOperationOutputFunc != nil {
+		return m.GetOperationOutputFunc(ctx, operationID)
+	}
+	return db.OperationOutput{}, sql.ErrNoRows
+}
+
+// The operation worker's queries are not used by any service
+
+func (m *MockQueries) ClaimOperations(ctx context.Context, params db.ClaimOperationsParams) ([]db.Operation, error) {
+	return []db.Operation{}, nil
+}
+
+func (m *MockQueries) UpdateOperationProgress(ctx context.Context, params db.UpdateOperationProgressParams) error {
+	return nil
+}
+
+func (m *MockQueries) RequeueOperation(ctx context.Context, id pgtype.UUID) error {
+	return nil
+}
+
+func (m *MockQueries) FinishOperation(ctx context.Context, params db.FinishOperationParams) error {
+	return nil
+}
+
+func (m *MockQueries) SaveOperationOutput(ctx context.Context, params db.SaveOperationOutputParams) error {
+	return nil
+}
+
+func (m *MockQueries) DeleteFinishedOperations(ctx context.Context, finishedAt pgtype.Timestamptz) (int64, error) {
+	return 0, nil
+}
+
+func TestGetOperation_OnlyForItsCreatorAndAdmins(t *testing.T) {
+	id := uuid.New()
+	mockQueries := &MockQueries{
+		GetOperationByIDFunc: func(ctx context.Context, opID pgtype.UUID) (db.Operation, error) {
+			if !db.UsesPrimary(ctx) {
+				t.Error("expected the operation to be read from the primary")
+			}
+			if opID.Bytes != id {
+				return db.Operation{}, sql.ErrNoRows
+			}
+			return db.Operation{ID: opID, CreatedBy: 3, Status: "running"}, nil
+		},
+	}
+	service := NewOperationService(mockQueries)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		id      uuid.UUID
+		wantErr error
+	}{
+		{"creator", asUser(3), id, nil},
+		{"admin", asAdmin(), id, nil},
+		{"other user", asUser(4), id, ErrOperationNotFound},
+		{"missing", asUser(3), uuid.New(), ErrOperationNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := service.GetOperation(tt.ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && op.CreatedBy != 3 {
+				t.Errorf("expected the operation, got %+v", op)
+			}
+		})
+	}
+}
+
+func TestGetOperationOutput_NotFoundUntilMade(t *testing.T) {
+	id := uuid.New()
+	var made bool
+	mockQueries := &MockQueries{
+		GetOperationByIDFunc: func(ctx context.Context, opID pgtype.UUID) (db.Operation, error) {
+			return db.Operation{ID: opID, CreatedBy: 3}, nil
+		},
+		GetOperationOutputFunc: func(ctx context.Context, operationID pgtype.UUID) (db.OperationOutput, error) {
+			if !made {
+				return db.OperationOutput{}, sql.ErrNoRows
+			}
+			return db.OperationOutput{OperationID: operationID, Filename: "users.csv", Data: []byte("id\n")}, nil
+		},
+	}
+	service := NewOperationService(mockQueries)
+
+	if _, err := service.GetOperationOutput(asUser(3), id); !errors.Is(err, ErrOperationOutputNotFound) {
+		t.Errorf("expected ErrOperationOutputNotFound before the output is made, got %v", err)
+	}
+	if _, err := service.GetOperationOutput(asUser(4), id); !errors.Is(err, ErrOperationNotFound) {
+		t.Errorf("expected other users' operations to be missing, got %v", err)
+	}
+
+	made = true
+	output, err := service.GetOperationOutput(asUser(3), id)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output.Filename != "users.csv" || string(output.Data) != "id\n" {
+		t.Errorf("expected the output, got %+v", output)
+	}
+}
+
+func TestResumeOperation_RunsAsItsCreator(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListUserRolesFunc: func(ctx context.Context, userID int32) ([]db.UserRole, error) {
+			return []db.UserRole{{UserID: userID, Role: string(auth.RoleAdmin)}}, nil
+		},
+	}
+	service := NewOperationService(mockQueries)
+
+	op := db.Operation{Kind: OperationUserImport, CreatedBy: 7, Input: []byte(`{"rows":[{"name":"Ana","email":"ana@example.com"}]}`)}
+	var input UserImportInput
+	ctx, err := service.ResumeOperation(context.Background(), op, &input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(input.Rows) != 1 || input.Rows[0].Email != "ana@example.com" {
+		t.Errorf("expected the input to be decoded, got %+v", input)
+	}
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID != 7 || !principal.HasRole(auth.RoleAdmin) {
+		t.Errorf("expected to run as the creator with their roles, got %+v", principal)
+	}
+
+	op.Input = []byte("not json")
+	if _, err := service.ResumeOperation(context.Background(), op, &input); err == nil {
+		t.Error("expected invalid input to be rejected")
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed or was
+// issued by a different list
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageRequest is the pagination a list request asked for
+//
+// Without a Cursor the page starts Offset rows into the list. A Cursor taken
+// from a previous page's NextCursor continues right after that page instead,
+// seeking by the list's sort key, so pages stay fast at any depth and rows
+// inserted or deleted meanwhile are neither repeated nor skipped. A Cursor
+// cannot be combined with an Offset.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+// pageSizes is the pagination policy shared by every list operation
+type pageSizes struct {
+	// defaultSize is used when a list request does not ask for a limit
+	defaultSize int
+	
+	// maxSize caps how many rows a single list request may return
+	maxSize int
+}
+
+// defaultPageSizes are used when no page sizes are configured
+var defaultPageSizes = pageSizes{defaultSize: 10, maxSize: 100}
+
+// UserPage is one page of users along with the pagination that was
+// actually applied, which may differ from what the caller asked for
+type UserPage struct {
+	Users  []db.User
+	Total  int64
+	Limit  int32
+	Offset int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// with returns a copy of the policy using the given sizes
+// Non-positive sizes keep the current value, and the default is never
+// allowed to exceed the maximum.
+func (p pageSizes) with(defaultSize, maxSize int) pageSizes {
+	if maxSize > 0 {
+		p.maxSize = maxSize
+	}
+	if defaultSize > 0 {
+		p.defaultSize = defaultSize
+	}
+	if p.defaultSize > p.maxSize {
+		p.defaultSize = p.maxSize
+	}
+	return p
+}
+
+// normalize applies the pagination policy to raw client input
+//
+// A zero or negative limit selects the default page size, a limit above the
+// maximum is clamped to it, and a negative offset starts from the beginning.
+//
+// Returns:
+//   - int32: The effective limit
+//   - int32: The effective offset
+func (p pageSizes) normalize(limit, offset int) (int32, int32) {
+	if limit <= 0 {
+		limit = p.defaultSize
+	}
+	if limit > p.maxSize {
+		limit = p.maxSize
+	}
+	
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > math.MaxInt32 {
+		offset = math.MaxInt32
+	}
+	
+	return int32(limit), int32(offset)
+}
+
+// apply normalizes a page request and checks that it does not combine a
+// cursor with an offset
+//
+// Returns:
+//   - int32: The effective limit
+//   - int32: The effective offset, always zero with a cursor
+//   - error: ErrInvalidInput if both a cursor and an offset were given
+func (p pageSizes) apply(req PageRequest) (int32, int32, error) {
+	if req.Cursor != "" && req.Offset > 0 {
+		return 0, 0, fmt.Errorf("%w: cursor cannot be combined with offset", ErrInvalidInput)
+	}
+	limit, offset := p.normalize(req.Limit, req.Offset)
+	return limit, offset, nil
+}
+
+// encodeCursor returns an opaque cursor for the list named list that
+// continues after the row whose sort key is keys
+func encodeCursor(list string, keys ...any) string {
+	data, err := json.Marshal(append([]any{list}, keys...))
+	if err != nil {
+		// Keys are plain numbers and times, which always marshal
+		panic(fmt.Sprintf("encoding cursor: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads the sort key from a cursor made by encodeCursor into
+// keys, which must point to values of the types that were encoded
+//
+// Returns:
+//   - error: ErrInvalidCursor if the cursor is malformed or belongs to
+//     another list
+func decodeCursor(cursor, list string, keys ...any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || len(fields) != len(keys)+1 {
+		return ErrInvalidCursor
+	}
+	
+	var name string
+	if err := json.Unmarshal(fields[0], &name); err != nil || name != list {
+		return ErrInvalidCursor
+	}
+	for i, key := range keys {
+		if err := json.Unmarshal(fields[i+1], key); err != nil {
+			return ErrInvalidCursor
+		}
+	}
+	return nil
+}
+
+// trimPage drops the extra row fetched past limit to learn whether another
+// page follows
+//
+// Returns:
+//   - []T: At most limit rows
+//   - bool: Whether there were more rows than limit
+func trimPage[T any](rows []T, limit int32) ([]T, bool) {
+	if len(rows) > int(limit) {
+		return rows[:limit], true
+	}
+	return rows, false
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrPlatformNotFound is returned when no platform has the given slug
+	ErrPlatformNotFound = errors.New("platform not found")
+	
+	// ErrDuplicatePlatformSlug is returned when a platform with the slug already exists
+	ErrDuplicatePlatformSlug = errors.New("platform with this slug already exists")
+	
+	// ErrPlatformInUse is returned when deleting a platform that runs were played on
+	ErrPlatformInUse = errors.New("platform has runs")
+)
+
+const (
+	// platformsSlugKey is the unique constraint on platforms.slug
+	platformsSlugKey = "platforms_slug_key"
+	
+	// runsPlatformFkey is the foreign key from runs.platform to platforms.slug
+	runsPlatformFkey = "runs_platform_fkey"
+)
+
+// PlatformService handles business logic for the platforms, such as N64 or
+// PC, that runs are played on
+type PlatformService struct {
+	queries db.Store
+}
+
+// NewPlatformService creates a new PlatformService instance
+func NewPlatformService(queries db.Store) *PlatformService {
+	return &PlatformService{queries: queries}
+}
+
+// ListPlatforms retrieves every platform
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []db.Platform: The platforms, ordered by name
+//   - error: Database errors
+func (s *PlatformService) ListPlatforms(ctx context.Context) ([]db.Platform, error) {
+	platforms, err := s.queries.ListPlatforms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list platforms: %w", err)
+	}
+	
+	return platforms, nil
+}
+
+// CreatePlatform adds a platform runs can be played on
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: URL-safe identifier runs refer to the platform by
+//   - name: Display name of the platform
+//
+// Returns:
+//   - *db.Platform: The created platform
+//   - error: ErrInvalidInput, ErrDuplicatePlatformSlug, or database errors
+func (s *PlatformService) CreatePlatform(ctx context.Context, slug, name string) (*db.Platform, error) {
+	if err := validateSlug(slug); err != nil {
+		return nil, err
+	}
+	name, err := validateDisplayName(name)
+	if err != nil {
+		return nil, err
+	}
+	
+	var platform db.Platform
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		platform, err = q.CreatePlatform(ctx, db.CreatePlatformParams{Slug: slug, Name: name})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "platform.create", AuditEntityPlatform, platform.ID, nil, platform)
+	})
+	if err != nil {
+		if isDuplicatePlatformSlugError(err) {
+			return nil, ErrDuplicatePlatformSlug
+		}
+		return nil, fmt.Errorf("failed to create platform: %w", err)
+	}
+	
+	return &platform, nil
+}
+
+// UpdatePlatform renames a platform; its slug cannot change, since runs
+// refer to the platform by it
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: Slug of the platform to rename
+//   - name: New display name of the platform
+//
+// Returns:
+//   - *db.Platform: The updated platform
+//   - error: ErrInvalidInput, ErrPlatformNotFound, or database errors
+func (s *PlatformService) UpdatePlatform(ctx context.Context, slug, name string) (*db.Platform, error) {
+	name, err := validateDisplayName(name)
+	if err != nil {
+		return nil, err
+	}
+	
+	var platform db.Platform
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		existing, err := getPlatform(ctx, q, slug)
+		if err != nil {
+			return err
+		}
+		
+		platform, err = q.UpdatePlatform(ctx, db.UpdatePlatformParams{Name: name, Slug: slug})
+		if err != nil {
+			return fmt.Errorf("failed to update platform: %w", err)
+		}
+		return recordAudit(ctx, q, "platform.update", AuditEntityPlatform, platform.ID, existing, platform)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return &platform, nil
+}
+
+// DeletePlatform deletes a platform no run was played on
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: Slug of the platform to delete
+//
+// Returns:
+//   - error: ErrPlatformNotFound, ErrPlatformInUse, or database errors
+func (s *PlatformService) DeletePlatform(ctx context.Context, slug string) error {
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		platform, err := getPlatform(ctx, q, slug)
+		if err != nil {
+			return err
+		}
+		
+		deleted, err := q.DeletePlatform(ctx, slug)
+		if err != nil {
+			if isForeignKeyViolation(err, runsPlatformFkey) {
+				return ErrPlatformInUse
+			}
+			return fmt.Errorf("failed to delete platform: %w", err)
+		}
+		if deleted == 0 {
+			return ErrPlatformNotFound
+		}
+		
+		return recordAudit(ctx, q, "platform.delete", AuditEntityPlatform, platform.ID, platform, nil)
+	})
+}
+
+// getPlatform looks up a platform by its slug
+func getPlatform(ctx context.Context, q db.Querier, slug string) (*db.Platform, error) {
+	platform, err := q.GetPlatformBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlatformNotFound
+		}
+		return nil, fmt.Errorf("failed to get platform: %w", err)
+	}
+	
+	return &platform, nil
+}
+
+// isDuplicatePlatformSlugError reports whether err is a unique violation on
+// platform slugs
+func isDuplicatePlatformSlugError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == platformsSlugKey
+}
+
+// isForeignKeyViolation reports whether err is a foreign key violation on
+// the given constraint, as when deleting a row that is still referenced
+func isForeignKeyViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == foreignKeyViolation &&
+		pgErr.ConstraintName == constraint
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (m *MockQueries) ListPlatforms(ctx context.Context) ([]db.Platform, error) {
+	if m.ListPlatformsFunc != nil {
+		return m.ListPlatformsFunc(ctx)
+	}
+	return []db.Platform{}, nil
+}
+
+// GetPlatformBySlug finds every platform unless a test stubs it, so tests of
+// run submission need not list the platform they use
+func (m *MockQueries) GetPlatformBySlug(ctx context.Context, slug string) (db.Platform, error) {
+	if m.GetPlatformBySlugFunc != nil {
+		return m.GetPlatformBySlugFunc(ctx, slug)
+	}
+	return db.Platform{ID: 1, Slug: slug}, nil
+}
+
+func (m *MockQueries) CreatePlatform(ctx context.Context, params db.CreatePlatformParams) (db.Platform, error) {
+	if m.CreatePlatformFunc != nil {
+		return m.CreatePlatformFunc(ctx, params)
+	}
+	return db.Platform{}, nil
+}
+
+func (m *MockQueries) UpdatePlatform(ctx context.Context, params db.UpdatePlatformParams) (db.Platform, error) {
+	if m.UpdatePlatformFunc != nil {
+		return m.UpdatePlatformFunc(ctx, params)
+	}
+	return db.Platform{}, nil
+}
+
+func (m *MockQueries) DeletePlatform(ctx context.Context, slug string) (int64, error) {
+	if m.DeletePlatformFunc != nil {
+		return m.DeletePlatformFunc(ctx, slug)
+	}
+	return 1, nil
+}
+
+// platformLookup returns a GetPlatformBySlugFunc that finds only platform
+func platformLookup(platform db.Platform) func(ctx context.Context, slug string) (db.Platform, error) {
+	return func(ctx context.Context, slug string) (db.Platform, error) {
+		if slug != platform.Slug {
+			return db.Platform{}, sql.ErrNoRows
+		}
+		return platform, nil
+	}
+}
+
+func TestCreatePlatform_Success(t *testing.T) {
+	var params db.CreatePlatformParams
+	var audited bool
+	mockQueries := &MockQueries{
+		CreatePlatformFunc: func(ctx context.Context, p db.CreatePlatformParams) (db.Platform, error) {
+			params = p
+			return db.Platform{ID: 2, Slug: p.Slug, Name: p.Name}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = p.Action == "platform.create" && p.EntityType == AuditEntityPlatform && p.EntityID == 2
+			return nil
+		},
+	}
+
+	service := NewPlatformService(mockQueries)
+	platform, err := service.CreatePlatform(context.Background(), "n64", " Nintendo 64 ")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.Slug != "n64" || params.Name != "Nintendo 64" {
+		t.Errorf("unexpected insert params %+v", params)
+	}
+	if platform.ID != 2 {
+		t.Errorf("expected the created platform, got %+v", platform)
+	}
+	if !audited {
+		t.Error("expected the creation to be audited")
+	}
+}
+
+func TestCreatePlatform_InvalidInput(t *testing.T) {
+	service := NewPlatformService(&MockQueries{})
+
+	if _, err := service.CreatePlatform(context.Background(), "Nintendo 64", "Nintendo 64"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("bad slug: expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := service.CreatePlatform(context.Background(), "n64", "  "); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("blank name: expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestCreatePlatform_DuplicateSlug(t *testing.T) {
+	mockQueries := &MockQueries{
+		CreatePlatformFunc: func(ctx context.Context, p db.CreatePlatformParams) (db.Platform, error) {
+			return db.Platform{}, &pgconn.PgError{Code: uniqueViolation, ConstraintName: platformsSlugKey}
+		},
+	}
+
+	service := NewPlatformService(mockQueries)
+	_, err := service.CreatePlatform(context.Background(), "n64", "Nintendo 64")
+
+	if !errors.Is(err, ErrDuplicatePlatformSlug) {
+		t.Errorf("expected ErrDuplicatePlatformSlug, got %v", err)
+	}
+}
+
+func TestUpdatePlatform(t *testing.T) {
+	var params db.UpdatePlatformParams
+	mockQueries := &MockQueries{
+		GetPlatformBySlugFunc: platformLookup(db.Platform{ID: 2, Slug: "n64", Name: "N64"}),
+		UpdatePlatformFunc: func(ctx context.Context, p db.UpdatePlatformParams) (db.Platform, error) {
+			params = p
+			return db.Platform{ID: 2, Slug: p.Slug, Name: p.Name}, nil
+		},
+	}
+
+	service := NewPlatformService(mockQueries)
+	platform, err := service.UpdatePlatform(context.Background(), "n64", "Nintendo 64")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.Slug != "n64" || params.Name != "Nintendo 64" || platform.Name != "Nintendo 64" {
+		t.Errorf("expected the platform renamed, got params %+v and %+v", params, platform)
+	}
+
+	if _, err := service.UpdatePlatform(context.Background(), "missing", "Missing"); !errors.Is(err, ErrPlatformNotFound) {
+		t.Errorf("expected ErrPlatformNotFound, got %v", err)
+	}
+}
+
+func TestDeletePlatform(t *testing.T) {
+	var audited bool
+	mockQueries := &MockQueries{
+		GetPlatformBySlugFunc: platformLookup(db.Platform{ID: 2, Slug: "n64"}),
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = p.Action == "platform.delete" && p.EntityID == 2
+			return nil
+		},
+	}
+
+	service := NewPlatformService(mockQueries)
+	if err := service.DeletePlatform(context.Background(), "n64"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !audited {
+		t.Error("expected the deletion to be audited")
+	}
+
+	if err := service.DeletePlatform(context.Background(), "missing"); !errors.Is(err, ErrPlatformNotFound) {
+		t.Errorf("expected ErrPlatformNotFound, got %v", err)
+	}
+}
+
+func TestDeletePlatform_InUse(t *testing.T) {
+	mockQueries := &MockQueries{
+		DeletePlatformFunc: func(ctx context.Context, slug string) (int64, error) {
+			return 0, &pgconn.PgError{Code: foreignKeyViolation, ConstraintName: runsPlatformFkey}
+		},
+	}
+
+	service := NewPlatformService(mockQueries)
+	err := service.DeletePlatform(context.Background(), "n64")
+
+	if !errors.Is(err, ErrPlatformInUse) {
+		t.Errorf("expected ErrPlatformInUse, got %v", err)
+	}
+}
@@ -0,0 +1,575 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/stream"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Race states stored in races.status
+const (
+	RaceStatusOpen      = "open"
+	RaceStatusRunning   = "running"
+	RaceStatusFinished  = "finished"
+	RaceStatusCancelled = "cancelled"
+)
+
+const (
+	// defaultRaceCountdown is how long after everyone is ready a race starts,
+	// unless WithRaceCountdown sets another
+	defaultRaceCountdown = 10 * time.Second
+	
+	// minRaceParticipants is how many runners must be ready for a race to
+	// start
+	minRaceParticipants = 2
+)
+
+var (
+	// ErrRaceNotFound is returned when a race is not found
+	ErrRaceNotFound = errors.New("race not found")
+	
+	// ErrRaceClosed is returned when joining, readying up in, or cancelling a
+	// race that has already started or ended
+	ErrRaceClosed = errors.New("race is no longer open")
+	
+	// ErrRaceNotRunning is returned when finishing a race whose countdown has
+	// not ended, or that has ended
+	ErrRaceNotRunning = errors.New("race is not running")
+	
+	// ErrNotInRace is returned when the caller acts in a race they have not
+	// joined
+	ErrNotInRace = errors.New("user is not in this race")
+	
+	// ErrRaceDone is returned when the caller acts in a race they have
+	// already finished or forfeited
+	ErrRaceDone = errors.New("user has already finished or forfeited this race")
+)
+
+// RaceService handles business logic for race rooms, where runners play a
+// category at the same time
+//
+// Participants join an open race and ready up. Once at least two have joined
+// and all of them are ready, the race starts after a countdown, and each
+// finish is submitted as a run of the category flagged as a race result.
+// Races are timed by the server from the end of the countdown, so only
+// categories timed in real time can be raced.
+type RaceService struct {
+	queries   db.Store
+	runs      *RunService
+	countdown time.Duration
+	now       func() time.Time
+}
+
+// Race is a race along with its participants, finishers fastest first
+type Race struct {
+	db.Race
+	Participants []db.ListRaceParticipantsRow `json:"participants"`
+}
+
+// FinishRaceInput holds the details of the run a participant finished
+type FinishRaceInput struct {
+	VideoURL string
+	
+	// Platform is the slug of the platform the run was played on
+	Platform string
+	
+	// Region is the slug of the region the run was played in; empty when
+	// it is not recorded
+	Region string
+}
+
+// RaceOption configures optional RaceService behavior
+type RaceOption func(*RaceService)
+
+// WithRaceCountdown sets how long after everyone is ready a race starts
+func WithRaceCountdown(d time.Duration) RaceOption {
+	return func(s *RaceService) {
+		if d > 0 {
+			s.countdown = d
+		}
+	}
+}
+
+// NewRaceService creates a new RaceService instance; runs validates and
+// submits the runs of finishers
+func NewRaceService(queries db.Store, runs *RunService, opts ...RaceOption) *RaceService {
+	s := &RaceService{
+		queries:   queries,
+		runs:      runs,
+		countdown: defaultRaceCountdown,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateRace opens a race room for a category, with the caller as its first
+// participant
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game
+//   - categorySlug: Slug of the category that is raced
+//
+// Returns:
+//   - *Race: The created race
+//   - error: ErrForbidden, ErrCategoryNotFound, ErrInvalidInput for a
+//     category not timed in real time, ErrUserNotFound, ErrEmailNotVerified,
+//     or database errors
+func (s *RaceService) CreateRace(ctx context.Context, gameSlug, categorySlug string) (*Race, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.runs.getCategory(ctx, gameSlug, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+	if category.TimingMethod != TimingMethodRTA {
+		return nil, invalidField("category", "must be timed by %s, as races are timed in real time", TimingMethodRTA)
+	}
+	if err := s.requireRacer(ctx, userID); err != nil {
+		return nil, err
+	}
+	
+	var race db.Race
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		race, err = q.CreateRace(ctx, db.CreateRaceParams{
+			CategoryID: category.ID,
+			CreatedBy:  pgtype.Int4{Int32: userID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := q.AddRaceParticipant(ctx, db.AddRaceParticipantParams{RaceID: race.ID, UserID: userID}); err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "race.create", AuditEntityRace, race.ID, nil, race)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create race: %w", err)
+	}
+	
+	return s.GetRace(ctx, race.ID)
+}
+
+// GetRace retrieves a race and its participants as they are on the primary,
+// since race rooms show changes moments after they are made
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - id: ID of the race
+//
+// Returns:
+//   - *Race: The race
+//   - error: ErrRaceNotFound, or database errors
+func (s *RaceService) GetRace(ctx context.Context, id int32) (*Race, error) {
+	ctx = db.WithPrimary(ctx)
+	race, err := s.queries.GetRaceByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRaceNotFound
+		}
+		return nil, fmt.Errorf("failed to get race: %w", err)
+	}
+	
+	return s.withParticipants(ctx, race)
+}
+
+// ListRaces retrieves a category's races that are open or running
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - categorySlug: Slug of the category
+//
+// Returns:
+//   - []Race: The races, oldest first
+//   - error: ErrCategoryNotFound, or database errors
+func (s *RaceService) ListRaces(ctx context.Context, gameSlug, categorySlug string) ([]Race, error) {
+	category, err := s.runs.getCategory(ctx, gameSlug, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	races, err := s.queries.ListActiveRacesByCategory(ctx, category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list races: %w", err)
+	}
+	result := make([]Race, len(races))
+	for i, race := range races {
+		withParticipants, err := s.withParticipants(ctx, race)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *withParticipants
+	}
+	return result, nil
+}
+
+// JoinRace adds the caller to an open race; joining a race they are already
+// in changes nothing
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the race
+//
+// Returns:
+//   - *Race: The race after joining
+//   - error: ErrForbidden, ErrUserNotFound, ErrEmailNotVerified,
+//     ErrRaceNotFound, ErrRaceClosed, or database errors
+func (s *RaceService) JoinRace(ctx context.Context, id int32) (*Race, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireRacer(ctx, userID); err != nil {
+		return nil, err
+	}
+	
+	return s.update(ctx, id, func(q db.Querier, race *db.Race) error {
+		if race.Status != RaceStatusOpen {
+			return ErrRaceClosed
+		}
+		if _, err := q.AddRaceParticipant(ctx, db.AddRaceParticipantParams{RaceID: race.ID, UserID: userID}); err != nil {
+			return fmt.Errorf("failed to join race: %w", err)
+		}
+		return nil
+	})
+}
+
+// LeaveRace takes the caller out of a race; leaving once the race has
+// started forfeits it instead, which ends the race if everyone else is done
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the race
+//
+// Returns:
+//   - *Race: The race after leaving
+//   - error: ErrForbidden, ErrRaceNotFound, ErrRaceClosed, ErrNotInRace,
+//     ErrRaceDone, or database errors
+func (s *RaceService) LeaveRace(ctx context.Context, id int32) (*Race, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.update(ctx, id, func(q db.Querier, race *db.Race) error {
+		participant, err := getRaceParticipant(ctx, q, race, userID)
+		if err != nil {
+			return err
+		}
+		switch race.Status {
+		case RaceStatusOpen:
+			if _, err := q.RemoveRaceParticipant(ctx, db.RemoveRaceParticipantParams{RaceID: race.ID, UserID: userID}); err != nil {
+				return fmt.Errorf("failed to leave race: %w", err)
+			}
+			return nil
+		case RaceStatusRunning:
+			if raceDone(participant) {
+				return ErrRaceDone
+			}
+			if _, err := q.ForfeitRaceParticipant(ctx, db.ForfeitRaceParticipantParams{RaceID: race.ID, UserID: userID}); err != nil {
+				return fmt.Errorf("failed to forfeit race: %w", err)
+			}
+			return endIfDone(ctx, q, race)
+		default:
+			return ErrRaceClosed
+		}
+	})
+}
+
+// SetReady marks whether the caller is ready to start an open race; once at
+// least two participants have joined and all are ready, the race starts
+// after the countdown
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the race
+//   - ready: Whether the caller is ready
+//
+// Returns:
+//   - *Race: The race after the change, with StartsAt set if it started
+//   - error: ErrForbidden, ErrRaceNotFound, ErrRaceClosed, ErrNotInRace, or
+//     database errors
+func (s *RaceService) SetReady(ctx context.Context, id int32, ready bool) (*Race, error) {
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.update(ctx, id, func(q db.Querier, race *db.Race) error {
+		if _, err := getRaceParticipant(ctx, q, race, userID); err != nil {
+			return err
+		}
+		if race.Status != RaceStatusOpen {
+			return ErrRaceClosed
+		}
+		if _, err := q.SetRaceParticipantReady(ctx, db.SetRaceParticipantReadyParams{
+			RaceID: race.ID,
+			UserID: userID,
+			Ready:  ready,
+		}); err != nil {
+			return fmt.Errorf("failed to ready up: %w", err)
+		}
+		if !ready {
+			return nil
+		}
+		
+		participants, err := q.ListRaceParticipants(ctx, race.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list race participants: %w", err)
+		}
+		if len(participants) < minRaceParticipants {
+			return nil
+		}
+		for _, participant := range participants {
+			if !participant.Ready {
+				return nil
+			}
+		}
+		started, err := q.StartRace(ctx, db.StartRaceParams{
+			ID:       race.ID,
+			StartsAt: pgtype.Timestamptz{Time: s.now().Add(s.countdown), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start race: %w", err)
+		}
+		*race = started
+		return nil
+	})
+}
+
+// FinishRace records that the caller finished a running race, timed from the
+// end of the countdown to now, and submits their run as a race result; the
+// race ends once every participant has finished or forfeited
+//
+// The run is submitted like any other, so its video must be a YouTube video
+// or Twitch VOD and moderators review it before it is ranked.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the race
+//   - input: The run's video, platform, and region
+//
+// Returns:
+//   - *Race: The race after finishing
+//   - error: ErrForbidden, ErrRaceNotFound, ErrRaceNotRunning, ErrInvalidInput,
+//     ErrNotInRace, ErrRaceDone, or database errors
+func (s *RaceService) FinishRace(ctx context.Context, id int32, input FinishRaceInput) (*Race, error) {
+	finishedAt := s.now()
+	userID, err := requireAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	current, err := s.GetRace(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != RaceStatusRunning || finishedAt.Before(current.StartsAt.Time) {
+		return nil, ErrRaceNotRunning
+	}
+	timeMs := max(finishedAt.Sub(current.StartsAt.Time).Milliseconds(), 1)
+	platform, link, err := s.runs.validateRun(&SubmitRunInput{
+		UserID:   userID,
+		Times:    RunTimes{RTA: timeMs},
+		VideoURL: input.VideoURL,
+		PlayedOn: current.StartsAt.Time,
+		Platform: input.Platform,
+		Region:   input.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.runs.checkVideo(ctx, link); err != nil {
+		return nil, err
+	}
+	
+	return s.update(ctx, id, func(q db.Querier, race *db.Race) error {
+		participant, err := getRaceParticipant(ctx, q, race, userID)
+		if err != nil {
+			return err
+		}
+		if race.Status != RaceStatusRunning {
+			return ErrRaceNotRunning
+		}
+		if raceDone(participant) {
+			return ErrRaceDone
+		}
+		_, region, err := resolvePlatformAndRegion(ctx, q, platform, input.Region)
+		if err != nil {
+			return err
+		}
+		
+		run, err := createRun(ctx, q, db.CreateRunParams{
+			UserID:     userID,
+			CategoryID: race.CategoryID,
+			TimeMs:     timeMs,
+			VideoUrl:   link.URL(),
+			Platform:   platform,
+			PlayedOn:   pgtype.Date{Time: race.StartsAt.Time, Valid: true},
+			Region:     region,
+			RtaMs:      optionalTime(timeMs),
+			RaceID:     pgtype.Int4{Int32: race.ID, Valid: true},
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create run: %w", err)
+		}
+		if _, err := q.FinishRaceParticipant(ctx, db.FinishRaceParticipantParams{
+			RaceID:     race.ID,
+			UserID:     userID,
+			FinishedAt: pgtype.Timestamptz{Time: finishedAt, Valid: true},
+			TimeMs:     pgtype.Int8{Int64: timeMs, Valid: true},
+			RunID:      pgtype.Int4{Int32: run.ID, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to finish race: %w", err)
+		}
+		return endIfDone(ctx, q, race)
+	})
+}
+
+// CancelRace ends a race before it starts; only its creator and admins may
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the race
+//
+// Returns:
+//   - *Race: The cancelled race
+//   - error: ErrRaceNotFound, ErrForbidden, ErrRaceClosed, or database errors
+func (s *RaceService) CancelRace(ctx context.Context, id int32) (*Race, error) {
+	return s.update(ctx, id, func(q db.Querier, race *db.Race) error {
+		if err := requireSelfOrAdmin(ctx, race.CreatedBy.Int32); err != nil {
+			return err
+		}
+		if race.Status != RaceStatusOpen {
+			return ErrRaceClosed
+		}
+		
+		cancelled, err := q.EndRace(ctx, db.EndRaceParams{ID: race.ID, Status: RaceStatusCancelled})
+		if err != nil {
+			return fmt.Errorf("failed to cancel race: %w", err)
+		}
+		if err := recordAudit(ctx, q, "race.cancel", AuditEntityRace, race.ID, *race, cancelled); err != nil {
+			return err
+		}
+		*race = cancelled
+		return nil
+	})
+}
+
+// update applies change to a race in a transaction holding the race's lock,
+// tells the race's room on every instance that it changed, and returns the
+// race as it is afterwards
+func (s *RaceService) update(ctx context.Context, id int32, change func(q db.Querier, race *db.Race) error) (*Race, error) {
+	err := s.queries.WithTx(ctx, func(q db.Querier) error {
+		race, err := q.GetRaceByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrRaceNotFound
+			}
+			return fmt.Errorf("failed to get race: %w", err)
+		}
+		if err := change(q, &race); err != nil {
+			return err
+		}
+		return notifyRaceUpdated(ctx, q, race.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.GetRace(ctx, id)
+}
+
+// withParticipants loads the participants of race
+func (s *RaceService) withParticipants(ctx context.Context, race db.Race) (*Race, error) {
+	participants, err := s.queries.ListRaceParticipants(ctx, race.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list race participants: %w", err)
+	}
+	if participants == nil {
+		participants = []db.ListRaceParticipantsRow{}
+	}
+	
+	return &Race{Race: race, Participants: participants}, nil
+}
+
+// requireRacer allows users who may submit runs, since every finish in a
+// race is submitted as one
+func (s *RaceService) requireRacer(ctx context.Context, userID int32) error {
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.EmailVerifiedAt.Valid {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// getRaceParticipant looks up userID's place in race
+func getRaceParticipant(ctx context.Context, q db.Querier, race *db.Race, userID int32) (*db.RaceParticipant, error) {
+	participant, err := q.GetRaceParticipant(ctx, db.GetRaceParticipantParams{RaceID: race.ID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotInRace
+		}
+		return nil, fmt.Errorf("failed to get race participant: %w", err)
+	}
+	
+	return &participant, nil
+}
+
+// raceDone reports whether participant has finished or forfeited their race
+func raceDone(participant *db.RaceParticipant) bool {
+	return participant.FinishedAt.Valid || participant.ForfeitedAt.Valid
+}
+
+// endIfDone finishes race once every participant has finished or forfeited
+func endIfDone(ctx context.Context, q db.Querier, race *db.Race) error {
+	participants, err := q.ListRaceParticipants(ctx, race.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list race participants: %w", err)
+	}
+	for _, participant := range participants {
+		if !participant.FinishedAt.Valid && !participant.ForfeitedAt.Valid {
+			return nil
+		}
+	}
+	
+	finished, err := q.EndRace(ctx, db.EndRaceParams{ID: race.ID, Status: RaceStatusFinished})
+	if err != nil {
+		return fmt.Errorf("failed to end race: %w", err)
+	}
+	*race = finished
+	return nil
+}
+
+// notifyRaceUpdated tells the clients in a race's room on every instance that
+// it changed; inside a transaction, they are told once it commits
+func notifyRaceUpdated(ctx context.Context, q db.Querier, raceID int32) error {
+	payload, err := json.Marshal(stream.Message{Event: stream.RaceUpdated, RaceID: raceID})
+	if err != nil {
+		return fmt.Errorf("failed to encode race update: %w", err)
+	}
+	if err := q.NotifyListeners(ctx, db.NotifyListenersParams{Channel: stream.Channel, Payload: string(payload)}); err != nil {
+		return fmt.Errorf("failed to notify race room: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) CreateRace(ctx context.Context, params db.CreateRaceParams) (db.Race, error) {
+	if m.CreateRaceFunc != nil {
+		return m.CreateRaceFunc(ctx, params)
+	}
+	return db.Race{}, nil
+}
+
+func (m *MockQueries) GetRaceByID(ctx context.Context, id int32) (db.Race, error) {
+	if m.GetRaceByIDFunc != nil {
+		return m.GetRaceByIDFunc(ctx, id)
+	}
+	return db.Race{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetRaceByIDForUpdate(ctx context.Context, id int32) (db.Race, error) {
+	if m.GetRaceByIDForUpdateFunc != nil {
+		return m.GetRaceByIDForUpdateFunc(ctx, id)
+	}
+	return db.Race{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) ListActiveRacesByCategory(ctx context.Context, categoryID int32) ([]db.Race, error) {
+	if m.ListActiveRacesByCategoryFunc != nil {
+		return m.ListActiveRacesByCategoryFunc(ctx, categoryID)
+	}
+	return []db.Race{}, nil
+}
+
+func (m *MockQueries) StartRace(ctx context.Context, params db.StartRaceParams) (db.Race, error) {
+	if m.StartRaceFunc != nil {
+		return m.StartRaceFunc(ctx, params)
+	}
+	return db.Race{}, nil
+}
+
+func (m *MockQueries) EndRace(ctx context.Context, params db.EndRaceParams) (db.Race, error) {
+	if m.EndRaceFunc != nil {
+		return m.EndRaceFunc(ctx, params)
+	}
+	return db.Race{}, nil
+}
+
+func (m *MockQueries) AddRaceParticipant(ctx context.Context, params db.AddRaceParticipantParams) (int64, error) {
+	if m.AddRaceParticipantFunc != nil {
+		return m.AddRaceParticipantFunc(ctx, params)
+	}
+	return 1, nil
+}
+
+func (m *MockQueries) RemoveRaceParticipant(ctx context.Context, params db.RemoveRaceParticipantParams) (int64, error) {
+	if m.RemoveRaceParticipantFunc != nil {
+		return m.RemoveRaceParticipantFunc(ctx, params)
+	}
+	return 1, nil
+}
+
+func (m *MockQueries) GetRaceParticipant(ctx context.Context, params db.GetRaceParticipantParams) (db.RaceParticipant, error) {
+	if m.GetRaceParticipantFunc != nil {
+		return m.GetRaceParticipantFunc(ctx, params)
+	}
+	return db.RaceParticipant{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) SetRaceParticipantReady(ctx context.Context, params db.SetRaceParticipantReadyParams) (int64, error) {
+	if m.SetRaceParticipantReadyFunc != nil {
+		return m.SetRaceParticipantReadyFunc(ctx, params)
+	}
+	return 1, nil
+}
+
+func (m *MockQueries) FinishRaceParticipant(ctx context.Context, params db.FinishRaceParticipantParams) (int64, error) {
+	if m.FinishRaceParticipantFunc != nil {
+		return m.FinishRaceParticipantFunc(ctx, params)
+	}
+	return 1, nil
+}
+
+func (m *MockQueries) ForfeitRaceParticipant(ctx context.Context, params db.ForfeitRaceParticipantParams) (int64, error) {
+	if m.ForfeitRaceParticipantFunc != nil {
+		return m.ForfeitRaceParticipantFunc(ctx, params)
+	}
+	return 1, nil
+}
+
+func (m *MockQueries) ListRaceParticipants(ctx context.Context, raceID int32) ([]db.ListRaceParticipantsRow, error) {
+	if m.ListRaceParticipantsFunc != nil {
+		return m.ListRaceParticipantsFunc(ctx, raceID)
+	}
+	return []db.ListRaceParticipantsRow{}, nil
+}
+
+// raceTable is an in-memory race and its participants behind MockQueries
+type raceTable struct {
+	race         db.Race
+	participants []db.RaceParticipant
+	runs         []db.CreateRunParams
+	notified     int
+}
+
+// newRaceTable stores race, with userIDs as its participants, in m, and
+// makes every user m looks up verified
+func newRaceTable(m *MockQueries, race db.Race, userIDs ...int32) *raceTable {
+	table := &raceTable{race: race}
+	for _, userID := range userIDs {
+		table.participants = append(table.participants, db.RaceParticipant{RaceID: race.ID, UserID: userID})
+	}
+
+	getRace := func(ctx context.Context, id int32) (db.Race, error) {
+		if id != table.race.ID {
+			return db.Race{}, sql.ErrNoRows
+		}
+		return table.race, nil
+	}
+	find := func(userID int32) int {
+		return slices.IndexFunc(table.participants, func(p db.RaceParticipant) bool { return p.UserID == userID })
+	}
+	update := func(userID int32, change func(p *db.RaceParticipant)) (int64, error) {
+		i := find(userID)
+		if i < 0 {
+			return 0, nil
+		}
+		change(&table.participants[i])
+		return 1, nil
+	}
+
+	m.GetUserByIDFunc = func(ctx context.Context, id int32) (db.User, error) {
+		return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+	}
+	m.GetRaceByIDFunc = getRace
+	m.GetRaceByIDForUpdateFunc = getRace
+	m.StartRaceFunc = func(ctx context.Context, params db.StartRaceParams) (db.Race, error) {
+		table.race.Status = RaceStatusRunning
+		table.race.StartsAt = params.StartsAt
+		return table.race, nil
+	}
+	m.EndRaceFunc = func(ctx context.Context, params db.EndRaceParams) (db.Race, error) {
+		table.race.Status = params.Status
+		table.race.FinishedAt = timeToTimestamptz(time.Now())
+		return table.race, nil
+	}
+	m.AddRaceParticipantFunc = func(ctx context.Context, params db.AddRaceParticipantParams) (int64, error) {
+		if find(params.UserID) >= 0 {
+			return 0, nil
+		}
+		table.participants = append(table.participants, db.RaceParticipant{RaceID: params.RaceID, UserID: params.UserID})
+		return 1, nil
+	}
+	m.RemoveRaceParticipantFunc = func(ctx context.Context, params db.RemoveRaceParticipantParams) (int64, error) {
+		i := find(params.UserID)
+		if i < 0 {
+			return 0, nil
+		}
+		table.participants = slices.Delete(table.participants, i, i+1)
+		return 1, nil
+	}
+	m.GetRaceParticipantFunc = func(ctx context.Context, params db.GetRaceParticipantParams) (db.RaceParticipant, error) {
+		i := find(params.UserID)
+		if i < 0 {
+			return db.RaceParticipant{}, sql.ErrNoRows
+		}
+		return table.participants[i], nil
+	}
+	m.SetRaceParticipantReadyFunc = func(ctx context.Context, params db.SetRaceParticipantReadyParams) (int64, error) {
+		return update(params.UserID, func(p *db.RaceParticipant) { p.Ready = params.Ready })
+	}
+	m.FinishRaceParticipantFunc = func(ctx context.Context, params db.FinishRaceParticipantParams) (int64, error) {
+		return update(params.UserID, func(p *db.RaceParticipant) {
+			p.FinishedAt, p.TimeMs, p.RunID = params.FinishedAt, params.TimeMs, params.RunID
+		})
+	}
+	m.ForfeitRaceParticipantFunc = func(ctx context.Context, params db.ForfeitRaceParticipantParams) (int64, error) {
+		return update(params.UserID, func(p *db.RaceParticipant) { p.ForfeitedAt = timeToTimestamptz(time.Now()) })
+	}
+	m.ListRaceParticipantsFunc = func(ctx context.Context, raceID int32) ([]db.ListRaceParticipantsRow, error) {
+		rows := make([]db.ListRaceParticipantsRow, len(table.participants))
+		for i, p := range table.participants {
+			rows[i] = db.ListRaceParticipantsRow{
+				RaceID:      p.RaceID,
+				UserID:      p.UserID,
+				Ready:       p.Ready,
+				FinishedAt:  p.FinishedAt,
+				TimeMs:      p.TimeMs,
+				RunID:       p.RunID,
+				ForfeitedAt: p.ForfeitedAt,
+			}
+		}
+		return rows, nil
+	}
+	m.CreateRunFunc = func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+		table.runs = append(table.runs, params)
+		return db.Run{ID: int32(100 + len(table.runs)), UserID: params.UserID, CategoryID: params.CategoryID, RaceID: params.RaceID}, nil
+	}
+	m.NotifyListenersFunc = func(ctx context.Context, params db.NotifyListenersParams) error {
+		table.notified++
+		return nil
+	}
+	return table
+}
+
+// racer returns a context for a logged in user
+func racer(userID int32) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID})
+}
+
+func TestCreateRace(t *testing.T) {
+	timing := TimingMethodRTA
+	var created db.CreateRaceParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1, TimingMethod: timing}, nil
+		},
+		CreateRaceFunc: func(ctx context.Context, params db.CreateRaceParams) (db.Race, error) {
+			created = params
+			return db.Race{ID: 7, CategoryID: params.CategoryID, CreatedBy: params.CreatedBy, Status: RaceStatusOpen}, nil
+		},
+	}
+	table := newRaceTable(mockQueries, db.Race{ID: 7, CategoryID: 3, Status: RaceStatusOpen})
+	service := NewRaceService(mockQueries, NewRunService(mockQueries))
+
+	race, err := service.CreateRace(racer(2), "super-mario-64", "120-star")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.CategoryID != 3 || created.CreatedBy != (pgtype.Int4{Int32: 2, Valid: true}) {
+		t.Errorf("unexpected insert params %+v", created)
+	}
+	if len(race.Participants) != 1 || race.Participants[0].UserID != 2 {
+		t.Errorf("expected the creator to be the only participant, got %+v", race.Participants)
+	}
+	if len(table.participants) != 1 {
+		t.Errorf("expected the creator to have joined, got %+v", table.participants)
+	}
+
+	if _, err := service.CreateRace(context.Background(), "super-mario-64", "120-star"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden for an anonymous caller, got %v", err)
+	}
+	timing = TimingMethodIGT
+	_, err = service.CreateRace(racer(2), "super-mario-64", "120-star")
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || invalid.Fields[0].Field != "category" {
+		t.Errorf("expected the category to be invalid when it is timed in game, got %v", err)
+	}
+}
+
+func TestSetReady_StartsOnceEveryoneIsReady(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockQueries := &MockQueries{}
+	table := newRaceTable(mockQueries, db.Race{ID: 7, CategoryID: 3, Status: RaceStatusOpen}, 1)
+	service := NewRaceService(mockQueries, NewRunService(mockQueries), WithRaceCountdown(15*time.Second))
+	service.now = func() time.Time { return now }
+
+	race, err := service.SetReady(racer(1), 7, true)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if race.Status != RaceStatusOpen {
+		t.Errorf("expected a race with one participant to stay open, got %s", race.Status)
+	}
+
+	if _, err := service.JoinRace(racer(2), 7); err != nil {
+		t.Fatalf("expected no error joining, got %v", err)
+	}
+	if _, err := service.SetReady(racer(3), 7, true); !errors.Is(err, ErrNotInRace) {
+		t.Errorf("expected ErrNotInRace for a spectator, got %v", err)
+	}
+	race, err = service.SetReady(racer(2), 7, true)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if race.Status != RaceStatusRunning {
+		t.Fatalf("expected the race to start, got %s", race.Status)
+	}
+	if !race.StartsAt.Time.Equal(now.Add(15 * time.Second)) {
+		t.Errorf("expected the race to start after the countdown, got %v", race.StartsAt.Time)
+	}
+	if table.notified != 3 {
+		t.Errorf("expected the room notified of every change, got %d notifications", table.notified)
+	}
+
+	if _, err := service.JoinRace(racer(3), 7); !errors.Is(err, ErrRaceClosed) {
+		t.Errorf("expected ErrRaceClosed joining a running race, got %v", err)
+	}
+	if _, err := service.SetReady(racer(1), 7, false); !errors.Is(err, ErrRaceClosed) {
+		t.Errorf("expected ErrRaceClosed unreadying in a running race, got %v", err)
+	}
+}
+
+func TestFinishRace(t *testing.T) {
+	startsAt := time.Now().Add(-90 * time.Second)
+	mockQueries := &MockQueries{
+		GetPlatformBySlugFunc: platformLookup(db.Platform{ID: 1, Slug: "n64"}),
+	}
+	table := newRaceTable(mockQueries, db.Race{
+		ID:         7,
+		CategoryID: 3,
+		Status:     RaceStatusRunning,
+		StartsAt:   timeToTimestamptz(startsAt),
+	}, 1, 2)
+	service := NewRaceService(mockQueries, NewRunService(mockQueries))
+	service.now = func() time.Time { return startsAt.Add(90 * time.Second) }
+	input := FinishRaceInput{VideoURL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Platform: "n64"}
+
+	race, err := service.FinishRace(racer(1), 7, input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(table.runs) != 1 {
+		t.Fatalf("expected a run submitted, got %d", len(table.runs))
+	}
+	run := table.runs[0]
+	if run.UserID != 1 || run.CategoryID != 3 || run.TimeMs != 90000 || run.RtaMs.Int64 != 90000 || run.RaceID != (pgtype.Int4{Int32: 7, Valid: true}) {
+		t.Errorf("unexpected run %+v", run)
+	}
+	if race.Status != RaceStatusRunning {
+		t.Errorf("expected the race to run until everyone is done, got %s", race.Status)
+	}
+	if p := race.Participants[0]; p.TimeMs.Int64 != 90000 || p.RunID.Int32 != 101 {
+		t.Errorf("expected the finish recorded with its run, got %+v", p)
+	}
+	if _, err := service.FinishRace(racer(1), 7, input); !errors.Is(err, ErrRaceDone) {
+		t.Errorf("expected ErrRaceDone finishing twice, got %v", err)
+	}
+
+	race, err = service.LeaveRace(racer(2), 7)
+	if err != nil {
+		t.Fatalf("expected no error forfeiting, got %v", err)
+	}
+	if race.Status != RaceStatusFinished || !race.Participants[1].ForfeitedAt.Valid {
+		t.Errorf("expected the forfeit to end the race, got %+v", race)
+	}
+	if _, err := service.FinishRace(racer(2), 7, input); !errors.Is(err, ErrRaceNotRunning) {
+		t.Errorf("expected ErrRaceNotRunning after the race ended, got %v", err)
+	}
+}
+
+func TestFinishRace_BeforeCountdownEnds(t *testing.T) {
+	startsAt := time.Now().Add(5 * time.Second)
+	mockQueries := &MockQueries{}
+	table := newRaceTable(mockQueries, db.Race{
+		ID:       7,
+		Status:   RaceStatusRunning,
+		StartsAt: timeToTimestamptz(startsAt),
+	}, 1, 2)
+	service := NewRaceService(mockQueries, NewRunService(mockQueries))
+
+	_, err := service.FinishRace(racer(1), 7, FinishRaceInput{VideoURL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Platform: "n64"})
+	if !errors.Is(err, ErrRaceNotRunning) {
+		t.Errorf("expected ErrRaceNotRunning, got %v", err)
+	}
+	if len(table.runs) != 0 {
+		t.Errorf("expected no run submitted, got %+v", table.runs)
+	}
+}
+
+func TestLeaveRace_Open(t *testing.T) {
+	mockQueries := &MockQueries{}
+	table := newRaceTable(mockQueries, db.Race{ID: 7, Status: RaceStatusOpen}, 1, 2)
+	service := NewRaceService(mockQueries, NewRunService(mockQueries))
+
+	race, err := service.LeaveRace(racer(2), 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(race.Participants) != 1 || table.race.Status != RaceStatusOpen {
+		t.Errorf("expected the participant removed from the open race, got %+v", race)
+	}
+	if _, err := service.LeaveRace(racer(2), 7); !errors.Is(err, ErrNotInRace) {
+		t.Errorf("expected ErrNotInRace, got %v", err)
+	}
+}
+
+func TestCancelRace(t *testing.T) {
+	mockQueries := &MockQueries{}
+	table := newRaceTable(mockQueries, db.Race{ID: 7, CreatedBy: pgtype.Int4{Int32: 1, Valid: true}, Status: RaceStatusOpen}, 1, 2)
+	service := NewRaceService(mockQueries, NewRunService(mockQueries))
+
+	if _, err := service.CancelRace(racer(2), 7); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden for another participant, got %v", err)
+	}
+	race, err := service.CancelRace(racer(1), 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if race.Status != RaceStatusCancelled {
+		t.Errorf("expected the race cancelled, got %s", race.Status)
+	}
+	if _, err := service.CancelRace(racer(1), 7); !errors.Is(err, ErrRaceClosed) {
+		t.Errorf("expected ErrRaceClosed cancelling twice, got %v", err)
+	}
+	if table.notified != 1 {
+		t.Errorf("expected the room notified once, got %d", table.notified)
+	}
+
+	if _, err := service.GetRace(context.Background(), 8); !errors.Is(err, ErrRaceNotFound) {
+		t.Errorf("expected ErrRaceNotFound, got %v", err)
+	}
+}
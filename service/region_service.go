@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrRegionNotFound is returned when no region has the given slug
+	ErrRegionNotFound = errors.New("region not found")
+	
+	// ErrDuplicateRegionSlug is returned when a region with the slug already exists
+	ErrDuplicateRegionSlug = errors.New("region with this slug already exists")
+	
+	// ErrRegionInUse is returned when deleting a region that runs were played in
+	ErrRegionInUse = errors.New("region has runs")
+)
+
+const (
+	// regionsSlugKey is the unique constraint on regions.slug
+	regionsSlugKey = "regions_slug_key"
+	
+	// runsRegionFkey is the foreign key from runs.region to regions.slug
+	runsRegionFkey = "runs_region_fkey"
+)
+
+// RegionService handles business logic for the regions, such as NTSC or
+// PAL releases of a game, that runs can record they were played in
+type RegionService struct {
+	queries db.Store
+}
+
+// NewRegionService creates a new RegionService instance
+func NewRegionService(queries db.Store) *RegionService {
+	return &RegionService{queries: queries}
+}
+
+// ListRegions retrieves every region
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []db.Region: The regions, ordered by name
+//   - error: Database errors
+func (s *RegionService) ListRegions(ctx context.Context) ([]db.Region, error) {
+	regions, err := s.queries.ListRegions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+	
+	return regions, nil
+}
+
+// CreateRegion adds a region runs can record they were played in
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: URL-safe identifier runs refer to the region by
+//   - name: Display name of the region
+//
+// Returns:
+//   - *db.Region: The created region
+//   - error: ErrInvalidInput, ErrDuplicateRegionSlug, or database errors
+func (s *RegionService) CreateRegion(ctx context.Context, slug, name string) (*db.Region, error) {
+	if err := validateSlug(slug); err != nil {
+		return nil, err
+	}
+	name, err := validateDisplayName(name)
+	if err != nil {
+		return nil, err
+	}
+	
+	var region db.Region
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		region, err = q.CreateRegion(ctx, db.CreateRegionParams{Slug: slug, Name: name})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "region.create", AuditEntityRegion, region.ID, nil, region)
+	})
+	if err != nil {
+		if isDuplicateRegionSlugError(err) {
+			return nil, ErrDuplicateRegionSlug
+		}
+		return nil, fmt.Errorf("failed to create region: %w", err)
+	}
+	
+	return &region, nil
+}
+
+// UpdateRegion renames a region; its slug cannot change, since runs
+// refer to the region by it
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: Slug of the region to rename
+//   - name: New display name of the region
+//
+// Returns:
+//   - *db.Region: The updated region
+//   - error: ErrInvalidInput, ErrRegionNotFound, or database errors
+func (s *RegionService) UpdateRegion(ctx context.Context, slug, name string) (*db.Region, error) {
+	name, err := validateDisplayName(name)
+	if err != nil {
+		return nil, err
+	}
+	
+	var region db.Region
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		existing, err := getRegion(ctx, q, slug)
+		if err != nil {
+			return err
+		}
+		
+		region, err = q.UpdateRegion(ctx, db.UpdateRegionParams{Name: name, Slug: slug})
+		if err != nil {
+			return fmt.Errorf("failed to update region: %w", err)
+		}
+		return recordAudit(ctx, q, "region.update", AuditEntityRegion, region.ID, existing, region)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return &region, nil
+}
+
+// DeleteRegion deletes a region no run was played in
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - slug: Slug of the region to delete
+//
+// Returns:
+//   - error: ErrRegionNotFound, ErrRegionInUse, or database errors
+func (s *RegionService) DeleteRegion(ctx context.Context, slug string) error {
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		region, err := getRegion(ctx, q, slug)
+		if err != nil {
+			return err
+		}
+		
+		deleted, err := q.DeleteRegion(ctx, slug)
+		if err != nil {
+			if isForeignKeyViolation(err, runsRegionFkey) {
+				return ErrRegionInUse
+			}
+			return fmt.Errorf("failed to delete region: %w", err)
+		}
+		if deleted == 0 {
+			return ErrRegionNotFound
+		}
+		
+		return recordAudit(ctx, q, "region.delete", AuditEntityRegion, region.ID, region, nil)
+	})
+}
+
+// getRegion looks up a region by its slug
+func getRegion(ctx context.Context, q db.Querier, slug string) (*db.Region, error) {
+	region, err := q.GetRegionBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRegionNotFound
+		}
+		return nil, fmt.Errorf("failed to get region: %w", err)
+	}
+	
+	return &region, nil
+}
+
+// isDuplicateRegionSlugError reports whether err is a unique violation on
+// region slugs
+func isDuplicateRegionSlugError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == regionsSlugKey
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (m *MockQueries) ListRegions(ctx context.Context) ([]db.Region, error) {
+	if m.ListRegionsFunc != nil {
+		return m.ListRegionsFunc(ctx)
+	}
+	return []db.Region{}, nil
+}
+
+func (m *MockQueries) GetRegionBySlug(ctx context.Context, slug string) (db.Region, error) {
+	if m.GetRegionBySlugFunc != nil {
+		return m.GetRegionBySlugFunc(ctx, slug)
+	}
+	return db.Region{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) CreateRegion(ctx context.Context, params db.CreateRegionParams) (db.Region, error) {
+	if m.CreateRegionFunc != nil {
+		return m.CreateRegionFunc(ctx, params)
+	}
+	return db.Region{}, nil
+}
+
+func (m *MockQueries) UpdateRegion(ctx context.Context, params db.UpdateRegionParams) (db.Region, error) {
+	if m.UpdateRegionFunc != nil {
+		return m.UpdateRegionFunc(ctx, params)
+	}
+	return db.Region{}, nil
+}
+
+func (m *MockQueries) DeleteRegion(ctx context.Context, slug string) (int64, error) {
+	if m.DeleteRegionFunc != nil {
+		return m.DeleteRegionFunc(ctx, slug)
+	}
+	return 1, nil
+}
+
+// regionLookup returns a GetRegionBySlugFunc that finds only region
+func regionLookup(region db.Region) func(ctx context.Context, slug string) (db.Region, error) {
+	return func(ctx context.Context, slug string) (db.Region, error) {
+		if slug != region.Slug {
+			return db.Region{}, sql.ErrNoRows
+		}
+		return region, nil
+	}
+}
+
+func TestListRegions(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListRegionsFunc: func(ctx context.Context) ([]db.Region, error) {
+			return []db.Region{{ID: 1, Slug: "eur"}, {ID: 2, Slug: "usa"}}, nil
+		},
+	}
+
+	regions, err := NewRegionService(mockQueries).ListRegions(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(regions) != 2 || regions[0].Slug != "eur" {
+		t.Errorf("expected both regions in order, got %+v", regions)
+	}
+}
+
+func TestCreateRegion_DuplicateSlug(t *testing.T) {
+	mockQueries := &MockQueries{
+		CreateRegionFunc: func(ctx context.Context, p db.CreateRegionParams) (db.Region, error) {
+			return db.Region{}, &pgconn.PgError{Code: uniqueViolation, ConstraintName: regionsSlugKey}
+		},
+	}
+
+	service := NewRegionService(mockQueries)
+	_, err := service.CreateRegion(context.Background(), "usa", "USA / NTSC")
+
+	if !errors.Is(err, ErrDuplicateRegionSlug) {
+		t.Errorf("expected ErrDuplicateRegionSlug, got %v", err)
+	}
+}
+
+func TestUpdateRegion_NotFound(t *testing.T) {
+	service := NewRegionService(&MockQueries{})
+
+	if _, err := service.UpdateRegion(context.Background(), "usa", "USA / NTSC"); !errors.Is(err, ErrRegionNotFound) {
+		t.Errorf("expected ErrRegionNotFound, got %v", err)
+	}
+}
+
+func TestDeleteRegion_InUse(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetRegionBySlugFunc: regionLookup(db.Region{ID: 1, Slug: "usa"}),
+		DeleteRegionFunc: func(ctx context.Context, slug string) (int64, error) {
+			return 0, &pgconn.PgError{Code: foreignKeyViolation, ConstraintName: runsRegionFkey}
+		},
+	}
+
+	service := NewRegionService(mockQueries)
+	err := service.DeleteRegion(context.Background(), "usa")
+
+	if !errors.Is(err, ErrRegionInUse) {
+		t.Errorf("expected ErrRegionInUse, got %v", err)
+	}
+}
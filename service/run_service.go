@@ -0,0 +1,1162 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/example/speedrun-rest-api/cache"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/video"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/sync/singleflight"
+)
+
+// maxPlatformLength matches the VARCHAR(100) runs.platform column
+const maxPlatformLength = 100
+
+// maxRejectionReasonLength bounds the reason a moderator gives for a rejection
+const maxRejectionReasonLength = 1000
+
+// defaultModerationSLA is how long a run may wait for review before the
+// moderation queue shows it as overdue, unless WithModerationSLA sets another
+const defaultModerationSLA = 72 * time.Hour
+
+// Run moderation states stored in runs.status
+const (
+	RunStatusPending  = "pending"
+	RunStatusVerified = "verified"
+	RunStatusRejected = "rejected"
+)
+
+var (
+	// ErrRunNotFound is returned when a run is not found
+	ErrRunNotFound = errors.New("run not found")
+	
+	// ErrInvalidRunTransition is returned when a run cannot move to the
+	// requested status from the one it is in
+	ErrInvalidRunTransition = errors.New("run cannot change to the requested status")
+	
+	// ErrEmailNotVerified is returned when a user who has not verified their
+	// email address submits a run
+	ErrEmailNotVerified = errors.New("email address has not been verified")
+)
+
+// runTransitions lists the statuses each run status may move to. Reviews are
+// final: a verified or rejected run is never moved again.
+var runTransitions = map[string][]string{
+	RunStatusPending: {RunStatusVerified, RunStatusRejected},
+}
+
+// RunService handles business logic for run submissions
+type RunService struct {
+	queries db.Store
+	pages   pageSizes
+	now     func() time.Time
+	
+	// videos confirms that submitted videos can be watched; nil skips the
+	// check
+	videos video.Checker
+	
+	// moderationSLA is how long a run may wait for review before it is
+	// overdue
+	moderationSLA time.Duration
+	
+	// cache holds leaderboard pages; nil disables caching
+	cache *cache.Cache
+	
+	// leaderboards coalesces concurrent Leaderboard calls for the same page
+	leaderboards singleflight.Group
+}
+
+// RunPage is one page of runs along with the pagination that was actually
+// applied
+type RunPage struct {
+	Runs   []db.Run
+	Total  int64
+	Limit  int32
+	Offset int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+	
+	// Variables holds the variable values of the runs that have any, keyed
+	// by run ID
+	Variables map[int32]RunVariables
+}
+
+// LeaderboardPage is one page of ranked standings along with the pagination
+// that was actually applied
+type LeaderboardPage struct {
+	Entries []db.GetLeaderboardRow
+	Total   int64
+	Limit   int32
+	Offset  int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// ModerationQueuePage is one page of a game's pending runs, longest waiting
+// first, along with the pagination that was actually applied
+type ModerationQueuePage struct {
+	Entries []ModerationQueueEntry
+	Total   int64
+	Limit   int32
+	Offset  int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+	
+	// SLA is how long a run may wait for review before it is overdue
+	SLA time.Duration
+	
+	// Variables holds the variable values of the runs that have any, keyed
+	// by run ID
+	Variables map[int32]RunVariables
+}
+
+// ModerationQueueEntry is a pending run and how long it has waited for review
+type ModerationQueueEntry struct {
+	Run db.Run
+	
+	// Waiting is how long ago the run was submitted
+	Waiting time.Duration
+	
+	// Overdue reports whether the run has waited longer than the SLA
+	Overdue bool
+}
+
+// ListRunsFilter narrows the runs returned by ListCategoryRuns and
+// ListUserRuns
+type ListRunsFilter struct {
+	// IncludeObsolete also returns verified runs their runner has since
+	// beaten in the same category
+	IncludeObsolete bool
+}
+
+// LeaderboardFilter narrows the runs Leaderboard ranks
+type LeaderboardFilter struct {
+	// Variables only ranks runs played with these values, keyed by variable
+	// slug
+	Variables RunVariables
+	
+	// Platform only ranks runs played on the platform with this slug
+	Platform string
+	
+	// Region only ranks runs played in the region with this slug
+	Region string
+}
+
+// SubmitRunInput holds the details of a run being submitted
+type SubmitRunInput struct {
+	UserID   int32
+	Times    RunTimes
+	VideoURL string
+	PlayedOn time.Time
+	
+	// Platform is the slug of the platform the run was played on
+	Platform string
+	
+	// Region is the slug of the region the run was played in; empty when
+	// it is not recorded
+	Region string
+	
+	// Variables are the values the run was played with, keyed by variable
+	// slug; variables that are left out have no value
+	Variables RunVariables
+	
+	// LevelSlug is the individual level the run is of; empty for a
+	// full-game run
+	LevelSlug string
+}
+
+// RunTimes holds a run's time in milliseconds by each timing method; zero
+// means the run was not timed by that method
+type RunTimes struct {
+	RTA int64
+	IGT int64
+	LRT int64
+}
+
+// byMethod returns the time by the given timing method
+func (t RunTimes) byMethod(method string) int64 {
+	switch method {
+	case TimingMethodIGT:
+		return t.IGT
+	case TimingMethodLRT:
+		return t.LRT
+	default:
+		return t.RTA
+	}
+}
+
+// RunOption configures optional RunService behavior
+type RunOption func(*RunService)
+
+// WithRunPageSizes sets the limit applied when a list request omits one and
+// the largest limit a list request may ask for
+func WithRunPageSizes(defaultSize, maxSize int) RunOption {
+	return func(s *RunService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// WithRunVideoChecker confirms with the video's provider that each submitted
+// run's video exists and is public; without one only the link is validated
+func WithRunVideoChecker(c video.Checker) RunOption {
+	return func(s *RunService) {
+		s.videos = c
+	}
+}
+
+// WithModerationSLA sets how long a run may wait for review before the
+// moderation queue shows it as overdue
+func WithModerationSLA(sla time.Duration) RunOption {
+	return func(s *RunService) {
+		if sla > 0 {
+			s.moderationSLA = sla
+		}
+	}
+}
+
+// WithRunCache caches the pages Leaderboard returns in c; verifying a run
+// invalidates its category's pages
+func WithRunCache(c *cache.Cache) RunOption {
+	return func(s *RunService) {
+		s.cache = c
+	}
+}
+
+// NewRunService creates a new RunService instance
+func NewRunService(queries db.Store, opts ...RunOption) *RunService {
+	s := &RunService{
+		queries:       queries,
+		pages:         defaultPageSizes,
+		now:           time.Now,
+		moderationSLA: defaultModerationSLA,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SubmitRun records a run for a game category, either of the full game or of
+// one of its levels
+//
+// The video must be a YouTube video or Twitch VOD, and is stored by its
+// canonical link. Only users who have verified their email address may submit runs, so
+// leaderboards can't be flooded from throwaway accounts.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game the run was played in
+//   - categorySlug: Slug of the category within that game
+//   - input: The run details
+//
+// Returns:
+//   - *db.Run: The stored run
+//   - error: ErrInvalidInput, including for an unknown platform or region,
+//     a missing time by the category's timing method, or a video that is not
+//     public, ErrCategoryNotFound, ErrLevelNotFound, ErrUserNotFound,
+//     ErrEmailNotVerified, or database errors
+func (s *RunService) SubmitRun(ctx context.Context, gameSlug, categorySlug string, input SubmitRunInput) (*db.Run, error) {
+	platform, link, err := s.validateRun(&input)
+	if err != nil {
+		return nil, err
+	}
+	
+	category, err := s.getCategory(ctx, gameSlug, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+	// Runs are ranked by the category's timing method, so they need a time by it
+	timeMs := input.Times.byMethod(category.TimingMethod)
+	if timeMs == 0 {
+		return nil, invalidField("times", "must include a %s time for this category", category.TimingMethod)
+	}
+	values, err := resolveRunVariables(ctx, s.queries, category, input.Variables)
+	if err != nil {
+		return nil, err
+	}
+	levelID := pgtype.Int4{}
+	if input.LevelSlug != "" {
+		level, err := getLevel(ctx, s.queries, gameSlug, input.LevelSlug)
+		if err != nil {
+			return nil, err
+		}
+		levelID = pgtype.Int4{Int32: level.ID, Valid: true}
+	}
+	_, region, err := resolvePlatformAndRegion(ctx, s.queries, platform, input.Region)
+	if err != nil {
+		return nil, err
+	}
+	
+	user, err := s.queries.GetUserByID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.EmailVerifiedAt.Valid {
+		return nil, ErrEmailNotVerified
+	}
+	if err := s.checkVideo(ctx, link); err != nil {
+		return nil, err
+	}
+	
+	var run db.Run
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		run, err = createRun(ctx, q, db.CreateRunParams{
+			UserID:     input.UserID,
+			CategoryID: category.ID,
+			TimeMs:     timeMs,
+			VideoUrl:   link.URL(),
+			Platform:   platform,
+			PlayedOn:   pgtype.Date{Time: input.PlayedOn, Valid: true},
+			LevelID:    levelID,
+			Region:     region,
+			RtaMs:      optionalTime(input.Times.RTA),
+			IgtMs:      optionalTime(input.Times.IGT),
+			LrtMs:      optionalTime(input.Times.LRT),
+		}, values)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run: %w", err)
+	}
+	
+	return &run, nil
+}
+
+// createRun stores a submitted run along with the variable values it was
+// played with, and publishes that it was submitted
+func createRun(ctx context.Context, q db.Querier, params db.CreateRunParams, values []db.VariableValue) (db.Run, error) {
+	run, err := q.CreateRun(ctx, params)
+	if err != nil {
+		return db.Run{}, err
+	}
+	for _, value := range values {
+		if err := q.CreateRunVariableValue(ctx, db.CreateRunVariableValueParams{
+			RunID:      run.ID,
+			VariableID: value.VariableID,
+			ValueID:    value.ID,
+		}); err != nil {
+			return db.Run{}, err
+		}
+	}
+	if err := recordAudit(ctx, q, "run.submit", AuditEntityRun, run.ID, nil, run); err != nil {
+		return db.Run{}, err
+	}
+	return run, publishEvent(ctx, q, EventRunSubmitted, newEventRun(run))
+}
+
+// ListCategoryRuns retrieves a paginated list of a category's runs, fastest first
+//
+// Obsolete runs are left out unless filter.IncludeObsolete is set.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - categorySlug: Slug of the category within that game
+//   - page: Requested page size and either an offset or a cursor
+//   - filter: Which runs to include
+//
+// Returns:
+//   - *RunPage: The runs, total count, the effective limit and offset, and
+//     the cursor of the next page
+//   - error: ErrInvalidInput, ErrInvalidCursor, ErrCategoryNotFound, or
+//     database errors
+func (s *RunService) ListCategoryRuns(ctx context.Context, gameSlug, categorySlug string, page PageRequest, filter ListRunsFilter) (*RunPage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	var afterTimeMs int64
+	var afterID int32
+	if page.Cursor != "" {
+		if err := decodeCursor(page.Cursor, "category-runs", &afterTimeMs, &afterID); err != nil {
+			return nil, err
+		}
+	}
+	
+	category, err := s.getCategory(ctx, gameSlug, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	var runs []db.Run
+	if page.Cursor == "" {
+		runs, err = s.queries.ListRunsByCategory(ctx, db.ListRunsByCategoryParams{
+			CategoryID:      category.ID,
+			IncludeObsolete: filter.IncludeObsolete,
+			Limit:           pageLimit + 1,
+			Offset:          pageOffset,
+		})
+	} else {
+		runs, err = s.queries.ListRunsByCategoryAfter(ctx, db.ListRunsByCategoryAfterParams{
+			CategoryID:      category.ID,
+			IncludeObsolete: filter.IncludeObsolete,
+			AfterTimeMs:     afterTimeMs,
+			AfterID:         afterID,
+			Limit:           pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	runs, more := trimPage(runs, pageLimit)
+	
+	count, err := s.queries.CountRunsByCategory(ctx, db.CountRunsByCategoryParams{
+		CategoryID:      category.ID,
+		IncludeObsolete: filter.IncludeObsolete,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count runs: %w", err)
+	}
+	variables, err := listRunVariables(ctx, s.queries, runs)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := &RunPage{Runs: runs, Total: count, Limit: pageLimit, Offset: pageOffset, Variables: variables}
+	if more {
+		last := runs[len(runs)-1]
+		result.NextCursor = encodeCursor("category-runs", last.TimeMs, last.ID)
+	}
+	return result, nil
+}
+
+// ExportCategoryRuns calls fn with every one of a category's runs, fastest
+// first, as the runs are read from the database, for exports too large to
+// page through
+//
+// Obsolete runs are left out unless filter.IncludeObsolete is set. The runs'
+// variables are not loaded. fn's first error stops the export and is
+// returned.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - categorySlug: Slug of the category within that game
+//   - filter: Which runs to include
+//   - fn: Called with each run in turn
+//
+// Returns:
+//   - error: ErrCategoryNotFound, fn's error, or database errors
+func (s *RunService) ExportCategoryRuns(ctx context.Context, gameSlug, categorySlug string, filter ListRunsFilter, fn func(db.Run) error) error {
+	category, err := s.getCategory(ctx, gameSlug, categorySlug)
+	if err != nil {
+		return err
+	}
+	
+	err = s.queries.ExportRunsByCategory(ctx, db.ExportRunsByCategoryParams{
+		CategoryID:      category.ID,
+		IncludeObsolete: filter.IncludeObsolete,
+	}, fn)
+	if err != nil {
+		return fmt.Errorf("failed to export runs: %w", err)
+	}
+	return nil
+}
+
+// ListUserRuns retrieves a paginated list of a user's runs, newest first
+//
+// Obsolete runs are left out unless filter.IncludeObsolete is set.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: ID of the player
+//   - page: Requested page size and either an offset or a cursor
+//   - filter: Which runs to include
+//
+// Returns:
+//   - *RunPage: The runs, total count, the effective limit and offset, and
+//     the cursor of the next page
+//   - error: ErrInvalidInput, ErrInvalidCursor, ErrUserNotFound, or
+//     database errors
+func (s *RunService) ListUserRuns(ctx context.Context, userID int32, page PageRequest, filter ListRunsFilter) (*RunPage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	var afterCreatedAt time.Time
+	var afterID int32
+	if page.Cursor != "" {
+		if err := decodeCursor(page.Cursor, "user-runs", &afterCreatedAt, &afterID); err != nil {
+			return nil, err
+		}
+	}
+	
+	if _, err := s.queries.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	var runs []db.Run
+	if page.Cursor == "" {
+		runs, err = s.queries.ListRunsByUser(ctx, db.ListRunsByUserParams{
+			UserID:          userID,
+			IncludeObsolete: filter.IncludeObsolete,
+			Limit:           pageLimit + 1,
+			Offset:          pageOffset,
+		})
+	} else {
+		runs, err = s.queries.ListRunsByUserAfter(ctx, db.ListRunsByUserAfterParams{
+			UserID:          userID,
+			IncludeObsolete: filter.IncludeObsolete,
+			AfterCreatedAt:  pgtype.Timestamptz{Time: afterCreatedAt, Valid: true},
+			AfterID:         afterID,
+			Limit:           pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	runs, more := trimPage(runs, pageLimit)
+	
+	count, err := s.queries.CountRunsByUser(ctx, db.CountRunsByUserParams{
+		UserID:          userID,
+		IncludeObsolete: filter.IncludeObsolete,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count runs: %w", err)
+	}
+	variables, err := listRunVariables(ctx, s.queries, runs)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := &RunPage{Runs: runs, Total: count, Limit: pageLimit, Offset: pageOffset, Variables: variables}
+	if more {
+		last := runs[len(runs)-1]
+		result.NextCursor = encodeCursor("user-runs", last.CreatedAt.Time, last.ID)
+	}
+	return result, nil
+}
+
+// Leaderboard ranks each runner's best full-game run in a category, fastest
+// first
+//
+// Ranking happens in SQL over verified runs: only a runner's best run counts,
+// so their slower runs never appear, and equal times share a rank with the
+// next rank skipped (1, 1, 3). Ties are listed by who played the time first.
+// With filter.Variables set, only runs played with all of those values are
+// ranked, so a runner's best is their best with those values; likewise with
+// filter.Platform and filter.Region.
+// Pages are served from the cache when WithRunCache configured one, and
+// concurrent requests for the same page share one set of queries.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - categorySlug: Slug of the category within that game
+//   - page: Requested page size and either an offset or a cursor
+//   - filter: Which runs to rank
+//
+// Returns:
+//   - *LeaderboardPage: The ranked entries, number of ranked runners, the
+//     effective limit and offset, and the cursor of the next page
+//   - error: ErrInvalidInput, ErrInvalidCursor, ErrCategoryNotFound, or
+//     database errors
+func (s *RunService) Leaderboard(ctx context.Context, gameSlug, categorySlug string, page PageRequest, filter LeaderboardFilter) (*LeaderboardPage, error) {
+	category, err := s.getCategory(ctx, gameSlug, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.leaderboard(ctx, category, pgtype.Int4{}, page, filter)
+}
+
+// LevelLeaderboard ranks each runner's best run of an individual level in a
+// category, fastest first
+//
+// Level runs are ranked, filtered, cached and paginated the same way as
+// Leaderboard does for full-game runs.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - levelSlug: Slug of the level within that game
+//   - categorySlug: Slug of the category within that game; empty ranks the
+//     game's default category
+//   - page: Requested page size and either an offset or a cursor
+//   - filter: Which runs to rank
+//
+// Returns:
+//   - *LeaderboardPage: The ranked entries, number of ranked runners, the
+//     effective limit and offset, and the cursor of the next page
+//   - error: ErrInvalidInput, ErrInvalidCursor, ErrLevelNotFound,
+//     ErrCategoryNotFound, or database errors
+func (s *RunService) LevelLeaderboard(ctx context.Context, gameSlug, levelSlug, categorySlug string, page PageRequest, filter LeaderboardFilter) (*LeaderboardPage, error) {
+	level, err := getLevel(ctx, s.queries, gameSlug, levelSlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	var category *db.Category
+	if categorySlug == "" {
+		found, err := s.queries.GetDefaultCategory(ctx, level.GameID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrCategoryNotFound
+			}
+			return nil, fmt.Errorf("failed to get default category: %w", err)
+		}
+		category = &found
+	} else {
+		category, err = s.getCategory(ctx, gameSlug, categorySlug)
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	return s.leaderboard(ctx, category, pgtype.Int4{Int32: level.ID, Valid: true}, page, filter)
+}
+
+// leaderboard ranks the verified runs of category for level levelID, or its
+// full-game runs when levelID is NULL
+func (s *RunService) leaderboard(ctx context.Context, category *db.Category, levelID pgtype.Int4, page PageRequest, filter LeaderboardFilter) (*LeaderboardPage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	var afterTimeMs int64
+	var afterPlayedOn time.Time
+	var afterID int32
+	if page.Cursor != "" {
+		if err := decodeCursor(page.Cursor, "leaderboard", &afterTimeMs, &afterPlayedOn, &afterID); err != nil {
+			return nil, err
+		}
+	}
+	
+	values, err := resolveRunVariables(ctx, s.queries, category, filter.Variables)
+	if err != nil {
+		return nil, err
+	}
+	valueIDs := make([]int32, len(values))
+	for i, value := range values {
+		valueIDs[i] = value.ID
+	}
+	platform, region, err := resolvePlatformAndRegion(ctx, s.queries, filter.Platform, filter.Region)
+	if err != nil {
+		return nil, err
+	}
+	ranked := fmt.Sprintf("%d:%v:%s:%s", levelID.Int32, valueIDs, platform.String, region.String)
+	
+	cacheKey, cacheable := s.leaderboardCacheKey(ctx, category.ID, ranked, pageLimit, pageOffset, page.Cursor)
+	if cacheable {
+		var cached LeaderboardPage
+		if s.cache.Get(ctx, cacheLeaderboards, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
+	
+	// Concurrent misses for the same page share one set of queries. The
+	// cache key includes the leaderboard's versions, so a read that starts
+	// after an invalidation never joins one that started before it.
+	flightKey := cacheKey
+	if !cacheable {
+		flightKey = fmt.Sprintf("%d:%s:%d:%d:%s", category.ID, ranked, pageLimit, pageOffset, page.Cursor)
+	}
+	result, err := coalesce(ctx, &s.leaderboards, flightKey, func(ctx context.Context) (LeaderboardPage, error) {
+		ctx = cacheFillContext(ctx, s.cache)
+		var entries []db.GetLeaderboardRow
+		var err error
+		if page.Cursor == "" {
+			entries, err = s.queries.GetLeaderboard(ctx, db.GetLeaderboardParams{
+				CategoryID: category.ID,
+				LevelID:    levelID,
+				Platform:   platform,
+				Region:     region,
+				ValueIds:   valueIDs,
+				Limit:      pageLimit + 1,
+				Offset:     pageOffset,
+			})
+		} else {
+			var rows []db.GetLeaderboardAfterRow
+			rows, err = s.queries.GetLeaderboardAfter(ctx, db.GetLeaderboardAfterParams{
+				CategoryID:    category.ID,
+				LevelID:       levelID,
+				Platform:      platform,
+				Region:        region,
+				ValueIds:      valueIDs,
+				AfterTimeMs:   afterTimeMs,
+				AfterPlayedOn: pgtype.Date{Time: afterPlayedOn, Valid: true},
+				AfterID:       afterID,
+				Limit:         pageLimit + 1,
+			})
+			for _, row := range rows {
+				entries = append(entries, db.GetLeaderboardRow(row))
+			}
+		}
+		if err != nil {
+			return LeaderboardPage{}, fmt.Errorf("failed to get leaderboard: %w", err)
+		}
+		entries, more := trimPage(entries, pageLimit)
+		
+		count, err := s.queries.CountLeaderboard(ctx, db.CountLeaderboardParams{
+			CategoryID: category.ID,
+			LevelID:    levelID,
+			Platform:   platform,
+			Region:     region,
+			ValueIds:   valueIDs,
+		})
+		if err != nil {
+			return LeaderboardPage{}, fmt.Errorf("failed to count leaderboard: %w", err)
+		}
+		
+		result := LeaderboardPage{Entries: entries, Total: count, Limit: pageLimit, Offset: pageOffset}
+		if more {
+			last := entries[len(entries)-1]
+			result.NextCursor = encodeCursor("leaderboard", last.TimeMs, last.PlayedOn.Time, last.ID)
+		}
+		if cacheable {
+			s.cache.Set(ctx, cacheKey, result)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PersonalBests returns a user's best verified run in every category they
+// have one in, ordered by game and then by the game's category order
+//
+// Each run is ranked as it would be on its category's leaderboard, and comes
+// with the category's record and how far behind it the run is, so a profile
+// page needs a single request rather than one leaderboard per category.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: ID of the player
+//
+// Returns:
+//   - []db.GetPersonalBestsRow: The personal bests, empty if the user has no
+//     verified runs
+//   - error: ErrUserNotFound or database errors
+func (s *RunService) PersonalBests(ctx context.Context, userID int32) ([]db.GetPersonalBestsRow, error) {
+	if _, err := s.queries.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	bests, err := s.queries.GetPersonalBests(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get personal bests: %w", err)
+	}
+	return bests, nil
+}
+
+// RecordHistory returns every run that held a category's record, in the order
+// the records were set
+//
+// A run takes the record when it is verified with a strictly faster time
+// than every run verified before it, so a run that ties the record does not
+// appear. The last entry is the current record.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - categorySlug: Slug of the category within that game
+//
+// Returns:
+//   - []db.ListCategoryRecordsRow: The record progression, oldest first
+//   - error: ErrCategoryNotFound or database errors
+func (s *RunService) RecordHistory(ctx context.Context, gameSlug, categorySlug string) ([]db.ListCategoryRecordsRow, error) {
+	category, err := s.getCategory(ctx, gameSlug, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	records, err := s.queries.ListCategoryRecords(ctx, category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list category records: %w", err)
+	}
+	return records, nil
+}
+
+// ModerationQueue lists the runs of a game that are waiting for review,
+// longest waiting first
+//
+// The caller must be able to moderate the game.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//   - page: Requested page size and either an offset or a cursor
+//
+// Returns:
+//   - *ModerationQueuePage: The pending runs with how long each has waited,
+//     the number of pending runs, the effective limit and offset, and the
+//     cursor of the next page
+//   - error: ErrInvalidInput, ErrInvalidCursor, ErrGameNotFound, ErrForbidden,
+//     or database errors
+func (s *RunService) ModerationQueue(ctx context.Context, gameSlug string, page PageRequest) (*ModerationQueuePage, error) {
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	var afterCreatedAt time.Time
+	var afterID int32
+	if page.Cursor != "" {
+		if err := decodeCursor(page.Cursor, "moderation-queue", &afterCreatedAt, &afterID); err != nil {
+			return nil, err
+		}
+	}
+	
+	game, err := s.queries.GetGameBySlug(ctx, gameSlug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	if err := requireGameModerator(ctx, game.ID); err != nil {
+		return nil, err
+	}
+	
+	var runs []db.Run
+	if page.Cursor == "" {
+		runs, err = s.queries.ListModerationQueue(ctx, db.ListModerationQueueParams{
+			GameID: game.ID,
+			Limit:  pageLimit + 1,
+			Offset: pageOffset,
+		})
+	} else {
+		runs, err = s.queries.ListModerationQueueAfter(ctx, db.ListModerationQueueAfterParams{
+			GameID:         game.ID,
+			AfterCreatedAt: pgtype.Timestamptz{Time: afterCreatedAt, Valid: true},
+			AfterID:        afterID,
+			Limit:          pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending runs: %w", err)
+	}
+	runs, more := trimPage(runs, pageLimit)
+	
+	count, err := s.queries.CountModerationQueue(ctx, game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending runs: %w", err)
+	}
+	variables, err := listRunVariables(ctx, s.queries, runs)
+	if err != nil {
+		return nil, err
+	}
+	
+	now := s.now()
+	entries := make([]ModerationQueueEntry, len(runs))
+	for i, run := range runs {
+		waiting := max(now.Sub(run.CreatedAt.Time), 0)
+		entries[i] = ModerationQueueEntry{Run: run, Waiting: waiting, Overdue: waiting > s.moderationSLA}
+	}
+	
+	result := &ModerationQueuePage{
+		Entries:   entries,
+		Total:     count,
+		Limit:     pageLimit,
+		Offset:    pageOffset,
+		SLA:       s.moderationSLA,
+		Variables: variables,
+	}
+	if more {
+		last := runs[len(runs)-1]
+		result.NextCursor = encodeCursor("moderation-queue", last.CreatedAt.Time, last.ID)
+	}
+	return result, nil
+}
+
+// VerifyRun marks a pending run as verified so it counts toward the leaderboard
+//
+// The caller must be able to moderate the run's game. The runner is notified
+// of the run.verified event through the channels they chose. Only the
+// runner's best verified run in the category and level with the same variable
+// values stays current: the others, possibly including this one, are marked
+// obsolete.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - id: ID of the run
+//
+// Returns:
+//   - *db.Run: The reviewed run
+//   - error: ErrRunNotFound, ErrForbidden, ErrInvalidRunTransition, or database errors
+func (s *RunService) VerifyRun(ctx context.Context, id int32) (*db.Run, error) {
+	return s.transition(ctx, id, "run.verify", RunStatusVerified, pgtype.Text{})
+}
+
+// RejectRun marks a pending run as rejected
+//
+// The caller must be able to moderate the run's game.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - id: ID of the run
+//   - reason: Why the run was rejected; required and shown to the runner
+//
+// Returns:
+//   - *db.Run: The reviewed run
+//   - error: ErrInvalidInput, ErrRunNotFound, ErrForbidden, ErrInvalidRunTransition,
+//     or database errors
+func (s *RunService) RejectRun(ctx context.Context, id int32, reason string) (*db.Run, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, invalidField("reason", "must not be empty")
+	}
+	if utf8.RuneCountInString(reason) > maxRejectionReasonLength {
+		return nil, invalidField("reason", "must be at most %d characters", maxRejectionReasonLength)
+	}
+	
+	return s.transition(ctx, id, "run.reject", RunStatusRejected, pgtype.Text{String: reason, Valid: true})
+}
+
+// transition moves a run to status if runTransitions allows it, auditing
+// the review as action
+//
+// The update only applies while the run is still in the status that was
+// checked, so when two moderators review the same run at once the second
+// gets ErrInvalidRunTransition rather than overwriting the first.
+func (s *RunService) transition(ctx context.Context, id int32, action, status string, reason pgtype.Text) (*db.Run, error) {
+	run, err := s.queries.GetRunByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRunNotFound
+		}
+		return nil, fmt.Errorf("failed to get run: %w", err)
+	}
+	
+	category, err := s.queries.GetCategoryByID(ctx, run.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if err := requireGameModerator(ctx, category.GameID); err != nil {
+		return nil, err
+	}
+	
+	if !slices.Contains(runTransitions[run.Status], status) {
+		return nil, fmt.Errorf("%w: run is %s", ErrInvalidRunTransition, run.Status)
+	}
+	
+	var updated db.Run
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		updated, err = q.UpdateRunStatus(ctx, db.UpdateRunStatusParams{
+			Status:          status,
+			RejectionReason: reason,
+			ID:              id,
+			FromStatus:      run.Status,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, q, action, AuditEntityRun, id, run, updated); err != nil {
+			return err
+		}
+		if status != RunStatusVerified {
+			return publishEvent(ctx, q, EventRunRejected, newEventRun(updated))
+		}
+		if err := publishRunVerified(ctx, q, updated); err != nil {
+			return err
+		}
+		return obsoleteBeatenRuns(ctx, q, &updated)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: run was reviewed concurrently", ErrInvalidRunTransition)
+		}
+		return nil, fmt.Errorf("failed to update run status: %w", err)
+	}
+	
+	if status == RunStatusVerified {
+		s.cache.Bump(ctx, leaderboardVersionKey(updated.CategoryID))
+	}
+	return &updated, nil
+}
+
+// publishRunVerified publishes that run was verified and, if it is a
+// full-game run that beats the category's previous record, adds it to the
+// record history and publishes that the record was broken
+// A first verified run sets a record rather than breaking one, so it is only
+// added to the history.
+func publishRunVerified(ctx context.Context, q db.Querier, run db.Run) error {
+	if err := publishEvent(ctx, q, EventRunVerified, newEventRun(run)); err != nil {
+		return err
+	}
+	if run.LevelID.Valid {
+		return nil
+	}
+	
+	record, err := q.GetFastestVerifiedRun(ctx, db.GetFastestVerifiedRunParams{CategoryID: run.CategoryID, ExcludeID: run.ID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return setRecord(ctx, q, run)
+		}
+		return fmt.Errorf("failed to get category record: %w", err)
+	}
+	if run.TimeMs >= record.TimeMs {
+		return nil
+	}
+	if err := setRecord(ctx, q, run); err != nil {
+		return err
+	}
+	return publishEvent(ctx, q, EventRecordBroken, eventRecord{
+		Run:            newEventRun(run),
+		PreviousRecord: newEventRun(record),
+	})
+}
+
+// setRecord adds run to its category's record history
+func setRecord(ctx context.Context, q db.Querier, run db.Run) error {
+	if err := q.CreateCategoryRecord(ctx, db.CreateCategoryRecordParams{CategoryID: run.CategoryID, RunID: run.ID}); err != nil {
+		return fmt.Errorf("failed to add record to history: %w", err)
+	}
+	return nil
+}
+
+// obsoleteBeatenRuns marks the runner's verified runs in run's category and
+// level with the same variable values obsolete, except their best, and
+// updates run if it is one of them, which happens when it was played before
+// their current best but verified after it
+func obsoleteBeatenRuns(ctx context.Context, q db.Querier, run *db.Run) error {
+	obsoleted, err := q.ObsoleteBeatenRuns(ctx, run.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark beaten runs obsolete: %w", err)
+	}
+	for _, after := range obsoleted {
+		before := after
+		before.Obsolete = false
+		if err := recordAudit(ctx, q, "run.obsolete", AuditEntityRun, after.ID, before, after); err != nil {
+			return err
+		}
+		if after.ID == run.ID {
+			*run = after
+		}
+	}
+	return nil
+}
+
+// getCategory looks up a category by its game and category slugs
+func (s *RunService) getCategory(ctx context.Context, gameSlug, categorySlug string) (*db.Category, error) {
+	category, err := s.queries.GetCategoryBySlug(ctx, db.GetCategoryBySlugParams{
+		GameSlug:     gameSlug,
+		CategorySlug: categorySlug,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	
+	return &category, nil
+}
+
+// validateRun checks a submission before it touches the database
+//
+// The played-on date may be at most one day past today in UTC, allowing for
+// players in time zones ahead of UTC. Every invalid field is reported, not
+// just the first.
+//
+// Returns:
+//   - string: The trimmed platform
+//   - video.Link: The run's video
+//   - error: A ValidationError listing the invalid fields
+func (s *RunService) validateRun(input *SubmitRunInput) (string, video.Link, error) {
+	var invalid ValidationError
+	
+	times := input.Times
+	if times.RTA < 0 || times.IGT < 0 || times.LRT < 0 {
+		invalid.add("times", "must be positive")
+	} else if times.RTA == 0 && times.IGT == 0 && times.LRT == 0 {
+		invalid.add("times", "must include at least one time")
+	}
+	
+	link, err := video.Parse(input.VideoURL)
+	if err != nil {
+		invalid.add("video_url", "must link to a YouTube video or a Twitch VOD")
+	}
+	
+	platform := strings.TrimSpace(input.Platform)
+	if platform == "" {
+		invalid.add("platform", "must not be empty")
+	} else if utf8.RuneCountInString(platform) > maxPlatformLength {
+		invalid.add("platform", "must be at most %d characters", maxPlatformLength)
+	}
+	
+	latest := s.now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if input.PlayedOn.IsZero() || input.PlayedOn.After(latest) {
+		invalid.add("played_on", "must be a date that is not in the future")
+	}
+	
+	if len(invalid.Fields) > 0 {
+		return "", video.Link{}, &invalid
+	}
+	return platform, link, nil
+}
+
+// checkVideo confirms with a run video's provider that it can be watched
+// A provider that can't be reached doesn't hold up the submission, since
+// moderators watch every run's video before verifying it anyway.
+func (s *RunService) checkVideo(ctx context.Context, link video.Link) error {
+	if s.videos == nil {
+		return nil
+	}
+	
+	err := s.videos.Check(ctx, link)
+	if errors.Is(err, video.ErrUnavailable) {
+		return invalidField("video_url", "must link to a video that exists and is public")
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to check run video; accepting it unchecked", "video_url", link.URL(), "error", err)
+	}
+	return nil
+}
+
+// optionalTime converts a time that may be absent to a nullable column value
+func optionalTime(ms int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: ms, Valid: ms != 0}
+}
+
+// resolvePlatformAndRegion checks that a run's platform and region, given by
+// slug, exist
+//
+// Returns:
+//   - pgtype.Text: The platform slug; NULL if platform is empty
+//   - pgtype.Text: The region slug; NULL if region is empty
+//   - error: ErrInvalidInput if either does not exist, or database errors
+func resolvePlatformAndRegion(ctx context.Context, q db.Querier, platform, region string) (pgtype.Text, pgtype.Text, error) {
+	if platform != "" {
+		if _, err := getPlatform(ctx, q, platform); err != nil {
+			if errors.Is(err, ErrPlatformNotFound) {
+				return pgtype.Text{}, pgtype.Text{}, invalidField("platform", "%q is not one of the listed platforms", platform)
+			}
+			return pgtype.Text{}, pgtype.Text{}, err
+		}
+	}
+	if region != "" {
+		if _, err := getRegion(ctx, q, region); err != nil {
+			if errors.Is(err, ErrRegionNotFound) {
+				return pgtype.Text{}, pgtype.Text{}, invalidField("region", "%q is not one of the listed regions", region)
+			}
+			return pgtype.Text{}, pgtype.Text{}, err
+		}
+	}
+	
+	return pgtype.Text{String: platform, Valid: platform != ""}, pgtype.Text{String: region, Valid: region != ""}, nil
+}
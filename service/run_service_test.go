@@ -0,0 +1,1250 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/cache"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/video"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) CreateRun(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+	if m.CreateRunFunc != nil {
+		return m.CreateRunFunc(ctx, params)
+	}
+	return db.Run{}, nil
+}
+
+func (m *MockQueries) ListRunsByCategory(ctx context.Context, params db.ListRunsByCategoryParams) ([]db.Run, error) {
+	if m.ListRunsByCategoryFunc != nil {
+		return m.ListRunsByCategoryFunc(ctx, params)
+	}
+	return []db.Run{}, nil
+}
+
+func (m *MockQueries) ListRunsByCategoryAfter(ctx context.Context, params db.ListRunsByCategoryAfterParams) ([]db.Run, error) {
+	if m.ListRunsByCategoryAfterFunc != nil {
+		return m.ListRunsByCategoryAfterFunc(ctx, params)
+	}
+	return []db.Run{}, nil
+}
+
+func (m *MockQueries) CountRunsByCategory(ctx context.Context, params db.CountRunsByCategoryParams) (int64, error) {
+	if m.CountRunsByCategoryFunc != nil {
+		return m.CountRunsByCategoryFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) ListRunsByUser(ctx context.Context, params db.ListRunsByUserParams) ([]db.Run, error) {
+	if m.ListRunsByUserFunc != nil {
+		return m.ListRunsByUserFunc(ctx, params)
+	}
+	return []db.Run{}, nil
+}
+
+func (m *MockQueries) ListRunsByUserAfter(ctx context.Context, params db.ListRunsByUserAfterParams) ([]db.Run, error) {
+	if m.ListRunsByUserAfterFunc != nil {
+		return m.ListRunsByUserAfterFunc(ctx, params)
+	}
+	return []db.Run{}, nil
+}
+
+func (m *MockQueries) CountRunsByUser(ctx context.Context, params db.CountRunsByUserParams) (int64, error) {
+	if m.CountRunsByUserFunc != nil {
+		return m.CountRunsByUserFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) GetLeaderboard(ctx context.Context, params db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error) {
+	if m.GetLeaderboardFunc != nil {
+		return m.GetLeaderboardFunc(ctx, params)
+	}
+	return []db.GetLeaderboardRow{}, nil
+}
+
+func (m *MockQueries) GetLeaderboardAfter(ctx context.Context, params db.GetLeaderboardAfterParams) ([]db.GetLeaderboardAfterRow, error) {
+	if m.GetLeaderboardAfterFunc != nil {
+		return m.GetLeaderboardAfterFunc(ctx, params)
+	}
+	return []db.GetLeaderboardAfterRow{}, nil
+}
+
+func (m *MockQueries) GetPersonalBests(ctx context.Context, userID int32) ([]db.GetPersonalBestsRow, error) {
+	if m.GetPersonalBestsFunc != nil {
+		return m.GetPersonalBestsFunc(ctx, userID)
+	}
+	return []db.GetPersonalBestsRow{}, nil
+}
+
+func (m *MockQueries) CountLeaderboard(ctx context.Context, params db.CountLeaderboardParams) (int64, error) {
+	if m.CountLeaderboardFunc != nil {
+		return m.CountLeaderboardFunc(ctx, params)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) GetRunByID(ctx context.Context, id int32) (db.Run, error) {
+	if m.GetRunByIDFunc != nil {
+		return m.GetRunByIDFunc(ctx, id)
+	}
+	return db.Run{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetRunSummary(ctx context.Context, id int32) (db.GetRunSummaryRow, error) {
+	if m.GetRunSummaryFunc != nil {
+		return m.GetRunSummaryFunc(ctx, id)
+	}
+	return db.GetRunSummaryRow{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetFastestVerifiedRun(ctx context.Context, params db.GetFastestVerifiedRunParams) (db.Run, error) {
+	if m.GetFastestVerifiedRunFunc != nil {
+		return m.GetFastestVerifiedRunFunc(ctx, params)
+	}
+	return db.Run{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) ObsoleteBeatenRuns(ctx context.Context, runID int32) ([]db.Run, error) {
+	if m.ObsoleteBeatenRunsFunc != nil {
+		return m.ObsoleteBeatenRunsFunc(ctx, runID)
+	}
+	return []db.Run{}, nil
+}
+
+func (m *MockQueries) CreateCategoryRecord(ctx context.Context, params db.CreateCategoryRecordParams) error {
+	if m.CreateCategoryRecordFunc != nil {
+		return m.CreateCategoryRecordFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockQueries) ListCategoryRecords(ctx context.Context, categoryID int32) ([]db.ListCategoryRecordsRow, error) {
+	if m.ListCategoryRecordsFunc != nil {
+		return m.ListCategoryRecordsFunc(ctx, categoryID)
+	}
+	return []db.ListCategoryRecordsRow{}, nil
+}
+
+func (m *MockQueries) UpdateRunStatus(ctx context.Context, params db.UpdateRunStatusParams) (db.Run, error) {
+	if m.UpdateRunStatusFunc != nil {
+		return m.UpdateRunStatusFunc(ctx, params)
+	}
+	return db.Run{ID: params.ID, Status: params.Status, RejectionReason: params.RejectionReason}, nil
+}
+
+func (m *MockQueries) ListModerationQueue(ctx context.Context, params db.ListModerationQueueParams) ([]db.Run, error) {
+	if m.ListModerationQueueFunc != nil {
+		return m.ListModerationQueueFunc(ctx, params)
+	}
+	return []db.Run{}, nil
+}
+
+func (m *MockQueries) ListModerationQueueAfter(ctx context.Context, params db.ListModerationQueueAfterParams) ([]db.Run, error) {
+	if m.ListModerationQueueAfterFunc != nil {
+		return m.ListModerationQueueAfterFunc(ctx, params)
+	}
+	return []db.Run{}, nil
+}
+
+func (m *MockQueries) CountModerationQueue(ctx context.Context, gameID int32) (int64, error) {
+	if m.CountModerationQueueFunc != nil {
+		return m.CountModerationQueueFunc(ctx, gameID)
+	}
+	return 0, nil
+}
+
+// validRun returns a submission that passes validation
+func validRun() SubmitRunInput {
+	return SubmitRunInput{
+		UserID:   1,
+		Times:    RunTimes{RTA: 1043250},
+		VideoURL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		Platform: " n64 ",
+		PlayedOn: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSubmitRun_Success(t *testing.T) {
+	var created db.CreateRunParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			if params.GameSlug != "super-mario-64" || params.CategorySlug != "120-star" {
+				t.Errorf("unexpected category lookup %+v", params)
+			}
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			created = params
+			return db.Run{ID: 9, UserID: params.UserID, CategoryID: params.CategoryID}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	run, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", validRun())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if run.ID != 9 {
+		t.Errorf("expected run ID 9, got %d", run.ID)
+	}
+	if created.CategoryID != 3 || created.Platform != "n64" || !created.PlayedOn.Valid || created.LevelID.Valid {
+		t.Errorf("unexpected insert params %+v", created)
+	}
+}
+
+func TestSubmitRun_Level(t *testing.T) {
+	var created db.CreateRunParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetLevelBySlugFunc: levelLookup("super-mario-64", db.Level{ID: 2, GameID: 1, Slug: "whomps-fortress"}),
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			created = params
+			return db.Run{ID: 9, CategoryID: params.CategoryID, LevelID: params.LevelID}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	input := validRun()
+	input.LevelSlug = "whomps-fortress"
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.LevelID != (pgtype.Int4{Int32: 2, Valid: true}) {
+		t.Errorf("expected the run tied to level 2, got %+v", created.LevelID)
+	}
+
+	input.LevelSlug = "missing"
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); !errors.Is(err, ErrLevelNotFound) {
+		t.Errorf("expected ErrLevelNotFound, got %v", err)
+	}
+}
+
+func TestSubmitRun_PlatformAndRegion(t *testing.T) {
+	var created db.CreateRunParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetPlatformBySlugFunc: platformLookup(db.Platform{ID: 1, Slug: "n64"}),
+		GetRegionBySlugFunc:   regionLookup(db.Region{ID: 1, Slug: "jpn"}),
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			created = params
+			return db.Run{ID: 9, Platform: params.Platform, Region: params.Region}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	input := validRun()
+	input.Region = "jpn"
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.Platform != "n64" || created.Region != (pgtype.Text{String: "jpn", Valid: true}) {
+		t.Errorf("expected the run on n64 in jpn, got %q in %+v", created.Platform, created.Region)
+	}
+
+	input.Region = "eur"
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown region, got %v", err)
+	}
+	input = validRun()
+	input.Platform = "Nintendo 64"
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown platform, got %v", err)
+	}
+}
+
+func TestSubmitRun_TimingMethods(t *testing.T) {
+	var created db.CreateRunParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1, TimingMethod: TimingMethodIGT}, nil
+		},
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			created = params
+			return db.Run{ID: 9}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	input := validRun()
+	input.Times = RunTimes{RTA: 1043250, IGT: 1021800}
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.TimeMs != 1021800 {
+		t.Errorf("expected the run ranked by its in-game time, got %d", created.TimeMs)
+	}
+	if created.RtaMs != (pgtype.Int8{Int64: 1043250, Valid: true}) ||
+		created.IgtMs != (pgtype.Int8{Int64: 1021800, Valid: true}) || created.LrtMs.Valid {
+		t.Errorf("expected the real and in-game times stored, got %+v %+v %+v", created.RtaMs, created.IgtMs, created.LrtMs)
+	}
+
+	input.Times = RunTimes{RTA: 1043250}
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without an in-game time, got %v", err)
+	}
+}
+
+// videoChecker is a video.Checker that records the links it checks and
+// returns err for each
+type videoChecker struct {
+	err     error
+	checked []video.Link
+}
+
+func (c *videoChecker) Check(ctx context.Context, link video.Link) error {
+	c.checked = append(c.checked, link)
+	return c.err
+}
+
+func TestSubmitRun_Video(t *testing.T) {
+	var created db.CreateRunParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			created = params
+			return db.Run{ID: 9}, nil
+		},
+	}
+	checker := &videoChecker{}
+	service := NewRunService(mockQueries, WithRunVideoChecker(checker))
+	input := validRun()
+	input.VideoURL = "https://youtu.be/dQw4w9WgXcQ?t=12"
+
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.VideoUrl != "https://www.youtube.com/watch?v=dQw4w9WgXcQ" {
+		t.Errorf("expected the canonical video link, got %q", created.VideoUrl)
+	}
+	if len(checker.checked) != 1 || checker.checked[0] != (video.Link{Provider: video.YouTube, ID: "dQw4w9WgXcQ"}) {
+		t.Errorf("expected the video to be checked once, got %+v", checker.checked)
+	}
+
+	checker.err = video.ErrUnavailable
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unavailable video, got %v", err)
+	}
+
+	// A provider outage doesn't block submissions
+	checker.err = errors.New("quota exceeded")
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); err != nil {
+		t.Errorf("expected the run accepted unchecked, got %v", err)
+	}
+}
+
+func TestSubmitRun_InvalidInput(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		modify func(*SubmitRunInput)
+	}{
+		{"no time", func(in *SubmitRunInput) { in.Times = RunTimes{} }},
+		{"negative time", func(in *SubmitRunInput) { in.Times.RTA = -5 }},
+		{"negative other time", func(in *SubmitRunInput) { in.Times.IGT = -5 }},
+		{"relative video url", func(in *SubmitRunInput) { in.VideoURL = "/videos/1" }},
+		{"non-http video url", func(in *SubmitRunInput) { in.VideoURL = "ftp://example.com/run.mp4" }},
+		{"unsupported video host", func(in *SubmitRunInput) { in.VideoURL = "https://videos.example.com/runs/1" }},
+		{"blank platform", func(in *SubmitRunInput) { in.Platform = "   " }},
+		{"missing date", func(in *SubmitRunInput) { in.PlayedOn = time.Time{} }},
+		{"future date", func(in *SubmitRunInput) { in.PlayedOn = now.AddDate(0, 0, 3) }},
+	}
+
+	service := NewRunService(&MockQueries{})
+	service.now = func() time.Time { return now }
+
+	for _, tt := range tests {
+		input := validRun()
+		tt.modify(&input)
+		_, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestSubmitRun_CategoryNotFound(t *testing.T) {
+	service := NewRunService(&MockQueries{})
+	_, err := service.SubmitRun(context.Background(), "super-mario-64", "missing", validRun())
+
+	if !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestSubmitRun_UserNotFound(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	_, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", validRun())
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestSubmitRun_EmailNotVerified(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			t.Error("expected no run to be stored for an unverified user")
+			return db.Run{}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	_, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", validRun())
+
+	if !errors.Is(err, ErrEmailNotVerified) {
+		t.Errorf("expected ErrEmailNotVerified, got %v", err)
+	}
+}
+
+func TestSubmitRun_Variables(t *testing.T) {
+	var recorded []db.CreateRunVariableValueParams
+	mockQueries := withGameVariables(&MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+			return db.Run{ID: 9}, nil
+		},
+		CreateRunVariableValueFunc: func(ctx context.Context, params db.CreateRunVariableValueParams) error {
+			recorded = append(recorded, params)
+			return nil
+		},
+	})
+
+	input := validRun()
+	input.Variables = RunVariables{"difficulty": "hard", "character": "luigi"}
+	service := NewRunService(mockQueries)
+	if _, err := service.SubmitRun(context.Background(), "super-mario-64", "120-star", input); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []db.CreateRunVariableValueParams{
+		{RunID: 9, VariableID: 1, ValueID: 11},
+		{RunID: 9, VariableID: 2, ValueID: 21},
+	}
+	if !slices.Equal(recorded, want) {
+		t.Errorf("expected values %+v, got %+v", want, recorded)
+	}
+}
+
+func TestSubmitRun_InvalidVariables(t *testing.T) {
+	tests := []struct {
+		name       string
+		categoryID int32
+		variables  RunVariables
+	}{
+		{"unknown variable", 3, RunVariables{"speed": "fast"}},
+		{"unknown value", 3, RunVariables{"difficulty": "extreme"}},
+		{"variable of another category", 4, RunVariables{"character": "mario"}},
+	}
+
+	for _, tt := range tests {
+		mockQueries := withGameVariables(&MockQueries{
+			GetCategoryBySlugFunc: func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error) {
+				return db.Category{ID: tt.categoryID, GameID: 1}, nil
+			},
+			CreateRunFunc: func(ctx context.Context, params db.CreateRunParams) (db.Run, error) {
+				t.Errorf("%s: expected no run to be stored", tt.name)
+				return db.Run{}, nil
+			},
+		})
+
+		input := validRun()
+		input.Variables = tt.variables
+		_, err := NewRunService(mockQueries).SubmitRun(context.Background(), "super-mario-64", "120-star", input)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestListCategoryRuns(t *testing.T) {
+	var params db.ListRunsByCategoryParams
+	var countParams db.CountRunsByCategoryParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		ListRunsByCategoryFunc: func(ctx context.Context, p db.ListRunsByCategoryParams) ([]db.Run, error) {
+			params = p
+			return []db.Run{{ID: 1}, {ID: 2}}, nil
+		},
+		CountRunsByCategoryFunc: func(ctx context.Context, p db.CountRunsByCategoryParams) (int64, error) {
+			countParams = p
+			return 2, nil
+		},
+		ListRunVariableValuesFunc: func(ctx context.Context, runIds []int32) ([]db.ListRunVariableValuesRow, error) {
+			return []db.ListRunVariableValuesRow{{RunID: 2, VariableSlug: "difficulty", ValueSlug: "hard"}}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	page, err := service.ListCategoryRuns(context.Background(), "super-mario-64", "120-star", PageRequest{}, ListRunsFilter{IncludeObsolete: true})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.CategoryID != 3 || params.Limit != 11 {
+		t.Errorf("expected category 3 with default limit, got %+v", params)
+	}
+	if !params.IncludeObsolete || !countParams.IncludeObsolete {
+		t.Errorf("expected obsolete runs listed and counted, got %+v and %+v", params, countParams)
+	}
+	if page.Total != 2 || len(page.Runs) != 2 {
+		t.Errorf("expected 2 runs, got %d (total %d)", len(page.Runs), page.Total)
+	}
+	if len(page.Variables) != 1 || page.Variables[2]["difficulty"] != "hard" {
+		t.Errorf("expected only run 2 to have variables, got %v", page.Variables)
+	}
+}
+
+func TestExportCategoryRuns(t *testing.T) {
+	var params db.ExportRunsByCategoryParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		ExportRunsByCategoryFunc: func(ctx context.Context, p db.ExportRunsByCategoryParams, fn func(db.Run) error) error {
+			params = p
+			for _, id := range []int32{1, 2} {
+				if err := fn(db.Run{ID: id}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	var exported []int32
+	err := service.ExportCategoryRuns(context.Background(), "super-mario-64", "120-star", ListRunsFilter{IncludeObsolete: true}, func(run db.Run) error {
+		exported = append(exported, run.ID)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.CategoryID != 3 || !params.IncludeObsolete {
+		t.Errorf("expected category 3 with obsolete runs, got %+v", params)
+	}
+	if len(exported) != 2 {
+		t.Errorf("expected 2 runs exported, got %v", exported)
+	}
+}
+
+func TestListUserRuns_CursorSeeksByCreatedAt(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	var after db.ListRunsByUserAfterParams
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		ListRunsByUserAfterFunc: func(ctx context.Context, p db.ListRunsByUserAfterParams) ([]db.Run, error) {
+			after = p
+			return nil, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	cursor := encodeCursor("user-runs", createdAt, int32(9))
+	if _, err := service.ListUserRuns(context.Background(), 1, PageRequest{Cursor: cursor}, ListRunsFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !after.AfterCreatedAt.Time.Equal(createdAt) || after.AfterID != 9 || after.UserID != 1 || after.IncludeObsolete {
+		t.Errorf("expected to seek after run 9 at %s, got %+v", createdAt, after)
+	}
+}
+
+func TestListUserRuns_UserNotFound(t *testing.T) {
+	service := NewRunService(&MockQueries{})
+	_, err := service.ListUserRuns(context.Background(), 999, PageRequest{Limit: 10}, ListRunsFilter{})
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	var params db.GetLeaderboardParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		GetLeaderboardFunc: func(ctx context.Context, p db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error) {
+			params = p
+			return []db.GetLeaderboardRow{
+				{Rank: 1, ID: 5, UserID: 2, TimeMs: 1000},
+				{Rank: 1, ID: 8, UserID: 4, TimeMs: 1000},
+				{Rank: 3, ID: 2, UserID: 1, TimeMs: 1200},
+			}, nil
+		},
+		CountLeaderboardFunc: func(ctx context.Context, p db.CountLeaderboardParams) (int64, error) {
+			return 3, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	page, err := service.Leaderboard(context.Background(), "super-mario-64", "120-star", PageRequest{Limit: 500}, LeaderboardFilter{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.CategoryID != 3 || params.LevelID.Valid || params.Limit != 101 {
+		t.Errorf("expected full-game runs of category 3 with clamped limit, got %+v", params)
+	}
+	if page.NextCursor != "" {
+		t.Errorf("expected no next cursor on the only page, got %q", page.NextCursor)
+	}
+	if page.Total != 3 || len(page.Entries) != 3 {
+		t.Errorf("expected 3 entries, got %d (total %d)", len(page.Entries), page.Total)
+	}
+}
+
+func TestLeaderboard_FilteredByVariables(t *testing.T) {
+	var params db.GetLeaderboardParams
+	var countParams db.CountLeaderboardParams
+	mockQueries := withGameVariables(&MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetLeaderboardFunc: func(ctx context.Context, p db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error) {
+			params = p
+			return []db.GetLeaderboardRow{}, nil
+		},
+		CountLeaderboardFunc: func(ctx context.Context, p db.CountLeaderboardParams) (int64, error) {
+			countParams = p
+			return 0, nil
+		},
+	})
+
+	service := NewRunService(mockQueries)
+	filter := LeaderboardFilter{Variables: RunVariables{"character": "mario", "difficulty": "hard"}}
+	if _, err := service.Leaderboard(context.Background(), "super-mario-64", "120-star", PageRequest{Limit: 10}, filter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(params.ValueIds, []int32{11, 20}) || !slices.Equal(countParams.ValueIds, []int32{11, 20}) {
+		t.Errorf("expected runs with values 11 and 20 ranked and counted, got %v and %v", params.ValueIds, countParams.ValueIds)
+	}
+
+	params = db.GetLeaderboardParams{}
+	if _, err := service.Leaderboard(context.Background(), "super-mario-64", "120-star", PageRequest{Limit: 10}, LeaderboardFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.ValueIds == nil || len(params.ValueIds) != 0 {
+		t.Errorf("expected an empty, non-nil filter without variables, got %#v", params.ValueIds)
+	}
+
+	filter = LeaderboardFilter{Variables: RunVariables{"difficulty": "extreme"}}
+	if _, err := service.Leaderboard(context.Background(), "super-mario-64", "120-star", PageRequest{Limit: 10}, filter); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown value, got %v", err)
+	}
+}
+
+func TestLeaderboard_FilteredByPlatformAndRegion(t *testing.T) {
+	var params db.GetLeaderboardParams
+	var countParams db.CountLeaderboardParams
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		GetPlatformBySlugFunc: platformLookup(db.Platform{ID: 1, Slug: "n64"}),
+		GetRegionBySlugFunc:   regionLookup(db.Region{ID: 1, Slug: "jpn"}),
+		GetLeaderboardFunc: func(ctx context.Context, p db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error) {
+			params = p
+			return []db.GetLeaderboardRow{}, nil
+		},
+		CountLeaderboardFunc: func(ctx context.Context, p db.CountLeaderboardParams) (int64, error) {
+			countParams = p
+			return 0, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	filter := LeaderboardFilter{Platform: "n64", Region: "jpn"}
+	if _, err := service.Leaderboard(context.Background(), "super-mario-64", "120-star", PageRequest{Limit: 10}, filter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.Platform != (pgtype.Text{String: "n64", Valid: true}) || params.Region != (pgtype.Text{String: "jpn", Valid: true}) {
+		t.Errorf("expected runs on n64 in jpn ranked, got %+v and %+v", params.Platform, params.Region)
+	}
+	if countParams.Platform != params.Platform || countParams.Region != params.Region {
+		t.Errorf("expected the count filtered the same way, got %+v", countParams)
+	}
+
+	if _, err := service.Leaderboard(context.Background(), "super-mario-64", "120-star", PageRequest{Limit: 10}, LeaderboardFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.Platform.Valid || params.Region.Valid {
+		t.Errorf("expected no platform or region filter, got %+v and %+v", params.Platform, params.Region)
+	}
+
+	for _, filter := range []LeaderboardFilter{{Platform: "pc"}, {Region: "eur"}} {
+		if _, err := service.Leaderboard(context.Background(), "super-mario-64", "120-star", PageRequest{Limit: 10}, filter); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", filter, err)
+		}
+	}
+}
+
+func TestLevelLeaderboard(t *testing.T) {
+	var params db.GetLeaderboardParams
+	var countParams db.CountLeaderboardParams
+	mockQueries := &MockQueries{
+		GetLevelBySlugFunc: levelLookup("super-mario-64", db.Level{ID: 2, GameID: 1, Slug: "whomps-fortress"}),
+		GetDefaultCategoryFunc: func(ctx context.Context, gameID int32) (db.Category, error) {
+			if gameID != 1 {
+				t.Errorf("expected the default category of game 1, got game %d", gameID)
+			}
+			return db.Category{ID: 3, GameID: 1, IsDefault: true}, nil
+		},
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 4, GameID: 1}, nil
+		},
+		GetLeaderboardFunc: func(ctx context.Context, p db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error) {
+			params = p
+			return []db.GetLeaderboardRow{{Rank: 1, ID: 5, UserID: 2, TimeMs: 1000}}, nil
+		},
+		CountLeaderboardFunc: func(ctx context.Context, p db.CountLeaderboardParams) (int64, error) {
+			countParams = p
+			return 1, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	page, err := service.LevelLeaderboard(context.Background(), "super-mario-64", "whomps-fortress", "", PageRequest{Limit: 10}, LeaderboardFilter{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	level := pgtype.Int4{Int32: 2, Valid: true}
+	if params.CategoryID != 3 || params.LevelID != level || countParams.LevelID != level {
+		t.Errorf("expected level 2 ranked in the default category, got %+v and %+v", params, countParams)
+	}
+	if page.Total != 1 || len(page.Entries) != 1 {
+		t.Errorf("expected 1 entry, got %d (total %d)", len(page.Entries), page.Total)
+	}
+
+	if _, err := service.LevelLeaderboard(context.Background(), "super-mario-64", "whomps-fortress", "70-star", PageRequest{Limit: 10}, LeaderboardFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.CategoryID != 4 || params.LevelID != level {
+		t.Errorf("expected level 2 ranked in the requested category, got %+v", params)
+	}
+}
+
+func TestLevelLeaderboard_NotFound(t *testing.T) {
+	service := NewRunService(&MockQueries{
+		GetLevelBySlugFunc: levelLookup("super-mario-64", db.Level{ID: 2, GameID: 1, Slug: "whomps-fortress"}),
+	})
+
+	if _, err := service.LevelLeaderboard(context.Background(), "super-mario-64", "missing", "", PageRequest{Limit: 10}, LeaderboardFilter{}); !errors.Is(err, ErrLevelNotFound) {
+		t.Errorf("expected ErrLevelNotFound, got %v", err)
+	}
+	if _, err := service.LevelLeaderboard(context.Background(), "super-mario-64", "whomps-fortress", "", PageRequest{Limit: 10}, LeaderboardFilter{}); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound without a default category, got %v", err)
+	}
+}
+
+func TestLeaderboard_CategoryNotFound(t *testing.T) {
+	service := NewRunService(&MockQueries{})
+	_, err := service.Leaderboard(context.Background(), "super-mario-64", "missing", PageRequest{Limit: 10}, LeaderboardFilter{})
+
+	if !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestPersonalBests(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		GetPersonalBestsFunc: func(ctx context.Context, userID int32) ([]db.GetPersonalBestsRow, error) {
+			if userID != 3 {
+				t.Errorf("expected user 3, got %d", userID)
+			}
+			return []db.GetPersonalBestsRow{
+				{Rank: 1, ID: 1, CategoryID: 1, TimeMs: 5843000, RecordTimeMs: 5843000},
+				{Rank: 2, ID: 13, CategoryID: 6, TimeMs: 463000, RecordTimeMs: 457000, DeltaMs: 6000},
+			}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	bests, err := service.PersonalBests(context.Background(), 3)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(bests) != 2 || bests[1].DeltaMs != 6000 {
+		t.Errorf("expected both personal bests, got %+v", bests)
+	}
+}
+
+func TestPersonalBests_UserNotFound(t *testing.T) {
+	service := NewRunService(&MockQueries{})
+	_, err := service.PersonalBests(context.Background(), 999)
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		ListCategoryRecordsFunc: func(ctx context.Context, categoryID int32) ([]db.ListCategoryRecordsRow, error) {
+			if categoryID != 3 {
+				t.Errorf("expected category 3, got %d", categoryID)
+			}
+			return []db.ListCategoryRecordsRow{{RunID: 2, TimeMs: 1200}, {RunID: 5, TimeMs: 1000}}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	records, err := service.RecordHistory(context.Background(), "super-mario-64", "120-star")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(records) != 2 || records[1].RunID != 5 {
+		t.Errorf("expected the progression in order, got %+v", records)
+	}
+}
+
+func TestRecordHistory_CategoryNotFound(t *testing.T) {
+	service := NewRunService(&MockQueries{})
+	_, err := service.RecordHistory(context.Background(), "super-mario-64", "missing")
+
+	if !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestLeaderboard_CachedUntilRunVerified(t *testing.T) {
+	var reads int
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		GetLeaderboardFunc: func(ctx context.Context, p db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error) {
+			reads++
+			return []db.GetLeaderboardRow{{Rank: 1, ID: 5, UserID: 2, TimeMs: 1000}}, nil
+		},
+		CountLeaderboardFunc: func(ctx context.Context, p db.CountLeaderboardParams) (int64, error) {
+			return 1, nil
+		},
+		GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: RunStatusPending}, nil
+		},
+		GetCategoryByIDFunc: categoryInGame(1),
+		UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{ID: p.ID, CategoryID: 3, Status: p.Status}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries, WithRunCache(cache.New(cache.NewMemory(), time.Minute)))
+	service.Leaderboard(context.Background(), "celeste", "any", PageRequest{Limit: 10}, LeaderboardFilter{})
+	page, err := service.Leaderboard(context.Background(), "celeste", "any", PageRequest{Limit: 10}, LeaderboardFilter{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if reads != 1 || page.Total != 1 || len(page.Entries) != 1 || page.Entries[0].ID != 5 {
+		t.Errorf("expected the second read to be served from the cache, got %d reads and %+v", reads, page)
+	}
+
+	// Another page size is a different cache entry
+	service.Leaderboard(context.Background(), "celeste", "any", PageRequest{Limit: 20}, LeaderboardFilter{})
+	if reads != 2 {
+		t.Errorf("expected a read for another page size, got %d reads", reads)
+	}
+
+	if _, err := service.VerifyRun(asAdmin(), 9); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	service.Leaderboard(context.Background(), "celeste", "any", PageRequest{Limit: 10}, LeaderboardFilter{})
+	if reads != 3 {
+		t.Errorf("expected verifying a run to invalidate the leaderboard, got %d reads", reads)
+	}
+}
+
+func TestLeaderboard_CoalescesConcurrentMisses(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mockQueries := &MockQueries{
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3}, nil
+		},
+		GetLeaderboardFunc: func(ctx context.Context, p db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error) {
+			calls.Add(1)
+			<-release
+			return []db.GetLeaderboardRow{{Rank: 1, ID: 5, UserID: 2, TimeMs: 1000}}, nil
+		},
+		CountLeaderboardFunc: func(ctx context.Context, p db.CountLeaderboardParams) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	service := NewRunService(mockQueries, WithRunCache(cache.New(cache.NewMemory(), time.Minute)))
+
+	const readers = 10
+	var wg sync.WaitGroup
+	results := make([]*LeaderboardPage, readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			page, err := service.Leaderboard(context.Background(), "celeste", "any", PageRequest{Limit: 10}, LeaderboardFilter{})
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			results[i] = page
+		}(i)
+	}
+
+	// Give every reader time to join the in-flight query before it returns
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 database call, got %d", got)
+	}
+	for i, page := range results {
+		if page == nil || len(page.Entries) != 1 {
+			t.Errorf("reader %d: expected one entry, got %+v", i, page)
+		}
+	}
+}
+
+// categoryInGame returns a GetCategoryByIDFunc placing every category in gameID
+func categoryInGame(gameID int32) func(ctx context.Context, id int32) (db.Category, error) {
+	return func(ctx context.Context, id int32) (db.Category, error) {
+		return db.Category{ID: id, GameID: gameID}, nil
+	}
+}
+
+func TestVerifyRun(t *testing.T) {
+	var params db.UpdateRunStatusParams
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: RunStatusPending}, nil
+		},
+		GetCategoryByIDFunc: categoryInGame(1),
+		UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+			params = p
+			return db.Run{ID: p.ID, Status: p.Status}, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	run, err := service.VerifyRun(asAdmin(), 7)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if run.Status != RunStatusVerified {
+		t.Errorf("expected status verified, got %s", run.Status)
+	}
+	if params.FromStatus != RunStatusPending || params.RejectionReason.Valid {
+		t.Errorf("expected update from pending without a reason, got %+v", params)
+	}
+}
+
+func TestVerifyRun_ObsoletesBeatenRuns(t *testing.T) {
+	var obsoleteRunID int32
+	var actions []string
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, UserID: 4, CategoryID: 3, TimeMs: 50_000, Status: RunStatusPending}, nil
+		},
+		GetCategoryByIDFunc: categoryInGame(1),
+		UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{ID: p.ID, UserID: 4, CategoryID: 3, TimeMs: 50_000, Status: p.Status}, nil
+		},
+		// The run was played before the runner's current best but verified
+		// after it, so it is obsolete as soon as it is verified
+		ObsoleteBeatenRunsFunc: func(ctx context.Context, runID int32) ([]db.Run, error) {
+			obsoleteRunID = runID
+			return []db.Run{
+				{ID: 2, UserID: 4, CategoryID: 3, TimeMs: 55_000, Status: RunStatusVerified, Obsolete: true},
+				{ID: 7, UserID: 4, CategoryID: 3, TimeMs: 50_000, Status: RunStatusVerified, Obsolete: true},
+			}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			actions = append(actions, fmt.Sprintf("%s %d", p.Action, p.EntityID))
+			return nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	run, err := service.VerifyRun(asAdmin(), 7)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if obsoleteRunID != 7 {
+		t.Errorf("expected the runs like run 7 to be marked, got run %d", obsoleteRunID)
+	}
+	if !run.Obsolete {
+		t.Error("expected the returned run to be marked obsolete")
+	}
+	want := []string{"run.verify 7", "run.obsolete 2", "run.obsolete 7"}
+	if !slices.Equal(actions, want) {
+		t.Errorf("expected audit events %v, got %v", want, actions)
+	}
+}
+
+func TestVerifyRun_AlreadyReviewed(t *testing.T) {
+	for _, status := range []string{RunStatusVerified, RunStatusRejected} {
+		mockQueries := &MockQueries{
+			GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+				return db.Run{ID: id, CategoryID: 3, Status: status}, nil
+			},
+			GetCategoryByIDFunc: categoryInGame(1),
+			UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+				t.Fatal("UpdateRunStatus should not be called")
+				return db.Run{}, nil
+			},
+		}
+
+		service := NewRunService(mockQueries)
+		_, err := service.VerifyRun(asAdmin(), 7)
+
+		if !errors.Is(err, ErrInvalidRunTransition) {
+			t.Errorf("%s: expected ErrInvalidRunTransition, got %v", status, err)
+		}
+	}
+}
+
+func TestVerifyRun_ConcurrentReview(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: RunStatusPending}, nil
+		},
+		GetCategoryByIDFunc: categoryInGame(1),
+		UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+			return db.Run{}, sql.ErrNoRows
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	_, err := service.VerifyRun(asAdmin(), 7)
+
+	if !errors.Is(err, ErrInvalidRunTransition) {
+		t.Errorf("expected ErrInvalidRunTransition, got %v", err)
+	}
+}
+
+func TestVerifyRun_NotFound(t *testing.T) {
+	service := NewRunService(&MockQueries{})
+	_, err := service.VerifyRun(asAdmin(), 7)
+
+	if !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("expected ErrRunNotFound, got %v", err)
+	}
+}
+
+func TestVerifyRun_Authorization(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"anonymous", context.Background(), ErrForbidden},
+		{"plain user", asUser(5), ErrForbidden},
+		{"other game moderator", asUser(5, auth.Grant{Role: auth.RoleModerator, GameID: 2}), ErrForbidden},
+		{"game moderator", asUser(5, auth.Grant{Role: auth.RoleModerator, GameID: 1}), nil},
+		{"global moderator", asUser(5, auth.Grant{Role: auth.RoleModerator}), nil},
+		{"admin", asAdmin(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			mockQueries := &MockQueries{
+				GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+					return db.Run{ID: id, CategoryID: 3, Status: RunStatusPending}, nil
+				},
+				GetCategoryByIDFunc: categoryInGame(1),
+				UpdateRunStatusFunc: func(ctx context.Context, p db.UpdateRunStatusParams) (db.Run, error) {
+					updated = true
+					return db.Run{ID: p.ID, Status: p.Status}, nil
+				},
+			}
+
+			service := NewRunService(mockQueries)
+			_, err := service.VerifyRun(tt.ctx, 7)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if updated != (tt.want == nil) {
+				t.Errorf("expected update to happen only when allowed, updated = %v", updated)
+			}
+		})
+	}
+}
+
+func TestModerationQueue(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	var params db.ListModerationQueueParams
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 1, Slug: "super-mario-64"}),
+		ListModerationQueueFunc: func(ctx context.Context, p db.ListModerationQueueParams) ([]db.Run, error) {
+			params = p
+			return []db.Run{
+				{ID: 4, Status: RunStatusPending, CreatedAt: pgtype.Timestamptz{Time: now.Add(-80 * time.Hour), Valid: true}},
+				{ID: 9, Status: RunStatusPending, CreatedAt: pgtype.Timestamptz{Time: now.Add(-2 * time.Hour), Valid: true}},
+				{ID: 12, Status: RunStatusPending, CreatedAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}},
+			}, nil
+		},
+		CountModerationQueueFunc: func(ctx context.Context, gameID int32) (int64, error) {
+			return 3, nil
+		},
+	}
+
+	service := NewRunService(mockQueries, WithModerationSLA(48*time.Hour))
+	service.now = func() time.Time { return now }
+	page, err := service.ModerationQueue(asUser(5, auth.Grant{Role: auth.RoleModerator, GameID: 1}), "super-mario-64", PageRequest{Limit: 2})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.GameID != 1 || params.Limit != 3 {
+		t.Errorf("expected one extra row of game 1 to be fetched, got %+v", params)
+	}
+	if len(page.Entries) != 2 || page.Entries[0].Run.ID != 4 || page.Entries[1].Run.ID != 9 {
+		t.Fatalf("expected the two longest waiting runs, got %+v", page.Entries)
+	}
+	if page.Entries[0].Waiting != 80*time.Hour || !page.Entries[0].Overdue {
+		t.Errorf("expected the first run to be 80h overdue, got %+v", page.Entries[0])
+	}
+	if page.Entries[1].Waiting != 2*time.Hour || page.Entries[1].Overdue {
+		t.Errorf("expected the second run to be within the SLA, got %+v", page.Entries[1])
+	}
+	if page.Total != 3 || page.SLA != 48*time.Hour || page.NextCursor == "" {
+		t.Errorf("unexpected page metadata %+v", page)
+	}
+
+	var after db.ListModerationQueueAfterParams
+	mockQueries.ListModerationQueueAfterFunc = func(ctx context.Context, p db.ListModerationQueueAfterParams) ([]db.Run, error) {
+		after = p
+		return nil, nil
+	}
+	if _, err := service.ModerationQueue(asAdmin(), "super-mario-64", PageRequest{Limit: 2, Cursor: page.NextCursor}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.AfterID != 9 || !after.AfterCreatedAt.Time.Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("expected the next page to seek past run 9, got %+v", after)
+	}
+}
+
+func TestModerationQueue_Authorization(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 1, Slug: "super-mario-64"}),
+		ListModerationQueueFunc: func(ctx context.Context, p db.ListModerationQueueParams) ([]db.Run, error) {
+			t.Error("expected the queue not to be listed")
+			return nil, nil
+		},
+	}
+
+	service := NewRunService(mockQueries)
+	for _, ctx := range []context.Context{
+		context.Background(),
+		asUser(5),
+		asUser(5, auth.Grant{Role: auth.RoleModerator, GameID: 2}),
+	} {
+		if _, err := service.ModerationQueue(ctx, "super-mario-64", PageRequest{}); !errors.Is(err, ErrForbidden) {
+			t.Errorf("expected ErrForbidden, got %v", err)
+		}
+	}
+	if _, err := service.ModerationQueue(asAdmin(), "missing", PageRequest{}); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestRejectRun(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetRunByIDFunc: func(ctx context.Context, id int32) (db.Run, error) {
+			return db.Run{ID: id, CategoryID: 3, Status: RunStatusPending}, nil
+		},
+		GetCategoryByIDFunc: categoryInGame(1),
+	}
+
+	service := NewRunService(mockQueries)
+	run, err := service.RejectRun(asAdmin(), 7, "  Timer starts too late  ")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if run.Status != RunStatusRejected {
+		t.Errorf("expected status rejected, got %s", run.Status)
+	}
+	if run.RejectionReason.String != "Timer starts too late" {
+		t.Errorf("expected trimmed reason, got %q", run.RejectionReason.String)
+	}
+}
+
+func TestRejectRun_InvalidReason(t *testing.T) {
+	service := NewRunService(&MockQueries{})
+
+	for _, reason := range []string{"", "   ", strings.Repeat("a", maxRejectionReasonLength+1)} {
+		_, err := service.RejectRun(asAdmin(), 7, reason)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("reason of length %d: expected ErrInvalidInput, got %v", len(reason), err)
+		}
+	}
+}
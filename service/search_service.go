@@ -0,0 +1,184 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// Types of search results
+const (
+	SearchTypeUser = "user"
+	SearchTypeGame = "game"
+	SearchTypeRun  = "run"
+)
+
+// SearchTypes are the types of results Search looks for, in the order results
+// of equal rank are listed
+var SearchTypes = []string{SearchTypeUser, SearchTypeGame, SearchTypeRun}
+
+// maxSearchQueryLength caps the characters of a search query
+const maxSearchQueryLength = 200
+
+// SearchService handles full-text search across users, games, and run
+// comments
+type SearchService struct {
+	queries db.Querier
+	pages   pageSizes
+}
+
+// SearchResult is one match of a search
+type SearchResult struct {
+	// Type is one of the SearchType constants
+	Type string
+	
+	// ID is the user, game, or run that matched
+	ID int32
+	
+	// Title names the match: the user's or game's name, or a run's game and
+	// category
+	Title string
+	
+	// GameSlug is the slug of the game that matched, or of a run's game
+	GameSlug string
+	
+	// CategorySlug is the slug of a run's category
+	CategorySlug string
+	
+	// Snippet is an excerpt of a run's best matching comment, with the
+	// matched words between ** marks
+	Snippet string
+	
+	// Rank is how well the result matched; higher is better
+	Rank float32
+}
+
+// SearchOption configures optional SearchService behavior
+type SearchOption func(*SearchService)
+
+// WithSearchPageSizes sets the number of results returned when a search
+// omits a limit and the largest limit a search may ask for
+func WithSearchPageSizes(defaultSize, maxSize int) SearchOption {
+	return func(s *SearchService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// NewSearchService creates a new SearchService instance
+func NewSearchService(queries db.Querier, opts ...SearchOption) *SearchService {
+	s := &SearchService{
+		queries: queries,
+		pages:   defaultPageSizes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Search finds the users, games, and runs matching a web search query, best
+// match first
+//
+// The query takes words, "quoted phrases", OR, and -excluded words. Users
+// match on their name, games on their name or slug, and runs on the
+// comments left on them; deleted users and comments never match.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - query: The search query
+//   - types: The SearchType constants to look for; empty looks for all
+//   - limit: Maximum number of results; zero selects the default
+//
+// Returns:
+//   - []SearchResult: The matches, highest rank first
+//   - error: ErrInvalidInput for a blank or overlong query or an unknown
+//     type, or database errors
+func (s *SearchService) Search(ctx context.Context, query string, types []string, limit int) ([]SearchResult, error) {
+	ctx, span := tracer.Start(ctx, "SearchService.Search")
+	defer span.End()
+	
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, invalidField("q", "must not be blank")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, invalidField("q", "must be at most %d characters", maxSearchQueryLength)
+	}
+	for _, t := range types {
+		if !slices.Contains(SearchTypes, t) {
+			return nil, invalidField("type", "includes unknown type %q", t)
+		}
+	}
+	if len(types) == 0 {
+		types = SearchTypes
+	}
+	pageLimit, _ := s.pages.normalize(limit, 0)
+	
+	// Each type is searched for the whole limit, since any one of them may
+	// hold every one of the best matches
+	var results []SearchResult
+	for _, t := range SearchTypes {
+		if !slices.Contains(types, t) {
+			continue
+		}
+		found, err := s.searchType(ctx, t, query, pageLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search %ss: %w", t, err)
+		}
+		results = append(results, found...)
+	}
+	
+	// The sort is stable, so ties keep each type's own order
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		return cmp.Compare(b.Rank, a.Rank)
+	})
+	if len(results) > int(pageLimit) {
+		results = results[:pageLimit]
+	}
+	return results, nil
+}
+
+// searchType runs the search query for one type of result
+func (s *SearchService) searchType(ctx context.Context, searchType, query string, limit int32) ([]SearchResult, error) {
+	var results []SearchResult
+	switch searchType {
+	case SearchTypeUser:
+		users, err := s.queries.SearchUsers(ctx, db.SearchUsersParams{Query: query, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			results = append(results, SearchResult{Type: SearchTypeUser, ID: user.ID, Title: user.Name, Rank: user.Rank})
+		}
+	case SearchTypeGame:
+		games, err := s.queries.SearchGames(ctx, db.SearchGamesParams{Query: query, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		for _, game := range games {
+			results = append(results, SearchResult{Type: SearchTypeGame, ID: game.ID, Title: game.Name, GameSlug: game.Slug, Rank: game.Rank})
+		}
+	case SearchTypeRun:
+		runs, err := s.queries.SearchRuns(ctx, db.SearchRunsParams{Query: query, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs {
+			results = append(results, SearchResult{
+				Type:         SearchTypeRun,
+				ID:           run.RunID,
+				Title:        run.GameName + " - " + run.CategoryName,
+				GameSlug:     run.GameSlug,
+				CategorySlug: run.CategorySlug,
+				Snippet:      run.Snippet,
+				Rank:         run.Rank,
+			})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+func TestSearch_MergesTypesByRank(t *testing.T) {
+	var limits []int32
+	mockQueries := &MockQueries{
+		SearchUsersFunc: func(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error) {
+			limits = append(limits, arg.Limit)
+			if arg.Query != "mario -kart" {
+				t.Errorf("expected the trimmed query, got %q", arg.Query)
+			}
+			return []db.SearchUsersRow{{ID: 1, Name: "Mario Fan", Rank: 0.5}, {ID: 2, Name: "Mario", Rank: 0.1}}, nil
+		},
+		SearchGamesFunc: func(ctx context.Context, arg db.SearchGamesParams) ([]db.SearchGamesRow, error) {
+			limits = append(limits, arg.Limit)
+			return []db.SearchGamesRow{{ID: 3, Slug: "sm64", Name: "Super Mario 64", Rank: 0.9}}, nil
+		},
+		SearchRunsFunc: func(ctx context.Context, arg db.SearchRunsParams) ([]db.SearchRunsRow, error) {
+			limits = append(limits, arg.Limit)
+			return []db.SearchRunsRow{{
+				RunID: 7, GameSlug: "sm64", GameName: "Super Mario 64", CategorySlug: "120-star", CategoryName: "120 Star",
+				Snippet: "best **Mario** movement", Rank: 0.5,
+			}}, nil
+		},
+	}
+
+	service := NewSearchService(mockQueries)
+	results, err := service.Search(context.Background(), "  mario -kart ", nil, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(limits) != 3 || limits[0] != 3 || limits[1] != 3 || limits[2] != 3 {
+		t.Errorf("expected every type searched for the whole limit, got %v", limits)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected the 3 best results, got %+v", results)
+	}
+	if results[0].Type != SearchTypeGame || results[0].GameSlug != "sm64" {
+		t.Errorf("expected the game first, got %+v", results[0])
+	}
+	if results[1].Type != SearchTypeUser || results[1].ID != 1 {
+		t.Errorf("expected the tied user before the tied run, got %+v", results[1])
+	}
+	run := results[2]
+	if run.Type != SearchTypeRun || run.ID != 7 || run.Title != "Super Mario 64 - 120 Star" || run.CategorySlug != "120-star" || run.Snippet == "" {
+		t.Errorf("expected the run with its game and category, got %+v", run)
+	}
+}
+
+func TestSearch_OnlyRequestedTypes(t *testing.T) {
+	mockQueries := &MockQueries{
+		SearchUsersFunc: func(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error) {
+			t.Error("expected users not to be searched")
+			return nil, nil
+		},
+		SearchRunsFunc: func(ctx context.Context, arg db.SearchRunsParams) ([]db.SearchRunsRow, error) {
+			t.Error("expected runs not to be searched")
+			return nil, nil
+		},
+		SearchGamesFunc: func(ctx context.Context, arg db.SearchGamesParams) ([]db.SearchGamesRow, error) {
+			if arg.Limit != 10 {
+				t.Errorf("expected the default limit of 10, got %d", arg.Limit)
+			}
+			return []db.SearchGamesRow{{ID: 3, Name: "Super Mario 64"}}, nil
+		},
+	}
+
+	results, err := NewSearchService(mockQueries).Search(context.Background(), "mario", []string{SearchTypeGame}, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Type != SearchTypeGame {
+		t.Errorf("expected the game only, got %+v", results)
+	}
+}
+
+func TestSearch_Errors(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	mockQueries := &MockQueries{
+		SearchUsersFunc: func(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error) {
+			return nil, dbErr
+		},
+	}
+	service := NewSearchService(mockQueries)
+
+	tests := []struct {
+		name     string
+		query    string
+		types    []string
+		expected error
+	}{
+		{"blank query", "   ", nil, ErrInvalidInput},
+		{"long query", strings.Repeat("a", maxSearchQueryLength+1), nil, ErrInvalidInput},
+		{"unknown type", "mario", []string{"comment"}, ErrInvalidInput},
+		{"database error", "mario", nil, dbErr},
+	}
+	for _, tt := range tests {
+		_, err := service.Search(context.Background(), tt.query, tt.types, 0)
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortOrder is the column a list is sorted by and its direction
+type SortOrder struct {
+	Column     string
+	Descending bool
+}
+
+// String formats the order the way parseSort reads it, e.g. "name:desc"
+func (o SortOrder) String() string {
+	if o.Descending {
+		return o.Column + ":desc"
+	}
+	return o.Column + ":asc"
+}
+
+// userSortColumns are the columns ListUsers can sort by; the ListUsers and
+// ListUsersAfter queries handle exactly these
+var userSortColumns = []string{"id", "name", "email", "created_at", "updated_at"}
+
+// parseSort reads a sort parameter of the form "column" or "column:dir",
+// where dir is asc (the default) or desc
+//
+// Only columns in the allowlist are accepted, so client input never chooses
+// what SQL is run; an empty parameter sorts by the first column, ascending.
+//
+// Returns:
+//   - SortOrder: The requested order
+//   - error: ErrInvalidInput if the column or direction is not allowed
+func parseSort(raw string, columns []string) (SortOrder, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return SortOrder{Column: columns[0]}, nil
+	}
+	
+	column, dir, _ := strings.Cut(raw, ":")
+	order := SortOrder{Column: strings.ToLower(column)}
+	if !slices.Contains(columns, order.Column) {
+		return SortOrder{}, fmt.Errorf("%w: sort must be one of %s", ErrInvalidInput, strings.Join(columns, ", "))
+	}
+	switch strings.ToLower(dir) {
+	case "", "asc":
+	case "desc":
+		order.Descending = true
+	default:
+		return SortOrder{}, fmt.Errorf("%w: sort direction must be asc or desc", ErrInvalidInput)
+	}
+	return order, nil
+}
+
+// escapeLike quotes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,8 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for each service call, so traces show the time spent
+// in business logic between the HTTP span and its queries. Until a tracer
+// provider is installed the spans are no-ops.
+var tracer = otel.Tracer("github.com/example/speedrun-rest-api/service")
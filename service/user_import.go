@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+var (
+	// ErrTooManyImportRows is returned when an import has more rows than
+	// MaxImportRows
+	ErrTooManyImportRows = errors.New("too many rows to import")
+)
+
+const (
+	// MaxImportRows is the most users a single import may create
+	MaxImportRows = 10000
+	
+	// importBatchSize is how many rows are copied into the users table at a
+	// time, bounding the memory one copy holds
+	importBatchSize = 1000
+)
+
+// ImportRow is one user to create in an import
+type ImportRow struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ImportRowResult is what became of one row of an import
+type ImportRowResult struct {
+	// Row is the row's 1-based position in the import
+	Row int
+	
+	// Email is the row's email address as given
+	Email string
+	
+	// UserID is the ID of the created user; it is zero when the row failed
+	UserID int32
+	
+	// Errors lists what is wrong with a row that failed; it is empty when
+	// the user was created
+	Errors []FieldError
+}
+
+// ImportReport is the outcome of an import, with one result per row in the
+// order the rows were given
+type ImportReport struct {
+	Rows     []ImportRowResult
+	Imported int
+	Failed   int
+}
+
+// ImportUsers creates users in bulk; only admins may import users
+//
+// Every row is validated as CreateUser would, and a row whose email is
+// already taken, or is repeated by an earlier row, fails. Rows that fail are
+// reported and skipped; the rest are created together in one transaction,
+// copied into the database in batches, so either all of them are created or
+// none are. Imported users are not sent verification emails.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - rows: The users to create
+//
+// Returns:
+//   - *ImportReport: What became of each row
+//   - error: ErrForbidden, ErrInvalidInput if there are no rows,
+//     ErrTooManyImportRows, ErrDuplicateEmail if another request took one of
+//     the emails during the import, or database errors
+func (s *UserService) ImportUsers(ctx context.Context, rows []ImportRow) (*ImportReport, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ImportUsers")
+	defer span.End()
+	
+	if err := checkImport(ctx, rows); err != nil {
+		return nil, err
+	}
+	
+	report := &ImportReport{Rows: make([]ImportRowResult, len(rows))}
+	emails := make([]string, len(rows))
+	for i, row := range rows {
+		emails[i] = row.Email
+	}
+	existing, err := s.queries.GetUsersByEmails(ctx, emails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate emails: %w", err)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, user := range existing {
+		taken[user.Email] = true
+	}
+	
+	// firstRow maps each email to the valid row that first used it
+	firstRow := make(map[string]int, len(rows))
+	var valid []db.ImportUsersParams
+	for i, row := range rows {
+		result := ImportRowResult{Row: i + 1, Email: row.Email}
+		name, err := s.validateName(row.Name)
+		err = joinValidation(err, validateEmail(row.Email))
+		if err == nil {
+			if first, ok := firstRow[row.Email]; ok {
+				err = invalidField("email", "is already used by row %d", first)
+			} else if taken[row.Email] {
+				err = invalidField("email", "is already taken")
+			}
+		}
+		
+		var invalid *ValidationError
+		if errors.As(err, &invalid) {
+			result.Errors = invalid.Fields
+			report.Failed++
+		} else {
+			firstRow[row.Email] = i + 1
+			valid = append(valid, db.ImportUsersParams{Name: name, Email: row.Email})
+		}
+		report.Rows[i] = result
+	}
+	if len(valid) == 0 {
+		return report, nil
+	}
+	
+	var created []db.User
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		for start := 0; start < len(valid); start += importBatchSize {
+			batch := valid[start:min(start+importBatchSize, len(valid))]
+			if _, err := q.ImportUsers(ctx, batch); err != nil {
+				return err
+			}
+		}
+		
+		// A copy returns no rows, so the created users are read back for
+		// their IDs
+		imported := make([]string, len(valid))
+		for i, row := range valid {
+			imported[i] = row.Email
+		}
+		var err error
+		created, err = q.GetUsersByEmails(ctx, imported)
+		if err != nil {
+			return fmt.Errorf("failed to load imported users: %w", err)
+		}
+		for _, user := range created {
+			if err := recordAudit(ctx, q, "user.import", AuditEntityUser, user.ID, nil, user); err != nil {
+				return err
+			}
+			if err := publishEvent(ctx, q, EventUserCreated, newEventUser(user)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// A concurrent request may have taken one of the emails after our
+		// pre-check
+		if isDuplicateEmailError(err) {
+			return nil, ErrDuplicateEmail
+		}
+		return nil, fmt.Errorf("failed to import users: %w", err)
+	}
+	
+	ids := make(map[string]int32, len(created))
+	for _, user := range created {
+		ids[user.Email] = user.ID
+	}
+	for i := range report.Rows {
+		if report.Rows[i].Errors == nil {
+			report.Rows[i].UserID = ids[report.Rows[i].Email]
+			report.Imported++
+		}
+	}
+	return report, nil
+}
+
+// StartImportUsers starts an operation importing rows in the background, as
+// ImportUsers would, for the caller to poll; only admins may import users
+//
+// The rows are checked as ImportUsers checks them before anything is
+// started, but are only validated one by one once the import runs.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - rows: The users to create
+//
+// Returns:
+//   - *db.Operation: The pending operation, whose input is a UserImportInput
+//   - error: ErrForbidden, ErrInvalidInput if there are no rows,
+//     ErrTooManyImportRows, or database errors
+func (s *UserService) StartImportUsers(ctx context.Context, rows []ImportRow) (*db.Operation, error) {
+	ctx, span := tracer.Start(ctx, "UserService.StartImportUsers")
+	defer span.End()
+	
+	if err := checkImport(ctx, rows); err != nil {
+		return nil, err
+	}
+	return startOperation(ctx, s.queries, OperationUserImport, UserImportInput{Rows: rows}, len(rows))
+}
+
+// checkImport allows admins to import rows when there are some to import,
+// but not too many
+func checkImport(ctx context.Context, rows []ImportRow) error {
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("%w: no rows to import", ErrInvalidInput)
+	}
+	if len(rows) > MaxImportRows {
+		return ErrTooManyImportRows
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// importQueries is an in-memory users table for the ImportUsers tests
+func importQueries(existing ...string) (*MockQueries, *[]db.User) {
+	users := []db.User{}
+	for _, email := range existing {
+		users = append(users, db.User{ID: int32(len(users) + 1), Email: email})
+	}
+	mockQueries := &MockQueries{
+		GetUsersByEmailsFunc: func(ctx context.Context, emails []string) ([]db.User, error) {
+			var found []db.User
+			for _, user := range users {
+				if slices.Contains(emails, user.Email) {
+					found = append(found, user)
+				}
+			}
+			return found, nil
+		},
+		ImportUsersFunc: func(ctx context.Context, arg []db.ImportUsersParams) (int64, error) {
+			for _, row := range arg {
+				users = append(users, db.User{ID: int32(len(users) + 1), Name: row.Name, Email: row.Email})
+			}
+			return int64(len(arg)), nil
+		},
+	}
+	return mockQueries, &users
+}
+
+func TestImportUsers_ReportsEachRow(t *testing.T) {
+	mockQueries, users := importQueries("taken@example.com")
+	var audited []string
+	mockQueries.CreateAuditEventFunc = func(ctx context.Context, params db.CreateAuditEventParams) error {
+		audited = append(audited, params.Action)
+		return nil
+	}
+
+	service := NewUserService(mockQueries)
+	report, err := service.ImportUsers(asAdmin(), []ImportRow{
+		{Name: " Ada ", Email: "ada@example.com"},
+		{Name: "", Email: "not-an-email"},
+		{Name: "Taken", Email: "taken@example.com"},
+		{Name: "Ada Again", Email: "ada@example.com"},
+		{Name: "Grace", Email: "grace@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if report.Imported != 2 || report.Failed != 3 {
+		t.Errorf("expected 2 imported and 3 failed, got %d and %d", report.Imported, report.Failed)
+	}
+	expected := []struct {
+		userID int32
+		fields []string
+	}{
+		{2, nil},
+		{0, []string{"name", "email"}},
+		{0, []string{"email"}},
+		{0, []string{"email"}},
+		{3, nil},
+	}
+	for i, want := range expected {
+		got := report.Rows[i]
+		var fields []string
+		for _, field := range got.Errors {
+			fields = append(fields, field.Field)
+		}
+		if got.Row != i+1 || got.UserID != want.userID || !slices.Equal(fields, want.fields) {
+			t.Errorf("row %d: expected user %d with errors for %v, got %+v", i+1, want.userID, want.fields, got)
+		}
+	}
+	if msg := report.Rows[3].Errors[0].Message; msg != "is already used by row 1" {
+		t.Errorf("expected the repeated email to point at row 1, got %q", msg)
+	}
+	if (*users)[1].Name != "Ada" {
+		t.Errorf("expected the name to be trimmed, got %q", (*users)[1].Name)
+	}
+	if len(audited) != 2 || audited[0] != "user.import" {
+		t.Errorf("expected an audit event per imported user, got %v", audited)
+	}
+}
+
+func TestImportUsers_CopiesInBatches(t *testing.T) {
+	mockQueries, _ := importQueries()
+	var batches []int
+	copyUsers := mockQueries.ImportUsersFunc
+	mockQueries.ImportUsersFunc = func(ctx context.Context, arg []db.ImportUsersParams) (int64, error) {
+		batches = append(batches, len(arg))
+		return copyUsers(ctx, arg)
+	}
+	transactions := 0
+	mockQueries.WithTxFunc = func(ctx context.Context, fn func(q db.Querier) error) error {
+		transactions++
+		return fn(mockQueries)
+	}
+
+	rows := make([]ImportRow, importBatchSize+5)
+	for i := range rows {
+		rows[i] = ImportRow{Name: "Runner", Email: fmt.Sprintf("runner%d@example.com", i)}
+	}
+	report, err := NewUserService(mockQueries).ImportUsers(asAdmin(), rows)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Imported != len(rows) {
+		t.Errorf("expected %d imported, got %d", len(rows), report.Imported)
+	}
+	if !slices.Equal(batches, []int{importBatchSize, 5}) || transactions != 1 {
+		t.Errorf("expected two batches in one transaction, got %v in %d", batches, transactions)
+	}
+}
+
+func TestImportUsers_Errors(t *testing.T) {
+	tooMany := make([]ImportRow, MaxImportRows+1)
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		rows     []ImportRow
+		expected error
+	}{
+		{"not admin", asUser(1), []ImportRow{{Name: "Ada", Email: "ada@example.com"}}, ErrForbidden},
+		{"no rows", asAdmin(), nil, ErrInvalidInput},
+		{"too many rows", asAdmin(), tooMany, ErrTooManyImportRows},
+	}
+	for _, tt := range tests {
+		mockQueries, _ := importQueries()
+		mockQueries.ImportUsersFunc = func(ctx context.Context, arg []db.ImportUsersParams) (int64, error) {
+			t.Errorf("%s: expected nothing to be imported", tt.name)
+			return 0, nil
+		}
+		_, err := NewUserService(mockQueries).ImportUsers(tt.ctx, tt.rows)
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
+
+func TestStartImportUsers_CreatesOperation(t *testing.T) {
+	var params db.CreateOperationParams
+	mockQueries := &MockQueries{
+		CreateOperationFunc: func(ctx context.Context, p db.CreateOperationParams) (db.Operation, error) {
+			params = p
+			return db.Operation{Kind: p.Kind, CreatedBy: p.CreatedBy, Status: "pending"}, nil
+		},
+		ImportUsersFunc: func(ctx context.Context, arg []db.ImportUsersParams) (int64, error) {
+			t.Error("expected the users to be imported later, by a worker")
+			return 0, nil
+		},
+	}
+	rows := []ImportRow{{Name: "Ada", Email: "ada@example.com"}, {Name: "Grace", Email: "grace@example.com"}}
+
+	op, err := NewUserService(mockQueries).StartImportUsers(asAdmin(), rows)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if op.Kind != OperationUserImport || params.CreatedBy != 100 {
+		t.Errorf("expected an import started by the admin, got %+v", params)
+	}
+	if !params.Total.Valid || params.Total.Int32 != 2 {
+		t.Errorf("expected a total of 2 rows, got %+v", params.Total)
+	}
+	var input UserImportInput
+	if err := json.Unmarshal(params.Input, &input); err != nil || !slices.Equal(input.Rows, rows) {
+		t.Errorf("expected the rows as input, got %s (%v)", params.Input, err)
+	}
+}
+
+func TestStartImportUsers_Errors(t *testing.T) {
+	rows := []ImportRow{{Name: "Ada", Email: "ada@example.com"}}
+	viaKey := auth.WithPrincipal(context.Background(), auth.Principal{UserID: 100, APIKeyID: 5, Grants: []auth.Grant{{Role: auth.RoleAdmin}}})
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		rows     []ImportRow
+		expected error
+	}{
+		{"not admin", asUser(1), rows, ErrForbidden},
+		{"api key", viaKey, rows, ErrForbidden},
+		{"no rows", asAdmin(), nil, ErrInvalidInput},
+		{"too many rows", asAdmin(), make([]ImportRow, MaxImportRows+1), ErrTooManyImportRows},
+	}
+	for _, tt := range tests {
+		mockQueries := &MockQueries{
+			CreateOperationFunc: func(ctx context.Context, p db.CreateOperationParams) (db.Operation, error) {
+				t.Errorf("%s: expected no operation to be created", tt.name)
+				return db.Operation{}, nil
+			},
+		}
+		_, err := NewUserService(mockQueries).StartImportUsers(tt.ctx, tt.rows)
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
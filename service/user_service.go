@@ -5,9 +5,22 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/cache"
 	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/mailer"
+	"github.com/example/speedrun-rest-api/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
 var (
@@ -19,22 +32,239 @@ var (
 	
 	// ErrInvalidInput is returned when input validation fails
 	ErrInvalidInput = errors.New("invalid input")
+	
+	// ErrUserActive is returned when purging or restoring a user that has not
+	// been soft-deleted
+	ErrUserActive = errors.New("user is still active")
+	
+	// ErrTooManyIDs is returned when a batch lookup exceeds the configured maximum
+	ErrTooManyIDs = errors.New("too many ids requested")
+	
+	// ErrVersionMismatch is returned when a user has changed since the
+	// version an update was based on
+	ErrVersionMismatch = errors.New("user has been modified")
+	
+	// ErrInvalidVerificationToken is returned when an email verification
+	// token is malformed, expired, or was sent to an address the user no
+	// longer has
+	ErrInvalidVerificationToken = errors.New("invalid email verification token")
+	
+	// ErrRoleAlreadyGranted is returned when granting a user a role they
+	// already have
+	ErrRoleAlreadyGranted = errors.New("user already has this role")
 )
 
+const (
+	// uniqueViolation is the PostgreSQL SQLSTATE for unique constraint violations
+	uniqueViolation = "23505"
+	
+	// foreignKeyViolation is the PostgreSQL SQLSTATE for foreign key violations
+	foreignKeyViolation = "23503"
+	
+	// usersEmailKey is the unique constraint on users.email
+	usersEmailKey = "users_email_key"
+)
+
+// defaultMaxBatchSize is used when no batch limit is configured
+const defaultMaxBatchSize = 100
+
+// defaultCorporateDomains are used when no corporate domains are configured
+var defaultCorporateDomains = []string{"company.com", "enterprise.com"}
+
+// defaultVerificationTTL is used when no verification token lifetime is configured
+const defaultVerificationTTL = 48 * time.Hour
+
 // UserService handles business logic for user operations
 type UserService struct {
-	queries db.Querier
+	queries          db.Store
+	maxBatchSize     int
+	maxNameLength    int
+	corporateDomains []string
+	pages            pageSizes
+	passwordCost     int
+	
+	// verifier issues the tokens sent in verification emails; without one
+	// no verification emails are sent and VerifyEmail rejects every token
+	verifier        *auth.Signer
+	verificationTTL time.Duration
+	mailer          mailer.Mailer
+	
+	// userLookups coalesces concurrent GetUserByID calls for the same ID
+	userLookups singleflight.Group
+	
+	// cache holds users read by GetUserByID; nil disables caching
+	cache *cache.Cache
+	
+	// avatars keeps uploaded avatars; nil disables avatar uploads
+	avatars    storage.Store
+	avatarSize int
+}
+
+// ListUsersFilter narrows and orders the users returned by ListUsers
+// A nil or empty field applies no filtering for that attribute.
+type ListUsersFilter struct {
+	// Corporate restricts results to corporate (true) or non-corporate (false) accounts
+	Corporate *bool `json:"corporate,omitempty"`
+	
+	// Name restricts results to users whose name contains it, ignoring case
+	Name string `json:"name,omitempty"`
+	
+	// EmailDomain restricts results to emails at this domain, e.g. "company.com"
+	EmailDomain string `json:"email_domain,omitempty"`
+	
+	// Sort is a column from userSortColumns with an optional direction, e.g.
+	// "created_at:desc"; empty sorts by ID
+	Sort string `json:"sort,omitempty"`
+	
+	// IncludeDeleted also returns soft-deleted users; only admins may set it
+	IncludeDeleted bool `json:"include_deleted,omitempty"`
+}
+
+// ProfileUpdate holds changes to a user's optional public profile details
+// A nil field leaves that detail unchanged; an empty one clears it.
+type ProfileUpdate struct {
+	// TwitchHandle, YoutubeHandle, and TwitterHandle are the user's names on
+	// those sites, with or without a leading @
+	TwitchHandle  *string
+	YoutubeHandle *string
+	TwitterHandle *string
+	
+	// CountryCode is an ISO 3166-1 alpha-2 code such as "SE"
+	CountryCode *string
+	
+	Pronouns *string
+	Bio      *string
+}
+
+// UserStats summarizes the user base for dashboards
+type UserStats struct {
+	Total        int64
+	Corporate    int64
+	NonCorporate int64
+	NewLast24h   int64
+	NewLast7d    int64
+	NewLast30d   int64
+}
+
+// Option configures optional UserService behavior
+type Option func(*UserService)
+
+// WithMaxBatchSize sets the maximum number of IDs accepted by GetUsersByIDs
+func WithMaxBatchSize(n int) Option {
+	return func(s *UserService) {
+		if n > 0 {
+			s.maxBatchSize = n
+		}
+	}
+}
+
+// WithMaxNameLength sets the maximum number of characters allowed in a name
+func WithMaxNameLength(n int) Option {
+	return func(s *UserService) {
+		if n > 0 {
+			s.maxNameLength = n
+		}
+	}
+}
+
+// WithCorporateDomains sets the email domains (e.g. "company.com") that
+// identify corporate accounts
+func WithCorporateDomains(domains []string) Option {
+	return func(s *UserService) {
+		if len(domains) > 0 {
+			s.corporateDomains = domains
+		}
+	}
+}
+
+// WithPageSizes sets the limit applied when a list request omits one and the
+// largest limit a list request may ask for
+func WithPageSizes(defaultSize, maxSize int) Option {
+	return func(s *UserService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// WithPasswordCost sets the bcrypt cost used to hash new passwords
+// Costs outside bcrypt's supported range are ignored.
+func WithPasswordCost(cost int) Option {
+	return func(s *UserService) {
+		if cost >= bcrypt.MinCost && cost <= bcrypt.MaxCost {
+			s.passwordCost = cost
+		}
+	}
+}
+
+// WithEmailVerification sends new and changed email addresses a token
+// issued by signer, valid for ttl, that VerifyEmail accepts
+func WithEmailVerification(signer *auth.Signer, ttl time.Duration) Option {
+	return func(s *UserService) {
+		s.verifier = signer
+		if ttl > 0 {
+			s.verificationTTL = ttl
+		}
+	}
+}
+
+// WithMailer sets how verification and welcome emails are delivered; by
+// default they are only logged
+func WithMailer(m mailer.Mailer) Option {
+	return func(s *UserService) {
+		if m != nil {
+			s.mailer = m
+		}
+	}
+}
+
+// WithCache caches the users GetUserByID returns in c; changes to a user
+// invalidate its entry, and those that show on leaderboards invalidate the
+// cached leaderboards
+func WithCache(c *cache.Cache) Option {
+	return func(s *UserService) {
+		s.cache = c
+	}
+}
+
+// WithAvatarStore keeps the avatars SetAvatar accepts in store; without one
+// avatar uploads are disabled
+func WithAvatarStore(store storage.Store) Option {
+	return func(s *UserService) {
+		s.avatars = store
+	}
+}
+
+// WithAvatarSize sets the width and height, in pixels, avatars are resized to
+func WithAvatarSize(size int) Option {
+	return func(s *UserService) {
+		if size > 0 {
+			s.avatarSize = size
+		}
+	}
 }
 
 // NewUserService creates a new UserService instance
-func NewUserService(queries db.Querier) *UserService {
-	return &UserService{
-		queries: queries,
+func NewUserService(queries db.Store, opts ...Option) *UserService {
+	s := &UserService{
+		queries:          queries,
+		maxBatchSize:     defaultMaxBatchSize,
+		maxNameLength:    defaultMaxNameLength,
+		corporateDomains: defaultCorporateDomains,
+		pages:            defaultPageSizes,
+		passwordCost:     bcrypt.DefaultCost,
+		verificationTTL:  defaultVerificationTTL,
+		mailer:           mailer.NewLogMailer(slog.Default()),
+		avatarSize:       defaultAvatarSize,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetUserByID retrieves a user by their ID
 //
+// Users are served from the cache when WithCache configured one.
+//
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //   - id: The user's unique identifier
@@ -43,7 +273,15 @@ func NewUserService(queries db.Querier) *UserService {
 //   - *db.User: The user object if found
 //   - error: ErrUserNotFound if user doesn't exist, or database errors
 func (s *UserService) GetUserByID(ctx context.Context, id int32) (*db.User, error) {
-	user, err := s.queries.GetUserByID(ctx, id)
+	ctx, span := tracer.Start(ctx, "UserService.GetUserByID")
+	defer span.End()
+	
+	var user db.User
+	if s.cache.Get(ctx, cacheUsers, userCacheKey(id), &user) {
+		return &user, nil
+	}
+	
+	user, err := s.getUserByIDShared(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrUserNotFound
@@ -54,38 +292,393 @@ func (s *UserService) GetUserByID(ctx context.Context, id int32) (*db.User, erro
 	return &user, nil
 }
 
-// ListUsers retrieves a paginated list of users
+// GetUserByPublicID retrieves a user by their public identifier
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
-//   - limit: Maximum number of users to return
-//   - offset: Number of users to skip
+//   - publicID: The user's externally visible UUID
 //
 // Returns:
-//   - []db.User: List of users
-//   - int64: Total count of users
-//   - error: Database errors if any
-func (s *UserService) ListUsers(ctx context.Context, limit, offset int32) ([]db.User, int64, error) {
-	users, err := s.queries.ListUsers(ctx, db.ListUsersParams{
-		Limit:  limit,
-		Offset: offset,
+//   - *db.User: The user object if found
+//   - error: ErrUserNotFound if user doesn't exist, or database errors
+func (s *UserService) GetUserByPublicID(ctx context.Context, publicID uuid.UUID) (*db.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserByPublicID")
+	defer span.End()
+	
+	user, err := s.queries.GetUserByPublicID(ctx, pgtype.UUID{Bytes: publicID, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	return &user, nil
+}
+
+// getUserByIDShared loads a user and caches it, sharing a single database
+// query across concurrent callers asking for the same ID
+func (s *UserService) getUserByIDShared(ctx context.Context, id int32) (db.User, error) {
+	return coalesce(ctx, &s.userLookups, strconv.Itoa(int(id)), func(ctx context.Context) (db.User, error) {
+		ctx = cacheFillContext(ctx, s.cache)
+		user, err := s.queries.GetUserByID(ctx, id)
+		if err == nil {
+			s.cache.Set(ctx, userCacheKey(id), user)
+		}
+		return user, err
 	})
+}
+
+// GetUsersByIDs retrieves several users with a single query
+//
+// Duplicate IDs are collapsed. Found users are returned in the order their
+// IDs were first requested, so callers get a deterministic response
+// regardless of how the database orders rows.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - ids: The user IDs to look up
+//
+// Returns:
+//   - []db.User: Users that were found, in request order
+//   - []int32: Requested IDs that did not match any user, in request order
+//   - error: ErrInvalidInput if no IDs are given, ErrTooManyIDs if the batch
+//     limit is exceeded, or database errors
+func (s *UserService) GetUsersByIDs(ctx context.Context, ids []int32) ([]db.User, []int32, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUsersByIDs")
+	defer span.End()
+	
+	// De-duplicate while keeping the caller's ordering
+	seen := make(map[int32]struct{}, len(ids))
+	unique := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	
+	if len(unique) == 0 {
+		return nil, nil, ErrInvalidInput
+	}
+	if len(unique) > s.maxBatchSize {
+		return nil, nil, ErrTooManyIDs
+	}
+	
+	rows, err := s.queries.GetUsersByIDs(ctx, unique)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	
+	byID := make(map[int32]db.User, len(rows))
+	for _, user := range rows {
+		byID[user.ID] = user
+	}
+	
+	users := make([]db.User, 0, len(byID))
+	missing := make([]int32, 0)
+	for _, id := range unique {
+		if user, ok := byID[id]; ok {
+			users = append(users, user)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	
+	return users, missing, nil
+}
+
+// ListUsers retrieves a filtered, sorted, paginated list of users
+//
+// Filtering and sorting happen in SQL so the total count always matches the
+// filtered result set, regardless of pagination. Sort columns are checked
+// against userSortColumns before they reach the query. Limit and offset are taken as the
+// client sent them and normalized here, so every caller shares one policy.
+// One row past the page is fetched to tell whether a next page exists. An
+// offset page and its total come back from one query; a cursor page is
+// counted concurrently, so either costs a single round trip of latency.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - page: Requested page size and either an offset or a cursor
+//   - filter: Optional criteria to narrow the results
+//
+// Returns:
+//   - *UserPage: The users, total count matching the filter, the
+//     effective limit and offset, and the cursor of the next page
+//   - error: ErrInvalidInput for a bad sort or page request,
+//     ErrInvalidCursor, ErrForbidden if a non-admin asks for deleted users
+//     or sorts or filters by email, or database errors
+func (s *UserService) ListUsers(ctx context.Context, page PageRequest, filter ListUsersFilter) (*UserPage, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListUsers")
+	defer span.End()
+	
+	pageLimit, pageOffset, err := s.pages.apply(page)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+		return nil, err
 	}
 	
-	count, err := s.queries.CountUsers(ctx)
+	order, err := parseSort(filter.Sort, userSortColumns)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+		return nil, err
+	}
+	// Only admins see other users' email addresses, so only they may sort by
+	// them, which puts the last one in the cursor, or filter by their domain
+	if filter.IncludeDeleted || order.Column == "email" || strings.TrimSpace(filter.EmailDomain) != "" {
+		if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+	// The cursor names the order it was issued for, so it cannot be replayed
+	// against a different sort
+	list := "users:" + order.String()
+	
+	corporate, name, emailDomain := userFilterArgs(filter)
+	countParams := db.CountUsersParams{
+		Corporate:        corporate,
+		CorporateDomains: s.corporateDomains,
+		Name:             name,
+		EmailDomain:      emailDomain,
+		IncludeDeleted:   filter.IncludeDeleted,
 	}
+	var users []db.User
+	var count int64
+	if page.Cursor == "" {
+		// The page carries the total, so only a page past the end needs
+		// counting separately
+		rows, err := s.queries.ListUsers(ctx, db.ListUsersParams{
+			Corporate:        corporate,
+			CorporateDomains: s.corporateDomains,
+			Name:             name,
+			EmailDomain:      emailDomain,
+			IncludeDeleted:   filter.IncludeDeleted,
+			Sort:             order.Column,
+			Descending:       order.Descending,
+			Limit:            pageLimit + 1,
+			Offset:           pageOffset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		users = make([]db.User, len(rows))
+		for i, row := range rows {
+			users[i] = row.User
+		}
+		if len(rows) > 0 {
+			count = rows[0].Total
+		} else if pageOffset > 0 {
+			if count, err = s.queries.CountUsers(ctx, countParams); err != nil {
+				return nil, fmt.Errorf("failed to count users: %w", err)
+			}
+		}
+	} else {
+		params := db.ListUsersAfterParams{
+			Corporate:        corporate,
+			CorporateDomains: s.corporateDomains,
+			Name:             name,
+			EmailDomain:      emailDomain,
+			IncludeDeleted:   filter.IncludeDeleted,
+			Sort:             order.Column,
+			Descending:       order.Descending,
+			Limit:            pageLimit + 1,
+		}
+		if err := decodeUserCursor(page.Cursor, list, order, &params); err != nil {
+			return nil, err
+		}
+		
+		// A keyset page only sees the rows after the cursor, so the total is
+		// counted alongside it rather than after it
+		g, gctx := errgroup.WithContext(ctx)
+		g.Go(func() error {
+			var err error
+			if users, err = s.queries.ListUsersAfter(gctx, params); err != nil {
+				return fmt.Errorf("failed to list users: %w", err)
+			}
+			return nil
+		})
+		g.Go(func() error {
+			var err error
+			if count, err = s.queries.CountUsers(gctx, countParams); err != nil {
+				return fmt.Errorf("failed to count users: %w", err)
+			}
+			return nil
+		})
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+	}
+	users, more := trimPage(users, pageLimit)
 	
-	return users, count, nil
+	result := &UserPage{
+		Users:  users,
+		Total:  count,
+		Limit:  pageLimit,
+		Offset: pageOffset,
+	}
+	if more {
+		result.NextCursor = encodeUserCursor(list, order, &users[len(users)-1])
+	}
+	return result, nil
+}
+
+// ExportUsers calls fn with every user matching filter, in its sort order,
+// as the users are read from the database, for exports too large to page
+// through
+//
+// fn's first error stops the export and is returned.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - filter: Optional criteria to narrow the results
+//   - fn: Called with each user in turn
+//
+// Returns:
+//   - error: ErrInvalidInput for a bad sort, ErrForbidden unless the caller
+//     is an admin, fn's error, or database errors
+func (s *UserService) ExportUsers(ctx context.Context, filter ListUsersFilter, fn func(db.User) error) error {
+	ctx, span := tracer.Start(ctx, "UserService.ExportUsers")
+	defer span.End()
+	
+	// Exports hold every user's email address, so only admins may make them
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return err
+	}
+	order, err := parseSort(filter.Sort, userSortColumns)
+	if err != nil {
+		return err
+	}
+	
+	corporate, name, emailDomain := userFilterArgs(filter)
+	err = s.queries.ExportUsers(ctx, db.ExportUsersParams{
+		Corporate:        corporate,
+		CorporateDomains: s.corporateDomains,
+		Name:             name,
+		EmailDomain:      emailDomain,
+		IncludeDeleted:   filter.IncludeDeleted,
+		Sort:             order.Column,
+		Descending:       order.Descending,
+	}, fn)
+	if err != nil {
+		return fmt.Errorf("failed to export users: %w", err)
+	}
+	return nil
+}
+
+// StartExportUsers starts an operation exporting, in format, every user
+// matching filter in the background, as ExportUsers would, for the caller to
+// poll
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - filter: Optional criteria to narrow the results
+//   - format: The media type to write the export in
+//
+// Returns:
+//   - *db.Operation: The pending operation, whose input is a
+//     UserExportInput
+//   - error: ErrInvalidInput for a bad sort, ErrForbidden unless the caller
+//     is an admin, or database errors
+func (s *UserService) StartExportUsers(ctx context.Context, filter ListUsersFilter, format string) (*db.Operation, error) {
+	ctx, span := tracer.Start(ctx, "UserService.StartExportUsers")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := parseSort(filter.Sort, userSortColumns); err != nil {
+		return nil, err
+	}
+	return startOperation(ctx, s.queries, OperationUserExport, UserExportInput{Filter: filter, Format: format}, -1)
+}
+
+// userFilterArgs converts the filters of filter to query arguments
+// Names are matched as substrings, and email domains without a leading @ or
+// regard to case.
+func userFilterArgs(filter ListUsersFilter) (corporate pgtype.Bool, name, emailDomain pgtype.Text) {
+	if filter.Corporate != nil {
+		corporate = pgtype.Bool{Bool: *filter.Corporate, Valid: true}
+	}
+	if n := strings.TrimSpace(filter.Name); n != "" {
+		name = pgtype.Text{String: escapeLike(n), Valid: true}
+	}
+	if d := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(filter.EmailDomain), "@")); d != "" {
+		emailDomain = pgtype.Text{String: d, Valid: true}
+	}
+	return corporate, name, emailDomain
+}
+
+// encodeUserCursor returns a cursor continuing after user in the given order
+// The cursor carries the sort column's value followed by the ID tiebreaker.
+func encodeUserCursor(list string, order SortOrder, user *db.User) string {
+	switch order.Column {
+	case "name":
+		return encodeCursor(list, user.Name, user.ID)
+	case "email":
+		return encodeCursor(list, user.Email, user.ID)
+	case "created_at":
+		return encodeCursor(list, user.CreatedAt.Time, user.ID)
+	case "updated_at":
+		return encodeCursor(list, user.UpdatedAt.Time, user.ID)
+	default:
+		return encodeCursor(list, user.ID)
+	}
+}
+
+// decodeUserCursor reads a cursor made by encodeUserCursor into the keyset
+// fields of params
+func decodeUserCursor(cursor, list string, order SortOrder, params *db.ListUsersAfterParams) error {
+	switch order.Column {
+	case "name", "email":
+		return decodeCursor(cursor, list, &params.AfterText, &params.AfterID)
+	case "created_at", "updated_at":
+		var after time.Time
+		if err := decodeCursor(cursor, list, &after, &params.AfterID); err != nil {
+			return err
+		}
+		params.AfterTime = pgtype.Timestamptz{Time: after, Valid: true}
+		return nil
+	default:
+		return decodeCursor(cursor, list, &params.AfterID)
+	}
+}
+
+// GetUserStats computes aggregate user counts
+//
+// All counts come from a single query with conditional aggregates, so no
+// rows are loaded. The time windows compare the timestamptz created_at
+// column against NOW(), which is independent of the session time zone.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - *UserStats: Totals, corporate split, and new-user counts
+//   - error: Database errors if any
+func (s *UserService) GetUserStats(ctx context.Context) (*UserStats, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserStats")
+	defer span.End()
+	
+	row, err := s.queries.GetUserStats(ctx, s.corporateDomains)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user stats: %w", err)
+	}
+	
+	return &UserStats{
+		Total:        row.Total,
+		Corporate:    row.Corporate,
+		NonCorporate: row.Total - row.Corporate,
+		NewLast24h:   row.Last24h,
+		NewLast7d:    row.Last7d,
+		NewLast30d:   row.Last30d,
+	}, nil
 }
 
 // CreateUser creates a new user after performing validation and duplicate checks
 //
 // This is where business logic lives. We check for duplicate emails,
-// validate input, and potentially call external services.
+// validate input, and potentially call external services. The user starts
+// out unverified and is emailed a token to pass to VerifyEmail.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
@@ -96,9 +689,13 @@ func (s *UserService) ListUsers(ctx context.Context, limit, offset int32) ([]db.
 //   - *db.User: The created user object
 //   - error: ErrDuplicateEmail, ErrInvalidInput, or database errors
 func (s *UserService) CreateUser(ctx context.Context, name, email string) (*db.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+	
 	// Validate input
-	if name == "" || email == "" {
-		return nil, ErrInvalidInput
+	name, err := s.validateName(name)
+	if err := joinValidation(err, validateEmail(email)); err != nil {
+		return nil, err
 	}
 	
 	// Check for duplicate email
@@ -111,11 +708,9 @@ func (s *UserService) CreateUser(ctx context.Context, name, email string) (*db.U
 	}
 	
 	// Business Logic: In a real application, you might:
-	// - Call an email verification service
 	// - Check against a blocklist
 	// - Apply business rules (e.g., require approval for certain domains)
 	// - Send a welcome email
-	// - Log to an audit trail
 	
 	// For demo purposes, we'll add a simple business rule:
 	// Users with corporate emails get special handling
@@ -125,29 +720,141 @@ func (s *UserService) CreateUser(ctx context.Context, name, email string) (*db.U
 	}
 	
 	// Create the user
-	user, err := s.queries.CreateUser(ctx, db.CreateUserParams{
-		Name:  name,
-		Email: email,
+	var user db.User
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		user, err = q.CreateUser(ctx, db.CreateUserParams{
+			Name:  name,
+			Email: email,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, q, "user.create", AuditEntityUser, user.ID, nil, user); err != nil {
+			return err
+		}
+		return publishEvent(ctx, q, EventUserCreated, newEventUser(user))
 	})
 	if err != nil {
+		// A concurrent request may have inserted the same email after our
+		// pre-check; the unique constraint is the final word
+		if isDuplicateEmailError(err) {
+			return nil, ErrDuplicateEmail
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 	
+	s.sendVerificationEmail(ctx, &user)
+	return &user, nil
+}
+
+// Register creates a user who can log in with the given password
+//
+// The password is hashed with bcrypt before it is stored, and the hash never
+// leaves the database: it is kept in user_credentials, which no user query
+// reads. Like CreateUser, the user is emailed a verification token.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - name: User's full name
+//   - email: User's email address
+//   - password: The password to log in with
+//
+// Returns:
+//   - *db.User: The created user object
+//   - error: ErrDuplicateEmail, ErrInvalidInput, or database errors
+func (s *UserService) Register(ctx context.Context, name, email, password string) (*db.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Register")
+	defer span.End()
+	
+	name, err := s.validateName(name)
+	if err := joinValidation(err, validateEmail(email), validatePassword(password)); err != nil {
+		return nil, err
+	}
+	
+	existing, err := s.queries.GetUserByEmail(ctx, email)
+	if err == nil && existing.ID != 0 {
+		return nil, ErrDuplicateEmail
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check for duplicate email: %w", err)
+	}
+	
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.passwordCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	
+	var user db.User
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		user, err = q.CreateUserWithPassword(ctx, db.CreateUserWithPasswordParams{
+			Name:         name,
+			Email:        email,
+			PasswordHash: string(hash),
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, q, "user.register", AuditEntityUser, user.ID, nil, user); err != nil {
+			return err
+		}
+		return publishEvent(ctx, q, EventUserCreated, newEventUser(user))
+	})
+	if err != nil {
+		if isDuplicateEmailError(err) {
+			return nil, ErrDuplicateEmail
+		}
+		return nil, fmt.Errorf("failed to register user: %w", err)
+	}
+	
+	s.sendVerificationEmail(ctx, &user)
 	return &user, nil
 }
 
 // UpdateUser updates an existing user's information
 //
+// Users may update their own account; only admins may update someone else's.
+// The update only applies if the user is still at the given version, so two
+// editors working from the same copy can't silently overwrite each other. A
+// changed email address is unverified until the token mailed to it is passed
+// to VerifyEmail.
+//
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //   - id: User ID to update
 //   - name: New name (optional, empty string means no change)
 //   - email: New email (optional, empty string means no change)
+//   - profile: Changes to the user's public profile details
+//   - version: The version the update is based on, or 0 for whichever
+//     version is current
 //
 // Returns:
 //   - *db.User: The updated user object
-//   - error: ErrUserNotFound, ErrDuplicateEmail, or database errors
-func (s *UserService) UpdateUser(ctx context.Context, id int32, name, email string) (*db.User, error) {
+//   - error: ErrForbidden, ErrUserNotFound, ErrVersionMismatch,
+//     ErrDuplicateEmail, ErrInvalidInput, or database errors
+func (s *UserService) UpdateUser(ctx context.Context, id int32, name, email string, profile ProfileUpdate, version int32) (*db.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+	
+	if err := requireSelfOrAdmin(ctx, id); err != nil {
+		return nil, err
+	}
+	
+	// Validate the name and email when they are provided; "   " is rejected
+	// rather than being treated as "no change"
+	var nameErr, emailErr error
+	if name != "" {
+		name, nameErr = s.validateName(name)
+	}
+	if email != "" {
+		emailErr = validateEmail(email)
+	}
+	profile, profileErr := validateProfileUpdate(profile)
+	if err := joinValidation(nameErr, emailErr, profileErr); err != nil {
+		return nil, err
+	}
+	
 	// First, verify the user exists
 	existing, err := s.queries.GetUserByID(ctx, id)
 	if err != nil {
@@ -156,6 +863,11 @@ func (s *UserService) UpdateUser(ctx context.Context, id int32, name, email stri
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
+	if version == 0 {
+		version = existing.Version
+	} else if version != existing.Version {
+		return nil, ErrVersionMismatch
+	}
 	
 	// Use existing values if not provided
 	if name == "" {
@@ -165,7 +877,8 @@ func (s *UserService) UpdateUser(ctx context.Context, id int32, name, email stri
 		email = existing.Email
 	}
 	
-	// Check for duplicate email if email is changing
+	// Check for duplicate email if email is changing; keeping the current
+	// email can never conflict with another user
 	if email != existing.Email {
 		duplicate, err := s.queries.GetUserByEmail(ctx, email)
 		if err == nil && duplicate.ID != 0 && duplicate.ID != id {
@@ -177,29 +890,272 @@ func (s *UserService) UpdateUser(ctx context.Context, id int32, name, email stri
 	}
 	
 	// Update the user
-	user, err := s.queries.UpdateUser(ctx, db.UpdateUserParams{
-		ID:    id,
-		Name:  name,
-		Email: email,
+	var user db.User
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		user, err = q.UpdateUser(ctx, db.UpdateUserParams{
+			ID:            id,
+			Name:          name,
+			Email:         email,
+			Version:       version,
+			TwitchHandle:  profileText(profile.TwitchHandle, existing.TwitchHandle),
+			YoutubeHandle: profileText(profile.YoutubeHandle, existing.YoutubeHandle),
+			TwitterHandle: profileText(profile.TwitterHandle, existing.TwitterHandle),
+			CountryCode:   profileText(profile.CountryCode, existing.CountryCode),
+			Pronouns:      profileText(profile.Pronouns, existing.Pronouns),
+			Bio:           profileText(profile.Bio, existing.Bio),
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "user.update", AuditEntityUser, id, existing, user)
 	})
 	if err != nil {
+		// The user was changed or deleted after it was read above
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVersionMismatch
+		}
+		// Another user may have claimed the email after our pre-check
+		if isDuplicateEmailError(err) {
+			return nil, ErrDuplicateEmail
+		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 	
+	s.cache.Delete(ctx, userCacheKey(id))
+	if user.Name != existing.Name {
+		s.cache.Bump(ctx, leaderboardRunnersKey)
+	}
+	if user.Email != existing.Email {
+		s.sendVerificationEmail(ctx, &user)
+	}
 	return &user, nil
 }
 
-// DeleteUser deletes a user by their ID
+// VerifyEmail marks a user's email address as verified
+//
+// The token names both the user and the address it was mailed to, so it
+// stops working once the user changes their email. Verifying an address
+// that is already verified returns the user unchanged.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - token: The token from the verification email
+//
+// Returns:
+//   - *db.User: The verified user
+//   - error: ErrInvalidVerificationToken, or database errors
+func (s *UserService) VerifyEmail(ctx context.Context, token string) (*db.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.VerifyEmail")
+	defer span.End()
+	
+	if s.verifier == nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	id, email, err := s.verifier.VerifyEmailVerification(token)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	
+	var user db.User
+	verified := false
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		existing, err := q.GetUserByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidVerificationToken
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if existing.Email != email {
+			return ErrInvalidVerificationToken
+		}
+		if existing.EmailVerifiedAt.Valid {
+			user = existing
+			return nil
+		}
+		
+		// The query re-checks the email, so an address changed in the
+		// meantime is never verified
+		user, err = q.VerifyUserEmail(ctx, db.VerifyUserEmailParams{ID: id, Email: email})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidVerificationToken
+			}
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+		
+		verified = true
+		return recordAudit(ctx, q, "user.verify_email", AuditEntityUser, id, existing, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if verified {
+		s.cache.Delete(ctx, userCacheKey(id))
+		sendEmail(ctx, s.mailer, mailer.TemplateWelcome, user.Email, mailer.WelcomeData{Name: user.Name})
+	}
+	return &user, nil
+}
+
+// sendVerificationEmail mails user a token to pass to VerifyEmail
+// Failures are logged rather than returned, since the change that prompted
+// the email has already been committed.
+func (s *UserService) sendVerificationEmail(ctx context.Context, user *db.User) {
+	if s.verifier == nil {
+		return
+	}
+	
+	token, err := s.verifier.IssueEmailVerification(user.ID, user.Email, s.verificationTTL)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to issue email verification token", "user_id", user.ID, "error", err)
+		return
+	}
+	sendEmail(ctx, s.mailer, mailer.TemplateVerification, user.Email, mailer.VerificationData{
+		Name:      user.Name,
+		Token:     token,
+		ExpiresIn: s.verificationTTL,
+	})
+}
+
+// sendEmail renders the email template name for to and sends it through m
+//
+// Emails are sent after the change they are about has been committed, so a
+// failure is logged rather than undoing or failing the request.
+func sendEmail(ctx context.Context, m mailer.Mailer, name, to string, data any) {
+	msg, err := mailer.Render(name, to, data)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to render email", "template", name, "error", err)
+		return
+	}
+	if err := m.Send(ctx, msg); err != nil {
+		slog.ErrorContext(ctx, "Failed to send email", "template", name, "error", err)
+	}
+}
+
+// DeleteUser soft-deletes a user by their ID
+//
+// The user disappears from every lookup and listing, can no longer log in,
+// and their API keys and refresh tokens stop working, but nothing is erased:
+// RestoreUser brings the account back and PurgeUser removes it for good.
+// Users may delete their own account; only admins may delete someone else's.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //   - id: User ID to delete
 //
 // Returns:
-//   - error: ErrUserNotFound if user doesn't exist, or database errors
+//   - error: ErrForbidden, ErrUserNotFound if the user doesn't exist or is
+//     already deleted, or database errors
 func (s *UserService) DeleteUser(ctx context.Context, id int32) error {
-	// First verify the user exists
-	_, err := s.queries.GetUserByID(ctx, id)
+	ctx, span := tracer.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+	
+	if err := requireSelfOrAdmin(ctx, id); err != nil {
+		return err
+	}
+	
+	err := s.queries.WithTx(ctx, func(q db.Querier) error {
+		user, err := q.GetUserByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		
+		deleted, err := q.DeleteUser(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+		if deleted == 0 {
+			return ErrUserNotFound
+		}
+		
+		return recordAudit(ctx, q, "user.delete", AuditEntityUser, id, user, nil)
+	})
+	if err != nil {
+		return err
+	}
+	
+	s.cache.Delete(ctx, userCacheKey(id))
+	s.cache.Bump(ctx, leaderboardRunnersKey)
+	return nil
+}
+
+// RestoreUser undoes a soft delete; only admins may restore users
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - id: User ID to restore
+//
+// Returns:
+//   - *db.User: The restored user
+//   - error: ErrForbidden, ErrUserNotFound if user doesn't exist, ErrUserActive
+//     if the user has not been soft-deleted, or database errors
+func (s *UserService) RestoreUser(ctx context.Context, id int32) (*db.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.RestoreUser")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	
+	var user db.User
+	err := s.queries.WithTx(ctx, func(q db.Querier) error {
+		existing, err := q.GetUserByIDIncludingDeleted(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if !existing.DeletedAt.Valid {
+			return ErrUserActive
+		}
+		
+		// The query re-checks deleted_at, so a user restored in the meantime
+		// is reported as active
+		user, err = q.RestoreUser(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrUserActive
+			}
+			return fmt.Errorf("failed to restore user: %w", err)
+		}
+		
+		return recordAudit(ctx, q, "user.restore", AuditEntityUser, id, existing, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	s.cache.Bump(ctx, leaderboardRunnersKey)
+	return &user, nil
+}
+
+// PurgeUser permanently removes a user that has already been soft-deleted
+//
+// Purging is the compliance path for erasing data, so it refuses to touch
+// active users: they must be soft-deleted first. Only admins may purge.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - id: User ID to purge
+//
+// Returns:
+//   - error: ErrForbidden, ErrUserNotFound if user doesn't exist, ErrUserActive
+//     if the user has not been soft-deleted, or database errors
+func (s *UserService) PurgeUser(ctx context.Context, id int32) error {
+	ctx, span := tracer.Start(ctx, "UserService.PurgeUser")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return err
+	}
+	
+	user, err := s.queries.GetUserByIDIncludingDeleted(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrUserNotFound
@@ -207,27 +1163,102 @@ func (s *UserService) DeleteUser(ctx context.Context, id int32) error {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	
-	// Business Logic: In a real application, you might:
-	// - Soft delete instead of hard delete
-	// - Check if user has dependent records
-	// - Send a deletion confirmation email
-	// - Log to an audit trail
+	if !user.DeletedAt.Valid {
+		return ErrUserActive
+	}
 	
-	err = s.queries.DeleteUser(ctx, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+	// The query re-checks deleted_at, so a user restored in the meantime is
+	// never purged
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		purged, err := q.PurgeUser(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to purge user: %w", err)
+		}
+		if purged == 0 {
+			return ErrUserActive
+		}
+		
+		return recordAudit(ctx, q, "user.purge", AuditEntityUser, id, user, nil)
+	})
+}
+
+// GrantRole gives a user a role, for every game or for a single one; only
+// admins may grant roles
+//
+// The role takes effect on the user's next request, since roles are read on
+// every request rather than stored in access tokens.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: User ID to grant the role to
+//   - role: auth.RoleModerator or auth.RoleAdmin
+//   - gameID: The game the grant is limited to, or 0 for every game
+//
+// Returns:
+//   - *db.UserRole: The stored grant
+//   - error: ErrForbidden, ErrInvalidInput for an unknown role,
+//     ErrUserNotFound, ErrRoleAlreadyGranted, or database errors
+func (s *UserService) GrantRole(ctx context.Context, id int32, role auth.Role, gameID int32) (*db.UserRole, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GrantRole")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if role != auth.RoleModerator && role != auth.RoleAdmin {
+		return nil, fmt.Errorf("%w: role must be %s or %s", ErrInvalidInput, auth.RoleModerator, auth.RoleAdmin)
 	}
 	
-	return nil
+	if _, err := s.queries.GetUserByID(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	var grant db.UserRole
+	err := s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		grant, err = q.GrantUserRole(ctx, db.GrantUserRoleParams{
+			UserID: id,
+			Role:   string(role),
+			GameID: pgtype.Int4{Int32: gameID, Valid: gameID != 0},
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrRoleAlreadyGranted
+			}
+			return fmt.Errorf("failed to grant role: %w", err)
+		}
+		return recordAudit(ctx, q, "user.grant_role", AuditEntityUser, id, nil, grant)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// isDuplicateEmailError reports whether err is a unique violation on the
+// users.email constraint
+func isDuplicateEmailError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == usersEmailKey
+}
+
+// isUniqueViolation reports whether err is a unique violation on any constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
 }
 
 // isCorporateEmail checks if an email belongs to a corporate domain
 // This is an example of business logic that you would implement
+// It must agree with the domain filter used by the ListUsers query.
 func (s *UserService) isCorporateEmail(email string) bool {
-	// Simple example - in production, this would be more sophisticated
-	corporateDomains := []string{"@company.com", "@enterprise.com"}
-	
-	for _, domain := range corporateDomains {
+	for _, domain := range s.corporateDomains {
+		domain = "@" + domain
 		if len(email) > len(domain) && email[len(email)-len(domain):] == domain {
 			return true
 		}
@@ -235,8 +1266,3 @@ func (s *UserService) isCorporateEmail(email string) bool {
 	
 	return false
 }
-
-// Helper function to convert time.Time to string for API responses
-func formatTime(t time.Time) string {
-	return t.Format(time.RFC3339)
-}
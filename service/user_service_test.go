@@ -1,19 +1,35 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/cache"
 	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/mailer"
+	"github.com/example/speedrun-rest-api/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
 )
 
-// Helper function to convert time.Time to pgtype.Timestamp
-func timeToTimestamp(t time.Time) pgtype.Timestamp {
-	return pgtype.Timestamp{
+// Helper function to convert time.Time to pgtype.Timestamptz
+func timeToTimestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{
 		Time:  t,
 		Valid: true,
 	}
@@ -21,13 +37,211 @@ func timeToTimestamp(t time.Time) pgtype.Timestamp {
 
 // MockQueries is a mock implementation of db.Queries for testing
 type MockQueries struct {
-	GetUserByIDFunc    func(ctx context.Context, id int32) (db.User, error)
-	GetUserByEmailFunc func(ctx context.Context, email string) (db.User, error)
-	ListUsersFunc      func(ctx context.Context, params db.ListUsersParams) ([]db.User, error)
-	CountUsersFunc     func(ctx context.Context) (int64, error)
-	CreateUserFunc     func(ctx context.Context, params db.CreateUserParams) (db.User, error)
-	UpdateUserFunc     func(ctx context.Context, params db.UpdateUserParams) (db.User, error)
-	DeleteUserFunc     func(ctx context.Context, id int32) error
+	GetUserByIDFunc func(ctx context.Context, id int32) (db.User, error)
+	// GetUserByIDIncludingDeletedFunc falls back to GetUserByIDFunc when unset
+	GetUserByIDIncludingDeletedFunc func(ctx context.Context, id int32) (db.User, error)
+	GetUserByEmailFunc              func(ctx context.Context, email string) (db.User, error)
+	GetUsersByIDsFunc               func(ctx context.Context, ids []int32) ([]db.User, error)
+	GetUsersByEmailsFunc            func(ctx context.Context, emails []string) ([]db.User, error)
+	ImportUsersFunc                 func(ctx context.Context, arg []db.ImportUsersParams) (int64, error)
+	ExportUsersFunc                 func(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error
+	ExportRunsByCategoryFunc        func(ctx context.Context, arg db.ExportRunsByCategoryParams, fn func(db.Run) error) error
+	SearchUsersFunc                 func(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error)
+	SearchGamesFunc                 func(ctx context.Context, arg db.SearchGamesParams) ([]db.SearchGamesRow, error)
+	SearchRunsFunc                  func(ctx context.Context, arg db.SearchRunsParams) ([]db.SearchRunsRow, error)
+	ListUsersFunc                   func(ctx context.Context, params db.ListUsersParams) ([]db.ListUsersRow, error)
+	ListUsersAfterFunc              func(ctx context.Context, params db.ListUsersAfterParams) ([]db.User, error)
+	CountUsersFunc                  func(ctx context.Context, params db.CountUsersParams) (int64, error)
+	CreateUserFunc                  func(ctx context.Context, params db.CreateUserParams) (db.User, error)
+	CreateUserWithPasswordFunc      func(ctx context.Context, params db.CreateUserWithPasswordParams) (db.User, error)
+	UpdateUserFunc                  func(ctx context.Context, params db.UpdateUserParams) (db.User, error)
+	DeleteUserFunc                  func(ctx context.Context, id int32) (int64, error)
+	RestoreUserFunc                 func(ctx context.Context, id int32) (db.User, error)
+	PurgeUserFunc                   func(ctx context.Context, id int32) (int64, error)
+	GetUserStatsFunc                func(ctx context.Context, corporateDomains []string) (db.GetUserStatsRow, error)
+	GetUserByPublicIDFunc           func(ctx context.Context, publicID pgtype.UUID) (db.User, error)
+	VerifyUserEmailFunc             func(ctx context.Context, params db.VerifyUserEmailParams) (db.User, error)
+	SetUserAvatarFunc               func(ctx context.Context, params db.SetUserAvatarParams) (db.User, error)
+
+	GetGameBySlugFunc  func(ctx context.Context, slug string) (db.Game, error)
+	GetGamesByIDsFunc  func(ctx context.Context, ids []int32) ([]db.Game, error)
+	ListGamesFunc      func(ctx context.Context, params db.ListGamesParams) ([]db.Game, error)
+	ListGamesAfterFunc func(ctx context.Context, params db.ListGamesAfterParams) ([]db.Game, error)
+	SuggestGamesFunc   func(ctx context.Context, params db.SuggestGamesParams) ([]db.Game, error)
+	CountGamesFunc     func(ctx context.Context) (int64, error)
+	CreateGameFunc     func(ctx context.Context, params db.CreateGameParams) (db.Game, error)
+	UpdateGameFunc     func(ctx context.Context, params db.UpdateGameParams) (db.Game, error)
+	DeleteGameFunc     func(ctx context.Context, slug string) (int64, error)
+
+	GetCategoryBySlugFunc            func(ctx context.Context, params db.GetCategoryBySlugParams) (db.Category, error)
+	ListCategoriesByGameFunc         func(ctx context.Context, gameID int32) ([]db.Category, error)
+	CreateCategoryFunc               func(ctx context.Context, params db.CreateCategoryParams) (db.Category, error)
+	GetDefaultCategoryFunc           func(ctx context.Context, gameID int32) (db.Category, error)
+	GetLevelBySlugFunc               func(ctx context.Context, params db.GetLevelBySlugParams) (db.Level, error)
+	ListLevelsByGameFunc             func(ctx context.Context, gameID int32) ([]db.Level, error)
+	CreateLevelFunc                  func(ctx context.Context, params db.CreateLevelParams) (db.Level, error)
+	ListPlatformsFunc                func(ctx context.Context) ([]db.Platform, error)
+	GetPlatformBySlugFunc            func(ctx context.Context, slug string) (db.Platform, error)
+	CreatePlatformFunc               func(ctx context.Context, params db.CreatePlatformParams) (db.Platform, error)
+	UpdatePlatformFunc               func(ctx context.Context, params db.UpdatePlatformParams) (db.Platform, error)
+	DeletePlatformFunc               func(ctx context.Context, slug string) (int64, error)
+	ListRegionsFunc                  func(ctx context.Context) ([]db.Region, error)
+	GetRegionBySlugFunc              func(ctx context.Context, slug string) (db.Region, error)
+	CreateRegionFunc                 func(ctx context.Context, params db.CreateRegionParams) (db.Region, error)
+	UpdateRegionFunc                 func(ctx context.Context, params db.UpdateRegionParams) (db.Region, error)
+	DeleteRegionFunc                 func(ctx context.Context, slug string) (int64, error)
+	ListVariablesByGameFunc          func(ctx context.Context, gameID int32) ([]db.Variable, error)
+	ListVariableValuesByGameFunc     func(ctx context.Context, gameID int32) ([]db.VariableValue, error)
+	CreateVariableFunc               func(ctx context.Context, params db.CreateVariableParams) (db.Variable, error)
+	CreateVariableValueFunc          func(ctx context.Context, params db.CreateVariableValueParams) (db.VariableValue, error)
+	CreateRunFunc                    func(ctx context.Context, params db.CreateRunParams) (db.Run, error)
+	CreateRunVariableValueFunc       func(ctx context.Context, params db.CreateRunVariableValueParams) error
+	ListRunVariableValuesFunc        func(ctx context.Context, runIds []int32) ([]db.ListRunVariableValuesRow, error)
+	ListRunsByCategoryFunc           func(ctx context.Context, params db.ListRunsByCategoryParams) ([]db.Run, error)
+	ListRunsByCategoryAfterFunc      func(ctx context.Context, params db.ListRunsByCategoryAfterParams) ([]db.Run, error)
+	CountRunsByCategoryFunc          func(ctx context.Context, params db.CountRunsByCategoryParams) (int64, error)
+	ListRunsByUserFunc               func(ctx context.Context, params db.ListRunsByUserParams) ([]db.Run, error)
+	ListRunsByUserAfterFunc          func(ctx context.Context, params db.ListRunsByUserAfterParams) ([]db.Run, error)
+	CountRunsByUserFunc              func(ctx context.Context, params db.CountRunsByUserParams) (int64, error)
+	GetLeaderboardFunc               func(ctx context.Context, params db.GetLeaderboardParams) ([]db.GetLeaderboardRow, error)
+	GetLeaderboardAfterFunc          func(ctx context.Context, params db.GetLeaderboardAfterParams) ([]db.GetLeaderboardAfterRow, error)
+	GetPersonalBestsFunc             func(ctx context.Context, userID int32) ([]db.GetPersonalBestsRow, error)
+	CountLeaderboardFunc             func(ctx context.Context, params db.CountLeaderboardParams) (int64, error)
+	GetRunByIDFunc                   func(ctx context.Context, id int32) (db.Run, error)
+	GetRunSummaryFunc                func(ctx context.Context, id int32) (db.GetRunSummaryRow, error)
+	GetFastestVerifiedRunFunc        func(ctx context.Context, params db.GetFastestVerifiedRunParams) (db.Run, error)
+	ObsoleteBeatenRunsFunc           func(ctx context.Context, runID int32) ([]db.Run, error)
+	CreateCategoryRecordFunc         func(ctx context.Context, params db.CreateCategoryRecordParams) error
+	ListCategoryRecordsFunc          func(ctx context.Context, categoryID int32) ([]db.ListCategoryRecordsRow, error)
+	UpdateRunStatusFunc              func(ctx context.Context, params db.UpdateRunStatusParams) (db.Run, error)
+	ListModerationQueueFunc          func(ctx context.Context, params db.ListModerationQueueParams) ([]db.Run, error)
+	ListModerationQueueAfterFunc     func(ctx context.Context, params db.ListModerationQueueAfterParams) ([]db.Run, error)
+	CountModerationQueueFunc         func(ctx context.Context, gameID int32) (int64, error)
+	GetCredentialsByEmailFunc        func(ctx context.Context, email string) (db.GetCredentialsByEmailRow, error)
+	CreateRefreshTokenFunc           func(ctx context.Context, params db.CreateRefreshTokenParams) (db.RefreshToken, error)
+	GetRefreshTokenByHashFunc        func(ctx context.Context, tokenHash string) (db.RefreshToken, error)
+	RevokeRefreshTokenFunc           func(ctx context.Context, id int32) (int64, error)
+	RevokeRefreshTokenFamilyFunc     func(ctx context.Context, familyID pgtype.UUID) error
+	DeleteExpiredRefreshTokensFunc   func(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+	ListUserRolesFunc                func(ctx context.Context, userID int32) ([]db.UserRole, error)
+	ListGameModeratorsFunc           func(ctx context.Context, gameID int32) ([]db.ListGameModeratorsRow, error)
+	ListGameModeratorsByUserFunc     func(ctx context.Context, userID int32) ([]db.GameModerator, error)
+	GetGameModeratorFunc             func(ctx context.Context, params db.GetGameModeratorParams) (db.GameModerator, error)
+	AddGameModeratorFunc             func(ctx context.Context, params db.AddGameModeratorParams) (db.GameModerator, error)
+	RemoveGameModeratorFunc          func(ctx context.Context, params db.RemoveGameModeratorParams) (int64, error)
+	GrantUserRoleFunc                func(ctx context.Context, params db.GrantUserRoleParams) (db.UserRole, error)
+	RevokeUserRefreshTokensFunc      func(ctx context.Context, userID int32) (int64, error)
+	GetCategoryByIDFunc              func(ctx context.Context, id int32) (db.Category, error)
+	GetCategoriesByIDsFunc           func(ctx context.Context, ids []int32) ([]db.Category, error)
+	ListCategoriesByGamesFunc        func(ctx context.Context, gameIds []int32) ([]db.Category, error)
+	GetUserIdentityFunc              func(ctx context.Context, params db.GetUserIdentityParams) (db.UserIdentity, error)
+	CreateUserIdentityFunc           func(ctx context.Context, params db.CreateUserIdentityParams) (db.UserIdentity, error)
+	CreateUserWithIdentityFunc       func(ctx context.Context, params db.CreateUserWithIdentityParams) (db.User, error)
+	CreateAPIKeyFunc                 func(ctx context.Context, params db.CreateAPIKeyParams) (db.ApiKey, error)
+	GetAPIKeyByHashFunc              func(ctx context.Context, keyHash string) (db.ApiKey, error)
+	ListAPIKeysByUserFunc            func(ctx context.Context, userID int32) ([]db.ApiKey, error)
+	RevokeAPIKeyFunc                 func(ctx context.Context, params db.RevokeAPIKeyParams) (db.ApiKey, error)
+	TouchAPIKeyFunc                  func(ctx context.Context, id int32) error
+	ClaimIdempotencyKeyFunc          func(ctx context.Context, params db.ClaimIdempotencyKeyParams) (int64, error)
+	GetIdempotencyKeyFunc            func(ctx context.Context, params db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)
+	CompleteIdempotencyKeyFunc       func(ctx context.Context, params db.CompleteIdempotencyKeyParams) error
+	DeleteIdempotencyKeyFunc         func(ctx context.Context, params db.DeleteIdempotencyKeyParams) error
+	DeleteExpiredIdempotencyKeysFunc func(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	CreateAuditEventFunc             func(ctx context.Context, params db.CreateAuditEventParams) error
+	ListAuditEventsFunc              func(ctx context.Context, params db.ListAuditEventsParams) ([]db.AuditEvent, error)
+	ListAuditEventsAfterFunc         func(ctx context.Context, params db.ListAuditEventsAfterParams) ([]db.AuditEvent, error)
+	CountAuditEventsFunc             func(ctx context.Context, params db.CountAuditEventsParams) (int64, error)
+	CreateWebhookFunc                func(ctx context.Context, params db.CreateWebhookParams) (db.Webhook, error)
+	GetWebhookByIDFunc               func(ctx context.Context, id int32) (db.Webhook, error)
+	ListWebhooksFunc                 func(ctx context.Context) ([]db.Webhook, error)
+	DeleteWebhookFunc                func(ctx context.Context, id int32) (int64, error)
+	ListWebhookDeliveriesFunc        func(ctx context.Context, params db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)
+	ListWebhookDeliveriesAfterFunc   func(ctx context.Context, params db.ListWebhookDeliveriesAfterParams) ([]db.WebhookDelivery, error)
+	CountWebhookDeliveriesFunc       func(ctx context.Context, webhookID int32) (int64, error)
+	CreateOutboxEventFunc            func(ctx context.Context, params db.CreateOutboxEventParams) error
+	ListRunCommentsFunc              func(ctx context.Context, params db.ListRunCommentsParams) ([]db.RunComment, error)
+	ListRunCommentsAfterFunc         func(ctx context.Context, params db.ListRunCommentsAfterParams) ([]db.RunComment, error)
+	CountRunCommentsFunc             func(ctx context.Context, runID int32) (int64, error)
+	CountRecentRunCommentsByUserFunc func(ctx context.Context, params db.CountRecentRunCommentsByUserParams) (int64, error)
+	GetRunCommentByIDFunc            func(ctx context.Context, id int32) (db.RunComment, error)
+	CreateRunCommentFunc             func(ctx context.Context, params db.CreateRunCommentParams) (db.RunComment, error)
+	UpdateRunCommentFunc             func(ctx context.Context, params db.UpdateRunCommentParams) (db.RunComment, error)
+	DeleteRunCommentFunc             func(ctx context.Context, params db.DeleteRunCommentParams) (db.RunComment, error)
+	FollowUserFunc                   func(ctx context.Context, params db.FollowUserParams) (int64, error)
+	UnfollowUserFunc                 func(ctx context.Context, params db.UnfollowUserParams) (int64, error)
+	FollowGameFunc                   func(ctx context.Context, params db.FollowGameParams) (int64, error)
+	UnfollowGameFunc                 func(ctx context.Context, params db.UnfollowGameParams) (int64, error)
+	ListUserFollowersFunc            func(ctx context.Context, params db.ListUserFollowersParams) ([]db.ListUserFollowersRow, error)
+	ListUserFollowersAfterFunc       func(ctx context.Context, params db.ListUserFollowersAfterParams) ([]db.ListUserFollowersAfterRow, error)
+	ListUserFollowingFunc            func(ctx context.Context, params db.ListUserFollowingParams) ([]db.ListUserFollowingRow, error)
+	ListUserFollowingAfterFunc       func(ctx context.Context, params db.ListUserFollowingAfterParams) ([]db.ListUserFollowingAfterRow, error)
+	ListGameFollowersFunc            func(ctx context.Context, params db.ListGameFollowersParams) ([]db.ListGameFollowersRow, error)
+	ListGameFollowersAfterFunc       func(ctx context.Context, params db.ListGameFollowersAfterParams) ([]db.ListGameFollowersAfterRow, error)
+	ListFollowedGamesFunc            func(ctx context.Context, params db.ListFollowedGamesParams) ([]db.ListFollowedGamesRow, error)
+	ListFollowedGamesAfterFunc       func(ctx context.Context, params db.ListFollowedGamesAfterParams) ([]db.ListFollowedGamesAfterRow, error)
+	GetUserFollowCountsFunc          func(ctx context.Context, userID int32) (db.GetUserFollowCountsRow, error)
+	CountGameFollowersFunc           func(ctx context.Context, gameID int32) (int64, error)
+	ListFeedItemsFunc                func(ctx context.Context, params db.ListFeedItemsParams) ([]db.ListFeedItemsRow, error)
+	ListFeedItemsAfterFunc           func(ctx context.Context, params db.ListFeedItemsAfterParams) ([]db.ListFeedItemsAfterRow, error)
+	CountFeedItemsFunc               func(ctx context.Context, userID int32) (int64, error)
+	ListNotificationsFunc            func(ctx context.Context, params db.ListNotificationsParams) ([]db.Notification, error)
+	ListNotificationsAfterFunc       func(ctx context.Context, params db.ListNotificationsAfterParams) ([]db.Notification, error)
+	CountNotificationsFunc           func(ctx context.Context, params db.CountNotificationsParams) (int64, error)
+	MarkNotificationReadFunc         func(ctx context.Context, params db.MarkNotificationReadParams) (int64, error)
+	MarkAllNotificationsReadFunc     func(ctx context.Context, userID int32) (int64, error)
+	ListNotificationPreferencesFunc  func(ctx context.Context, userID int32) ([]db.NotificationPreference, error)
+	UpsertNotificationPreferenceFunc func(ctx context.Context, params db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error)
+	CreateRaceFunc                   func(ctx context.Context, params db.CreateRaceParams) (db.Race, error)
+	GetRaceByIDFunc                  func(ctx context.Context, id int32) (db.Race, error)
+	GetRaceByIDForUpdateFunc         func(ctx context.Context, id int32) (db.Race, error)
+	ListActiveRacesByCategoryFunc    func(ctx context.Context, categoryID int32) ([]db.Race, error)
+	StartRaceFunc                    func(ctx context.Context, params db.StartRaceParams) (db.Race, error)
+	EndRaceFunc                      func(ctx context.Context, params db.EndRaceParams) (db.Race, error)
+	AddRaceParticipantFunc           func(ctx context.Context, params db.AddRaceParticipantParams) (int64, error)
+	RemoveRaceParticipantFunc        func(ctx context.Context, params db.RemoveRaceParticipantParams) (int64, error)
+	GetRaceParticipantFunc           func(ctx context.Context, params db.GetRaceParticipantParams) (db.RaceParticipant, error)
+	SetRaceParticipantReadyFunc      func(ctx context.Context, params db.SetRaceParticipantReadyParams) (int64, error)
+	FinishRaceParticipantFunc        func(ctx context.Context, params db.FinishRaceParticipantParams) (int64, error)
+	ForfeitRaceParticipantFunc       func(ctx context.Context, params db.ForfeitRaceParticipantParams) (int64, error)
+	ListRaceParticipantsFunc         func(ctx context.Context, raceID int32) ([]db.ListRaceParticipantsRow, error)
+	NotifyListenersFunc              func(ctx context.Context, params db.NotifyListenersParams) error
+	CreateOperationFunc              func(ctx context.Context, params db.CreateOperationParams) (db.Operation, error)
+	GetOperationByIDFunc             func(ctx context.Context, id pgtype.UUID) (db.Operation, error)
+	GetOperationOutputFunc           func(ctx context.Context, operationID pgtype.UUID) (db.OperationOutput, error)
+	WithTxFunc                       func(ctx context.Context, fn func(q db.Querier) error) error
+}
+
+// WithTx runs fn against the mock itself unless a test stubs WithTxFunc,
+// e.g. to roll back an in-memory table
+func (m *MockQueries) WithTx(ctx context.Context, fn func(q db.Querier) error) error {
+	if m.WithTxFunc != nil {
+		return m.WithTxFunc(ctx, fn)
+	}
+	return fn(m)
+}
+
+func (m *MockQueries) ListUserRoles(ctx context.Context, userID int32) ([]db.UserRole, error) {
+	if m.ListUserRolesFunc != nil {
+		return m.ListUserRolesFunc(ctx, userID)
+	}
+	return []db.UserRole{}, nil
+}
+
+func (m *MockQueries) GrantUserRole(ctx context.Context, params db.GrantUserRoleParams) (db.UserRole, error) {
+	if m.GrantUserRoleFunc != nil {
+		return m.GrantUserRoleFunc(ctx, params)
+	}
+	return db.UserRole{UserID: params.UserID, Role: params.Role, GameID: params.GameID}, nil
+}
+
+// asUser returns a context authenticated as userID with the given grants
+func asUser(userID int32, grants ...auth.Grant) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID, Grants: grants})
+}
+
+// asAdmin returns a context authenticated as a global admin
+func asAdmin() context.Context {
+	return asUser(100, auth.Grant{Role: auth.RoleAdmin})
 }
 
 func (m *MockQueries) GetUserByID(ctx context.Context, id int32) (db.User, error) {
@@ -37,6 +251,13 @@ func (m *MockQueries) GetUserByID(ctx context.Context, id int32) (db.User, error
 	return db.User{}, sql.ErrNoRows
 }
 
+func (m *MockQueries) GetUserByIDIncludingDeleted(ctx context.Context, id int32) (db.User, error) {
+	if m.GetUserByIDIncludingDeletedFunc != nil {
+		return m.GetUserByIDIncludingDeletedFunc(ctx, id)
+	}
+	return m.GetUserByID(ctx, id)
+}
+
 func (m *MockQueries) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
 	if m.GetUserByEmailFunc != nil {
 		return m.GetUserByEmailFunc(ctx, email)
@@ -44,16 +265,88 @@ func (m *MockQueries) GetUserByEmail(ctx context.Context, email string) (db.User
 	return db.User{}, sql.ErrNoRows
 }
 
-func (m *MockQueries) ListUsers(ctx context.Context, params db.ListUsersParams) ([]db.User, error) {
+func (m *MockQueries) GetUsersByIDs(ctx context.Context, ids []int32) ([]db.User, error) {
+	if m.GetUsersByIDsFunc != nil {
+		return m.GetUsersByIDsFunc(ctx, ids)
+	}
+	return []db.User{}, nil
+}
+
+func (m *MockQueries) GetUsersByEmails(ctx context.Context, emails []string) ([]db.User, error) {
+	if m.GetUsersByEmailsFunc != nil {
+		return m.GetUsersByEmailsFunc(ctx, emails)
+	}
+	return []db.User{}, nil
+}
+
+func (m *MockQueries) ImportUsers(ctx context.Context, arg []db.ImportUsersParams) (int64, error) {
+	if m.ImportUsersFunc != nil {
+		return m.ImportUsersFunc(ctx, arg)
+	}
+	return int64(len(arg)), nil
+}
+
+func (m *MockQueries) ExportUsers(ctx context.Context, arg db.ExportUsersParams, fn func(db.User) error) error {
+	if m.ExportUsersFunc != nil {
+		return m.ExportUsersFunc(ctx, arg, fn)
+	}
+	return nil
+}
+
+func (m *MockQueries) ExportRunsByCategory(ctx context.Context, arg db.ExportRunsByCategoryParams, fn func(db.Run) error) error {
+	if m.ExportRunsByCategoryFunc != nil {
+		return m.ExportRunsByCategoryFunc(ctx, arg, fn)
+	}
+	return nil
+}
+
+func (m *MockQueries) SearchUsers(ctx context.Context, arg db.SearchUsersParams) ([]db.SearchUsersRow, error) {
+	if m.SearchUsersFunc != nil {
+		return m.SearchUsersFunc(ctx, arg)
+	}
+	return []db.SearchUsersRow{}, nil
+}
+
+func (m *MockQueries) SearchGames(ctx context.Context, arg db.SearchGamesParams) ([]db.SearchGamesRow, error) {
+	if m.SearchGamesFunc != nil {
+		return m.SearchGamesFunc(ctx, arg)
+	}
+	return []db.SearchGamesRow{}, nil
+}
+
+func (m *MockQueries) SearchRuns(ctx context.Context, arg db.SearchRunsParams) ([]db.SearchRunsRow, error) {
+	if m.SearchRunsFunc != nil {
+		return m.SearchRunsFunc(ctx, arg)
+	}
+	return []db.SearchRunsRow{}, nil
+}
+
+func (m *MockQueries) ListUsers(ctx context.Context, params db.ListUsersParams) ([]db.ListUsersRow, error) {
 	if m.ListUsersFunc != nil {
 		return m.ListUsersFunc(ctx, params)
 	}
+	return []db.ListUsersRow{}, nil
+}
+
+// listedUsers returns users as ListUsers rows, each carrying total
+func listedUsers(total int64, users ...db.User) []db.ListUsersRow {
+	rows := make([]db.ListUsersRow, len(users))
+	for i, user := range users {
+		rows[i] = db.ListUsersRow{User: user, Total: total}
+	}
+	return rows
+}
+
+func (m *MockQueries) ListUsersAfter(ctx context.Context, params db.ListUsersAfterParams) ([]db.User, error) {
+	if m.ListUsersAfterFunc != nil {
+		return m.ListUsersAfterFunc(ctx, params)
+	}
 	return []db.User{}, nil
 }
 
-func (m *MockQueries) CountUsers(ctx context.Context) (int64, error) {
+func (m *MockQueries) CountUsers(ctx context.Context, params db.CountUsersParams) (int64, error) {
 	if m.CountUsersFunc != nil {
-		return m.CountUsersFunc(ctx)
+		return m.CountUsersFunc(ctx, params)
 	}
 	return 0, nil
 }
@@ -65,6 +358,13 @@ func (m *MockQueries) CreateUser(ctx context.Context, params db.CreateUserParams
 	return db.User{}, nil
 }
 
+func (m *MockQueries) CreateUserWithPassword(ctx context.Context, params db.CreateUserWithPasswordParams) (db.User, error) {
+	if m.CreateUserWithPasswordFunc != nil {
+		return m.CreateUserWithPasswordFunc(ctx, params)
+	}
+	return db.User{}, nil
+}
+
 func (m *MockQueries) UpdateUser(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
 	if m.UpdateUserFunc != nil {
 		return m.UpdateUserFunc(ctx, params)
@@ -72,11 +372,53 @@ func (m *MockQueries) UpdateUser(ctx context.Context, params db.UpdateUserParams
 	return db.User{}, nil
 }
 
-func (m *MockQueries) DeleteUser(ctx context.Context, id int32) error {
+func (m *MockQueries) DeleteUser(ctx context.Context, id int32) (int64, error) {
 	if m.DeleteUserFunc != nil {
 		return m.DeleteUserFunc(ctx, id)
 	}
-	return nil
+	return 0, nil
+}
+
+func (m *MockQueries) RestoreUser(ctx context.Context, id int32) (db.User, error) {
+	if m.RestoreUserFunc != nil {
+		return m.RestoreUserFunc(ctx, id)
+	}
+	return db.User{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) PurgeUser(ctx context.Context, id int32) (int64, error) {
+	if m.PurgeUserFunc != nil {
+		return m.PurgeUserFunc(ctx, id)
+	}
+	return 0, nil
+}
+
+func (m *MockQueries) VerifyUserEmail(ctx context.Context, params db.VerifyUserEmailParams) (db.User, error) {
+	if m.VerifyUserEmailFunc != nil {
+		return m.VerifyUserEmailFunc(ctx, params)
+	}
+	return db.User{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) SetUserAvatar(ctx context.Context, params db.SetUserAvatarParams) (db.User, error) {
+	if m.SetUserAvatarFunc != nil {
+		return m.SetUserAvatarFunc(ctx, params)
+	}
+	return db.User{}, sql.ErrNoRows
+}
+
+func (m *MockQueries) GetUserStats(ctx context.Context, corporateDomains []string) (db.GetUserStatsRow, error) {
+	if m.GetUserStatsFunc != nil {
+		return m.GetUserStatsFunc(ctx, corporateDomains)
+	}
+	return db.GetUserStatsRow{}, nil
+}
+
+func (m *MockQueries) GetUserByPublicID(ctx context.Context, publicID pgtype.UUID) (db.User, error) {
+	if m.GetUserByPublicIDFunc != nil {
+		return m.GetUserByPublicIDFunc(ctx, publicID)
+	}
+	return db.User{}, sql.ErrNoRows
 }
 
 func TestGetUserByID_Success(t *testing.T) {
@@ -87,8 +429,8 @@ func TestGetUserByID_Success(t *testing.T) {
 				ID:        1,
 				Name:      "John Doe",
 				Email:     "john@example.com",
-				CreatedAt: timeToTimestamp(now),
-				UpdatedAt: timeToTimestamp(now),
+				CreatedAt: timeToTimestamptz(now),
+				UpdatedAt: timeToTimestamptz(now),
 			}, nil
 		},
 	}
@@ -107,6 +449,139 @@ func TestGetUserByID_Success(t *testing.T) {
 	}
 }
 
+func TestGetUserByID_CachedUntilUpdated(t *testing.T) {
+	created := time.Date(2024, 1, 15, 12, 0, 0, 123456000, time.UTC)
+	var reads atomic.Int32
+	name := "John Doe"
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			if reads.Add(1) == 1 && !db.UsesPrimary(ctx) {
+				t.Error("expected the cache to be filled from the primary")
+			}
+			return db.User{ID: id, Name: name, Version: 1, CreatedAt: timeToTimestamptz(created)}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, p db.UpdateUserParams) (db.User, error) {
+			name = p.Name
+			return db.User{ID: p.ID, Name: p.Name, Version: 2}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries, WithCache(cache.New(cache.NewMemory(), time.Minute)))
+	service.GetUserByID(context.Background(), 1)
+	user, err := service.GetUserByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if reads.Load() != 1 || !user.CreatedAt.Time.Equal(created) {
+		t.Errorf("expected the second read to be served from the cache, got %d reads and %+v", reads.Load(), user)
+	}
+
+	if _, err := service.UpdateUser(asAdmin(), 1, "Jane Doe", "", ProfileUpdate{}, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	user, _ = service.GetUserByID(context.Background(), 1)
+	if user.Name != "Jane Doe" {
+		t.Errorf("expected the update to invalidate the cached user, got %q", user.Name)
+	}
+}
+
+func TestGetUserByPublicID(t *testing.T) {
+	publicID := uuid.MustParse("0b8f6c3e-2a41-4d7b-9c55-3f1e8a2d6b90")
+	mockQueries := &MockQueries{
+		GetUserByPublicIDFunc: func(ctx context.Context, id pgtype.UUID) (db.User, error) {
+			if !id.Valid || id.Bytes != publicID {
+				t.Errorf("expected public ID %s, got %v", publicID, id)
+			}
+			return db.User{ID: 1, Name: "John Doe", PublicID: id}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	user, err := service.GetUserByPublicID(context.Background(), publicID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.ID != 1 {
+		t.Errorf("expected user ID 1, got %d", user.ID)
+	}
+}
+
+func TestGetUserByPublicID_NotFound(t *testing.T) {
+	service := NewUserService(&MockQueries{})
+	_, err := service.GetUserByPublicID(context.Background(), uuid.New())
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestGetUserByID_CoalescesConcurrentReads(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			calls.Add(1)
+			<-release
+			return db.User{ID: id, Name: "John Doe"}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+
+	const readers = 10
+	var wg sync.WaitGroup
+	results := make([]*db.User, readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			user, err := service.GetUserByID(context.Background(), 1)
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			results[i] = user
+		}(i)
+	}
+
+	// Give every reader time to join the in-flight query before it returns
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 database call, got %d", got)
+	}
+	for i, user := range results {
+		if user == nil || user.ID != 1 {
+			t.Errorf("reader %d: expected user 1, got %v", i, user)
+		}
+	}
+	// Each caller gets its own copy of the shared result
+	if results[0] == results[1] {
+		t.Error("expected readers not to share the same *db.User")
+	}
+}
+
+func TestGetUserByID_CallerCancellation(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			<-release
+			return db.User{ID: id}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := service.GetUserByID(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
 func TestGetUserByID_NotFound(t *testing.T) {
 	mockQueries := &MockQueries{
 		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
@@ -133,8 +608,8 @@ func TestCreateUser_Success(t *testing.T) {
 				ID:        1,
 				Name:      params.Name,
 				Email:     params.Email,
-				CreatedAt: timeToTimestamp(now),
-				UpdatedAt: timeToTimestamp(now),
+				CreatedAt: timeToTimestamptz(now),
+				UpdatedAt: timeToTimestamptz(now),
 			}, nil
 		},
 	}
@@ -171,146 +646,1120 @@ func TestCreateUser_DuplicateEmail(t *testing.T) {
 	}
 }
 
-func TestCreateUser_InvalidInput(t *testing.T) {
-	tests := []struct {
-		name  string
-		email string
-	}{
-		{"", "test@example.com"},
-		{"John Doe", ""},
-		{"", ""},
+func TestCreateUser_DuplicateEmailRace(t *testing.T) {
+	mockQueries := &MockQueries{
+		// The pre-check sees no user, but another request inserts one before us
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{}, sql.ErrNoRows
+		},
+		CreateUserFunc: func(ctx context.Context, params db.CreateUserParams) (db.User, error) {
+			return db.User{}, &pgconn.PgError{
+				Code:           "23505",
+				ConstraintName: "users_email_key",
+				Message:        "duplicate key value violates unique constraint \"users_email_key\"",
+			}
+		},
 	}
 
-	service := NewUserService(&MockQueries{})
+	service := NewUserService(mockQueries)
+	_, err := service.CreateUser(context.Background(), "Jane Doe", "jane@example.com")
 
-	for _, tt := range tests {
-		_, err := service.CreateUser(context.Background(), tt.name, tt.email)
-		if !errors.Is(err, ErrInvalidInput) {
-			t.Errorf("expected ErrInvalidInput for name=%q email=%q, got %v", tt.name, tt.email, err)
-		}
+	if !errors.Is(err, ErrDuplicateEmail) {
+		t.Errorf("expected ErrDuplicateEmail, got %v", err)
 	}
 }
 
-func TestListUsers_Success(t *testing.T) {
+func TestCreateUser_OtherConstraintViolation(t *testing.T) {
 	mockQueries := &MockQueries{
-		ListUsersFunc: func(ctx context.Context, params db.ListUsersParams) ([]db.User, error) {
-			return []db.User{
-				{ID: 1, Name: "User 1", Email: "user1@example.com"},
-				{ID: 2, Name: "User 2", Email: "user2@example.com"},
-			}, nil
-		},
-		CountUsersFunc: func(ctx context.Context) (int64, error) {
-			return 2, nil
+		CreateUserFunc: func(ctx context.Context, params db.CreateUserParams) (db.User, error) {
+			return db.User{}, &pgconn.PgError{Code: "23505", ConstraintName: "users_pkey"}
 		},
 	}
 
 	service := NewUserService(mockQueries)
-	users, count, err := service.ListUsers(context.Background(), 10, 0)
+	_, err := service.CreateUser(context.Background(), "Jane Doe", "jane@example.com")
 
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
-	}
-	if len(users) != 2 {
-		t.Errorf("expected 2 users, got %d", len(users))
-	}
-	if count != 2 {
-		t.Errorf("expected count 2, got %d", count)
+	if err == nil || errors.Is(err, ErrDuplicateEmail) {
+		t.Errorf("expected a generic error for unrelated constraints, got %v", err)
 	}
 }
 
-func TestUpdateUser_Success(t *testing.T) {
-	now := time.Now()
+func TestRegister_HashesPassword(t *testing.T) {
+	var params db.CreateUserWithPasswordParams
 	mockQueries := &MockQueries{
-		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
-			return db.User{
-				ID:    1,
-				Name:  "Old Name",
-				Email: "old@example.com",
-			}, nil
-		},
 		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
-			return db.User{}, sql.ErrNoRows // No duplicate
+			return db.User{}, sql.ErrNoRows
 		},
-		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
-			return db.User{
-				ID:        params.ID,
-				Name:      params.Name,
-				Email:     params.Email,
-				UpdatedAt: timeToTimestamp(now),
-			}, nil
+		CreateUserWithPasswordFunc: func(ctx context.Context, p db.CreateUserWithPasswordParams) (db.User, error) {
+			params = p
+			return db.User{ID: 1, Name: p.Name, Email: p.Email}, nil
 		},
 	}
 
-	service := NewUserService(mockQueries)
-	user, err := service.UpdateUser(context.Background(), 1, "New Name", "new@example.com")
+	service := NewUserService(mockQueries, WithPasswordCost(bcrypt.MinCost))
+	user, err := service.Register(context.Background(), " Jane Doe ", "jane@example.com", "hunter22")
 
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if user.Name != "New Name" {
-		t.Errorf("expected name 'New Name', got %s", user.Name)
+	if user.Name != "Jane Doe" {
+		t.Errorf("expected trimmed name, got %q", user.Name)
 	}
-}
-
-func TestUpdateUser_NotFound(t *testing.T) {
-	mockQueries := &MockQueries{
-		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
-			return db.User{}, sql.ErrNoRows
-		},
+	if params.PasswordHash == "hunter22" {
+		t.Fatal("expected the password to be hashed before it is stored")
 	}
+	if err := bcrypt.CompareHashAndPassword([]byte(params.PasswordHash), []byte("hunter22")); err != nil {
+		t.Errorf("expected stored hash to match the password, got %v", err)
+	}
+}
 
-	service := NewUserService(mockQueries)
-	_, err := service.UpdateUser(context.Background(), 999, "Name", "email@example.com")
+func TestRegister_InvalidPassword(t *testing.T) {
+	service := NewUserService(&MockQueries{})
 
-	if !errors.Is(err, ErrUserNotFound) {
-		t.Errorf("expected ErrUserNotFound, got %v", err)
+	for _, password := range []string{"", "short", strings.Repeat("a", 73)} {
+		_, err := service.Register(context.Background(), "Jane Doe", "jane@example.com", password)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("password of length %d: expected ErrInvalidInput, got %v", len(password), err)
+		}
 	}
 }
 
-func TestDeleteUser_Success(t *testing.T) {
+func TestRegister_DuplicateEmailRace(t *testing.T) {
 	mockQueries := &MockQueries{
-		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
-			return db.User{ID: 1}, nil
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{}, sql.ErrNoRows
 		},
-		DeleteUserFunc: func(ctx context.Context, id int32) error {
-			return nil
+		CreateUserWithPasswordFunc: func(ctx context.Context, p db.CreateUserWithPasswordParams) (db.User, error) {
+			return db.User{}, &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}
 		},
 	}
 
-	service := NewUserService(mockQueries)
-	err := service.DeleteUser(context.Background(), 1)
+	service := NewUserService(mockQueries, WithPasswordCost(bcrypt.MinCost))
+	_, err := service.Register(context.Background(), "Jane Doe", "jane@example.com", "hunter22")
 
-	if err != nil {
-		t.Errorf("expected no error, got %v", err)
+	if !errors.Is(err, ErrDuplicateEmail) {
+		t.Errorf("expected ErrDuplicateEmail, got %v", err)
 	}
 }
 
-func TestDeleteUser_NotFound(t *testing.T) {
-	mockQueries := &MockQueries{
-		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
-			return db.User{}, sql.ErrNoRows
-		},
+func TestCreateUser_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+	}{
+		{"", "test@example.com"},
+		{"John Doe", ""},
+		{"", ""},
+		{"   ", "test@example.com"},
+		{"\t\n", "test@example.com"},
 	}
 
-	service := NewUserService(mockQueries)
-	err := service.DeleteUser(context.Background(), 999)
+	service := NewUserService(&MockQueries{})
 
-	if !errors.Is(err, ErrUserNotFound) {
-		t.Errorf("expected ErrUserNotFound, got %v", err)
+	for _, tt := range tests {
+		_, err := service.CreateUser(context.Background(), tt.name, tt.email)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput for name=%q email=%q, got %v", tt.name, tt.email, err)
+		}
 	}
 }
 
-func TestIsCorporateEmail(t *testing.T) {
+func TestCreateUser_InvalidEmail(t *testing.T) {
 	service := NewUserService(&MockQueries{})
 
-	tests := []struct {
-		email    string
-		expected bool
-	}{
-		{"user@company.com", true},
-		{"user@enterprise.com", true},
-		{"user@gmail.com", false},
-		{"user@example.com", false},
+	for _, email := range []string{"jane", "jane@", "Jane <jane@example.com>", " jane@example.com"} {
+		_, err := service.CreateUser(context.Background(), "Jane Doe", email)
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) || len(invalid.Fields) != 1 || invalid.Fields[0].Field != "email" {
+			t.Errorf("email %q: expected a ValidationError for email, got %v", email, err)
+		}
+	}
+}
+
+func TestRegister_ReportsEveryInvalidField(t *testing.T) {
+	service := NewUserService(&MockQueries{})
+
+	_, err := service.Register(context.Background(), "  ", "not-an-email", "short")
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected a ValidationError, got %T", err)
+	}
+	expected := []FieldError{
+		{Field: "name", Message: "must not be empty"},
+		{Field: "email", Message: "must be a valid email address"},
+		{Field: "password", Message: "must be at least 8 characters"},
+	}
+	if !slices.Equal(invalid.Fields, expected) {
+		t.Errorf("expected fields %+v, got %+v", expected, invalid.Fields)
+	}
+	if want := "invalid input: name must not be empty; email must be a valid email address; password must be at least 8 characters"; err.Error() != want {
+		t.Errorf("expected message %q, got %q", want, err.Error())
+	}
+}
+
+func TestCreateUser_TrimsName(t *testing.T) {
+	var created db.CreateUserParams
+	mockQueries := &MockQueries{
+		CreateUserFunc: func(ctx context.Context, params db.CreateUserParams) (db.User, error) {
+			created = params
+			return db.User{ID: 1, Name: params.Name, Email: params.Email}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	_, err := service.CreateUser(context.Background(), "  Jane Doe  ", "jane@example.com")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.Name != "Jane Doe" {
+		t.Errorf("expected trimmed name 'Jane Doe', got %q", created.Name)
+	}
+}
+
+func TestCreateUser_NameTooLong(t *testing.T) {
+	service := NewUserService(&MockQueries{}, WithMaxNameLength(5))
+
+	if _, err := service.CreateUser(context.Background(), "Jane Doe", "jane@example.com"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for long name, got %v", err)
+	}
+
+	// The limit counts characters, not bytes, and applies after trimming
+	if _, err := service.CreateUser(context.Background(), "  Zoë  ", "zoe@example.com"); err != nil {
+		t.Errorf("expected name within limit to be accepted, got %v", err)
+	}
+}
+
+func TestListUsers_Success(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, params db.ListUsersParams) ([]db.ListUsersRow, error) {
+			return listedUsers(2,
+				db.User{ID: 1, Name: "User 1", Email: "user1@example.com"},
+				db.User{ID: 2, Name: "User 2", Email: "user2@example.com"},
+			), nil
+		},
+		CountUsersFunc: func(ctx context.Context, params db.CountUsersParams) (int64, error) {
+			t.Error("expected the total to come with the page")
+			return 0, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	page, err := service.ListUsers(context.Background(), PageRequest{Limit: 10}, ListUsersFilter{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Users) != 2 {
+		t.Errorf("expected 2 users, got %d", len(page.Users))
+	}
+	if page.Total != 2 {
+		t.Errorf("expected count 2, got %d", page.Total)
+	}
+}
+
+func TestListUsers_NormalizesPagination(t *testing.T) {
+	var params db.ListUsersParams
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, p db.ListUsersParams) ([]db.ListUsersRow, error) {
+			params = p
+			return []db.ListUsersRow{}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries, WithPageSizes(20, 50))
+
+	tests := []struct {
+		name           string
+		limit, offset  int
+		expectedLimit  int32
+		expectedOffset int32
+	}{
+		{"zero limit uses default", 0, 0, 20, 0},
+		{"negative limit uses default", -5, 0, 20, 0},
+		{"limit within range", 30, 10, 30, 10},
+		{"over-max limit is clamped", 500, 0, 50, 0},
+		{"negative offset starts at zero", 10, -3, 10, 0},
+	}
+
+	for _, tt := range tests {
+		page, err := service.ListUsers(context.Background(), PageRequest{Limit: tt.limit, Offset: tt.offset}, ListUsersFilter{})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+		if page.Limit != tt.expectedLimit || page.Offset != tt.expectedOffset {
+			t.Errorf("%s: expected limit=%d offset=%d, got limit=%d offset=%d",
+				tt.name, tt.expectedLimit, tt.expectedOffset, page.Limit, page.Offset)
+		}
+		if params.Limit != page.Limit+1 || params.Offset != page.Offset {
+			t.Errorf("%s: expected query to use effective values, got %+v", tt.name, params)
+		}
+	}
+}
+
+func TestListUsers_CursorContinuesAfterLastUser(t *testing.T) {
+	var after db.ListUsersAfterParams
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, params db.ListUsersParams) ([]db.ListUsersRow, error) {
+			return listedUsers(3, db.User{ID: 1}, db.User{ID: 2}, db.User{ID: 3}), nil
+		},
+		ListUsersAfterFunc: func(ctx context.Context, params db.ListUsersAfterParams) ([]db.User, error) {
+			after = params
+			return []db.User{{ID: 3}}, nil
+		},
+	}
+	service := NewUserService(mockQueries)
+
+	first, err := service.ListUsers(context.Background(), PageRequest{Limit: 2}, ListUsersFilter{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(first.Users) != 2 || first.NextCursor == "" {
+		t.Fatalf("expected 2 users and a next cursor, got %d users and %q", len(first.Users), first.NextCursor)
+	}
+
+	second, err := service.ListUsers(context.Background(), PageRequest{Limit: 2, Cursor: first.NextCursor}, ListUsersFilter{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.AfterID != 2 || after.Limit != 3 {
+		t.Errorf("expected to seek after user 2 fetching 3 rows, got %+v", after)
+	}
+	if len(second.Users) != 1 || second.NextCursor != "" {
+		t.Errorf("expected the last page without a cursor, got %d users and %q", len(second.Users), second.NextCursor)
+	}
+}
+
+func TestListUsers_RejectsBadCursors(t *testing.T) {
+	service := NewUserService(&MockQueries{})
+
+	tests := []struct {
+		name string
+		page PageRequest
+		want error
+	}{
+		{"malformed", PageRequest{Cursor: "%%%"}, ErrInvalidCursor},
+		{"not JSON", PageRequest{Cursor: "bm90IGpzb24"}, ErrInvalidCursor},
+		{"another list", PageRequest{Cursor: encodeCursor("games", 2)}, ErrInvalidCursor},
+		{"another sort", PageRequest{Cursor: encodeCursor("users:name:asc", "Ann", 2)}, ErrInvalidCursor},
+		{"wrong key type", PageRequest{Cursor: encodeCursor("users:id:asc", "two")}, ErrInvalidCursor},
+		{"with offset", PageRequest{Offset: 10, Cursor: encodeCursor("users:id:asc", 2)}, ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		if _, err := service.ListUsers(context.Background(), tt.page, ListUsersFilter{}); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestListUsers_FiltersAndSorts(t *testing.T) {
+	var params db.ListUsersParams
+	var count db.CountUsersParams
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, p db.ListUsersParams) ([]db.ListUsersRow, error) {
+			params = p
+			return []db.ListUsersRow{}, nil
+		},
+		CountUsersFunc: func(ctx context.Context, p db.CountUsersParams) (int64, error) {
+			count = p
+			return 0, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	filter := ListUsersFilter{Name: " 100%_fan ", EmailDomain: "@Company.com", Sort: "Created_At:DESC"}
+	// A page past the end carries no total, so it is counted separately
+	if _, err := service.ListUsers(asAdmin(), PageRequest{Offset: 40}, filter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if params.Sort != "created_at" || !params.Descending {
+		t.Errorf("expected created_at descending, got sort=%q descending=%v", params.Sort, params.Descending)
+	}
+	if params.Name != (pgtype.Text{String: `100\%\_fan`, Valid: true}) {
+		t.Errorf("expected name with LIKE wildcards escaped, got %+v", params.Name)
+	}
+	if params.EmailDomain != (pgtype.Text{String: "company.com", Valid: true}) {
+		t.Errorf("expected normalized email domain, got %+v", params.EmailDomain)
+	}
+	if count.Name != params.Name || count.EmailDomain != params.EmailDomain {
+		t.Errorf("expected the count to use the same filters, got %+v", count)
+	}
+}
+
+func TestListUsers_EmailOnlyForAdmins(t *testing.T) {
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, p db.ListUsersParams) ([]db.ListUsersRow, error) {
+			t.Error("expected no users to be listed")
+			return []db.ListUsersRow{}, nil
+		},
+	}
+	service := NewUserService(mockQueries)
+	adminKey := auth.WithPrincipal(context.Background(), auth.Principal{
+		UserID:   100,
+		Grants:   []auth.Grant{{Role: auth.RoleAdmin}},
+		APIKeyID: 1,
+		Scopes:   []string{ScopeGamesWrite, ScopeRunsModerate},
+	})
+
+	for _, filter := range []ListUsersFilter{{Sort: "email"}, {Sort: "Email:desc"}, {EmailDomain: "company.com"}, {IncludeDeleted: true}} {
+		for name, ctx := range map[string]context.Context{"anonymous": context.Background(), "non-admin": asUser(1), "admin's api key": adminKey} {
+			if _, err := service.ListUsers(ctx, PageRequest{}, filter); !errors.Is(err, ErrForbidden) {
+				t.Errorf("%s with %+v: expected ErrForbidden, got %v", name, filter, err)
+			}
+		}
+	}
+}
+
+func TestExportUsers_StreamsEveryUser(t *testing.T) {
+	var params db.ExportUsersParams
+	mockQueries := &MockQueries{
+		ExportUsersFunc: func(ctx context.Context, p db.ExportUsersParams, fn func(db.User) error) error {
+			params = p
+			for _, id := range []int32{1, 2, 3} {
+				if err := fn(db.User{ID: id}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	stop := errors.New("client went away")
+	var exported []int32
+	filter := ListUsersFilter{Name: " 100%_fan ", EmailDomain: "@Company.com", Sort: "Created_At:DESC"}
+	err := service.ExportUsers(asAdmin(), filter, func(user db.User) error {
+		exported = append(exported, user.ID)
+		if len(exported) == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, stop) {
+		t.Errorf("expected the callback's error, got %v", err)
+	}
+	if !slices.Equal(exported, []int32{1, 2}) {
+		t.Errorf("expected the export to stop after the callback failed, got %v", exported)
+	}
+	if params.Sort != "created_at" || !params.Descending {
+		t.Errorf("expected created_at descending, got sort=%q descending=%v", params.Sort, params.Descending)
+	}
+	if params.Name != (pgtype.Text{String: `100\%\_fan`, Valid: true}) || params.EmailDomain != (pgtype.Text{String: "company.com", Valid: true}) {
+		t.Errorf("expected the filters normalized as ListUsers does, got %+v", params)
+	}
+}
+
+func TestExportUsers_Rejects(t *testing.T) {
+	mockQueries := &MockQueries{
+		ExportUsersFunc: func(ctx context.Context, p db.ExportUsersParams, fn func(db.User) error) error {
+			t.Error("expected nothing to be exported")
+			return nil
+		},
+	}
+	service := NewUserService(mockQueries)
+	noop := func(db.User) error { return nil }
+
+	if err := service.ExportUsers(asAdmin(), ListUsersFilter{Sort: "password_hash"}, noop); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown sort, got %v", err)
+	}
+	for name, ctx := range map[string]context.Context{"anonymous": context.Background(), "non-admin": asUser(1)} {
+		if err := service.ExportUsers(ctx, ListUsersFilter{}, noop); !errors.Is(err, ErrForbidden) {
+			t.Errorf("%s: expected ErrForbidden, got %v", name, err)
+		}
+	}
+}
+
+func TestStartExportUsers_CreatesOperation(t *testing.T) {
+	var params db.CreateOperationParams
+	mockQueries := &MockQueries{
+		CreateOperationFunc: func(ctx context.Context, p db.CreateOperationParams) (db.Operation, error) {
+			params = p
+			return db.Operation{Kind: p.Kind, CreatedBy: p.CreatedBy, Status: "pending"}, nil
+		},
+	}
+	service := NewUserService(mockQueries)
+
+	filter := ListUsersFilter{Name: "fan", Sort: "name"}
+	op, err := service.StartExportUsers(asAdmin(), filter, "text/csv")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if op.Kind != OperationUserExport || params.CreatedBy != 100 {
+		t.Errorf("expected an export started by the caller, got %+v", params)
+	}
+	if params.Total.Valid {
+		t.Errorf("expected the total to be unknown until the export runs, got %+v", params.Total)
+	}
+	var input UserExportInput
+	if err := json.Unmarshal(params.Input, &input); err != nil || input.Filter != filter || input.Format != "text/csv" {
+		t.Errorf("expected the filter and format as input, got %s (%v)", params.Input, err)
+	}
+
+	if _, err := service.StartExportUsers(asAdmin(), ListUsersFilter{Sort: "password_hash"}, "text/csv"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown sort, got %v", err)
+	}
+	if _, err := service.StartExportUsers(asUser(3), ListUsersFilter{}, "text/csv"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden for a non-admin, got %v", err)
+	}
+}
+
+func TestListUsers_RejectsUnknownSort(t *testing.T) {
+	service := NewUserService(&MockQueries{})
+
+	for _, sort := range []string{"password_hash", "name:sideways", "id;DROP TABLE users"} {
+		_, err := service.ListUsers(context.Background(), PageRequest{}, ListUsersFilter{Sort: sort})
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%q: expected ErrInvalidInput, got %v", sort, err)
+		}
+	}
+}
+
+func TestListUsers_CursorKeepsSortOrder(t *testing.T) {
+	var after db.ListUsersAfterParams
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, p db.ListUsersParams) ([]db.ListUsersRow, error) {
+			return listedUsers(3, db.User{ID: 7, Name: "Bea"}, db.User{ID: 3, Name: "Ann"}), nil
+		},
+		ListUsersAfterFunc: func(ctx context.Context, p db.ListUsersAfterParams) ([]db.User, error) {
+			after = p
+			return []db.User{}, nil
+		},
+	}
+	service := NewUserService(mockQueries)
+	filter := ListUsersFilter{Sort: "name:desc"}
+
+	first, err := service.ListUsers(context.Background(), PageRequest{Limit: 1}, filter)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := service.ListUsers(context.Background(), PageRequest{Limit: 1, Cursor: first.NextCursor}, filter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if after.Sort != "name" || !after.Descending || after.AfterText != "Bea" || after.AfterID != 7 {
+		t.Errorf("expected to seek after Bea (7) by name descending, got %+v", after)
+	}
+
+	_, err = service.ListUsers(context.Background(), PageRequest{Cursor: first.NextCursor}, ListUsersFilter{Sort: "name"})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected a cursor from another sort order to be rejected, got %v", err)
+	}
+}
+
+func TestWithPageSizes_DefaultNeverExceedsMax(t *testing.T) {
+	service := NewUserService(&MockQueries{}, WithPageSizes(0, 5))
+
+	page, err := service.ListUsers(context.Background(), PageRequest{}, ListUsersFilter{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if page.Limit != 5 {
+		t.Errorf("expected default limit clamped to 5, got %d", page.Limit)
+	}
+}
+
+func TestGetUsersByIDs_Success(t *testing.T) {
+	var queried []int32
+	mockQueries := &MockQueries{
+		GetUsersByIDsFunc: func(ctx context.Context, ids []int32) ([]db.User, error) {
+			queried = ids
+			// The database returns rows ordered by ID, not request order
+			return []db.User{
+				{ID: 1, Name: "User 1"},
+				{ID: 3, Name: "User 3"},
+			}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	users, missing, err := service.GetUsersByIDs(context.Background(), []int32{3, 2, 1, 3})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(queried) != 3 {
+		t.Errorf("expected duplicate IDs to be collapsed, queried %v", queried)
+	}
+	if len(users) != 2 || users[0].ID != 3 || users[1].ID != 1 {
+		t.Errorf("expected users in request order [3 1], got %v", users)
+	}
+	if len(missing) != 1 || missing[0] != 2 {
+		t.Errorf("expected missing IDs [2], got %v", missing)
+	}
+}
+
+func TestGetUsersByIDs_InvalidInput(t *testing.T) {
+	service := NewUserService(&MockQueries{})
+	_, _, err := service.GetUsersByIDs(context.Background(), nil)
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestGetUsersByIDs_TooMany(t *testing.T) {
+	service := NewUserService(&MockQueries{}, WithMaxBatchSize(2))
+	_, _, err := service.GetUsersByIDs(context.Background(), []int32{1, 2, 3})
+
+	if !errors.Is(err, ErrTooManyIDs) {
+		t.Errorf("expected ErrTooManyIDs, got %v", err)
+	}
+}
+
+func TestListUsers_CorporateFilter(t *testing.T) {
+	var listParams db.ListUsersParams
+	var countParams db.CountUsersParams
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, params db.ListUsersParams) ([]db.ListUsersRow, error) {
+			listParams = params
+			return []db.ListUsersRow{}, nil
+		},
+		CountUsersFunc: func(ctx context.Context, params db.CountUsersParams) (int64, error) {
+			countParams = params
+			return 0, nil
+		},
+	}
+
+	service := NewUserService(mockQueries, WithCorporateDomains([]string{"acme.io"}))
+
+	corporate := false
+	if _, err := service.ListUsers(context.Background(), PageRequest{Limit: 10, Offset: 40}, ListUsersFilter{Corporate: &corporate}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !listParams.Corporate.Valid || listParams.Corporate.Bool {
+		t.Errorf("expected corporate=false filter, got %+v", listParams.Corporate)
+	}
+	if len(listParams.CorporateDomains) != 1 || listParams.CorporateDomains[0] != "acme.io" {
+		t.Errorf("expected configured domains, got %v", listParams.CorporateDomains)
+	}
+	if countParams.Corporate != listParams.Corporate {
+		t.Errorf("expected count to use the same filter as the list, got %+v", countParams.Corporate)
+	}
+
+	// No filter leaves the corporate param NULL so every user is returned
+	if _, err := service.ListUsers(context.Background(), PageRequest{Limit: 10}, ListUsersFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if listParams.Corporate.Valid {
+		t.Errorf("expected no corporate filter, got %+v", listParams.Corporate)
+	}
+}
+
+func TestUpdateUser_Success(t *testing.T) {
+	now := time.Now()
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{
+				ID:    1,
+				Name:  "Old Name",
+				Email: "old@example.com",
+			}, nil
+		},
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{}, sql.ErrNoRows // No duplicate
+		},
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			return db.User{
+				ID:        params.ID,
+				Name:      params.Name,
+				Email:     params.Email,
+				UpdatedAt: timeToTimestamptz(now),
+			}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	user, err := service.UpdateUser(asAdmin(), 1, "New Name", "new@example.com", ProfileUpdate{}, 0)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.Name != "New Name" {
+		t.Errorf("expected name 'New Name', got %s", user.Name)
+	}
+}
+
+func TestUpdateUser_NotFound(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{}, sql.ErrNoRows
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	_, err := service.UpdateUser(asAdmin(), 999, "Name", "email@example.com", ProfileUpdate{}, 0)
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestUpdateUser_DuplicateEmailRace(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1, Name: "Old Name", Email: "old@example.com"}, nil
+		},
+		// The pre-check sees the email as free, but another user takes it first
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{}, sql.ErrNoRows
+		},
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			return db.User{}, &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	_, err := service.UpdateUser(asAdmin(), 1, "", "taken@example.com", ProfileUpdate{}, 0)
+
+	if !errors.Is(err, ErrDuplicateEmail) {
+		t.Errorf("expected ErrDuplicateEmail, got %v", err)
+	}
+}
+
+func TestUpdateUser_KeepsOwnEmail(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1, Name: "Old Name", Email: "old@example.com"}, nil
+		},
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			t.Error("expected no duplicate check when the email is unchanged")
+			return db.User{ID: 1}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			return db.User{ID: params.ID, Name: params.Name, Email: params.Email}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	user, err := service.UpdateUser(asAdmin(), 1, "New Name", "old@example.com", ProfileUpdate{}, 0)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.Email != "old@example.com" {
+		t.Errorf("expected email to be kept, got %s", user.Email)
+	}
+}
+
+func TestUpdateUser_StaleVersion(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1, Name: "Old Name", Email: "old@example.com", Version: 3}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			t.Error("expected no update from a stale version")
+			return db.User{}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	_, err := service.UpdateUser(asAdmin(), 1, "New Name", "", ProfileUpdate{}, 2)
+
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("expected ErrVersionMismatch, got %v", err)
+	}
+}
+
+func TestUpdateUser_ConcurrentEdit(t *testing.T) {
+	var gotVersion int32
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1, Name: "Old Name", Email: "old@example.com", Version: 3}, nil
+		},
+		// Another editor's update lands between the read and the write
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			gotVersion = params.Version
+			return db.User{}, sql.ErrNoRows
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	_, err := service.UpdateUser(asAdmin(), 1, "New Name", "", ProfileUpdate{}, 0)
+
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("expected ErrVersionMismatch, got %v", err)
+	}
+	if gotVersion != 3 {
+		t.Errorf("expected the update to require the version read, got %d", gotVersion)
+	}
+}
+
+func TestUpdateUser_BlankName(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1, Name: "Old Name", Email: "old@example.com"}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	_, err := service.UpdateUser(asAdmin(), 1, "   ", "", ProfileUpdate{}, 0)
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestUpdateUser_Profile(t *testing.T) {
+	var params db.UpdateUserParams
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{
+				ID:            1,
+				Name:          "Old Name",
+				Email:         "old@example.com",
+				YoutubeHandle: pgtype.Text{String: "OldChannel", Valid: true},
+				Pronouns:      pgtype.Text{String: "they/them", Valid: true},
+			}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, p db.UpdateUserParams) (db.User, error) {
+			params = p
+			return db.User{ID: p.ID, Name: p.Name, Email: p.Email}, nil
+		},
+	}
+
+	twitch, country, pronouns, bio := " @mario_speedruns ", "se", "", "  Runs N64 games.  "
+	service := NewUserService(mockQueries)
+	_, err := service.UpdateUser(asUser(1), 1, "", "", ProfileUpdate{
+		TwitchHandle: &twitch,
+		CountryCode:  &country,
+		Pronouns:     &pronouns,
+		Bio:          &bio,
+	}, 0)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.TwitchHandle.String != "mario_speedruns" || params.CountryCode.String != "SE" || params.Bio.String != "Runs N64 games." {
+		t.Errorf("expected normalized profile details, got %+v", params)
+	}
+	if params.YoutubeHandle.String != "OldChannel" {
+		t.Errorf("expected the unchanged YouTube handle to be kept, got %+v", params.YoutubeHandle)
+	}
+	if params.Pronouns.Valid {
+		t.Errorf("expected empty pronouns to clear them, got %+v", params.Pronouns)
+	}
+}
+
+func TestUpdateUser_InvalidProfile(t *testing.T) {
+	value := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		profile ProfileUpdate
+	}{
+		{"short twitch handle", ProfileUpdate{TwitchHandle: value("abc")}},
+		{"twitch handle starting with an underscore", ProfileUpdate{TwitchHandle: value("_mario")}},
+		{"youtube handle with spaces", ProfileUpdate{YoutubeHandle: value("Mario Runs")}},
+		{"long twitter handle", ProfileUpdate{TwitterHandle: value("mario_speedruns_64")}},
+		{"unknown country", ProfileUpdate{CountryCode: value("XX")}},
+		{"three letter country", ProfileUpdate{CountryCode: value("SWE")}},
+		{"long pronouns", ProfileUpdate{Pronouns: value(strings.Repeat("a", maxPronounsLength+1))}},
+		{"long bio", ProfileUpdate{Bio: value(strings.Repeat("a", maxBioLength+1))}},
+	}
+
+	updated := false
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1, Name: "Old Name", Email: "old@example.com"}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, p db.UpdateUserParams) (db.User, error) {
+			updated = true
+			return db.User{}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	for _, tt := range tests {
+		_, err := service.UpdateUser(asUser(1), 1, "", "", tt.profile, 0)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+	if updated {
+		t.Error("expected no invalid profile to be stored")
+	}
+}
+
+func TestDeleteUser_Success(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+		DeleteUserFunc: func(ctx context.Context, id int32) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	err := service.DeleteUser(asAdmin(), 1)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteUser_NotFound(t *testing.T) {
+	// Already-deleted users match no rows either
+	service := NewUserService(&MockQueries{})
+	err := service.DeleteUser(asAdmin(), 999)
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestPurgeUser_Success(t *testing.T) {
+	purged := false
+	mockQueries := &MockQueries{
+		GetUserByIDIncludingDeletedFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1, DeletedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		PurgeUserFunc: func(ctx context.Context, id int32) (int64, error) {
+			purged = true
+			return 1, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	err := service.PurgeUser(asAdmin(), 1)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if !purged {
+		t.Error("expected PurgeUser query to be called")
+	}
+}
+
+func TestPurgeUser_Active(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDIncludingDeletedFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: 1}, nil
+		},
+		PurgeUserFunc: func(ctx context.Context, id int32) (int64, error) {
+			t.Error("expected active user not to be purged")
+			return 0, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	err := service.PurgeUser(asAdmin(), 1)
+
+	if !errors.Is(err, ErrUserActive) {
+		t.Errorf("expected ErrUserActive, got %v", err)
+	}
+}
+
+func TestPurgeUser_NotFound(t *testing.T) {
+	service := NewUserService(&MockQueries{})
+	err := service.PurgeUser(asAdmin(), 999)
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestRestoreUser(t *testing.T) {
+	deletedAt := timeToTimestamptz(time.Now())
+	mockQueries := &MockQueries{
+		RestoreUserFunc: func(ctx context.Context, id int32) (db.User, error) {
+			if id != 1 {
+				return db.User{}, sql.ErrNoRows
+			}
+			return db.User{ID: 1}, nil
+		},
+		GetUserByIDIncludingDeletedFunc: func(ctx context.Context, id int32) (db.User, error) {
+			switch id {
+			case 1:
+				return db.User{ID: 1, DeletedAt: deletedAt}, nil
+			case 2:
+				return db.User{ID: 2}, nil
+			}
+			return db.User{}, sql.ErrNoRows
+		},
+	}
+	service := NewUserService(mockQueries)
+
+	if user, err := service.RestoreUser(asAdmin(), 1); err != nil || user.ID != 1 {
+		t.Errorf("deleted user: expected user 1 restored, got %v, %v", user, err)
+	}
+	if _, err := service.RestoreUser(asAdmin(), 2); !errors.Is(err, ErrUserActive) {
+		t.Errorf("active user: expected ErrUserActive, got %v", err)
+	}
+	if _, err := service.RestoreUser(asAdmin(), 3); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("missing user: expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := service.RestoreUser(asUser(1), 1); !errors.Is(err, ErrForbidden) {
+		t.Errorf("non-admin: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestGrantRole(t *testing.T) {
+	var params db.GrantUserRoleParams
+	var audited db.CreateAuditEventParams
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			if id != 1 {
+				return db.User{}, sql.ErrNoRows
+			}
+			return db.User{ID: 1}, nil
+		},
+		GrantUserRoleFunc: func(ctx context.Context, p db.GrantUserRoleParams) (db.UserRole, error) {
+			params = p
+			return db.UserRole{ID: 5, UserID: p.UserID, Role: p.Role, GameID: p.GameID}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = p
+			return nil
+		},
+	}
+	service := NewUserService(mockQueries)
+
+	grant, err := service.GrantRole(asAdmin(), 1, auth.RoleModerator, 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if grant.ID != 5 || params.Role != "moderator" || params.GameID != (pgtype.Int4{Int32: 7, Valid: true}) {
+		t.Errorf("expected a moderator grant for game 7, got %+v", params)
+	}
+	if audited.Action != "user.grant_role" || audited.EntityID != 1 {
+		t.Errorf("expected the grant to be audited, got %+v", audited)
+	}
+
+	if _, err := service.GrantRole(asAdmin(), 1, auth.RoleAdmin, 0); err != nil || params.GameID.Valid {
+		t.Errorf("global grant: expected no game, got %+v, %v", params.GameID, err)
+	}
+	if _, err := service.GrantRole(asAdmin(), 1, "owner", 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("unknown role: expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := service.GrantRole(asAdmin(), 2, auth.RoleAdmin, 0); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("missing user: expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := service.GrantRole(asUser(1), 1, auth.RoleAdmin, 0); !errors.Is(err, ErrForbidden) {
+		t.Errorf("non-admin: expected ErrForbidden, got %v", err)
+	}
+
+	mockQueries.GrantUserRoleFunc = func(ctx context.Context, p db.GrantUserRoleParams) (db.UserRole, error) {
+		return db.UserRole{}, sql.ErrNoRows
+	}
+	if _, err := service.GrantRole(asAdmin(), 1, auth.RoleAdmin, 0); !errors.Is(err, ErrRoleAlreadyGranted) {
+		t.Errorf("existing grant: expected ErrRoleAlreadyGranted, got %v", err)
+	}
+}
+
+func TestListUsers_IncludeDeletedRequiresAdmin(t *testing.T) {
+	var params db.ListUsersParams
+	mockQueries := &MockQueries{
+		ListUsersFunc: func(ctx context.Context, p db.ListUsersParams) ([]db.ListUsersRow, error) {
+			params = p
+			return []db.ListUsersRow{}, nil
+		},
+	}
+	service := NewUserService(mockQueries)
+	filter := ListUsersFilter{IncludeDeleted: true}
+
+	if _, err := service.ListUsers(asUser(1), PageRequest{}, filter); !errors.Is(err, ErrForbidden) {
+		t.Errorf("non-admin: expected ErrForbidden, got %v", err)
+	}
+	if _, err := service.ListUsers(asAdmin(), PageRequest{}, filter); err != nil {
+		t.Fatalf("admin: expected no error, got %v", err)
+	}
+	if !params.IncludeDeleted {
+		t.Error("expected the query to include deleted users")
+	}
+}
+
+func TestUpdateUser_Authorization(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Old Name", Email: "old@example.com"}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			return db.User{ID: params.ID, Name: params.Name}, nil
+		},
+	}
+	service := NewUserService(mockQueries)
+
+	if _, err := service.UpdateUser(asUser(1), 1, "New Name", "", ProfileUpdate{}, 0); err != nil {
+		t.Errorf("self: expected no error, got %v", err)
+	}
+	if _, err := service.UpdateUser(asUser(2), 1, "New Name", "", ProfileUpdate{}, 0); !errors.Is(err, ErrForbidden) {
+		t.Errorf("other user: expected ErrForbidden, got %v", err)
+	}
+	if _, err := service.UpdateUser(context.Background(), 1, "New Name", "", ProfileUpdate{}, 0); !errors.Is(err, ErrForbidden) {
+		t.Errorf("anonymous: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestDeleteUser_Forbidden(t *testing.T) {
+	mockQueries := &MockQueries{
+		DeleteUserFunc: func(ctx context.Context, id int32) (int64, error) {
+			t.Fatal("DeleteUser should not be called")
+			return 0, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	err := service.DeleteUser(asUser(2, auth.Grant{Role: auth.RoleModerator}), 1)
+
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestPurgeUser_RequiresAdmin(t *testing.T) {
+	mockQueries := &MockQueries{
+		PurgeUserFunc: func(ctx context.Context, id int32) (int64, error) {
+			t.Fatal("PurgeUser should not be called")
+			return 0, nil
+		},
+	}
+
+	service := NewUserService(mockQueries)
+	err := service.PurgeUser(asUser(1), 1)
+
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestGetUserStats(t *testing.T) {
+	mock := &MockQueries{
+		GetUserStatsFunc: func(ctx context.Context, corporateDomains []string) (db.GetUserStatsRow, error) {
+			if len(corporateDomains) != 1 || corporateDomains[0] != "acme.io" {
+				t.Errorf("expected configured corporate domains, got %v", corporateDomains)
+			}
+			return db.GetUserStatsRow{Total: 10, Corporate: 4, Last24h: 1, Last7d: 3, Last30d: 6}, nil
+		},
+	}
+
+	service := NewUserService(mock, WithCorporateDomains([]string{"acme.io"}))
+	stats, err := service.GetUserStats(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := UserStats{Total: 10, Corporate: 4, NonCorporate: 6, NewLast24h: 1, NewLast7d: 3, NewLast30d: 6}
+	if *stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, *stats)
+	}
+}
+
+func TestIsCorporateEmail(t *testing.T) {
+	service := NewUserService(&MockQueries{})
+
+	tests := []struct {
+		email    string
+		expected bool
+	}{
+		{"user@company.com", true},
+		{"user@enterprise.com", true},
+		{"user@gmail.com", false},
+		{"user@example.com", false},
 	}
 
 	for _, tt := range tests {
@@ -320,3 +1769,291 @@ func TestIsCorporateEmail(t *testing.T) {
 		}
 	}
 }
+
+// recordingMailer keeps the messages it is asked to send
+type recordingMailer struct {
+	sent []mailer.Message
+	err  error
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+// verificationToken returns the token in a verification email
+func verificationToken(t *testing.T, msg mailer.Message) string {
+	t.Helper()
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if strings.Count(line, ".") == 2 && !strings.Contains(line, " ") {
+			return line
+		}
+	}
+	t.Fatalf("no token in email body %q", msg.Body)
+	return ""
+}
+
+func TestCreateUser_SendsVerificationEmail(t *testing.T) {
+	mail := &recordingMailer{}
+	signer := auth.NewSigner([]byte("test-secret"), time.Hour)
+	mockQueries := &MockQueries{
+		CreateUserFunc: func(ctx context.Context, params db.CreateUserParams) (db.User, error) {
+			return db.User{ID: 7, Name: params.Name, Email: params.Email}, nil
+		},
+	}
+
+	service := NewUserService(mockQueries, WithEmailVerification(signer, time.Hour), WithMailer(mail))
+	if _, err := service.CreateUser(context.Background(), "Jane", "jane@example.com"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(mail.sent) != 1 || mail.sent[0].To != "jane@example.com" {
+		t.Fatalf("expected one email to jane@example.com, got %+v", mail.sent)
+	}
+	userID, email, err := signer.VerifyEmailVerification(verificationToken(t, mail.sent[0]))
+	if err != nil || userID != 7 || email != "jane@example.com" {
+		t.Errorf("expected a token for user 7 at jane@example.com, got %d %q %v", userID, email, err)
+	}
+}
+
+func TestCreateUser_SucceedsWhenMailFails(t *testing.T) {
+	mail := &recordingMailer{err: errors.New("connection refused")}
+	mockQueries := &MockQueries{
+		CreateUserFunc: func(ctx context.Context, params db.CreateUserParams) (db.User, error) {
+			return db.User{ID: 7, Name: params.Name, Email: params.Email}, nil
+		},
+	}
+
+	signer := auth.NewSigner([]byte("test-secret"), time.Hour)
+	service := NewUserService(mockQueries, WithEmailVerification(signer, time.Hour), WithMailer(mail))
+	if _, err := service.CreateUser(context.Background(), "Jane", "jane@example.com"); err != nil {
+		t.Errorf("expected the user to be created anyway, got %v", err)
+	}
+}
+
+func TestUpdateUser_ReverifiesChangedEmail(t *testing.T) {
+	mail := &recordingMailer{}
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Name: "Jane", Email: "old@example.com", Version: 1}, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, params db.UpdateUserParams) (db.User, error) {
+			return db.User{ID: params.ID, Name: params.Name, Email: params.Email, Version: 2}, nil
+		},
+	}
+
+	signer := auth.NewSigner([]byte("test-secret"), time.Hour)
+	service := NewUserService(mockQueries, WithEmailVerification(signer, time.Hour), WithMailer(mail))
+	if _, err := service.UpdateUser(asUser(1), 1, "Janet", "", ProfileUpdate{}, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(mail.sent) != 0 {
+		t.Fatalf("expected no email when the address is unchanged, got %+v", mail.sent)
+	}
+
+	if _, err := service.UpdateUser(asUser(1), 1, "", "new@example.com", ProfileUpdate{}, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(mail.sent) != 1 || mail.sent[0].To != "new@example.com" {
+		t.Errorf("expected one email to the new address, got %+v", mail.sent)
+	}
+}
+
+func TestVerifyEmail_Success(t *testing.T) {
+	signer := auth.NewSigner([]byte("test-secret"), time.Hour)
+	token, _ := signer.IssueEmailVerification(7, "jane@example.com", time.Hour)
+	var audited string
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Email: "jane@example.com"}, nil
+		},
+		VerifyUserEmailFunc: func(ctx context.Context, params db.VerifyUserEmailParams) (db.User, error) {
+			if params.ID != 7 || params.Email != "jane@example.com" {
+				t.Errorf("expected user 7 at jane@example.com, got %+v", params)
+			}
+			return db.User{ID: params.ID, Email: params.Email, EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, params db.CreateAuditEventParams) error {
+			audited = params.Action
+			return nil
+		},
+	}
+
+	mail := &recordingMailer{}
+	service := NewUserService(mockQueries, WithEmailVerification(signer, time.Hour), WithMailer(mail))
+	user, err := service.VerifyEmail(context.Background(), token)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !user.EmailVerifiedAt.Valid {
+		t.Error("expected the user to be verified")
+	}
+	if audited != "user.verify_email" {
+		t.Errorf("expected user.verify_email to be audited, got %q", audited)
+	}
+	if len(mail.sent) != 1 || mail.sent[0].To != "jane@example.com" || !strings.HasPrefix(mail.sent[0].Subject, "Welcome") {
+		t.Errorf("expected a welcome email to jane@example.com, got %+v", mail.sent)
+	}
+}
+
+func TestVerifyEmail_AlreadyVerified(t *testing.T) {
+	signer := auth.NewSigner([]byte("test-secret"), time.Hour)
+	token, _ := signer.IssueEmailVerification(7, "jane@example.com", time.Hour)
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, Email: "jane@example.com", EmailVerifiedAt: timeToTimestamptz(time.Now())}, nil
+		},
+	}
+
+	mail := &recordingMailer{}
+	service := NewUserService(mockQueries, WithEmailVerification(signer, time.Hour), WithMailer(mail))
+	user, err := service.VerifyEmail(context.Background(), token)
+
+	if err != nil || !user.EmailVerifiedAt.Valid {
+		t.Errorf("expected the verified user to be returned, got %+v %v", user, err)
+	}
+	if len(mail.sent) != 0 {
+		t.Errorf("expected no second welcome email, got %+v", mail.sent)
+	}
+}
+
+func TestVerifyEmail_Rejects(t *testing.T) {
+	signer := auth.NewSigner([]byte("test-secret"), time.Hour)
+	changed, _ := signer.IssueEmailVerification(7, "old@example.com", time.Hour)
+	deleted, _ := signer.IssueEmailVerification(8, "gone@example.com", time.Hour)
+	access, _ := signer.Issue(7)
+	otherKey, _ := auth.NewSigner([]byte("other-secret"), time.Hour).IssueEmailVerification(7, "jane@example.com", time.Hour)
+
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			if id != 7 {
+				return db.User{}, sql.ErrNoRows
+			}
+			return db.User{ID: id, Email: "jane@example.com"}, nil
+		},
+	}
+	service := NewUserService(mockQueries, WithEmailVerification(signer, time.Hour))
+
+	tests := map[string]string{
+		"changed email": changed,
+		"deleted user":  deleted,
+		"access token":  access,
+		"other key":     otherKey,
+		"garbage":       "not-a-token",
+	}
+	for name, token := range tests {
+		if _, err := service.VerifyEmail(context.Background(), token); !errors.Is(err, ErrInvalidVerificationToken) {
+			t.Errorf("%s: expected ErrInvalidVerificationToken, got %v", name, err)
+		}
+	}
+}
+
+// memoryStore is a storage.Store keeping objects in a map
+type memoryStore struct {
+	objects map[string]storage.Object
+	deleted []string
+}
+
+func (m *memoryStore) Put(ctx context.Context, obj storage.Object) error {
+	m.objects[obj.Key] = obj
+	return nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, key string) error {
+	m.deleted = append(m.deleted, key)
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memoryStore) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+// encodeTestImage returns a width by height PNG
+func encodeTestImage(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := range width {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSetAvatar_Success(t *testing.T) {
+	publicID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	oldURL := "https://cdn.example.com/avatars/" + uuid.UUID(publicID.Bytes).String() + "/0011223344556677.png"
+	var stored db.SetUserAvatarParams
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id, PublicID: publicID, AvatarUrl: pgtype.Text{String: oldURL, Valid: true}}, nil
+		},
+		SetUserAvatarFunc: func(ctx context.Context, params db.SetUserAvatarParams) (db.User, error) {
+			stored = params
+			return db.User{ID: params.ID, PublicID: publicID, AvatarUrl: params.AvatarUrl}, nil
+		},
+	}
+	store := &memoryStore{objects: map[string]storage.Object{}}
+	service := NewUserService(mockQueries, WithAvatarStore(store), WithAvatarSize(64))
+
+	user, err := service.SetAvatar(asUser(1), bytes.NewReader(encodeTestImage(t, 300, 200)))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stored.ID != 1 || user.AvatarUrl != stored.AvatarUrl {
+		t.Errorf("expected the caller's avatar to be set, got %+v and user %+v", stored, user)
+	}
+	key := strings.TrimPrefix(stored.AvatarUrl.String, "https://cdn.example.com/")
+	obj, ok := store.objects[key]
+	if !ok || !strings.HasPrefix(key, "avatars/"+uuid.UUID(publicID.Bytes).String()+"/") {
+		t.Fatalf("expected the avatar stored under the user's public ID, got %q in %v", key, store.objects)
+	}
+	if obj.ContentType != "image/png" || obj.CacheControl != avatarCacheControl {
+		t.Errorf("unexpected object headers %q, %q", obj.ContentType, obj.CacheControl)
+	}
+	img, err := png.Decode(bytes.NewReader(obj.Data))
+	if err != nil || img.Bounds() != image.Rect(0, 0, 64, 64) {
+		t.Errorf("expected a 64x64 PNG, got %v, %v", img, err)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != strings.TrimPrefix(oldURL, "https://cdn.example.com/") {
+		t.Errorf("expected the old avatar deleted, got %v", store.deleted)
+	}
+}
+
+func TestSetAvatar_Errors(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetUserByIDFunc: func(ctx context.Context, id int32) (db.User, error) {
+			return db.User{ID: id}, nil
+		},
+	}
+	store := &memoryStore{objects: map[string]storage.Object{}}
+	service := NewUserService(mockQueries, WithAvatarStore(store))
+	valid := encodeTestImage(t, 10, 10)
+
+	tests := []struct {
+		name    string
+		service *UserService
+		ctx     context.Context
+		upload  []byte
+		want    error
+	}{
+		{"anonymous", service, context.Background(), valid, ErrForbidden},
+		{"disabled", NewUserService(mockQueries), asUser(1), valid, ErrAvatarsDisabled},
+		{"not an image", service, asUser(1), []byte("hello"), ErrInvalidInput},
+		{"truncated", service, asUser(1), valid[:len(valid)/2], ErrInvalidInput},
+		{"too many pixels", service, asUser(1), encodeTestImage(t, 5000, 4000), ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		if _, err := tt.service.SetAvatar(tt.ctx, bytes.NewReader(tt.upload)); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+	if len(store.objects) != 0 {
+		t.Errorf("expected nothing stored, got %v", store.objects)
+	}
+}
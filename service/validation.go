@@ -0,0 +1,246 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// FieldError describes why one field of the input failed validation
+type FieldError struct {
+	// Field is the name of the request field, such as video_url
+	Field string
+	
+	// Message says what is wrong with the field's value, such as "must not
+	// be empty"
+	Message string
+}
+
+// ValidationError is returned when input fails validation, listing each
+// field that failed it so that it can be reported against that field
+//
+// errors.Is reports a ValidationError as ErrInvalidInput.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + " " + field.Message
+	}
+	return ErrInvalidInput.Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Is reports a ValidationError as ErrInvalidInput
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// add records that field failed validation, with its message formatted from
+// format and args
+func (e *ValidationError) add(field, format string, args ...any) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// invalidField returns a ValidationError for one field, with its message
+// formatted from format and args
+func invalidField(field, format string, args ...any) error {
+	var invalid ValidationError
+	invalid.add(field, format, args...)
+	return &invalid
+}
+
+// joinValidation combines the results of validating several fields so that
+// all of the fields that failed are reported at once
+//
+// Returns:
+//   - error: nil if every err is nil, a ValidationError listing the fields
+//     of every ValidationError among errs, or else the first other error
+func joinValidation(errs ...error) error {
+	var joined ValidationError
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) {
+			return err
+		}
+		joined.Fields = append(joined.Fields, invalid.Fields...)
+	}
+	if len(joined.Fields) == 0 {
+		return nil
+	}
+	return &joined
+}
+
+// defaultMaxNameLength matches the VARCHAR(255) users.name column
+const defaultMaxNameLength = 255
+
+const (
+	// minPasswordLength is the fewest characters a password may have
+	minPasswordLength = 8
+	
+	// maxPasswordBytes is the longest password bcrypt can hash; anything
+	// beyond it would be silently ignored, so it is rejected instead
+	maxPasswordBytes = 72
+)
+
+const (
+	// maxPronounsLength matches the VARCHAR(40) users.pronouns column
+	maxPronounsLength = 40
+	
+	// maxBioLength matches the check on users.bio
+	maxBioLength = 1000
+)
+
+var (
+	// twitchHandlePattern matches Twitch usernames: 4 to 25 letters, digits,
+	// and underscores, not starting with an underscore
+	twitchHandlePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_]{3,24}$`)
+	
+	// youtubeHandlePattern matches YouTube handles: 3 to 30 letters, digits,
+	// underscores, hyphens, and periods
+	youtubeHandlePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,30}$`)
+	
+	// twitterHandlePattern matches Twitter usernames: up to 15 letters,
+	// digits, and underscores
+	twitterHandlePattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,15}$`)
+)
+
+// validateName normalizes and validates a user's name
+//
+// Leading and trailing whitespace is trimmed before any other check, so a
+// name made up only of spaces is rejected as empty.
+//
+// Returns:
+//   - string: The trimmed name
+//   - error: A ValidationError for the name field
+func (s *UserService) validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	
+	if name == "" {
+		return "", invalidField("name", "must not be empty")
+	}
+	if utf8.RuneCountInString(name) > s.maxNameLength {
+		return "", invalidField("name", "must be at most %d characters", s.maxNameLength)
+	}
+	
+	return name, nil
+}
+
+// validateEmail checks that email is a bare email address, such as
+// jane@example.com, without a display name or angle brackets
+//
+// Returns:
+//   - error: A ValidationError for the email field
+func validateEmail(email string) error {
+	if email == "" {
+		return invalidField("email", "must not be empty")
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" || address.Address != email {
+		return invalidField("email", "must be a valid email address")
+	}
+	return nil
+}
+
+// validatePassword checks a new password against the length policy
+//
+// Passwords are not trimmed: surrounding whitespace is part of what the user
+// typed and must match at login.
+//
+// Returns:
+//   - error: A ValidationError for the password field
+func validatePassword(password string) error {
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return invalidField("password", "must be at least %d characters", minPasswordLength)
+	}
+	if len(password) > maxPasswordBytes {
+		return invalidField("password", "must be at most %d bytes", maxPasswordBytes)
+	}
+	
+	return nil
+}
+
+// validateProfileUpdate normalizes and validates the profile details being
+// changed, leaving unchanged (nil) ones alone
+//
+// Surrounding whitespace is trimmed from every detail, a leading @ is dropped
+// from handles, and country codes are upper-cased. A detail that is empty
+// after trimming clears it. Every invalid detail is reported, not just the
+// first.
+//
+// Returns:
+//   - ProfileUpdate: The normalized changes
+//   - error: A ValidationError listing the invalid details
+func validateProfileUpdate(update ProfileUpdate) (ProfileUpdate, error) {
+	var invalid ValidationError
+	handles := []struct {
+		field   string
+		value   **string
+		pattern *regexp.Regexp
+		rule    string
+	}{
+		{"twitch_handle", &update.TwitchHandle, twitchHandlePattern, "4 to 25 letters, digits, and underscores"},
+		{"youtube_handle", &update.YoutubeHandle, youtubeHandlePattern, "3 to 30 letters, digits, underscores, hyphens, and periods"},
+		{"twitter_handle", &update.TwitterHandle, twitterHandlePattern, "at most 15 letters, digits, and underscores"},
+	}
+	for _, h := range handles {
+		if *h.value == nil {
+			continue
+		}
+		handle := strings.TrimPrefix(strings.TrimSpace(**h.value), "@")
+		if handle != "" && !h.pattern.MatchString(handle) {
+			invalid.add(h.field, "must be %s", h.rule)
+		}
+		*h.value = &handle
+	}
+	
+	if update.CountryCode != nil {
+		code := strings.ToUpper(strings.TrimSpace(*update.CountryCode))
+		if code != "" && !countryCodes[code] {
+			invalid.add("country_code", "must be an ISO 3166-1 alpha-2 code")
+		}
+		update.CountryCode = &code
+	}
+	if update.Pronouns != nil {
+		pronouns := strings.TrimSpace(*update.Pronouns)
+		if utf8.RuneCountInString(pronouns) > maxPronounsLength {
+			invalid.add("pronouns", "must be at most %d characters", maxPronounsLength)
+		}
+		update.Pronouns = &pronouns
+	}
+	if update.Bio != nil {
+		bio := strings.TrimSpace(*update.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			invalid.add("bio", "must be at most %d characters", maxBioLength)
+		}
+		update.Bio = &bio
+	}
+	
+	if len(invalid.Fields) > 0 {
+		return ProfileUpdate{}, &invalid
+	}
+	return update, nil
+}
+
+// profileText resolves one profile detail for storage: the current value when
+// it is not being changed, NULL when it is being cleared, and the new value
+// otherwise
+func profileText(change *string, current pgtype.Text) pgtype.Text {
+	if change == nil {
+		return current
+	}
+	if *change == "" {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *change, Valid: true}
+}
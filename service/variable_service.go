@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrDuplicateVariableSlug is returned when a game already has a variable with the slug
+	ErrDuplicateVariableSlug = errors.New("variable with this slug already exists")
+)
+
+const (
+	// variablesGameSlugKey is the unique constraint on (variables.game_id, variables.slug)
+	variablesGameSlugKey = "variables_game_id_slug_key"
+	
+	// maxVariableValues keeps a variable's values to a list a runner can pick from
+	maxVariableValues = 100
+)
+
+// VariableService handles business logic for the variables of a game, such
+// as difficulty or character, that runs record the value they were played with
+type VariableService struct {
+	queries db.Store
+}
+
+// Variable is a variable along with its allowed values in display order
+type Variable struct {
+	db.Variable
+	Values []db.VariableValue `json:"values"`
+}
+
+// CreateVariableInput holds the details of a variable being created
+type CreateVariableInput struct {
+	Slug string
+	Name string
+	
+	// CategorySlug limits the variable to one of the game's categories;
+	// empty applies it to every category
+	CategorySlug string
+	
+	// Values are the values runs may choose from, in display order
+	Values []CreateVariableValueInput
+}
+
+// CreateVariableValueInput holds the details of one allowed value of a variable
+type CreateVariableValueInput struct {
+	Slug  string
+	Label string
+}
+
+// RunVariables holds the values a run was played with, keyed by variable slug
+type RunVariables map[string]string
+
+// NewVariableService creates a new VariableService instance
+func NewVariableService(queries db.Store) *VariableService {
+	return &VariableService{queries: queries}
+}
+
+// ListVariables retrieves every variable of a game along with its values
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - gameSlug: Slug of the game
+//
+// Returns:
+//   - []Variable: The variables in the order they were created
+//   - error: ErrGameNotFound, or database errors
+func (s *VariableService) ListVariables(ctx context.Context, gameSlug string) ([]Variable, error) {
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	
+	return listGameVariables(ctx, s.queries, game.ID)
+}
+
+// CreateVariable adds a variable and its allowed values to a game; only admins
+// and the game's super moderators may
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - gameSlug: Slug of the game the variable belongs to
+//   - input: The variable details
+//
+// Returns:
+//   - *Variable: The created variable
+//   - error: ErrInvalidInput, ErrGameNotFound, ErrForbidden,
+//     ErrCategoryNotFound, ErrDuplicateVariableSlug, or database errors
+func (s *VariableService) CreateVariable(ctx context.Context, gameSlug string, input CreateVariableInput) (*Variable, error) {
+	if err := validateSlug(input.Slug); err != nil {
+		return nil, err
+	}
+	name, err := validateDisplayName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	values, err := validateVariableValues(input.Values)
+	if err != nil {
+		return nil, err
+	}
+	
+	game, err := s.getGame(ctx, gameSlug)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireGameManager(ctx, game.ID); err != nil {
+		return nil, err
+	}
+	categoryID := pgtype.Int4{}
+	if input.CategorySlug != "" {
+		category, err := s.queries.GetCategoryBySlug(ctx, db.GetCategoryBySlugParams{
+			GameSlug:     gameSlug,
+			CategorySlug: input.CategorySlug,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrCategoryNotFound
+			}
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		categoryID = pgtype.Int4{Int32: category.ID, Valid: true}
+	}
+	
+	var variable Variable
+	err = s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		variable.Variable, err = q.CreateVariable(ctx, db.CreateVariableParams{
+			GameID:     game.ID,
+			CategoryID: categoryID,
+			Slug:       input.Slug,
+			Name:       name,
+		})
+		if err != nil {
+			return err
+		}
+		for i, value := range values {
+			created, err := q.CreateVariableValue(ctx, db.CreateVariableValueParams{
+				VariableID: variable.ID,
+				Slug:       value.Slug,
+				Label:      value.Label,
+				Position:   int32(i),
+			})
+			if err != nil {
+				return err
+			}
+			variable.Values = append(variable.Values, created)
+		}
+		return recordAudit(ctx, q, "variable.create", AuditEntityVariable, variable.ID, nil, variable)
+	})
+	if err != nil {
+		if isDuplicateVariableSlugError(err) {
+			return nil, ErrDuplicateVariableSlug
+		}
+		return nil, fmt.Errorf("failed to create variable: %w", err)
+	}
+	
+	return &variable, nil
+}
+
+// getGame looks up the game that owns a set of variables
+func (s *VariableService) getGame(ctx context.Context, slug string) (*db.Game, error) {
+	game, err := s.queries.GetGameBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	return &game, nil
+}
+
+// listGameVariables loads a game's variables and groups their values under them
+func listGameVariables(ctx context.Context, q db.Querier, gameID int32) ([]Variable, error) {
+	variables, err := q.ListVariablesByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variables: %w", err)
+	}
+	values, err := q.ListVariableValuesByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variable values: %w", err)
+	}
+	
+	result := make([]Variable, len(variables))
+	index := make(map[int32]int, len(variables))
+	for i, variable := range variables {
+		result[i] = Variable{Variable: variable, Values: []db.VariableValue{}}
+		index[variable.ID] = i
+	}
+	for _, value := range values {
+		if i, ok := index[value.VariableID]; ok {
+			result[i].Values = append(result[i].Values, value)
+		}
+	}
+	return result, nil
+}
+
+// resolveRunVariables looks up the values chosen for a run in category,
+// given as value slugs keyed by variable slug
+//
+// Returns:
+//   - []db.VariableValue: The chosen values, ordered by ID; empty, not nil,
+//     when none were chosen, so it can be passed to queries as an array
+//   - error: ErrInvalidInput if a variable does not apply to the category or a
+//     value is not one of its variable's, or database errors
+func resolveRunVariables(ctx context.Context, q db.Querier, category *db.Category, chosen RunVariables) ([]db.VariableValue, error) {
+	resolved := []db.VariableValue{}
+	if len(chosen) == 0 {
+		return resolved, nil
+	}
+	
+	variables, err := listGameVariables(ctx, q, category.GameID)
+	if err != nil {
+		return nil, err
+	}
+	for variableSlug, valueSlug := range chosen {
+		i := slices.IndexFunc(variables, func(v Variable) bool {
+			return v.Slug == variableSlug && (!v.CategoryID.Valid || v.CategoryID.Int32 == category.ID)
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s is not a variable of this category", ErrInvalidInput, variableSlug)
+		}
+		j := slices.IndexFunc(variables[i].Values, func(v db.VariableValue) bool { return v.Slug == valueSlug })
+		if j < 0 {
+			return nil, fmt.Errorf("%w: %q is not a value of variable %s", ErrInvalidInput, valueSlug, variableSlug)
+		}
+		resolved = append(resolved, variables[i].Values[j])
+	}
+	slices.SortFunc(resolved, func(a, b db.VariableValue) int { return int(a.ID - b.ID) })
+	return resolved, nil
+}
+
+// listRunVariables loads the variable values of runs, keyed by run ID; runs
+// without any are left out
+func listRunVariables(ctx context.Context, q db.Querier, runs []db.Run) (map[int32]RunVariables, error) {
+	result := map[int32]RunVariables{}
+	if len(runs) == 0 {
+		return result, nil
+	}
+	
+	ids := make([]int32, len(runs))
+	for i, run := range runs {
+		ids[i] = run.ID
+	}
+	rows, err := q.ListRunVariableValues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list run variables: %w", err)
+	}
+	for _, row := range rows {
+		if result[row.RunID] == nil {
+			result[row.RunID] = RunVariables{}
+		}
+		result[row.RunID][row.VariableSlug] = row.ValueSlug
+	}
+	return result, nil
+}
+
+// validateVariableValues checks a new variable's values, trimming labels
+func validateVariableValues(values []CreateVariableValueInput) ([]CreateVariableValueInput, error) {
+	if len(values) == 0 {
+		return nil, invalidField("values", "must not be empty")
+	}
+	if len(values) > maxVariableValues {
+		return nil, invalidField("values", "must have at most %d values", maxVariableValues)
+	}
+	
+	result := make([]CreateVariableValueInput, len(values))
+	seen := make(map[string]bool, len(values))
+	for i, value := range values {
+		if !slugPattern.MatchString(value.Slug) || len(value.Slug) > maxSlugLength {
+			return nil, invalidField(fmt.Sprintf("values.%d.slug", i), "must be at most %d lowercase letters, digits, and single hyphens", maxSlugLength)
+		}
+		if seen[value.Slug] {
+			return nil, invalidField(fmt.Sprintf("values.%d.slug", i), "%q is given more than once", value.Slug)
+		}
+		seen[value.Slug] = true
+		
+		label := strings.TrimSpace(value.Label)
+		if label == "" || utf8.RuneCountInString(label) > maxDisplayNameLength {
+			return nil, invalidField(fmt.Sprintf("values.%d.label", i), "must be 1 to %d characters", maxDisplayNameLength)
+		}
+		result[i] = CreateVariableValueInput{Slug: value.Slug, Label: label}
+	}
+	return result, nil
+}
+
+// isDuplicateVariableSlugError reports whether err is a unique violation on
+// a game's variable slugs
+func isDuplicateVariableSlugError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == variablesGameSlugKey
+}
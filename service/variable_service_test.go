@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (m *MockQueries) ListVariablesByGame(ctx context.Context, gameID int32) ([]db.Variable, error) {
+	if m.ListVariablesByGameFunc != nil {
+		return m.ListVariablesByGameFunc(ctx, gameID)
+	}
+	return []db.Variable{}, nil
+}
+
+func (m *MockQueries) ListVariableValuesByGame(ctx context.Context, gameID int32) ([]db.VariableValue, error) {
+	if m.ListVariableValuesByGameFunc != nil {
+		return m.ListVariableValuesByGameFunc(ctx, gameID)
+	}
+	return []db.VariableValue{}, nil
+}
+
+func (m *MockQueries) CreateVariable(ctx context.Context, params db.CreateVariableParams) (db.Variable, error) {
+	if m.CreateVariableFunc != nil {
+		return m.CreateVariableFunc(ctx, params)
+	}
+	return db.Variable{}, nil
+}
+
+func (m *MockQueries) CreateVariableValue(ctx context.Context, params db.CreateVariableValueParams) (db.VariableValue, error) {
+	if m.CreateVariableValueFunc != nil {
+		return m.CreateVariableValueFunc(ctx, params)
+	}
+	return db.VariableValue{}, nil
+}
+
+func (m *MockQueries) CreateRunVariableValue(ctx context.Context, params db.CreateRunVariableValueParams) error {
+	if m.CreateRunVariableValueFunc != nil {
+		return m.CreateRunVariableValueFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockQueries) ListRunVariableValues(ctx context.Context, runIds []int32) ([]db.ListRunVariableValuesRow, error) {
+	if m.ListRunVariableValuesFunc != nil {
+		return m.ListRunVariableValuesFunc(ctx, runIds)
+	}
+	return []db.ListRunVariableValuesRow{}, nil
+}
+
+// withGameVariables stubs the variables of game 1: difficulty, with values
+// easy (10) and hard (11), applies to every category, and character, with
+// values mario (20) and luigi (21), only to category 3
+func withGameVariables(m *MockQueries) *MockQueries {
+	m.ListVariablesByGameFunc = func(ctx context.Context, gameID int32) ([]db.Variable, error) {
+		if gameID != 1 {
+			return []db.Variable{}, nil
+		}
+		return []db.Variable{
+			{ID: 1, GameID: 1, Slug: "difficulty", Name: "Difficulty"},
+			{ID: 2, GameID: 1, CategoryID: pgtype.Int4{Int32: 3, Valid: true}, Slug: "character", Name: "Character"},
+		}, nil
+	}
+	m.ListVariableValuesByGameFunc = func(ctx context.Context, gameID int32) ([]db.VariableValue, error) {
+		if gameID != 1 {
+			return []db.VariableValue{}, nil
+		}
+		return []db.VariableValue{
+			{ID: 10, VariableID: 1, Slug: "easy", Label: "Easy"},
+			{ID: 11, VariableID: 1, Slug: "hard", Label: "Hard", Position: 1},
+			{ID: 20, VariableID: 2, Slug: "mario", Label: "Mario"},
+			{ID: 21, VariableID: 2, Slug: "luigi", Label: "Luigi", Position: 1},
+		}, nil
+	}
+	return m
+}
+
+func TestListVariables(t *testing.T) {
+	mockQueries := withGameVariables(&MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 1, Slug: "super-mario-64"}),
+	})
+
+	service := NewVariableService(mockQueries)
+	variables, err := service.ListVariables(context.Background(), "super-mario-64")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(variables) != 2 {
+		t.Fatalf("expected 2 variables, got %d", len(variables))
+	}
+	if variables[0].Slug != "difficulty" || len(variables[0].Values) != 2 || variables[0].Values[1].Slug != "hard" {
+		t.Errorf("expected difficulty with easy and hard, got %+v", variables[0])
+	}
+	if variables[1].Slug != "character" || len(variables[1].Values) != 2 {
+		t.Errorf("expected character with two values, got %+v", variables[1])
+	}
+
+	if _, err := service.ListVariables(context.Background(), "missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+}
+
+func TestCreateVariable_Success(t *testing.T) {
+	var params db.CreateVariableParams
+	var values []db.CreateVariableValueParams
+	var audited bool
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 1, Slug: "super-mario-64"}),
+		GetCategoryBySlugFunc: func(ctx context.Context, p db.GetCategoryBySlugParams) (db.Category, error) {
+			return db.Category{ID: 3, GameID: 1}, nil
+		},
+		CreateVariableFunc: func(ctx context.Context, p db.CreateVariableParams) (db.Variable, error) {
+			params = p
+			return db.Variable{ID: 5, GameID: p.GameID, CategoryID: p.CategoryID, Slug: p.Slug, Name: p.Name}, nil
+		},
+		CreateVariableValueFunc: func(ctx context.Context, p db.CreateVariableValueParams) (db.VariableValue, error) {
+			values = append(values, p)
+			return db.VariableValue{ID: int32(len(values)), VariableID: p.VariableID, Slug: p.Slug, Label: p.Label, Position: p.Position}, nil
+		},
+		CreateAuditEventFunc: func(ctx context.Context, p db.CreateAuditEventParams) error {
+			audited = p.Action == "variable.create" && p.EntityType == AuditEntityVariable && p.EntityID == 5
+			return nil
+		},
+	}
+
+	service := NewVariableService(mockQueries)
+	variable, err := service.CreateVariable(asAdmin(), "super-mario-64", CreateVariableInput{
+		Slug:         "character",
+		Name:         "  Character ",
+		CategorySlug: "120-star",
+		Values: []CreateVariableValueInput{
+			{Slug: "mario", Label: "Mario"},
+			{Slug: "luigi", Label: " Luigi "},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.GameID != 1 || params.CategoryID != (pgtype.Int4{Int32: 3, Valid: true}) || params.Name != "Character" {
+		t.Errorf("unexpected insert params %+v", params)
+	}
+	if len(values) != 2 || values[1].Position != 1 || values[1].Label != "Luigi" || values[1].VariableID != 5 {
+		t.Errorf("expected values in order with trimmed labels, got %+v", values)
+	}
+	if len(variable.Values) != 2 {
+		t.Errorf("expected the created values returned, got %+v", variable.Values)
+	}
+	if !audited {
+		t.Error("expected the creation to be audited")
+	}
+}
+
+func TestCreateVariable_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*CreateVariableInput)
+	}{
+		{"bad slug", func(in *CreateVariableInput) { in.Slug = "Difficulty" }},
+		{"blank name", func(in *CreateVariableInput) { in.Name = "  " }},
+		{"no values", func(in *CreateVariableInput) { in.Values = nil }},
+		{"bad value slug", func(in *CreateVariableInput) { in.Values[0].Slug = "very hard" }},
+		{"duplicate value slug", func(in *CreateVariableInput) { in.Values[1].Slug = in.Values[0].Slug }},
+		{"blank label", func(in *CreateVariableInput) { in.Values[0].Label = " " }},
+	}
+
+	service := NewVariableService(&MockQueries{})
+	for _, tt := range tests {
+		input := CreateVariableInput{
+			Slug:   "difficulty",
+			Name:   "Difficulty",
+			Values: []CreateVariableValueInput{{Slug: "easy", Label: "Easy"}, {Slug: "hard", Label: "Hard"}},
+		}
+		tt.modify(&input)
+		if _, err := service.CreateVariable(context.Background(), "super-mario-64", input); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestCreateVariable_NotFound(t *testing.T) {
+	service := NewVariableService(&MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 1, Slug: "super-mario-64"}),
+	})
+	input := CreateVariableInput{Slug: "difficulty", Name: "Difficulty", Values: []CreateVariableValueInput{{Slug: "easy", Label: "Easy"}}}
+
+	if _, err := service.CreateVariable(context.Background(), "missing", input); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("expected ErrGameNotFound, got %v", err)
+	}
+	input.CategorySlug = "missing"
+	if _, err := service.CreateVariable(asAdmin(), "super-mario-64", input); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestCreateVariable_DuplicateSlug(t *testing.T) {
+	mockQueries := &MockQueries{
+		GetGameBySlugFunc: gameLookup(db.Game{ID: 1, Slug: "super-mario-64"}),
+		CreateVariableFunc: func(ctx context.Context, p db.CreateVariableParams) (db.Variable, error) {
+			return db.Variable{}, &pgconn.PgError{Code: uniqueViolation, ConstraintName: variablesGameSlugKey}
+		},
+	}
+
+	service := NewVariableService(mockQueries)
+	_, err := service.CreateVariable(asAdmin(), "super-mario-64", CreateVariableInput{
+		Slug:   "difficulty",
+		Name:   "Difficulty",
+		Values: []CreateVariableValueInput{{Slug: "easy", Label: "Easy"}},
+	})
+
+	if !errors.Is(err, ErrDuplicateVariableSlug) {
+		t.Errorf("expected ErrDuplicateVariableSlug, got %v", err)
+	}
+}
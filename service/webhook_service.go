@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/example/speedrun-rest-api/auth"
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrWebhookNotFound is returned when a webhook does not exist
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+const (
+	// webhookSecretPrefix starts every signing secret so leaked secrets are
+	// easy to spot
+	webhookSecretPrefix = "whsec_"
+	
+	// maxWebhookURLLength bounds the URL a webhook is delivered to
+	maxWebhookURLLength = 2000
+)
+
+// WebhookService manages the endpoints notified of events; only admins may
+// use it
+type WebhookService struct {
+	queries db.Store
+	pages   pageSizes
+}
+
+// WebhookDeliveryPage is one page of a webhook's deliveries along with the
+// pagination that was actually applied
+type WebhookDeliveryPage struct {
+	Deliveries []db.WebhookDelivery
+	Total      int64
+	Limit      int32
+	Offset     int32
+	
+	// NextCursor continues after this page; it is empty on the last page
+	NextCursor string
+}
+
+// WebhookOption configures optional WebhookService behavior
+type WebhookOption func(*WebhookService)
+
+// WithWebhookPageSizes sets the limit applied when a list request omits one
+// and the largest limit a list request may ask for
+func WithWebhookPageSizes(defaultSize, maxSize int) WebhookOption {
+	return func(s *WebhookService) {
+		s.pages = s.pages.with(defaultSize, maxSize)
+	}
+}
+
+// NewWebhookService creates a new WebhookService instance
+func NewWebhookService(queries db.Store, opts ...WebhookOption) *WebhookService {
+	s := &WebhookService{
+		queries: queries,
+		pages:   defaultPageSizes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateWebhook registers an endpoint to be sent the given events
+//
+// Every delivery is signed with the returned webhook's secret, which
+// receivers use to check that a request came from this API.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - endpoint: Absolute http or https URL deliveries are posted to
+//   - events: The events to deliver; see Events
+//
+// Returns:
+//   - *db.Webhook: The stored webhook, including its secret
+//   - error: ErrForbidden, ErrInvalidInput, or database errors
+func (s *WebhookService) CreateWebhook(ctx context.Context, endpoint string, events []string) (*db.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	
+	if err := validateWebhookURL(endpoint); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, invalidField("events", "must include at least one event")
+	}
+	for _, event := range events {
+		if !slices.Contains(Events, event) {
+			return nil, invalidField("events", "includes unknown event %q", event)
+		}
+	}
+	
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	
+	var webhook db.Webhook
+	err := s.queries.WithTx(ctx, func(q db.Querier) error {
+		var err error
+		webhook, err = q.CreateWebhook(ctx, db.CreateWebhookParams{
+			Url:       endpoint,
+			Secret:    webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secret),
+			Events:    slices.Compact(slices.Sorted(slices.Values(events))),
+			CreatedBy: callerID(ctx),
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, "webhook.create", AuditEntityWebhook, webhook.ID, nil, webhook)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	
+	return &webhook, nil
+}
+
+// ListWebhooks returns every registered webhook, oldest first
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//
+// Returns:
+//   - []db.Webhook: The webhooks
+//   - error: ErrForbidden, or database errors
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]db.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.ListWebhooks")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	
+	webhooks, err := s.queries.ListWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// GetWebhook retrieves a webhook by its ID
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the webhook
+//
+// Returns:
+//   - *db.Webhook: The webhook
+//   - error: ErrForbidden, ErrWebhookNotFound, or database errors
+func (s *WebhookService) GetWebhook(ctx context.Context, id int32) (*db.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetWebhook")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	
+	webhook, err := s.queries.GetWebhookByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook unregisters a webhook; deliveries still queued for it are
+// dropped along with its delivery log
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the webhook
+//
+// Returns:
+//   - error: ErrForbidden, ErrWebhookNotFound, or database errors
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int32) error {
+	ctx, span := tracer.Start(ctx, "WebhookService.DeleteWebhook")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return err
+	}
+	
+	return s.queries.WithTx(ctx, func(q db.Querier) error {
+		webhook, err := q.GetWebhookByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWebhookNotFound
+			}
+			return fmt.Errorf("failed to get webhook: %w", err)
+		}
+		
+		rows, err := q.DeleteWebhook(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+		if rows == 0 {
+			return ErrWebhookNotFound
+		}
+		return recordAudit(ctx, q, "webhook.delete", AuditEntityWebhook, id, webhook, nil)
+	})
+}
+
+// ListWebhookDeliveries retrieves a paginated log of the deliveries queued
+// for a webhook, newest first
+//
+// Pagination follows the same policy as ListUsers.
+//
+// Parameters:
+//   - ctx: Context carrying the authenticated caller
+//   - id: ID of the webhook
+//   - page: Requested page size and either an offset or a cursor
+//
+// Returns:
+//   - *WebhookDeliveryPage: The deliveries, total count, the effective limit
+//     and offset, and the cursor of the next page
+//   - error: ErrForbidden, ErrWebhookNotFound, ErrInvalidInput or
+//     ErrInvalidCursor for a bad page request, or database errors
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, id int32, page PageRequest) (*WebhookDeliveryPage, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.ListWebhookDeliveries")
+	defer span.End()
+	
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	
+	pageLimit, pageOffset, err := s.pages.apply(page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.queries.GetWebhookByID(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	
+	var deliveries []db.WebhookDelivery
+	if page.Cursor == "" {
+		deliveries, err = s.queries.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+			WebhookID: id,
+			Limit:     pageLimit + 1,
+			Offset:    pageOffset,
+		})
+	} else {
+		var afterID int32
+		if err := decodeCursor(page.Cursor, "webhook_deliveries", &afterID); err != nil {
+			return nil, err
+		}
+		deliveries, err = s.queries.ListWebhookDeliveriesAfter(ctx, db.ListWebhookDeliveriesAfterParams{
+			WebhookID: id,
+			AfterID:   afterID,
+			Limit:     pageLimit + 1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	deliveries, more := trimPage(deliveries, pageLimit)
+	
+	count, err := s.queries.CountWebhookDeliveries(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	
+	result := &WebhookDeliveryPage{
+		Deliveries: deliveries,
+		Total:      count,
+		Limit:      pageLimit,
+		Offset:     pageOffset,
+	}
+	if more {
+		result.NextCursor = encodeCursor("webhook_deliveries", deliveries[len(deliveries)-1].ID)
+	}
+	return result, nil
+}
+
+// validateWebhookURL checks that endpoint is an absolute http or https URL
+func validateWebhookURL(endpoint string) error {
+	if len(endpoint) > maxWebhookURLLength {
+		return invalidField("url", "must be at most %d characters", maxWebhookURLLength)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return invalidField("url", "must be an absolute http or https URL")
+	}
+	if u.User != nil {
+		return invalidField("url", "must not contain credentials")
+	}
+	return nil
+}
+
+// callerID returns the ID of the caller in ctx, or NULL if there is none or
+// the caller is not a user, such as an operator running adminctl
+func callerID(ctx context.Context) pgtype.Int4 {
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.UserID != 0 {
+		return pgtype.Int4{Int32: p.UserID, Valid: true}
+	}
+	return pgtype.Int4{}
+}
@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// s3Service is the service name requests are signed for
+const s3Service = "s3"
+
+// S3Config holds the settings of an S3Store
+type S3Config struct {
+	// Endpoint is the base URL of the service, such as
+	// https://s3.us-east-1.amazonaws.com or http://minio:9000
+	Endpoint string
+
+	Region string
+	Bucket string
+
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// PathStyle addresses the bucket in the path, endpoint/bucket/key, as
+	// MinIO expects, rather than as a subdomain of the endpoint
+	PathStyle bool
+
+	// PublicURL is the base URL objects are served from, such as a CDN in
+	// front of the bucket; empty serves them from the bucket itself
+	PublicURL string
+}
+
+// S3Store keeps objects in a bucket of Amazon S3 or an S3-compatible service
+type S3Store struct {
+	cfg    S3Config
+	bucket *url.URL
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Store creates an S3Store for the bucket described by cfg
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+
+	bucket := *endpoint
+	if cfg.PathStyle {
+		bucket.Path += "/" + cfg.Bucket
+	} else {
+		bucket.Host = cfg.Bucket + "." + endpoint.Host
+	}
+	cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = bucket.String()
+	}
+
+	return &S3Store{
+		cfg:    cfg,
+		bucket: &bucket,
+		client: &http.Client{Timeout: 30 * time.Second},
+		now:    time.Now,
+	}, nil
+}
+
+// Put uploads obj to the bucket
+func (s *S3Store) Put(ctx context.Context, obj Object) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(obj.Key), bytes.NewReader(obj.Data))
+	if err != nil {
+		return fmt.Errorf("failed to build S3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", obj.ContentType)
+	if obj.CacheControl != "" {
+		req.Header.Set("Cache-Control", obj.CacheControl)
+	}
+
+	return s.do(req, obj.Data, "upload "+obj.Key)
+}
+
+// Delete removes the object with key from the bucket
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build S3 request: %w", err)
+	}
+
+	return s.do(req, nil, "delete "+key)
+}
+
+// URL returns the public URL of the object with key
+func (s *S3Store) URL(key string) string {
+	return s.cfg.PublicURL + "/" + escapePath(key)
+}
+
+// objectURL returns the URL requests for the object with key are sent to
+func (s *S3Store) objectURL(key string) string {
+	return s.bucket.String() + "/" + escapePath(key)
+}
+
+// do signs and sends req, whose body is payload, and checks that S3
+// accepted it
+func (s *S3Store) do(req *http.Request, payload []byte, action string) error {
+	s.sign(req, payload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call S3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("S3 refused to %s with status %d: %s", action, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// sign adds the headers of AWS Signature Version 4 to req, covering every
+// header already set on it
+func (s *S3Store) sign(req *http.Request, payload []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.cfg.Region + "/" + s3Service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// escapePath percent-encodes a key the way Signature Version 4 expects,
+// leaving only unreserved characters and the slashes between segments
+func escapePath(key string) string {
+	var b strings.Builder
+	for _, c := range []byte(key) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// sha256Hex returns the hex-encoded SHA-256 digest of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data under key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
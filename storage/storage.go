@@ -0,0 +1,34 @@
+// Package storage keeps files uploaded to the API, such as user avatars, in
+// an object store and hands out the public URLs they are served from. The
+// S3 driver works with Amazon S3 and compatible services such as MinIO.
+package storage
+
+import "context"
+
+// Object is a file to store
+type Object struct {
+	// Key names the object within the store, e.g. "avatars/1/ab12.png"
+	Key string
+
+	ContentType string
+
+	// CacheControl is sent along when the object is served, so a CDN in
+	// front of the store knows how long it may keep it; empty sends none
+	CacheControl string
+
+	Data []byte
+}
+
+// Store keeps objects and says where they can be downloaded from
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Put stores obj, replacing any object with the same key
+	Put(ctx context.Context, obj Object) error
+
+	// Delete removes the object with key; deleting a missing object
+	// succeeds
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the public URL the object with key is served from
+	URL(key string) string
+}
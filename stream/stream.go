@@ -0,0 +1,190 @@
+// Package stream pushes events to the clients following them live, such as
+// GET /events/stream and race rooms. Events reach every API instance through
+// Postgres LISTEN/NOTIFY, on which the outbox's stream sink sends them and
+// the race service sends race changes.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Channel is the Postgres notification channel events are sent on
+const Channel = "event_stream"
+
+// RaceUpdated is the event sent whenever a race or its participants change,
+// so the clients in its room can fetch its new state
+const RaceUpdated = "race.updated"
+
+const (
+	// subscriberBuffer is how many events a subscriber may fall behind by
+	// before it is dropped
+	subscriberBuffer = 64
+
+	// reconnectDelay is how long Listen waits before listening again after
+	// losing its connection
+	reconnectDelay = 5 * time.Second
+)
+
+// Message is an event sent on Channel
+type Message struct {
+	// ID is the outbox event's ID
+	ID    int32  `json:"id"`
+	Event string `json:"event"`
+
+	// GameID is the game the event is about
+	GameID int32 `json:"game_id"`
+
+	// RaceID is the race a RaceUpdated message is about
+	RaceID int32 `json:"race_id,omitempty"`
+
+	// Envelope is the event as webhooks receive it
+	Envelope json.RawMessage `json:"envelope,omitempty"`
+}
+
+// Filter selects the messages a subscriber receives; an empty field matches
+// every message
+type Filter struct {
+	GameIDs []int32
+	Events  []string
+	RaceID  int32
+}
+
+// matches reports whether m passes the filter
+func (f Filter) matches(m Message) bool {
+	return (len(f.GameIDs) == 0 || slices.Contains(f.GameIDs, m.GameID)) &&
+		(len(f.Events) == 0 || slices.Contains(f.Events, m.Event)) &&
+		(f.RaceID == 0 || f.RaceID == m.RaceID)
+}
+
+// subscriber is one client following the hub
+type subscriber struct {
+	filter Filter
+	ch     chan Message
+}
+
+// Hub hands the messages it is given to every subscriber whose filter they
+// match
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// NewHub creates a Hub without subscribers
+func NewHub() *Hub {
+	return &Hub{subscribers: map[*subscriber]struct{}{}}
+}
+
+// Subscribe follows the messages matching filter until unsubscribe is called
+//
+// The channel is closed once the subscription ends, which also happens when
+// the hub is closed or the subscriber falls too far behind; clients that
+// were dropped should reconnect.
+func (h *Hub) Subscribe(filter Filter) (messages <-chan Message, unsubscribe func()) {
+	sub := &subscriber{filter: filter, ch: make(chan Message, subscriberBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub.ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(sub)
+	}
+}
+
+// Publish hands m to the subscribers it matches without waiting for them,
+// dropping those that are too far behind
+func (h *Hub) Publish(m Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		if !sub.filter.matches(m) {
+			continue
+		}
+		select {
+		case sub.ch <- m:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// Close ends every subscription, and those made later, so streaming
+// responses finish before a shutdown
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subscribers {
+		h.remove(sub)
+	}
+}
+
+// remove ends sub's subscription; h.mu must be held
+func (h *Hub) remove(sub *subscriber) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Listen publishes the messages sent on Channel until ctx is cancelled,
+// listening again whenever the connection is lost
+//
+// It holds one of pool's connections for as long as it runs.
+func (h *Hub) Listen(ctx context.Context, pool *pgxpool.Pool) {
+	for {
+		err := h.listen(ctx, pool)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.WarnContext(ctx, "Lost event stream connection; listening again", "retry_in", reconnectDelay.String(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listen publishes the messages sent on Channel until the connection fails
+// or ctx is cancelled
+func (h *Hub) listen(ctx context.Context, pool *pgxpool.Pool) error {
+	pooled, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A listening connection must not go back to the pool, so it is taken
+	// out of it and closed when done
+	conn := pooled.Hijack()
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+Channel); err != nil {
+		return err
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var m Message
+		if err := json.Unmarshal([]byte(notification.Payload), &m); err != nil {
+			slog.WarnContext(ctx, "Ignoring malformed event stream message", "error", err)
+			continue
+		}
+		h.Publish(m)
+	}
+}
@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"slices"
+	"testing"
+)
+
+// received drains the messages waiting on ch
+func received(ch <-chan Message) []int32 {
+	var ids []int32
+	for {
+		select {
+		case m, ok := <-ch:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, m.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestHub_FiltersByGameAndEvent(t *testing.T) {
+	hub := NewHub()
+	all, _ := hub.Subscribe(Filter{})
+	game, _ := hub.Subscribe(Filter{GameIDs: []int32{2}})
+	verified, _ := hub.Subscribe(Filter{GameIDs: []int32{2, 3}, Events: []string{"run.verified"}})
+
+	hub.Publish(Message{ID: 1, Event: "run.verified", GameID: 2})
+	hub.Publish(Message{ID: 2, Event: "run.rejected", GameID: 2})
+	hub.Publish(Message{ID: 3, Event: "run.verified", GameID: 4})
+
+	for name, tc := range map[string]struct {
+		ch   <-chan Message
+		want []int32
+	}{
+		"all":      {all, []int32{1, 2, 3}},
+		"game":     {game, []int32{1, 2}},
+		"verified": {verified, []int32{1}},
+	} {
+		if got := received(tc.ch); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: expected messages %v, got %v", name, tc.want, got)
+		}
+	}
+}
+
+func TestHub_DropsSlowSubscribers(t *testing.T) {
+	hub := NewHub()
+	slow, _ := hub.Subscribe(Filter{})
+
+	for i := range subscriberBuffer + 1 {
+		hub.Publish(Message{ID: int32(i)})
+	}
+
+	if got := len(received(slow)); got != subscriberBuffer {
+		t.Errorf("expected the buffered %d messages, got %d", subscriberBuffer, got)
+	}
+	if _, ok := <-slow; ok {
+		t.Error("expected the subscriber that fell behind to be dropped")
+	}
+}
+
+func TestHub_CloseEndsSubscriptions(t *testing.T) {
+	hub := NewHub()
+	before, unsubscribe := hub.Subscribe(Filter{})
+	hub.Close()
+	unsubscribe()
+
+	if _, ok := <-before; ok {
+		t.Error("expected the subscription to end when the hub closes")
+	}
+	after, _ := hub.Subscribe(Filter{})
+	if _, ok := <-after; ok {
+		t.Error("expected subscriptions to a closed hub to end at once")
+	}
+}
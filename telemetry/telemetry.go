@@ -0,0 +1,100 @@
+// Package telemetry configures OpenTelemetry tracing for the API server.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/example/speedrun-rest-api/version"
+	"github.com/exaring/otelpgx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// ServiceName identifies this API in traces unless OTEL_SERVICE_NAME is set
+const ServiceName = "speedrun-rest-api"
+
+// Exporters that can be selected with OTEL_TRACES_EXPORTER
+const (
+	// ExporterNone records no spans
+	ExporterNone = "none"
+
+	// ExporterOTLP sends spans over OTLP/HTTP
+	ExporterOTLP = "otlp"
+)
+
+// Setup installs a global tracer provider for exporter and returns a
+// function that flushes buffered spans and stops it
+//
+// Trace context is always propagated, so a request that arrives with a
+// traceparent header keeps its trace ID in downstream calls even when spans
+// are not exported here. The OTLP exporter, sampler, and resource are
+// further configured with the standard OTEL_* environment variables, such
+// as OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_TRACES_SAMPLER.
+func Setup(ctx context.Context, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	switch exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter %q", exporter)
+	}
+
+	client, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version.Version),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(client),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewPgxTracer creates a pgx tracer that records a span per query, named
+// after the sqlc query, e.g. "query GetUserByID"
+// Create it after Setup so it uses the configured tracer provider.
+func NewPgxTracer() *otelpgx.Tracer {
+	return otelpgx.NewTracer(otelpgx.WithSpanNameFunc(QueryName))
+}
+
+// QueryName returns the sqlc name of a statement from its leading
+// "-- name: GetUserByID :one" comment, or its first word, such as "SELECT",
+// for statements that have none
+func QueryName(stmt string) string {
+	stmt = strings.TrimSpace(stmt)
+	if rest, ok := strings.CutPrefix(stmt, "-- name:"); ok {
+		if fields := strings.Fields(rest); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	if fields := strings.Fields(stmt); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "unknown"
+}
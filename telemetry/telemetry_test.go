@@ -0,0 +1,29 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		expected string
+	}{
+		{"-- name: GetUserByID :one\nSELECT id FROM users WHERE id = $1", "GetUserByID"},
+		{"\n  select 1", "SELECT"},
+		{"", "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := QueryName(tt.stmt); got != tt.expected {
+			t.Errorf("QueryName(%q): expected %q, got %q", tt.stmt, tt.expected, got)
+		}
+	}
+}
+
+func TestSetup_RejectsUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), "zipkin"); err == nil {
+		t.Error("expected an error for an unsupported exporter")
+	}
+}
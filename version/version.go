@@ -0,0 +1,17 @@
+// Package version exposes build metadata injected at link time.
+//
+// Values are set with -ldflags, for example:
+//
+//	go build -ldflags "-X github.com/example/speedrun-rest-api/version.Version=v1.2.0" ./cmd/api
+package version
+
+var (
+	// Version is the release version of the build
+	Version = "dev"
+
+	// Commit is the git commit the build was made from
+	Commit = "unknown"
+
+	// BuildTime is when the binary was built, in RFC3339
+	BuildTime = "unknown"
+)
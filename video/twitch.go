@@ -0,0 +1,152 @@
+package video
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// twitchTokenEndpoint issues app access tokens
+	twitchTokenEndpoint = "https://id.twitch.tv/oauth2/token"
+
+	// twitchVideosEndpoint is the Helix API's videos resource
+	twitchVideosEndpoint = "https://api.twitch.tv/helix/videos"
+
+	// twitchTokenLeeway renews an app access token this long before it
+	// expires, so requests in flight don't race its expiry
+	twitchTokenLeeway = time.Minute
+)
+
+// TwitchChecker checks Twitch VODs through the Helix API, authenticating as
+// the app registered with Twitch
+type TwitchChecker struct {
+	clientID      string
+	clientSecret  string
+	tokenEndpoint string
+	endpoint      string
+	client        *http.Client
+
+	mu           sync.Mutex
+	token        string
+	tokenExpires time.Time
+}
+
+// NewTwitchChecker creates a TwitchChecker using the client credentials of a
+// Twitch app
+func NewTwitchChecker(clientID, clientSecret string) *TwitchChecker {
+	return &TwitchChecker{
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		tokenEndpoint: twitchTokenEndpoint,
+		endpoint:      twitchVideosEndpoint,
+		client:        &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// twitchVideosResponse is the part of a Get Videos response that is checked
+type twitchVideosResponse struct {
+	Data []struct {
+		Viewable string `json:"viewable"`
+	} `json:"data"`
+}
+
+// Check reports whether the VOD exists and is public
+func (c *TwitchChecker) Check(ctx context.Context, link Link) error {
+	token, err := c.appToken(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"?"+url.Values{"id": {link.ID}}.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build Twitch request: %w", err)
+	}
+	req.Header.Set("Client-Id", c.clientID)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Twitch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read Twitch response: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ErrUnavailable
+	case http.StatusUnauthorized:
+		// The token was revoked or expired early; get a new one next time
+		c.mu.Lock()
+		c.token = ""
+		c.mu.Unlock()
+		return errors.New("Twitch rejected the app access token")
+	default:
+		return fmt.Errorf("Twitch returned status %d", resp.StatusCode)
+	}
+	var videos twitchVideosResponse
+	if err := json.Unmarshal(body, &videos); err != nil {
+		return fmt.Errorf("failed to decode Twitch response: %w", err)
+	}
+
+	if len(videos.Data) == 0 || (videos.Data[0].Viewable != "" && videos.Data[0].Viewable != "public") {
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// appToken returns an app access token, requesting a new one when there is
+// none or it is about to expire
+func (c *TwitchChecker) appToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Before(c.tokenExpires) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build Twitch token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Twitch app access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read Twitch token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Twitch token endpoint returned status %d", resp.StatusCode)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil || token.AccessToken == "" {
+		return "", errors.New("Twitch token endpoint returned no access token")
+	}
+
+	c.token = token.AccessToken
+	c.tokenExpires = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - twitchTokenLeeway)
+	return c.token, nil
+}
@@ -0,0 +1,78 @@
+package video
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTwitchServer serves the token and videos endpoints, answering video
+// lookups with status and body
+func newTwitchServer(t *testing.T, status int, body string, tokens *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		*tokens++
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("client_secret") != "secret" {
+			t.Errorf("unexpected token request %v", r.Form)
+		}
+		w.Write([]byte(`{"access_token":"app-token","expires_in":3600,"token_type":"bearer"}`))
+	})
+	mux.HandleFunc("GET /videos", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer app-token" || r.Header.Get("Client-Id") != "client" {
+			t.Errorf("expected the app token and client ID, got %v", r.Header)
+		}
+		if r.URL.Query().Get("id") != "2143657890" {
+			t.Errorf("unexpected video ID %q", r.URL.Query().Get("id"))
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestTwitchChecker_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"public", http.StatusOK, `{"data":[{"id":"2143657890","viewable":"public"}]}`, nil},
+		{"private", http.StatusOK, `{"data":[{"id":"2143657890","viewable":"private"}]}`, ErrUnavailable},
+		{"missing", http.StatusNotFound, `{"error":"Not Found","status":404}`, ErrUnavailable},
+	}
+	for _, tt := range tests {
+		var tokens int
+		srv := newTwitchServer(t, tt.status, tt.body, &tokens)
+
+		c := NewTwitchChecker("client", "secret")
+		c.tokenEndpoint = srv.URL + "/token"
+		c.endpoint = srv.URL + "/videos"
+		err := c.Check(context.Background(), Link{Twitch, "2143657890"})
+		srv.Close()
+
+		if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestTwitchChecker_ReusesAppToken(t *testing.T) {
+	var tokens int
+	srv := newTwitchServer(t, http.StatusOK, `{"data":[{"viewable":"public"}]}`, &tokens)
+	defer srv.Close()
+
+	c := NewTwitchChecker("client", "secret")
+	c.tokenEndpoint = srv.URL + "/token"
+	c.endpoint = srv.URL + "/videos"
+	for range 3 {
+		if err := c.Check(context.Background(), Link{Twitch, "2143657890"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if tokens != 1 {
+		t.Errorf("expected one token request, got %d", tokens)
+	}
+}
@@ -0,0 +1,125 @@
+// Package video parses the links to recordings that runs are submitted with,
+// and checks with the provider hosting them, YouTube or Twitch, that they can
+// be watched.
+package video
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrUnsupported is returned by Parse for links that are not to a
+	// YouTube video or a Twitch VOD
+	ErrUnsupported = errors.New("not a YouTube video or Twitch VOD link")
+
+	// ErrUnavailable is returned by a Checker when the video does not exist
+	// or is not public
+	ErrUnavailable = errors.New("video is not available")
+)
+
+// maxResponseBytes bounds how much of a provider response is read
+const maxResponseBytes = 1 << 20
+
+// Provider names a video host
+type Provider string
+
+const (
+	// YouTube hosts videos at youtube.com
+	YouTube Provider = "youtube"
+
+	// Twitch hosts past broadcasts and highlights at twitch.tv
+	Twitch Provider = "twitch"
+)
+
+var (
+	// youTubeIDPattern matches the 11-character IDs of YouTube videos
+	youTubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+	// twitchIDPattern matches the numeric IDs of Twitch videos
+	twitchIDPattern = regexp.MustCompile(`^[0-9]{1,20}$`)
+)
+
+// Link is a parsed link to a video
+type Link struct {
+	Provider Provider
+
+	// ID is the provider's ID of the video
+	ID string
+}
+
+// URL returns the canonical link to the video, so every run links to its
+// video the same way however it was submitted
+func (l Link) URL() string {
+	if l.Provider == Twitch {
+		return "https://www.twitch.tv/videos/" + l.ID
+	}
+	return "https://www.youtube.com/watch?v=" + l.ID
+}
+
+// Parse recognizes a link to a YouTube video, in any of the forms YouTube
+// shares them, or to a Twitch VOD
+// Anything else, including links to channels or playlists, returns
+// ErrUnsupported.
+func Parse(raw string) (Link, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return Link{}, ErrUnsupported
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var link Link
+	switch host {
+	case "youtube.com", "music.youtube.com", "youtube-nocookie.com":
+		link.Provider = YouTube
+		switch {
+		case len(segments) == 1 && segments[0] == "watch":
+			link.ID = u.Query().Get("v")
+		case len(segments) == 2 && (segments[0] == "shorts" || segments[0] == "live" || segments[0] == "embed"):
+			link.ID = segments[1]
+		}
+	case "youtu.be":
+		link.Provider = YouTube
+		if len(segments) == 1 {
+			link.ID = segments[0]
+		}
+	case "twitch.tv":
+		link.Provider = Twitch
+		if len(segments) == 2 && segments[0] == "videos" {
+			link.ID = segments[1]
+		}
+	}
+
+	switch {
+	case link.Provider == YouTube && youTubeIDPattern.MatchString(link.ID):
+		return link, nil
+	case link.Provider == Twitch && twitchIDPattern.MatchString(link.ID):
+		return link, nil
+	}
+	return Link{}, ErrUnsupported
+}
+
+// Checker confirms with a provider that a video can be watched
+// Implementations must be safe for concurrent use.
+type Checker interface {
+	// Check returns ErrUnavailable if the video does not exist or is not
+	// public; any other error means the provider could not be asked
+	Check(ctx context.Context, link Link) error
+}
+
+// Checkers sends each link to the checker of its provider
+// Links to a provider without a checker pass unchecked.
+type Checkers map[Provider]Checker
+
+// Check checks link with its provider's checker
+func (c Checkers) Check(ctx context.Context, link Link) error {
+	if checker, ok := c[link.Provider]; ok {
+		return checker.Check(ctx, link)
+	}
+	return nil
+}
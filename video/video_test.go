@@ -0,0 +1,82 @@
+package video
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Link
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", Link{YouTube, "dQw4w9WgXcQ"}},
+		{"https://youtube.com/watch?v=dQw4w9WgXcQ&t=42s&list=PL123", Link{YouTube, "dQw4w9WgXcQ"}},
+		{"http://m.youtube.com/watch?v=dQw4w9WgXcQ", Link{YouTube, "dQw4w9WgXcQ"}},
+		{"https://youtu.be/dQw4w9WgXcQ?si=abc", Link{YouTube, "dQw4w9WgXcQ"}},
+		{"https://www.youtube.com/shorts/dQw4w9WgXcQ", Link{YouTube, "dQw4w9WgXcQ"}},
+		{"https://www.youtube.com/live/dQw4w9WgXcQ", Link{YouTube, "dQw4w9WgXcQ"}},
+		{"https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", Link{YouTube, "dQw4w9WgXcQ"}},
+		{" https://www.twitch.tv/videos/2143657890 ", Link{Twitch, "2143657890"}},
+		{"https://m.twitch.tv/videos/2143657890?t=1h2m3s", Link{Twitch, "2143657890"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.raw)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.raw, tt.want, got)
+		}
+	}
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	for _, raw := range []string{
+		"/videos/1",
+		"ftp://youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://videos.example.com/runs/1",
+		"https://www.youtube.com/watch?v=short",
+		"https://www.youtube.com/@speedrunner",
+		"https://www.youtube.com/playlist?list=PL123",
+		"https://youtu.be/",
+		"https://www.twitch.tv/speedrunner",
+		"https://www.twitch.tv/videos/abc",
+		"https://www.twitch.tv/speedrunner/clip/FunnyClip",
+	} {
+		if _, err := Parse(raw); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("%s: expected ErrUnsupported, got %v", raw, err)
+		}
+	}
+}
+
+func TestLink_URL(t *testing.T) {
+	if got := (Link{YouTube, "dQw4w9WgXcQ"}).URL(); got != "https://www.youtube.com/watch?v=dQw4w9WgXcQ" {
+		t.Errorf("unexpected YouTube URL %q", got)
+	}
+	if got := (Link{Twitch, "2143657890"}).URL(); got != "https://www.twitch.tv/videos/2143657890" {
+		t.Errorf("unexpected Twitch URL %q", got)
+	}
+}
+
+// checkerFunc adapts a function to a Checker
+type checkerFunc func(ctx context.Context, link Link) error
+
+func (f checkerFunc) Check(ctx context.Context, link Link) error {
+	return f(ctx, link)
+}
+
+func TestCheckers(t *testing.T) {
+	checkers := Checkers{
+		YouTube: checkerFunc(func(ctx context.Context, link Link) error { return ErrUnavailable }),
+	}
+
+	if err := checkers.Check(context.Background(), Link{YouTube, "dQw4w9WgXcQ"}); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected the YouTube checker's result, got %v", err)
+	}
+	if err := checkers.Check(context.Background(), Link{Twitch, "2143657890"}); err != nil {
+		t.Errorf("expected a provider without a checker to pass, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package video
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// youTubeVideosEndpoint is the YouTube Data API's videos resource
+const youTubeVideosEndpoint = "https://www.googleapis.com/youtube/v3/videos"
+
+// YouTubeChecker checks YouTube videos through the YouTube Data API
+type YouTubeChecker struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+// NewYouTubeChecker creates a YouTubeChecker that authenticates with a
+// YouTube Data API key
+func NewYouTubeChecker(apiKey string) *YouTubeChecker {
+	return &YouTubeChecker{
+		apiKey:   apiKey,
+		endpoint: youTubeVideosEndpoint,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// youTubeVideosResponse is the part of a videos.list response that is checked
+type youTubeVideosResponse struct {
+	Items []struct {
+		Status struct {
+			UploadStatus  string `json:"uploadStatus"`
+			PrivacyStatus string `json:"privacyStatus"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// Check reports whether the video exists and anyone with the link can watch
+// it; unlisted videos count as public, since runners often upload their
+// proof that way
+func (c *YouTubeChecker) Check(ctx context.Context, link Link) error {
+	query := url.Values{"part": {"status"}, "id": {link.ID}, "key": {c.apiKey}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build YouTube request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call YouTube: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read YouTube response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("YouTube returned status %d", resp.StatusCode)
+	}
+	var videos youTubeVideosResponse
+	if err := json.Unmarshal(body, &videos); err != nil {
+		return fmt.Errorf("failed to decode YouTube response: %w", err)
+	}
+
+	if len(videos.Items) == 0 {
+		return ErrUnavailable
+	}
+	status := videos.Items[0].Status
+	if status.PrivacyStatus == "private" {
+		return ErrUnavailable
+	}
+	switch status.UploadStatus {
+	case "deleted", "failed", "rejected":
+		return ErrUnavailable
+	}
+	return nil
+}
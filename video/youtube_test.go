@@ -0,0 +1,56 @@
+package video
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestYouTubeChecker_Check(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"public", `{"items":[{"status":{"uploadStatus":"processed","privacyStatus":"public"}}]}`, nil},
+		{"unlisted", `{"items":[{"status":{"uploadStatus":"processed","privacyStatus":"unlisted"}}]}`, nil},
+		{"private", `{"items":[{"status":{"uploadStatus":"processed","privacyStatus":"private"}}]}`, ErrUnavailable},
+		{"removed", `{"items":[{"status":{"uploadStatus":"rejected","privacyStatus":"public"}}]}`, ErrUnavailable},
+		{"missing", `{"items":[]}`, ErrUnavailable},
+	}
+	for _, tt := range tests {
+		var query string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			w.Write([]byte(tt.body))
+		}))
+
+		c := NewYouTubeChecker("yt-key")
+		c.endpoint = srv.URL
+		err := c.Check(context.Background(), Link{YouTube, "dQw4w9WgXcQ"})
+		srv.Close()
+
+		if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+		if query != "id=dQw4w9WgXcQ&key=yt-key&part=status" {
+			t.Errorf("%s: unexpected query %q", tt.name, query)
+		}
+	}
+}
+
+func TestYouTubeChecker_ReportsProviderErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"quota exceeded"}}`, http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c := NewYouTubeChecker("yt-key")
+	c.endpoint = srv.URL
+	err := c.Check(context.Background(), Link{YouTube, "dQw4w9WgXcQ"})
+	if err == nil || errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected a provider error rather than ErrUnavailable, got %v", err)
+	}
+}
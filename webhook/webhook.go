@@ -0,0 +1,234 @@
+// Package webhook sends queued events to the endpoints registered for them,
+// signing every request and retrying failed deliveries with backoff.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+	"github.com/example/speedrun-rest-api/version"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Headers sent with every delivery
+const (
+	// HeaderEvent names the event, e.g. "run.verified"
+	HeaderEvent = "X-Webhook-Event"
+
+	// HeaderDelivery is the ID of the delivery; it stays the same across
+	// retries, so receivers can drop duplicates
+	HeaderDelivery = "X-Webhook-Delivery"
+
+	// HeaderSignature carries the signature made by Sign
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Delivery states stored in webhook_deliveries.status
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+const (
+	// requestTimeout bounds each attempt; an endpoint that takes longer is
+	// retried
+	requestTimeout = 10 * time.Second
+
+	// leaseDuration is how long a claimed delivery is skipped by other
+	// dispatchers; it outlasts every attempt in a batch, which run at once
+	leaseDuration = 2 * requestTimeout
+
+	// maxRetryDelay caps the exponential backoff between attempts
+	maxRetryDelay = 6 * time.Hour
+
+	// batchSize is how many deliveries are claimed at a time
+	batchSize = 20
+
+	// maxErrorBody is how much of a rejected delivery's response is kept
+	maxErrorBody = 512
+)
+
+// Store is the part of db.Querier the dispatcher needs
+type Store interface {
+	ClaimWebhookDeliveries(ctx context.Context, arg db.ClaimWebhookDeliveriesParams) ([]db.ClaimWebhookDeliveriesRow, error)
+	UpdateWebhookDelivery(ctx context.Context, arg db.UpdateWebhookDeliveryParams) error
+}
+
+// Dispatcher periodically sends the deliveries that are due
+//
+// Any number of dispatchers may share a database: each delivery is leased to
+// one of them while it is being sent.
+type Dispatcher struct {
+	store       Store
+	client      *http.Client
+	interval    time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	now         func() time.Time
+}
+
+// NewDispatcher creates a Dispatcher that every interval sends the deliveries
+// that are due, giving up on one after maxAttempts attempts
+// The first retry waits backoff, and each one after that twice as long as
+// the last.
+func NewDispatcher(store Store, interval time.Duration, maxAttempts int, backoff time.Duration) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+		client: &http.Client{
+			Timeout: requestTimeout,
+			// A redirect is treated as a failure rather than followed, so a
+			// delivery only ever goes to the registered URL
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		now:         time.Now,
+	}
+}
+
+// Run sends due deliveries on every tick until ctx is cancelled
+// It returns only after the deliveries being sent have finished, so callers
+// can wait for Run to return before closing the database.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.dispatch(ctx)
+		}
+	}
+}
+
+// dispatch sends batches of due deliveries until none are left or ctx is
+// cancelled
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.store.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+			LeaseUntil: pgtype.Timestamptz{Time: d.now().Add(leaseDuration), Valid: true},
+			BatchSize:  batchSize,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to claim webhook deliveries", "error", err)
+			return
+		}
+
+		// Deliveries already claimed are sent even if ctx is cancelled
+		// meanwhile; requestTimeout bounds how long that takes
+		sendCtx := context.WithoutCancel(ctx)
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.deliver(sendCtx, delivery)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver makes one attempt at a delivery and records its outcome
+func (d *Dispatcher) deliver(ctx context.Context, delivery db.ClaimWebhookDeliveriesRow) {
+	statusCode, err := d.send(ctx, delivery)
+
+	update := db.UpdateWebhookDeliveryParams{
+		ID:            delivery.ID,
+		Status:        StatusSucceeded,
+		NextAttemptAt: pgtype.Timestamptz{Time: d.now(), Valid: true},
+	}
+	if statusCode != 0 {
+		update.ResponseStatus = pgtype.Int4{Int32: int32(statusCode), Valid: true}
+	}
+	if err != nil {
+		update.LastError = pgtype.Text{String: err.Error(), Valid: true}
+		if int(delivery.Attempts) >= d.maxAttempts {
+			update.Status = StatusFailed
+			slog.WarnContext(ctx, "Webhook delivery failed for the last time", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "attempts", delivery.Attempts, "error", err)
+		} else {
+			update.Status = StatusPending
+			update.NextAttemptAt.Time = d.now().Add(d.retryDelay(int(delivery.Attempts)))
+		}
+	}
+
+	if err := d.store.UpdateWebhookDelivery(ctx, update); err != nil {
+		slog.ErrorContext(ctx, "Failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// send posts a delivery's payload to its webhook, returning the response
+// status if there was one and an error unless it was a 2xx
+func (d *Dispatcher) send(ctx context.Context, delivery db.ClaimWebhookDeliveriesRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "speedrun-rest-api-webhooks/"+version.Version)
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(int(delivery.ID)))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, d.now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+	return resp.StatusCode, nil
+}
+
+// retryDelay is how long to wait after the given number of failed attempts
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// Sign returns the HeaderSignature value for a payload sent at t
+//
+// The value has the form "t=<unix seconds>,v1=<hex HMAC-SHA256>", where the
+// HMAC is keyed with the webhook's secret and computed over "<t>.<payload>".
+// Receivers should recompute it and reject requests whose timestamp is too
+// old, so a captured delivery cannot be replayed later.
+func Sign(secret string, t time.Time, payload []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
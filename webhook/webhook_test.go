@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/example/speedrun-rest-api/db"
+)
+
+// fakeStore hands out the deliveries it holds once and records every update
+type fakeStore struct {
+	mu         sync.Mutex
+	deliveries []db.ClaimWebhookDeliveriesRow
+	updates    map[int32]db.UpdateWebhookDeliveryParams
+}
+
+func (s *fakeStore) ClaimWebhookDeliveries(ctx context.Context, arg db.ClaimWebhookDeliveriesParams) ([]db.ClaimWebhookDeliveriesRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := min(int(arg.BatchSize), len(s.deliveries))
+	claimed := s.deliveries[:n]
+	s.deliveries = s.deliveries[n:]
+	return claimed, nil
+}
+
+func (s *fakeStore) UpdateWebhookDelivery(ctx context.Context, arg db.UpdateWebhookDeliveryParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.updates == nil {
+		s.updates = map[int32]db.UpdateWebhookDeliveryParams{}
+	}
+	s.updates[arg.ID] = arg
+	return nil
+}
+
+func TestDispatch_SignsAndDelivers(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	payload := []byte(`{"event":"run.verified"}`)
+
+	var got *http.Request
+	var body []byte
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer endpoint.Close()
+
+	store := &fakeStore{deliveries: []db.ClaimWebhookDeliveriesRow{
+		{ID: 3, WebhookID: 1, Event: "run.verified", Payload: payload, Attempts: 1, Url: endpoint.URL, Secret: "whsec_test"},
+	}}
+	d := NewDispatcher(store, time.Minute, 3, time.Second)
+	d.now = func() time.Time { return now }
+	d.dispatch(context.Background())
+
+	if got == nil {
+		t.Fatal("expected the endpoint to be called")
+	}
+	if string(body) != string(payload) {
+		t.Errorf("expected the payload to be posted, got %s", body)
+	}
+	if got.Header.Get(HeaderEvent) != "run.verified" || got.Header.Get(HeaderDelivery) != "3" {
+		t.Errorf("expected event and delivery headers, got %v", got.Header)
+	}
+	if want := Sign("whsec_test", now, payload); got.Header.Get(HeaderSignature) != want {
+		t.Errorf("expected signature %q, got %q", want, got.Header.Get(HeaderSignature))
+	}
+
+	update := store.updates[3]
+	if update.Status != StatusSucceeded || update.ResponseStatus.Int32 != http.StatusNoContent || update.LastError.Valid {
+		t.Errorf("expected the delivery to be recorded as succeeded, got %+v", update)
+	}
+}
+
+func TestDispatch_RetriesWithBackoff(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+	}))
+	defer endpoint.Close()
+
+	store := &fakeStore{deliveries: []db.ClaimWebhookDeliveriesRow{
+		{ID: 1, Attempts: 1, Url: endpoint.URL},
+		{ID: 2, Attempts: 3, Url: endpoint.URL},
+		{ID: 3, Attempts: 5, Url: endpoint.URL},
+	}}
+	d := NewDispatcher(store, time.Minute, 5, 30*time.Second)
+	d.now = func() time.Time { return now }
+	d.dispatch(context.Background())
+
+	first := store.updates[1]
+	if first.Status != StatusPending || !first.NextAttemptAt.Time.Equal(now.Add(30*time.Second)) {
+		t.Errorf("expected a retry in 30s after the first attempt, got %+v", first)
+	}
+	if first.ResponseStatus.Int32 != http.StatusServiceUnavailable || first.LastError.String == "" {
+		t.Errorf("expected the response to be recorded, got %+v", first)
+	}
+	if third := store.updates[2]; !third.NextAttemptAt.Time.Equal(now.Add(2 * time.Minute)) {
+		t.Errorf("expected a retry in 2m after the third attempt, got %s", third.NextAttemptAt.Time.Sub(now))
+	}
+	if last := store.updates[3]; last.Status != StatusFailed {
+		t.Errorf("expected the delivery to fail after the last attempt, got %+v", last)
+	}
+}
+
+func TestDispatch_DoesNotFollowRedirects(t *testing.T) {
+	elsewhere := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		elsewhere = true
+	}))
+	defer target.Close()
+	endpoint := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer endpoint.Close()
+
+	store := &fakeStore{deliveries: []db.ClaimWebhookDeliveriesRow{{ID: 1, Attempts: 1, Url: endpoint.URL}}}
+	NewDispatcher(store, time.Minute, 5, time.Second).dispatch(context.Background())
+
+	if elsewhere {
+		t.Error("expected the redirect not to be followed")
+	}
+	if update := store.updates[1]; update.Status != StatusPending {
+		t.Errorf("expected a redirect to be retried, got %+v", update)
+	}
+}
+
+func TestRetryDelay_Capped(t *testing.T) {
+	d := NewDispatcher(&fakeStore{}, time.Minute, 100, time.Minute)
+	if got := d.retryDelay(50); got != maxRetryDelay {
+		t.Errorf("expected the delay to be capped at %s, got %s", maxRetryDelay, got)
+	}
+}